@@ -11,6 +11,7 @@ import (
 	"time"
 
 	_ "github.com/EduGoGroup/edugo-api-administracion/docs"
+	authmiddleware "github.com/EduGoGroup/edugo-api-administracion/internal/auth/middleware"
 	"github.com/EduGoGroup/edugo-api-administracion/internal/bootstrap"
 	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
 	"github.com/EduGoGroup/edugo-api-administracion/internal/container"
@@ -91,13 +92,56 @@ func main() {
 
 		// Verify endpoint (para otros servicios)
 		c.VerifyHandler.RegisterRoutes(v1Public)
+
+		// Aceptación de invitaciones (el link firmado reemplaza la autenticación)
+		v1Public.POST("/invitations/accept", c.InvitationHandler.AcceptInvitation)
+	}
+
+	// ==================== RUTAS INTERNAS (API Key o red interna) ====================
+	internalAPI := r.Group("/internal/v1")
+	internalAPI.Use(authmiddleware.NewInternalServiceMiddleware(c.VerifyHandler).Middleware())
+	{
+		internalAPI.GET("/schools/:id/settings", c.SchoolSettingsHandler.GetInternalSettings)
 	}
 
 	// ==================== RUTAS PROTEGIDAS (requieren JWT) ====================
 	v1 := r.Group("/v1")
-	// Middleware de autenticación JWT (todas las rutas requieren token válido)
-	v1.Use(ginmiddleware.JWTAuthMiddleware(c.JWTManager))
+	// Middleware de autenticación JWT (todas las rutas requieren token válido y no revocado)
+	v1.Use(ginmiddleware.JWTAuthMiddleware(c.JWTManager), c.AuthMiddleware.Middleware())
 	{
+		// ==================== ME (autoservicio del usuario autenticado) ====================
+		v1.GET("/me", c.MeHandler.GetMe)
+		v1.PATCH("/me", c.MeHandler.UpdateMe)
+		v1.GET("/me/mfa", c.MeHandler.GetMFAStatus)
+		v1.POST("/me/mfa/enroll", c.MeHandler.EnrollMFA)
+		v1.POST("/me/mfa/confirm", c.MeHandler.ConfirmMFA)
+		v1.POST("/me/mfa/disable", c.MeHandler.DisableMFA)
+
+		// ==================== ADMINS (solo super admins) ====================
+		v1.POST("/admins", c.AdminManagementHandler.CreateAdmin)
+		adminRequests := v1.Group("/admin-requests")
+		{
+			adminRequests.GET("", c.AdminManagementHandler.ListRequests)
+			adminRequests.POST("/:id/approve", c.AdminManagementHandler.ApproveRequest)
+			adminRequests.POST("/:id/reject", c.AdminManagementHandler.RejectRequest)
+		}
+
+		// ==================== ONBOARDING ====================
+		onboarding := v1.Group("/onboarding")
+		{
+			onboarding.GET("/templates", c.OnboardingHandler.ListTemplates)
+			onboarding.POST("/schools", c.OnboardingHandler.OnboardSchool)
+		}
+
+		// ==================== SUBSCRIPTION TIERS ====================
+		v1.GET("/subscription-tiers", c.SubscriptionHandler.ListTiers)
+
+		// ==================== SCHOOL PURGE JOBS ====================
+		v1.GET("/school-purge-jobs/:id", c.SchoolLifecycleHandler.GetPurgeJob)
+
+		// ==================== SCHOOL SETTINGS ====================
+		v1.GET("/school-settings/schema", c.SchoolSettingsHandler.GetSchema)
+
 		// ==================== SCHOOLS ====================
 		schools := v1.Group("/schools")
 		{
@@ -107,14 +151,39 @@ func main() {
 
 			// Academic Units nested under school (usando :id como parámetro)
 			schools.POST("/:id/units", c.AcademicUnitHandler.CreateUnit)
+			schools.POST("/:id/units/bulk", c.AcademicUnitHandler.BulkCreateUnits)
 			schools.GET("/:id/units", c.AcademicUnitHandler.ListUnitsBySchool)
 			schools.GET("/:id/units/tree", c.AcademicUnitHandler.GetUnitTree)
+			schools.GET("/:id/units/export", c.UnitTreeTransferHandler.ExportUnitTree)
+			schools.POST("/:id/units/import", c.UnitTreeTransferHandler.ImportUnitTree)
 			schools.GET("/:id/units/by-type", c.AcademicUnitHandler.ListUnitsByType)
+			schools.GET("/:id/unit-types", c.UnitTypeHandler.ListUnitTypes)
+			schools.POST("/:id/unit-types", c.UnitTypeHandler.CreateUnitType)
+			schools.PATCH("/:id/unit-types/:code", c.UnitTypeHandler.UpdateUnitType)
+			schools.DELETE("/:id/unit-types/:code", c.UnitTypeHandler.DeleteUnitType)
+			schools.GET("/:id/invitations", c.InvitationHandler.ListSchoolInvitations)
+			schools.GET("/:id/usage", c.SchoolQuotaHandler.GetUsage)
+			schools.GET("/:id/subscription", c.SubscriptionHandler.GetSchoolSubscription)
+			schools.PUT("/:id/subscription", c.SubscriptionHandler.ChangeTier)
+			schools.GET("/:id/subscription/history", c.SubscriptionHandler.ListTierHistory)
+			schools.GET("/:id/features/:feature", c.SubscriptionHandler.CheckFeature)
+			schools.GET("/:id/settings", c.SchoolSettingsHandler.GetSettings)
+			schools.PATCH("/:id/settings", c.SchoolSettingsHandler.PatchSettings)
+			schools.GET("/:id/settings/history", c.SchoolSettingsHandler.ListHistory)
+			schools.POST("/:id/archive", c.SchoolLifecycleHandler.ArchiveSchool)
+			schools.GET("/:id/archive", c.SchoolLifecycleHandler.GetArchive)
+			schools.POST("/:id/restore", c.SchoolLifecycleHandler.RestoreSchool)
+			schools.POST("/:id/purge", c.SchoolLifecycleHandler.PurgeSchool)
+			schools.POST("/:id/clone", c.SchoolCloneHandler.CloneSchool)
+			schools.GET("/:id/academic-periods", c.AcademicPeriodHandler.ListPeriods)
+			schools.GET("/:id/academic-periods/current", c.AcademicPeriodHandler.GetCurrentPeriod)
+			schools.POST("/:id/academic-years", c.AcademicPeriodHandler.CreateYear)
+			schools.POST("/:id/rollover", c.RolloverHandler.Rollover)
 
 			// School CRUD (mismo parámetro :id)
 			schools.GET("/:id", c.SchoolHandler.GetSchool)
 			schools.PUT("/:id", c.SchoolHandler.UpdateSchool)
-			schools.DELETE("/:id", c.SchoolHandler.DeleteSchool)
+			schools.DELETE("/:id", c.SchoolLifecycleHandler.ArchiveSchool) // archiva en cascada
 		}
 
 		// ==================== ACADEMIC UNITS ====================
@@ -124,8 +193,25 @@ func main() {
 			units.PUT("/:id", c.AcademicUnitHandler.UpdateUnit)
 			units.DELETE("/:id", c.AcademicUnitHandler.DeleteUnit)
 			units.POST("/:id/restore", c.AcademicUnitHandler.RestoreUnit)
+			units.POST("/:id/move", c.AcademicUnitHandler.MoveUnit)
 			units.GET("/:id/hierarchy-path", c.AcademicUnitHandler.GetHierarchyPath)
+			units.GET("/:id/descendants", c.AcademicUnitHandler.ListDescendants)
+			units.GET("/:id/waitlist", c.UnitCapacityHandler.ListWaitlist)
+			units.DELETE("/:id/waitlist/:entryId", c.UnitCapacityHandler.CancelWaitlistEntry)
+			units.GET("/:id/memberships/export", c.ExportHandler.ExportUnitMemberships)
+			units.GET("/:id/guardian-relations/export", c.ExportHandler.ExportGuardianRelations)
+		}
+
+		// ==================== ACADEMIC PERIODS ====================
+		periods := v1.Group("/academic-periods")
+		{
+			periods.GET("/:id", c.AcademicPeriodHandler.GetPeriod)
+			periods.PATCH("/:id", c.AcademicPeriodHandler.UpdatePeriod)
+			periods.POST("/:id/terms", c.AcademicPeriodHandler.CreateTerm)
+			periods.POST("/:id/activate", c.AcademicPeriodHandler.ActivatePeriod)
+			periods.POST("/:id/close", c.AcademicPeriodHandler.ClosePeriod)
 		}
+		v1.GET("/school-rollovers/:id", c.RolloverHandler.GetRollover)
 
 		// ==================== MEMBERSHIPS ====================
 		memberships := v1.Group("/memberships")
@@ -142,7 +228,32 @@ func main() {
 		// ==================== USERS ====================
 		users := v1.Group("/users")
 		{
+			users.POST("/import", c.UserImportHandler.ImportUsers)
+			users.POST("/merge", c.UserDuplicateHandler.MergeUsers)
+			users.GET("/duplicates", c.UserDuplicateHandler.ListDuplicates)
+			users.POST("/duplicates/scan", c.UserDuplicateHandler.ScanDuplicates)
+			users.POST("/duplicates/:candidateId/dismiss", c.UserDuplicateHandler.DismissDuplicate)
 			users.GET("/:userId/memberships", c.UnitMembershipHandler.ListMembershipsByUser)
+			users.POST("/:userId/deactivate", c.UserStatusHandler.DeactivateUser)
+			users.POST("/:userId/reactivate", c.UserStatusHandler.ReactivateUser)
+			users.GET("/:userId/status-history", c.UserStatusHandler.GetStatusHistory)
+			users.GET("/:userId/personal-data", c.PersonalDataHandler.ExportPersonalData)
+			users.POST("/:userId/erase", c.PersonalDataHandler.EraseUser)
+			users.POST("/:userId/promote-admin", c.AdminManagementHandler.PromoteToAdmin)
+		}
+
+		// ==================== EXPORTS ====================
+		exports := v1.Group("/exports")
+		{
+			exports.GET("/users", c.ExportHandler.ExportUsers)
+		}
+
+		// ==================== INVITATIONS ====================
+		invitations := v1.Group("/invitations")
+		{
+			invitations.POST("", c.InvitationHandler.CreateInvitation)
+			invitations.POST("/:id/resend", c.InvitationHandler.ResendInvitation)
+			invitations.POST("/:id/revoke", c.InvitationHandler.RevokeInvitation)
 		}
 
 		// ==================== SUBJECTS ====================
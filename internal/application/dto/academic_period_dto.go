@@ -0,0 +1,127 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// PeriodDateLayout es el formato de las fechas de inicio y fin de un período
+const PeriodDateLayout = "2006-01-02"
+
+// CreateAcademicYearRequest representa la creación de un año lectivo
+type CreateAcademicYearRequest struct {
+	Name      string `json:"name"`
+	Year      int    `json:"year"` // identifica el año lectivo, p.ej. 2026
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// Validate valida el request
+func (r *CreateAcademicYearRequest) Validate() error {
+	v := validator.New()
+	v.Required(r.Name, "name")
+	v.MaxLength(r.Name, 100, "name")
+	if err := v.GetError(); err != nil {
+		return err
+	}
+	if r.Year < 1900 || r.Year > 9999 {
+		return errors.NewValidationError("year must be between 1900 and 9999")
+	}
+	_, _, err := ParsePeriodRange(r.StartDate, r.EndDate)
+	return err
+}
+
+// CreateAcademicTermRequest representa la creación de un período dentro de un año lectivo
+type CreateAcademicTermRequest struct {
+	Name      string `json:"name"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// Validate valida el request
+func (r *CreateAcademicTermRequest) Validate() error {
+	v := validator.New()
+	v.Required(r.Name, "name")
+	v.MaxLength(r.Name, 100, "name")
+	if err := v.GetError(); err != nil {
+		return err
+	}
+	_, _, err := ParsePeriodRange(r.StartDate, r.EndDate)
+	return err
+}
+
+// UpdateAcademicPeriodRequest representa la modificación de nombre o fechas de un período
+type UpdateAcademicPeriodRequest struct {
+	Name      *string `json:"name"`
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+}
+
+// Validate valida el request
+func (r *UpdateAcademicPeriodRequest) Validate() error {
+	v := validator.New()
+	if r.Name != nil {
+		v.Required(*r.Name, "name")
+		v.MaxLength(*r.Name, 100, "name")
+	}
+	return v.GetError()
+}
+
+// ParsePeriodRange interpreta las fechas de un período y valida que el fin sea posterior al inicio
+func ParsePeriodRange(start, end string) (time.Time, time.Time, error) {
+	startDate, err := time.Parse(PeriodDateLayout, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.NewValidationError("start_date must have format YYYY-MM-DD")
+	}
+	endDate, err := time.Parse(PeriodDateLayout, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.NewValidationError("end_date must have format YYYY-MM-DD")
+	}
+	if !endDate.After(startDate) {
+		return time.Time{}, time.Time{}, errors.NewValidationError("end_date must be after start_date")
+	}
+	return startDate, endDate, nil
+}
+
+// AcademicPeriodResponse representa un año lectivo o un período; los años incluyen sus períodos
+type AcademicPeriodResponse struct {
+	ID        string                   `json:"id"`
+	SchoolID  string                   `json:"school_id"`
+	ParentID  string                   `json:"parent_id,omitempty"`
+	Type      string                   `json:"type"` // year, term
+	Name      string                   `json:"name"`
+	Year      int                      `json:"year"`
+	StartDate string                   `json:"start_date"`
+	EndDate   string                   `json:"end_date"`
+	Status    string                   `json:"status"` // planned, active, closed
+	ClosedBy  string                   `json:"closed_by,omitempty"`
+	ClosedAt  *time.Time               `json:"closed_at,omitempty"`
+	Terms     []AcademicPeriodResponse `json:"terms,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+}
+
+// ToAcademicPeriodResponse convierte un período a response
+func ToAcademicPeriodResponse(period *repository.AcademicPeriod) AcademicPeriodResponse {
+	resp := AcademicPeriodResponse{
+		ID:        period.ID.String(),
+		SchoolID:  period.SchoolID.String(),
+		Type:      period.Type,
+		Name:      period.Name,
+		Year:      period.Year,
+		StartDate: period.StartDate.Format(PeriodDateLayout),
+		EndDate:   period.EndDate.Format(PeriodDateLayout),
+		Status:    period.Status,
+		ClosedBy:  period.ClosedBy,
+		ClosedAt:  period.ClosedAt,
+		CreatedAt: period.CreatedAt,
+		UpdatedAt: period.UpdatedAt,
+	}
+	if period.ParentID != nil {
+		resp.ParentID = period.ParentID.String()
+	}
+	return resp
+}
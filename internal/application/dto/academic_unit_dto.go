@@ -2,9 +2,12 @@ package dto
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
 )
 
 // CreateAcademicUnitRequest representa la solicitud para crear una unidad académica
@@ -14,7 +17,11 @@ type CreateAcademicUnitRequest struct {
 	DisplayName  string                 `json:"display_name" binding:"required,min=3,max=255" validate:"required,min=3,max=255"`
 	Code         string                 `json:"code" binding:"omitempty,min=2,max=50" validate:"omitempty,min=2,max=50"`
 	Description  string                 `json:"description"`
+	AcademicYear *int                   `json:"academic_year"` // por defecto el año lectivo activo de la escuela
 	Metadata     map[string]interface{} `json:"metadata"`
+
+	MaxStudents     *int  `json:"max_students" binding:"omitempty,min=0"` // sin valor o 0 = sin cupo
+	WaitlistEnabled *bool `json:"waitlist_enabled"`                       // sin cupo disponible, los estudiantes quedan en espera
 }
 
 // UpdateAcademicUnitRequest representa la solicitud para actualizar una unidad
@@ -23,6 +30,72 @@ type UpdateAcademicUnitRequest struct {
 	DisplayName  *string                `json:"display_name" binding:"omitempty,min=3,max=255" validate:"omitempty,min=3,max=255"`
 	Description  *string                `json:"description"`
 	Metadata     map[string]interface{} `json:"metadata"`
+
+	MaxStudents     *int  `json:"max_students" binding:"omitempty,min=0"` // 0 quita el cupo
+	WaitlistEnabled *bool `json:"waitlist_enabled"`
+}
+
+// MoveAcademicUnitRequest representa el cambio de padre de una unidad junto con todo su subárbol
+type MoveAcademicUnitRequest struct {
+	ParentUnitID *string `json:"parent_unit_id" binding:"omitempty,uuid"` // nil mueve la unidad a la raíz
+}
+
+// BulkCreateUnitsRequest genera un árbol de unidades a partir de niveles con patrones, por ejemplo
+// "Grado 1..11" con "Sección A..D" debajo de cada grado
+type BulkCreateUnitsRequest struct {
+	ParentUnitID *string              `json:"parent_unit_id" binding:"omitempty,uuid"` // el primer nivel cuelga de esta unidad
+	ParentTypes  []string             `json:"parent_types"`                            // o de cada unidad existente de estos tipos
+	AcademicYear *int                 `json:"academic_year"`                           // por defecto el del padre o el año activo
+	Levels       []UnitGeneratorLevel `json:"levels"`
+}
+
+// UnitGeneratorLevel es un nivel del árbol generado: cada unidad del nivel anterior recibe una unidad por
+// valor. Las plantillas aceptan {value}, {index}, {parent_name} y {parent_code}.
+type UnitGeneratorLevel struct {
+	Type         string              `json:"type"`
+	Range        *UnitGeneratorRange `json:"range"`  // rango numérico (1..11) o de letras (A..D)
+	Values       []string            `json:"values"` // o lista explícita de valores
+	NameTemplate string              `json:"name_template"`
+	CodeTemplate string              `json:"code_template"` // vacío = unidades sin código
+}
+
+// UnitGeneratorRange es un rango inclusivo de números o de letras
+type UnitGeneratorRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// MaxUnitGeneratorLevels limita los niveles de una generación
+const MaxUnitGeneratorLevels = 5
+
+// Validate valida la forma del request (tipos, rangos y plantillas los valida el servicio)
+func (r *BulkCreateUnitsRequest) Validate() error {
+	if r.ParentUnitID != nil && len(r.ParentTypes) > 0 {
+		return errors.NewValidationError("parent_unit_id and parent_types are mutually exclusive")
+	}
+	if len(r.Levels) == 0 || len(r.Levels) > MaxUnitGeneratorLevels {
+		return errors.NewValidationError(fmt.Sprintf("levels must have between 1 and %d entries", MaxUnitGeneratorLevels))
+	}
+	v := validator.New()
+	for i, level := range r.Levels {
+		field := fmt.Sprintf("levels[%d]", i)
+		if (level.Range == nil) == (len(level.Values) == 0) {
+			return errors.NewValidationError(field + ": exactly one of range or values is required")
+		}
+		v.Required(level.Type, field+".type")
+		v.Required(level.NameTemplate, field+".name_template")
+		v.MaxLength(level.NameTemplate, 255, field+".name_template")
+		v.MaxLength(level.CodeTemplate, 50, field+".code_template")
+	}
+	return v.GetError()
+}
+
+// BulkCreateUnitsResponse representa las unidades creadas por una generación. El árbol parte de las
+// unidades padre indicadas (o de las unidades raíz generadas).
+type BulkCreateUnitsResponse struct {
+	Created int             `json:"created"`
+	ByType  map[string]int  `json:"by_type"`
+	Tree    []*UnitTreeNode `json:"tree"`
 }
 
 // AcademicUnitResponse representa la respuesta con datos de una unidad académica
@@ -34,10 +107,57 @@ type AcademicUnitResponse struct {
 	DisplayName  string                 `json:"display_name"`
 	Code         string                 `json:"code,omitempty"`
 	Description  string                 `json:"description,omitempty"`
+	AcademicYear int                    `json:"academic_year,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt    time.Time              `json:"created_at"`
 	UpdatedAt    time.Time              `json:"updated_at"`
 	DeletedAt    *time.Time             `json:"deleted_at,omitempty"`
+
+	Occupancy *UnitOccupancyResponse `json:"occupancy,omitempty"` // solo unidades con cupo de estudiantes
+}
+
+// UnitOccupancyResponse representa la ocupación de una unidad con cupo
+type UnitOccupancyResponse struct {
+	MaxStudents     int  `json:"max_students"`
+	Students        int  `json:"students"`  // estudiantes con membresía vigente
+	Available       int  `json:"available"` // lugares libres (0 si está llena o excedida)
+	WaitlistEnabled bool `json:"waitlist_enabled"`
+	Waiting         int  `json:"waiting"`
+}
+
+// UnitDeletionResponse representa el resultado (o el preview con dry_run) de eliminar o restaurar
+// una unidad en cascada
+type UnitDeletionResponse struct {
+	BatchID     string         `json:"batch_id,omitempty"` // vacío en el preview de eliminación y en eliminaciones previas a los batches
+	DryRun      bool           `json:"dry_run"`
+	UnitID      string         `json:"unit_id"`
+	Units       int            `json:"units"` // unidades eliminadas o restauradas, incluida la raíz
+	UnitsByType map[string]int `json:"units_by_type"`
+	Memberships int            `json:"memberships"`       // membresías suspendidas o reactivadas
+	Skipped     int            `json:"skipped,omitempty"` // restauración: entidades que ya no existen o se reactivaron aparte
+	PerformedBy string         `json:"performed_by,omitempty"`
+	PerformedAt *time.Time     `json:"performed_at,omitempty"`
+}
+
+// UnitDescendantsResponse representa el subárbol de una unidad
+type UnitDescendantsResponse struct {
+	UnitID      string                   `json:"unit_id"`
+	MaxDepth    int                      `json:"max_depth,omitempty"` // 0 = sin límite
+	Counts      UnitSubtreeCountResponse `json:"counts"`              // del subárbol completo, sin límite de profundidad; con include_deleted cuenta también los eliminados
+	Descendants []UnitDescendantResponse `json:"descendants"`
+}
+
+// UnitDescendantResponse es una unidad del subárbol con su profundidad relativa (1 = hijo directo)
+type UnitDescendantResponse struct {
+	AcademicUnitResponse
+	Depth int `json:"depth"`
+}
+
+// UnitSubtreeCountResponse resume el subárbol de una unidad
+type UnitSubtreeCountResponse struct {
+	Descendants int            `json:"descendants"`
+	ByType      map[string]int `json:"by_type"`
+	MaxDepth    int            `json:"max_depth"`
 }
 
 // UnitTreeNode representa un nodo en el árbol jerárquico
@@ -77,6 +197,7 @@ func ToAcademicUnitResponse(unit *entities.AcademicUnit) AcademicUnitResponse {
 		DisplayName:  unit.Name,
 		Code:         unit.Code,
 		Description:  desc,
+		AcademicYear: unit.AcademicYear,
 		Metadata:     metadata,
 		CreatedAt:    unit.CreatedAt,
 		UpdatedAt:    unit.UpdatedAt,
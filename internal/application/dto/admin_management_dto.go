@@ -0,0 +1,164 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// CreateAdminRequest representa el alta de un administrador.
+// Sin school_id el administrador es super admin (alcance global).
+type CreateAdminRequest struct {
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	SchoolID  string `json:"school_id,omitempty"`
+	Reason    string `json:"reason"`
+	MFACode   string `json:"mfa_code,omitempty"` // si es válido, el alta se aplica sin esperar aprobación
+}
+
+// Validate valida el request
+func (r *CreateAdminRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.Email, "email")
+	v.Email(r.Email, "email")
+	v.MaxLength(r.Email, 100, "email")
+
+	v.Required(r.FirstName, "first_name")
+	v.MinLength(r.FirstName, 2, "first_name")
+	v.MaxLength(r.FirstName, 50, "first_name")
+	v.Name(r.FirstName, "first_name")
+
+	v.Required(r.LastName, "last_name")
+	v.MinLength(r.LastName, 2, "last_name")
+	v.MaxLength(r.LastName, 50, "last_name")
+	v.Name(r.LastName, "last_name")
+
+	v.Required(r.Password, "password")
+	v.MinLength(r.Password, 8, "password")
+
+	if r.SchoolID != "" {
+		v.UUID(r.SchoolID, "school_id")
+	}
+
+	v.Required(r.Reason, "reason")
+	v.MinLength(r.Reason, 3, "reason")
+	v.MaxLength(r.Reason, 500, "reason")
+
+	return v.GetError()
+}
+
+// PromoteAdminRequest representa la promoción de un usuario existente a administrador
+type PromoteAdminRequest struct {
+	SchoolID string `json:"school_id,omitempty"`
+	Reason   string `json:"reason"`
+	MFACode  string `json:"mfa_code,omitempty"`
+}
+
+// Validate valida el request
+func (r *PromoteAdminRequest) Validate() error {
+	v := validator.New()
+
+	if r.SchoolID != "" {
+		v.UUID(r.SchoolID, "school_id")
+	}
+
+	v.Required(r.Reason, "reason")
+	v.MinLength(r.Reason, 3, "reason")
+	v.MaxLength(r.Reason, 500, "reason")
+
+	return v.GetError()
+}
+
+// DecideAdminChangeRequest representa la aprobación o el rechazo de una solicitud pendiente
+type DecideAdminChangeRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// Validate valida el request
+func (r *DecideAdminChangeRequest) Validate() error {
+	v := validator.New()
+
+	v.MaxLength(r.Note, 500, "note")
+
+	return v.GetError()
+}
+
+// ListAdminChangeRequestsRequest representa los filtros del listado de solicitudes
+type ListAdminChangeRequestsRequest struct {
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+// Validate valida el request
+func (r *ListAdminChangeRequestsRequest) Validate() error {
+	v := validator.New()
+
+	if r.Status != "" {
+		v.InSlice(r.Status, []string{
+			repository.AdminChangeStatusPending,
+			repository.AdminChangeStatusApplied,
+			repository.AdminChangeStatusRejected,
+		}, "status")
+	}
+
+	return v.GetError()
+}
+
+// AdminChangeRequestResponse representa una solicitud de cambio de administradores
+type AdminChangeRequestResponse struct {
+	ID             string     `json:"id"`
+	Action         string     `json:"action"`
+	Status         string     `json:"status"`
+	Expired        bool       `json:"expired,omitempty"`
+	TargetUserID   *string    `json:"target_user_id,omitempty"`
+	Email          string     `json:"email"`
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	SchoolID       *string    `json:"school_id,omitempty"`
+	Reason         string     `json:"reason"`
+	ApprovalMethod string     `json:"approval_method,omitempty"`
+	RequestedBy    string     `json:"requested_by"`
+	DecidedBy      *string    `json:"decided_by,omitempty"`
+	DecisionNote   string     `json:"decision_note,omitempty"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+}
+
+// ToAdminChangeRequestResponse convierte una solicitud a DTO de respuesta
+func ToAdminChangeRequestResponse(request *repository.AdminChangeRequest, now time.Time) *AdminChangeRequestResponse {
+	response := &AdminChangeRequestResponse{
+		ID:             request.ID.String(),
+		Action:         request.Action,
+		Status:         request.Status,
+		Expired:        request.IsExpired(now),
+		Email:          request.Email,
+		FirstName:      request.FirstName,
+		LastName:       request.LastName,
+		Reason:         request.Reason,
+		ApprovalMethod: request.ApprovalMethod,
+		RequestedBy:    request.RequestedBy.String(),
+		DecisionNote:   request.DecisionNote,
+		ExpiresAt:      request.ExpiresAt,
+		CreatedAt:      request.CreatedAt,
+		DecidedAt:      request.DecidedAt,
+	}
+	if request.TargetUserID != nil {
+		id := request.TargetUserID.String()
+		response.TargetUserID = &id
+	}
+	if request.SchoolID != nil {
+		id := request.SchoolID.String()
+		response.SchoolID = &id
+	}
+	if request.DecidedBy != nil {
+		id := request.DecidedBy.String()
+		response.DecidedBy = &id
+	}
+	return response
+}
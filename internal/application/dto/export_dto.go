@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/spreadsheet"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// ExportOptions agrupa las opciones comunes de todas las exportaciones
+type ExportOptions struct {
+	Format  string `form:"format"`  // csv (default), xlsx o ndjson
+	Columns string `form:"columns"` // columnas separadas por coma; vacío = todas
+}
+
+// OutputFormat retorna el formato de salida (csv por defecto)
+func (o ExportOptions) OutputFormat() (spreadsheet.Format, error) {
+	if o.Format == "" {
+		return spreadsheet.FormatCSV, nil
+	}
+	return spreadsheet.ParseFormat(o.Format)
+}
+
+// ColumnList retorna las columnas solicitadas
+func (o ExportOptions) ColumnList() []string {
+	if strings.TrimSpace(o.Columns) == "" {
+		return nil
+	}
+
+	parts := strings.Split(o.Columns, ",")
+	columns := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if col := strings.TrimSpace(part); col != "" {
+			columns = append(columns, strings.ToLower(col))
+		}
+	}
+	return columns
+}
+
+// exportFormats lista los formatos de salida soportados
+func exportFormats() []string {
+	return []string{
+		string(spreadsheet.FormatCSV),
+		string(spreadsheet.FormatXLSX),
+		string(spreadsheet.FormatNDJSON),
+	}
+}
+
+// ExportUsersRequest representa los filtros para exportar usuarios
+type ExportUsersRequest struct {
+	ExportOptions
+	Role     string `form:"role"`
+	IsActive *bool  `form:"is_active"`
+	SchoolID string `form:"school_id"`
+}
+
+// Validate valida el request
+func (r *ExportUsersRequest) Validate() error {
+	v := validator.New()
+
+	if r.Format != "" {
+		v.InSlice(strings.ToLower(r.Format), exportFormats(), "format")
+	}
+
+	if r.Role != "" {
+		v.InSlice(r.Role, enum.AllSystemRolesStrings(), "role")
+	}
+	if r.SchoolID != "" {
+		v.UUID(r.SchoolID, "school_id")
+	}
+
+	return v.GetError()
+}
+
+// ExportMembershipsRequest representa los filtros para exportar el roster de una unidad
+type ExportMembershipsRequest struct {
+	ExportOptions
+	UnitID     string `form:"-"`
+	Role       string `form:"role"`
+	ActiveOnly bool   `form:"active_only"` // solo membresías activas y no retiradas
+}
+
+// Validate valida el request
+func (r *ExportMembershipsRequest) Validate() error {
+	v := validator.New()
+
+	if r.Format != "" {
+		v.InSlice(strings.ToLower(r.Format), exportFormats(), "format")
+	}
+
+	v.Required(r.UnitID, "unit_id")
+	v.UUID(r.UnitID, "unit_id")
+
+	return v.GetError()
+}
+
+// ExportGuardianRelationsRequest representa los filtros para exportar las relaciones
+// apoderado-estudiante de los estudiantes de una unidad
+type ExportGuardianRelationsRequest struct {
+	ExportOptions
+	UnitID     string `form:"-"`
+	ActiveOnly bool   `form:"active_only"`
+}
+
+// Validate valida el request
+func (r *ExportGuardianRelationsRequest) Validate() error {
+	v := validator.New()
+
+	if r.Format != "" {
+		v.InSlice(strings.ToLower(r.Format), exportFormats(), "format")
+	}
+
+	v.Required(r.UnitID, "unit_id")
+	v.UUID(r.UnitID, "unit_id")
+
+	return v.GetError()
+}
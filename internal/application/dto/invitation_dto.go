@@ -0,0 +1,181 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// CreateInvitationRequest representa la solicitud para invitar un email a una escuela
+type CreateInvitationRequest struct {
+	Email    string `json:"email"`
+	SchoolID string `json:"school_id"`
+	UnitID   string `json:"unit_id"`   // opcional: unidad de la membresía
+	Role     string `json:"role"`      // rol de membresía (teacher, student, director...)
+	UserRole string `json:"user_role"` // opcional: rol de sistema si se crea el usuario; se deriva de role
+}
+
+// Validate valida el request
+func (r *CreateInvitationRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.Email, "email")
+	v.Email(r.Email, "email")
+	v.MaxLength(r.Email, 100, "email")
+
+	v.Required(r.SchoolID, "school_id")
+	v.UUID(r.SchoolID, "school_id")
+	if r.UnitID != "" {
+		v.UUID(r.UnitID, "unit_id")
+	}
+
+	v.Required(r.Role, "role")
+	v.InSlice(r.Role, membershipRoleStrings(), "role")
+
+	if r.UserRole != "" {
+		v.InSlice(r.UserRole, enum.AllSystemRolesStrings(), "user_role")
+	}
+
+	return v.GetError()
+}
+
+// ListInvitationsRequest representa los filtros para listar invitaciones de una escuela
+type ListInvitationsRequest struct {
+	SchoolID string `form:"-"`
+	Status   string `form:"status"`
+	Limit    int    `form:"limit"`
+	Offset   int    `form:"offset"`
+}
+
+// Validate valida el request
+func (r *ListInvitationsRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.SchoolID, "school_id")
+	v.UUID(r.SchoolID, "school_id")
+	if r.Status != "" {
+		v.InSlice(r.Status, []string{
+			repository.InvitationStatusPending,
+			repository.InvitationStatusAccepted,
+			repository.InvitationStatusRevoked,
+			repository.InvitationStatusExpired,
+		}, "status")
+	}
+
+	return v.GetError()
+}
+
+// AcceptInvitationRequest representa la aceptación de una invitación desde el link recibido.
+// Nombre y password son obligatorios solo si el email aún no tiene cuenta.
+type AcceptInvitationRequest struct {
+	Token     string `json:"token"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// Validate valida el request
+func (r *AcceptInvitationRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.Token, "token")
+
+	if r.FirstName != "" {
+		v.MinLength(r.FirstName, 2, "first_name")
+		v.MaxLength(r.FirstName, 50, "first_name")
+		v.Name(r.FirstName, "first_name")
+	}
+	if r.LastName != "" {
+		v.MinLength(r.LastName, 2, "last_name")
+		v.MaxLength(r.LastName, 50, "last_name")
+		v.Name(r.LastName, "last_name")
+	}
+	if r.Password != "" {
+		v.MinLength(r.Password, 8, "password")
+	}
+
+	return v.GetError()
+}
+
+// ValidateNewUser valida los datos obligatorios para crear la cuenta del invitado
+func (r *AcceptInvitationRequest) ValidateNewUser() error {
+	v := validator.New()
+
+	v.Required(r.FirstName, "first_name")
+	v.Required(r.LastName, "last_name")
+	v.Required(r.Password, "password")
+
+	return v.GetError()
+}
+
+// InvitationResponse representa una invitación
+type InvitationResponse struct {
+	ID             string     `json:"id"`
+	Email          string     `json:"email"`
+	SchoolID       string     `json:"school_id"`
+	UnitID         *string    `json:"unit_id,omitempty"`
+	Role           string     `json:"role"`
+	Status         string     `json:"status"`
+	InvitedBy      string     `json:"invited_by"`
+	SentCount      int        `json:"sent_count"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	LastSentAt     time.Time  `json:"last_sent_at"`
+	AcceptedUserID *string    `json:"accepted_user_id,omitempty"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AcceptURL      string     `json:"accept_url,omitempty"` // solo al crear o reenviar
+}
+
+// ToInvitationResponse convierte una invitación a DTO de respuesta
+func ToInvitationResponse(invitation *repository.Invitation) *InvitationResponse {
+	response := &InvitationResponse{
+		ID:         invitation.ID.String(),
+		Email:      invitation.Email,
+		SchoolID:   invitation.SchoolID.String(),
+		Role:       invitation.Role,
+		Status:     invitation.Status,
+		InvitedBy:  invitation.InvitedBy,
+		SentCount:  invitation.SentCount,
+		ExpiresAt:  invitation.ExpiresAt,
+		LastSentAt: invitation.LastSentAt,
+		AcceptedAt: invitation.AcceptedAt,
+		RevokedAt:  invitation.RevokedAt,
+		CreatedAt:  invitation.CreatedAt,
+	}
+
+	if invitation.IsExpired(time.Now()) {
+		response.Status = repository.InvitationStatusExpired
+	}
+	if invitation.AcademicUnitID != nil {
+		unitID := invitation.AcademicUnitID.String()
+		response.UnitID = &unitID
+	}
+	if invitation.AcceptedUserID != nil {
+		userID := invitation.AcceptedUserID.String()
+		response.AcceptedUserID = &userID
+	}
+	return response
+}
+
+// AcceptInvitationResponse representa el resultado de aceptar una invitación
+type AcceptInvitationResponse struct {
+	InvitationID string `json:"invitation_id"`
+	UserID       string `json:"user_id"`
+	MembershipID string `json:"membership_id,omitempty"` // vacío si el estudiante quedó en lista de espera
+	UserCreated  bool   `json:"user_created"`
+
+	Waitlist *WaitlistEntryResponse `json:"waitlist,omitempty"` // la unidad no tenía cupo y el estudiante quedó en espera
+}
+
+func membershipRoleStrings() []string {
+	roles := valueobject.AllMembershipRoles()
+	result := make([]string, len(roles))
+	for i, role := range roles {
+		result[i] = role.String()
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// MFACodeRequest representa un código TOTP de 6 dígitos
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+// Validate valida el request
+func (r *MFACodeRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.Code, "code")
+	v.MinLength(r.Code, 6, "code")
+	v.MaxLength(r.Code, 6, "code")
+
+	return v.GetError()
+}
+
+// EnrollMFARequest representa la solicitud de enrolamiento; si el usuario ya tiene
+// MFA activo debe enviar un código vigente para reemplazar el secret
+type EnrollMFARequest struct {
+	Code string `json:"code,omitempty"`
+}
+
+// MFAStatusResponse representa el estado del segundo factor del usuario
+type MFAStatusResponse struct {
+	Enabled    bool       `json:"enabled"`
+	Pending    bool       `json:"pending"` // enrolado pero sin confirmar
+	EnabledAt  *time.Time `json:"enabled_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// MFAEnrollmentResponse contiene el secret a registrar en la app autenticadora.
+// Solo se muestra una vez; el MFA queda activo al confirmar con un código.
+type MFAEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
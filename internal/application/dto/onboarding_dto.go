@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// OnboardSchoolRequest representa el alta completa de una escuela:
+// datos de la escuela, plantilla de unidades y cuenta del primer director
+type OnboardSchoolRequest struct {
+	School   CreateSchoolRequest       `json:"school"`
+	Template string                    `json:"template"`
+	Director OnboardingDirectorRequest `json:"director"`
+}
+
+// OnboardingDirectorRequest representa la cuenta del primer director de la escuela
+type OnboardingDirectorRequest struct {
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// Validate valida el request (los datos de la escuela los valida SchoolService)
+func (r *OnboardSchoolRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.Template, "template")
+
+	v.Required(r.Director.Email, "director.email")
+	v.Email(r.Director.Email, "director.email")
+	v.MaxLength(r.Director.Email, 100, "director.email")
+
+	v.Required(r.Director.FirstName, "director.first_name")
+	v.MinLength(r.Director.FirstName, 2, "director.first_name")
+	v.MaxLength(r.Director.FirstName, 50, "director.first_name")
+	v.Name(r.Director.FirstName, "director.first_name")
+
+	v.Required(r.Director.LastName, "director.last_name")
+	v.MinLength(r.Director.LastName, 2, "director.last_name")
+	v.MaxLength(r.Director.LastName, 50, "director.last_name")
+	v.Name(r.Director.LastName, "director.last_name")
+
+	v.Required(r.Director.Password, "director.password")
+	v.MinLength(r.Director.Password, 8, "director.password")
+
+	return v.GetError()
+}
+
+// OnboardSchoolResponse representa el resultado del onboarding
+type OnboardSchoolResponse struct {
+	School             SchoolResponse         `json:"school"`
+	Template           string                 `json:"template"`
+	Units              []AcademicUnitResponse `json:"units"`
+	Director           *UserResponse          `json:"director"`
+	DirectorMembership MembershipResponse     `json:"director_membership"`
+}
+
+// UnitTreeTemplateResponse representa una plantilla de árbol de unidades disponible
+type UnitTreeTemplateResponse struct {
+	Name        string              `json:"name"`
+	DisplayName string              `json:"display_name"`
+	Description string              `json:"description,omitempty"`
+	UnitCount   int                 `json:"unit_count"`
+	Units       []UnitTemplateEntry `json:"units"`
+}
+
+// UnitTemplateEntry representa una unidad de la plantilla
+type UnitTemplateEntry struct {
+	Type     string              `json:"type"`
+	Name     string              `json:"name"`
+	Code     string              `json:"code,omitempty"`
+	Level    string              `json:"level,omitempty"`
+	Children []UnitTemplateEntry `json:"children,omitempty"`
+}
@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// PersonalDataUser representa la fila del usuario en el archivo de datos personales (sin hash de password)
+type PersonalDataUser struct {
+	ID            string     `json:"id"`
+	Email         string     `json:"email"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	Role          string     `json:"role"`
+	IsActive      bool       `json:"is_active"`
+	EmailVerified bool       `json:"email_verified"`
+	SchoolID      *string    `json:"school_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ToPersonalDataUser convierte un usuario a su representación para el archivo
+func ToPersonalDataUser(user *entities.User) *PersonalDataUser {
+	result := &PersonalDataUser{
+		ID:            user.ID.String(),
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Role:          user.Role,
+		IsActive:      user.IsActive,
+		EmailVerified: user.EmailVerified,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		DeletedAt:     user.DeletedAt,
+	}
+	if user.SchoolID != nil {
+		schoolID := user.SchoolID.String()
+		result.SchoolID = &schoolID
+	}
+	return result
+}
+
+// PersonalDataMembership representa una membresía en el archivo, incluida su metadata
+type PersonalDataMembership struct {
+	MembershipResponse
+	SchoolID string          `json:"school_id"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+}
+
+// ToPersonalDataMembership convierte una membresía a su representación para el archivo
+func ToPersonalDataMembership(membership *entities.Membership) PersonalDataMembership {
+	return PersonalDataMembership{
+		MembershipResponse: ToMembershipResponse(membership),
+		SchoolID:           membership.SchoolID.String(),
+		Metadata:           membership.Metadata,
+	}
+}
+
+// LoginEventResponse representa un intento de login del historial
+type LoginEventResponse struct {
+	Result     string    `json:"result"`
+	SchoolID   *string   `json:"school_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ToLoginEventResponse convierte un evento de login a DTO de respuesta
+func ToLoginEventResponse(event *repository.LoginEvent) LoginEventResponse {
+	result := LoginEventResponse{
+		Result:     event.Result,
+		OccurredAt: event.OccurredAt,
+	}
+	if event.SchoolID != nil {
+		schoolID := event.SchoolID.String()
+		result.SchoolID = &schoolID
+	}
+	return result
+}
+
+// PersonalDataManifest describe el contenido del archivo de datos personales
+type PersonalDataManifest struct {
+	UserID      string         `json:"user_id"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	RequestedBy string         `json:"requested_by"`
+	Files       map[string]int `json:"files"` // archivo -> cantidad de registros
+}
+
+// EraseUserRequest representa la solicitud de borrado (anonimización) de datos personales.
+// Confirmation debe ser el email actual del usuario; con DryRun solo se muestra lo que se haría.
+type EraseUserRequest struct {
+	Reason       string `json:"reason"`
+	Confirmation string `json:"confirmation"`
+	DryRun       bool   `json:"dry_run"`
+}
+
+// Validate valida el request
+func (r *EraseUserRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.Reason, "reason")
+	v.MinLength(r.Reason, 3, "reason")
+	v.MaxLength(r.Reason, 500, "reason")
+
+	if !r.DryRun {
+		v.Required(r.Confirmation, "confirmation")
+	}
+
+	return v.GetError()
+}
+
+// EraseUserResponse representa el resultado (o el plan, si DryRun) del borrado
+type EraseUserResponse struct {
+	UserID                       string   `json:"user_id"`
+	DryRun                       bool     `json:"dry_run"`
+	Applied                      bool     `json:"applied"`
+	AnonymizedFields             []string `json:"anonymized_fields"`
+	MembershipsAnonymized        int      `json:"memberships_anonymized"`
+	GuardianRelationsDeactivated int      `json:"guardian_relations_deactivated"`
+	LoginEventsDeleted           int      `json:"login_events_deleted"`
+	InvitationsAnonymized        int64    `json:"invitations_anonymized"`
+	ProfileDeleted               bool     `json:"profile_deleted"`
+	TokensRevoked                bool     `json:"tokens_revoked,omitempty"`
+	AuditID                      string   `json:"audit_id,omitempty"`
+}
@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// RolloverGraduate es el destino de un grado cuyos estudiantes egresan
+const RolloverGraduate = "graduate"
+
+// RolloverRequest representa el cambio de año lectivo de una escuela
+type RolloverRequest struct {
+	FromYear int `json:"from_year"`
+	ToYear   int `json:"to_year"`
+	// GradeMapping sobrescribe el grado destino por código de grado de origen
+	// (otro código de grado del año de origen o "graduate"); por defecto cada grado
+	// pasa al siguiente según su nivel y el último egresa
+	GradeMapping     map[string]string         `json:"grade_mapping"`
+	StudentOverrides []RolloverStudentOverride `json:"student_overrides"`
+	DryRun           bool                      `json:"dry_run"`
+}
+
+// RolloverStudentOverride fija la acción para un estudiante puntual
+type RolloverStudentOverride struct {
+	UserID string `json:"user_id"`
+	Action string `json:"action"` // retain, graduate
+}
+
+// Validate valida el request
+func (r *RolloverRequest) Validate() error {
+	if r.FromYear <= 0 || r.ToYear <= r.FromYear {
+		return errors.NewValidationError("to_year must be after from_year")
+	}
+	v := validator.New()
+	for _, override := range r.StudentOverrides {
+		v.UUID(override.UserID, "student_overrides.user_id")
+		v.InSlice(override.Action, []string{repository.RolloverActionRetain, repository.RolloverActionGraduate}, "student_overrides.action")
+	}
+	return v.GetError()
+}
+
+// RolloverResponse representa el reporte (dry run) o el resultado de un cambio de año
+type RolloverResponse struct {
+	ID            string                    `json:"id,omitempty"` // vacío en dry run
+	DryRun        bool                      `json:"dry_run"`
+	SchoolID      string                    `json:"school_id"`
+	FromYear      int                       `json:"from_year"`
+	ToYear        int                       `json:"to_year"`
+	GradeMapping  map[string]string         `json:"grade_mapping,omitempty"`
+	Units         []ClonedUnitEntry         `json:"units,omitempty"`
+	UnitsCreated  int                       `json:"units_created"`
+	Promoted      int                       `json:"promoted"`
+	Retained      int                       `json:"retained"`
+	Graduated     int                       `json:"graduated"`
+	CarriedOver   int                       `json:"carried_over"` // estudiantes de unidades sin grado (clubes, departamentos)
+	Students      []RolloverStudentEntry    `json:"students"`
+	PendingReview []RolloverAssignmentEntry `json:"pending_review"`
+	PendingCount  int                       `json:"pending_review_count"`
+	Warnings      []string                  `json:"warnings,omitempty"`
+	Conflicts     []string                  `json:"conflicts,omitempty"`
+	PerformedBy   string                    `json:"performed_by,omitempty"`
+	CreatedAt     *time.Time                `json:"created_at,omitempty"`
+}
+
+// RolloverStudentEntry representa el movimiento de un estudiante
+type RolloverStudentEntry struct {
+	UserID       string `json:"user_id"`
+	Action       string `json:"action"` // promote, retain, graduate, carry_over
+	FromUnitID   string `json:"from_unit_id"`
+	FromUnitCode string `json:"from_unit_code,omitempty"`
+	ToUnitID     string `json:"to_unit_id,omitempty"`
+	ToUnitCode   string `json:"to_unit_code,omitempty"`
+}
+
+// RolloverAssignmentEntry representa una asignación de docente o personal que no se mueve
+// automáticamente; SuggestedUnitCode es la copia de su unidad en el año nuevo
+type RolloverAssignmentEntry struct {
+	MembershipID      string `json:"membership_id"`
+	UserID            string `json:"user_id"`
+	Role              string `json:"role"`
+	FromUnitCode      string `json:"from_unit_code,omitempty"`
+	SuggestedUnitCode string `json:"suggested_unit_code,omitempty"`
+}
+
+// ToRolloverResponse convierte un cambio de año guardado y su detalle a response
+func ToRolloverResponse(rollover *repository.SchoolRollover, entries []repository.SchoolRolloverEntry) *RolloverResponse {
+	createdAt := rollover.CreatedAt
+	resp := &RolloverResponse{
+		ID:            rollover.ID.String(),
+		SchoolID:      rollover.SchoolID.String(),
+		FromYear:      rollover.FromYear,
+		ToYear:        rollover.ToYear,
+		UnitsCreated:  rollover.UnitsCreated,
+		Promoted:      rollover.Promoted,
+		Retained:      rollover.Retained,
+		Graduated:     rollover.Graduated,
+		Students:      make([]RolloverStudentEntry, len(entries)),
+		PendingReview: []RolloverAssignmentEntry{}, // el detalle solo se reporta al ejecutar
+		PendingCount:  rollover.PendingReview,
+		PerformedBy:   rollover.PerformedBy,
+		CreatedAt:     &createdAt,
+	}
+	for i, entry := range entries {
+		resp.Students[i] = RolloverStudentEntry{
+			UserID:     entry.UserID.String(),
+			Action:     entry.Action,
+			FromUnitID: entry.FromUnitID.String(),
+		}
+		if entry.ToUnitID != nil {
+			resp.Students[i].ToUnitID = entry.ToUnitID.String()
+		}
+		if entry.Action == repository.RolloverActionCarryOver {
+			resp.CarriedOver++
+		}
+	}
+	return resp
+}
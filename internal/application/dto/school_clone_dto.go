@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// CloneSchoolRequest representa la copia de la estructura de una escuela hacia
+// una escuela existente (target_school_id) o una nueva (new_school)
+type CloneSchoolRequest struct {
+	TargetSchoolID  string               `json:"target_school_id"`
+	NewSchool       *CreateSchoolRequest `json:"new_school"`
+	CodePrefix      string               `json:"code_prefix"` // se antepone a cada código de unidad
+	CodeSuffix      string               `json:"code_suffix"` // se agrega al final de cada código de unidad
+	IncludeInactive bool                 `json:"include_inactive"`
+	SkipMemberships bool                 `json:"skip_memberships"`
+	SkipSettings    bool                 `json:"skip_settings"`
+	DryRun          bool                 `json:"dry_run"` // solo calcula la vista previa
+}
+
+// Validate valida el request (los datos de la escuela nueva los valida SchoolService)
+func (r *CloneSchoolRequest) Validate() error {
+	if (r.TargetSchoolID == "") == (r.NewSchool == nil) {
+		return errors.NewValidationError("exactly one of target_school_id or new_school is required")
+	}
+
+	v := validator.New()
+	if r.TargetSchoolID != "" {
+		v.UUID(r.TargetSchoolID, "target_school_id")
+	}
+	if r.NewSchool != nil {
+		v.Required(r.NewSchool.Name, "new_school.name")
+		v.Required(r.NewSchool.Code, "new_school.code")
+	}
+	v.MaxLength(r.CodePrefix, 20, "code_prefix")
+	v.MaxLength(r.CodeSuffix, 20, "code_suffix")
+	return v.GetError()
+}
+
+// CloneSchoolResponse representa la vista previa o el resultado de una copia de estructura
+type CloneSchoolResponse struct {
+	DryRun           bool              `json:"dry_run"`
+	SourceSchoolID   string            `json:"source_school_id"`
+	TargetSchoolID   string            `json:"target_school_id,omitempty"` // vacío en la vista previa de una escuela nueva
+	TargetSchoolCode string            `json:"target_school_code"`
+	CreatesSchool    bool              `json:"creates_school"`
+	Units            []ClonedUnitEntry `json:"units"`
+	AcademicYears    []int             `json:"academic_years"` // años lectivos copiados con sus períodos
+	Memberships      int               `json:"memberships"`
+	SettingsCopied   bool              `json:"settings_copied"`
+	Conflicts        []string          `json:"conflicts,omitempty"`
+}
+
+// ClonedUnitEntry representa una unidad a copiar (o copiada) con su código remapeado
+type ClonedUnitEntry struct {
+	SourceID   string `json:"source_id"`
+	ID         string `json:"id,omitempty"` // vacío en la vista previa
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	SourceCode string `json:"source_code,omitempty"`
+	Code       string `json:"code,omitempty"`
+	ParentCode string `json:"parent_code,omitempty"`
+	Depth      int    `json:"depth"`
+}
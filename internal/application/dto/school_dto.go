@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
 )
 
 // CreateSchoolRequest representa la solicitud para crear una escuela
@@ -110,3 +113,65 @@ func ToSchoolResponseList(schools []*entities.School) []SchoolResponse {
 	}
 	return responses
 }
+
+// Paginación del listado de escuelas
+const (
+	DefaultSchoolListLimit = 50
+	MaxSchoolListLimit     = 200
+)
+
+// ListSchoolsRequest representa la búsqueda de escuelas. Sin paginate la respuesta sigue siendo el
+// arreglo de escuelas (sin límite salvo que se indique limit); con paginate=true es un SchoolListResponse.
+type ListSchoolsRequest struct {
+	Paginate         bool   `form:"paginate"`
+	Search           string `form:"q"` // nombre o código (coincidencia parcial)
+	Country          string `form:"country"`
+	City             string `form:"city"`
+	SubscriptionTier string `form:"subscription_tier"`
+	IsActive         *bool  `form:"is_active"`
+	SortBy           string `form:"sort_by"` // name, code, country, city, subscription_tier, created_at, updated_at
+	Order            string `form:"order"`   // asc o desc (default desc)
+	Limit            int    `form:"limit"`   // máximo 200; con paginate, default 50
+	Offset           int    `form:"offset"`
+}
+
+// Validate valida el request
+func (r *ListSchoolsRequest) Validate() error {
+	v := validator.New()
+
+	v.MaxLength(r.Search, 100, "q")
+	if r.SortBy != "" {
+		v.InSlice(r.SortBy, []string{
+			repository.SchoolSortName,
+			repository.SchoolSortCode,
+			repository.SchoolSortCountry,
+			repository.SchoolSortCity,
+			repository.SchoolSortTier,
+			repository.SchoolSortCreatedAt,
+			repository.SchoolSortUpdatedAt,
+		}, "sort_by")
+	}
+	if r.Order != "" {
+		v.InSlice(r.Order, []string{"asc", "desc"}, "order")
+	}
+	if err := v.GetError(); err != nil {
+		return err
+	}
+
+	if r.Limit < 0 || r.Limit > MaxSchoolListLimit {
+		return errors.NewValidationError("limit must be between 1 and 200").WithField("limit", "invalid")
+	}
+	if r.Offset < 0 {
+		return errors.NewValidationError("offset must not be negative").WithField("offset", "invalid")
+	}
+	return nil
+}
+
+// SchoolListResponse representa una página del listado de escuelas
+type SchoolListResponse struct {
+	Items   []SchoolResponse `json:"items"`
+	Total   int              `json:"total"` // total de escuelas que cumplen los filtros
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+	HasMore bool             `json:"has_more"`
+}
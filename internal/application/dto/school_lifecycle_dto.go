@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// ArchiveSchoolRequest representa el archivado de una escuela
+type ArchiveSchoolRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Validate valida el request
+func (r *ArchiveSchoolRequest) Validate() error {
+	v := validator.New()
+	v.MaxLength(r.Reason, 500, "reason")
+	return v.GetError()
+}
+
+// PurgeSchoolRequest confirma la purga definitiva repitiendo el código de la escuela
+type PurgeSchoolRequest struct {
+	ConfirmCode string `json:"confirm_code"`
+}
+
+// Validate valida el request
+func (r *PurgeSchoolRequest) Validate() error {
+	v := validator.New()
+	v.Required(r.ConfirmCode, "confirm_code")
+	return v.GetError()
+}
+
+// SchoolArchiveResponse representa el archivado de una escuela
+type SchoolArchiveResponse struct {
+	ID                  string     `json:"id"`
+	SchoolID            string     `json:"school_id"`
+	SchoolCode          string     `json:"school_code"`
+	Reason              string     `json:"reason,omitempty"`
+	ArchivedBy          string     `json:"archived_by"`
+	ArchivedAt          time.Time  `json:"archived_at"`
+	PurgeAfter          time.Time  `json:"purge_after"`
+	RestoredBy          string     `json:"restored_by,omitempty"`
+	RestoredAt          *time.Time `json:"restored_at,omitempty"`
+	PurgedAt            *time.Time `json:"purged_at,omitempty"`
+	UnitsArchived       int        `json:"units_archived"`
+	MembershipsArchived int        `json:"memberships_archived"`
+}
+
+// ToSchoolArchiveResponse convierte un archivado y sus items a response
+func ToSchoolArchiveResponse(archive *repository.SchoolArchive, items []repository.SchoolArchiveItem) *SchoolArchiveResponse {
+	resp := &SchoolArchiveResponse{
+		ID:         archive.ID.String(),
+		SchoolID:   archive.SchoolID.String(),
+		SchoolCode: archive.SchoolCode,
+		Reason:     archive.Reason,
+		ArchivedBy: archive.ArchivedBy,
+		ArchivedAt: archive.ArchivedAt,
+		PurgeAfter: archive.PurgeAfter,
+		RestoredBy: archive.RestoredBy,
+		RestoredAt: archive.RestoredAt,
+		PurgedAt:   archive.PurgedAt,
+	}
+	for _, item := range items {
+		switch item.EntityType {
+		case repository.SchoolArchiveItemUnit:
+			resp.UnitsArchived++
+		case repository.SchoolArchiveItemMembership:
+			resp.MembershipsArchived++
+		}
+	}
+	return resp
+}
+
+// RestoreSchoolResponse representa el resultado de restaurar una escuela archivada
+type RestoreSchoolResponse struct {
+	School              SchoolResponse `json:"school"`
+	UnitsRestored       int            `json:"units_restored"`
+	MembershipsRestored int            `json:"memberships_restored"`
+	Skipped             int            `json:"skipped"` // entidades archivadas que ya no existen
+}
+
+// SchoolPurgeJobResponse representa el progreso de una purga definitiva
+type SchoolPurgeJobResponse struct {
+	ID              string           `json:"id"`
+	SchoolID        string           `json:"school_id"`
+	Status          string           `json:"status"` // pending, running, completed, failed
+	TotalSteps      int              `json:"total_steps"`
+	CompletedSteps  int              `json:"completed_steps"`
+	ProgressPercent int              `json:"progress_percent"`
+	CurrentStep     string           `json:"current_step,omitempty"`
+	DeletedRows     map[string]int64 `json:"deleted_rows"`
+	Error           string           `json:"error,omitempty"`
+	RequestedBy     string           `json:"requested_by"`
+	CreatedAt       time.Time        `json:"created_at"`
+	StartedAt       *time.Time       `json:"started_at,omitempty"`
+	FinishedAt      *time.Time       `json:"finished_at,omitempty"`
+}
+
+// ToSchoolPurgeJobResponse convierte un job de purga a response
+func ToSchoolPurgeJobResponse(job *repository.SchoolPurgeJob) *SchoolPurgeJobResponse {
+	resp := &SchoolPurgeJobResponse{
+		ID:             job.ID.String(),
+		SchoolID:       job.SchoolID.String(),
+		Status:         job.Status,
+		TotalSteps:     job.TotalSteps,
+		CompletedSteps: job.CompletedSteps,
+		CurrentStep:    job.CurrentStep,
+		DeletedRows:    job.DeletedRows,
+		Error:          job.Error,
+		RequestedBy:    job.RequestedBy,
+		CreatedAt:      job.CreatedAt,
+		StartedAt:      job.StartedAt,
+		FinishedAt:     job.FinishedAt,
+	}
+	if job.TotalSteps > 0 {
+		resp.ProgressPercent = job.CompletedSteps * 100 / job.TotalSteps
+	}
+	if resp.DeletedRows == nil {
+		resp.DeletedRows = map[string]int64{}
+	}
+	return resp
+}
@@ -0,0 +1,20 @@
+package dto
+
+// QuotaUsage representa el uso de un cupo del plan de la escuela
+type QuotaUsage struct {
+	Used             int     `json:"used"`
+	Limit            int     `json:"limit"`                       // 0 = sin límite
+	Remaining        *int    `json:"remaining,omitempty"`         // nil si no hay límite
+	UsagePercent     float64 `json:"usage_percent"`               // 0 si no hay límite
+	WarningThreshold int     `json:"warning_threshold,omitempty"` // umbral de aviso más alto alcanzado
+	LimitReached     bool    `json:"limit_reached"`
+}
+
+// SchoolUsageResponse representa el uso actual de los cupos de una escuela
+type SchoolUsageResponse struct {
+	SchoolID          string     `json:"school_id"`
+	SubscriptionTier  string     `json:"subscription_tier"`
+	Teachers          QuotaUsage `json:"teachers"`
+	Students          QuotaUsage `json:"students"`
+	WarningThresholds []int      `json:"warning_thresholds"`
+}
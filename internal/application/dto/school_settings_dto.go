@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// SchoolSettings es la configuración tipada de una escuela
+type SchoolSettings struct {
+	Locale           string                   `json:"locale"`
+	Timezone         string                   `json:"timezone"`
+	GradingScale     GradingScaleSettings     `json:"grading_scale"`
+	AcademicCalendar AcademicCalendarSettings `json:"academic_calendar"`
+	Attendance       AttendanceSettings       `json:"attendance"`
+	UnitNesting      UnitNestingSettings      `json:"unit_nesting"`
+}
+
+// GradingScaleSettings define la escala de calificación
+type GradingScaleSettings struct {
+	Type     string  `json:"type"` // numeric, letter
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Passing  float64 `json:"passing"`
+	Decimals int     `json:"decimals"`
+}
+
+// AcademicCalendarSettings define el inicio y la división del año académico
+type AcademicCalendarSettings struct {
+	StartMonth int `json:"start_month"`
+	StartDay   int `json:"start_day"`
+	Terms      int `json:"terms"`
+}
+
+// AttendanceSettings define las reglas de asistencia
+type AttendanceSettings struct {
+	LateAfterMinutes        int  `json:"late_after_minutes"`
+	AbsenceAlertPercent     int  `json:"absence_alert_percent"`
+	ExcusedRequiresDocument bool `json:"excused_requires_document"`
+}
+
+// UnitNestingSettings define qué tipos de unidad pueden colgar de cuáles y la
+// profundidad máxima del árbol (la raíz es el nivel 1)
+type UnitNestingSettings struct {
+	MaxDepth       int                 `json:"max_depth"`
+	RootTypes      []string            `json:"root_types"`
+	AllowedParents map[string][]string `json:"allowed_parents"` // tipo -> tipos de padre permitidos
+}
+
+// SchoolSettingsResponse representa la configuración efectiva de una escuela
+type SchoolSettingsResponse struct {
+	SchoolID      string                 `json:"school_id"`
+	SchemaVersion int                    `json:"schema_version"`
+	Version       int                    `json:"version"` // 0 = hereda todo de la configuración global
+	Settings      SchoolSettings         `json:"settings"`
+	Overrides     map[string]interface{} `json:"overrides"` // valores propios de la escuela
+	UpdatedBy     string                 `json:"updated_by,omitempty"`
+	UpdatedAt     *time.Time             `json:"updated_at,omitempty"`
+}
+
+// PatchSchoolSettingsRequest aplica un JSON merge patch (RFC 7386) sobre los
+// valores propios de la escuela; un null restaura el valor heredado.
+type PatchSchoolSettingsRequest struct {
+	Version  *int                   `json:"version"` // versión esperada (control de concurrencia)
+	Settings map[string]interface{} `json:"settings"`
+	Reason   string                 `json:"reason"`
+}
+
+// Validate valida el request
+func (r *PatchSchoolSettingsRequest) Validate() error {
+	if len(r.Settings) == 0 {
+		return errors.NewValidationError("settings is required")
+	}
+	if r.Version != nil && *r.Version < 0 {
+		return errors.NewValidationError("version must be >= 0")
+	}
+
+	v := validator.New()
+	v.MaxLength(r.Reason, 500, "reason")
+	return v.GetError()
+}
+
+// SchoolSettingsChangeResponse representa una entrada del historial de configuración
+type SchoolSettingsChangeResponse struct {
+	ID        string          `json:"id"`
+	Version   int             `json:"version"`
+	Patch     json.RawMessage `json:"patch"`
+	Overrides json.RawMessage `json:"overrides"`
+	Reason    string          `json:"reason,omitempty"`
+	ChangedBy string          `json:"changed_by"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ToSchoolSettingsChangeResponse convierte un cambio de configuración a response
+func ToSchoolSettingsChangeResponse(change *repository.SchoolSettingsChange) SchoolSettingsChangeResponse {
+	return SchoolSettingsChangeResponse{
+		ID:        change.ID.String(),
+		Version:   change.Version,
+		Patch:     change.Patch,
+		Overrides: change.Overrides,
+		Reason:    change.Reason,
+		ChangedBy: change.ChangedBy,
+		CreatedAt: change.CreatedAt,
+	}
+}
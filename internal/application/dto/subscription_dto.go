@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// SubscriptionTierResponse representa un plan del catálogo (límites en 0 = sin límite)
+type SubscriptionTierResponse struct {
+	Name         string   `json:"name"`
+	DisplayName  string   `json:"display_name"`
+	MaxTeachers  int      `json:"max_teachers"`
+	MaxStudents  int      `json:"max_students"`
+	MaxUnits     int      `json:"max_units"`
+	MaxStorageMB int      `json:"max_storage_mb"`
+	Features     []string `json:"features"`
+}
+
+// SubscriptionUsage representa el uso actual de los recursos limitados por el plan
+type SubscriptionUsage struct {
+	Teachers int `json:"teachers"`
+	Students int `json:"students"`
+	Units    int `json:"units"`
+}
+
+// SchoolSubscriptionResponse representa el plan vigente de una escuela
+type SchoolSubscriptionResponse struct {
+	SchoolID string                    `json:"school_id"`
+	Tier     string                    `json:"tier"`
+	Plan     *SubscriptionTierResponse `json:"plan,omitempty"` // nil si el tier no está en el catálogo
+	Usage    SubscriptionUsage         `json:"usage"`
+}
+
+// FeatureCheckResponse indica si un feature está habilitado para una escuela
+type FeatureCheckResponse struct {
+	SchoolID string `json:"school_id"`
+	Tier     string `json:"tier"`
+	Feature  string `json:"feature"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// ChangeSubscriptionTierRequest representa un upgrade o downgrade de plan
+type ChangeSubscriptionTierRequest struct {
+	Tier   string `json:"tier"`
+	Reason string `json:"reason"`
+}
+
+// Validate valida el request
+func (r *ChangeSubscriptionTierRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.Tier, "tier")
+	v.MaxLength(r.Tier, 50, "tier")
+
+	v.Required(r.Reason, "reason")
+	v.MinLength(r.Reason, 3, "reason")
+	v.MaxLength(r.Reason, 500, "reason")
+
+	return v.GetError()
+}
+
+// SubscriptionChangeResponse representa una entrada del historial de planes
+type SubscriptionChangeResponse struct {
+	ID          string    `json:"id"`
+	SchoolID    string    `json:"school_id"`
+	FromTier    string    `json:"from_tier"`
+	ToTier      string    `json:"to_tier"`
+	Direction   string    `json:"direction"`
+	Reason      string    `json:"reason"`
+	PerformedBy string    `json:"performed_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ToSubscriptionChangeResponse convierte un cambio de plan a response
+func ToSubscriptionChangeResponse(change *repository.SubscriptionChange) SubscriptionChangeResponse {
+	return SubscriptionChangeResponse{
+		ID:          change.ID.String(),
+		SchoolID:    change.SchoolID.String(),
+		FromTier:    change.FromTier,
+		ToTier:      change.ToTier,
+		Direction:   change.Direction,
+		Reason:      change.Reason,
+		PerformedBy: change.PerformedBy,
+		CreatedAt:   change.CreatedAt,
+	}
+}
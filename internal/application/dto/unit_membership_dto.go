@@ -1,8 +1,10 @@
 package dto
 
 import (
-	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 )
 
 type CreateMembershipRequest struct {
@@ -19,7 +21,7 @@ type UpdateMembershipRequest struct {
 }
 
 type MembershipResponse struct {
-	ID          string     `json:"id"`
+	ID          string     `json:"id,omitempty"` // vacío si el estudiante quedó en lista de espera
 	UnitID      string     `json:"unit_id"`
 	UserID      string     `json:"user_id"`
 	Role        string     `json:"role"`
@@ -28,6 +30,20 @@ type MembershipResponse struct {
 	IsActive    bool       `json:"is_active"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Waitlist *WaitlistEntryResponse `json:"waitlist,omitempty"` // la unidad no tenía cupo y el estudiante quedó en espera
+}
+
+// WaitlistEntryResponse representa un estudiante en la lista de espera de una unidad
+type WaitlistEntryResponse struct {
+	ID           string     `json:"id"`
+	UnitID       string     `json:"unit_id"`
+	UserID       string     `json:"user_id"`
+	Status       string     `json:"status"`
+	Position     int        `json:"position,omitempty"` // solo para las entradas en espera
+	MembershipID *string    `json:"membership_id,omitempty"`
+	RequestedAt  time.Time  `json:"requested_at"`
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
 }
 
 func ToMembershipResponse(m *entities.Membership) MembershipResponse {
@@ -47,3 +63,22 @@ func ToMembershipResponse(m *entities.Membership) MembershipResponse {
 		UpdatedAt:   m.UpdatedAt,
 	}
 }
+
+// ToWaitlistEntryResponse convierte una entrada de la lista de espera; position 0 la omite
+func ToWaitlistEntryResponse(entry *repository.UnitWaitlistEntry, position int) WaitlistEntryResponse {
+	var membershipID *string
+	if entry.MembershipID != nil {
+		id := entry.MembershipID.String()
+		membershipID = &id
+	}
+	return WaitlistEntryResponse{
+		ID:           entry.ID.String(),
+		UnitID:       entry.UnitID.String(),
+		UserID:       entry.UserID.String(),
+		Status:       entry.Status,
+		Position:     position,
+		MembershipID: membershipID,
+		RequestedAt:  entry.RequestedAt,
+		ResolvedAt:   entry.ResolvedAt,
+	}
+}
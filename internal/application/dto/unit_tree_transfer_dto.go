@@ -0,0 +1,185 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// Formatos del documento del árbol de unidades
+const (
+	UnitTreeFormatJSON = "json"
+	UnitTreeFormatYAML = "yaml"
+)
+
+// UnitTreeDocumentVersion es la versión actual del formato del documento
+const UnitTreeDocumentVersion = 1
+
+// MaxUnitTreeDocumentUnits limita las unidades de un documento importado
+const MaxUnitTreeDocumentUnits = 2000
+
+// Acciones sobre las membresías de una importación
+const (
+	UnitTreeMembershipCreate     = "create"
+	UnitTreeMembershipUpdateRole = "update_role"
+	UnitTreeMembershipWaitlisted = "waitlisted" // el estudiante quedó en la lista de espera de la unidad
+	UnitTreeMembershipSkip       = "skip"
+)
+
+// ParseUnitTreeFormat normaliza el formato del documento (json por defecto)
+func ParseUnitTreeFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", UnitTreeFormatJSON:
+		return UnitTreeFormatJSON, nil
+	case UnitTreeFormatYAML, "yml":
+		return UnitTreeFormatYAML, nil
+	default:
+		return "", errors.NewValidationError("format must be json or yaml")
+	}
+}
+
+// UnitTreeDocument es el árbol de unidades de una escuela en el formato de exportación e importación.
+// Las unidades se identifican por código y los usuarios por email, así el documento sirve entre ambientes.
+type UnitTreeDocument struct {
+	Version      int                     `json:"version" yaml:"version"`
+	AcademicYear int                     `json:"academic_year,omitempty" yaml:"academic_year,omitempty"`
+	ExportedAt   *time.Time              `json:"exported_at,omitempty" yaml:"exported_at,omitempty"`
+	Units        []*UnitTreeDocumentNode `json:"units" yaml:"units"`
+}
+
+// UnitTreeDocumentNode es una unidad del documento con sus hijos
+type UnitTreeDocumentNode struct {
+	Code        string                       `json:"code" yaml:"code"`
+	Type        string                       `json:"type" yaml:"type"`
+	DisplayName string                       `json:"display_name" yaml:"display_name"`
+	Description string                       `json:"description,omitempty" yaml:"description,omitempty"`
+	Metadata    map[string]interface{}       `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Memberships []UnitTreeDocumentMembership `json:"memberships,omitempty" yaml:"memberships,omitempty"`
+	Children    []*UnitTreeDocumentNode      `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// UnitTreeDocumentMembership es una membresía activa de la unidad
+type UnitTreeDocumentMembership struct {
+	Email string `json:"email" yaml:"email"`
+	Role  string `json:"role" yaml:"role"`
+}
+
+// Validate valida la estructura del documento: versión, códigos únicos y campos requeridos de cada unidad
+func (d *UnitTreeDocument) Validate() error {
+	if d.Version > UnitTreeDocumentVersion {
+		return errors.NewValidationError(fmt.Sprintf("unsupported document version %d", d.Version))
+	}
+	if len(d.Units) == 0 {
+		return errors.NewValidationError("units cannot be empty")
+	}
+
+	codes := make(map[string]bool)
+	count := 0
+	var walk func(nodes []*UnitTreeDocumentNode, path string) error
+	walk = func(nodes []*UnitTreeDocumentNode, path string) error {
+		for i, node := range nodes {
+			nodePath := fmt.Sprintf("%s[%d]", path, i)
+			if node == nil {
+				return errors.NewValidationError(nodePath + ": unit cannot be empty")
+			}
+			count++
+			if count > MaxUnitTreeDocumentUnits {
+				return errors.NewValidationError(fmt.Sprintf("document cannot have more than %d units", MaxUnitTreeDocumentUnits))
+			}
+			if err := node.validate(nodePath); err != nil {
+				return err
+			}
+			if codes[node.Code] {
+				return errors.NewValidationError(fmt.Sprintf("%s: duplicate code %s", nodePath, node.Code))
+			}
+			codes[node.Code] = true
+			if err := walk(node.Children, nodePath+".children"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(d.Units, "units")
+}
+
+func (n *UnitTreeDocumentNode) validate(path string) error {
+	n.Code = strings.TrimSpace(n.Code)
+	n.Type = strings.TrimSpace(n.Type)
+	n.DisplayName = strings.TrimSpace(n.DisplayName)
+
+	switch {
+	case n.Code == "":
+		return errors.NewValidationError(path + ": code is required")
+	case len(n.Code) < 2 || len(n.Code) > 50:
+		return errors.NewValidationError(path + ": code must be between 2 and 50 characters")
+	case n.Type == "":
+		return errors.NewValidationError(path + ": type is required")
+	case len(n.DisplayName) < 3 || len(n.DisplayName) > 255:
+		return errors.NewValidationError(path + ": display_name must be between 3 and 255 characters")
+	}
+
+	emails := make(map[string]bool, len(n.Memberships))
+	for i := range n.Memberships {
+		membership := &n.Memberships[i]
+		membership.Email = strings.ToLower(strings.TrimSpace(membership.Email))
+		if membership.Email == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s.memberships[%d]: email is required", path, i))
+		}
+		if _, err := valueobject.ParseMembershipRole(membership.Role); err != nil {
+			return errors.NewValidationError(fmt.Sprintf("%s.memberships[%d]: %s", path, i, err.Error()))
+		}
+		if emails[membership.Email] {
+			return errors.NewValidationError(fmt.Sprintf("%s.memberships[%d]: duplicate email %s", path, i, membership.Email))
+		}
+		emails[membership.Email] = true
+	}
+	return nil
+}
+
+// ExportUnitTreeRequest representa las opciones de exportación del árbol de unidades
+type ExportUnitTreeRequest struct {
+	Format             string `form:"format"`        // json (default) o yaml
+	AcademicYear       *int   `form:"academic_year"` // por defecto el año lectivo activo
+	IncludeMemberships bool   `form:"include_memberships"`
+}
+
+// ImportUnitTreeRequest representa las opciones de importación del árbol de unidades
+type ImportUnitTreeRequest struct {
+	Format       string `form:"format"`        // json (default) o yaml
+	AcademicYear *int   `form:"academic_year"` // por defecto el año lectivo activo
+	DryRun       bool   `form:"dry_run"`       // solo calcula las diferencias
+	Prune        bool   `form:"prune"`         // elimina las unidades del año que no están en el documento
+}
+
+// UnitTreeImportResponse representa las diferencias aplicadas (o el preview con dry_run) de una importación
+type UnitTreeImportResponse struct {
+	DryRun       bool                       `json:"dry_run"`
+	AcademicYear int                        `json:"academic_year,omitempty"`
+	Creates      []UnitTreeChange           `json:"creates"`
+	Updates      []UnitTreeChange           `json:"updates"`
+	Deletes      []UnitTreeChange           `json:"deletes"` // solo con prune; incluye las que caen en cascada
+	Unchanged    int                        `json:"unchanged"`
+	Memberships  []UnitTreeMembershipChange `json:"memberships,omitempty"`
+}
+
+// UnitTreeChange es una unidad creada, actualizada o eliminada por la importación
+type UnitTreeChange struct {
+	UnitID      string   `json:"unit_id,omitempty"` // vacío en las creaciones del preview
+	Code        string   `json:"code,omitempty"`
+	Type        string   `json:"type"`
+	DisplayName string   `json:"display_name"`
+	ParentCode  string   `json:"parent_code,omitempty"`
+	Fields      []string `json:"fields,omitempty"` // actualizaciones: campos que cambian
+}
+
+// UnitTreeMembershipChange es una membresía creada o modificada por la importación
+type UnitTreeMembershipChange struct {
+	UnitCode string `json:"unit_code"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	Action   string `json:"action"`
+	Reason   string `json:"reason,omitempty"`
+}
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// UnitTypeResponse representa un tipo de unidad disponible en la escuela (predefinido o propio)
+type UnitTypeResponse struct {
+	Code         string     `json:"code"`
+	Label        string     `json:"label"`
+	Icon         string     `json:"icon,omitempty"`
+	Builtin      bool       `json:"builtin"`
+	AllowedRoles []string   `json:"allowed_roles"` // vacío = cualquier rol
+	ParentTypes  []string   `json:"parent_types"`  // tipos de los que puede colgar según las reglas de la escuela
+	CanBeRoot    bool       `json:"can_be_root"`
+	ChildTypes   []string   `json:"child_types,omitempty"` // solo tipos propios: tipos que pueden colgar de él
+	CreatedBy    string     `json:"created_by,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// CreateUnitTypeRequest registra un tipo de unidad propio de la escuela
+type CreateUnitTypeRequest struct {
+	Code         string   `json:"code"`
+	Label        string   `json:"label"`
+	Icon         string   `json:"icon"`
+	AllowedRoles []string `json:"allowed_roles"`
+	ParentTypes  []string `json:"parent_types"`
+	ChildTypes   []string `json:"child_types"`
+	CanBeRoot    bool     `json:"can_be_root"`
+}
+
+// Validate valida el request (el código y los tipos referenciados los valida el servicio)
+func (r *CreateUnitTypeRequest) Validate() error {
+	v := validator.New()
+	v.Required(r.Code, "code")
+	v.Required(r.Label, "label")
+	v.MaxLength(r.Label, 100, "label")
+	v.MaxLength(r.Icon, 50, "icon")
+	return v.GetError()
+}
+
+// UpdateUnitTypeRequest actualiza un tipo propio; los campos nil no cambian
+type UpdateUnitTypeRequest struct {
+	Label        *string   `json:"label"`
+	Icon         *string   `json:"icon"`
+	AllowedRoles *[]string `json:"allowed_roles"`
+	ParentTypes  *[]string `json:"parent_types"`
+	ChildTypes   *[]string `json:"child_types"`
+	CanBeRoot    *bool     `json:"can_be_root"`
+}
+
+// Validate valida el request
+func (r *UpdateUnitTypeRequest) Validate() error {
+	v := validator.New()
+	if r.Label != nil {
+		v.Required(*r.Label, "label")
+		v.MaxLength(*r.Label, 100, "label")
+	}
+	if r.Icon != nil {
+		v.MaxLength(*r.Icon, 50, "icon")
+	}
+	return v.GetError()
+}
+
+// ToUnitTypeResponse convierte un tipo propio a response
+func ToUnitTypeResponse(unitType *repository.SchoolUnitType) UnitTypeResponse {
+	createdAt := unitType.CreatedAt
+	updatedAt := unitType.UpdatedAt
+	return UnitTypeResponse{
+		Code:         unitType.Code,
+		Label:        unitType.Label,
+		Icon:         unitType.Icon,
+		AllowedRoles: nonNilStrings(unitType.AllowedRoles),
+		ParentTypes:  nonNilStrings(unitType.ParentTypes),
+		ChildTypes:   nonNilStrings(unitType.ChildTypes),
+		CanBeRoot:    unitType.CanBeRoot,
+		CreatedBy:    unitType.CreatedBy,
+		CreatedAt:    &createdAt,
+		UpdatedAt:    &updatedAt,
+	}
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,150 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// ScanDuplicatesRequest representa la solicitud para buscar posibles duplicados
+type ScanDuplicatesRequest struct {
+	SchoolID string `json:"school_id"` // opcional: limita el escaneo a usuarios de la escuela
+}
+
+// Validate valida el request
+func (r *ScanDuplicatesRequest) Validate() error {
+	v := validator.New()
+
+	if r.SchoolID != "" {
+		v.UUID(r.SchoolID, "school_id")
+	}
+
+	return v.GetError()
+}
+
+// ScanDuplicatesResponse resume el resultado de un escaneo de duplicados
+type ScanDuplicatesResponse struct {
+	ScannedUsers      int `json:"scanned_users"`
+	NewCandidates     int `json:"new_candidates"`
+	UpdatedCandidates int `json:"updated_candidates"`
+}
+
+// ListDuplicatesRequest representa los filtros de la cola de revisión
+type ListDuplicatesRequest struct {
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+// Validate valida el request
+func (r *ListDuplicatesRequest) Validate() error {
+	v := validator.New()
+
+	if r.Status != "" {
+		v.InSlice(r.Status, []string{
+			repository.DuplicateStatusPending,
+			repository.DuplicateStatusDismissed,
+			repository.DuplicateStatusMerged,
+		}, "status")
+	}
+
+	return v.GetError()
+}
+
+// DuplicateUserSummary resume un usuario dentro de un candidato a duplicado
+type DuplicateUserSummary struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role"`
+	IsActive  bool   `json:"is_active"`
+}
+
+// DuplicateCandidateResponse representa un par de usuarios en la cola de revisión
+type DuplicateCandidateResponse struct {
+	ID         string                `json:"id"`
+	UserA      *DuplicateUserSummary `json:"user_a"`
+	UserB      *DuplicateUserSummary `json:"user_b"`
+	Score      float64               `json:"score"`
+	Reasons    []string              `json:"reasons"`
+	Status     string                `json:"status"`
+	DetectedAt time.Time             `json:"detected_at"`
+	ReviewedBy *string               `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time            `json:"reviewed_at,omitempty"`
+}
+
+// ToDuplicateCandidateResponse convierte un candidato a DTO; los usuarios pueden ser nil
+// si ya no existen
+func ToDuplicateCandidateResponse(candidate *repository.DuplicateCandidate, userA, userB *entities.User) *DuplicateCandidateResponse {
+	return &DuplicateCandidateResponse{
+		ID:         candidate.ID.String(),
+		UserA:      toDuplicateUserSummary(userA),
+		UserB:      toDuplicateUserSummary(userB),
+		Score:      candidate.Score,
+		Reasons:    candidate.Reasons,
+		Status:     candidate.Status,
+		DetectedAt: candidate.DetectedAt,
+		ReviewedBy: candidate.ReviewedBy,
+		ReviewedAt: candidate.ReviewedAt,
+	}
+}
+
+func toDuplicateUserSummary(user *entities.User) *DuplicateUserSummary {
+	if user == nil {
+		return nil
+	}
+	return &DuplicateUserSummary{
+		ID:        user.ID.String(),
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Role:      user.Role,
+		IsActive:  user.IsActive,
+	}
+}
+
+// MergeUsersRequest representa la solicitud para fusionar dos cuentas.
+// La cuenta origen se retira y todo lo suyo pasa a la cuenta destino.
+type MergeUsersRequest struct {
+	SourceUserID string `json:"source_user_id"`
+	TargetUserID string `json:"target_user_id"`
+	CandidateID  string `json:"candidate_id"` // opcional: candidato de la cola que se marca como fusionado
+	Reason       string `json:"reason"`
+	DryRun       bool   `json:"dry_run"`
+}
+
+// Validate valida el request
+func (r *MergeUsersRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.SourceUserID, "source_user_id")
+	v.UUID(r.SourceUserID, "source_user_id")
+	v.Required(r.TargetUserID, "target_user_id")
+	v.UUID(r.TargetUserID, "target_user_id")
+	if r.CandidateID != "" {
+		v.UUID(r.CandidateID, "candidate_id")
+	}
+	v.MaxLength(r.Reason, 500, "reason")
+
+	return v.GetError()
+}
+
+// MergeUsersResponse describe el plan de fusión; Applied indica si se ejecutó o es una vista previa
+type MergeUsersResponse struct {
+	SourceUserID                  string   `json:"source_user_id"`
+	TargetUserID                  string   `json:"target_user_id"`
+	MembershipsToMove             []string `json:"memberships_to_move"`
+	MembershipsToExpire           []string `json:"memberships_to_expire"`
+	GuardianRelationsToMove       []string `json:"guardian_relations_to_move"`
+	GuardianRelationsToDeactivate []string `json:"guardian_relations_to_deactivate"`
+	AuditReferences               int      `json:"audit_references"`
+	Profile                       string   `json:"profile"` // none, move o merge
+	LoginEvents                   int      `json:"login_events"`
+	Applied                       bool     `json:"applied"`
+	AuditReferencesMoved          int64    `json:"audit_references_moved,omitempty"`
+	LoginEventsMoved              int64    `json:"login_events_moved,omitempty"`
+	InvitationsMoved              int64    `json:"invitations_moved,omitempty"` // pendientes redirigidas al email del destino o revocadas
+}
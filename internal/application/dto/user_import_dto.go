@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// Estados posibles de una fila importada
+const (
+	ImportRowStatusValid   = "valid"   // dry-run: la fila se crearía
+	ImportRowStatusCreated = "created" // la fila se creó
+	ImportRowStatusSkipped = "skipped" // el email ya existía y se pidió skip_existing
+	ImportRowStatusFailed  = "failed"  // la fila tiene errores
+
+	// ImportRowStatusCompleted: el usuario ya existía y se creó la membresía que le faltaba
+	ImportRowStatusCompleted = "completed"
+)
+
+// ImportUsersRequest representa las opciones de una importación masiva de usuarios
+type ImportUsersRequest struct {
+	SchoolID       string `form:"school_id"`
+	UnitCode       string `form:"unit_code"`
+	MembershipRole string `form:"membership_role"`
+	DryRun         bool   `form:"dry_run"`
+	SkipExisting   bool   `form:"skip_existing"`
+}
+
+// Validate valida el request
+func (r *ImportUsersRequest) Validate() error {
+	v := validator.New()
+
+	// La membresía es opcional, pero si se pide una unidad se requiere la escuela
+	if r.UnitCode != "" {
+		v.Required(r.SchoolID, "school_id")
+		v.UUID(r.SchoolID, "school_id")
+		v.MaxLength(r.UnitCode, 50, "unit_code")
+	}
+
+	return v.GetError()
+}
+
+// ImportRowResult representa el resultado de una fila del archivo
+type ImportRowResult struct {
+	Row          int      `json:"row"`
+	Email        string   `json:"email"`
+	Status       string   `json:"status"`
+	UserID       string   `json:"user_id,omitempty"`
+	MembershipID string   `json:"membership_id,omitempty"`
+	Waitlisted   bool     `json:"waitlisted,omitempty"` // la unidad estaba llena y el estudiante quedó en lista de espera
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// ImportUsersReport representa el reporte de una importación masiva
+type ImportUsersReport struct {
+	DryRun    bool              `json:"dry_run"`
+	TotalRows int               `json:"total_rows"`
+	Valid     int               `json:"valid"`
+	Created   int               `json:"created"`
+	Skipped   int               `json:"skipped"`
+	Completed int               `json:"completed"`
+	Failed    int               `json:"failed"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+// Add agrega el resultado de una fila y actualiza los contadores
+func (r *ImportUsersReport) Add(result ImportRowResult) {
+	r.TotalRows++
+	switch result.Status {
+	case ImportRowStatusValid:
+		r.Valid++
+	case ImportRowStatusCreated:
+		r.Created++
+	case ImportRowStatusSkipped:
+		r.Skipped++
+	case ImportRowStatusCompleted:
+		r.Completed++
+	case ImportRowStatusFailed:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, result)
+}
+
+// WriteCSV escribe el reporte por fila en formato CSV (archivo descargable)
+func (r *ImportUsersReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"row", "email", "status", "user_id", "membership_id", "errors"}); err != nil {
+		return err
+	}
+
+	for _, row := range r.Rows {
+		record := []string{
+			strconv.Itoa(row.Row),
+			row.Email,
+			row.Status,
+			row.UserID,
+			row.MembershipID,
+			strings.Join(row.Errors, "; "),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
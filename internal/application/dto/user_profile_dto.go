@@ -0,0 +1,188 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+	_ "time/tzdata" // zonas horarias IANA embebidas para validar sin depender del sistema
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+const (
+	// DefaultPreferredLanguage es el idioma asignado a usuarios sin perfil
+	DefaultPreferredLanguage = "es"
+	// DefaultTimezone es la zona horaria asignada a usuarios sin perfil
+	DefaultTimezone = "UTC"
+	// DateOfBirthLayout es el formato de fecha de nacimiento (YYYY-MM-DD)
+	DateOfBirthLayout = "2006-01-02"
+)
+
+// SupportedLanguages son los idiomas disponibles en la plataforma
+var SupportedLanguages = []string{"es", "en", "pt"}
+
+// NotificationDigests son las frecuencias válidas del resumen de notificaciones
+var NotificationDigests = []string{"none", "daily", "weekly"}
+
+// NotificationPreferencesPatch representa cambios parciales a las preferencias de notificación
+type NotificationPreferencesPatch struct {
+	Email  *bool   `json:"email,omitempty"`
+	SMS    *bool   `json:"sms,omitempty"`
+	Push   *bool   `json:"push,omitempty"`
+	Digest *string `json:"digest,omitempty"`
+}
+
+// UpdateMyProfileRequest representa la actualización del perfil propio.
+// Un string vacío en phone, date_of_birth o avatar_url borra el valor.
+type UpdateMyProfileRequest struct {
+	FirstName               *string                       `json:"first_name,omitempty"`
+	LastName                *string                       `json:"last_name,omitempty"`
+	Phone                   *string                       `json:"phone,omitempty"`
+	PreferredLanguage       *string                       `json:"preferred_language,omitempty"`
+	Timezone                *string                       `json:"timezone,omitempty"`
+	DateOfBirth             *string                       `json:"date_of_birth,omitempty" example:"2008-04-21"`
+	AvatarURL               *string                       `json:"avatar_url,omitempty"`
+	NotificationPreferences *NotificationPreferencesPatch `json:"notification_preferences,omitempty"`
+}
+
+// Validate valida el request
+func (r *UpdateMyProfileRequest) Validate() error {
+	v := validator.New()
+
+	if r.FirstName != nil {
+		v.MinLength(*r.FirstName, 2, "first_name")
+		v.MaxLength(*r.FirstName, 50, "first_name")
+		v.Name(*r.FirstName, "first_name")
+	}
+
+	if r.LastName != nil {
+		v.MinLength(*r.LastName, 2, "last_name")
+		v.MaxLength(*r.LastName, 50, "last_name")
+		v.Name(*r.LastName, "last_name")
+	}
+
+	if r.Phone != nil && *r.Phone != "" {
+		v.MinLength(*r.Phone, 7, "phone")
+		v.MaxLength(*r.Phone, 20, "phone")
+	}
+
+	if r.PreferredLanguage != nil {
+		v.InSlice(*r.PreferredLanguage, SupportedLanguages, "preferred_language")
+	}
+
+	if r.AvatarURL != nil && *r.AvatarURL != "" {
+		v.MaxLength(*r.AvatarURL, 500, "avatar_url")
+	}
+
+	if r.NotificationPreferences != nil && r.NotificationPreferences.Digest != nil {
+		v.InSlice(*r.NotificationPreferences.Digest, NotificationDigests, "notification_preferences.digest")
+	}
+
+	if err := v.GetError(); err != nil {
+		return err
+	}
+
+	if r.Phone != nil && *r.Phone != "" && !isPhone(*r.Phone) {
+		return errors.NewValidationError("phone must contain only digits, spaces, dashes, parentheses and an optional leading +")
+	}
+
+	if r.Timezone != nil {
+		if _, err := time.LoadLocation(*r.Timezone); err != nil || *r.Timezone == "" || *r.Timezone == "Local" {
+			return errors.NewValidationError("timezone must be a valid IANA time zone").
+				WithField("timezone", *r.Timezone)
+		}
+	}
+
+	if r.DateOfBirth != nil && *r.DateOfBirth != "" {
+		if _, err := r.ParsedDateOfBirth(); err != nil {
+			return err
+		}
+	}
+
+	if r.AvatarURL != nil && *r.AvatarURL != "" {
+		parsed, err := url.ParseRequestURI(*r.AvatarURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return errors.NewValidationError("avatar_url must be an absolute http(s) URL")
+		}
+	}
+
+	return nil
+}
+
+// ParsedDateOfBirth interpreta date_of_birth; debe ser una fecha pasada posterior a 1900
+func (r *UpdateMyProfileRequest) ParsedDateOfBirth() (*time.Time, error) {
+	if r.DateOfBirth == nil || *r.DateOfBirth == "" {
+		return nil, nil
+	}
+
+	dob, err := time.Parse(DateOfBirthLayout, *r.DateOfBirth)
+	if err != nil {
+		return nil, errors.NewValidationError("date_of_birth must use the YYYY-MM-DD format")
+	}
+	if dob.Year() < 1900 || !dob.Before(time.Now()) {
+		return nil, errors.NewValidationError("date_of_birth must be a past date after 1900")
+	}
+	return &dob, nil
+}
+
+// isPhone verifica que el teléfono solo tenga dígitos y separadores habituales
+func isPhone(phone string) bool {
+	digits := 0
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')':
+		default:
+			return false
+		}
+	}
+	return digits >= 7
+}
+
+// NotificationPreferencesResponse representa las preferencias de notificación
+type NotificationPreferencesResponse struct {
+	Email  bool   `json:"email"`
+	SMS    bool   `json:"sms"`
+	Push   bool   `json:"push"`
+	Digest string `json:"digest"`
+}
+
+// UserProfileResponse representa el perfil y las preferencias de un usuario
+type UserProfileResponse struct {
+	Phone                   *string                         `json:"phone,omitempty"`
+	PreferredLanguage       string                          `json:"preferred_language"`
+	Timezone                string                          `json:"timezone"`
+	DateOfBirth             *string                         `json:"date_of_birth,omitempty"`
+	AvatarURL               *string                         `json:"avatar_url,omitempty"`
+	NotificationPreferences NotificationPreferencesResponse `json:"notification_preferences"`
+}
+
+// MeResponse representa al usuario autenticado junto con su perfil
+type MeResponse struct {
+	*UserResponse
+	Profile *UserProfileResponse `json:"profile"`
+}
+
+// ToUserProfileResponse convierte un perfil del dominio a DTO de respuesta
+func ToUserProfileResponse(profile *repository.UserProfile) *UserProfileResponse {
+	response := &UserProfileResponse{
+		Phone:             profile.Phone,
+		PreferredLanguage: profile.PreferredLanguage,
+		Timezone:          profile.Timezone,
+		AvatarURL:         profile.AvatarURL,
+		NotificationPreferences: NotificationPreferencesResponse{
+			Email:  profile.NotificationPreferences.Email,
+			SMS:    profile.NotificationPreferences.SMS,
+			Push:   profile.NotificationPreferences.Push,
+			Digest: profile.NotificationPreferences.Digest,
+		},
+	}
+	if profile.DateOfBirth != nil {
+		dob := profile.DateOfBirth.Format(DateOfBirthLayout)
+		response.DateOfBirth = &dob
+	}
+	return response
+}
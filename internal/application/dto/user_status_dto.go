@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/validator"
+)
+
+// DeactivateUserRequest representa la solicitud para desactivar un usuario
+type DeactivateUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Validate valida el request
+func (r *DeactivateUserRequest) Validate() error {
+	v := validator.New()
+
+	v.Required(r.Reason, "reason")
+	v.MinLength(r.Reason, 3, "reason")
+	v.MaxLength(r.Reason, 500, "reason")
+
+	return v.GetError()
+}
+
+// ReactivateUserRequest representa la solicitud para reactivar un usuario
+type ReactivateUserRequest struct {
+	Reason                   string `json:"reason"`
+	RestoreMemberships       bool   `json:"restore_memberships"`
+	RestoreGuardianRelations bool   `json:"restore_guardian_relations"`
+}
+
+// Validate valida el request
+func (r *ReactivateUserRequest) Validate() error {
+	v := validator.New()
+
+	v.MaxLength(r.Reason, 500, "reason")
+
+	return v.GetError()
+}
+
+// UserStatusChangeResponse representa un cambio de estado de usuario
+type UserStatusChangeResponse struct {
+	ID                  string    `json:"id"`
+	UserID              string    `json:"user_id"`
+	Action              string    `json:"action"`
+	Reason              string    `json:"reason,omitempty"`
+	PerformedBy         string    `json:"performed_by"`
+	MembershipIDs       []string  `json:"membership_ids"`
+	GuardianRelationIDs []string  `json:"guardian_relation_ids"`
+	TokensRevoked       bool      `json:"tokens_revoked,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// ToUserStatusChangeResponse convierte un cambio de estado a DTO de respuesta
+func ToUserStatusChangeResponse(change *repository.UserStatusChange) *UserStatusChangeResponse {
+	membershipIDs := make([]string, len(change.MembershipIDs))
+	for i, id := range change.MembershipIDs {
+		membershipIDs[i] = id.String()
+	}
+
+	relationIDs := make([]string, len(change.GuardianRelationIDs))
+	for i, id := range change.GuardianRelationIDs {
+		relationIDs[i] = id.String()
+	}
+
+	return &UserStatusChangeResponse{
+		ID:                  change.ID.String(),
+		UserID:              change.UserID.String(),
+		Action:              change.Action,
+		Reason:              change.Reason,
+		PerformedBy:         change.PerformedBy,
+		MembershipIDs:       membershipIDs,
+		GuardianRelationIDs: relationIDs,
+		CreatedAt:           change.CreatedAt,
+	}
+}
@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// AcademicYearFilterAll desactiva el filtro por año lectivo en los listados
+const AcademicYearFilterAll = "all"
+
+// AcademicPeriodService administra los años lectivos de cada escuela y sus períodos.
+// Un período pasa de planned a active y de active a closed; un período cerrado es de solo lectura,
+// al igual que las unidades del año y sus membresías.
+type AcademicPeriodService interface {
+	// ListPeriods lista los años lectivos de la escuela con sus períodos
+	ListPeriods(ctx context.Context, schoolID string) ([]dto.AcademicPeriodResponse, error)
+
+	// GetCurrentPeriod obtiene el año lectivo activo de la escuela con sus períodos
+	GetCurrentPeriod(ctx context.Context, schoolID string) (*dto.AcademicPeriodResponse, error)
+
+	// GetPeriod obtiene un año lectivo o período
+	GetPeriod(ctx context.Context, id string) (*dto.AcademicPeriodResponse, error)
+
+	// CreateYear crea un año lectivo en estado planned
+	CreateYear(ctx context.Context, schoolID string, req dto.CreateAcademicYearRequest) (*dto.AcademicPeriodResponse, error)
+
+	// CreateTerm crea un período dentro de un año lectivo
+	CreateTerm(ctx context.Context, yearID string, req dto.CreateAcademicTermRequest) (*dto.AcademicPeriodResponse, error)
+
+	// UpdatePeriod modifica nombre o fechas de un período que no esté cerrado
+	UpdatePeriod(ctx context.Context, id string, req dto.UpdateAcademicPeriodRequest) (*dto.AcademicPeriodResponse, error)
+
+	// ActivatePeriod activa un período planificado
+	ActivatePeriod(ctx context.Context, id string) (*dto.AcademicPeriodResponse, error)
+
+	// ClosePeriod cierra un período activo; cerrar un año cierra también sus períodos
+	ClosePeriod(ctx context.Context, id string, closedBy string) (*dto.AcademicPeriodResponse, error)
+}
+
+type academicPeriodService struct {
+	periodRepo repository.AcademicPeriodRepository
+	schoolRepo repository.SchoolRepository
+	txManager  repository.TransactionManager
+	logger     logger.Logger
+}
+
+// NewAcademicPeriodService crea un nuevo AcademicPeriodService
+func NewAcademicPeriodService(
+	periodRepo repository.AcademicPeriodRepository,
+	schoolRepo repository.SchoolRepository,
+	txManager repository.TransactionManager,
+	logger logger.Logger,
+) AcademicPeriodService {
+	return &academicPeriodService{
+		periodRepo: periodRepo,
+		schoolRepo: schoolRepo,
+		txManager:  txManager,
+		logger:     logger,
+	}
+}
+
+func (s *academicPeriodService) ListPeriods(ctx context.Context, schoolID string) ([]dto.AcademicPeriodResponse, error) {
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+
+	periods, err := s.periodRepo.ListBySchool(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list academic periods", err)
+	}
+
+	terms := map[uuid.UUID][]dto.AcademicPeriodResponse{}
+	for _, period := range periods {
+		if period.ParentID != nil {
+			terms[*period.ParentID] = append(terms[*period.ParentID], dto.ToAcademicPeriodResponse(period))
+		}
+	}
+	responses := []dto.AcademicPeriodResponse{}
+	for _, period := range periods {
+		if period.Type == repository.AcademicPeriodYear {
+			resp := dto.ToAcademicPeriodResponse(period)
+			resp.Terms = terms[period.ID]
+			responses = append(responses, resp)
+		}
+	}
+	return responses, nil
+}
+
+func (s *academicPeriodService) GetCurrentPeriod(ctx context.Context, schoolID string) (*dto.AcademicPeriodResponse, error) {
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+
+	year, err := s.periodRepo.FindActiveYear(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find active academic year", err)
+	}
+	if year == nil {
+		return nil, errors.NewNotFoundError("active academic year")
+	}
+	return s.toResponseWithTerms(ctx, year)
+}
+
+func (s *academicPeriodService) GetPeriod(ctx context.Context, id string) (*dto.AcademicPeriodResponse, error) {
+	period, err := s.findPeriod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponseWithTerms(ctx, period)
+}
+
+func (s *academicPeriodService) CreateYear(ctx context.Context, schoolID string, req dto.CreateAcademicYearRequest) (*dto.AcademicPeriodResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	startDate, endDate, _ := dto.ParsePeriodRange(req.StartDate, req.EndDate)
+
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewNotFoundError("school")
+		}
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+
+	existing, err := s.periodRepo.FindYear(ctx, school.ID, req.Year)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find academic year", err)
+	}
+	if existing != nil {
+		return nil, errors.NewAlreadyExistsError("academic year").WithField("year", strconv.Itoa(req.Year))
+	}
+
+	now := time.Now()
+	year := &repository.AcademicPeriod{
+		ID:        uuid.New(),
+		SchoolID:  school.ID,
+		Type:      repository.AcademicPeriodYear,
+		Name:      strings.TrimSpace(req.Name),
+		Year:      req.Year,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Status:    repository.AcademicPeriodPlanned,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.checkOverlap(ctx, year); err != nil {
+		return nil, err
+	}
+	if err := s.periodRepo.Create(ctx, year); err != nil {
+		return nil, errors.NewDatabaseError("create academic year", err)
+	}
+
+	s.logger.Info("entity created",
+		"entity_type", "academic_year",
+		"entity_id", year.ID.String(),
+		"school_id", school.ID.String(),
+		"year", year.Year,
+	)
+
+	resp := dto.ToAcademicPeriodResponse(year)
+	return &resp, nil
+}
+
+func (s *academicPeriodService) CreateTerm(ctx context.Context, yearID string, req dto.CreateAcademicTermRequest) (*dto.AcademicPeriodResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	startDate, endDate, _ := dto.ParsePeriodRange(req.StartDate, req.EndDate)
+
+	year, err := s.findPeriod(ctx, yearID)
+	if err != nil {
+		return nil, err
+	}
+	if year.Type != repository.AcademicPeriodYear {
+		return nil, errors.NewValidationError("terms can only be created inside an academic year")
+	}
+	if year.IsClosed() {
+		return nil, errors.NewBusinessRuleError(fmt.Sprintf("academic year %d is closed", year.Year))
+	}
+
+	now := time.Now()
+	term := &repository.AcademicPeriod{
+		ID:        uuid.New(),
+		SchoolID:  year.SchoolID,
+		ParentID:  &year.ID,
+		Type:      repository.AcademicPeriodTerm,
+		Name:      strings.TrimSpace(req.Name),
+		Year:      year.Year,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Status:    repository.AcademicPeriodPlanned,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.checkOverlap(ctx, term); err != nil {
+		return nil, err
+	}
+	if err := s.periodRepo.Create(ctx, term); err != nil {
+		return nil, errors.NewDatabaseError("create academic term", err)
+	}
+
+	s.logger.Info("entity created",
+		"entity_type", "academic_term",
+		"entity_id", term.ID.String(),
+		"year_id", year.ID.String(),
+	)
+
+	resp := dto.ToAcademicPeriodResponse(term)
+	return &resp, nil
+}
+
+func (s *academicPeriodService) UpdatePeriod(ctx context.Context, id string, req dto.UpdateAcademicPeriodRequest) (*dto.AcademicPeriodResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	period, err := s.findPeriod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if period.IsClosed() {
+		return nil, errors.NewBusinessRuleError("closed periods are read-only")
+	}
+
+	if req.Name != nil {
+		period.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.StartDate != nil || req.EndDate != nil {
+		start, end := period.StartDate.Format(dto.PeriodDateLayout), period.EndDate.Format(dto.PeriodDateLayout)
+		if req.StartDate != nil {
+			start = *req.StartDate
+		}
+		if req.EndDate != nil {
+			end = *req.EndDate
+		}
+		period.StartDate, period.EndDate, err = dto.ParsePeriodRange(start, end)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkOverlap(ctx, period); err != nil {
+			return nil, err
+		}
+	}
+
+	period.UpdatedAt = time.Now()
+	if err := s.periodRepo.Update(ctx, period); err != nil {
+		return nil, errors.NewDatabaseError("update academic period", err)
+	}
+	return s.toResponseWithTerms(ctx, period)
+}
+
+func (s *academicPeriodService) ActivatePeriod(ctx context.Context, id string) (*dto.AcademicPeriodResponse, error) {
+	period, err := s.findPeriod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if period.Status != repository.AcademicPeriodPlanned {
+		return nil, errors.NewBusinessRuleError(fmt.Sprintf("only planned periods can be activated, period is %s", period.Status))
+	}
+
+	if period.Type == repository.AcademicPeriodYear {
+		active, err := s.periodRepo.FindActiveYear(ctx, period.SchoolID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find active academic year", err)
+		}
+		if active != nil {
+			return nil, errors.NewBusinessRuleError(fmt.Sprintf("academic year %d is still active, close it first", active.Year))
+		}
+	} else {
+		year, err := s.periodRepo.FindByID(ctx, *period.ParentID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find academic year", err)
+		}
+		if year == nil || year.Status != repository.AcademicPeriodActive {
+			return nil, errors.NewBusinessRuleError("the academic year must be active before activating one of its terms")
+		}
+		siblings, err := s.periodRepo.ListTerms(ctx, year.ID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("list academic terms", err)
+		}
+		for _, sibling := range siblings {
+			if sibling.Status == repository.AcademicPeriodActive {
+				return nil, errors.NewBusinessRuleError(fmt.Sprintf("term %q is still active, close it first", sibling.Name))
+			}
+		}
+	}
+
+	period.Status = repository.AcademicPeriodActive
+	period.UpdatedAt = time.Now()
+	if err := s.periodRepo.Update(ctx, period); err != nil {
+		return nil, errors.NewDatabaseError("activate academic period", err)
+	}
+
+	s.logger.Info("academic period activated",
+		"period_id", period.ID.String(),
+		"type", period.Type,
+		"school_id", period.SchoolID.String(),
+	)
+	return s.toResponseWithTerms(ctx, period)
+}
+
+func (s *academicPeriodService) ClosePeriod(ctx context.Context, id string, closedBy string) (*dto.AcademicPeriodResponse, error) {
+	period, err := s.findPeriod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if period.Status != repository.AcademicPeriodActive {
+		return nil, errors.NewBusinessRuleError(fmt.Sprintf("only active periods can be closed, period is %s", period.Status))
+	}
+
+	now := time.Now()
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if period.Type == repository.AcademicPeriodYear {
+			terms, err := s.periodRepo.ListTerms(ctx, period.ID)
+			if err != nil {
+				return errors.NewDatabaseError("list academic terms", err)
+			}
+			for _, term := range terms {
+				if term.IsClosed() {
+					continue
+				}
+				closeAcademicPeriod(term, closedBy, now)
+				if err := s.periodRepo.Update(ctx, term); err != nil {
+					return errors.NewDatabaseError("close academic term", err)
+				}
+			}
+		}
+		closeAcademicPeriod(period, closedBy, now)
+		if err := s.periodRepo.Update(ctx, period); err != nil {
+			return errors.NewDatabaseError("close academic period", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("academic period closed",
+		"period_id", period.ID.String(),
+		"type", period.Type,
+		"school_id", period.SchoolID.String(),
+		"closed_by", closedBy,
+	)
+	return s.toResponseWithTerms(ctx, period)
+}
+
+func (s *academicPeriodService) findPeriod(ctx context.Context, id string) (*repository.AcademicPeriod, error) {
+	periodID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid period ID")
+	}
+	period, err := s.periodRepo.FindByID(ctx, periodID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find academic period", err)
+	}
+	if period == nil {
+		return nil, errors.NewNotFoundError("academic period")
+	}
+	return period, nil
+}
+
+// checkOverlap valida que un año no se cruce con otros años de la escuela y que un período
+// quede dentro de su año sin cruzarse con los demás períodos
+func (s *academicPeriodService) checkOverlap(ctx context.Context, period *repository.AcademicPeriod) error {
+	var siblings []*repository.AcademicPeriod
+	if period.Type == repository.AcademicPeriodYear {
+		periods, err := s.periodRepo.ListBySchool(ctx, period.SchoolID)
+		if err != nil {
+			return errors.NewDatabaseError("list academic periods", err)
+		}
+		for _, other := range periods {
+			switch {
+			case other.Type == repository.AcademicPeriodYear:
+				siblings = append(siblings, other)
+			case other.ParentID != nil && *other.ParentID == period.ID:
+				if other.StartDate.Before(period.StartDate) || other.EndDate.After(period.EndDate) {
+					return errors.NewBusinessRuleError(fmt.Sprintf("term %q would fall outside the academic year", other.Name))
+				}
+			}
+		}
+	} else {
+		year, err := s.periodRepo.FindByID(ctx, *period.ParentID)
+		if err != nil {
+			return errors.NewDatabaseError("find academic year", err)
+		}
+		if year == nil {
+			return errors.NewNotFoundError("academic year")
+		}
+		if period.StartDate.Before(year.StartDate) || period.EndDate.After(year.EndDate) {
+			return errors.NewBusinessRuleError("term dates must be within the academic year")
+		}
+		siblings, err = s.periodRepo.ListTerms(ctx, year.ID)
+		if err != nil {
+			return errors.NewDatabaseError("list academic terms", err)
+		}
+	}
+
+	for _, other := range siblings {
+		if other.ID != period.ID && other.Overlaps(period) {
+			return errors.NewBusinessRuleError(fmt.Sprintf("dates overlap with %q", other.Name))
+		}
+	}
+	return nil
+}
+
+func (s *academicPeriodService) toResponseWithTerms(ctx context.Context, period *repository.AcademicPeriod) (*dto.AcademicPeriodResponse, error) {
+	resp := dto.ToAcademicPeriodResponse(period)
+	if period.Type != repository.AcademicPeriodYear {
+		return &resp, nil
+	}
+	terms, err := s.periodRepo.ListTerms(ctx, period.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list academic terms", err)
+	}
+	for _, term := range terms {
+		resp.Terms = append(resp.Terms, dto.ToAcademicPeriodResponse(term))
+	}
+	return &resp, nil
+}
+
+func closeAcademicPeriod(period *repository.AcademicPeriod, closedBy string, now time.Time) {
+	period.Status = repository.AcademicPeriodClosed
+	period.ClosedBy = closedBy
+	period.ClosedAt = &now
+	period.UpdatedAt = now
+}
+
+// resolveAcademicYear obtiene el año lectivo de una unidad nueva: el solicitado (que debe existir
+// y no estar cerrado) o el activo de la escuela. Retorna 0 si la escuela no tiene años definidos.
+func resolveAcademicYear(ctx context.Context, periodRepo repository.AcademicPeriodRepository, schoolID uuid.UUID, requested *int) (int, error) {
+	if requested == nil {
+		active, err := periodRepo.FindActiveYear(ctx, schoolID)
+		if err != nil {
+			return 0, errors.NewDatabaseError("find active academic year", err)
+		}
+		if active == nil {
+			return 0, nil
+		}
+		return active.Year, nil
+	}
+
+	year, err := periodRepo.FindYear(ctx, schoolID, *requested)
+	if err != nil {
+		return 0, errors.NewDatabaseError("find academic year", err)
+	}
+	if year == nil {
+		return 0, errors.NewValidationError("academic year does not exist").WithField("academic_year", strconv.Itoa(*requested))
+	}
+	if year.IsClosed() {
+		return 0, errors.NewBusinessRuleError(fmt.Sprintf("academic year %d is closed", year.Year))
+	}
+	return year.Year, nil
+}
+
+// ensureAcademicYearWritable rechaza cambios sobre datos de un año lectivo cerrado.
+// Las unidades sin año (0) no pertenecen a ningún período y siempre son editables.
+func ensureAcademicYearWritable(ctx context.Context, periodRepo repository.AcademicPeriodRepository, schoolID uuid.UUID, academicYear int) error {
+	if academicYear == 0 {
+		return nil
+	}
+	year, err := periodRepo.FindYear(ctx, schoolID, academicYear)
+	if err != nil {
+		return errors.NewDatabaseError("find academic year", err)
+	}
+	if year != nil && year.IsClosed() {
+		return errors.NewBusinessRuleError(fmt.Sprintf("academic year %d is closed and read-only", academicYear))
+	}
+	return nil
+}
+
+// resolveAcademicYearFilter interpreta el filtro academic_year de los listados: vacío usa el año
+// activo, "all" no filtra y un número filtra por ese año. Retorna 0 cuando no se debe filtrar.
+func resolveAcademicYearFilter(ctx context.Context, periodRepo repository.AcademicPeriodRepository, schoolID uuid.UUID, raw string) (int, error) {
+	switch raw {
+	case AcademicYearFilterAll:
+		return 0, nil
+	case "":
+		active, err := periodRepo.FindActiveYear(ctx, schoolID)
+		if err != nil {
+			return 0, errors.NewDatabaseError("find active academic year", err)
+		}
+		if active == nil {
+			return 0, nil
+		}
+		return active.Year, nil
+	}
+	year, err := strconv.Atoi(raw)
+	if err != nil || year <= 0 {
+		return 0, errors.NewValidationError("academic_year must be a year or \"all\"")
+	}
+	return year, nil
+}
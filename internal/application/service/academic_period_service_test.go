@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockAcademicPeriodRepository mock implementation
+type MockAcademicPeriodRepository struct {
+	mock.Mock
+}
+
+func (m *MockAcademicPeriodRepository) Create(ctx context.Context, period *repository.AcademicPeriod) error {
+	args := m.Called(ctx, period)
+	return args.Error(0)
+}
+
+func (m *MockAcademicPeriodRepository) Update(ctx context.Context, period *repository.AcademicPeriod) error {
+	args := m.Called(ctx, period)
+	return args.Error(0)
+}
+
+func (m *MockAcademicPeriodRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.AcademicPeriod, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AcademicPeriod), args.Error(1)
+}
+
+func (m *MockAcademicPeriodRepository) FindYear(ctx context.Context, schoolID uuid.UUID, year int) (*repository.AcademicPeriod, error) {
+	args := m.Called(ctx, schoolID, year)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AcademicPeriod), args.Error(1)
+}
+
+func (m *MockAcademicPeriodRepository) FindActiveYear(ctx context.Context, schoolID uuid.UUID) (*repository.AcademicPeriod, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AcademicPeriod), args.Error(1)
+}
+
+func (m *MockAcademicPeriodRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*repository.AcademicPeriod, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.AcademicPeriod), args.Error(1)
+}
+
+func (m *MockAcademicPeriodRepository) ListTerms(ctx context.Context, yearID uuid.UUID) ([]*repository.AcademicPeriod, error) {
+	args := m.Called(ctx, yearID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.AcademicPeriod), args.Error(1)
+}
+
+func testAcademicYear(schoolID uuid.UUID, year int, status string) *repository.AcademicPeriod {
+	return &repository.AcademicPeriod{
+		ID:        uuid.New(),
+		SchoolID:  schoolID,
+		Type:      repository.AcademicPeriodYear,
+		Name:      "Año lectivo",
+		Year:      year,
+		StartDate: time.Date(year, 1, 15, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(year, 11, 30, 0, 0, 0, 0, time.UTC),
+		Status:    status,
+	}
+}
+
+func TestCreateAcademicYear_RejectsOverlap(t *testing.T) {
+	periodRepo := new(MockAcademicPeriodRepository)
+	schoolRepo := new(MockSchoolRepository)
+	svc := NewAcademicPeriodService(periodRepo, schoolRepo, passthroughTxManager{}, newTestLogger())
+
+	school := &entities.School{ID: uuid.New()}
+	existing := testAcademicYear(school.ID, 2026, repository.AcademicPeriodActive)
+	schoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	periodRepo.On("FindYear", mock.Anything, school.ID, 2027).Return(nil, nil)
+	periodRepo.On("ListBySchool", mock.Anything, school.ID).Return([]*repository.AcademicPeriod{existing}, nil)
+
+	_, err := svc.CreateYear(context.Background(), school.ID.String(), dto.CreateAcademicYearRequest{
+		Name: "2027", Year: 2027, StartDate: "2026-11-01", EndDate: "2027-11-30",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "overlap")
+
+	periodRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *repository.AcademicPeriod) bool {
+		return p.Year == 2027 && p.Status == repository.AcademicPeriodPlanned
+	})).Return(nil)
+	result, err := svc.CreateYear(context.Background(), school.ID.String(), dto.CreateAcademicYearRequest{
+		Name: "2027", Year: 2027, StartDate: "2027-01-15", EndDate: "2027-11-30",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2027-01-15", result.StartDate)
+	periodRepo.AssertExpectations(t)
+}
+
+func TestActivateAcademicYear_RequiresPreviousClosed(t *testing.T) {
+	periodRepo := new(MockAcademicPeriodRepository)
+	svc := NewAcademicPeriodService(periodRepo, new(MockSchoolRepository), passthroughTxManager{}, newTestLogger())
+
+	schoolID := uuid.New()
+	planned := testAcademicYear(schoolID, 2027, repository.AcademicPeriodPlanned)
+	active := testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive)
+	periodRepo.On("FindByID", mock.Anything, planned.ID).Return(planned, nil)
+	periodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(active, nil)
+
+	_, err := svc.ActivatePeriod(context.Background(), planned.ID.String())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2026")
+	periodRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCloseAcademicYear_ClosesTerms(t *testing.T) {
+	periodRepo := new(MockAcademicPeriodRepository)
+	svc := NewAcademicPeriodService(periodRepo, new(MockSchoolRepository), passthroughTxManager{}, newTestLogger())
+
+	year := testAcademicYear(uuid.New(), 2026, repository.AcademicPeriodActive)
+	term := &repository.AcademicPeriod{ID: uuid.New(), SchoolID: year.SchoolID, ParentID: &year.ID, Type: repository.AcademicPeriodTerm,
+		Name: "Primer trimestre", Year: 2026, StartDate: year.StartDate, EndDate: year.StartDate.AddDate(0, 3, 0), Status: repository.AcademicPeriodActive}
+	periodRepo.On("FindByID", mock.Anything, year.ID).Return(year, nil)
+	periodRepo.On("ListTerms", mock.Anything, year.ID).Return([]*repository.AcademicPeriod{term}, nil)
+	periodRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	result, err := svc.ClosePeriod(context.Background(), year.ID.String(), "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, repository.AcademicPeriodClosed, result.Status)
+	assert.Equal(t, repository.AcademicPeriodClosed, term.Status)
+	assert.Equal(t, "admin-1", term.ClosedBy)
+	periodRepo.AssertExpectations(t)
+}
+
+func TestCreateUnit_DefaultsToActiveAcademicYear(t *testing.T) {
+	unitRepo := new(MockAcademicUnitRepository)
+	schoolRepo := new(MockSchoolRepository)
+	periodRepo := new(MockAcademicPeriodRepository)
+	svc := NewAcademicUnitService(unitRepo, schoolRepo, periodRepo, new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	school := &entities.School{ID: uuid.New()}
+	schoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	unitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, school.ID, "G1").Return(false, nil)
+	unitRepo.On("LockHierarchy", mock.Anything, school.ID).Return(nil)
+	periodRepo.On("FindActiveYear", mock.Anything, school.ID).Return(testAcademicYear(school.ID, 2026, repository.AcademicPeriodActive), nil)
+	unitRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entities.AcademicUnit) bool {
+		return u.AcademicYear == 2026
+	})).Return(nil)
+
+	result, err := svc.CreateUnit(context.Background(), school.ID.String(), dto.CreateAcademicUnitRequest{
+		Type: "grade", DisplayName: "Primero", Code: "G1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2026, result.AcademicYear)
+	unitRepo.AssertExpectations(t)
+}
+
+func TestUpdateUnit_ClosedAcademicYearIsReadOnly(t *testing.T) {
+	unitRepo := new(MockAcademicUnitRepository)
+	periodRepo := new(MockAcademicPeriodRepository)
+	svc := NewAcademicUnitService(unitRepo, liveSchools(), periodRepo, new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: uuid.New(), Name: "Primero", AcademicYear: 2025}
+	unitRepo.On("FindByID", mock.Anything, unit.ID, false).Return(unit, nil)
+	periodRepo.On("FindYear", mock.Anything, unit.SchoolID, 2025).Return(testAcademicYear(unit.SchoolID, 2025, repository.AcademicPeriodClosed), nil)
+
+	name := "Primero B"
+	_, err := svc.UpdateUnit(context.Background(), unit.ID.String(), dto.UpdateAcademicUnitRequest{DisplayName: &name})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "closed")
+	unitRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestListUnitsBySchool_DefaultsToActiveAcademicYear(t *testing.T) {
+	unitRepo := new(MockAcademicUnitRepository)
+	periodRepo := new(MockAcademicPeriodRepository)
+	svc := NewAcademicUnitService(unitRepo, liveSchools(), periodRepo, new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	schoolID := uuid.New()
+	units := []*entities.AcademicUnit{
+		{ID: uuid.New(), SchoolID: schoolID, Name: "Primero 2025", AcademicYear: 2025},
+		{ID: uuid.New(), SchoolID: schoolID, Name: "Primero 2026", AcademicYear: 2026},
+		{ID: uuid.New(), SchoolID: schoolID, Name: "Departamento", AcademicYear: 0},
+	}
+	periodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	unitRepo.On("FindBySchoolID", mock.Anything, schoolID, false).Return(units, nil)
+
+	current, err := svc.ListUnitsBySchool(context.Background(), schoolID.String(), false, "")
+	require.NoError(t, err)
+	require.Len(t, current, 2)
+	assert.Equal(t, "Primero 2026", current[0].DisplayName)
+
+	past, err := svc.ListUnitsBySchool(context.Background(), schoolID.String(), false, "2025")
+	require.NoError(t, err)
+	assert.Len(t, past, 2)
+
+	all, err := svc.ListUnitsBySchool(context.Background(), schoolID.String(), false, AcademicYearFilterAll)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+}
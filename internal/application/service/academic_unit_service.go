@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
@@ -13,33 +16,70 @@ import (
 	"github.com/google/uuid"
 )
 
+// errUnitRestoreDryRun revierte la transacción de la vista previa de una restauración
+var errUnitRestoreDryRun = stderrors.New("unit restore dry run")
+
 type AcademicUnitService interface {
 	CreateUnit(ctx context.Context, schoolID string, req dto.CreateAcademicUnitRequest) (*dto.AcademicUnitResponse, error)
 	GetUnit(ctx context.Context, id string) (*dto.AcademicUnitResponse, error)
-	GetUnitTree(ctx context.Context, schoolID string) ([]*dto.UnitTreeNode, error)
-	ListUnitsBySchool(ctx context.Context, schoolID string, includeDeleted bool) ([]dto.AcademicUnitResponse, error)
-	ListUnitsByType(ctx context.Context, schoolID string, unitType string) ([]dto.AcademicUnitResponse, error)
+	// Los listados reciben el filtro academic_year: vacío usa el año activo, "all" no filtra
+	GetUnitTree(ctx context.Context, schoolID string, academicYear string) ([]*dto.UnitTreeNode, error)
+	ListUnitsBySchool(ctx context.Context, schoolID string, includeDeleted bool, academicYear string) ([]dto.AcademicUnitResponse, error)
+	ListUnitsByType(ctx context.Context, schoolID string, unitType string, academicYear string) ([]dto.AcademicUnitResponse, error)
 	UpdateUnit(ctx context.Context, id string, req dto.UpdateAcademicUnitRequest) (*dto.AcademicUnitResponse, error)
-	DeleteUnit(ctx context.Context, id string) error
-	RestoreUnit(ctx context.Context, id string) error
+	// BulkCreateUnits crea en una transacción el árbol de unidades que describe el generador
+	BulkCreateUnits(ctx context.Context, schoolID string, req dto.BulkCreateUnitsRequest) (*dto.BulkCreateUnitsResponse, error)
+	// MoveUnit cambia el padre de una unidad; su subárbol se mueve con ella
+	MoveUnit(ctx context.Context, id string, req dto.MoveAcademicUnitRequest) (*dto.AcademicUnitResponse, error)
+	// DeleteUnit elimina la unidad con su subárbol y suspende sus membresías en un batch;
+	// con dryRun solo informa qué se afectaría
+	DeleteUnit(ctx context.Context, id string, dryRun bool, deletedBy string) (*dto.UnitDeletionResponse, error)
+	// RestoreUnit revierte el batch que eliminó la unidad; con dryRun solo informa qué se restauraría
+	RestoreUnit(ctx context.Context, id string, dryRun bool, restoredBy string) (*dto.UnitDeletionResponse, error)
 	GetHierarchyPath(ctx context.Context, id string) ([]dto.AcademicUnitResponse, error)
+	// ListDescendants lista el subárbol de una unidad hasta maxDepth niveles (0 = sin límite)
+	ListDescendants(ctx context.Context, id string, maxDepth int, includeDeleted bool) (*dto.UnitDescendantsResponse, error)
 }
 
 type academicUnitService struct {
-	unitRepo   repository.AcademicUnitRepository
-	schoolRepo repository.SchoolRepository
-	logger     logger.Logger
+	unitRepo        repository.AcademicUnitRepository
+	schoolRepo      repository.SchoolRepository
+	periodRepo      repository.AcademicPeriodRepository
+	membershipRepo  repository.UnitMembershipRepository
+	deletionRepo    repository.UnitDeletionRepository
+	unitTypeRepo    repository.SchoolUnitTypeRepository
+	settingsService SchoolSettingsService
+	capacityService UnitCapacityService
+	quotaService    SchoolQuotaService
+	txManager       repository.TransactionManager
+	logger          logger.Logger
 }
 
 func NewAcademicUnitService(
 	unitRepo repository.AcademicUnitRepository,
 	schoolRepo repository.SchoolRepository,
+	periodRepo repository.AcademicPeriodRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	deletionRepo repository.UnitDeletionRepository,
+	unitTypeRepo repository.SchoolUnitTypeRepository,
+	settingsService SchoolSettingsService,
+	capacityService UnitCapacityService,
+	quotaService SchoolQuotaService,
+	txManager repository.TransactionManager,
 	logger logger.Logger,
 ) AcademicUnitService {
 	return &academicUnitService{
-		unitRepo:   unitRepo,
-		schoolRepo: schoolRepo,
-		logger:     logger,
+		unitRepo:        unitRepo,
+		schoolRepo:      schoolRepo,
+		periodRepo:      periodRepo,
+		membershipRepo:  membershipRepo,
+		deletionRepo:    deletionRepo,
+		unitTypeRepo:    unitTypeRepo,
+		settingsService: settingsService,
+		capacityService: capacityService,
+		quotaService:    quotaService,
+		txManager:       txManager,
+		logger:          logger,
 	}
 }
 
@@ -50,13 +90,9 @@ func (s *academicUnitService) CreateUnit(ctx context.Context, schoolID string, r
 		return nil, errors.NewValidationError("invalid school ID")
 	}
 
-	// Verificar escuela existe
-	school, err := s.schoolRepo.FindByID(ctx, schoolUUID)
-	if err != nil {
-		return nil, errors.NewDatabaseError("find school", err)
-	}
-	if school == nil {
-		return nil, errors.NewNotFoundError("school")
+	// Verificar escuela existe y no está archivada
+	if err := ensureSchoolWritable(ctx, s.schoolRepo, schoolUUID); err != nil {
+		return nil, err
 	}
 
 	// Verificar código único
@@ -77,18 +113,16 @@ func (s *academicUnitService) CreateUnit(ctx context.Context, schoolID string, r
 		if err != nil {
 			return nil, errors.NewValidationError("invalid parent_unit_id")
 		}
-		parent, err := s.unitRepo.FindByID(ctx, pid, false)
-		if err != nil {
-			return nil, errors.NewDatabaseError("find parent unit", err)
-		}
-		if parent == nil {
-			return nil, errors.NewNotFoundError("parent unit")
-		}
 		parentUUID = &pid
 	}
 
-	// Validar tipo de unidad usando value object
-	if _, err := valueobject.ParseUnitType(req.Type); err != nil {
+	// Validar tipo de unidad contra los tipos de la escuela y sus reglas de anidamiento
+	registry, rules, err := s.unitTypeRules(ctx, schoolUUID)
+	if err != nil {
+		return nil, err
+	}
+	unitType, err := registry.Parse(req.Type)
+	if err != nil {
 		return nil, errors.NewValidationError(err.Error())
 	}
 
@@ -100,27 +134,75 @@ func (s *academicUnitService) CreateUnit(ctx context.Context, schoolID string, r
 		return nil, errors.NewValidationError("display_name must be at least 3 characters")
 	}
 
-	now := time.Now()
-	unit := &entities.AcademicUnit{
-		ID:           uuid.New(),
-		ParentUnitID: parentUUID,
-		SchoolID:     schoolUUID,
-		Name:         req.DisplayName,
-		Code:         req.Code,
-		Type:         req.Type,
-		Description:  &req.Description,
-		Level:        nil, // TODO: agregar si se necesita
-		AcademicYear: 0,   // TODO: agregar si se necesita
-		Metadata:     []byte("{}"),
-		IsActive:     true,
-		CreatedAt:    now,
-		UpdatedAt:    now,
-		DeletedAt:    nil,
-	}
-
-	// Persistir
-	if err := s.unitRepo.Create(ctx, unit); err != nil {
-		return nil, errors.NewDatabaseError("create unit", err)
+	var unit *entities.AcademicUnit
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Con el lock tomado, un movimiento concurrente no puede cambiar la profundidad del padre
+		if err := s.unitRepo.LockHierarchy(ctx, schoolUUID); err != nil {
+			return errors.NewDatabaseError("lock unit hierarchy", err)
+		}
+
+		var parent *entities.AcademicUnit
+		if parentUUID != nil {
+			parent, err = s.unitRepo.FindByID(ctx, *parentUUID, false)
+			if err != nil {
+				if isNotFoundError(err) {
+					return errors.NewNotFoundError("parent unit")
+				}
+				return errors.NewDatabaseError("find parent unit", err)
+			}
+			if parent == nil {
+				return errors.NewNotFoundError("parent unit")
+			}
+			if parent.SchoolID != schoolUUID {
+				return errors.NewBusinessRuleError("parent unit belongs to another school")
+			}
+		}
+		if err := s.checkUnitNesting(ctx, rules, unitType, parent, 0); err != nil {
+			return err
+		}
+
+		// Año lectivo: el solicitado, el del padre o el activo de la escuela
+		requestedYear := req.AcademicYear
+		if requestedYear == nil && parent != nil && parent.AcademicYear != 0 {
+			requestedYear = &parent.AcademicYear
+		}
+		academicYear, err := resolveAcademicYear(ctx, s.periodRepo, schoolUUID, requestedYear)
+		if err != nil {
+			return err
+		}
+		if parent != nil && parent.AcademicYear != 0 && parent.AcademicYear != academicYear {
+			return errors.NewBusinessRuleError("unit must belong to the same academic year as its parent")
+		}
+
+		now := time.Now()
+		unit = &entities.AcademicUnit{
+			ID:           uuid.New(),
+			ParentUnitID: parentUUID,
+			SchoolID:     schoolUUID,
+			Name:         req.DisplayName,
+			Code:         req.Code,
+			Type:         req.Type,
+			Description:  &req.Description,
+			Level:        nil, // TODO: agregar si se necesita
+			AcademicYear: academicYear,
+			Metadata:     []byte("{}"),
+			IsActive:     true,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+			DeletedAt:    nil,
+		}
+
+		if err := s.quotaService.CheckUnitQuota(ctx, schoolUUID, 1); err != nil {
+			return err
+		}
+		// Persistir junto con el cupo de estudiantes
+		if err := s.unitRepo.Create(ctx, unit); err != nil {
+			return errors.NewDatabaseError("create unit", err)
+		}
+		return s.capacityService.ConfigureCapacity(ctx, schoolUUID, unit.ID, req.MaxStudents, req.WaitlistEnabled)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	s.logger.Info("entity created",
@@ -131,8 +213,7 @@ func (s *academicUnitService) CreateUnit(ctx context.Context, schoolID string, r
 		"school_id", schoolUUID.String(),
 	)
 
-	response := dto.ToAcademicUnitResponse(unit)
-	return &response, nil
+	return s.unitResponse(ctx, unit)
 }
 
 func (s *academicUnitService) GetUnit(ctx context.Context, id string) (*dto.AcademicUnitResponse, error) {
@@ -153,63 +234,73 @@ func (s *academicUnitService) GetUnit(ctx context.Context, id string) (*dto.Acad
 		return nil, errors.NewNotFoundError("academic unit")
 	}
 
-	response := dto.ToAcademicUnitResponse(unit)
-	return &response, nil
+	return s.unitResponse(ctx, unit)
 }
 
-func (s *academicUnitService) GetUnitTree(ctx context.Context, schoolID string) ([]*dto.UnitTreeNode, error) {
+func (s *academicUnitService) GetUnitTree(ctx context.Context, schoolID string, academicYear string) ([]*dto.UnitTreeNode, error) {
 	schoolUUID, err := uuid.Parse(schoolID)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid school ID")
 	}
+	year, err := resolveAcademicYearFilter(ctx, s.periodRepo, schoolUUID, academicYear)
+	if err != nil {
+		return nil, err
+	}
 
 	units, err := s.unitRepo.FindBySchoolID(ctx, schoolUUID, false)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find units", err)
 	}
 
-	return dto.BuildUnitTree(units), nil
+	return dto.BuildUnitTree(filterUnitsByAcademicYear(units, year)), nil
 }
 
-func (s *academicUnitService) ListUnitsBySchool(ctx context.Context, schoolID string, includeDeleted bool) ([]dto.AcademicUnitResponse, error) {
+func (s *academicUnitService) ListUnitsBySchool(ctx context.Context, schoolID string, includeDeleted bool, academicYear string) ([]dto.AcademicUnitResponse, error) {
 	schoolUUID, err := uuid.Parse(schoolID)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid school ID")
 	}
+	year, err := resolveAcademicYearFilter(ctx, s.periodRepo, schoolUUID, academicYear)
+	if err != nil {
+		return nil, err
+	}
 
 	units, err := s.unitRepo.FindBySchoolID(ctx, schoolUUID, includeDeleted)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find units", err)
 	}
+	units = filterUnitsByAcademicYear(units, year)
 
-	responses := make([]dto.AcademicUnitResponse, len(units))
-	for i, unit := range units {
-		responses[i] = dto.ToAcademicUnitResponse(unit)
-	}
-	return responses, nil
+	return s.unitResponses(ctx, units)
 }
 
-func (s *academicUnitService) ListUnitsByType(ctx context.Context, schoolID string, unitType string) ([]dto.AcademicUnitResponse, error) {
+func (s *academicUnitService) ListUnitsByType(ctx context.Context, schoolID string, unitType string, academicYear string) ([]dto.AcademicUnitResponse, error) {
 	schoolUUID, err := uuid.Parse(schoolID)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid school ID")
 	}
 
-	// Validar tipo de unidad usando value object
-	if _, err := valueobject.ParseUnitType(unitType); err != nil {
+	// Validar tipo de unidad contra los tipos de la escuela (predefinidos y propios)
+	custom, err := loadSchoolUnitTypes(ctx, s.unitTypeRepo, schoolUUID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := custom.registry().Parse(unitType); err != nil {
 		return nil, errors.NewValidationError(err.Error())
 	}
 
+	year, err := resolveAcademicYearFilter(ctx, s.periodRepo, schoolUUID, academicYear)
+	if err != nil {
+		return nil, err
+	}
+
 	units, err := s.unitRepo.FindByType(ctx, schoolUUID, unitType, false)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find units", err)
 	}
+	units = filterUnitsByAcademicYear(units, year)
 
-	responses := make([]dto.AcademicUnitResponse, len(units))
-	for i, unit := range units {
-		responses[i] = dto.ToAcademicUnitResponse(unit)
-	}
-	return responses, nil
+	return s.unitResponses(ctx, units)
 }
 
 func (s *academicUnitService) UpdateUnit(ctx context.Context, id string, req dto.UpdateAcademicUnitRequest) (*dto.AcademicUnitResponse, error) {
@@ -230,6 +321,12 @@ func (s *academicUnitService) UpdateUnit(ctx context.Context, id string, req dto
 	if unit == nil {
 		return nil, errors.NewNotFoundError("academic unit")
 	}
+	if err := ensureSchoolWritable(ctx, s.schoolRepo, unit.SchoolID); err != nil {
+		return nil, err
+	}
+	if err := ensureAcademicYearWritable(ctx, s.periodRepo, unit.SchoolID, unit.AcademicYear); err != nil {
+		return nil, err
+	}
 
 	// Actualizar campos (lógica movida del entity)
 	if req.DisplayName != nil {
@@ -243,21 +340,35 @@ func (s *academicUnitService) UpdateUnit(ctx context.Context, id string, req dto
 		unit.Description = req.Description
 	}
 
+	var parentID *uuid.UUID
 	if req.ParentUnitID != nil {
 		pid, err := uuid.Parse(*req.ParentUnitID)
 		if err != nil {
 			return nil, errors.NewValidationError("invalid parent_unit_id")
 		}
-		if pid == unitID {
-			return nil, errors.NewBusinessRuleError("unit cannot be its own parent")
-		}
-		unit.ParentUnitID = &pid
+		parentID = &pid
 	}
 
 	unit.UpdatedAt = time.Now()
 
-	if err := s.unitRepo.Update(ctx, unit); err != nil {
-		return nil, errors.NewDatabaseError("update unit", err)
+	// El cambio de padre pasa por las mismas validaciones que MoveUnit
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if parentID != nil {
+			if err := s.unitRepo.LockHierarchy(ctx, unit.SchoolID); err != nil {
+				return errors.NewDatabaseError("lock unit hierarchy", err)
+			}
+			if err := s.validateUnitParent(ctx, unit, parentID); err != nil {
+				return err
+			}
+			unit.ParentUnitID = parentID
+		}
+		if err := s.unitRepo.Update(ctx, unit); err != nil {
+			return errors.NewDatabaseError("update unit", err)
+		}
+		return s.capacityService.ConfigureCapacity(ctx, unit.SchoolID, unit.ID, req.MaxStudents, req.WaitlistEnabled)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	updatedFields := []string{}
@@ -270,6 +381,12 @@ func (s *academicUnitService) UpdateUnit(ctx context.Context, id string, req dto
 	if req.ParentUnitID != nil {
 		updatedFields = append(updatedFields, "parent_unit_id")
 	}
+	if req.MaxStudents != nil {
+		updatedFields = append(updatedFields, "max_students")
+	}
+	if req.WaitlistEnabled != nil {
+		updatedFields = append(updatedFields, "waitlist_enabled")
+	}
 
 	s.logger.Info("entity updated",
 		"entity_type", "academic_unit",
@@ -277,55 +394,728 @@ func (s *academicUnitService) UpdateUnit(ctx context.Context, id string, req dto
 		"fields_updated", updatedFields,
 	)
 
-	response := dto.ToAcademicUnitResponse(unit)
-	return &response, nil
+	return s.unitResponse(ctx, unit)
+}
+
+func (s *academicUnitService) BulkCreateUnits(ctx context.Context, schoolID string, req dto.BulkCreateUnitsRequest) (*dto.BulkCreateUnitsResponse, error) {
+	schoolUUID, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ensureSchoolWritable(ctx, s.schoolRepo, schoolUUID); err != nil {
+		return nil, err
+	}
+
+	// Tipos de cada nivel y anidamiento entre niveles consecutivos
+	registry, rules, err := s.unitTypeRules(ctx, schoolUUID)
+	if err != nil {
+		return nil, err
+	}
+	levelTypes := make([]valueobject.UnitType, len(req.Levels))
+	for i, level := range req.Levels {
+		levelTypes[i], err = registry.Parse(level.Type)
+		if err != nil {
+			return nil, errors.NewValidationError(err.Error()).WithField("level", strconv.Itoa(i))
+		}
+		if i > 0 && !rules.CanNest(levelTypes[i], levelTypes[i-1]) {
+			return nil, errors.NewBusinessRuleError(fmt.Sprintf("a %s cannot be placed under a %s", levelTypes[i], levelTypes[i-1]))
+		}
+	}
+	generator, err := newUnitGenerator(req.Levels)
+	if err != nil {
+		return nil, err
+	}
+
+	parents, academicYear, err := s.bulkParents(ctx, schoolUUID, registry, req)
+	if err != nil {
+		return nil, err
+	}
+	total := len(parents) * generator.UnitsPerParent()
+	if total > maxGeneratedUnits {
+		return nil, errors.NewValidationError(fmt.Sprintf("generator would create %d units (max %d)", total, maxGeneratedUnits))
+	}
+
+	// Árbol en memoria: nombres válidos y códigos únicos dentro de la generación
+	trees := make([][]*generatedUnit, len(parents))
+	codes := make(map[string]bool)
+	var checkGenerated func(units []*generatedUnit) error
+	checkGenerated = func(units []*generatedUnit) error {
+		for _, unit := range units {
+			if len(unit.Name) < 3 || len(unit.Name) > 255 {
+				return errors.NewValidationError("generated display_name must have between 3 and 255 characters").WithField("display_name", unit.Name)
+			}
+			if unit.Code != "" {
+				if len(unit.Code) < 2 || len(unit.Code) > 50 {
+					return errors.NewValidationError("generated code must have between 2 and 50 characters").WithField("code", unit.Code)
+				}
+				if codes[unit.Code] {
+					return errors.NewValidationError("generator produces duplicate codes").WithField("code", unit.Code)
+				}
+				codes[unit.Code] = true
+			}
+			if err := checkGenerated(unit.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i, parent := range parents {
+		if err := s.checkUnitNesting(ctx, rules, levelTypes[0], parent, len(req.Levels)-1); err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			trees[i] = generator.Generate("", "")
+		} else {
+			trees[i] = generator.Generate(parent.Name, parent.Code)
+		}
+		if err := checkGenerated(trees[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	var created []*entities.AcademicUnit
+	var persist func(parentID *uuid.UUID, units []*generatedUnit) error
+	persist = func(parentID *uuid.UUID, units []*generatedUnit) error {
+		for _, generated := range units {
+			description := ""
+			unit := &entities.AcademicUnit{
+				ID:           uuid.New(),
+				ParentUnitID: parentID,
+				SchoolID:     schoolUUID,
+				Name:         generated.Name,
+				Code:         generated.Code,
+				Type:         generated.Type,
+				Description:  &description,
+				AcademicYear: academicYear,
+				Metadata:     []byte("{}"),
+				IsActive:     true,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+			if err := s.unitRepo.Create(ctx, unit); err != nil {
+				return errors.NewDatabaseError("create unit", err)
+			}
+			created = append(created, unit)
+			if err := persist(&unit.ID, generated.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.unitRepo.LockHierarchy(ctx, schoolUUID); err != nil {
+			return errors.NewDatabaseError("lock unit hierarchy", err)
+		}
+		for code := range codes {
+			exists, err := s.unitRepo.ExistsBySchoolIDAndCode(ctx, schoolUUID, code)
+			if err != nil {
+				return errors.NewDatabaseError("check unit code", err)
+			}
+			if exists {
+				return errors.NewAlreadyExistsError("academic unit with code").WithField("code", code)
+			}
+		}
+		if err := s.quotaService.CheckUnitQuota(ctx, schoolUUID, total); err != nil {
+			return err
+		}
+		for i, parent := range parents {
+			var parentID *uuid.UUID
+			if parent != nil {
+				parentID = &parent.ID
+			}
+			if err := persist(parentID, trees[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.BulkCreateUnitsResponse{Created: len(created), ByType: make(map[string]int)}
+	treeUnits := make([]*entities.AcademicUnit, 0, len(parents)+len(created))
+	for _, parent := range parents {
+		if parent != nil {
+			treeUnits = append(treeUnits, parent)
+		}
+	}
+	for _, unit := range created {
+		response.ByType[unit.Type]++
+		treeUnits = append(treeUnits, unit)
+	}
+	response.Tree = dto.BuildUnitTree(treeUnits)
+
+	s.logger.Info("academic units generated",
+		"school_id", schoolUUID.String(),
+		"parents", len(parents),
+		"units_created", len(created),
+		"academic_year", academicYear,
+	)
+	return response, nil
+}
+
+// bulkParents resuelve de qué unidades cuelga el primer nivel generado (nil = raíz) y el año lectivo de las unidades
+func (s *academicUnitService) bulkParents(ctx context.Context, schoolID uuid.UUID, registry valueobject.UnitTypeRegistry, req dto.BulkCreateUnitsRequest) ([]*entities.AcademicUnit, int, error) {
+	if req.ParentUnitID != nil {
+		pid, err := uuid.Parse(*req.ParentUnitID)
+		if err != nil {
+			return nil, 0, errors.NewValidationError("invalid parent_unit_id")
+		}
+		parent, err := s.unitRepo.FindByID(ctx, pid, false)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, 0, errors.NewNotFoundError("parent unit")
+			}
+			return nil, 0, errors.NewDatabaseError("find parent unit", err)
+		}
+		if parent == nil || parent.SchoolID != schoolID {
+			return nil, 0, errors.NewNotFoundError("parent unit")
+		}
+
+		requestedYear := req.AcademicYear
+		if requestedYear == nil && parent.AcademicYear != 0 {
+			requestedYear = &parent.AcademicYear
+		}
+		academicYear, err := resolveAcademicYear(ctx, s.periodRepo, schoolID, requestedYear)
+		if err != nil {
+			return nil, 0, err
+		}
+		if parent.AcademicYear != 0 && parent.AcademicYear != academicYear {
+			return nil, 0, errors.NewBusinessRuleError("unit must belong to the same academic year as its parent")
+		}
+		return []*entities.AcademicUnit{parent}, academicYear, nil
+	}
+
+	academicYear, err := resolveAcademicYear(ctx, s.periodRepo, schoolID, req.AcademicYear)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(req.ParentTypes) == 0 {
+		return []*entities.AcademicUnit{nil}, academicYear, nil
+	}
+
+	// Un padre por cada unidad existente de los tipos indicados, del mismo año lectivo
+	var parents []*entities.AcademicUnit
+	seen := make(map[valueobject.UnitType]bool)
+	for _, name := range req.ParentTypes {
+		parentType, err := registry.Parse(name)
+		if err != nil {
+			return nil, 0, errors.NewValidationError(err.Error()).WithField("field", "parent_types")
+		}
+		if seen[parentType] {
+			continue
+		}
+		seen[parentType] = true
+		units, err := s.unitRepo.FindByType(ctx, schoolID, parentType.String(), false)
+		if err != nil {
+			return nil, 0, errors.NewDatabaseError("find units", err)
+		}
+		parents = append(parents, filterUnitsByAcademicYear(units, academicYear)...)
+	}
+	if len(parents) == 0 {
+		return nil, 0, errors.NewValidationError("no units of parent_types in the academic year").WithField("academic_year", strconv.Itoa(academicYear))
+	}
+	return parents, academicYear, nil
 }
 
-func (s *academicUnitService) DeleteUnit(ctx context.Context, id string) error {
+func (s *academicUnitService) MoveUnit(ctx context.Context, id string, req dto.MoveAcademicUnitRequest) (*dto.AcademicUnitResponse, error) {
 	unitID, err := uuid.Parse(id)
 	if err != nil {
-		return errors.NewValidationError("invalid unit ID")
+		return nil, errors.NewValidationError("invalid unit ID")
+	}
+	var parentID *uuid.UUID
+	if req.ParentUnitID != nil {
+		pid, err := uuid.Parse(*req.ParentUnitID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid parent_unit_id")
+		}
+		parentID = &pid
+	}
+
+	var unit *entities.AcademicUnit
+	var previousParent *uuid.UUID
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		unit, err = s.unitRepo.FindByID(ctx, unitID, false)
+		if err != nil {
+			if isNotFoundError(err) {
+				return errors.NewNotFoundError("academic unit")
+			}
+			return errors.NewDatabaseError("find academic unit", err)
+		}
+		if unit == nil {
+			return errors.NewNotFoundError("academic unit")
+		}
+		if err := ensureSchoolWritable(ctx, s.schoolRepo, unit.SchoolID); err != nil {
+			return err
+		}
+		if err := ensureAcademicYearWritable(ctx, s.periodRepo, unit.SchoolID, unit.AcademicYear); err != nil {
+			return err
+		}
+
+		// Con el lock tomado, otro movimiento concurrente no puede cerrar un ciclo entre las validaciones y el update
+		if err := s.unitRepo.LockHierarchy(ctx, unit.SchoolID); err != nil {
+			return errors.NewDatabaseError("lock unit hierarchy", err)
+		}
+		if err := s.validateUnitParent(ctx, unit, parentID); err != nil {
+			return err
+		}
+
+		previousParent = unit.ParentUnitID
+		unit.ParentUnitID = parentID
+		unit.UpdatedAt = time.Now()
+		if err := s.unitRepo.Update(ctx, unit); err != nil {
+			return errors.NewDatabaseError("move unit", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("academic unit moved",
+		"unit_id", unit.ID.String(),
+		"school_id", unit.SchoolID.String(),
+		"from_parent", uuidPtrString(previousParent),
+		"to_parent", uuidPtrString(parentID),
+	)
+
+	return s.unitResponse(ctx, unit)
+}
+
+// validateUnitParent valida que parentID pueda ser el padre de la unidad: misma escuela, no eliminado,
+// reglas de anidamiento de la escuela, mismo año lectivo y que no sea la unidad ni uno de sus descendientes
+func (s *academicUnitService) validateUnitParent(ctx context.Context, unit *entities.AcademicUnit, parentID *uuid.UUID) error {
+	if parentID != nil && *parentID == unit.ID {
+		return errors.NewBusinessRuleError("unit cannot be its own parent")
+	}
+
+	var parent *entities.AcademicUnit
+	if parentID != nil {
+		var err error
+		parent, err = s.unitRepo.FindByID(ctx, *parentID, false)
+		if err != nil {
+			if isNotFoundError(err) {
+				return errors.NewNotFoundError("parent unit")
+			}
+			return errors.NewDatabaseError("find parent unit", err)
+		}
+		if parent == nil {
+			return errors.NewNotFoundError("parent unit")
+		}
+		if parent.SchoolID != unit.SchoolID {
+			return errors.NewBusinessRuleError("parent unit belongs to another school")
+		}
+	}
+
+	// El subárbol se mueve con la unidad, así que cuenta para la profundidad
+	subtree, err := s.unitRepo.CountSubtree(ctx, unit.ID, false)
+	if err != nil {
+		return errors.NewDatabaseError("count unit subtree", err)
+	}
+	_, rules, err := s.unitTypeRules(ctx, unit.SchoolID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkUnitNesting(ctx, rules, valueobject.UnitType(unit.Type), parent, subtree.MaxDepth); err != nil {
+		return err
+	}
+	if parent == nil {
+		return nil
+	}
+
+	if parent.AcademicYear != 0 && parent.AcademicYear != unit.AcademicYear {
+		return errors.NewBusinessRuleError("unit must belong to the same academic year as its parent")
+	}
+	if err := ensureAcademicYearWritable(ctx, s.periodRepo, parent.SchoolID, parent.AcademicYear); err != nil {
+		return err
+	}
+
+	descendant, err := s.unitRepo.IsAncestor(ctx, unit.ID, parent.ID)
+	if err != nil {
+		return errors.NewDatabaseError("check unit ancestry", err)
+	}
+	if descendant {
+		return errors.NewBusinessRuleError("unit cannot be moved under one of its descendants")
+	}
+	return nil
+}
+
+// unitTypeRules obtiene los tipos válidos en la escuela y sus reglas de anidamiento efectivas
+// (configuración de la escuela más las reglas de sus tipos propios)
+func (s *academicUnitService) unitTypeRules(ctx context.Context, schoolID uuid.UUID) (valueobject.UnitTypeRegistry, valueobject.UnitNestingRules, error) {
+	custom, err := loadSchoolUnitTypes(ctx, s.unitTypeRepo, schoolID)
+	if err != nil {
+		return nil, valueobject.UnitNestingRules{}, err
+	}
+	rules, err := s.settingsService.GetUnitNestingRules(ctx, schoolID)
+	if err != nil {
+		return nil, valueobject.UnitNestingRules{}, err
+	}
+	return custom.registry(), custom.applyNesting(rules), nil
+}
+
+// checkUnitNesting valida que una unidad del tipo pueda colgar de parent (nil = raíz) y que
+// ella más subtreeDepth niveles por debajo no superen la profundidad máxima de la escuela
+func (s *academicUnitService) checkUnitNesting(ctx context.Context, rules valueobject.UnitNestingRules, unitType valueobject.UnitType, parent *entities.AcademicUnit, subtreeDepth int) error {
+	depth := 1
+	if parent == nil {
+		if !rules.CanBeRoot(unitType) {
+			return errors.NewBusinessRuleError(fmt.Sprintf("a %s must have a parent unit", unitType))
+		}
+	} else {
+		if !rules.CanNest(unitType, valueobject.UnitType(parent.Type)) {
+			return errors.NewBusinessRuleError(fmt.Sprintf("a %s cannot be placed under a %s", unitType, parent.Type))
+		}
+		ancestors, err := s.unitRepo.FindAncestors(ctx, parent.ID)
+		if err != nil {
+			return errors.NewDatabaseError("find unit ancestors", err)
+		}
+		depth = len(ancestors) + 2
+	}
+	if !rules.AllowsDepth(depth + subtreeDepth) {
+		return errors.NewBusinessRuleError(fmt.Sprintf("unit hierarchy cannot be deeper than %d levels", rules.MaxDepth))
+	}
+	return nil
+}
+
+// unitResponse arma la respuesta de la unidad con su ocupación si tiene cupo
+func (s *academicUnitService) unitResponse(ctx context.Context, unit *entities.AcademicUnit) (*dto.AcademicUnitResponse, error) {
+	responses, err := s.unitResponses(ctx, []*entities.AcademicUnit{unit})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+// unitResponses arma las respuestas de las unidades con la ocupación de las que tienen cupo
+func (s *academicUnitService) unitResponses(ctx context.Context, units []*entities.AcademicUnit) ([]dto.AcademicUnitResponse, error) {
+	unitIDs := make([]uuid.UUID, len(units))
+	for i, unit := range units {
+		unitIDs[i] = unit.ID
+	}
+	occupancies, err := s.capacityService.Occupancies(ctx, unitIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.AcademicUnitResponse, len(units))
+	for i, unit := range units {
+		responses[i] = dto.ToAcademicUnitResponse(unit)
+		responses[i].Occupancy = occupancies[unit.ID]
+	}
+	return responses, nil
+}
+
+func uuidPtrString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func (s *academicUnitService) DeleteUnit(ctx context.Context, id string, dryRun bool, deletedBy string) (*dto.UnitDeletionResponse, error) {
+	unitID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid unit ID")
 	}
 
 	unit, err := s.unitRepo.FindByID(ctx, unitID, false)
 	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewNotFoundError("academic unit")
+		}
 		s.logger.Error("database error",
 			"operation", "find_academic_unit",
 			"unit_id", unitID,
 			"error", err.Error(),
 		)
-		return errors.NewDatabaseError("find academic unit", err)
+		return nil, errors.NewDatabaseError("find academic unit", err)
 	}
 	if unit == nil {
-		return errors.NewNotFoundError("academic unit")
+		return nil, errors.NewNotFoundError("academic unit")
+	}
+	if err := ensureSchoolWritable(ctx, s.schoolRepo, unit.SchoolID); err != nil {
+		return nil, err
+	}
+	if err := ensureAcademicYearWritable(ctx, s.periodRepo, unit.SchoolID, unit.AcademicYear); err != nil {
+		return nil, err
 	}
 
-	if err := s.unitRepo.SoftDelete(ctx, unitID); err != nil {
-		return errors.NewDatabaseError("delete unit", err)
+	response := &dto.UnitDeletionResponse{DryRun: dryRun, UnitID: unit.ID.String()}
+	if dryRun {
+		units, memberships, err := s.collectSubtreeForDeletion(ctx, unit)
+		if err != nil {
+			return nil, err
+		}
+		countUnitDeletion(response, units, len(memberships))
+		return response, nil
 	}
 
+	now := time.Now()
+	batch := &repository.UnitDeletionBatch{
+		ID:         uuid.New(),
+		SchoolID:   unit.SchoolID,
+		RootUnitID: unit.ID,
+		DeletedBy:  deletedBy,
+		DeletedAt:  now,
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Con el lock, nadie puede mover unidades dentro del subárbol mientras se elimina
+		if err := s.unitRepo.LockHierarchy(ctx, unit.SchoolID); err != nil {
+			return errors.NewDatabaseError("lock unit hierarchy", err)
+		}
+		units, memberships, err := s.collectSubtreeForDeletion(ctx, unit)
+		if err != nil {
+			return err
+		}
+
+		var items []repository.UnitDeletionItem
+		for _, u := range units {
+			if err := s.unitRepo.SoftDelete(ctx, u.ID); err != nil {
+				return errors.NewDatabaseError("delete unit", err)
+			}
+			items = append(items, repository.UnitDeletionItem{BatchID: batch.ID, EntityType: repository.UnitDeletionItemUnit, EntityID: u.ID})
+		}
+		// Las membresías se suspenden sin withdrawn_at para distinguirlas de las bajas
+		for _, membership := range memberships {
+			membership.IsActive = false
+			membership.UpdatedAt = now
+			if err := s.membershipRepo.Update(ctx, membership); err != nil {
+				return errors.NewDatabaseError("suspend membership", err)
+			}
+			items = append(items, repository.UnitDeletionItem{BatchID: batch.ID, EntityType: repository.UnitDeletionItemMembership, EntityID: membership.ID})
+		}
+
+		batch.UnitsDeleted = len(units)
+		batch.MembershipsSuspended = len(memberships)
+		if err := s.deletionRepo.CreateBatch(ctx, batch, items); err != nil {
+			return errors.NewDatabaseError("create unit deletion batch", err)
+		}
+		countUnitDeletion(response, units, len(memberships))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.BatchID = batch.ID.String()
+	response.PerformedBy = deletedBy
+	response.PerformedAt = &now
+
 	s.logger.Info("entity deleted",
 		"entity_type", "academic_unit",
 		"entity_id", id,
+		"batch_id", batch.ID.String(),
+		"units", response.Units,
+		"memberships", response.Memberships,
+		"deleted_by", deletedBy,
 	)
-	return nil
+	return response, nil
 }
 
-func (s *academicUnitService) RestoreUnit(ctx context.Context, id string) error {
+// collectSubtreeForDeletion obtiene la unidad con sus descendientes no eliminados y las membresías activas de todos ellos
+func (s *academicUnitService) collectSubtreeForDeletion(ctx context.Context, unit *entities.AcademicUnit) ([]*entities.AcademicUnit, []*entities.Membership, error) {
+	descendants, err := s.unitRepo.FindDescendants(ctx, unit.ID, 0, false)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("find descendants", err)
+	}
+	units := []*entities.AcademicUnit{unit}
+	unitIDs := []uuid.UUID{unit.ID}
+	for _, descendant := range descendants {
+		units = append(units, descendant.Unit)
+		unitIDs = append(unitIDs, descendant.Unit.ID)
+	}
+
+	memberships, err := s.membershipRepo.FindActiveByUnits(ctx, unitIDs)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("list memberships", err)
+	}
+	return units, memberships, nil
+}
+
+func (s *academicUnitService) RestoreUnit(ctx context.Context, id string, dryRun bool, restoredBy string) (*dto.UnitDeletionResponse, error) {
 	unitID, err := uuid.Parse(id)
 	if err != nil {
-		return errors.NewValidationError("invalid unit ID")
+		return nil, errors.NewValidationError("invalid unit ID")
 	}
 
-	if err := s.unitRepo.Restore(ctx, unitID); err != nil {
-		return errors.NewDatabaseError("restore unit", err)
+	unit, err := s.unitRepo.FindByID(ctx, unitID, true)
+	if err != nil {
+		if _, ok := errors.GetAppError(err); ok {
+			return nil, err
+		}
+		return nil, errors.NewDatabaseError("find unit", err)
+	}
+	if unit == nil {
+		return nil, errors.NewNotFoundError("academic unit")
+	}
+	if unit.DeletedAt == nil {
+		return nil, errors.NewBusinessRuleError("academic unit is not deleted")
+	}
+	if err := ensureSchoolWritable(ctx, s.schoolRepo, unit.SchoolID); err != nil {
+		return nil, err
+	}
+	if err := ensureAcademicYearWritable(ctx, s.periodRepo, unit.SchoolID, unit.AcademicYear); err != nil {
+		return nil, err
+	}
+
+	batch, err := s.deletionRepo.FindOpenBatchByUnit(ctx, unit.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find unit deletion batch", err)
+	}
+	if batch != nil && batch.RootUnitID != unit.ID {
+		return nil, errors.NewBusinessRuleError(fmt.Sprintf("unit was deleted together with unit %s, restore that unit instead", batch.RootUnitID))
+	}
+
+	// Unidades eliminadas antes de existir los batches: se restaura solo la fila
+	items := []repository.UnitDeletionItem{{EntityType: repository.UnitDeletionItemUnit, EntityID: unit.ID}}
+	if batch != nil {
+		if items, err = s.deletionRepo.ListItems(ctx, batch.ID); err != nil {
+			return nil, errors.NewDatabaseError("list unit deletion items", err)
+		}
 	}
 
+	response := &dto.UnitDeletionResponse{DryRun: dryRun, UnitID: unit.ID.String(), UnitsByType: map[string]int{}}
+	if batch != nil {
+		response.BatchID = batch.ID.String()
+	}
+	now := time.Now()
+	// La vista previa aplica la restauración y la revierte: así las membresías del batch cuentan
+	// los lugares y el cupo que ocupan las anteriores
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Con el lock, nadie puede eliminar ni mover el padre mientras se restaura debajo de él
+		if err := s.unitRepo.LockHierarchy(ctx, unit.SchoolID); err != nil {
+			return errors.NewDatabaseError("lock unit hierarchy", err)
+		}
+		if unit.ParentUnitID != nil {
+			parent, err := s.unitRepo.FindByID(ctx, *unit.ParentUnitID, false)
+			if isNotFoundError(err) || (err == nil && parent == nil) {
+				return errors.NewBusinessRuleError("parent unit is deleted, restore it first")
+			}
+			if err != nil {
+				return errors.NewDatabaseError("find parent unit", err)
+			}
+		}
+
+		for _, item := range items {
+			restored, err := s.restoreDeletionItem(ctx, item, now)
+			if err != nil {
+				return err
+			}
+			switch {
+			case restored == "":
+				response.Skipped++
+			case item.EntityType == repository.UnitDeletionItemUnit:
+				response.Units++
+				response.UnitsByType[restored]++
+			default:
+				response.Memberships++
+			}
+		}
+		if dryRun {
+			return errUnitRestoreDryRun
+		}
+		if batch != nil {
+			batch.RestoredBy = restoredBy
+			batch.RestoredAt = &now
+			if err := s.deletionRepo.UpdateBatch(ctx, batch); err != nil {
+				return errors.NewDatabaseError("update unit deletion batch", err)
+			}
+		}
+		return nil
+	})
+	if dryRun && stderrors.Is(err, errUnitRestoreDryRun) {
+		return response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response.PerformedBy = restoredBy
+	response.PerformedAt = &now
 	s.logger.Info("entity restored",
 		"entity_type", "academic_unit",
 		"entity_id", id,
+		"batch_id", response.BatchID,
+		"units", response.Units,
+		"memberships", response.Memberships,
+		"skipped", response.Skipped,
+		"restored_by", restoredBy,
 	)
-	return nil
+	return response, nil
+}
+
+// restoreDeletionItem revierte un item de un batch; retorna el tipo de la unidad (o el rol de la membresía)
+// restaurada, o "" si la entidad ya no existe o alguien la reactivó por otro camino
+func (s *academicUnitService) restoreDeletionItem(ctx context.Context, item repository.UnitDeletionItem, now time.Time) (string, error) {
+	switch item.EntityType {
+	case repository.UnitDeletionItemUnit:
+		unit, err := s.unitRepo.FindByID(ctx, item.EntityID, true)
+		if isNotFoundError(err) || (err == nil && (unit == nil || unit.DeletedAt == nil)) {
+			return "", nil
+		}
+		if err != nil {
+			return "", errors.NewDatabaseError("find unit", err)
+		}
+		// Mientras estuvo eliminada, otra unidad pudo tomar su código
+		if unit.Code != "" {
+			exists, err := s.unitRepo.ExistsBySchoolIDAndCode(ctx, unit.SchoolID, unit.Code)
+			if err != nil {
+				return "", errors.NewDatabaseError("check unit code", err)
+			}
+			if exists {
+				return "", errors.NewAlreadyExistsError("academic unit with code").WithField("code", unit.Code)
+			}
+		}
+		if err := s.quotaService.CheckUnitQuota(ctx, unit.SchoolID, 1); err != nil {
+			return "", err
+		}
+		if err := s.unitRepo.Restore(ctx, unit.ID); err != nil {
+			return "", errors.NewDatabaseError("restore unit", err)
+		}
+		return unit.Type, nil
+
+	case repository.UnitDeletionItemMembership:
+		membership, err := s.membershipRepo.FindByID(ctx, item.EntityID)
+		if isNotFoundError(err) || (err == nil && (membership == nil || membership.IsActive || membership.WithdrawnAt != nil)) {
+			return "", nil
+		}
+		if err != nil {
+			return "", errors.NewDatabaseError("find membership", err)
+		}
+		// Mientras estuvo suspendida, otros pudieron ocupar su lugar en la unidad o el cupo del plan
+		if membership.AcademicUnitID != nil && membership.Role == string(valueobject.RoleStudent) {
+			if _, err := s.capacityService.ReserveSeat(ctx, membership.SchoolID, *membership.AcademicUnitID, membership.UserID, false); err != nil {
+				return "", err
+			}
+		}
+		if err := s.quotaService.CheckMembershipQuota(ctx, membership.SchoolID, membership.UserID, membership.Role); err != nil {
+			return "", err
+		}
+		membership.IsActive = true
+		membership.UpdatedAt = now
+		if err := s.membershipRepo.Update(ctx, membership); err != nil {
+			return "", errors.NewDatabaseError("restore membership", err)
+		}
+		return membership.Role, nil
+	}
+	return "", nil
+}
+
+func countUnitDeletion(response *dto.UnitDeletionResponse, units []*entities.AcademicUnit, memberships int) {
+	response.Units = len(units)
+	response.UnitsByType = map[string]int{}
+	for _, unit := range units {
+		response.UnitsByType[unit.Type]++
+	}
+	response.Memberships = memberships
 }
 
 func (s *academicUnitService) GetHierarchyPath(ctx context.Context, id string) ([]dto.AcademicUnitResponse, error) {
@@ -345,3 +1135,66 @@ func (s *academicUnitService) GetHierarchyPath(ctx context.Context, id string) (
 	}
 	return responses, nil
 }
+
+func (s *academicUnitService) ListDescendants(ctx context.Context, id string, maxDepth int, includeDeleted bool) (*dto.UnitDescendantsResponse, error) {
+	unitID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid unit ID")
+	}
+	if maxDepth < 0 {
+		return nil, errors.NewValidationError("max_depth must be zero or positive")
+	}
+
+	unit, err := s.unitRepo.FindByID(ctx, unitID, includeDeleted)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewNotFoundError("academic unit")
+		}
+		return nil, errors.NewDatabaseError("find academic unit", err)
+	}
+	if unit == nil {
+		return nil, errors.NewNotFoundError("academic unit")
+	}
+
+	descendants, err := s.unitRepo.FindDescendants(ctx, unitID, maxDepth, includeDeleted)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find descendants", err)
+	}
+	count, err := s.unitRepo.CountSubtree(ctx, unitID, includeDeleted)
+	if err != nil {
+		return nil, errors.NewDatabaseError("count subtree", err)
+	}
+
+	response := &dto.UnitDescendantsResponse{
+		UnitID:   unit.ID.String(),
+		MaxDepth: maxDepth,
+		Counts: dto.UnitSubtreeCountResponse{
+			Descendants: count.Descendants,
+			ByType:      count.ByType,
+			MaxDepth:    count.MaxDepth,
+		},
+		Descendants: make([]dto.UnitDescendantResponse, len(descendants)),
+	}
+	for i, descendant := range descendants {
+		response.Descendants[i] = dto.UnitDescendantResponse{
+			AcademicUnitResponse: dto.ToAcademicUnitResponse(descendant.Unit),
+			Depth:                descendant.Depth,
+		}
+	}
+	return response, nil
+}
+
+// filterUnitsByAcademicYear deja las unidades del año indicado y las que no pertenecen a ningún año.
+// Con year 0 no filtra.
+func filterUnitsByAcademicYear(units []*entities.AcademicUnit, year int) []*entities.AcademicUnit {
+	if year == 0 {
+		return units
+	}
+	result := make([]*entities.AcademicUnit, 0, len(units))
+	for _, unit := range units {
+		if unit.AcademicYear == 0 || unit.AcademicYear == year {
+			result = append(result, unit)
+		}
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -10,7 +11,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
 )
 
 type MockAcademicUnitRepository struct {
@@ -46,6 +51,11 @@ func (m *MockAcademicUnitRepository) FindBySchoolID(ctx context.Context, schoolI
 	return args.Get(0).([]*entities.AcademicUnit), args.Error(1)
 }
 
+func (m *MockAcademicUnitRepository) CountBySchoolID(ctx context.Context, schoolID uuid.UUID) (int, error) {
+	args := m.Called(ctx, schoolID)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockAcademicUnitRepository) FindByParentID(ctx context.Context, parentID uuid.UUID, includeDeleted bool) ([]*entities.AcademicUnit, error) {
 	args := m.Called(ctx, parentID, includeDeleted)
 	if args.Get(0) == nil {
@@ -98,16 +108,139 @@ func (m *MockAcademicUnitRepository) GetHierarchyPath(ctx context.Context, id uu
 	return args.Get(0).([]*entities.AcademicUnit), args.Error(1)
 }
 
+func (m *MockAcademicUnitRepository) FindAncestors(ctx context.Context, id uuid.UUID) ([]*entities.AcademicUnit, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.AcademicUnit), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepository) FindDescendants(ctx context.Context, id uuid.UUID, maxDepth int, includeDeleted bool) ([]repository.UnitDescendant, error) {
+	args := m.Called(ctx, id, maxDepth, includeDeleted)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.UnitDescendant), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepository) CountSubtree(ctx context.Context, id uuid.UUID, includeDeleted bool) (*repository.UnitSubtreeCount, error) {
+	args := m.Called(ctx, id, includeDeleted)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UnitSubtreeCount), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepository) IsAncestor(ctx context.Context, ancestorID, unitID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, ancestorID, unitID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepository) LockHierarchy(ctx context.Context, schoolID uuid.UUID) error {
+	args := m.Called(ctx, schoolID)
+	return args.Error(0)
+}
+
 func (m *MockAcademicUnitRepository) ExistsBySchoolIDAndCode(ctx context.Context, schoolID uuid.UUID, code string) (bool, error) {
 	args := m.Called(ctx, schoolID, code)
 	return args.Bool(0), args.Error(1)
 }
 
+// MockSchoolSettingsService mock implementation
+type MockSchoolSettingsService struct {
+	mock.Mock
+}
+
+func (m *MockSchoolSettingsService) GetSchema() json.RawMessage {
+	args := m.Called()
+	return args.Get(0).(json.RawMessage)
+}
+
+func (m *MockSchoolSettingsService) GetSettings(ctx context.Context, schoolID string) (*dto.SchoolSettingsResponse, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SchoolSettingsResponse), args.Error(1)
+}
+
+func (m *MockSchoolSettingsService) PatchSettings(ctx context.Context, schoolID string, req dto.PatchSchoolSettingsRequest, changedBy string) (*dto.SchoolSettingsResponse, error) {
+	args := m.Called(ctx, schoolID, req, changedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SchoolSettingsResponse), args.Error(1)
+}
+
+func (m *MockSchoolSettingsService) ListHistory(ctx context.Context, schoolID string) ([]dto.SchoolSettingsChangeResponse, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.SchoolSettingsChangeResponse), args.Error(1)
+}
+
+func (m *MockSchoolSettingsService) GetUnitNestingRules(ctx context.Context, schoolID uuid.UUID) (valueobject.UnitNestingRules, error) {
+	args := m.Called(ctx, schoolID)
+	return args.Get(0).(valueobject.UnitNestingRules), args.Error(1)
+}
+
+// nestingSettings retorna un settings service que entrega las reglas indicadas para cualquier escuela
+func nestingSettings(rules valueobject.UnitNestingRules) *MockSchoolSettingsService {
+	settings := new(MockSchoolSettingsService)
+	settings.On("GetUnitNestingRules", mock.Anything, mock.Anything).Return(rules, nil).Maybe()
+	return settings
+}
+
+func defaultNestingSettings() *MockSchoolSettingsService {
+	return nestingSettings(valueobject.DefaultUnitNestingRules())
+}
+
+// MockUnitDeletionRepository mock implementation
+type MockUnitDeletionRepository struct {
+	mock.Mock
+}
+
+func (m *MockUnitDeletionRepository) CreateBatch(ctx context.Context, batch *repository.UnitDeletionBatch, items []repository.UnitDeletionItem) error {
+	args := m.Called(ctx, batch, items)
+	return args.Error(0)
+}
+
+func (m *MockUnitDeletionRepository) FindBatch(ctx context.Context, id uuid.UUID) (*repository.UnitDeletionBatch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UnitDeletionBatch), args.Error(1)
+}
+
+func (m *MockUnitDeletionRepository) FindOpenBatchByUnit(ctx context.Context, unitID uuid.UUID) (*repository.UnitDeletionBatch, error) {
+	args := m.Called(ctx, unitID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UnitDeletionBatch), args.Error(1)
+}
+
+func (m *MockUnitDeletionRepository) ListItems(ctx context.Context, batchID uuid.UUID) ([]repository.UnitDeletionItem, error) {
+	args := m.Called(ctx, batchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.UnitDeletionItem), args.Error(1)
+}
+
+func (m *MockUnitDeletionRepository) UpdateBatch(ctx context.Context, batch *repository.UnitDeletionBatch) error {
+	args := m.Called(ctx, batch)
+	return args.Error(0)
+}
+
 func TestGetUnit_Success(t *testing.T) {
 	mockUnitRepo := new(MockAcademicUnitRepository)
 	mockSchoolRepo := new(MockSchoolRepository)
 	mockLogger := newTestLogger()
-	service := NewAcademicUnitService(mockUnitRepo, mockSchoolRepo, mockLogger)
+	service := NewAcademicUnitService(mockUnitRepo, mockSchoolRepo, new(MockAcademicPeriodRepository), new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, mockLogger)
 
 	unitID := uuid.New()
 	unit := &entities.AcademicUnit{
@@ -134,7 +267,7 @@ func TestGetUnitTree_Success(t *testing.T) {
 	mockUnitRepo := new(MockAcademicUnitRepository)
 	mockSchoolRepo := new(MockSchoolRepository)
 	mockLogger := newTestLogger()
-	service := NewAcademicUnitService(mockUnitRepo, mockSchoolRepo, mockLogger)
+	service := NewAcademicUnitService(mockUnitRepo, mockSchoolRepo, new(MockAcademicPeriodRepository), new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, mockLogger)
 
 	schoolID := uuid.New()
 	units := []*entities.AcademicUnit{
@@ -152,34 +285,479 @@ func TestGetUnitTree_Success(t *testing.T) {
 
 	mockUnitRepo.On("FindBySchoolID", mock.Anything, schoolID, false).Return(units, nil)
 
-	result, err := service.GetUnitTree(context.Background(), schoolID.String())
+	result, err := service.GetUnitTree(context.Background(), schoolID.String(), AcademicYearFilterAll)
 
 	require.NoError(t, err)
 	assert.Len(t, result, 1)
 	mockUnitRepo.AssertExpectations(t)
 }
 
-func TestDeleteUnit_Success(t *testing.T) {
+func TestDeleteUnit_CascadesToSubtreeAndMemberships(t *testing.T) {
 	mockUnitRepo := new(MockAcademicUnitRepository)
-	mockSchoolRepo := new(MockSchoolRepository)
-	mockLogger := newTestLogger()
-	service := NewAcademicUnitService(mockUnitRepo, mockSchoolRepo, mockLogger)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockDeletionRepo := new(MockUnitDeletionRepository)
+	service := NewAcademicUnitService(mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), mockMembershipRepo, mockDeletionRepo, noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
 
-	unitID := uuid.New()
-	unit := &entities.AcademicUnit{
-		ID:        unitID,
-		SchoolID:  uuid.New(),
-		Name:      "Grade 1",
-		IsActive:  true,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
+	schoolID := uuid.New()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grade 1", Type: "grade", IsActive: true}
+	section := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Section A", Type: "section", ParentUnitID: &grade.ID, IsActive: true}
+	inSection := &entities.Membership{ID: uuid.New(), SchoolID: schoolID, AcademicUnitID: &section.ID, Role: "student", IsActive: true}
+	elsewhereID := uuid.New()
+	elsewhere := &entities.Membership{ID: uuid.New(), SchoolID: schoolID, AcademicUnitID: &elsewhereID, Role: "student", IsActive: true}
 
-	mockUnitRepo.On("FindByID", mock.Anything, unitID, false).Return(unit, nil)
-	mockUnitRepo.On("SoftDelete", mock.Anything, unitID).Return(nil)
+	mockUnitRepo.On("FindByID", mock.Anything, grade.ID, false).Return(grade, nil)
+	mockUnitRepo.On("FindDescendants", mock.Anything, grade.ID, 0, false).Return([]repository.UnitDescendant{{Unit: section, Depth: 1}}, nil)
+	mockMembershipRepo.On("FindActiveByUnits", mock.Anything, []uuid.UUID{grade.ID, section.ID}).Return([]*entities.Membership{inSection}, nil)
+
+	preview, err := service.DeleteUnit(context.Background(), grade.ID.String(), true, "admin-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, preview.Units)
+	assert.Equal(t, 1, preview.Memberships)
+	assert.Empty(t, preview.BatchID)
+	mockUnitRepo.AssertNotCalled(t, "SoftDelete", mock.Anything, mock.Anything)
+
+	mockUnitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+	mockUnitRepo.On("SoftDelete", mock.Anything, grade.ID).Return(nil)
+	mockUnitRepo.On("SoftDelete", mock.Anything, section.ID).Return(nil)
+	mockMembershipRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *entities.Membership) bool {
+		return m.ID == inSection.ID && !m.IsActive && m.WithdrawnAt == nil
+	})).Return(nil)
+	mockDeletionRepo.On("CreateBatch", mock.Anything, mock.MatchedBy(func(b *repository.UnitDeletionBatch) bool {
+		return b.RootUnitID == grade.ID && b.UnitsDeleted == 2 && b.MembershipsSuspended == 1 && b.DeletedBy == "admin-1"
+	}), mock.MatchedBy(func(items []repository.UnitDeletionItem) bool {
+		return len(items) == 3
+	})).Return(nil)
+
+	result, err := service.DeleteUnit(context.Background(), grade.ID.String(), false, "admin-1")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.BatchID)
+	assert.Equal(t, map[string]int{"grade": 1, "section": 1}, result.UnitsByType)
+	assert.True(t, elsewhere.IsActive)
+	mockUnitRepo.AssertExpectations(t)
+	mockMembershipRepo.AssertExpectations(t)
+	mockDeletionRepo.AssertExpectations(t)
+}
+
+func TestRestoreUnit_RevertsDeletionBatch(t *testing.T) {
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockDeletionRepo := new(MockUnitDeletionRepository)
+	service := NewAcademicUnitService(mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), mockMembershipRepo, mockDeletionRepo, noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), newTestQuotaService(liveSchools(), mockMembershipRepo), passthroughTxManager{}, newTestLogger())
+
+	deletedAt := time.Now()
+	schoolID := uuid.New()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grade 1", Type: "grade", DeletedAt: &deletedAt}
+	section := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Section A", Type: "section", ParentUnitID: &grade.ID, DeletedAt: &deletedAt}
+	suspended := &entities.Membership{ID: uuid.New(), SchoolID: schoolID, AcademicUnitID: &section.ID, Role: "student"}
+	withdrawn := &entities.Membership{ID: uuid.New(), SchoolID: schoolID, AcademicUnitID: &section.ID, Role: "student", WithdrawnAt: &deletedAt}
+	batch := &repository.UnitDeletionBatch{ID: uuid.New(), SchoolID: schoolID, RootUnitID: grade.ID, DeletedAt: deletedAt}
+
+	mockUnitRepo.On("FindByID", mock.Anything, grade.ID, true).Return(grade, nil)
+	mockUnitRepo.On("FindByID", mock.Anything, section.ID, true).Return(section, nil)
+	mockDeletionRepo.On("FindOpenBatchByUnit", mock.Anything, grade.ID).Return(batch, nil)
+	mockDeletionRepo.On("FindOpenBatchByUnit", mock.Anything, section.ID).Return(batch, nil)
+	mockDeletionRepo.On("ListItems", mock.Anything, batch.ID).Return([]repository.UnitDeletionItem{
+		{BatchID: batch.ID, EntityType: repository.UnitDeletionItemUnit, EntityID: grade.ID},
+		{BatchID: batch.ID, EntityType: repository.UnitDeletionItemUnit, EntityID: section.ID},
+		{BatchID: batch.ID, EntityType: repository.UnitDeletionItemMembership, EntityID: suspended.ID},
+		{BatchID: batch.ID, EntityType: repository.UnitDeletionItemMembership, EntityID: withdrawn.ID},
+	}, nil)
+	mockMembershipRepo.On("FindByID", mock.Anything, suspended.ID).Return(suspended, nil)
+	mockMembershipRepo.On("FindByID", mock.Anything, withdrawn.ID).Return(withdrawn, nil)
+
+	// Una unidad eliminada junto con su ancestro solo se restaura a través de él
+	_, err := service.RestoreUnit(context.Background(), section.ID.String(), false, "admin-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), grade.ID.String())
+
+	mockUnitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+	mockUnitRepo.On("Restore", mock.Anything, grade.ID).Return(nil)
+	mockUnitRepo.On("Restore", mock.Anything, section.ID).Return(nil)
+	mockMembershipRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *entities.Membership) bool {
+		return m.ID == suspended.ID && m.IsActive
+	})).Return(nil)
+	mockDeletionRepo.On("UpdateBatch", mock.Anything, mock.MatchedBy(func(b *repository.UnitDeletionBatch) bool {
+		return b.RestoredAt != nil && b.RestoredBy == "admin-1"
+	})).Return(nil)
+
+	result, err := service.RestoreUnit(context.Background(), grade.ID.String(), false, "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Units)
+	assert.Equal(t, 1, result.Memberships)
+	assert.Equal(t, 1, result.Skipped)
+	assert.False(t, withdrawn.IsActive)
+	mockUnitRepo.AssertExpectations(t)
+	mockDeletionRepo.AssertExpectations(t)
+}
+
+func TestRestoreUnit_RejectsCodeTakenByLiveUnit(t *testing.T) {
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockDeletionRepo := new(MockUnitDeletionRepository)
+	service := NewAcademicUnitService(mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), new(MockUnitMembershipRepository), mockDeletionRepo, noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	deletedAt := time.Now()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: uuid.New(), Name: "Grade 1", Type: "grade", Code: "G1", DeletedAt: &deletedAt}
+	mockUnitRepo.On("FindByID", mock.Anything, grade.ID, true).Return(grade, nil)
+	mockDeletionRepo.On("FindOpenBatchByUnit", mock.Anything, grade.ID).Return(nil, nil)
+	mockUnitRepo.On("LockHierarchy", mock.Anything, grade.SchoolID).Return(nil)
+	mockUnitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, grade.SchoolID, "G1").Return(true, nil)
+
+	_, err := service.RestoreUnit(context.Background(), grade.ID.String(), false, "admin-1")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+	mockUnitRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
 
-	err := service.DeleteUnit(context.Background(), unitID.String())
+func TestRestoreUnit_StudentNeedsFreeSeat(t *testing.T) {
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockDeletionRepo := new(MockUnitDeletionRepository)
+	capacityService := new(MockUnitCapacityService)
+	service := NewAcademicUnitService(mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), mockMembershipRepo, mockDeletionRepo, noCustomUnitTypes(), defaultNestingSettings(), capacityService, newTestQuotaService(liveSchools(), mockMembershipRepo), passthroughTxManager{}, newTestLogger())
+
+	deletedAt := time.Now()
+	section := &entities.AcademicUnit{ID: uuid.New(), SchoolID: uuid.New(), Name: "Section A", Type: "section", DeletedAt: &deletedAt}
+	suspended := &entities.Membership{ID: uuid.New(), UserID: uuid.New(), SchoolID: section.SchoolID, AcademicUnitID: &section.ID, Role: "student"}
+	batch := &repository.UnitDeletionBatch{ID: uuid.New(), SchoolID: section.SchoolID, RootUnitID: section.ID, DeletedAt: deletedAt}
+
+	mockUnitRepo.On("FindByID", mock.Anything, section.ID, true).Return(section, nil)
+	mockDeletionRepo.On("FindOpenBatchByUnit", mock.Anything, section.ID).Return(batch, nil)
+	mockDeletionRepo.On("ListItems", mock.Anything, batch.ID).Return([]repository.UnitDeletionItem{
+		{BatchID: batch.ID, EntityType: repository.UnitDeletionItemUnit, EntityID: section.ID},
+		{BatchID: batch.ID, EntityType: repository.UnitDeletionItemMembership, EntityID: suspended.ID},
+	}, nil)
+	mockUnitRepo.On("LockHierarchy", mock.Anything, section.SchoolID).Return(nil)
+	mockUnitRepo.On("Restore", mock.Anything, section.ID).Return(nil)
+	mockMembershipRepo.On("FindByID", mock.Anything, suspended.ID).Return(suspended, nil)
+	// Mientras la unidad estuvo eliminada su lugar se ocupó
+	capacityService.On("ReserveSeat", mock.Anything, section.SchoolID, section.ID, suspended.UserID, false).
+		Return(nil, errors.NewBusinessRuleError("unit is at capacity"))
+
+	_, err := service.RestoreUnit(context.Background(), section.ID.String(), true, "admin-1")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "capacity")
+	mockMembershipRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockDeletionRepo.AssertNotCalled(t, "UpdateBatch", mock.Anything, mock.Anything)
+}
+
+func TestMoveUnit_MovesUnderNewParent(t *testing.T) {
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	service := NewAcademicUnitService(mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	schoolID := uuid.New()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grade 2", Type: "grade"}
+	section := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Section A", Type: "section", ParentUnitID: func() *uuid.UUID { id := uuid.New(); return &id }()}
+
+	mockUnitRepo.On("FindByID", mock.Anything, section.ID, false).Return(section, nil)
+	mockUnitRepo.On("FindByID", mock.Anything, grade.ID, false).Return(grade, nil)
+	mockUnitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+	mockUnitRepo.On("IsAncestor", mock.Anything, section.ID, grade.ID).Return(false, nil)
+	mockUnitRepo.On("CountSubtree", mock.Anything, section.ID, false).Return(&repository.UnitSubtreeCount{ByType: map[string]int{}}, nil)
+	mockUnitRepo.On("FindAncestors", mock.Anything, grade.ID).Return([]*entities.AcademicUnit{}, nil)
+	mockUnitRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.AcademicUnit) bool {
+		return u.ParentUnitID != nil && *u.ParentUnitID == grade.ID
+	})).Return(nil)
+
+	parentID := grade.ID.String()
+	result, err := service.MoveUnit(context.Background(), section.ID.String(), dto.MoveAcademicUnitRequest{ParentUnitID: &parentID})
 
 	require.NoError(t, err)
+	assert.Equal(t, parentID, *result.ParentUnitID)
 	mockUnitRepo.AssertExpectations(t)
 }
+
+func TestMoveUnit_RejectsInvalidParents(t *testing.T) {
+	schoolID := uuid.New()
+	department := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Sciences", Type: "department"}
+
+	tests := []struct {
+		name    string
+		parent  *entities.AcademicUnit
+		isChild bool
+		message string
+	}{
+		{
+			name:    "descendant parent",
+			parent:  &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Physics", Type: "department", ParentUnitID: &department.ID},
+			isChild: true,
+			message: "descendants",
+		},
+		{
+			name:    "parent from another school",
+			parent:  &entities.AcademicUnit{ID: uuid.New(), SchoolID: uuid.New(), Name: "Other", Type: "school"},
+			message: "another school",
+		},
+		{
+			name:    "incompatible parent type",
+			parent:  &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Section A", Type: "section"},
+			message: "cannot be placed under",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUnitRepo := new(MockAcademicUnitRepository)
+			service := NewAcademicUnitService(mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+			mockUnitRepo.On("FindByID", mock.Anything, department.ID, false).Return(department, nil)
+			mockUnitRepo.On("FindByID", mock.Anything, tt.parent.ID, false).Return(tt.parent, nil)
+			mockUnitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+			mockUnitRepo.On("IsAncestor", mock.Anything, department.ID, tt.parent.ID).Return(tt.isChild, nil)
+			mockUnitRepo.On("CountSubtree", mock.Anything, department.ID, false).Return(&repository.UnitSubtreeCount{ByType: map[string]int{}}, nil)
+			mockUnitRepo.On("FindAncestors", mock.Anything, tt.parent.ID).Return([]*entities.AcademicUnit{department}, nil)
+
+			parentID := tt.parent.ID.String()
+			_, err := service.MoveUnit(context.Background(), department.ID.String(), dto.MoveAcademicUnitRequest{ParentUnitID: &parentID})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+			mockUnitRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateUnit_EnforcesSchoolNestingRules(t *testing.T) {
+	schoolID := uuid.New()
+	section := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Section A", Type: "section", AcademicYear: 2026}
+	department := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Sciences", Type: "department", AcademicYear: 2026}
+	foreignGrade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: uuid.New(), Name: "Primero", Type: "grade", AcademicYear: 2026}
+
+	overridden := valueobject.DefaultUnitNestingRules()
+	overridden.AllowedParents[valueobject.UnitTypeSection] = []valueobject.UnitType{valueobject.UnitTypeGrade, valueobject.UnitTypeDepartment}
+	overridden.MaxDepth = 2
+
+	tests := []struct {
+		name    string
+		rules   valueobject.UnitNestingRules
+		req     dto.CreateAcademicUnitRequest
+		parent  *entities.AcademicUnit
+		depth   int
+		message string
+	}{
+		{
+			name:    "grade under section",
+			rules:   valueobject.DefaultUnitNestingRules(),
+			req:     dto.CreateAcademicUnitRequest{Type: "grade", DisplayName: "Primero"},
+			parent:  section,
+			message: "a grade cannot be placed under a section",
+		},
+		{
+			name:    "section without parent",
+			rules:   valueobject.DefaultUnitNestingRules(),
+			req:     dto.CreateAcademicUnitRequest{Type: "section", DisplayName: "Sección B"},
+			message: "must have a parent unit",
+		},
+		{
+			name:   "school override allows section under department",
+			rules:  overridden,
+			req:    dto.CreateAcademicUnitRequest{Type: "section", DisplayName: "Laboratorio"},
+			parent: department,
+		},
+		{
+			name:    "deeper than school max depth",
+			rules:   overridden,
+			req:     dto.CreateAcademicUnitRequest{Type: "section", DisplayName: "Laboratorio"},
+			parent:  department,
+			depth:   1,
+			message: "deeper than 2 levels",
+		},
+		{
+			name:    "parent from another school",
+			rules:   valueobject.DefaultUnitNestingRules(),
+			req:     dto.CreateAcademicUnitRequest{Type: "section", DisplayName: "Sección A"},
+			parent:  foreignGrade,
+			message: "parent unit belongs to another school",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unitRepo := new(MockAcademicUnitRepository)
+			schoolRepo := new(MockSchoolRepository)
+			periodRepo := new(MockAcademicPeriodRepository)
+			service := NewAcademicUnitService(unitRepo, schoolRepo, periodRepo, new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), nestingSettings(tt.rules), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+			schoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+			unitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+			periodRepo.On("FindYear", mock.Anything, schoolID, 2026).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil).Maybe()
+			periodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil).Maybe()
+			unitRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			req := tt.req
+			if tt.parent != nil {
+				parentID := tt.parent.ID.String()
+				req.ParentUnitID = &parentID
+				unitRepo.On("FindByID", mock.Anything, tt.parent.ID, false).Return(tt.parent, nil)
+				unitRepo.On("FindAncestors", mock.Anything, tt.parent.ID).Return(make([]*entities.AcademicUnit, tt.depth), nil).Maybe()
+			}
+
+			_, err := service.CreateUnit(context.Background(), schoolID.String(), req)
+
+			if tt.message == "" {
+				require.NoError(t, err)
+				unitRepo.AssertCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+			unitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMoveUnit_CountsSubtreeAgainstMaxDepth(t *testing.T) {
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	rules := valueobject.DefaultUnitNestingRules()
+	rules.MaxDepth = 4
+	service := NewAcademicUnitService(mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), nestingSettings(rules), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	schoolID := uuid.New()
+	root := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Sciences", Type: "department"}
+	target := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Physics", Type: "department", ParentUnitID: &root.ID}
+	moved := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Chemistry", Type: "department"}
+
+	mockUnitRepo.On("FindByID", mock.Anything, moved.ID, false).Return(moved, nil)
+	mockUnitRepo.On("FindByID", mock.Anything, target.ID, false).Return(target, nil)
+	mockUnitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+	mockUnitRepo.On("CountSubtree", mock.Anything, moved.ID, false).Return(&repository.UnitSubtreeCount{Descendants: 2, ByType: map[string]int{"department": 2}, MaxDepth: 2}, nil)
+	mockUnitRepo.On("FindAncestors", mock.Anything, target.ID).Return([]*entities.AcademicUnit{root}, nil)
+
+	parentID := target.ID.String()
+	_, err := service.MoveUnit(context.Background(), moved.ID.String(), dto.MoveAcademicUnitRequest{ParentUnitID: &parentID})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deeper than 4 levels")
+	mockUnitRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestListDescendants_ReturnsDepthAndCounts(t *testing.T) {
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	service := NewAcademicUnitService(mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: uuid.New(), Name: "Grade 1", Type: "grade"}
+	section := &entities.AcademicUnit{ID: uuid.New(), SchoolID: grade.SchoolID, Name: "Section A", Type: "section", ParentUnitID: &grade.ID}
+	count := &repository.UnitSubtreeCount{Descendants: 2, ByType: map[string]int{"section": 1, "club": 1}, MaxDepth: 2}
+
+	mockUnitRepo.On("FindByID", mock.Anything, grade.ID, false).Return(grade, nil)
+	mockUnitRepo.On("FindDescendants", mock.Anything, grade.ID, 1, false).Return([]repository.UnitDescendant{{Unit: section, Depth: 1}}, nil)
+	mockUnitRepo.On("CountSubtree", mock.Anything, grade.ID, false).Return(count, nil)
+
+	result, err := service.ListDescendants(context.Background(), grade.ID.String(), 1, false)
+
+	require.NoError(t, err)
+	require.Len(t, result.Descendants, 1)
+	assert.Equal(t, 1, result.Descendants[0].Depth)
+	assert.Equal(t, "Section A", result.Descendants[0].DisplayName)
+	assert.Equal(t, 2, result.Counts.Descendants)
+	assert.Equal(t, 2, result.Counts.MaxDepth)
+
+	_, err = service.ListDescendants(context.Background(), grade.ID.String(), -1, false)
+	require.Error(t, err)
+}
+
+func TestListDescendants_IncludeDeletedCountsDeletedUnits(t *testing.T) {
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	service := NewAcademicUnitService(mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	deletedAt := time.Now()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: uuid.New(), Name: "Grade 1", Type: "grade"}
+	section := &entities.AcademicUnit{ID: uuid.New(), SchoolID: grade.SchoolID, Name: "Section A", Type: "section", ParentUnitID: &grade.ID}
+	deleted := &entities.AcademicUnit{ID: uuid.New(), SchoolID: grade.SchoolID, Name: "Section B", Type: "section", ParentUnitID: &grade.ID, DeletedAt: &deletedAt}
+
+	mockUnitRepo.On("FindByID", mock.Anything, grade.ID, true).Return(grade, nil)
+	mockUnitRepo.On("FindDescendants", mock.Anything, grade.ID, 0, true).Return([]repository.UnitDescendant{{Unit: section, Depth: 1}, {Unit: deleted, Depth: 1}}, nil)
+	mockUnitRepo.On("CountSubtree", mock.Anything, grade.ID, true).Return(&repository.UnitSubtreeCount{Descendants: 2, ByType: map[string]int{"section": 2}, MaxDepth: 1}, nil)
+
+	result, err := service.ListDescendants(context.Background(), grade.ID.String(), 0, true)
+
+	require.NoError(t, err)
+	require.Len(t, result.Descendants, 2)
+	assert.Equal(t, 2, result.Counts.Descendants, "counts must match the listed descendants")
+	assert.Equal(t, 2, result.Counts.ByType["section"])
+	mockUnitRepo.AssertNotCalled(t, "CountSubtree", mock.Anything, grade.ID, false)
+}
+
+func TestBulkCreateUnits_GeneratesGradesAndSections(t *testing.T) {
+	schoolID := uuid.New()
+	unitRepo := new(MockAcademicUnitRepository)
+	schoolRepo := new(MockSchoolRepository)
+	periodRepo := new(MockAcademicPeriodRepository)
+	service := NewAcademicUnitService(unitRepo, schoolRepo, periodRepo, new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	schoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	periodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	unitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+	unitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, schoolID, mock.Anything).Return(false, nil)
+	var created []*entities.AcademicUnit
+	unitRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(1).(*entities.AcademicUnit))
+	}).Return(nil)
+
+	resp, err := service.BulkCreateUnits(context.Background(), schoolID.String(), dto.BulkCreateUnitsRequest{
+		Levels: []dto.UnitGeneratorLevel{
+			{Type: "grade", Range: &dto.UnitGeneratorRange{From: "1", To: "11"}, NameTemplate: "Grado {value}", CodeTemplate: "G{value}"},
+			{Type: "section", Range: &dto.UnitGeneratorRange{From: "A", To: "D"}, NameTemplate: "{parent_name} - Sección {value}", CodeTemplate: "{parent_code}{value}"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 55, resp.Created)
+	assert.Equal(t, map[string]int{"grade": 11, "section": 44}, resp.ByType)
+	require.Len(t, resp.Tree, 11)
+	assert.Equal(t, "Grado 11", resp.Tree[10].DisplayName)
+	require.Len(t, resp.Tree[10].Children, 4)
+	assert.Equal(t, "G11D", resp.Tree[10].Children[3].Code)
+	assert.Equal(t, "Grado 11 - Sección D", resp.Tree[10].Children[3].DisplayName)
+	for _, unit := range created {
+		assert.Equal(t, 2026, unit.AcademicYear)
+	}
+	unitRepo.AssertNumberOfCalls(t, "ExistsBySchoolIDAndCode", 55)
+}
+
+func TestBulkCreateUnits_RejectsConflictingCodes(t *testing.T) {
+	schoolID := uuid.New()
+	first := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Primero", Code: "P1", Type: "grade", AcademicYear: 2026}
+	second := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Segundo", Code: "P2", Type: "grade", AcademicYear: 2026}
+	previousYear := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Primero", Code: "P1-2025", Type: "grade", AcademicYear: 2025}
+
+	tests := []struct {
+		name         string
+		codeTemplate string
+		existing     string
+		message      string
+	}{
+		{name: "duplicated within the generation", codeTemplate: "S{value}", message: "duplicate codes"},
+		{name: "already used in the school", codeTemplate: "{parent_code}-{value}", existing: "P2-B", message: "already exists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unitRepo := new(MockAcademicUnitRepository)
+			schoolRepo := new(MockSchoolRepository)
+			periodRepo := new(MockAcademicPeriodRepository)
+			service := NewAcademicUnitService(unitRepo, schoolRepo, periodRepo, new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+			schoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+			periodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+			unitRepo.On("FindByType", mock.Anything, schoolID, "grade", false).Return([]*entities.AcademicUnit{first, second, previousYear}, nil)
+			unitRepo.On("FindAncestors", mock.Anything, mock.Anything).Return([]*entities.AcademicUnit{}, nil)
+			unitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil).Maybe()
+			unitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, schoolID, tt.existing).Return(true, nil).Maybe()
+			unitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, schoolID, mock.Anything).Return(false, nil).Maybe()
+
+			_, err := service.BulkCreateUnits(context.Background(), schoolID.String(), dto.BulkCreateUnitsRequest{
+				ParentTypes: []string{"grade"},
+				Levels: []dto.UnitGeneratorLevel{
+					{Type: "section", Values: []string{"A", "B"}, NameTemplate: "Sección {value}", CodeTemplate: tt.codeTemplate},
+				},
+			})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+			unitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
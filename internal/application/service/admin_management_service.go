@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/crypto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// defaultAdminRequestsLimit es el tamaño de página por defecto del listado de solicitudes
+const defaultAdminRequestsLimit = 50
+
+// AdminManagementService define el alta y la promoción de administradores.
+// Solo los super admins (admin sin escuela) pueden operar; cada cambio requiere
+// re-autenticación MFA del solicitante o la aprobación de un segundo super admin.
+type AdminManagementService interface {
+	// CreateAdmin solicita el alta de un administrador (se aplica de inmediato con MFA válido)
+	CreateAdmin(ctx context.Context, req dto.CreateAdminRequest, actorID string) (*dto.AdminChangeRequestResponse, error)
+
+	// PromoteToAdmin solicita la promoción de un usuario existente a administrador
+	PromoteToAdmin(ctx context.Context, userID string, req dto.PromoteAdminRequest, actorID string) (*dto.AdminChangeRequestResponse, error)
+
+	// ListRequests lista las solicitudes (registro de auditoría)
+	ListRequests(ctx context.Context, req dto.ListAdminChangeRequestsRequest, actorID string) ([]*dto.AdminChangeRequestResponse, error)
+
+	// ApproveRequest aplica una solicitud pendiente; debe aprobarla un super admin distinto del solicitante
+	ApproveRequest(ctx context.Context, id string, req dto.DecideAdminChangeRequest, actorID string) (*dto.AdminChangeRequestResponse, error)
+
+	// RejectRequest rechaza (o el solicitante cancela) una solicitud pendiente
+	RejectRequest(ctx context.Context, id string, req dto.DecideAdminChangeRequest, actorID string) (*dto.AdminChangeRequestResponse, error)
+}
+
+type adminManagementService struct {
+	userRepo       repository.UserRepository
+	schoolRepo     repository.SchoolRepository
+	requestRepo    repository.AdminChangeRequestRepository
+	txManager      repository.TransactionManager
+	mfaVerifier    MFAVerifier
+	tokenRevoker   UserTokenRevoker
+	passwordHasher *crypto.PasswordHasher
+	cfg            config.AdminApprovalConfig
+	logger         logger.Logger
+}
+
+// NewAdminManagementService crea un nuevo AdminManagementService
+func NewAdminManagementService(
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	requestRepo repository.AdminChangeRequestRepository,
+	txManager repository.TransactionManager,
+	mfaVerifier MFAVerifier,
+	tokenRevoker UserTokenRevoker,
+	cfg config.AdminApprovalConfig,
+	logger logger.Logger,
+) AdminManagementService {
+	return &adminManagementService{
+		userRepo:       userRepo,
+		schoolRepo:     schoolRepo,
+		requestRepo:    requestRepo,
+		txManager:      txManager,
+		mfaVerifier:    mfaVerifier,
+		tokenRevoker:   tokenRevoker,
+		passwordHasher: crypto.NewPasswordHasher(12),
+		cfg:            cfg,
+		logger:         logger,
+	}
+}
+
+func (s *adminManagementService) CreateAdmin(ctx context.Context, req dto.CreateAdminRequest, actorID string) (*dto.AdminChangeRequestResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	actor, err := s.requireSuperAdmin(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	exists, err := s.userRepo.ExistsByEmail(ctx, email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check user", err)
+	}
+	if exists {
+		return nil, errors.NewAlreadyExistsError("user").WithField("email", email)
+	}
+
+	schoolID, err := s.resolveSchool(ctx, req.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.passwordHasher.Validate(req.Password); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	passwordHash, err := s.passwordHasher.Hash(req.Password)
+	if err != nil {
+		return nil, errors.NewDatabaseError("hash password", err)
+	}
+
+	now := time.Now()
+	request := &repository.AdminChangeRequest{
+		ID:           uuid.New(),
+		Action:       repository.AdminChangeActionCreate,
+		Email:        email,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		PasswordHash: passwordHash,
+		SchoolID:     schoolID,
+		Reason:       req.Reason,
+		RequestedBy:  actor.ID,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(s.cfg.TTL),
+	}
+
+	return s.submit(ctx, request, actor, req.MFACode)
+}
+
+func (s *adminManagementService) PromoteToAdmin(ctx context.Context, userID string, req dto.PromoteAdminRequest, actorID string) (*dto.AdminChangeRequestResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	actor, err := s.requireSuperAdmin(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id format")
+	}
+	if targetID == actor.ID {
+		return nil, errors.NewBusinessRuleError("admins cannot change their own role")
+	}
+
+	target, err := s.userRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user", err)
+	}
+	if target == nil {
+		return nil, errors.NewNotFoundError("user").WithField("id", userID)
+	}
+	if !target.IsActive {
+		return nil, errors.NewBusinessRuleError("cannot promote an inactive user")
+	}
+
+	schoolID, err := s.resolveSchool(ctx, req.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Role == string(enum.SystemRoleAdmin) && sameSchool(target.SchoolID, schoolID) {
+		return nil, errors.NewBusinessRuleError("user is already an admin with this scope")
+	}
+
+	now := time.Now()
+	request := &repository.AdminChangeRequest{
+		ID:           uuid.New(),
+		Action:       repository.AdminChangeActionPromote,
+		TargetUserID: &target.ID,
+		Email:        strings.ToLower(target.Email),
+		FirstName:    target.FirstName,
+		LastName:     target.LastName,
+		SchoolID:     schoolID,
+		Reason:       req.Reason,
+		RequestedBy:  actor.ID,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(s.cfg.TTL),
+	}
+
+	return s.submit(ctx, request, actor, req.MFACode)
+}
+
+func (s *adminManagementService) ListRequests(ctx context.Context, req dto.ListAdminChangeRequestsRequest, actorID string) ([]*dto.AdminChangeRequestResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireSuperAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	filters := repository.AdminChangeRequestFilters{Status: req.Status, Limit: req.Limit, Offset: req.Offset}
+	if filters.Limit <= 0 {
+		filters.Limit = defaultAdminRequestsLimit
+	}
+
+	requests, err := s.requestRepo.List(ctx, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list admin change requests", err)
+	}
+
+	now := time.Now()
+	responses := make([]*dto.AdminChangeRequestResponse, len(requests))
+	for i, request := range requests {
+		responses[i] = dto.ToAdminChangeRequestResponse(request, now)
+	}
+	return responses, nil
+}
+
+func (s *adminManagementService) ApproveRequest(ctx context.Context, id string, req dto.DecideAdminChangeRequest, actorID string) (*dto.AdminChangeRequestResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	actor, request, err := s.loadPending(ctx, id, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if request.RequestedBy == actor.ID {
+		return nil, errors.NewBusinessRuleError("the request must be approved by a different super admin")
+	}
+
+	request.DecisionNote = req.Note
+	if err := s.apply(ctx, request, actor, repository.AdminApprovalSecondAdmin, false); err != nil {
+		return nil, err
+	}
+	return dto.ToAdminChangeRequestResponse(request, time.Now()), nil
+}
+
+func (s *adminManagementService) RejectRequest(ctx context.Context, id string, req dto.DecideAdminChangeRequest, actorID string) (*dto.AdminChangeRequestResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	actor, request, err := s.loadPending(ctx, id, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	request.Status = repository.AdminChangeStatusRejected
+	request.PasswordHash = ""
+	request.DecidedBy = &actor.ID
+	request.DecidedAt = &now
+	request.DecisionNote = req.Note
+	if err := s.requestRepo.Update(ctx, request); err != nil {
+		return nil, errors.NewDatabaseError("update admin change request", err)
+	}
+
+	s.logger.Info("admin change request rejected",
+		"entity_type", "admin_change_request",
+		"entity_id", request.ID.String(),
+		"action", request.Action,
+		"email", request.Email,
+		"decided_by", actor.ID.String(),
+	)
+	return dto.ToAdminChangeRequestResponse(request, now), nil
+}
+
+// submit aplica la solicitud si el solicitante se re-autentica con MFA; si no, la deja pendiente
+func (s *adminManagementService) submit(ctx context.Context, request *repository.AdminChangeRequest, actor *entities.User, mfaCode string) (*dto.AdminChangeRequestResponse, error) {
+	pending, err := s.requestRepo.FindPendingByEmail(ctx, request.Email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find admin change request", err)
+	}
+	if pending != nil {
+		return nil, errors.NewConflictError("there is already a pending admin change request for this email")
+	}
+
+	if mfaCode != "" {
+		if err := s.mfaVerifier.VerifyCode(ctx, actor.ID, mfaCode); err != nil {
+			return nil, err
+		}
+		if err := s.apply(ctx, request, actor, repository.AdminApprovalMFA, true); err != nil {
+			return nil, err
+		}
+		return dto.ToAdminChangeRequestResponse(request, time.Now()), nil
+	}
+
+	request.Status = repository.AdminChangeStatusPending
+	if err := s.requestRepo.Create(ctx, request); err != nil {
+		return nil, errors.NewDatabaseError("create admin change request", err)
+	}
+
+	s.logger.Info("admin change request pending approval",
+		"entity_type", "admin_change_request",
+		"entity_id", request.ID.String(),
+		"action", request.Action,
+		"email", request.Email,
+		"requested_by", actor.ID.String(),
+	)
+	return dto.ToAdminChangeRequestResponse(request, time.Now()), nil
+}
+
+// apply ejecuta el alta o la promoción y registra la decisión en la misma transacción.
+// isNew indica que la solicitud aún no existe (aplicación inmediata con MFA).
+func (s *adminManagementService) apply(ctx context.Context, request *repository.AdminChangeRequest, approver *entities.User, method string, isNew bool) error {
+	now := time.Now()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		switch request.Action {
+		case repository.AdminChangeActionCreate:
+			exists, err := s.userRepo.ExistsByEmail(ctx, request.Email)
+			if err != nil {
+				return errors.NewDatabaseError("check user", err)
+			}
+			if exists {
+				return errors.NewAlreadyExistsError("user").WithField("email", request.Email)
+			}
+
+			user := &entities.User{
+				ID:           uuid.New(),
+				Email:        request.Email,
+				PasswordHash: request.PasswordHash,
+				FirstName:    request.FirstName,
+				LastName:     request.LastName,
+				Role:         string(enum.SystemRoleAdmin),
+				IsActive:     true,
+				SchoolID:     request.SchoolID,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+			if err := s.userRepo.Create(ctx, user); err != nil {
+				return errors.NewDatabaseError("create admin user", err)
+			}
+			request.TargetUserID = &user.ID
+
+		case repository.AdminChangeActionPromote:
+			user, err := s.userRepo.FindByID(ctx, *request.TargetUserID)
+			if err != nil {
+				return errors.NewDatabaseError("find user", err)
+			}
+			if user == nil || !user.IsActive {
+				return errors.NewBusinessRuleError("target user no longer exists or is inactive")
+			}
+			user.Role = string(enum.SystemRoleAdmin)
+			user.SchoolID = request.SchoolID
+			user.UpdatedAt = now
+			if err := s.userRepo.Update(ctx, user); err != nil {
+				return errors.NewDatabaseError("promote user", err)
+			}
+		}
+
+		request.Status = repository.AdminChangeStatusApplied
+		request.ApprovalMethod = method
+		request.PasswordHash = ""
+		request.DecidedBy = &approver.ID
+		request.DecidedAt = &now
+
+		if isNew {
+			if err := s.requestRepo.Create(ctx, request); err != nil {
+				return errors.NewDatabaseError("create admin change request", err)
+			}
+			return nil
+		}
+		if err := s.requestRepo.Update(ctx, request); err != nil {
+			return errors.NewDatabaseError("update admin change request", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Los tokens vigentes del promovido llevan el rol anterior
+	if request.Action == repository.AdminChangeActionPromote && request.TargetUserID != nil {
+		revokeUserTokens(ctx, s.tokenRevoker, s.logger, *request.TargetUserID)
+	}
+
+	s.logger.Info("admin change applied",
+		"entity_type", "admin_change_request",
+		"entity_id", request.ID.String(),
+		"action", request.Action,
+		"target_user_id", request.TargetUserID.String(),
+		"approval_method", method,
+		"requested_by", request.RequestedBy.String(),
+		"decided_by", approver.ID.String(),
+	)
+	return nil
+}
+
+// loadPending obtiene una solicitud pendiente y vigente, validando que el actor sea super admin
+func (s *adminManagementService) loadPending(ctx context.Context, id string, actorID string) (*entities.User, *repository.AdminChangeRequest, error) {
+	actor, err := s.requireSuperAdmin(ctx, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil, errors.NewValidationError("invalid request id format")
+	}
+
+	request, err := s.requestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("find admin change request", err)
+	}
+	if request == nil {
+		return nil, nil, errors.NewNotFoundError("admin change request").WithField("id", id)
+	}
+	if request.Status != repository.AdminChangeStatusPending {
+		return nil, nil, errors.NewBusinessRuleError("admin change request is not pending")
+	}
+	if request.IsExpired(time.Now()) {
+		return nil, nil, errors.NewBusinessRuleError("admin change request has expired")
+	}
+	return actor, request, nil
+}
+
+// requireSuperAdmin valida que el actor sea un admin activo sin escuela (alcance global)
+func (s *adminManagementService) requireSuperAdmin(ctx context.Context, actorID string) (*entities.User, error) {
+	id, err := uuid.Parse(actorID)
+	if err != nil {
+		return nil, errors.NewForbiddenError("super admin privileges required")
+	}
+
+	actor, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user", err)
+	}
+	if actor == nil || !actor.IsActive || actor.Role != string(enum.SystemRoleAdmin) || actor.SchoolID != nil {
+		return nil, errors.NewForbiddenError("super admin privileges required")
+	}
+	return actor, nil
+}
+
+// resolveSchool valida la escuela opcional del administrador (nil = super admin)
+func (s *adminManagementService) resolveSchool(ctx context.Context, schoolID string) (*uuid.UUID, error) {
+	if schoolID == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school_id format")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school").WithField("id", schoolID)
+	}
+	return &id, nil
+}
+
+func sameSchool(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// MockAdminChangeRequestRepository mock implementation
+type MockAdminChangeRequestRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminChangeRequestRepository) Create(ctx context.Context, request *repository.AdminChangeRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *MockAdminChangeRequestRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.AdminChangeRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AdminChangeRequest), args.Error(1)
+}
+
+func (m *MockAdminChangeRequestRepository) FindPendingByEmail(ctx context.Context, email string) (*repository.AdminChangeRequest, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AdminChangeRequest), args.Error(1)
+}
+
+func (m *MockAdminChangeRequestRepository) List(ctx context.Context, filters repository.AdminChangeRequestFilters) ([]*repository.AdminChangeRequest, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.AdminChangeRequest), args.Error(1)
+}
+
+func (m *MockAdminChangeRequestRepository) Update(ctx context.Context, request *repository.AdminChangeRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *MockAdminChangeRequestRepository) AnonymizeEmail(ctx context.Context, email, replacement string) (int64, error) {
+	args := m.Called(ctx, email, replacement)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockMFAVerifier mock implementation
+type MockMFAVerifier struct {
+	mock.Mock
+}
+
+func (m *MockMFAVerifier) VerifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func newSuperAdmin() *entities.User {
+	return &entities.User{ID: uuid.New(), Email: "root@edugo.com", Role: "admin", IsActive: true}
+}
+
+func validCreateAdminRequest() dto.CreateAdminRequest {
+	return dto.CreateAdminRequest{
+		Email:     "nuevo.admin@edugo.com",
+		Password:  "Sup3r$ecret",
+		FirstName: "Laura",
+		LastName:  "Gomez",
+		Reason:    "nuevo responsable de plataforma",
+	}
+}
+
+func TestCreateAdmin_RequiresSuperAdmin(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRequestRepo := new(MockAdminChangeRequestRepository)
+	svc := NewAdminManagementService(mockUserRepo, new(MockSchoolRepository), mockRequestRepo, passthroughTxManager{},
+		new(MockMFAVerifier), new(MockTokenRevoker), config.AdminApprovalConfig{TTL: 24 * time.Hour}, newTestLogger())
+	schoolID := uuid.New()
+	schoolAdmin := &entities.User{ID: uuid.New(), Role: "admin", IsActive: true, SchoolID: &schoolID}
+	mockUserRepo.On("FindByID", mock.Anything, schoolAdmin.ID).Return(schoolAdmin, nil)
+
+	_, err := svc.CreateAdmin(context.Background(), validCreateAdminRequest(), schoolAdmin.ID.String())
+
+	require.Error(t, err)
+	mockRequestRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateAdmin_WithoutMFAQueuesForApproval(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRequestRepo := new(MockAdminChangeRequestRepository)
+	svc := NewAdminManagementService(mockUserRepo, new(MockSchoolRepository), mockRequestRepo, passthroughTxManager{},
+		new(MockMFAVerifier), new(MockTokenRevoker), config.AdminApprovalConfig{TTL: 24 * time.Hour}, newTestLogger())
+	actor := newSuperAdmin()
+	mockUserRepo.On("FindByID", mock.Anything, actor.ID).Return(actor, nil)
+	mockUserRepo.On("ExistsByEmail", mock.Anything, "nuevo.admin@edugo.com").Return(false, nil)
+	mockRequestRepo.On("FindPendingByEmail", mock.Anything, "nuevo.admin@edugo.com").Return(nil, nil)
+	mockRequestRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *repository.AdminChangeRequest) bool {
+		return r.Status == repository.AdminChangeStatusPending && r.PasswordHash != "" && r.RequestedBy == actor.ID
+	})).Return(nil).Once()
+
+	result, err := svc.CreateAdmin(context.Background(), validCreateAdminRequest(), actor.ID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, repository.AdminChangeStatusPending, result.Status)
+	assert.Nil(t, result.TargetUserID)
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockRequestRepo.AssertExpectations(t)
+}
+
+func TestCreateAdmin_WithMFAAppliesImmediately(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRequestRepo := new(MockAdminChangeRequestRepository)
+	mockMFA := new(MockMFAVerifier)
+	svc := NewAdminManagementService(mockUserRepo, new(MockSchoolRepository), mockRequestRepo, passthroughTxManager{},
+		mockMFA, new(MockTokenRevoker), config.AdminApprovalConfig{TTL: 24 * time.Hour}, newTestLogger())
+	actor := newSuperAdmin()
+	mockUserRepo.On("FindByID", mock.Anything, actor.ID).Return(actor, nil)
+	mockUserRepo.On("ExistsByEmail", mock.Anything, "nuevo.admin@edugo.com").Return(false, nil)
+	mockRequestRepo.On("FindPendingByEmail", mock.Anything, "nuevo.admin@edugo.com").Return(nil, nil)
+	mockMFA.On("VerifyCode", mock.Anything, actor.ID, "123456").Return(nil).Once()
+	mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.Role == "admin" && u.SchoolID == nil && u.PasswordHash != ""
+	})).Return(nil).Once()
+	mockRequestRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *repository.AdminChangeRequest) bool {
+		return r.Status == repository.AdminChangeStatusApplied && r.ApprovalMethod == repository.AdminApprovalMFA &&
+			r.PasswordHash == "" && r.TargetUserID != nil
+	})).Return(nil).Once()
+
+	req := validCreateAdminRequest()
+	req.MFACode = "123456"
+	result, err := svc.CreateAdmin(context.Background(), req, actor.ID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, repository.AdminChangeStatusApplied, result.Status)
+	assert.NotNil(t, result.TargetUserID)
+	mockUserRepo.AssertExpectations(t)
+	mockRequestRepo.AssertExpectations(t)
+}
+
+func TestCreateAdmin_InvalidMFADoesNotApply(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRequestRepo := new(MockAdminChangeRequestRepository)
+	mockMFA := new(MockMFAVerifier)
+	svc := NewAdminManagementService(mockUserRepo, new(MockSchoolRepository), mockRequestRepo, passthroughTxManager{},
+		mockMFA, new(MockTokenRevoker), config.AdminApprovalConfig{TTL: 24 * time.Hour}, newTestLogger())
+	actor := newSuperAdmin()
+	mockUserRepo.On("FindByID", mock.Anything, actor.ID).Return(actor, nil)
+	mockUserRepo.On("ExistsByEmail", mock.Anything, mock.Anything).Return(false, nil)
+	mockRequestRepo.On("FindPendingByEmail", mock.Anything, mock.Anything).Return(nil, nil)
+	mockMFA.On("VerifyCode", mock.Anything, actor.ID, "000000").Return(errors.NewValidationError("invalid mfa code"))
+
+	req := validCreateAdminRequest()
+	req.MFACode = "000000"
+	_, err := svc.CreateAdmin(context.Background(), req, actor.ID.String())
+
+	require.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockRequestRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestApproveRequest_RequiresSecondAdmin(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRequestRepo := new(MockAdminChangeRequestRepository)
+	mockRevoker := new(MockTokenRevoker)
+	svc := NewAdminManagementService(mockUserRepo, new(MockSchoolRepository), mockRequestRepo, passthroughTxManager{},
+		new(MockMFAVerifier), mockRevoker, config.AdminApprovalConfig{TTL: 24 * time.Hour}, newTestLogger())
+	requester, approver := newSuperAdmin(), newSuperAdmin()
+	target := &entities.User{ID: uuid.New(), Email: "docente@edugo.com", Role: "teacher", IsActive: true}
+	request := &repository.AdminChangeRequest{
+		ID:           uuid.New(),
+		Action:       repository.AdminChangeActionPromote,
+		TargetUserID: &target.ID,
+		Email:        target.Email,
+		Status:       repository.AdminChangeStatusPending,
+		RequestedBy:  requester.ID,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+	mockUserRepo.On("FindByID", mock.Anything, requester.ID).Return(requester, nil)
+	mockUserRepo.On("FindByID", mock.Anything, approver.ID).Return(approver, nil)
+	mockUserRepo.On("FindByID", mock.Anything, target.ID).Return(target, nil)
+	mockRequestRepo.On("FindByID", mock.Anything, request.ID).Return(request, nil)
+
+	_, err := svc.ApproveRequest(context.Background(), request.ID.String(), dto.DecideAdminChangeRequest{}, requester.ID.String())
+	require.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.ID == target.ID && u.Role == "admin"
+	})).Return(nil).Once()
+	mockRequestRepo.On("Update", mock.Anything, mock.MatchedBy(func(r *repository.AdminChangeRequest) bool {
+		return r.Status == repository.AdminChangeStatusApplied && r.ApprovalMethod == repository.AdminApprovalSecondAdmin &&
+			*r.DecidedBy == approver.ID
+	})).Return(nil).Once()
+	mockRevoker.On("RevokeUserTokens", mock.Anything, target.ID.String()).Return(nil).Once()
+
+	result, err := svc.ApproveRequest(context.Background(), request.ID.String(), dto.DecideAdminChangeRequest{Note: "ok"}, approver.ID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, repository.AdminChangeStatusApplied, result.Status)
+	mockUserRepo.AssertExpectations(t)
+	mockRequestRepo.AssertExpectations(t)
+	mockRevoker.AssertExpectations(t)
+}
+
+func TestApproveRequest_Expired(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRequestRepo := new(MockAdminChangeRequestRepository)
+	svc := NewAdminManagementService(mockUserRepo, new(MockSchoolRepository), mockRequestRepo, passthroughTxManager{},
+		new(MockMFAVerifier), new(MockTokenRevoker), config.AdminApprovalConfig{TTL: 24 * time.Hour}, newTestLogger())
+	approver := newSuperAdmin()
+	request := &repository.AdminChangeRequest{
+		ID:          uuid.New(),
+		Action:      repository.AdminChangeActionCreate,
+		Status:      repository.AdminChangeStatusPending,
+		RequestedBy: uuid.New(),
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	}
+	mockUserRepo.On("FindByID", mock.Anything, approver.ID).Return(approver, nil)
+	mockRequestRepo.On("FindByID", mock.Anything, request.ID).Return(request, nil)
+
+	_, err := svc.ApproveRequest(context.Background(), request.ID.String(), dto.DecideAdminChangeRequest{}, approver.ID.String())
+
+	require.Error(t, err)
+	mockRequestRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
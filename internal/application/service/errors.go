@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// isNotFoundError reconoce los repositorios que reportan "no existe" como error en vez de nil
+func isNotFoundError(err error) bool {
+	appErr, ok := errors.GetAppError(err)
+	return ok && appErr.StatusCode == http.StatusNotFound
+}
+
+// ensureSchoolWritable rechaza cambios sobre las unidades y membresías de una escuela archivada,
+// que queda de solo lectura hasta que se restaure
+func ensureSchoolWritable(ctx context.Context, schoolRepo repository.SchoolRepository, schoolID uuid.UUID) error {
+	school, err := schoolRepo.FindByID(ctx, schoolID)
+	if err != nil && !isNotFoundError(err) {
+		return errors.NewDatabaseError("find school", err)
+	}
+	if err == nil && school != nil {
+		return nil
+	}
+	return missingSchoolError(ctx, schoolRepo, schoolID)
+}
+
+// missingSchoolError distingue una escuela archivada, que existe pero es de solo lectura, de una inexistente
+func missingSchoolError(ctx context.Context, schoolRepo repository.SchoolRepository, schoolID uuid.UUID) error {
+	archived, err := schoolRepo.FindArchivedByID(ctx, schoolID)
+	if err != nil {
+		return errors.NewDatabaseError("find school", err)
+	}
+	if archived != nil {
+		return errors.NewBusinessRuleError("school is archived and read-only")
+	}
+	return errors.NewNotFoundError("school")
+}
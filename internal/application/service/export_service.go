@@ -0,0 +1,502 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/spreadsheet"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// exportBatchSize es el tamaño de página usado al recorrer usuarios para exportar
+const exportBatchSize = 500
+
+// ExportService define las operaciones de exportación de datos de una escuela.
+// Las exportaciones se escriben directamente en w a medida que se leen los datos.
+// Los errores de validación se retornan antes de escribir el primer byte.
+// Las exportaciones de una escuela requieren que su plan incluya el feature exports.
+type ExportService interface {
+	ExportUsers(ctx context.Context, req dto.ExportUsersRequest, w io.Writer) error
+	ExportUnitMemberships(ctx context.Context, req dto.ExportMembershipsRequest, w io.Writer) error
+	ExportGuardianRelations(ctx context.Context, req dto.ExportGuardianRelationsRequest, w io.Writer) error
+}
+
+type exportService struct {
+	userRepo       repository.UserRepository
+	unitRepo       repository.AcademicUnitRepository
+	membershipRepo repository.UnitMembershipRepository
+	guardianRepo   repository.GuardianRepository
+	subscriptions  SubscriptionService
+	logger         logger.Logger
+}
+
+// NewExportService crea un nuevo ExportService
+func NewExportService(
+	userRepo repository.UserRepository,
+	unitRepo repository.AcademicUnitRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	guardianRepo repository.GuardianRepository,
+	subscriptions SubscriptionService,
+	logger logger.Logger,
+) ExportService {
+	return &exportService{
+		userRepo:       userRepo,
+		unitRepo:       unitRepo,
+		membershipRepo: membershipRepo,
+		guardianRepo:   guardianRepo,
+		subscriptions:  subscriptions,
+		logger:         logger,
+	}
+}
+
+// exportColumn define una columna exportable y cómo obtener su valor
+type exportColumn[T any] struct {
+	name  string
+	value func(T) string
+}
+
+// selectColumns filtra y ordena las columnas según lo solicitado (vacío = todas)
+func selectColumns[T any](available []exportColumn[T], requested []string) ([]exportColumn[T], error) {
+	if len(requested) == 0 {
+		return available, nil
+	}
+
+	byName := make(map[string]exportColumn[T], len(available))
+	for _, col := range available {
+		byName[col.name] = col
+	}
+
+	selected := make([]exportColumn[T], 0, len(requested))
+	for _, name := range requested {
+		col, ok := byName[name]
+		if !ok {
+			return nil, errors.NewValidationError("unknown export column").WithField("column", name)
+		}
+		selected = append(selected, col)
+	}
+	return selected, nil
+}
+
+func columnNames[T any](columns []exportColumn[T]) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.name
+	}
+	return names
+}
+
+func columnValues[T any](columns []exportColumn[T], record T) []string {
+	values := make([]string, len(columns))
+	for i, col := range columns {
+		values[i] = col.value(record)
+	}
+	return values
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func fullName(u *entities.User) string {
+	if u == nil {
+		return ""
+	}
+	return u.FirstName + " " + u.LastName
+}
+
+// ==================== USERS ====================
+
+var userExportColumns = []exportColumn[*entities.User]{
+	{"id", func(u *entities.User) string { return u.ID.String() }},
+	{"email", func(u *entities.User) string { return u.Email }},
+	{"first_name", func(u *entities.User) string { return u.FirstName }},
+	{"last_name", func(u *entities.User) string { return u.LastName }},
+	{"full_name", fullName},
+	{"role", func(u *entities.User) string { return u.Role }},
+	{"is_active", func(u *entities.User) string { return strconv.FormatBool(u.IsActive) }},
+	{"email_verified", func(u *entities.User) string { return strconv.FormatBool(u.EmailVerified) }},
+	{"created_at", func(u *entities.User) string { return formatTime(u.CreatedAt) }},
+	{"updated_at", func(u *entities.User) string { return formatTime(u.UpdatedAt) }},
+}
+
+func (s *exportService) ExportUsers(ctx context.Context, req dto.ExportUsersRequest, w io.Writer) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	columns, err := selectColumns(userExportColumns, req.ColumnList())
+	if err != nil {
+		return err
+	}
+
+	filters := repository.ListFilters{IsActive: req.IsActive, Limit: exportBatchSize}
+	if req.Role != "" {
+		filters.Role = &req.Role
+	}
+	if req.SchoolID != "" {
+		schoolID, _ := uuid.Parse(req.SchoolID)
+		if err := s.ensureExportsEnabled(ctx, schoolID); err != nil {
+			return err
+		}
+		filters.SchoolID = &schoolID
+	}
+
+	out, err := s.newWriter(req.ExportOptions, w, columnNames(columns))
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for {
+		users, err := s.userRepo.List(ctx, filters)
+		if err != nil {
+			return errors.NewDatabaseError("list users", err)
+		}
+
+		for _, user := range users {
+			if err := out.WriteRow(columnValues(columns, user)); err != nil {
+				return err
+			}
+		}
+		total += len(users)
+
+		if len(users) < exportBatchSize {
+			break
+		}
+		filters.Offset += exportBatchSize
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	s.logger.Info("entities exported", "entity_type", "user", "format", req.Format, "rows", total)
+	return nil
+}
+
+// ==================== MEMBERSHIPS ====================
+
+// membershipExportRow combina la membresía con los datos del usuario
+type membershipExportRow struct {
+	membership *entities.Membership
+	user       *entities.User
+}
+
+var membershipExportColumns = []exportColumn[membershipExportRow]{
+	{"id", func(r membershipExportRow) string { return r.membership.ID.String() }},
+	{"unit_id", func(r membershipExportRow) string {
+		if r.membership.AcademicUnitID == nil {
+			return ""
+		}
+		return r.membership.AcademicUnitID.String()
+	}},
+	{"user_id", func(r membershipExportRow) string { return r.membership.UserID.String() }},
+	{"email", func(r membershipExportRow) string {
+		if r.user == nil {
+			return ""
+		}
+		return r.user.Email
+	}},
+	{"full_name", func(r membershipExportRow) string { return fullName(r.user) }},
+	{"role", func(r membershipExportRow) string { return r.membership.Role }},
+	{"is_active", func(r membershipExportRow) string { return strconv.FormatBool(r.membership.IsActive) }},
+	{"enrolled_at", func(r membershipExportRow) string { return formatTime(r.membership.EnrolledAt) }},
+	{"withdrawn_at", func(r membershipExportRow) string { return formatTimePtr(r.membership.WithdrawnAt) }},
+}
+
+func (s *exportService) ExportUnitMemberships(ctx context.Context, req dto.ExportMembershipsRequest, w io.Writer) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	columns, err := selectColumns(membershipExportColumns, req.ColumnList())
+	if err != nil {
+		return err
+	}
+
+	unitID, _ := uuid.Parse(req.UnitID)
+	if err := s.ensureUnitExportable(ctx, unitID); err != nil {
+		return err
+	}
+
+	filters := repository.MembershipListFilters{ActiveOnly: req.ActiveOnly, Limit: exportBatchSize}
+	if req.Role != "" {
+		if _, err := valueobject.ParseMembershipRole(req.Role); err != nil {
+			return errors.NewValidationError(err.Error())
+		}
+		filters.Role = req.Role
+	}
+
+	out, err := s.newWriter(req.ExportOptions, w, columnNames(columns))
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for {
+		memberships, err := s.membershipRepo.ListByUnit(ctx, unitID, filters)
+		if err != nil {
+			return errors.NewDatabaseError("list memberships", err)
+		}
+
+		// Los usuarios de la página se leen en una sola consulta
+		users := newUserLookup(s.userRepo)
+		userIDs := make([]uuid.UUID, len(memberships))
+		for i, membership := range memberships {
+			userIDs[i] = membership.UserID
+		}
+		if err := users.load(ctx, userIDs); err != nil {
+			return err
+		}
+
+		for _, membership := range memberships {
+			user, err := users.get(ctx, membership.UserID)
+			if err != nil {
+				return err
+			}
+			row := membershipExportRow{membership: membership, user: user}
+			if err := out.WriteRow(columnValues(columns, row)); err != nil {
+				return err
+			}
+		}
+		total += len(memberships)
+
+		if len(memberships) < exportBatchSize {
+			break
+		}
+		filters.Offset += exportBatchSize
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	s.logger.Info("entities exported", "entity_type", "membership", "unit_id", req.UnitID, "format", req.Format, "rows", total)
+	return nil
+}
+
+// ==================== GUARDIAN RELATIONS ====================
+
+type guardianExportRow struct {
+	relation *entities.GuardianRelation
+	guardian *entities.User
+	student  *entities.User
+}
+
+var guardianExportColumns = []exportColumn[guardianExportRow]{
+	{"id", func(r guardianExportRow) string { return r.relation.ID.String() }},
+	{"guardian_id", func(r guardianExportRow) string { return r.relation.GuardianID.String() }},
+	{"guardian_email", func(r guardianExportRow) string {
+		if r.guardian == nil {
+			return ""
+		}
+		return r.guardian.Email
+	}},
+	{"guardian_name", func(r guardianExportRow) string { return fullName(r.guardian) }},
+	{"student_id", func(r guardianExportRow) string { return r.relation.StudentID.String() }},
+	{"student_email", func(r guardianExportRow) string {
+		if r.student == nil {
+			return ""
+		}
+		return r.student.Email
+	}},
+	{"student_name", func(r guardianExportRow) string { return fullName(r.student) }},
+	{"relationship_type", func(r guardianExportRow) string { return r.relation.RelationshipType }},
+	{"is_active", func(r guardianExportRow) string { return strconv.FormatBool(r.relation.IsActive) }},
+	{"created_at", func(r guardianExportRow) string { return formatTime(r.relation.CreatedAt) }},
+}
+
+func (s *exportService) ExportGuardianRelations(ctx context.Context, req dto.ExportGuardianRelationsRequest, w io.Writer) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	columns, err := selectColumns(guardianExportColumns, req.ColumnList())
+	if err != nil {
+		return err
+	}
+
+	unitID, _ := uuid.Parse(req.UnitID)
+	if err := s.ensureUnitExportable(ctx, unitID); err != nil {
+		return err
+	}
+
+	out, err := s.newWriter(req.ExportOptions, w, columnNames(columns))
+	if err != nil {
+		return err
+	}
+
+	// Se recorren los estudiantes activos de la unidad por páginas; las relaciones
+	// y los usuarios de cada página se leen con una consulta cada uno
+	filters := repository.MembershipListFilters{
+		Role:       string(valueobject.RoleStudent),
+		ActiveOnly: true,
+		Limit:      exportBatchSize,
+	}
+	total := 0
+	for {
+		students, err := s.membershipRepo.ListByUnit(ctx, unitID, filters)
+		if err != nil {
+			return errors.NewDatabaseError("list memberships", err)
+		}
+
+		studentIDs := make([]uuid.UUID, len(students))
+		for i, student := range students {
+			studentIDs[i] = student.UserID
+		}
+		relations, err := s.guardianRepo.FindByStudents(ctx, studentIDs, req.ActiveOnly)
+		if err != nil {
+			return errors.NewDatabaseError("find guardian relations", err)
+		}
+
+		users := newUserLookup(s.userRepo)
+		userIDs := make([]uuid.UUID, 0, 2*len(relations))
+		for _, relation := range relations {
+			userIDs = append(userIDs, relation.GuardianID, relation.StudentID)
+		}
+		if err := users.load(ctx, userIDs); err != nil {
+			return err
+		}
+
+		for _, relation := range relations {
+			guardian, err := users.get(ctx, relation.GuardianID)
+			if err != nil {
+				return err
+			}
+			student, err := users.get(ctx, relation.StudentID)
+			if err != nil {
+				return err
+			}
+			row := guardianExportRow{relation: relation, guardian: guardian, student: student}
+			if err := out.WriteRow(columnValues(columns, row)); err != nil {
+				return err
+			}
+			total++
+		}
+
+		if len(students) < exportBatchSize {
+			break
+		}
+		filters.Offset += exportBatchSize
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	s.logger.Info("entities exported", "entity_type", "guardian_relation", "unit_id", req.UnitID, "format", req.Format, "rows", total)
+	return nil
+}
+
+// ==================== HELPERS ====================
+
+func (s *exportService) ensureUnitExportable(ctx context.Context, unitID uuid.UUID) error {
+	unit, err := s.unitRepo.FindByID(ctx, unitID, false)
+	if err != nil {
+		if _, ok := errors.GetAppError(err); ok {
+			return err
+		}
+		return errors.NewDatabaseError("find unit", err)
+	}
+	if unit == nil {
+		return errors.NewNotFoundError("academic unit")
+	}
+	return s.ensureExportsEnabled(ctx, unit.SchoolID)
+}
+
+// ensureExportsEnabled valida que el plan de la escuela incluya las exportaciones
+func (s *exportService) ensureExportsEnabled(ctx context.Context, schoolID uuid.UUID) error {
+	enabled, err := s.subscriptions.IsFeatureEnabled(ctx, schoolID, valueobject.FeatureExports)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return errors.NewForbiddenError("exports are not included in the school's subscription tier")
+	}
+	return nil
+}
+
+func (s *exportService) newWriter(opts dto.ExportOptions, w io.Writer, header []string) (spreadsheet.Writer, error) {
+	format, err := opts.OutputFormat()
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	out, err := spreadsheet.NewWriter(format, w)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := out.WriteHeader(header); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// userLookup cachea usuarios ya leídos durante una exportación
+type userLookup struct {
+	repo  repository.UserRepository
+	cache map[uuid.UUID]*entities.User
+}
+
+func newUserLookup(repo repository.UserRepository) *userLookup {
+	return &userLookup{repo: repo, cache: make(map[uuid.UUID]*entities.User)}
+}
+
+// load lee en una sola consulta los usuarios que aún no están en caché
+func (l *userLookup) load(ctx context.Context, ids []uuid.UUID) error {
+	missing := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := l.cache[id]; !ok {
+			l.cache[id] = nil // los que no existen quedan en caché como nil
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	users, err := l.repo.FindByIDs(ctx, missing)
+	if err != nil {
+		for _, id := range missing {
+			delete(l.cache, id)
+		}
+		return errors.NewDatabaseError("find users", err)
+	}
+	for _, user := range users {
+		l.cache[user.ID] = user
+	}
+	return nil
+}
+
+// get retorna el usuario o nil si no existe (la fila se exporta igual, sin datos del usuario).
+// Los errores de base de datos se retornan: una falla de lectura no deja celdas vacías.
+func (l *userLookup) get(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	if user, ok := l.cache[id]; ok {
+		return user, nil
+	}
+	user, err := l.repo.FindByID(ctx, id)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return nil, errors.NewDatabaseError("find user", err)
+		}
+		user = nil
+	}
+	l.cache[id] = user
+	return user, nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+func TestExportUsers_SelectedColumnsCSV(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewExportService(mockRepo, nil, nil, nil, nil, newTestLogger())
+
+	users := []*entities.User{
+		{ID: uuid.New(), Email: "a@example.com", FirstName: "Ana", LastName: "Diaz", Role: "teacher", IsActive: true},
+		{ID: uuid.New(), Email: "b@example.com", FirstName: "Luis", LastName: "Rojas", Role: "teacher", IsActive: true},
+	}
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f repository.ListFilters) bool {
+		return f.Role != nil && *f.Role == "teacher" && f.Offset == 0
+	})).Return(users, nil).Once()
+
+	req := dto.ExportUsersRequest{
+		ExportOptions: dto.ExportOptions{Format: "csv", Columns: "email, full_name"},
+		Role:          "teacher",
+	}
+
+	var buf bytes.Buffer
+	err := service.ExportUsers(context.Background(), req, &buf)
+
+	require.NoError(t, err)
+	assert.Equal(t, "email,full_name\na@example.com,Ana Diaz\nb@example.com,Luis Rojas\n", buf.String())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExportUsers_UnknownColumn(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewExportService(mockRepo, nil, nil, nil, nil, newTestLogger())
+
+	req := dto.ExportUsersRequest{ExportOptions: dto.ExportOptions{Columns: "email,password_hash"}}
+
+	var buf bytes.Buffer
+	err := service.ExportUsers(context.Background(), req, &buf)
+
+	require.Error(t, err)
+	assert.Empty(t, buf.String())
+	mockRepo.AssertNotCalled(t, "List")
+}
+
+func TestExportUsers_NDJSON(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewExportService(mockRepo, nil, nil, nil, nil, newTestLogger())
+
+	mockRepo.On("List", mock.Anything, mock.Anything).
+		Return([]*entities.User{{ID: uuid.New(), Email: "a@example.com"}}, nil).Once()
+
+	req := dto.ExportUsersRequest{ExportOptions: dto.ExportOptions{Format: "ndjson", Columns: "email"}}
+
+	var buf bytes.Buffer
+	require.NoError(t, service.ExportUsers(context.Background(), req, &buf))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), `{"email":"a@example.com"}`)
+}
+
+func TestExportUnitMemberships_ActiveOnlyWithoutRole(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	unitRepo := new(MockAcademicUnitRepository)
+	membershipRepo := new(MockUnitMembershipRepository)
+	service := NewExportService(userRepo, unitRepo, membershipRepo, nil, allFeaturesEnabled(), newTestLogger())
+
+	unitID := uuid.New()
+	user := &entities.User{ID: uuid.New(), Email: "a@example.com"}
+	unitRepo.On("FindByID", mock.Anything, unitID, false).Return(&entities.AcademicUnit{ID: unitID}, nil)
+	membershipRepo.On("ListByUnit", mock.Anything, unitID, repository.MembershipListFilters{
+		ActiveOnly: true,
+		Limit:      exportBatchSize,
+	}).Return([]*entities.Membership{{ID: uuid.New(), UserID: user.ID, Role: "teacher", IsActive: true}}, nil).Once()
+	userRepo.On("FindByIDs", mock.Anything, []uuid.UUID{user.ID}).Return([]*entities.User{user}, nil).Once()
+
+	req := dto.ExportMembershipsRequest{
+		ExportOptions: dto.ExportOptions{Format: "csv", Columns: "email,role"},
+		UnitID:        unitID.String(),
+		ActiveOnly:    true,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, service.ExportUnitMemberships(context.Background(), req, &buf))
+	assert.Equal(t, "email,role\na@example.com,teacher\n", buf.String())
+	membershipRepo.AssertExpectations(t)
+	userRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestExportUnitMemberships_UserLookupError(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	unitRepo := new(MockAcademicUnitRepository)
+	membershipRepo := new(MockUnitMembershipRepository)
+	service := NewExportService(userRepo, unitRepo, membershipRepo, nil, allFeaturesEnabled(), newTestLogger())
+
+	unitID := uuid.New()
+	unitRepo.On("FindByID", mock.Anything, unitID, false).Return(&entities.AcademicUnit{ID: unitID}, nil)
+	membershipRepo.On("ListByUnit", mock.Anything, unitID, mock.Anything).
+		Return([]*entities.Membership{{ID: uuid.New(), UserID: uuid.New(), Role: "teacher"}}, nil).Once()
+	userRepo.On("FindByIDs", mock.Anything, mock.Anything).Return(nil, stderrors.New("connection reset"))
+
+	req := dto.ExportMembershipsRequest{
+		ExportOptions: dto.ExportOptions{Format: "csv", Columns: "email"},
+		UnitID:        unitID.String(),
+	}
+
+	var buf bytes.Buffer
+	err := service.ExportUnitMemberships(context.Background(), req, &buf)
+
+	require.Error(t, err)
+	membershipRepo.AssertExpectations(t)
+}
+
+func TestExportGuardianRelations_OneQueryPerPage(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	unitRepo := new(MockAcademicUnitRepository)
+	membershipRepo := new(MockUnitMembershipRepository)
+	guardianRepo := new(MockGuardianRepository)
+	service := NewExportService(userRepo, unitRepo, membershipRepo, guardianRepo, allFeaturesEnabled(), newTestLogger())
+
+	unitID := uuid.New()
+	guardian := &entities.User{ID: uuid.New(), Email: "parent@example.com"}
+	studentA := &entities.User{ID: uuid.New(), Email: "a@example.com"}
+	studentB := &entities.User{ID: uuid.New(), Email: "b@example.com"}
+
+	unitRepo.On("FindByID", mock.Anything, unitID, false).Return(&entities.AcademicUnit{ID: unitID}, nil)
+	membershipRepo.On("ListByUnit", mock.Anything, unitID, mock.MatchedBy(func(f repository.MembershipListFilters) bool {
+		return f.Role == "student" && f.ActiveOnly && f.Offset == 0
+	})).Return([]*entities.Membership{{UserID: studentA.ID}, {UserID: studentB.ID}}, nil).Once()
+	guardianRepo.On("FindByStudents", mock.Anything, []uuid.UUID{studentA.ID, studentB.ID}, false).
+		Return([]*entities.GuardianRelation{
+			{ID: uuid.New(), GuardianID: guardian.ID, StudentID: studentA.ID, IsActive: true},
+			{ID: uuid.New(), GuardianID: guardian.ID, StudentID: studentB.ID, IsActive: false},
+		}, nil).Once()
+	userRepo.On("FindByIDs", mock.Anything, mock.Anything).
+		Return([]*entities.User{guardian, studentA, studentB}, nil).Once()
+
+	req := dto.ExportGuardianRelationsRequest{
+		ExportOptions: dto.ExportOptions{Format: "csv", Columns: "guardian_email,student_email,is_active"},
+		UnitID:        unitID.String(),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, service.ExportGuardianRelations(context.Background(), req, &buf))
+	assert.Equal(t, "guardian_email,student_email,is_active\n"+
+		"parent@example.com,a@example.com,true\n"+
+		"parent@example.com,b@example.com,false\n", buf.String())
+	guardianRepo.AssertExpectations(t)
+	guardianRepo.AssertNotCalled(t, "FindByStudent", mock.Anything, mock.Anything)
+	userRepo.AssertExpectations(t)
+}
+
+func TestExportUnitMemberships_RequiresExportsFeature(t *testing.T) {
+	unitRepo := new(MockAcademicUnitRepository)
+	membershipRepo := new(MockUnitMembershipRepository)
+	schoolRepo := new(MockSchoolRepository)
+	subscriptions := NewSubscriptionService(schoolRepo, new(MockAcademicUnitRepository), new(MockUnitMembershipRepository), new(MockSubscriptionChangeRepository),
+		passthroughTxManager{}, testSubscriptionsConfig(), newTestLogger())
+	service := NewExportService(new(MockUserRepository), unitRepo, membershipRepo, nil, subscriptions, newTestLogger())
+
+	school := &entities.School{ID: uuid.New(), SubscriptionTier: "free"}
+	unitID := uuid.New()
+	schoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	unitRepo.On("FindByID", mock.Anything, unitID, false).Return(&entities.AcademicUnit{ID: unitID, SchoolID: school.ID}, nil)
+
+	req := dto.ExportMembershipsRequest{
+		ExportOptions: dto.ExportOptions{Format: "csv", Columns: "email"},
+		UnitID:        unitID.String(),
+	}
+
+	var buf bytes.Buffer
+	err := service.ExportUnitMemberships(context.Background(), req, &buf)
+
+	require.Error(t, err)
+	assert.Empty(t, buf.String())
+	membershipRepo.AssertNotCalled(t, "ListByUnit", mock.Anything, mock.Anything, mock.Anything)
+}
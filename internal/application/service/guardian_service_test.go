@@ -217,3 +217,16 @@ func TestGetGuardianRelations_Success(t *testing.T) {
 	assert.Len(t, results, 1)
 	mockRepo.AssertExpectations(t)
 }
+
+func (m *MockGuardianRepository) ReassignRelation(ctx context.Context, id, guardianID, studentID uuid.UUID) error {
+	args := m.Called(ctx, id, guardianID, studentID)
+	return args.Error(0)
+}
+
+func (m *MockGuardianRepository) FindByStudents(ctx context.Context, studentIDs []uuid.UUID, activeOnly bool) ([]*entities.GuardianRelation, error) {
+	args := m.Called(ctx, studentIDs, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.GuardianRelation), args.Error(1)
+}
@@ -101,12 +101,15 @@ func (s *HierarchyService) GetUnitTree(ctx context.Context, unitID uuid.UUID) (*
 		return nil, nil, errors.NewNotFoundError("unit")
 	}
 
-	// Obtener todos los descendientes (implementación simple)
-	// TODO: mejorar con query recursivo o ltree
-	descendants, err := s.unitRepo.FindBySchoolID(ctx, unit.SchoolID, false)
+	// Descendientes con una sola consulta recursiva
+	subtree, err := s.unitRepo.FindDescendants(ctx, unit.ID, 0, false)
 	if err != nil {
 		return nil, nil, err
 	}
+	descendants := make([]*entities.AcademicUnit, len(subtree))
+	for i, descendant := range subtree {
+		descendants[i] = descendant.Unit
+	}
 
 	return unit, descendants, nil
 }
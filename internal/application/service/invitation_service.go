@@ -0,0 +1,576 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	stderrors "errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/crypto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// defaultInvitationTTL se usa si la configuración no define la vigencia del link
+const defaultInvitationTTL = 72 * time.Hour
+
+// InvitationSender entrega el link de invitación al invitado (email, cola de notificaciones, etc.)
+type InvitationSender interface {
+	SendInvitation(ctx context.Context, invitation *repository.Invitation, acceptURL string) error
+}
+
+// InvitationService define el onboarding de usuarios a una escuela mediante invitaciones
+type InvitationService interface {
+	// CreateInvitation invita un email a una escuela (y opcionalmente una unidad) con un rol de membresía
+	CreateInvitation(ctx context.Context, req dto.CreateInvitationRequest, invitedBy string) (*dto.InvitationResponse, error)
+
+	// ListInvitations lista las invitaciones de una escuela
+	ListInvitations(ctx context.Context, req dto.ListInvitationsRequest) ([]*dto.InvitationResponse, error)
+
+	// ResendInvitation genera un nuevo link (invalida el anterior) y renueva la vigencia
+	ResendInvitation(ctx context.Context, id string) (*dto.InvitationResponse, error)
+
+	// RevokeInvitation revoca una invitación pendiente
+	RevokeInvitation(ctx context.Context, id string) (*dto.InvitationResponse, error)
+
+	// AcceptInvitation crea o vincula el usuario y crea la membresía en una sola transacción
+	AcceptInvitation(ctx context.Context, req dto.AcceptInvitationRequest) (*dto.AcceptInvitationResponse, error)
+}
+
+type invitationService struct {
+	invitationRepo repository.InvitationRepository
+	userRepo       repository.UserRepository
+	schoolRepo     repository.SchoolRepository
+	unitRepo       repository.AcademicUnitRepository
+	membershipRepo repository.UnitMembershipRepository
+	// membershipService da de alta las membresías de unidad con las mismas reglas que CreateMembership
+	membershipService UnitMembershipService
+	quotaService      SchoolQuotaService
+	txManager         repository.TransactionManager
+	signer            *crypto.SignedTokenSigner
+	passwordHasher    *crypto.PasswordHasher
+	sender            InvitationSender
+	config            config.InvitationsConfig
+	logger            logger.Logger
+}
+
+// NewInvitationService crea un nuevo InvitationService
+func NewInvitationService(
+	invitationRepo repository.InvitationRepository,
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	unitRepo repository.AcademicUnitRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	membershipService UnitMembershipService,
+	quotaService SchoolQuotaService,
+	txManager repository.TransactionManager,
+	signer *crypto.SignedTokenSigner,
+	sender InvitationSender,
+	cfg config.InvitationsConfig,
+	logger logger.Logger,
+) InvitationService {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultInvitationTTL
+	}
+	return &invitationService{
+		invitationRepo:    invitationRepo,
+		userRepo:          userRepo,
+		schoolRepo:        schoolRepo,
+		unitRepo:          unitRepo,
+		membershipRepo:    membershipRepo,
+		membershipService: membershipService,
+		quotaService:      quotaService,
+		txManager:         txManager,
+		signer:            signer,
+		passwordHasher:    crypto.NewPasswordHasher(12), // bcrypt cost 12 para producción
+		sender:            sender,
+		config:            cfg,
+		logger:            logger,
+	}
+}
+
+func (s *invitationService) CreateInvitation(ctx context.Context, req dto.CreateInvitationRequest, invitedBy string) (*dto.InvitationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	schoolID, _ := uuid.Parse(req.SchoolID)
+
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+
+	var unitID *uuid.UUID
+	if req.UnitID != "" {
+		id, _ := uuid.Parse(req.UnitID)
+		unit, err := s.unitRepo.FindByID(ctx, id, false)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find unit", err)
+		}
+		if unit == nil {
+			return nil, errors.NewNotFoundError("academic unit")
+		}
+		if unit.SchoolID != schoolID {
+			return nil, errors.NewValidationError("unit does not belong to school").WithField("unit_id", req.UnitID)
+		}
+		unitID = &id
+	}
+
+	userRole := req.UserRole
+	if userRole == "" {
+		userRole = systemRoleForMembership(req.Role)
+	}
+	if enum.SystemRole(userRole) == enum.SystemRoleAdmin {
+		return nil, errors.NewBusinessRuleError("cannot invite admin users")
+	}
+
+	pending, err := s.invitationRepo.FindPendingByEmail(ctx, schoolID, email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find invitation", err)
+	}
+	if pending != nil {
+		return nil, errors.NewConflictError("a pending invitation already exists for this email; resend it instead")
+	}
+
+	// Si el email ya tiene cuenta, verificar que no sea ya miembro
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user", err)
+	}
+	if user != nil {
+		isMember, err := s.hasActiveMembership(ctx, user.ID, schoolID, unitID)
+		if err != nil {
+			return nil, err
+		}
+		if isMember {
+			return nil, errors.NewAlreadyExistsError("membership").WithField("email", email)
+		}
+	}
+
+	now := time.Now()
+	invitation := &repository.Invitation{
+		ID:             uuid.New(),
+		Email:          email,
+		SchoolID:       schoolID,
+		AcademicUnitID: unitID,
+		Role:           req.Role,
+		UserRole:       userRole,
+		Status:         repository.InvitationStatusPending,
+		InvitedBy:      invitedBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	token, err := s.issueToken(invitation, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, errors.NewDatabaseError("create invitation", err)
+	}
+
+	s.logger.Info("entity created",
+		"entity_type", "invitation",
+		"entity_id", invitation.ID.String(),
+		"school_id", schoolID.String(),
+		"role", invitation.Role,
+		"invited_by", invitedBy,
+	)
+
+	return s.deliver(ctx, invitation, token), nil
+}
+
+func (s *invitationService) ListInvitations(ctx context.Context, req dto.ListInvitationsRequest) ([]*dto.InvitationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	schoolID, _ := uuid.Parse(req.SchoolID)
+	filters := repository.InvitationFilters{Limit: req.Limit, Offset: req.Offset}
+	if req.Status != "" {
+		filters.Status = &req.Status
+	}
+
+	invitations, err := s.invitationRepo.ListBySchool(ctx, schoolID, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list invitations", err)
+	}
+
+	responses := make([]*dto.InvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		responses[i] = dto.ToInvitationResponse(invitation)
+	}
+	return responses, nil
+}
+
+func (s *invitationService) ResendInvitation(ctx context.Context, id string) (*dto.InvitationResponse, error) {
+	var invitation *repository.Invitation
+	var token string
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		invitation, err = s.lockInvitation(ctx, id)
+		if err != nil {
+			return err
+		}
+		// Una invitación pendiente vencida se puede reenviar: el nuevo link renueva la vigencia
+		if invitation.Status != repository.InvitationStatusPending {
+			return errors.NewBusinessRuleError("only pending invitations can be resent").
+				WithField("status", invitation.Status)
+		}
+
+		now := time.Now()
+		token, err = s.issueToken(invitation, now)
+		if err != nil {
+			return err
+		}
+		invitation.UpdatedAt = now
+
+		if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+			return errors.NewDatabaseError("update invitation", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("invitation resent",
+		"entity_type", "invitation",
+		"entity_id", invitation.ID.String(),
+		"sent_count", invitation.SentCount,
+	)
+
+	return s.deliver(ctx, invitation, token), nil
+}
+
+func (s *invitationService) RevokeInvitation(ctx context.Context, id string) (*dto.InvitationResponse, error) {
+	var invitation *repository.Invitation
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		invitation, err = s.lockInvitation(ctx, id)
+		if err != nil {
+			return err
+		}
+		if invitation.Status != repository.InvitationStatusPending {
+			return errors.NewBusinessRuleError("only pending invitations can be revoked").
+				WithField("status", invitation.Status)
+		}
+
+		now := time.Now()
+		invitation.Status = repository.InvitationStatusRevoked
+		invitation.RevokedAt = &now
+		invitation.UpdatedAt = now
+
+		if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+			return errors.NewDatabaseError("update invitation", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("invitation revoked",
+		"entity_type", "invitation",
+		"entity_id", invitation.ID.String(),
+	)
+
+	return dto.ToInvitationResponse(invitation), nil
+}
+
+func (s *invitationService) AcceptInvitation(ctx context.Context, req dto.AcceptInvitationRequest) (*dto.AcceptInvitationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.signer.Verify(req.Token)
+	if err != nil {
+		if stderrors.Is(err, crypto.ErrTokenExpired) {
+			return nil, errors.NewBusinessRuleError("invitation has expired")
+		}
+		return nil, errors.NewValidationError("invalid invitation token")
+	}
+
+	var response *dto.AcceptInvitationResponse
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// El bloqueo serializa aceptaciones, reenvíos y revocaciones concurrentes del mismo link
+		invitation, err := s.lockInvitation(ctx, claims.Subject)
+		if err != nil {
+			return err
+		}
+
+		// Solo el último link enviado es válido
+		if subtle.ConstantTimeCompare([]byte(crypto.HashNonce(claims.Nonce)), []byte(invitation.TokenHash)) != 1 {
+			return errors.NewValidationError("invitation link is no longer valid")
+		}
+		if invitation.Status != repository.InvitationStatusPending {
+			return errors.NewBusinessRuleError("invitation is no longer pending").WithField("status", invitation.Status)
+		}
+
+		now := time.Now()
+		if invitation.IsExpired(now) {
+			return errors.NewBusinessRuleError("invitation has expired")
+		}
+
+		user, created, err := s.resolveInvitedUser(ctx, invitation, req, now)
+		if err != nil {
+			return err
+		}
+
+		membership, err := s.createInvitedMembership(ctx, invitation, user.ID, now)
+		if err != nil {
+			return err
+		}
+
+		invitation.Status = repository.InvitationStatusAccepted
+		invitation.AcceptedUserID = &user.ID
+		invitation.AcceptedAt = &now
+		invitation.UpdatedAt = now
+		if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+			return errors.NewDatabaseError("update invitation", err)
+		}
+
+		response = &dto.AcceptInvitationResponse{
+			InvitationID: invitation.ID.String(),
+			UserID:       user.ID.String(),
+			MembershipID: membership.ID,
+			UserCreated:  created,
+			Waitlist:     membership.Waitlist,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("invitation accepted",
+		"entity_type", "invitation",
+		"entity_id", response.InvitationID,
+		"user_id", response.UserID,
+		"user_created", response.UserCreated,
+	)
+
+	return response, nil
+}
+
+// resolveInvitedUser vincula la cuenta existente del email o crea una nueva con el password indicado.
+// Las cuentas existentes conservan su password.
+func (s *invitationService) resolveInvitedUser(
+	ctx context.Context,
+	invitation *repository.Invitation,
+	req dto.AcceptInvitationRequest,
+	now time.Time,
+) (*entities.User, bool, error) {
+	user, err := s.userRepo.FindByEmail(ctx, invitation.Email)
+	if err != nil {
+		return nil, false, errors.NewDatabaseError("find user", err)
+	}
+
+	if user != nil {
+		if !user.IsActive {
+			return nil, false, errors.NewBusinessRuleError("user account is inactive")
+		}
+		// El link se entrega al administrador, no al buzón: aceptar no verifica el email
+		return user, false, nil
+	}
+
+	if err := req.ValidateNewUser(); err != nil {
+		return nil, false, err
+	}
+	if err := s.passwordHasher.Validate(req.Password); err != nil {
+		return nil, false, errors.NewValidationError(err.Error())
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(req.Password)
+	if err != nil {
+		return nil, false, errors.NewDatabaseError("hash password", err)
+	}
+
+	user = &entities.User{
+		ID:            uuid.New(),
+		Email:         invitation.Email,
+		PasswordHash:  passwordHash,
+		FirstName:     req.FirstName,
+		LastName:      req.LastName,
+		Role:          invitation.UserRole,
+		IsActive:      true,
+		EmailVerified: false,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, false, errors.NewDatabaseError("create user", err)
+	}
+	return user, true, nil
+}
+
+// createInvitedMembership da de alta la membresía de la invitación aceptada. Las de unidad pasan por
+// CreateMembership (unidad vigente, año lectivo abierto, rol del tipo de unidad, cupo y lista de espera);
+// las de escuela revalidan que la escuela siga existiendo y no esté archivada antes del cupo del plan.
+func (s *invitationService) createInvitedMembership(
+	ctx context.Context,
+	invitation *repository.Invitation,
+	userID uuid.UUID,
+	now time.Time,
+) (*dto.MembershipResponse, error) {
+	if invitation.AcademicUnitID != nil {
+		return s.membershipService.CreateMembership(ctx, dto.CreateMembershipRequest{
+			UnitID: invitation.AcademicUnitID.String(),
+			UserID: userID.String(),
+			Role:   invitation.Role,
+		})
+	}
+	if err := ensureSchoolWritable(ctx, s.schoolRepo, invitation.SchoolID); err != nil {
+		return nil, err
+	}
+
+	isMember, err := s.hasActiveMembership(ctx, userID, invitation.SchoolID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, errors.NewAlreadyExistsError("active membership for this user and school")
+	}
+	if err := s.quotaService.CheckMembershipQuota(ctx, invitation.SchoolID, userID, invitation.Role); err != nil {
+		return nil, err
+	}
+
+	membership := &entities.Membership{
+		ID:         uuid.New(),
+		UserID:     userID,
+		SchoolID:   invitation.SchoolID,
+		Role:       invitation.Role,
+		Metadata:   []byte("{}"),
+		IsActive:   true,
+		EnrolledAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.membershipRepo.Create(ctx, membership); err != nil {
+		return nil, errors.NewDatabaseError("create membership", err)
+	}
+	response := dto.ToMembershipResponse(membership)
+	return &response, nil
+}
+
+// hasActiveMembership verifica si el usuario ya pertenece a la unidad (o a la escuela si no hay unidad)
+func (s *invitationService) hasActiveMembership(ctx context.Context, userID, schoolID uuid.UUID, unitID *uuid.UUID) (bool, error) {
+	if unitID != nil {
+		exists, err := s.membershipRepo.ExistsByUnitAndUser(ctx, *unitID, userID)
+		if err != nil {
+			return false, errors.NewDatabaseError("check membership", err)
+		}
+		return exists, nil
+	}
+
+	membership, err := s.membershipRepo.FindByUserAndSchool(ctx, userID, schoolID)
+	if err != nil {
+		return false, errors.NewDatabaseError("check membership", err)
+	}
+	return membership != nil && membership.IsActive && membership.WithdrawnAt == nil, nil
+}
+
+// issueToken firma un nuevo link para la invitación; el anterior deja de ser válido
+func (s *invitationService) issueToken(invitation *repository.Invitation, now time.Time) (string, error) {
+	token, claims, err := s.signer.Sign(invitation.ID.String(), now.Add(s.config.TTL))
+	if err != nil {
+		return "", errors.NewDatabaseError("sign invitation token", err)
+	}
+
+	invitation.TokenHash = crypto.HashNonce(claims.Nonce)
+	invitation.ExpiresAt = claims.ExpiresAt
+	invitation.LastSentAt = now
+	invitation.SentCount++
+	return token, nil
+}
+
+// deliver envía el link al invitado; un fallo de envío no revierte la invitación
+// porque el administrador puede reenviarla
+func (s *invitationService) deliver(ctx context.Context, invitation *repository.Invitation, token string) *dto.InvitationResponse {
+	acceptURL := s.acceptURL(token)
+
+	if s.sender != nil {
+		if err := s.sender.SendInvitation(ctx, invitation, acceptURL); err != nil {
+			s.logger.Error("failed to send invitation",
+				"invitation_id", invitation.ID.String(),
+				"error", err.Error(),
+			)
+		}
+	}
+
+	response := dto.ToInvitationResponse(invitation)
+	response.AcceptURL = acceptURL
+	return response
+}
+
+func (s *invitationService) acceptURL(token string) string {
+	separator := "?"
+	if strings.Contains(s.config.AcceptURL, "?") {
+		separator = "&"
+	}
+	return s.config.AcceptURL + separator + "token=" + url.QueryEscape(token)
+}
+
+// lockInvitation busca la invitación bloqueando su fila; debe llamarse dentro de una transacción
+func (s *invitationService) lockInvitation(ctx context.Context, id string) (*repository.Invitation, error) {
+	invitationID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid invitation_id format")
+	}
+
+	invitation, err := s.invitationRepo.FindByIDForUpdate(ctx, invitationID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find invitation", err)
+	}
+	if invitation == nil {
+		return nil, errors.NewNotFoundError("invitation").WithField("id", id)
+	}
+	return invitation, nil
+}
+
+// systemRoleForMembership deriva el rol de sistema de una cuenta nueva a partir del rol de membresía
+func systemRoleForMembership(role string) string {
+	switch valueobject.MembershipRole(role) {
+	case valueobject.RoleStudent:
+		return string(enum.SystemRoleStudent)
+	case valueobject.RoleGuardian:
+		return string(enum.SystemRoleGuardian)
+	default:
+		return string(enum.SystemRoleTeacher)
+	}
+}
+
+// logInvitationSender registra el envío en el log; se usa mientras no haya un servicio de email.
+// El link no se registra para no exponer el token: el administrador lo recibe en la respuesta.
+type logInvitationSender struct {
+	logger logger.Logger
+}
+
+// NewLogInvitationSender crea un InvitationSender que solo registra el envío
+func NewLogInvitationSender(logger logger.Logger) InvitationSender {
+	return &logInvitationSender{logger: logger}
+}
+
+func (l *logInvitationSender) SendInvitation(ctx context.Context, invitation *repository.Invitation, acceptURL string) error {
+	l.logger.Info("invitation issued",
+		"invitation_id", invitation.ID.String(),
+		"email", invitation.Email,
+		"expires_at", invitation.ExpiresAt,
+	)
+	return nil
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/crypto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// MockInvitationRepository mock implementation
+type MockInvitationRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvitationRepository) Create(ctx context.Context, invitation *repository.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.Invitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*repository.Invitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) FindPendingByEmail(ctx context.Context, schoolID uuid.UUID, email string) (*repository.Invitation, error) {
+	args := m.Called(ctx, schoolID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID, filters repository.InvitationFilters) ([]*repository.Invitation, error) {
+	args := m.Called(ctx, schoolID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) Update(ctx context.Context, invitation *repository.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) AnonymizeEmail(ctx context.Context, email, replacement string) (int64, error) {
+	args := m.Called(ctx, email, replacement)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockInvitationRepository) ReassignPendingEmail(ctx context.Context, fromEmail, toEmail string) (int64, error) {
+	args := m.Called(ctx, fromEmail, toEmail)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+const testInvitationSecret = "test-secret-key-minimum-32-characters-long"
+
+func newTestInvitationService(
+	invitationRepo *MockInvitationRepository,
+	userRepo *MockUserRepository,
+	schoolRepo *MockSchoolRepository,
+	unitRepo *MockAcademicUnitRepository,
+	membershipRepo *MockUnitMembershipRepository,
+) InvitationService {
+	quotaService := NewSchoolQuotaService(schoolRepo, unitRepo, membershipRepo, config.QuotaDefaults{}, config.SubscriptionsConfig{}, newTestLogger())
+	return NewInvitationService(
+		invitationRepo, userRepo, schoolRepo, unitRepo, membershipRepo,
+		NewUnitMembershipService(membershipRepo, unitRepo, schoolRepo, new(MockAcademicPeriodRepository), noCustomUnitTypes(), quotaService, noUnitCapacities(), passthroughTxManager{}, newTestLogger()),
+		quotaService,
+		passthroughTxManager{},
+		crypto.NewSignedTokenSigner(testInvitationSecret),
+		nil,
+		config.InvitationsConfig{TTL: time.Hour, AcceptURL: "https://app.test/accept"},
+		newTestLogger(),
+	)
+}
+
+func tokenFromAcceptURL(t *testing.T, acceptURL string) string {
+	parsed, err := url.Parse(acceptURL)
+	require.NoError(t, err)
+	return parsed.Query().Get("token")
+}
+
+func TestCreateAndAcceptInvitation_NewUser(t *testing.T) {
+	mockInvitationRepo := new(MockInvitationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	service := newTestInvitationService(mockInvitationRepo, mockUserRepo, mockSchoolRepo, nil, mockMembershipRepo)
+
+	schoolID := uuid.New()
+	var stored *repository.Invitation
+
+	mockSchoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	mockInvitationRepo.On("FindPendingByEmail", mock.Anything, schoolID, "new.teacher@example.com").Return(nil, nil)
+	mockUserRepo.On("FindByEmail", mock.Anything, "new.teacher@example.com").Return(nil, nil)
+	mockInvitationRepo.On("Create", mock.Anything, mock.AnythingOfType("*repository.Invitation")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*repository.Invitation) }).
+		Return(nil).Once()
+
+	created, err := service.CreateInvitation(context.Background(), dto.CreateInvitationRequest{
+		Email:    "New.Teacher@example.com",
+		SchoolID: schoolID.String(),
+		Role:     "teacher",
+	}, "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, repository.InvitationStatusPending, created.Status)
+	assert.Equal(t, 1, created.SentCount)
+	require.NotEmpty(t, created.AcceptURL)
+	require.NotNil(t, stored)
+	assert.Equal(t, "teacher", stored.UserRole)
+
+	mockInvitationRepo.On("FindByIDForUpdate", mock.Anything, stored.ID).Return(stored, nil)
+	mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.Email == "new.teacher@example.com" && !u.EmailVerified && u.PasswordHash != ""
+	})).Return(nil).Once()
+	mockMembershipRepo.On("FindByUserAndSchool", mock.Anything, mock.Anything, schoolID).Return(nil, nil)
+	mockMembershipRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *entities.Membership) bool {
+		return m.SchoolID == schoolID && m.Role == "teacher" && m.IsActive
+	})).Return(nil).Once()
+	mockInvitationRepo.On("Update", mock.Anything, mock.MatchedBy(func(i *repository.Invitation) bool {
+		return i.Status == repository.InvitationStatusAccepted && i.AcceptedUserID != nil
+	})).Return(nil).Once()
+
+	accepted, err := service.AcceptInvitation(context.Background(), dto.AcceptInvitationRequest{
+		Token:     tokenFromAcceptURL(t, created.AcceptURL),
+		Password:  "Secret123",
+		FirstName: "Ana",
+		LastName:  "Diaz",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, accepted.UserCreated)
+	mockUserRepo.AssertExpectations(t)
+	mockMembershipRepo.AssertExpectations(t)
+	mockInvitationRepo.AssertExpectations(t)
+}
+
+func TestAcceptInvitation_SupersededLink(t *testing.T) {
+	mockInvitationRepo := new(MockInvitationRepository)
+	service := newTestInvitationService(mockInvitationRepo, nil, nil, nil, nil)
+
+	signer := crypto.NewSignedTokenSigner(testInvitationSecret)
+	invitationID := uuid.New()
+	oldToken, _, err := signer.Sign(invitationID.String(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	_, latest, err := signer.Sign(invitationID.String(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	mockInvitationRepo.On("FindByIDForUpdate", mock.Anything, invitationID).Return(&repository.Invitation{
+		ID:        invitationID,
+		Status:    repository.InvitationStatusPending,
+		TokenHash: crypto.HashNonce(latest.Nonce),
+		ExpiresAt: latest.ExpiresAt,
+	}, nil)
+
+	_, err = service.AcceptInvitation(context.Background(), dto.AcceptInvitationRequest{Token: oldToken})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no longer valid")
+	mockInvitationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAcceptInvitation_Revoked(t *testing.T) {
+	mockInvitationRepo := new(MockInvitationRepository)
+	service := newTestInvitationService(mockInvitationRepo, nil, nil, nil, nil)
+
+	signer := crypto.NewSignedTokenSigner(testInvitationSecret)
+	invitationID := uuid.New()
+	token, claims, err := signer.Sign(invitationID.String(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	mockInvitationRepo.On("FindByIDForUpdate", mock.Anything, invitationID).Return(&repository.Invitation{
+		ID:        invitationID,
+		Status:    repository.InvitationStatusRevoked,
+		TokenHash: crypto.HashNonce(claims.Nonce),
+		ExpiresAt: claims.ExpiresAt,
+	}, nil)
+
+	_, err = service.AcceptInvitation(context.Background(), dto.AcceptInvitationRequest{Token: token})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no longer pending")
+}
+
+// pendingInvitation registra en el mock una invitación pendiente para un usuario existente y retorna su token
+func pendingInvitation(t *testing.T, invitationRepo *MockInvitationRepository, userRepo *MockUserRepository, invitation *repository.Invitation) string {
+	token, claims, err := crypto.NewSignedTokenSigner(testInvitationSecret).Sign(invitation.ID.String(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	invitation.Status = repository.InvitationStatusPending
+	invitation.TokenHash = crypto.HashNonce(claims.Nonce)
+	invitation.ExpiresAt = claims.ExpiresAt
+	invitationRepo.On("FindByIDForUpdate", mock.Anything, invitation.ID).Return(invitation, nil)
+	userRepo.On("FindByEmail", mock.Anything, invitation.Email).Return(&entities.User{ID: uuid.New(), Email: invitation.Email, IsActive: true}, nil)
+	return token
+}
+
+func TestAcceptInvitation_UnitDeletedSinceInvite(t *testing.T) {
+	mockInvitationRepo := new(MockInvitationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	service := newTestInvitationService(mockInvitationRepo, mockUserRepo, liveSchools(), mockUnitRepo, mockMembershipRepo)
+
+	unitID := uuid.New()
+	token := pendingInvitation(t, mockInvitationRepo, mockUserRepo, &repository.Invitation{
+		ID: uuid.New(), Email: "student@example.com", SchoolID: uuid.New(), AcademicUnitID: &unitID, Role: "student",
+	})
+	mockUnitRepo.On("FindByID", mock.Anything, unitID, false).Return(nil, nil)
+
+	_, err := service.AcceptInvitation(context.Background(), dto.AcceptInvitationRequest{Token: token})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	mockMembershipRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockInvitationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAcceptInvitation_SchoolArchivedSinceInvite(t *testing.T) {
+	mockInvitationRepo := new(MockInvitationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	service := newTestInvitationService(mockInvitationRepo, mockUserRepo, mockSchoolRepo, nil, mockMembershipRepo)
+
+	school := &entities.School{ID: uuid.New()}
+	token := pendingInvitation(t, mockInvitationRepo, mockUserRepo, &repository.Invitation{
+		ID: uuid.New(), Email: "teacher@example.com", SchoolID: school.ID, Role: "teacher",
+	})
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(nil, errors.NewNotFoundError("school"))
+	mockSchoolRepo.On("FindArchivedByID", mock.Anything, school.ID).Return(school, nil)
+
+	_, err := service.AcceptInvitation(context.Background(), dto.AcceptInvitationRequest{Token: token})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "archived")
+	mockMembershipRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockInvitationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCreateInvitation_PendingExists(t *testing.T) {
+	mockInvitationRepo := new(MockInvitationRepository)
+	mockSchoolRepo := new(MockSchoolRepository)
+	service := newTestInvitationService(mockInvitationRepo, nil, mockSchoolRepo, nil, nil)
+
+	schoolID := uuid.New()
+	mockSchoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	mockInvitationRepo.On("FindPendingByEmail", mock.Anything, schoolID, "a@example.com").
+		Return(&repository.Invitation{ID: uuid.New(), Status: repository.InvitationStatusPending}, nil)
+
+	_, err := service.CreateInvitation(context.Background(), dto.CreateInvitationRequest{
+		Email:    "a@example.com",
+		SchoolID: schoolID.String(),
+		Role:     "student",
+	}, "admin-1")
+
+	require.Error(t, err)
+	mockInvitationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestResendInvitation_RotatesLink(t *testing.T) {
+	mockInvitationRepo := new(MockInvitationRepository)
+	service := newTestInvitationService(mockInvitationRepo, nil, nil, nil, nil)
+
+	invitation := &repository.Invitation{
+		ID:        uuid.New(),
+		Status:    repository.InvitationStatusPending,
+		TokenHash: "old-hash",
+		SentCount: 1,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+	mockInvitationRepo.On("FindByIDForUpdate", mock.Anything, invitation.ID).Return(invitation, nil)
+	mockInvitationRepo.On("Update", mock.Anything, mock.MatchedBy(func(i *repository.Invitation) bool {
+		return i.TokenHash != "old-hash" && i.SentCount == 2 && i.ExpiresAt.After(time.Now())
+	})).Return(nil).Once()
+
+	resent, err := service.ResendInvitation(context.Background(), invitation.ID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, repository.InvitationStatusPending, resent.Status)
+	assert.NotEmpty(t, resent.AcceptURL)
+	mockInvitationRepo.AssertExpectations(t)
+}
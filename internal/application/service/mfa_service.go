@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/crypto"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMFAMaxAttempts  = 5
+	defaultMFALockDuration = 15 * time.Minute
+)
+
+// MFAVerifier verifica un código del segundo factor de un usuario (re-autenticación)
+type MFAVerifier interface {
+	VerifyCode(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+// MFAService define el enrolamiento y la verificación del segundo factor (TOTP)
+type MFAService interface {
+	MFAVerifier
+
+	// GetStatus obtiene el estado del MFA del usuario
+	GetStatus(ctx context.Context, userID string) (*dto.MFAStatusResponse, error)
+
+	// Enroll genera un nuevo secret; queda pendiente hasta confirmarlo
+	Enroll(ctx context.Context, userID string, req dto.EnrollMFARequest) (*dto.MFAEnrollmentResponse, error)
+
+	// Confirm activa el MFA con el primer código válido
+	Confirm(ctx context.Context, userID string, req dto.MFACodeRequest) (*dto.MFAStatusResponse, error)
+
+	// Disable desactiva el MFA (requiere un código vigente)
+	Disable(ctx context.Context, userID string, req dto.MFACodeRequest) error
+}
+
+type mfaService struct {
+	userRepo repository.UserRepository
+	mfaRepo  repository.UserMFARepository
+	cfg      config.MFAConfig
+	now      func() time.Time
+	logger   logger.Logger
+}
+
+// NewMFAService crea un nuevo MFAService
+func NewMFAService(
+	userRepo repository.UserRepository,
+	mfaRepo repository.UserMFARepository,
+	cfg config.MFAConfig,
+	logger logger.Logger,
+) MFAService {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMFAMaxAttempts
+	}
+	if cfg.LockDuration <= 0 {
+		cfg.LockDuration = defaultMFALockDuration
+	}
+	return &mfaService{
+		userRepo: userRepo,
+		mfaRepo:  mfaRepo,
+		cfg:      cfg,
+		now:      time.Now,
+		logger:   logger,
+	}
+}
+
+func (s *mfaService) GetStatus(ctx context.Context, userID string) (*dto.MFAStatusResponse, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id format")
+	}
+
+	mfa, err := s.mfaRepo.FindByUserID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user mfa", err)
+	}
+	return toMFAStatusResponse(mfa), nil
+}
+
+func (s *mfaService) Enroll(ctx context.Context, userID string, req dto.EnrollMFARequest) (*dto.MFAEnrollmentResponse, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id format")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user", err)
+	}
+	if user == nil {
+		return nil, errors.NewNotFoundError("user")
+	}
+
+	existing, err := s.mfaRepo.FindByUserID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user mfa", err)
+	}
+	// Reemplazar un MFA activo exige demostrar posesión del dispositivo actual
+	if existing != nil && existing.IsEnabled() {
+		if err := s.VerifyCode(ctx, id, req.Code); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := crypto.GenerateTOTPSecret()
+	if err != nil {
+		return nil, errors.NewDatabaseError("generate mfa secret", err)
+	}
+
+	now := s.now()
+	mfa := &repository.UserMFA{
+		UserID:    id,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.mfaRepo.Upsert(ctx, mfa); err != nil {
+		return nil, errors.NewDatabaseError("save user mfa", err)
+	}
+
+	s.logger.Info("mfa enrollment started", "user_id", id.String())
+
+	return &dto.MFAEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: crypto.TOTPProvisioningURI(s.cfg.Issuer, user.Email, secret),
+	}, nil
+}
+
+func (s *mfaService) Confirm(ctx context.Context, userID string, req dto.MFACodeRequest) (*dto.MFAStatusResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id format")
+	}
+
+	mfa, err := s.mfaRepo.FindByUserID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user mfa", err)
+	}
+	if mfa == nil {
+		return nil, errors.NewBusinessRuleError("mfa enrollment not started")
+	}
+	if mfa.IsEnabled() {
+		return nil, errors.NewBusinessRuleError("mfa is already enabled")
+	}
+
+	now := s.now()
+	step, err := s.matchCode(ctx, mfa, req.Code, now)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa.EnabledAt = &now
+	mfa.LastUsedAt = &now
+	mfa.LastStep = step
+	mfa.FailedAttempts = 0
+	mfa.UpdatedAt = now
+	if err := s.mfaRepo.Upsert(ctx, mfa); err != nil {
+		return nil, errors.NewDatabaseError("save user mfa", err)
+	}
+
+	s.logger.Info("mfa enabled", "user_id", id.String())
+	return toMFAStatusResponse(mfa), nil
+}
+
+func (s *mfaService) Disable(ctx context.Context, userID string, req dto.MFACodeRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.NewValidationError("invalid user_id format")
+	}
+
+	if err := s.VerifyCode(ctx, id, req.Code); err != nil {
+		return err
+	}
+	if err := s.mfaRepo.Delete(ctx, id); err != nil {
+		return errors.NewDatabaseError("delete user mfa", err)
+	}
+
+	s.logger.Info("mfa disabled", "user_id", id.String())
+	return nil
+}
+
+// VerifyCode valida un código contra el MFA activo del usuario y registra su uso.
+// Cada código se acepta una sola vez: no vale otro del mismo periodo ni de uno anterior.
+func (s *mfaService) VerifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	mfa, err := s.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return errors.NewDatabaseError("find user mfa", err)
+	}
+	if mfa == nil || !mfa.IsEnabled() {
+		return errors.NewBusinessRuleError("mfa is not enabled for this user")
+	}
+
+	now := s.now()
+	step, err := s.matchCode(ctx, mfa, code, now)
+	if err != nil {
+		return err
+	}
+	consumed, err := s.mfaRepo.ConsumeStep(ctx, userID, step, now)
+	if err != nil {
+		return errors.NewDatabaseError("save user mfa", err)
+	}
+	if !consumed {
+		// Otra request aceptó un código de este periodo entre la lectura y el update
+		return s.rejectCode(ctx, mfa, now)
+	}
+	return nil
+}
+
+// matchCode verifica el código y retorna su periodo TOTP. Rechaza los códigos de un periodo ya usado
+// (replay) y cuenta los inválidos: tras MaxAttempts seguidos el MFA queda bloqueado LockDuration.
+func (s *mfaService) matchCode(ctx context.Context, mfa *repository.UserMFA, code string, now time.Time) (int64, error) {
+	if mfa.IsLocked(now) {
+		return 0, errors.NewBusinessRuleError("too many invalid mfa codes, try again later")
+	}
+	step, ok := crypto.MatchTOTP(mfa.Secret, code, now)
+	if !ok || step <= mfa.LastStep {
+		return 0, s.rejectCode(ctx, mfa, now)
+	}
+	return step, nil
+}
+
+// rejectCode registra el intento fallido y retorna el error para el cliente
+func (s *mfaService) rejectCode(ctx context.Context, mfa *repository.UserMFA, now time.Time) error {
+	locked, err := s.mfaRepo.RecordFailedAttempt(ctx, mfa.UserID, s.cfg.MaxAttempts, now.Add(s.cfg.LockDuration), now)
+	if err != nil {
+		return errors.NewDatabaseError("save user mfa", err)
+	}
+	s.logger.Warn("invalid mfa code", "user_id", mfa.UserID.String(), "locked", locked)
+	return errors.NewValidationError("invalid mfa code")
+}
+
+func toMFAStatusResponse(mfa *repository.UserMFA) *dto.MFAStatusResponse {
+	if mfa == nil {
+		return &dto.MFAStatusResponse{}
+	}
+	return &dto.MFAStatusResponse{
+		Enabled:    mfa.IsEnabled(),
+		Pending:    !mfa.IsEnabled(),
+		EnabledAt:  mfa.EnabledAt,
+		LastUsedAt: mfa.LastUsedAt,
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	memrepo "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/persistence/mock/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/crypto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockUserMFARepository mock implementation
+type MockUserMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockUserMFARepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*repository.UserMFA, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UserMFA), args.Error(1)
+}
+
+func (m *MockUserMFARepository) Upsert(ctx context.Context, mfa *repository.UserMFA) error {
+	args := m.Called(ctx, mfa)
+	return args.Error(0)
+}
+
+func (m *MockUserMFARepository) ConsumeStep(ctx context.Context, userID uuid.UUID, step int64, usedAt time.Time) (bool, error) {
+	args := m.Called(ctx, userID, step, usedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserMFARepository) RecordFailedAttempt(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil, at time.Time) (bool, error) {
+	args := m.Called(ctx, userID, maxAttempts, lockedUntil, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserMFARepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestMFA_EnrollAndConfirm(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockMFARepo := new(MockUserMFARepository)
+	svc := NewMFAService(mockUserRepo, mockMFARepo, config.MFAConfig{Issuer: "EduGo"}, newTestLogger())
+
+	user := &entities.User{ID: uuid.New(), Email: "root@edugo.com"}
+	mockUserRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	mockMFARepo.On("FindByUserID", mock.Anything, user.ID).Return(nil, nil).Once()
+	var stored *repository.UserMFA
+	mockMFARepo.On("Upsert", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*repository.UserMFA)
+	}).Return(nil)
+
+	enrollment, err := svc.Enroll(context.Background(), user.ID.String(), dto.EnrollMFARequest{})
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/EduGo")
+	require.NotNil(t, stored)
+	assert.False(t, stored.IsEnabled())
+
+	mockMFARepo.On("FindByUserID", mock.Anything, user.ID).Return(stored, nil)
+	code, err := crypto.TOTPCode(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+
+	status, err := svc.Confirm(context.Background(), user.ID.String(), dto.MFACodeRequest{Code: code})
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+
+	// El código usado al confirmar no vale de nuevo; el del periodo siguiente sí
+	mockMFARepo.On("RecordFailedAttempt", mock.Anything, user.ID, defaultMFAMaxAttempts, mock.Anything, mock.Anything).Return(false, nil).Once()
+	assert.Error(t, svc.VerifyCode(context.Background(), user.ID, code))
+	next, err := crypto.TOTPCode(enrollment.Secret, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+	mockMFARepo.On("ConsumeStep", mock.Anything, user.ID, mock.MatchedBy(func(step int64) bool { return step > stored.LastStep }), mock.Anything).Return(true, nil).Once()
+	assert.NoError(t, svc.VerifyCode(context.Background(), user.ID, next))
+	mockMFARepo.AssertExpectations(t)
+}
+
+// enabledMFA registra un MFA confirmado en el repositorio en memoria y fija el reloj del servicio
+func enabledMFA(t *testing.T, cfg config.MFAConfig, now time.Time) (*mfaService, repository.UserMFARepository, uuid.UUID, string) {
+	mfaRepo := memrepo.NewMockUserMFARepository()
+	svc := NewMFAService(nil, mfaRepo, cfg, newTestLogger()).(*mfaService)
+	svc.now = func() time.Time { return now }
+
+	secret, err := crypto.GenerateTOTPSecret()
+	require.NoError(t, err)
+	userID := uuid.New()
+	enabledAt := now.Add(-time.Hour)
+	require.NoError(t, mfaRepo.Upsert(context.Background(), &repository.UserMFA{UserID: userID, Secret: secret, EnabledAt: &enabledAt}))
+	return svc, mfaRepo, userID, secret
+}
+
+func TestMFA_VerifyCodeRejectsReplay(t *testing.T) {
+	now := time.Now()
+	svc, _, userID, secret := enabledMFA(t, config.MFAConfig{}, now)
+	current, err := crypto.TOTPCode(secret, now)
+	require.NoError(t, err)
+	previous, err := crypto.TOTPCode(secret, now.Add(-30*time.Second))
+	require.NoError(t, err)
+
+	require.NoError(t, svc.VerifyCode(context.Background(), userID, current))
+
+	err = svc.VerifyCode(context.Background(), userID, current)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid mfa code")
+	// Un código de un periodo anterior dentro del desfase tampoco se acepta después
+	assert.Error(t, svc.VerifyCode(context.Background(), userID, previous))
+}
+
+func TestMFA_LocksAfterFailedAttempts(t *testing.T) {
+	now := time.Now()
+	svc, mfaRepo, userID, secret := enabledMFA(t, config.MFAConfig{MaxAttempts: 3, LockDuration: 10 * time.Minute}, now)
+	valid, err := crypto.TOTPCode(secret, now)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		assert.Error(t, svc.VerifyCode(context.Background(), userID, "000000"))
+	}
+	stored, err := mfaRepo.FindByUserID(context.Background(), userID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LockedUntil)
+	assert.True(t, stored.IsLocked(now))
+
+	// Bloqueado, ni un código válido pasa
+	err = svc.VerifyCode(context.Background(), userID, valid)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "try again later")
+
+	// Vencido el bloqueo vuelve a aceptar códigos y reinicia la cuenta
+	later := now.Add(11 * time.Minute)
+	svc.now = func() time.Time { return later }
+	fresh, err := crypto.TOTPCode(secret, later)
+	require.NoError(t, err)
+	require.NoError(t, svc.VerifyCode(context.Background(), userID, fresh))
+	stored, err = mfaRepo.FindByUserID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.LockedUntil)
+	assert.Zero(t, stored.FailedAttempts)
+}
+
+func TestMFA_VerifyCodeRequiresEnabled(t *testing.T) {
+	mockMFARepo := new(MockUserMFARepository)
+	svc := NewMFAService(nil, mockMFARepo, config.MFAConfig{Issuer: "EduGo"}, newTestLogger())
+
+	userID := uuid.New()
+	mockMFARepo.On("FindByUserID", mock.Anything, userID).Return(&repository.UserMFA{UserID: userID, Secret: "GEZDGNBVGY3TQOJQ"}, nil)
+
+	err := svc.VerifyCode(context.Background(), userID, "123456")
+
+	assert.Error(t, err)
+	mockMFARepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/crypto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// OnboardingService da de alta una escuela completa en una sola transacción
+type OnboardingService interface {
+	// ListTemplates lista las plantillas de árbol de unidades disponibles
+	ListTemplates(ctx context.Context) []dto.UnitTreeTemplateResponse
+
+	// OnboardSchool crea la escuela, instancia la plantilla de unidades y crea el primer director con su membresía
+	OnboardSchool(ctx context.Context, req dto.OnboardSchoolRequest) (*dto.OnboardSchoolResponse, error)
+}
+
+type onboardingService struct {
+	schoolService  SchoolService
+	unitRepo       repository.AcademicUnitRepository
+	userRepo       repository.UserRepository
+	membershipRepo repository.UnitMembershipRepository
+	quotaService   SchoolQuotaService
+	txManager      repository.TransactionManager
+	templates      map[string]*UnitTreeTemplate
+	passwordHasher *crypto.PasswordHasher
+	logger         logger.Logger
+}
+
+// NewOnboardingService crea un nuevo OnboardingService
+func NewOnboardingService(
+	schoolService SchoolService,
+	unitRepo repository.AcademicUnitRepository,
+	userRepo repository.UserRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	quotaService SchoolQuotaService,
+	txManager repository.TransactionManager,
+	templates map[string]*UnitTreeTemplate,
+	logger logger.Logger,
+) OnboardingService {
+	return &onboardingService{
+		schoolService:  schoolService,
+		unitRepo:       unitRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		quotaService:   quotaService,
+		txManager:      txManager,
+		templates:      templates,
+		passwordHasher: crypto.NewPasswordHasher(12), // bcrypt cost 12 para producción
+		logger:         logger,
+	}
+}
+
+func (s *onboardingService) ListTemplates(ctx context.Context) []dto.UnitTreeTemplateResponse {
+	names := make([]string, 0, len(s.templates))
+	for name := range s.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	responses := make([]dto.UnitTreeTemplateResponse, len(names))
+	for i, name := range names {
+		template := s.templates[name]
+		displayName := template.DisplayName
+		if displayName == "" {
+			displayName = template.Name
+		}
+		responses[i] = dto.UnitTreeTemplateResponse{
+			Name:        template.Name,
+			DisplayName: displayName,
+			Description: template.Description,
+			UnitCount:   template.UnitCount(),
+			Units:       toUnitTemplateEntries(template.Units),
+		}
+	}
+	return responses
+}
+
+func (s *onboardingService) OnboardSchool(ctx context.Context, req dto.OnboardSchoolRequest) (*dto.OnboardSchoolResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	template := s.templates[req.Template]
+	if template == nil {
+		return nil, errors.NewValidationError("unknown unit tree template").WithField("template", req.Template)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Director.Email))
+	if err := s.passwordHasher.Validate(req.Director.Password); err != nil {
+		return nil, errors.NewValidationError(err.Error()).WithField("field", "director.password")
+	}
+	exists, err := s.userRepo.ExistsByEmail(ctx, email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check user", err)
+	}
+	if exists {
+		return nil, errors.NewAlreadyExistsError("user").WithField("email", email)
+	}
+
+	// El hash se calcula fuera de la transacción para no retenerla durante bcrypt
+	passwordHash, err := s.passwordHasher.Hash(req.Director.Password)
+	if err != nil {
+		return nil, errors.NewDatabaseError("hash password", err)
+	}
+
+	response := &dto.OnboardSchoolResponse{Template: template.Name}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		school, err := s.schoolService.CreateSchool(ctx, req.School)
+		if err != nil {
+			return err
+		}
+		response.School = *school
+		schoolID, err := uuid.Parse(school.ID)
+		if err != nil {
+			return errors.NewDatabaseError("parse school id", err)
+		}
+
+		// El plan por defecto de la escuela nueva puede no alcanzar para la plantilla
+		if err := s.quotaService.CheckUnitQuota(ctx, schoolID, template.UnitCount()); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		units, err := s.createTemplateUnits(ctx, schoolID, nil, template.Units, now)
+		if err != nil {
+			return err
+		}
+		response.Units = units
+
+		director := &entities.User{
+			ID:            uuid.New(),
+			Email:         email,
+			PasswordHash:  passwordHash,
+			FirstName:     req.Director.FirstName,
+			LastName:      req.Director.LastName,
+			Role:          string(enum.SystemRoleAdmin), // admin con escuela = administrador de esa escuela
+			IsActive:      true,
+			EmailVerified: false,
+			SchoolID:      &schoolID,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := s.userRepo.Create(ctx, director); err != nil {
+			return errors.NewDatabaseError("create user", err)
+		}
+		response.Director = dto.ToUserResponse(director)
+
+		// Membresía a nivel escuela (sin unidad)
+		membership := &entities.Membership{
+			ID:         uuid.New(),
+			UserID:     director.ID,
+			SchoolID:   schoolID,
+			Role:       string(valueobject.RoleDirector),
+			Metadata:   []byte("{}"),
+			IsActive:   true,
+			EnrolledAt: now,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		if err := s.membershipRepo.Create(ctx, membership); err != nil {
+			return errors.NewDatabaseError("create membership", err)
+		}
+		response.DirectorMembership = dto.ToMembershipResponse(membership)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("school onboarded",
+		"school_id", response.School.ID,
+		"template", template.Name,
+		"units_created", len(response.Units),
+		"director_id", response.Director.ID,
+	)
+	return response, nil
+}
+
+// createTemplateUnits crea las unidades de la plantilla en orden padre → hijos
+func (s *onboardingService) createTemplateUnits(
+	ctx context.Context,
+	schoolID uuid.UUID,
+	parentID *uuid.UUID,
+	nodes []UnitTemplateNode,
+	now time.Time,
+) ([]dto.AcademicUnitResponse, error) {
+	var created []dto.AcademicUnitResponse
+	for _, node := range nodes {
+		unit := &entities.AcademicUnit{
+			ID:           uuid.New(),
+			ParentUnitID: parentID,
+			SchoolID:     schoolID,
+			Name:         node.Name,
+			Code:         node.Code,
+			Type:         node.Type,
+			Description:  optionalString(node.Description),
+			Level:        optionalString(node.Level),
+			Metadata:     []byte("{}"),
+			IsActive:     true,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if err := s.unitRepo.Create(ctx, unit); err != nil {
+			return nil, errors.NewDatabaseError("create unit", err)
+		}
+		created = append(created, dto.ToAcademicUnitResponse(unit))
+
+		children, err := s.createTemplateUnits(ctx, schoolID, &unit.ID, node.Children, now)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, children...)
+	}
+	return created, nil
+}
+
+func toUnitTemplateEntries(nodes []UnitTemplateNode) []dto.UnitTemplateEntry {
+	entries := make([]dto.UnitTemplateEntry, len(nodes))
+	for i, n := range nodes {
+		entries[i] = dto.UnitTemplateEntry{
+			Type:     n.Type,
+			Name:     n.Name,
+			Code:     n.Code,
+			Level:    n.Level,
+			Children: toUnitTemplateEntries(n.Children),
+		}
+	}
+	return entries
+}
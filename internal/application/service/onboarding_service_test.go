@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+const repoTemplatesDir = "../../../config/unit-templates"
+
+func TestLoadUnitTreeTemplates_RepoTemplates(t *testing.T) {
+	templates, err := LoadUnitTreeTemplates(repoTemplatesDir)
+
+	require.NoError(t, err)
+	require.Contains(t, templates, "co-k11-ab")
+	require.Contains(t, templates, "primaria-basica")
+	assert.Equal(t, 36, templates["co-k11-ab"].UnitCount())
+	assert.Equal(t, 10, templates["primaria-basica"].UnitCount())
+}
+
+func TestLoadUnitTreeTemplates_InvalidTemplate(t *testing.T) {
+	dir := t.TempDir()
+	content := "name: roto\nunits:\n  - type: aula\n    name: Aula 1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "roto.yaml"), []byte(content), 0o600))
+
+	_, err := LoadUnitTreeTemplates(dir)
+	assert.Error(t, err)
+
+	templates, err := LoadUnitTreeTemplates(filepath.Join(dir, "no-existe"))
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+}
+
+func validOnboardingRequest() dto.OnboardSchoolRequest {
+	return dto.OnboardSchoolRequest{
+		School:   dto.CreateSchoolRequest{Name: "Colegio San José", Code: "SJ-001"},
+		Template: "primaria-basica",
+		Director: dto.OnboardingDirectorRequest{
+			Email:     "Directora@SanJose.edu.co",
+			Password:  "Director123!",
+			FirstName: "Marta",
+			LastName:  "Rojas",
+		},
+	}
+}
+
+func TestOnboardSchool_CreatesSchoolUnitsAndDirector(t *testing.T) {
+	templates, err := LoadUnitTreeTemplates(repoTemplatesDir)
+	require.NoError(t, err)
+
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	schoolService := NewSchoolService(mockSchoolRepo, newTestLogger(), getTestDefaults())
+	svc := NewOnboardingService(schoolService, mockUnitRepo, mockUserRepo, mockMembershipRepo,
+		newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, templates, newTestLogger())
+
+	mockUserRepo.On("ExistsByEmail", mock.Anything, "directora@sanjose.edu.co").Return(false, nil)
+	mockSchoolRepo.On("ExistsByCode", mock.Anything, "SJ-001").Return(false, nil)
+	mockSchoolRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	units := map[string]*entities.AcademicUnit{}
+	mockUnitRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		unit := args.Get(1).(*entities.AcademicUnit)
+		units[unit.Code] = unit
+	}).Return(nil)
+	mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.Role == "admin" && u.SchoolID != nil && u.Email == "directora@sanjose.edu.co"
+	})).Return(nil).Once()
+	mockMembershipRepo.On("Create", mock.Anything, mock.MatchedBy(func(ms *entities.Membership) bool {
+		return ms.Role == "director" && ms.AcademicUnitID == nil
+	})).Return(nil).Once()
+
+	result, err := svc.OnboardSchool(context.Background(), validOnboardingRequest())
+
+	require.NoError(t, err)
+	assert.Len(t, result.Units, 10)
+	require.Contains(t, units, "G03-U")
+	assert.Equal(t, units["G03"].ID, *units["G03-U"].ParentUnitID)
+	assert.Equal(t, result.School.ID, units["G01"].SchoolID.String())
+	assert.Equal(t, result.Director.ID, result.DirectorMembership.UserID)
+	mockUserRepo.AssertExpectations(t)
+	mockMembershipRepo.AssertExpectations(t)
+}
+
+func TestOnboardSchool_UnknownTemplate(t *testing.T) {
+	templates, err := LoadUnitTreeTemplates(repoTemplatesDir)
+	require.NoError(t, err)
+
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	schoolService := NewSchoolService(mockSchoolRepo, newTestLogger(), getTestDefaults())
+	svc := NewOnboardingService(schoolService, new(MockAcademicUnitRepository), new(MockUserRepository), mockMembershipRepo,
+		newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, templates, newTestLogger())
+
+	req := validOnboardingRequest()
+	req.Template = "finlandia"
+	_, err = svc.OnboardSchool(context.Background(), req)
+
+	require.Error(t, err)
+	mockSchoolRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestOnboardSchool_SchoolCodeTakenStopsBeforeUnits(t *testing.T) {
+	templates, err := LoadUnitTreeTemplates(repoTemplatesDir)
+	require.NoError(t, err)
+
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	schoolService := NewSchoolService(mockSchoolRepo, newTestLogger(), getTestDefaults())
+	svc := NewOnboardingService(schoolService, mockUnitRepo, mockUserRepo, mockMembershipRepo,
+		newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, templates, newTestLogger())
+
+	mockUserRepo.On("ExistsByEmail", mock.Anything, mock.Anything).Return(false, nil)
+	mockSchoolRepo.On("ExistsByCode", mock.Anything, "SJ-001").Return(true, nil)
+
+	_, err = svc.OnboardSchool(context.Background(), validOnboardingRequest())
+
+	require.Error(t, err)
+	mockUnitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	// erasedEmailDomain es el dominio (reservado, no enrutable) de los emails anonimizados
+	erasedEmailDomain = "erased.invalid"
+	erasedFirstName   = "Usuario"
+	erasedLastName    = "Eliminado"
+)
+
+// PersonalDataService define el acceso y el borrado de datos personales del titular
+// (Habeas Data / GDPR)
+type PersonalDataService interface {
+	// ExportPersonalData escribe en w un archivo ZIP con todos los datos personales del usuario
+	ExportPersonalData(ctx context.Context, id string, requestedBy string, w io.Writer) error
+
+	// EraseUser anonimiza los datos personales del usuario conservando las relaciones y
+	// las estadísticas agregadas (o solo muestra el alcance si DryRun)
+	EraseUser(ctx context.Context, id string, req dto.EraseUserRequest, performedBy string) (*dto.EraseUserResponse, error)
+}
+
+type personalDataService struct {
+	userRepo         repository.UserRepository
+	profileRepo      repository.UserProfileRepository
+	membershipRepo   repository.UnitMembershipRepository
+	guardianRepo     repository.GuardianRepository
+	loginEventRepo   repository.LoginEventRepository
+	statusChangeRepo repository.UserStatusChangeRepository
+	invitationRepo   repository.InvitationRepository
+	adminChangeRepo  repository.AdminChangeRequestRepository
+	txManager        repository.TransactionManager
+	tokenRevoker     UserTokenRevoker
+	logger           logger.Logger
+}
+
+// NewPersonalDataService crea un nuevo PersonalDataService
+func NewPersonalDataService(
+	userRepo repository.UserRepository,
+	profileRepo repository.UserProfileRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	guardianRepo repository.GuardianRepository,
+	loginEventRepo repository.LoginEventRepository,
+	statusChangeRepo repository.UserStatusChangeRepository,
+	invitationRepo repository.InvitationRepository,
+	adminChangeRepo repository.AdminChangeRequestRepository,
+	txManager repository.TransactionManager,
+	tokenRevoker UserTokenRevoker,
+	logger logger.Logger,
+) PersonalDataService {
+	return &personalDataService{
+		userRepo:         userRepo,
+		profileRepo:      profileRepo,
+		membershipRepo:   membershipRepo,
+		guardianRepo:     guardianRepo,
+		loginEventRepo:   loginEventRepo,
+		statusChangeRepo: statusChangeRepo,
+		invitationRepo:   invitationRepo,
+		adminChangeRepo:  adminChangeRepo,
+		txManager:        txManager,
+		tokenRevoker:     tokenRevoker,
+		logger:           logger,
+	}
+}
+
+// personalData agrupa todo lo que se conoce del titular
+type personalData struct {
+	user          *entities.User
+	profile       *repository.UserProfile
+	memberships   []*entities.Membership
+	relations     []*entities.GuardianRelation
+	loginEvents   []*repository.LoginEvent
+	statusChanges []*repository.UserStatusChange
+}
+
+// archiveFile es un archivo JSON del ZIP de datos personales
+type archiveFile struct {
+	name    string
+	count   int
+	content any
+}
+
+func (s *personalDataService) ExportPersonalData(ctx context.Context, id string, requestedBy string, w io.Writer) error {
+	// Se reúne todo antes de escribir para poder reportar errores con su status HTTP
+	data, err := s.collect(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	memberships := make([]dto.PersonalDataMembership, len(data.memberships))
+	for i, membership := range data.memberships {
+		memberships[i] = dto.ToPersonalDataMembership(membership)
+	}
+	relations := make([]*dto.GuardianRelationResponse, len(data.relations))
+	for i, relation := range data.relations {
+		relations[i] = dto.ToGuardianRelationResponse(relation)
+	}
+	logins := make([]dto.LoginEventResponse, len(data.loginEvents))
+	for i, event := range data.loginEvents {
+		logins[i] = dto.ToLoginEventResponse(event)
+	}
+	history := make([]*dto.UserStatusChangeResponse, len(data.statusChanges))
+	for i, change := range data.statusChanges {
+		history[i] = dto.ToUserStatusChangeResponse(change)
+	}
+
+	files := []archiveFile{
+		{"user.json", 1, dto.ToPersonalDataUser(data.user)},
+		{"memberships.json", len(memberships), memberships},
+		{"guardian_relations.json", len(relations), relations},
+		{"login_history.json", len(logins), logins},
+		{"status_history.json", len(history), history},
+	}
+	if data.profile != nil {
+		files = append(files, archiveFile{"profile.json", 1, dto.ToUserProfileResponse(data.profile)})
+	}
+
+	manifest := dto.PersonalDataManifest{
+		UserID:      data.user.ID.String(),
+		GeneratedAt: time.Now().UTC(),
+		RequestedBy: requestedBy,
+		Files:       make(map[string]int, len(files)),
+	}
+	for _, file := range files {
+		manifest.Files[file.name] = file.count
+	}
+
+	archive := zip.NewWriter(w)
+	if err := writeZipJSON(archive, "manifest.json", manifest); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := writeZipJSON(archive, file.name, file.content); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+
+	s.logger.Info("personal data exported",
+		"entity_type", "user",
+		"entity_id", data.user.ID.String(),
+		"requested_by", requestedBy,
+	)
+	return nil
+}
+
+func (s *personalDataService) EraseUser(ctx context.Context, id string, req dto.EraseUserRequest, performedBy string) (*dto.EraseUserResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := s.collect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	user := data.user
+	if isErasedEmail(user.Email) {
+		return nil, errors.NewBusinessRuleError("user personal data was already erased")
+	}
+	if !req.DryRun && !strings.EqualFold(strings.TrimSpace(req.Confirmation), user.Email) {
+		return nil, errors.NewValidationError("confirmation must match the user's current email")
+	}
+
+	var activeRelations []*entities.GuardianRelation
+	for _, relation := range data.relations {
+		if relation.IsActive {
+			activeRelations = append(activeRelations, relation)
+		}
+	}
+
+	response := &dto.EraseUserResponse{
+		UserID:                       user.ID.String(),
+		DryRun:                       req.DryRun,
+		AnonymizedFields:             []string{"email", "first_name", "last_name", "password"},
+		MembershipsAnonymized:        len(data.memberships),
+		GuardianRelationsDeactivated: len(activeRelations),
+		LoginEventsDeleted:           len(data.loginEvents),
+		ProfileDeleted:               data.profile != nil,
+	}
+	if req.DryRun {
+		return response, nil
+	}
+
+	now := time.Now()
+	originalEmail := user.Email
+	change := &repository.UserStatusChange{
+		ID:          uuid.New(),
+		UserID:      user.ID,
+		Action:      repository.UserStatusActionErased,
+		Reason:      req.Reason,
+		PerformedBy: performedBy,
+		CreatedAt:   now,
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 1. Anonimizar la fila del usuario; se conservan id, rol, escuela y fechas
+		// para que las referencias y las estadísticas sigan siendo válidas
+		user.Email = "erased-" + user.ID.String() + "@" + erasedEmailDomain
+		user.FirstName = erasedFirstName
+		user.LastName = erasedLastName
+		user.PasswordHash = "erased:" + uuid.NewString() // no es un hash bcrypt válido: bloquea el login
+		user.IsActive = false
+		user.EmailVerified = false
+		user.UpdatedAt = now
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return errors.NewDatabaseError("anonymize user", err)
+		}
+
+		// 2. Eliminar el perfil (teléfono, fecha de nacimiento, avatar, preferencias)
+		if data.profile != nil {
+			if err := s.profileRepo.Delete(ctx, user.ID); err != nil {
+				return errors.NewDatabaseError("delete user profile", err)
+			}
+		}
+
+		// 3. Membresías: se conservan (cuentan en estadísticas) pero se vacía su metadata y se expiran
+		for _, membership := range data.memberships {
+			membership.Metadata = json.RawMessage(`{}`)
+			if membership.IsActive && membership.WithdrawnAt == nil {
+				membership.WithdrawnAt = &now
+			}
+			membership.UpdatedAt = now
+			if err := s.membershipRepo.Update(ctx, membership); err != nil {
+				return errors.NewDatabaseError("anonymize membership", err)
+			}
+			change.MembershipIDs = append(change.MembershipIDs, membership.ID)
+		}
+
+		// 4. Desactivar relaciones de apoderado vigentes
+		for _, relation := range activeRelations {
+			relation.IsActive = false
+			relation.UpdatedAt = now
+			if err := s.guardianRepo.Update(ctx, relation); err != nil {
+				return errors.NewDatabaseError("deactivate guardian relation", err)
+			}
+			change.GuardianRelationIDs = append(change.GuardianRelationIDs, relation.ID)
+		}
+
+		// 5. Borrar historial de login y quitar el email original de invitaciones y solicitudes de administrador
+		deleted, err := s.loginEventRepo.DeleteByUser(ctx, user.ID)
+		if err != nil {
+			return errors.NewDatabaseError("delete login history", err)
+		}
+		response.LoginEventsDeleted = int(deleted)
+
+		if response.InvitationsAnonymized, err = s.invitationRepo.AnonymizeEmail(ctx, originalEmail, user.Email); err != nil {
+			return errors.NewDatabaseError("anonymize invitations", err)
+		}
+		if _, err := s.adminChangeRepo.AnonymizeEmail(ctx, originalEmail, user.Email); err != nil {
+			return errors.NewDatabaseError("anonymize admin change requests", err)
+		}
+
+		// 6. Auditoría: quién, cuándo y por qué (sin datos personales)
+		if err := s.statusChangeRepo.Create(ctx, change); err != nil {
+			return errors.NewDatabaseError("create user status change", err)
+		}
+
+		// 7. Quitar el email original de los motivos del historial (p. ej. fusiones de cuentas), incluido este registro
+		if _, err := s.statusChangeRepo.RedactReason(ctx, originalEmail, user.Email); err != nil {
+			return errors.NewDatabaseError("redact user status changes", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.Applied = true
+	response.AuditID = change.ID.String()
+	// Un fallo no revierte el borrado: la cuenta anonimizada ya no puede autenticarse
+	response.TokensRevoked = revokeUserTokens(ctx, s.tokenRevoker, s.logger, user.ID)
+
+	s.logger.Info("user personal data erased",
+		"entity_type", "user",
+		"entity_id", user.ID.String(),
+		"performed_by", performedBy,
+		"audit_id", response.AuditID,
+		"memberships_anonymized", response.MembershipsAnonymized,
+		"guardian_relations_deactivated", response.GuardianRelationsDeactivated,
+		"login_events_deleted", response.LoginEventsDeleted,
+		"invitations_anonymized", response.InvitationsAnonymized,
+	)
+
+	return response, nil
+}
+
+// collect reúne los datos personales del usuario
+func (s *personalDataService) collect(ctx context.Context, id string) (*personalData, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id format")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user", err)
+	}
+	if user == nil {
+		return nil, errors.NewNotFoundError("user").WithField("id", id)
+	}
+
+	data := &personalData{user: user}
+	if data.profile, err = s.profileRepo.FindByUserID(ctx, userID); err != nil {
+		return nil, errors.NewDatabaseError("find user profile", err)
+	}
+	if data.memberships, err = s.membershipRepo.FindByUser(ctx, userID); err != nil {
+		return nil, errors.NewDatabaseError("find memberships", err)
+	}
+
+	asGuardian, err := s.guardianRepo.FindByGuardian(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find guardian relations", err)
+	}
+	asStudent, err := s.guardianRepo.FindByStudent(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find guardian relations", err)
+	}
+	data.relations = append(asGuardian, asStudent...)
+
+	if data.loginEvents, err = s.loginEventRepo.ListByUser(ctx, userID); err != nil {
+		return nil, errors.NewDatabaseError("find login history", err)
+	}
+	if data.statusChanges, err = s.statusChangeRepo.ListByUser(ctx, userID); err != nil {
+		return nil, errors.NewDatabaseError("find user status history", err)
+	}
+
+	return data, nil
+}
+
+// isErasedEmail indica si el email corresponde a una cuenta ya anonimizada
+func isErasedEmail(email string) bool {
+	return strings.HasSuffix(strings.ToLower(email), "@"+erasedEmailDomain)
+}
+
+// writeZipJSON agrega un archivo JSON indentado al ZIP
+func writeZipJSON(archive *zip.Writer, name string, content any) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(content)
+}
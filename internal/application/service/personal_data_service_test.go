@@ -0,0 +1,302 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	memrepo "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/persistence/mock/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockLoginEventRepository mock implementation
+type MockLoginEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginEventRepository) Create(ctx context.Context, event *repository.LoginEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockLoginEventRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*repository.LoginEvent, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.LoginEvent), args.Error(1)
+}
+
+func (m *MockLoginEventRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLoginEventRepository) ReassignUser(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, fromUserID, toUserID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// expectCollect configura las lecturas de datos personales de un usuario
+func expectCollect(mockUserRepo *MockUserRepository, mockProfileRepo *MockUserProfileRepository,
+	mockMembershipRepo *MockUnitMembershipRepository, mockGuardianRepo *MockGuardianRepository,
+	mockLoginRepo *MockLoginEventRepository, mockStatusRepo *MockUserStatusChangeRepository,
+	user *entities.User, membership *entities.Membership, relation *entities.GuardianRelation) {
+	mockUserRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	mockProfileRepo.On("FindByUserID", mock.Anything, user.ID).Return(&repository.UserProfile{UserID: user.ID, Phone: strPtr("+573001234567")}, nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, user.ID).Return([]*entities.Membership{membership}, nil)
+	mockGuardianRepo.On("FindByGuardian", mock.Anything, user.ID).Return([]*entities.GuardianRelation{relation}, nil)
+	mockGuardianRepo.On("FindByStudent", mock.Anything, user.ID).Return([]*entities.GuardianRelation{}, nil)
+	mockLoginRepo.On("ListByUser", mock.Anything, user.ID).Return([]*repository.LoginEvent{
+		{ID: uuid.New(), UserID: user.ID, Result: repository.LoginResultSuccess, OccurredAt: time.Now()},
+	}, nil)
+	mockStatusRepo.On("ListByUser", mock.Anything, user.ID).Return([]*repository.UserStatusChange{}, nil)
+}
+
+func newPersonalDataFixtures() (*entities.User, *entities.Membership, *entities.GuardianRelation) {
+	user := &entities.User{ID: uuid.New(), Email: "ana@example.com", FirstName: "Ana", LastName: "Diaz", PasswordHash: "hash", IsActive: true}
+	membership := &entities.Membership{ID: uuid.New(), UserID: user.ID, SchoolID: uuid.New(), IsActive: true, Metadata: json.RawMessage(`{"note":"alergia"}`)}
+	relation := &entities.GuardianRelation{ID: uuid.New(), GuardianID: user.ID, StudentID: uuid.New(), IsActive: true}
+	return user, membership, relation
+}
+
+func TestExportPersonalData_WritesArchive(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockLoginRepo := new(MockLoginEventRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	svc := NewPersonalDataService(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo,
+		mockStatusRepo, new(MockInvitationRepository), new(MockAdminChangeRequestRepository),
+		passthroughTxManager{}, new(MockTokenRevoker), newTestLogger())
+	user, membership, relation := newPersonalDataFixtures()
+	expectCollect(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo, mockStatusRepo,
+		user, membership, relation)
+
+	var buf bytes.Buffer
+	err := svc.ExportPersonalData(context.Background(), user.ID.String(), "admin", &buf)
+	require.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	names := make(map[string]*zip.File)
+	for _, f := range archive.File {
+		names[f.Name] = f
+	}
+	for _, name := range []string{"manifest.json", "user.json", "profile.json", "memberships.json", "guardian_relations.json", "login_history.json", "status_history.json"} {
+		assert.Contains(t, names, name)
+	}
+
+	reader, err := names["user.json"].Open()
+	require.NoError(t, err)
+	var exported map[string]any
+	require.NoError(t, json.NewDecoder(reader).Decode(&exported))
+	assert.Equal(t, "ana@example.com", exported["email"])
+	assert.NotContains(t, exported, "password_hash")
+}
+
+func TestEraseUser_RequiresConfirmation(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockLoginRepo := new(MockLoginEventRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	svc := NewPersonalDataService(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo,
+		mockStatusRepo, new(MockInvitationRepository), new(MockAdminChangeRequestRepository),
+		passthroughTxManager{}, new(MockTokenRevoker), newTestLogger())
+	user, membership, relation := newPersonalDataFixtures()
+	expectCollect(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo, mockStatusRepo,
+		user, membership, relation)
+
+	_, err := svc.EraseUser(context.Background(), user.ID.String(), dto.EraseUserRequest{
+		Reason:       "solicitud del titular",
+		Confirmation: "otro@example.com",
+	}, "admin")
+
+	assert.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestEraseUser_DryRunDoesNotWrite(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockLoginRepo := new(MockLoginEventRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	svc := NewPersonalDataService(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo,
+		mockStatusRepo, new(MockInvitationRepository), new(MockAdminChangeRequestRepository),
+		passthroughTxManager{}, new(MockTokenRevoker), newTestLogger())
+	user, membership, relation := newPersonalDataFixtures()
+	expectCollect(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo, mockStatusRepo,
+		user, membership, relation)
+
+	result, err := svc.EraseUser(context.Background(), user.ID.String(), dto.EraseUserRequest{
+		Reason: "solicitud del titular",
+		DryRun: true,
+	}, "admin")
+
+	require.NoError(t, err)
+	assert.False(t, result.Applied)
+	assert.Equal(t, 1, result.MembershipsAnonymized)
+	assert.Equal(t, 1, result.GuardianRelationsDeactivated)
+	assert.Equal(t, 1, result.LoginEventsDeleted)
+	assert.True(t, result.ProfileDeleted)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockStatusRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestEraseUser_AnonymizesInPlaceAndAudits(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockLoginRepo := new(MockLoginEventRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	mockInvitationRepo := new(MockInvitationRepository)
+	mockAdminRequestRepo := new(MockAdminChangeRequestRepository)
+	mockRevoker := new(MockTokenRevoker)
+	svc := NewPersonalDataService(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo,
+		mockStatusRepo, mockInvitationRepo, mockAdminRequestRepo,
+		passthroughTxManager{}, mockRevoker, newTestLogger())
+	user, membership, relation := newPersonalDataFixtures()
+	expectCollect(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo, mockStatusRepo,
+		user, membership, relation)
+
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.ID == user.ID && isErasedEmail(u.Email) && u.FirstName == erasedFirstName && !u.IsActive && u.PasswordHash != "hash"
+	})).Return(nil).Once()
+	mockProfileRepo.On("Delete", mock.Anything, user.ID).Return(nil).Once()
+	mockMembershipRepo.On("Update", mock.Anything, mock.MatchedBy(func(ms *entities.Membership) bool {
+		return ms.ID == membership.ID && string(ms.Metadata) == `{}` && ms.WithdrawnAt != nil
+	})).Return(nil).Once()
+	mockGuardianRepo.On("Update", mock.Anything, mock.MatchedBy(func(r *entities.GuardianRelation) bool {
+		return r.ID == relation.ID && !r.IsActive
+	})).Return(nil).Once()
+	mockLoginRepo.On("DeleteByUser", mock.Anything, user.ID).Return(int64(1), nil).Once()
+	mockInvitationRepo.On("AnonymizeEmail", mock.Anything, "ana@example.com", mock.Anything).Return(int64(2), nil).Once()
+	mockAdminRequestRepo.On("AnonymizeEmail", mock.Anything, "ana@example.com", mock.Anything).Return(int64(0), nil).Once()
+	mockStatusRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *repository.UserStatusChange) bool {
+		return c.UserID == user.ID && c.Action == repository.UserStatusActionErased && c.PerformedBy == "admin"
+	})).Return(nil).Once()
+	mockStatusRepo.On("RedactReason", mock.Anything, "ana@example.com", mock.Anything).Return(int64(1), nil).Once()
+	mockRevoker.On("RevokeUserTokens", mock.Anything, user.ID.String()).Return(nil).Once()
+
+	result, err := svc.EraseUser(context.Background(), user.ID.String(), dto.EraseUserRequest{
+		Reason:       "solicitud del titular",
+		Confirmation: "ANA@example.com",
+	}, "admin")
+
+	require.NoError(t, err)
+	assert.True(t, result.Applied)
+	assert.True(t, result.TokensRevoked)
+	assert.Equal(t, int64(2), result.InvitationsAnonymized)
+	assert.NotEmpty(t, result.AuditID)
+	mockUserRepo.AssertExpectations(t)
+	mockProfileRepo.AssertExpectations(t)
+	mockMembershipRepo.AssertExpectations(t)
+	mockGuardianRepo.AssertExpectations(t)
+	mockLoginRepo.AssertExpectations(t)
+	mockInvitationRepo.AssertExpectations(t)
+	mockAdminRequestRepo.AssertExpectations(t)
+	mockStatusRepo.AssertExpectations(t)
+}
+
+func TestEraseUser_RemovesEmailFromAuditTrail(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockLoginRepo := new(MockLoginEventRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	mockInvitationRepo := new(MockInvitationRepository)
+	mockRevoker := new(MockTokenRevoker)
+	user, membership, relation := newPersonalDataFixtures()
+	expectCollect(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo, mockStatusRepo,
+		user, membership, relation)
+	mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockProfileRepo.On("Delete", mock.Anything, user.ID).Return(nil)
+	mockMembershipRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockGuardianRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockLoginRepo.On("DeleteByUser", mock.Anything, user.ID).Return(int64(0), nil)
+	mockInvitationRepo.On("AnonymizeEmail", mock.Anything, "ana@example.com", mock.Anything).Return(int64(0), nil)
+	mockRevoker.On("RevokeUserTokens", mock.Anything, user.ID.String()).Return(nil)
+
+	// Historial y solicitudes en memoria para verificar el contenido que queda guardado
+	ctx := context.Background()
+	statuses := memrepo.NewMockUserStatusChangeRepository()
+	admins := memrepo.NewMockAdminChangeRequestRepository()
+	require.NoError(t, statuses.Create(ctx, &repository.UserStatusChange{
+		UserID:    user.ID,
+		Action:    repository.UserStatusActionMerged,
+		Reason:    "merged account " + uuid.NewString() + " (ANA@example.com): duplicado",
+		CreatedAt: time.Now().Add(-time.Hour),
+	}))
+	require.NoError(t, admins.Create(ctx, &repository.AdminChangeRequest{
+		ID:        uuid.New(),
+		Action:    repository.AdminChangeActionCreate,
+		Email:     "ana@example.com",
+		FirstName: "Ana",
+		Status:    repository.AdminChangeStatusPending,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	svc := NewPersonalDataService(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo,
+		statuses, mockInvitationRepo, admins,
+		passthroughTxManager{}, mockRevoker, newTestLogger())
+	_, err := svc.EraseUser(ctx, user.ID.String(), dto.EraseUserRequest{
+		Reason:       "solicitud de ana@example.com",
+		Confirmation: "ana@example.com",
+	}, "admin")
+	require.NoError(t, err)
+
+	changes, err := statuses.ListByUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	for _, change := range changes {
+		assert.NotContains(t, strings.ToLower(change.Reason), "ana@example.com")
+	}
+	requests, err := admins.List(ctx, repository.AdminChangeRequestFilters{})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.True(t, isErasedEmail(requests[0].Email))
+	assert.Empty(t, requests[0].FirstName)
+	assert.Equal(t, repository.AdminChangeStatusRejected, requests[0].Status)
+}
+
+func TestEraseUser_AlreadyErased(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockLoginRepo := new(MockLoginEventRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	svc := NewPersonalDataService(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo,
+		mockStatusRepo, new(MockInvitationRepository), new(MockAdminChangeRequestRepository),
+		passthroughTxManager{}, new(MockTokenRevoker), newTestLogger())
+	user, membership, relation := newPersonalDataFixtures()
+	user.Email = "erased-" + user.ID.String() + "@" + erasedEmailDomain
+	expectCollect(mockUserRepo, mockProfileRepo, mockMembershipRepo, mockGuardianRepo, mockLoginRepo, mockStatusRepo,
+		user, membership, relation)
+
+	_, err := svc.EraseUser(context.Background(), user.ID.String(), dto.EraseUserRequest{
+		Reason:       "solicitud del titular",
+		Confirmation: user.Email,
+	}, "admin")
+
+	assert.Error(t, err)
+}
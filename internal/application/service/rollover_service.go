@@ -0,0 +1,522 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// RolloverService ejecuta el cambio de año lectivo: copia el árbol de unidades del año de origen
+// al nuevo año, mueve a los estudiantes al grado siguiente y deja las asignaciones de docentes
+// y personal para revisión. Todo se aplica en una sola transacción y queda registrado.
+type RolloverService interface {
+	// Rollover calcula el cambio de año y, salvo en dry run, lo aplica
+	Rollover(ctx context.Context, schoolID string, req dto.RolloverRequest, performedBy string) (*dto.RolloverResponse, error)
+
+	// GetRollover obtiene un cambio de año ya aplicado con el detalle por estudiante
+	GetRollover(ctx context.Context, id string) (*dto.RolloverResponse, error)
+}
+
+type rolloverService struct {
+	schoolRepo     repository.SchoolRepository
+	unitRepo       repository.AcademicUnitRepository
+	membershipRepo repository.UnitMembershipRepository
+	periodRepo     repository.AcademicPeriodRepository
+	rolloverRepo   repository.RolloverRepository
+	quotaService   SchoolQuotaService
+	txManager      repository.TransactionManager
+	logger         logger.Logger
+}
+
+// NewRolloverService crea un nuevo RolloverService
+func NewRolloverService(
+	schoolRepo repository.SchoolRepository,
+	unitRepo repository.AcademicUnitRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	periodRepo repository.AcademicPeriodRepository,
+	rolloverRepo repository.RolloverRepository,
+	quotaService SchoolQuotaService,
+	txManager repository.TransactionManager,
+	logger logger.Logger,
+) RolloverService {
+	return &rolloverService{
+		schoolRepo:     schoolRepo,
+		unitRepo:       unitRepo,
+		membershipRepo: membershipRepo,
+		periodRepo:     periodRepo,
+		rolloverRepo:   rolloverRepo,
+		quotaService:   quotaService,
+		txManager:      txManager,
+		logger:         logger,
+	}
+}
+
+// rolloverMove es el destino calculado para la membresía de un estudiante
+type rolloverMove struct {
+	membership *entities.Membership
+	action     string
+	from       *entities.AcademicUnit
+	to         *entities.AcademicUnit // unidad del año de origen cuya copia recibe al estudiante
+}
+
+func (s *rolloverService) Rollover(ctx context.Context, schoolID string, req dto.RolloverRequest, performedBy string) (*dto.RolloverResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, id)
+	if err != nil && !isNotFoundError(err) {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if err != nil || school == nil {
+		// Una escuela archivada es de solo lectura
+		return nil, missingSchoolError(ctx, s.schoolRepo, id)
+	}
+
+	toPeriod, err := s.periodRepo.FindYear(ctx, school.ID, req.ToYear)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find academic year", err)
+	}
+	if toPeriod == nil {
+		return nil, errors.NewValidationError("target academic year does not exist").WithField("to_year", strconv.Itoa(req.ToYear))
+	}
+	if toPeriod.IsClosed() {
+		return nil, errors.NewBusinessRuleError(fmt.Sprintf("academic year %d is closed", req.ToYear))
+	}
+	previous, err := s.rolloverRepo.FindBySchoolAndYears(ctx, school.ID, req.FromYear, req.ToYear)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find rollover", err)
+	}
+	if previous != nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("rollover from %d to %d was already applied", req.FromYear, req.ToYear))
+	}
+
+	allUnits, err := s.unitRepo.FindBySchoolID(ctx, school.ID, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list units", err)
+	}
+	var sourceUnits []*entities.AcademicUnit
+	unitsByID := make(map[uuid.UUID]*entities.AcademicUnit, len(allUnits))
+	existingCodes := map[string]bool{}
+	for _, unit := range allUnits {
+		unitsByID[unit.ID] = unit
+		if unit.Code != "" {
+			existingCodes[unit.Code] = true
+		}
+		if unit.AcademicYear == req.FromYear && unit.IsActive {
+			sourceUnits = append(sourceUnits, unit)
+		}
+	}
+	if len(sourceUnits) == 0 {
+		return nil, errors.NewBusinessRuleError(fmt.Sprintf("academic year %d has no active units", req.FromYear))
+	}
+
+	response := &dto.RolloverResponse{
+		DryRun:        req.DryRun,
+		SchoolID:      school.ID.String(),
+		FromYear:      req.FromYear,
+		ToYear:        req.ToYear,
+		Students:      []dto.RolloverStudentEntry{},
+		PendingReview: []dto.RolloverAssignmentEntry{},
+	}
+
+	// Copia del árbol con códigos del año nuevo
+	plan := planUnitClone(sourceUnits, false)
+	planned := map[string]bool{}
+	for _, entry := range plan {
+		if entry.source.Code == "" {
+			continue
+		}
+		entry.code = rolloverUnitCode(entry.source.Code, req.FromYear, req.ToYear)
+		switch {
+		case len(entry.code) > maxUnitCodeLength:
+			response.Conflicts = append(response.Conflicts, fmt.Sprintf("unit code %q exceeds %d characters", entry.code, maxUnitCodeLength))
+		case existingCodes[entry.code]:
+			response.Conflicts = append(response.Conflicts, fmt.Sprintf("unit code %q already exists", entry.code))
+		case planned[entry.code]:
+			response.Conflicts = append(response.Conflicts, fmt.Sprintf("unit code %q is generated more than once", entry.code))
+		}
+		planned[entry.code] = true
+	}
+	plannedCodes := make(map[uuid.UUID]string, len(plan))
+	inPlan := make(map[uuid.UUID]bool, len(plan))
+	for _, entry := range plan {
+		plannedCodes[entry.source.ID] = entry.code
+		inPlan[entry.source.ID] = true
+	}
+
+	grades, mapping, err := resolveGradeMapping(plan, req.GradeMapping)
+	if err != nil {
+		return nil, err
+	}
+	response.GradeMapping = make(map[string]string, len(mapping))
+	for gradeID, target := range mapping {
+		if target == nil {
+			response.GradeMapping[grades[gradeID].Code] = dto.RolloverGraduate
+		} else {
+			response.GradeMapping[grades[gradeID].Code] = target.Code
+		}
+	}
+
+	memberships, err := s.membershipRepo.FindActiveBySchool(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list memberships", err)
+	}
+	overrides := make(map[uuid.UUID]string, len(req.StudentOverrides))
+	for _, override := range req.StudentOverrides {
+		overrides[uuid.MustParse(override.UserID)] = override.Action
+	}
+
+	var moves []*rolloverMove
+	for _, membership := range memberships {
+		if membership.AcademicUnitID == nil || !inPlan[*membership.AcademicUnitID] {
+			continue
+		}
+		unit := unitsByID[*membership.AcademicUnitID]
+		if membership.Role != string(valueobject.RoleStudent) {
+			response.PendingReview = append(response.PendingReview, dto.RolloverAssignmentEntry{
+				MembershipID:      membership.ID.String(),
+				UserID:            membership.UserID.String(),
+				Role:              membership.Role,
+				FromUnitCode:      unit.Code,
+				SuggestedUnitCode: plannedCodes[unit.ID],
+			})
+			continue
+		}
+
+		move := &rolloverMove{membership: membership, from: unit}
+		grade := findGradeAncestor(unit, unitsByID, grades)
+		switch {
+		case grade == nil:
+			move.action = repository.RolloverActionCarryOver
+			move.to = unit
+		case overrides[membership.UserID] == repository.RolloverActionGraduate || (overrides[membership.UserID] == "" && mapping[grade.ID] == nil):
+			move.action = repository.RolloverActionGraduate
+		case overrides[membership.UserID] == repository.RolloverActionRetain:
+			move.action = repository.RolloverActionRetain
+			move.to = unit
+		default:
+			move.action = repository.RolloverActionPromote
+			move.to = matchUnitInGrade(unit, grade, mapping[grade.ID], plan)
+			if move.to == mapping[grade.ID] && unit.ID != grade.ID {
+				response.Warnings = append(response.Warnings, fmt.Sprintf(
+					"no unit matching %q in grade %q, student %s is placed in the grade", unit.Code, mapping[grade.ID].Code, membership.UserID))
+			}
+		}
+		moves = append(moves, move)
+	}
+	response.PendingCount = len(response.PendingReview)
+
+	if req.DryRun {
+		response.Units = toClonedUnitEntries(plan, nil)
+		response.UnitsCreated = len(plan)
+		for _, move := range moves {
+			response.Students = append(response.Students, toRolloverStudentEntry(move, plannedCodes, nil))
+			countRolloverAction(response, move.action)
+		}
+		return response, nil
+	}
+	if len(response.Conflicts) > 0 {
+		return nil, errors.NewConflictError(fmt.Sprintf("rollover has %d conflicts, run a dry run to review them", len(response.Conflicts)))
+	}
+
+	now := time.Now()
+	rollover := &repository.SchoolRollover{
+		ID:            uuid.New(),
+		SchoolID:      school.ID,
+		FromYear:      req.FromYear,
+		ToYear:        req.ToYear,
+		UnitsCreated:  len(plan),
+		PendingReview: len(response.PendingReview),
+		PerformedBy:   performedBy,
+		CreatedAt:     now,
+	}
+	newIDs := make(map[uuid.UUID]uuid.UUID, len(plan))
+	var entries []repository.SchoolRolloverEntry
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Los códigos se validaron fuera de la transacción: se confirman con la jerarquía bloqueada
+		if err := s.unitRepo.LockHierarchy(ctx, school.ID); err != nil {
+			return errors.NewDatabaseError("lock unit hierarchy", err)
+		}
+		if err := ensurePlannedCodesFree(ctx, s.unitRepo, school.ID, plan); err != nil {
+			return err
+		}
+		if err := s.quotaService.CheckUnitQuota(ctx, school.ID, len(plan)); err != nil {
+			return err
+		}
+		for _, entry := range plan {
+			unit := &entities.AcademicUnit{
+				ID:           uuid.New(),
+				SchoolID:     school.ID,
+				Name:         entry.source.Name,
+				Code:         entry.code,
+				Type:         entry.source.Type,
+				Description:  entry.source.Description,
+				Level:        entry.source.Level,
+				AcademicYear: req.ToYear,
+				Metadata:     entry.source.Metadata,
+				IsActive:     true,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+			if parentID := entry.source.ParentUnitID; parentID != nil {
+				if newParentID, ok := newIDs[*parentID]; ok {
+					unit.ParentUnitID = &newParentID
+				} else if parent := unitsByID[*parentID]; parent != nil && parent.AcademicYear == 0 {
+					// Las unidades sin año (p.ej. departamentos) siguen siendo el padre
+					unit.ParentUnitID = parentID
+				}
+			}
+			if len(unit.Metadata) == 0 {
+				unit.Metadata = []byte("{}")
+			}
+			if err := s.unitRepo.Create(ctx, unit); err != nil {
+				return errors.NewDatabaseError("create unit", err)
+			}
+			newIDs[entry.source.ID] = unit.ID
+		}
+
+		for _, move := range moves {
+			// La membresía del año anterior se cierra y queda como historial
+			old := move.membership
+			old.IsActive = false
+			old.WithdrawnAt = &now
+			old.UpdatedAt = now
+			if err := s.membershipRepo.Update(ctx, old); err != nil {
+				return errors.NewDatabaseError("close membership", err)
+			}
+
+			entry := repository.SchoolRolloverEntry{
+				RolloverID:       rollover.ID,
+				UserID:           old.UserID,
+				Action:           move.action,
+				FromMembershipID: old.ID,
+				FromUnitID:       move.from.ID,
+			}
+			if move.to != nil {
+				unitID := newIDs[move.to.ID]
+				membership := &entities.Membership{
+					ID:             uuid.New(),
+					UserID:         old.UserID,
+					SchoolID:       school.ID,
+					AcademicUnitID: &unitID,
+					Role:           old.Role,
+					Metadata:       old.Metadata,
+					IsActive:       true,
+					EnrolledAt:     now,
+					CreatedAt:      now,
+					UpdatedAt:      now,
+				}
+				if len(membership.Metadata) == 0 {
+					membership.Metadata = []byte("{}")
+				}
+				if err := s.membershipRepo.Create(ctx, membership); err != nil {
+					return errors.NewDatabaseError("create membership", err)
+				}
+				entry.ToMembershipID = &membership.ID
+				entry.ToUnitID = &unitID
+			}
+			entries = append(entries, entry)
+			countRolloverAction(response, move.action)
+		}
+
+		rollover.Promoted = response.Promoted
+		rollover.Retained = response.Retained
+		rollover.Graduated = response.Graduated
+		if err := s.rolloverRepo.Create(ctx, rollover, entries); err != nil {
+			return errors.NewDatabaseError("create rollover", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.ID = rollover.ID.String()
+	response.Units = toClonedUnitEntries(plan, newIDs)
+	response.UnitsCreated = len(plan)
+	response.PerformedBy = performedBy
+	response.CreatedAt = &now
+	for _, move := range moves {
+		response.Students = append(response.Students, toRolloverStudentEntry(move, plannedCodes, newIDs))
+	}
+
+	s.logger.Info("school rollover applied",
+		"school_id", school.ID.String(),
+		"from_year", req.FromYear,
+		"to_year", req.ToYear,
+		"units", len(plan),
+		"promoted", response.Promoted,
+		"retained", response.Retained,
+		"graduated", response.Graduated,
+		"pending_review", response.PendingCount,
+		"performed_by", performedBy,
+	)
+
+	return response, nil
+}
+
+func (s *rolloverService) GetRollover(ctx context.Context, id string) (*dto.RolloverResponse, error) {
+	rolloverID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid rollover ID")
+	}
+	rollover, err := s.rolloverRepo.FindByID(ctx, rolloverID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find rollover", err)
+	}
+	if rollover == nil {
+		return nil, errors.NewNotFoundError("rollover")
+	}
+	entries, err := s.rolloverRepo.ListEntries(ctx, rollover.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list rollover entries", err)
+	}
+	return dto.ToRolloverResponse(rollover, entries), nil
+}
+
+// resolveGradeMapping ordena los grados del año de origen por nivel y asigna a cada uno el
+// siguiente; el último egresa. El mapeo del request sobrescribe el destino por código.
+// Un destino nil significa que los estudiantes del grado egresan.
+func resolveGradeMapping(plan []*clonePlanEntry, overrides map[string]string) (map[uuid.UUID]*entities.AcademicUnit, map[uuid.UUID]*entities.AcademicUnit, error) {
+	var ordered []*entities.AcademicUnit
+	grades := map[uuid.UUID]*entities.AcademicUnit{}
+	byCode := map[string]*entities.AcademicUnit{}
+	for _, entry := range plan {
+		if entry.source.Type == string(valueobject.UnitTypeGrade) {
+			ordered = append(ordered, entry.source)
+			grades[entry.source.ID] = entry.source
+			if entry.source.Code != "" {
+				byCode[entry.source.Code] = entry.source
+			}
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		li, iok := gradeLevel(ordered[i])
+		lj, jok := gradeLevel(ordered[j])
+		if iok != jok {
+			return iok
+		}
+		if iok && li != lj {
+			return li < lj
+		}
+		return ordered[i].Code < ordered[j].Code
+	})
+
+	mapping := make(map[uuid.UUID]*entities.AcademicUnit, len(ordered))
+	for i, grade := range ordered {
+		if i+1 < len(ordered) {
+			mapping[grade.ID] = ordered[i+1]
+		} else {
+			mapping[grade.ID] = nil
+		}
+	}
+
+	for from, to := range overrides {
+		grade, ok := byCode[from]
+		if !ok {
+			return nil, nil, errors.NewValidationError("grade_mapping references an unknown grade").WithField("grade", from)
+		}
+		if to == dto.RolloverGraduate {
+			mapping[grade.ID] = nil
+			continue
+		}
+		target, ok := byCode[to]
+		if !ok {
+			return nil, nil, errors.NewValidationError("grade_mapping target must be a grade code or \"graduate\"").WithField("grade", to)
+		}
+		mapping[grade.ID] = target
+	}
+	return grades, mapping, nil
+}
+
+func gradeLevel(grade *entities.AcademicUnit) (int, bool) {
+	if grade.Level == nil {
+		return 0, false
+	}
+	level, err := strconv.Atoi(strings.TrimSpace(*grade.Level))
+	return level, err == nil
+}
+
+// findGradeAncestor obtiene el grado al que pertenece la unidad (ella misma o un ancestro)
+func findGradeAncestor(unit *entities.AcademicUnit, unitsByID map[uuid.UUID]*entities.AcademicUnit, grades map[uuid.UUID]*entities.AcademicUnit) *entities.AcademicUnit {
+	for current, depth := unit, 0; current != nil && depth <= len(unitsByID); depth++ {
+		if grade, ok := grades[current.ID]; ok {
+			return grade
+		}
+		if current.ParentUnitID == nil {
+			return nil
+		}
+		current = unitsByID[*current.ParentUnitID]
+	}
+	return nil
+}
+
+// matchUnitInGrade busca en el grado destino la unidad equivalente a la de origen por el
+// sufijo de su código (p.ej. G1-A → G2-A); si no existe, el estudiante queda en el grado
+func matchUnitInGrade(unit, grade, target *entities.AcademicUnit, plan []*clonePlanEntry) *entities.AcademicUnit {
+	if unit.ID == grade.ID || unit.Code == "" || grade.Code == "" || !strings.HasPrefix(unit.Code, grade.Code) {
+		return target
+	}
+	code := target.Code + strings.TrimPrefix(unit.Code, grade.Code)
+	for _, entry := range plan {
+		if entry.source.Code == code {
+			return entry.source
+		}
+	}
+	return target
+}
+
+// rolloverUnitCode reemplaza el año de origen por el nuevo en el código, o lo agrega como sufijo
+func rolloverUnitCode(code string, fromYear, toYear int) string {
+	from, to := strconv.Itoa(fromYear), strconv.Itoa(toYear)
+	if strings.Contains(code, from) {
+		return strings.ReplaceAll(code, from, to)
+	}
+	return code + "-" + to
+}
+
+func countRolloverAction(response *dto.RolloverResponse, action string) {
+	switch action {
+	case repository.RolloverActionPromote:
+		response.Promoted++
+	case repository.RolloverActionRetain:
+		response.Retained++
+	case repository.RolloverActionGraduate:
+		response.Graduated++
+	case repository.RolloverActionCarryOver:
+		response.CarriedOver++
+	}
+}
+
+func toRolloverStudentEntry(move *rolloverMove, plannedCodes map[uuid.UUID]string, newIDs map[uuid.UUID]uuid.UUID) dto.RolloverStudentEntry {
+	entry := dto.RolloverStudentEntry{
+		UserID:       move.membership.UserID.String(),
+		Action:       move.action,
+		FromUnitID:   move.from.ID.String(),
+		FromUnitCode: move.from.Code,
+	}
+	if move.to != nil {
+		entry.ToUnitCode = plannedCodes[move.to.ID]
+		if id, ok := newIDs[move.to.ID]; ok {
+			entry.ToUnitID = id.String()
+		}
+	}
+	return entry
+}
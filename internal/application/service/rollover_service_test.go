@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockRolloverRepository mock implementation
+type MockRolloverRepository struct {
+	mock.Mock
+}
+
+func (m *MockRolloverRepository) Create(ctx context.Context, rollover *repository.SchoolRollover, entries []repository.SchoolRolloverEntry) error {
+	args := m.Called(ctx, rollover, entries)
+	return args.Error(0)
+}
+
+func (m *MockRolloverRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.SchoolRollover, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.SchoolRollover), args.Error(1)
+}
+
+func (m *MockRolloverRepository) FindBySchoolAndYears(ctx context.Context, schoolID uuid.UUID, fromYear, toYear int) (*repository.SchoolRollover, error) {
+	args := m.Called(ctx, schoolID, fromYear, toYear)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.SchoolRollover), args.Error(1)
+}
+
+func (m *MockRolloverRepository) ListEntries(ctx context.Context, rolloverID uuid.UUID) ([]repository.SchoolRolloverEntry, error) {
+	args := m.Called(ctx, rolloverID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.SchoolRolloverEntry), args.Error(1)
+}
+
+type rolloverFixture struct {
+	school         *entities.School
+	schoolRepo     *MockSchoolRepository
+	unitRepo       *MockAcademicUnitRepository
+	membershipRepo *MockUnitMembershipRepository
+	periodRepo     *MockAcademicPeriodRepository
+	rolloverRepo   *MockRolloverRepository
+	svc            RolloverService
+}
+
+func newRolloverFixture() *rolloverFixture {
+	f := &rolloverFixture{
+		school:         &entities.School{ID: uuid.New()},
+		schoolRepo:     new(MockSchoolRepository),
+		unitRepo:       new(MockAcademicUnitRepository),
+		membershipRepo: new(MockUnitMembershipRepository),
+		periodRepo:     new(MockAcademicPeriodRepository),
+		rolloverRepo:   new(MockRolloverRepository),
+	}
+	f.svc = NewRolloverService(f.schoolRepo, f.unitRepo, f.membershipRepo, f.periodRepo, f.rolloverRepo, newTestQuotaService(f.schoolRepo, f.membershipRepo), passthroughTxManager{}, newTestLogger())
+	f.schoolRepo.On("FindByID", mock.Anything, f.school.ID).Return(f.school, nil)
+	f.periodRepo.On("FindYear", mock.Anything, f.school.ID, 2027).Return(testAcademicYear(f.school.ID, 2027, repository.AcademicPeriodPlanned), nil)
+	f.rolloverRepo.On("FindBySchoolAndYears", mock.Anything, f.school.ID, 2026, 2027).Return(nil, nil)
+	return f
+}
+
+func (f *rolloverFixture) unit(code, unitType, level string, parent *entities.AcademicUnit) *entities.AcademicUnit {
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: f.school.ID, Name: code, Code: code, Type: unitType, AcademicYear: 2026, IsActive: true}
+	if level != "" {
+		unit.Level = &level
+	}
+	if parent != nil {
+		unit.ParentUnitID = &parent.ID
+	}
+	return unit
+}
+
+func (f *rolloverFixture) member(unit *entities.AcademicUnit, role string) *entities.Membership {
+	return &entities.Membership{ID: uuid.New(), UserID: uuid.New(), SchoolID: f.school.ID, AcademicUnitID: &unit.ID, Role: role, IsActive: true}
+}
+
+func TestRollover_DryRunPromotesByGradeLevel(t *testing.T) {
+	f := newRolloverFixture()
+	g1 := f.unit("G1-2026", "grade", "1", nil)
+	g1a := f.unit("G1-2026-A", "section", "", g1)
+	g2 := f.unit("G2-2026", "grade", "2", nil)
+	g2a := f.unit("G2-2026-A", "section", "", g2)
+	f.unitRepo.On("FindBySchoolID", mock.Anything, f.school.ID, false).Return([]*entities.AcademicUnit{g2, g2a, g1, g1a}, nil)
+
+	student := f.member(g1a, "student")
+	senior := f.member(g2a, "student")
+	teacher := f.member(g1a, "teacher")
+	f.membershipRepo.On("FindActiveBySchool", mock.Anything, f.school.ID).Return([]*entities.Membership{student, senior, teacher}, nil)
+
+	result, err := f.svc.Rollover(context.Background(), f.school.ID.String(), dto.RolloverRequest{FromYear: 2026, ToYear: 2027, DryRun: true}, "admin-1")
+
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 4, result.UnitsCreated)
+	assert.Equal(t, map[string]string{"G1-2026": "G2-2026", "G2-2026": dto.RolloverGraduate}, result.GradeMapping)
+	assert.Equal(t, 1, result.Promoted)
+	assert.Equal(t, 1, result.Graduated)
+	require.Len(t, result.PendingReview, 1)
+	assert.Equal(t, "G1-2027-A", result.PendingReview[0].SuggestedUnitCode)
+	for _, entry := range result.Students {
+		if entry.UserID == student.UserID.String() {
+			assert.Equal(t, "G2-2027-A", entry.ToUnitCode)
+		}
+	}
+	f.unitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	f.rolloverRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRollover_AppliesMovesAndRecordsHistory(t *testing.T) {
+	f := newRolloverFixture()
+	g1 := f.unit("G1", "grade", "1", nil)
+	g2 := f.unit("G2", "grade", "2", nil)
+	f.unitRepo.On("FindBySchoolID", mock.Anything, f.school.ID, false).Return([]*entities.AcademicUnit{g1, g2}, nil)
+
+	promoted := f.member(g1, "student")
+	retained := f.member(g1, "student")
+	f.membershipRepo.On("FindActiveBySchool", mock.Anything, f.school.ID).Return([]*entities.Membership{promoted, retained}, nil)
+	f.unitRepo.On("LockHierarchy", mock.Anything, f.school.ID).Return(nil).Once()
+	f.unitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, f.school.ID, mock.Anything).Return(false, nil).Twice()
+	f.unitRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entities.AcademicUnit) bool {
+		return u.AcademicYear == 2027 && (u.Code == "G1-2027" || u.Code == "G2-2027")
+	})).Return(nil).Twice()
+	f.membershipRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *entities.Membership) bool {
+		return !m.IsActive && m.WithdrawnAt != nil
+	})).Return(nil).Twice()
+	f.membershipRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Twice()
+	f.rolloverRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *repository.SchoolRollover) bool {
+		return r.Promoted == 1 && r.Retained == 1 && r.UnitsCreated == 2
+	}), mock.MatchedBy(func(entries []repository.SchoolRolloverEntry) bool {
+		return len(entries) == 2
+	})).Return(nil)
+
+	result, err := f.svc.Rollover(context.Background(), f.school.ID.String(), dto.RolloverRequest{
+		FromYear:         2026,
+		ToYear:           2027,
+		StudentOverrides: []dto.RolloverStudentOverride{{UserID: retained.UserID.String(), Action: "retain"}},
+	}, "admin-1")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.ID)
+	for _, entry := range result.Students {
+		switch entry.UserID {
+		case promoted.UserID.String():
+			assert.Equal(t, "G2-2027", entry.ToUnitCode)
+		case retained.UserID.String():
+			assert.Equal(t, "G1-2027", entry.ToUnitCode)
+		}
+	}
+	f.unitRepo.AssertExpectations(t)
+	f.membershipRepo.AssertExpectations(t)
+	f.rolloverRepo.AssertExpectations(t)
+}
+
+func TestRollover_RejectsRepeatedRollover(t *testing.T) {
+	f := newRolloverFixture()
+	f.rolloverRepo.ExpectedCalls = nil
+	f.rolloverRepo.On("FindBySchoolAndYears", mock.Anything, f.school.ID, 2026, 2027).Return(&repository.SchoolRollover{ID: uuid.New()}, nil)
+
+	_, err := f.svc.Rollover(context.Background(), f.school.ID.String(), dto.RolloverRequest{FromYear: 2026, ToYear: 2027}, "admin-1")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already applied")
+	f.unitRepo.AssertNotCalled(t, "FindBySchoolID", mock.Anything, mock.Anything, mock.Anything)
+}
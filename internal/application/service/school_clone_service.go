@@ -0,0 +1,611 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// maxUnitCodeLength es el largo máximo de código que acepta una unidad académica
+const maxUnitCodeLength = 50
+
+// SchoolCloneService copia la estructura de una escuela (árbol de unidades, años lectivos,
+// membresías y configuración) hacia otra escuela, por ejemplo para abrir una nueva sede.
+// Las materias no se copian: son un catálogo global compartido por todas las escuelas.
+type SchoolCloneService interface {
+	// CloneSchool calcula la copia y, salvo en dry run, la aplica en una sola transacción
+	CloneSchool(ctx context.Context, sourceSchoolID string, req dto.CloneSchoolRequest, clonedBy string) (*dto.CloneSchoolResponse, error)
+}
+
+type schoolCloneService struct {
+	schoolService  SchoolService
+	schoolRepo     repository.SchoolRepository
+	unitRepo       repository.AcademicUnitRepository
+	membershipRepo repository.UnitMembershipRepository
+	settingsRepo   repository.SchoolSettingsRepository
+	periodRepo     repository.AcademicPeriodRepository
+	quotaService   SchoolQuotaService
+	txManager      repository.TransactionManager
+	logger         logger.Logger
+}
+
+// NewSchoolCloneService crea un nuevo SchoolCloneService
+func NewSchoolCloneService(
+	schoolService SchoolService,
+	schoolRepo repository.SchoolRepository,
+	unitRepo repository.AcademicUnitRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	settingsRepo repository.SchoolSettingsRepository,
+	periodRepo repository.AcademicPeriodRepository,
+	quotaService SchoolQuotaService,
+	txManager repository.TransactionManager,
+	logger logger.Logger,
+) SchoolCloneService {
+	return &schoolCloneService{
+		schoolService:  schoolService,
+		schoolRepo:     schoolRepo,
+		unitRepo:       unitRepo,
+		membershipRepo: membershipRepo,
+		settingsRepo:   settingsRepo,
+		periodRepo:     periodRepo,
+		quotaService:   quotaService,
+		txManager:      txManager,
+		logger:         logger,
+	}
+}
+
+// clonePlanEntry es una unidad de origen con su código ya remapeado
+type clonePlanEntry struct {
+	source *entities.AcademicUnit
+	code   string
+	depth  int
+}
+
+// clonePeriodPlan es un año lectivo de origen con sus períodos, a copiar en el destino
+type clonePeriodPlan struct {
+	year  *repository.AcademicPeriod
+	terms []*repository.AcademicPeriod
+}
+
+func (s *schoolCloneService) CloneSchool(ctx context.Context, sourceSchoolID string, req dto.CloneSchoolRequest, clonedBy string) (*dto.CloneSchoolResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	sourceID, err := uuid.Parse(sourceSchoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	source, err := s.findSchool(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.CloneSchoolResponse{
+		DryRun:         req.DryRun,
+		SourceSchoolID: source.ID.String(),
+		CreatesSchool:  req.NewSchool != nil,
+	}
+
+	// Escuela destino: existente (se validan choques de código) o nueva (se crea al aplicar)
+	var target *entities.School
+	existingCodes := map[string]bool{}
+	if req.NewSchool != nil {
+		response.TargetSchoolCode = req.NewSchool.Code
+		exists, err := s.schoolRepo.ExistsByCode(ctx, req.NewSchool.Code)
+		if err != nil {
+			return nil, errors.NewDatabaseError("check school", err)
+		}
+		if exists {
+			response.Conflicts = append(response.Conflicts, fmt.Sprintf("school code %q already exists", req.NewSchool.Code))
+		}
+	} else {
+		targetID, _ := uuid.Parse(req.TargetSchoolID)
+		if targetID == source.ID {
+			return nil, errors.NewValidationError("target school must be different from the source school")
+		}
+		// Una escuela archivada es de solo lectura: no puede recibir la copia
+		if err := ensureSchoolWritable(ctx, s.schoolRepo, targetID); err != nil {
+			return nil, err
+		}
+		target, err = s.findSchool(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if !target.IsActive {
+			return nil, errors.NewBusinessRuleError("target school is not active")
+		}
+		response.TargetSchoolID = target.ID.String()
+		response.TargetSchoolCode = target.Code
+
+		targetUnits, err := s.unitRepo.FindBySchoolID(ctx, target.ID, false)
+		if err != nil {
+			return nil, errors.NewDatabaseError("list target units", err)
+		}
+		for _, unit := range targetUnits {
+			if unit.Code != "" {
+				existingCodes[unit.Code] = true
+			}
+		}
+	}
+
+	sourceUnits, err := s.unitRepo.FindBySchoolID(ctx, source.ID, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list units", err)
+	}
+	plan := planUnitClone(sourceUnits, req.IncludeInactive)
+
+	// Remapear códigos y detectar choques antes de escribir nada
+	planned := map[string]bool{}
+	for _, entry := range plan {
+		if entry.source.Code == "" {
+			continue
+		}
+		entry.code = remapUnitCode(entry.source.Code, source.Code, response.TargetSchoolCode, req.CodePrefix, req.CodeSuffix)
+		switch {
+		case len(entry.code) > maxUnitCodeLength:
+			response.Conflicts = append(response.Conflicts, fmt.Sprintf("unit code %q exceeds %d characters", entry.code, maxUnitCodeLength))
+		case existingCodes[entry.code]:
+			response.Conflicts = append(response.Conflicts, fmt.Sprintf("unit code %q already exists in target school", entry.code))
+		case planned[entry.code]:
+			response.Conflicts = append(response.Conflicts, fmt.Sprintf("unit code %q is generated more than once", entry.code))
+		}
+		planned[entry.code] = true
+	}
+
+	// Las unidades conservan su año lectivo: se copian los años (y sus períodos) que el destino no tiene
+	periods, conflicts, err := s.planPeriodClone(ctx, source.ID, target, plan)
+	if err != nil {
+		return nil, err
+	}
+	response.Conflicts = append(response.Conflicts, conflicts...)
+
+	var memberships []*entities.Membership
+	if !req.SkipMemberships {
+		memberships, err = s.membershipRepo.FindActiveBySchool(ctx, source.ID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("list memberships", err)
+		}
+	}
+
+	var settings *repository.SchoolSettings
+	if !req.SkipSettings {
+		settings, err = s.settingsRepo.FindBySchoolID(ctx, source.ID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find school settings", err)
+		}
+	}
+
+	if req.DryRun {
+		response.Units = toClonedUnitEntries(plan, nil)
+		response.AcademicYears = clonedYears(periods)
+		response.Memberships = countClonableMemberships(memberships, plan)
+		response.SettingsCopied = settings != nil
+		return response, nil
+	}
+	if len(response.Conflicts) > 0 {
+		return nil, errors.NewConflictError(fmt.Sprintf("clone has %d conflicts, run a dry run to review them", len(response.Conflicts)))
+	}
+
+	newIDs := make(map[uuid.UUID]uuid.UUID, len(plan))
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if req.NewSchool != nil {
+			created, err := s.schoolService.CreateSchool(ctx, *req.NewSchool)
+			if err != nil {
+				return err
+			}
+			targetID, err := uuid.Parse(created.ID)
+			if err != nil {
+				return errors.NewDatabaseError("parse school id", err)
+			}
+			target = &entities.School{ID: targetID, Code: created.Code}
+		}
+
+		// Los códigos se validaron fuera de la transacción: se confirman con la jerarquía bloqueada
+		if err := s.unitRepo.LockHierarchy(ctx, target.ID); err != nil {
+			return errors.NewDatabaseError("lock unit hierarchy", err)
+		}
+		if err := ensurePlannedCodesFree(ctx, s.unitRepo, target.ID, plan); err != nil {
+			return err
+		}
+		if err := s.quotaService.CheckUnitQuota(ctx, target.ID, len(plan)); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		years, err := s.clonePeriods(ctx, target.ID, periods, clonedBy, now)
+		if err != nil {
+			return err
+		}
+		response.AcademicYears = years
+
+		for _, entry := range plan {
+			unit := &entities.AcademicUnit{
+				ID:           uuid.New(),
+				SchoolID:     target.ID,
+				Name:         entry.source.Name,
+				Code:         entry.code,
+				Type:         entry.source.Type,
+				Description:  entry.source.Description,
+				Level:        entry.source.Level,
+				AcademicYear: entry.source.AcademicYear,
+				Metadata:     entry.source.Metadata,
+				IsActive:     entry.source.IsActive,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+			if entry.source.ParentUnitID != nil {
+				if parentID, ok := newIDs[*entry.source.ParentUnitID]; ok {
+					unit.ParentUnitID = &parentID
+				}
+			}
+			if len(unit.Metadata) == 0 {
+				unit.Metadata = []byte("{}")
+			}
+			if err := s.unitRepo.Create(ctx, unit); err != nil {
+				return errors.NewDatabaseError("create unit", err)
+			}
+			newIDs[entry.source.ID] = unit.ID
+		}
+
+		copied, err := s.cloneMemberships(ctx, target.ID, memberships, newIDs, now)
+		if err != nil {
+			return err
+		}
+		response.Memberships = copied
+
+		if settings != nil {
+			if err := s.cloneSettings(ctx, source, target.ID, settings, clonedBy, now); err != nil {
+				return err
+			}
+			response.SettingsCopied = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.TargetSchoolID = target.ID.String()
+	response.Units = toClonedUnitEntries(plan, newIDs)
+
+	s.logger.Info("school structure cloned",
+		"source_school_id", source.ID.String(),
+		"target_school_id", response.TargetSchoolID,
+		"units", len(plan),
+		"academic_years", len(response.AcademicYears),
+		"memberships", response.Memberships,
+		"cloned_by", clonedBy,
+	)
+
+	return response, nil
+}
+
+func (s *schoolCloneService) findSchool(ctx context.Context, id uuid.UUID) (*entities.School, error) {
+	school, err := s.schoolRepo.FindByID(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewNotFoundError("school")
+		}
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	return school, nil
+}
+
+// planPeriodClone arma los años lectivos de origen que usan las unidades copiadas y que el
+// destino todavía no tiene. Un año que se cruza en fechas con uno del destino es un conflicto.
+func (s *schoolCloneService) planPeriodClone(ctx context.Context, sourceID uuid.UUID, target *entities.School, plan []*clonePlanEntry) ([]*clonePeriodPlan, []string, error) {
+	years := map[int]bool{}
+	for _, entry := range plan {
+		if entry.source.AcademicYear > 0 {
+			years[entry.source.AcademicYear] = true
+		}
+	}
+	if len(years) == 0 {
+		return nil, nil, nil
+	}
+
+	sourcePeriods, err := s.periodRepo.ListBySchool(ctx, sourceID)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("list academic periods", err)
+	}
+	var targetYears []*repository.AcademicPeriod
+	if target != nil {
+		targetPeriods, err := s.periodRepo.ListBySchool(ctx, target.ID)
+		if err != nil {
+			return nil, nil, errors.NewDatabaseError("list academic periods", err)
+		}
+		for _, period := range targetPeriods {
+			if period.Type == repository.AcademicPeriodYear {
+				targetYears = append(targetYears, period)
+				delete(years, period.Year) // el destino ya tiene ese año: las unidades lo usan tal cual
+			}
+		}
+	}
+
+	var periods []*clonePeriodPlan
+	var conflicts []string
+	byYearID := map[uuid.UUID]*clonePeriodPlan{}
+	for _, period := range sourcePeriods {
+		if period.Type != repository.AcademicPeriodYear || !years[period.Year] {
+			continue
+		}
+		for _, existing := range targetYears {
+			if period.Overlaps(existing) {
+				conflicts = append(conflicts, fmt.Sprintf("academic year %d overlaps academic year %d of the target school", period.Year, existing.Year))
+			}
+		}
+		entry := &clonePeriodPlan{year: period}
+		periods = append(periods, entry)
+		byYearID[period.ID] = entry
+	}
+	for _, period := range sourcePeriods {
+		if period.Type != repository.AcademicPeriodTerm || period.ParentID == nil {
+			continue
+		}
+		if entry, ok := byYearID[*period.ParentID]; ok {
+			entry.terms = append(entry.terms, period)
+		}
+	}
+	return periods, conflicts, nil
+}
+
+// clonePeriods crea en el destino los años lectivos planificados con sus períodos y
+// retorna los años copiados. Los años cerrados se copian cerrados (son historia de solo
+// lectura); el resto queda planificado para que el destino active su propio calendario.
+func (s *schoolCloneService) clonePeriods(ctx context.Context, targetID uuid.UUID, periods []*clonePeriodPlan, clonedBy string, now time.Time) ([]int, error) {
+	years := []int{}
+	for _, entry := range periods {
+		existing, err := s.periodRepo.FindYear(ctx, targetID, entry.year.Year)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find academic year", err)
+		}
+		if existing != nil {
+			continue
+		}
+
+		year := clonePeriod(entry.year, targetID, nil, clonedBy, now)
+		if err := s.periodRepo.Create(ctx, year); err != nil {
+			return nil, errors.NewDatabaseError("create academic year", err)
+		}
+		for _, term := range entry.terms {
+			if err := s.periodRepo.Create(ctx, clonePeriod(term, targetID, &year.ID, clonedBy, now)); err != nil {
+				return nil, errors.NewDatabaseError("create academic term", err)
+			}
+		}
+		years = append(years, year.Year)
+	}
+	return years, nil
+}
+
+// clonePeriod copia un período de origen a la escuela destino
+func clonePeriod(source *repository.AcademicPeriod, schoolID uuid.UUID, parentID *uuid.UUID, clonedBy string, now time.Time) *repository.AcademicPeriod {
+	period := &repository.AcademicPeriod{
+		ID:        uuid.New(),
+		SchoolID:  schoolID,
+		ParentID:  parentID,
+		Type:      source.Type,
+		Name:      source.Name,
+		Year:      source.Year,
+		StartDate: source.StartDate,
+		EndDate:   source.EndDate,
+		Status:    repository.AcademicPeriodPlanned,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if source.IsClosed() {
+		period.Status = repository.AcademicPeriodClosed
+		period.ClosedBy = clonedBy
+		period.ClosedAt = &now
+	}
+	return period
+}
+
+// clonedYears retorna los años lectivos de la vista previa
+func clonedYears(periods []*clonePeriodPlan) []int {
+	years := make([]int, 0, len(periods))
+	for _, entry := range periods {
+		years = append(years, entry.year.Year)
+	}
+	return years
+}
+
+// cloneMemberships copia las membresías activas a la escuela destino, reubicadas en las
+// unidades copiadas. Se omiten las que el usuario ya tiene en el destino.
+func (s *schoolCloneService) cloneMemberships(ctx context.Context, targetID uuid.UUID, memberships []*entities.Membership, newIDs map[uuid.UUID]uuid.UUID, now time.Time) (int, error) {
+	copied := 0
+	for _, membership := range memberships {
+		var unitID *uuid.UUID
+		if membership.AcademicUnitID != nil {
+			newID, ok := newIDs[*membership.AcademicUnitID]
+			if !ok {
+				continue // la unidad quedó fuera de la copia
+			}
+			unitID = &newID
+		} else {
+			existing, err := s.membershipRepo.FindByUserAndSchool(ctx, membership.UserID, targetID)
+			if err != nil && !isNotFoundError(err) {
+				return 0, errors.NewDatabaseError("find membership", err)
+			}
+			if err == nil && existing != nil {
+				continue
+			}
+		}
+
+		if err := s.quotaService.CheckMembershipQuota(ctx, targetID, membership.UserID, membership.Role); err != nil {
+			return 0, err
+		}
+		clone := &entities.Membership{
+			ID:             uuid.New(),
+			UserID:         membership.UserID,
+			SchoolID:       targetID,
+			AcademicUnitID: unitID,
+			Role:           membership.Role,
+			Metadata:       membership.Metadata,
+			IsActive:       true,
+			EnrolledAt:     now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if len(clone.Metadata) == 0 {
+			clone.Metadata = []byte("{}")
+		}
+		if err := s.membershipRepo.Create(ctx, clone); err != nil {
+			return 0, errors.NewDatabaseError("create membership", err)
+		}
+		copied++
+	}
+	return copied, nil
+}
+
+// cloneSettings reemplaza la configuración del destino por la de origen y deja registro en el historial
+func (s *schoolCloneService) cloneSettings(ctx context.Context, source *entities.School, targetID uuid.UUID, settings *repository.SchoolSettings, clonedBy string, now time.Time) error {
+	current, err := s.settingsRepo.FindBySchoolID(ctx, targetID)
+	if err != nil {
+		return errors.NewDatabaseError("find school settings", err)
+	}
+	record := &repository.SchoolSettings{
+		SchoolID:      targetID,
+		SchemaVersion: settings.SchemaVersion,
+		Version:       1,
+		Overrides:     settings.Overrides,
+		UpdatedBy:     clonedBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	expectedVersion := 0
+	if current != nil {
+		expectedVersion = current.Version
+		record.Version = current.Version + 1
+		record.CreatedAt = current.CreatedAt
+	}
+
+	saved, err := s.settingsRepo.Save(ctx, record, expectedVersion)
+	if err != nil {
+		return errors.NewDatabaseError("save school settings", err)
+	}
+	if !saved {
+		return errors.NewConflictError("target settings were modified concurrently, retry the clone")
+	}
+	change := &repository.SchoolSettingsChange{
+		ID:        uuid.New(),
+		SchoolID:  targetID,
+		Version:   record.Version,
+		Patch:     settings.Overrides,
+		Overrides: settings.Overrides,
+		Reason:    fmt.Sprintf("cloned from school %s", source.Code),
+		ChangedBy: clonedBy,
+		CreatedAt: now,
+	}
+	if err := s.settingsRepo.CreateChange(ctx, change); err != nil {
+		return errors.NewDatabaseError("create school settings change", err)
+	}
+	return nil
+}
+
+// planUnitClone ordena las unidades de modo que cada padre preceda a sus hijos.
+// Si una unidad inactiva se excluye, se excluye también su subárbol.
+func planUnitClone(units []*entities.AcademicUnit, includeInactive bool) []*clonePlanEntry {
+	byID := make(map[uuid.UUID]bool, len(units))
+	for _, unit := range units {
+		byID[unit.ID] = true
+	}
+	children := map[uuid.UUID][]*entities.AcademicUnit{}
+	var roots []*entities.AcademicUnit
+	for _, unit := range units {
+		if unit.ParentUnitID != nil && byID[*unit.ParentUnitID] {
+			children[*unit.ParentUnitID] = append(children[*unit.ParentUnitID], unit)
+		} else {
+			roots = append(roots, unit)
+		}
+	}
+
+	var plan []*clonePlanEntry
+	var walk func(nodes []*entities.AcademicUnit, depth int)
+	walk = func(nodes []*entities.AcademicUnit, depth int) {
+		for _, unit := range nodes {
+			if !unit.IsActive && !includeInactive {
+				continue
+			}
+			plan = append(plan, &clonePlanEntry{source: unit, depth: depth})
+			walk(children[unit.ID], depth+1)
+		}
+	}
+	walk(roots, 0)
+	return plan
+}
+
+// remapUnitCode reemplaza el código de la escuela origen por el del destino y aplica prefijo y sufijo
+func remapUnitCode(code, sourceSchoolCode, targetSchoolCode, prefix, suffix string) string {
+	if sourceSchoolCode != "" {
+		code = strings.ReplaceAll(code, sourceSchoolCode, targetSchoolCode)
+	}
+	return prefix + code + suffix
+}
+
+// ensurePlannedCodesFree confirma que ningún código del plan se haya ocupado en la escuela destino
+func ensurePlannedCodesFree(ctx context.Context, unitRepo repository.AcademicUnitRepository, schoolID uuid.UUID, plan []*clonePlanEntry) error {
+	for _, entry := range plan {
+		if entry.code == "" {
+			continue
+		}
+		exists, err := unitRepo.ExistsBySchoolIDAndCode(ctx, schoolID, entry.code)
+		if err != nil {
+			return errors.NewDatabaseError("check unit code", err)
+		}
+		if exists {
+			return errors.NewAlreadyExistsError("academic unit with code").WithField("code", entry.code)
+		}
+	}
+	return nil
+}
+
+func countClonableMemberships(memberships []*entities.Membership, plan []*clonePlanEntry) int {
+	inPlan := make(map[uuid.UUID]bool, len(plan))
+	for _, entry := range plan {
+		inPlan[entry.source.ID] = true
+	}
+	count := 0
+	for _, membership := range memberships {
+		if membership.AcademicUnitID == nil || inPlan[*membership.AcademicUnitID] {
+			count++
+		}
+	}
+	return count
+}
+
+func toClonedUnitEntries(plan []*clonePlanEntry, newIDs map[uuid.UUID]uuid.UUID) []dto.ClonedUnitEntry {
+	codes := make(map[uuid.UUID]string, len(plan))
+	for _, entry := range plan {
+		codes[entry.source.ID] = entry.code
+	}
+	entries := make([]dto.ClonedUnitEntry, len(plan))
+	for i, entry := range plan {
+		entries[i] = dto.ClonedUnitEntry{
+			SourceID:   entry.source.ID.String(),
+			Type:       entry.source.Type,
+			Name:       entry.source.Name,
+			SourceCode: entry.source.Code,
+			Code:       entry.code,
+			Depth:      entry.depth,
+		}
+		if id, ok := newIDs[entry.source.ID]; ok {
+			entries[i].ID = id.String()
+		}
+		if entry.source.ParentUnitID != nil {
+			entries[i].ParentCode = codes[*entry.source.ParentUnitID]
+		}
+	}
+	return entries
+}
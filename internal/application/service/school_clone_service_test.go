@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// cloneSourceTree arma una escuela origen con un grado, su sección y una unidad inactiva
+func cloneSourceTree() (*entities.School, []*entities.AcademicUnit) {
+	school := &entities.School{ID: uuid.New(), Code: "NORTE", IsActive: true}
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, Code: "NORTE-G1", Type: "grade", Name: "Primero", IsActive: true}
+	section := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, ParentUnitID: &grade.ID, Code: "NORTE-G1-A", Type: "section", Name: "Primero A", IsActive: true}
+	old := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, Code: "NORTE-OLD", Type: "grade", Name: "Antiguo", IsActive: false}
+	// La sección llega antes que su padre para verificar el orden de creación
+	return school, []*entities.AcademicUnit{section, old, grade}
+}
+
+func TestCloneSchool_DryRunReportsConflicts(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockSettingsRepo := new(MockSchoolSettingsRepository)
+	schoolService := NewSchoolService(mockSchoolRepo, newTestLogger(), getTestDefaults())
+	svc := NewSchoolCloneService(schoolService, mockSchoolRepo, mockUnitRepo, mockMembershipRepo, mockSettingsRepo,
+		new(MockAcademicPeriodRepository), newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger())
+	source, units := cloneSourceTree()
+	target := &entities.School{ID: uuid.New(), Code: "SUR", IsActive: true}
+
+	mockSchoolRepo.On("FindByID", mock.Anything, source.ID).Return(source, nil)
+	mockSchoolRepo.On("FindByID", mock.Anything, target.ID).Return(target, nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, target.ID, false).Return([]*entities.AcademicUnit{{ID: uuid.New(), SchoolID: target.ID, Code: "SUR-G1"}}, nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, source.ID, false).Return(units, nil)
+	mockMembershipRepo.On("FindActiveBySchool", mock.Anything, source.ID).Return([]*entities.Membership{
+		{ID: uuid.New(), UserID: uuid.New(), SchoolID: source.ID, AcademicUnitID: &units[0].ID, Role: "student"},
+		{ID: uuid.New(), UserID: uuid.New(), SchoolID: source.ID, AcademicUnitID: &units[1].ID, Role: "student"},
+	}, nil)
+	mockSettingsRepo.On("FindBySchoolID", mock.Anything, source.ID).Return(nil, nil)
+
+	result, err := svc.CloneSchool(context.Background(), source.ID.String(), dto.CloneSchoolRequest{TargetSchoolID: target.ID.String(), DryRun: true}, "admin-1")
+
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	require.Len(t, result.Units, 2)
+	assert.Equal(t, "SUR-G1", result.Units[0].Code)
+	assert.Equal(t, "SUR-G1-A", result.Units[1].Code)
+	assert.Equal(t, "SUR-G1", result.Units[1].ParentCode)
+	assert.Equal(t, 1, result.Units[1].Depth)
+	assert.Equal(t, 1, result.Memberships) // la membresía de la unidad inactiva queda fuera
+	require.Len(t, result.Conflicts, 1)
+	assert.Contains(t, result.Conflicts[0], "SUR-G1")
+	mockUnitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	// Sin dry run, los conflictos impiden aplicar la copia
+	_, err = svc.CloneSchool(context.Background(), source.ID.String(), dto.CloneSchoolRequest{TargetSchoolID: target.ID.String()}, "admin-1")
+	require.Error(t, err)
+	mockUnitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCloneSchool_IntoExistingSchool(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockSettingsRepo := new(MockSchoolSettingsRepository)
+	schoolService := NewSchoolService(mockSchoolRepo, newTestLogger(), getTestDefaults())
+	svc := NewSchoolCloneService(schoolService, mockSchoolRepo, mockUnitRepo, mockMembershipRepo, mockSettingsRepo,
+		new(MockAcademicPeriodRepository), newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger())
+	source, units := cloneSourceTree()
+	section, grade := units[0], units[2]
+	target := &entities.School{ID: uuid.New(), Code: "SUR", IsActive: true}
+	studentID, directorID := uuid.New(), uuid.New()
+
+	mockSchoolRepo.On("FindByID", mock.Anything, source.ID).Return(source, nil)
+	mockSchoolRepo.On("FindByID", mock.Anything, target.ID).Return(target, nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, target.ID, false).Return([]*entities.AcademicUnit{}, nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, source.ID, false).Return(units, nil)
+	mockMembershipRepo.On("FindActiveBySchool", mock.Anything, source.ID).Return([]*entities.Membership{
+		{ID: uuid.New(), UserID: studentID, SchoolID: source.ID, AcademicUnitID: &section.ID, Role: "student"},
+		{ID: uuid.New(), UserID: directorID, SchoolID: source.ID, Role: "director"},
+	}, nil)
+	mockMembershipRepo.On("FindByUserAndSchool", mock.Anything, directorID, target.ID).Return(nil, nil)
+	mockSettingsRepo.On("FindBySchoolID", mock.Anything, source.ID).Return(&repository.SchoolSettings{
+		SchoolID: source.ID, SchemaVersion: 1, Version: 3, Overrides: []byte(`{"locale":"es-MX"}`),
+	}, nil)
+	mockSettingsRepo.On("FindBySchoolID", mock.Anything, target.ID).Return(nil, nil)
+	mockUnitRepo.On("LockHierarchy", mock.Anything, target.ID).Return(nil).Once()
+	mockUnitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, target.ID, mock.Anything).Return(false, nil)
+
+	var created []*entities.AcademicUnit
+	mockUnitRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.AcademicUnit")).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(1).(*entities.AcademicUnit))
+	}).Return(nil)
+	var memberships []*entities.Membership
+	mockMembershipRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Membership")).Run(func(args mock.Arguments) {
+		memberships = append(memberships, args.Get(1).(*entities.Membership))
+	}).Return(nil)
+	mockSettingsRepo.On("Save", mock.Anything, mock.MatchedBy(func(s *repository.SchoolSettings) bool {
+		return s.SchoolID == target.ID && s.Version == 1 && string(s.Overrides) == `{"locale":"es-MX"}`
+	}), 0).Return(true, nil)
+	mockSettingsRepo.On("CreateChange", mock.Anything, mock.AnythingOfType("*repository.SchoolSettingsChange")).Return(nil)
+
+	result, err := svc.CloneSchool(context.Background(), source.ID.String(), dto.CloneSchoolRequest{TargetSchoolID: target.ID.String()}, "admin-1")
+
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.Equal(t, grade.Code, result.Units[0].SourceCode)
+	assert.Equal(t, "SUR-G1", created[0].Code)
+	assert.Nil(t, created[0].ParentUnitID)
+	require.NotNil(t, created[1].ParentUnitID)
+	assert.Equal(t, created[0].ID, *created[1].ParentUnitID)
+	assert.Equal(t, target.ID, created[1].SchoolID)
+
+	require.Len(t, memberships, 2)
+	assert.Equal(t, created[1].ID, *memberships[0].AcademicUnitID)
+	assert.Nil(t, memberships[1].AcademicUnitID)
+	assert.Equal(t, target.ID, memberships[1].SchoolID)
+
+	assert.Equal(t, 2, result.Memberships)
+	assert.True(t, result.SettingsCopied)
+	assert.Equal(t, created[0].ID.String(), result.Units[0].ID)
+	mockSettingsRepo.AssertExpectations(t)
+}
+
+func TestCloneSchool_NewSchoolWithoutMemberships(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockSettingsRepo := new(MockSchoolSettingsRepository)
+	schoolService := NewSchoolService(mockSchoolRepo, newTestLogger(), getTestDefaults())
+	svc := NewSchoolCloneService(schoolService, mockSchoolRepo, mockUnitRepo, mockMembershipRepo, mockSettingsRepo,
+		new(MockAcademicPeriodRepository), newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger())
+	source, units := cloneSourceTree()
+
+	mockSchoolRepo.On("FindByID", mock.Anything, source.ID).Return(source, nil)
+	mockSchoolRepo.On("ExistsByCode", mock.Anything, "CENTRO").Return(false, nil)
+	mockSchoolRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.School")).Return(nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, source.ID, false).Return(units, nil)
+	mockUnitRepo.On("LockHierarchy", mock.Anything, mock.Anything).Return(nil).Once()
+	mockUnitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	var created []*entities.AcademicUnit
+	mockUnitRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.AcademicUnit")).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(1).(*entities.AcademicUnit))
+	}).Return(nil)
+
+	req := dto.CloneSchoolRequest{
+		NewSchool:       &dto.CreateSchoolRequest{Name: "Sede Centro", Code: "CENTRO"},
+		CodeSuffix:      "-2027",
+		IncludeInactive: true,
+		SkipMemberships: true,
+		SkipSettings:    true,
+	}
+	result, err := svc.CloneSchool(context.Background(), source.ID.String(), req, "admin-1")
+
+	require.NoError(t, err)
+	assert.True(t, result.CreatesSchool)
+	assert.NotEmpty(t, result.TargetSchoolID)
+	require.Len(t, created, 3)
+	assert.Equal(t, "CENTRO-OLD-2027", result.Units[0].Code)
+	assert.False(t, created[0].IsActive)
+	assert.Equal(t, "CENTRO-G1-A-2027", result.Units[2].Code)
+	assert.Equal(t, 0, result.Memberships)
+	mockMembershipRepo.AssertNotCalled(t, "FindActiveBySchool", mock.Anything, mock.Anything)
+	mockSettingsRepo.AssertNotCalled(t, "FindBySchoolID", mock.Anything, mock.Anything)
+}
+
+func TestCloneSchool_CopiesAcademicYearsOfClonedUnits(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockPeriodRepo := new(MockAcademicPeriodRepository)
+	schoolService := NewSchoolService(mockSchoolRepo, newTestLogger(), getTestDefaults())
+	svc := NewSchoolCloneService(schoolService, mockSchoolRepo, mockUnitRepo, mockMembershipRepo, new(MockSchoolSettingsRepository),
+		mockPeriodRepo, newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger())
+	source, units := cloneSourceTree()
+	for _, unit := range units {
+		unit.AcademicYear = 2026
+	}
+	target := &entities.School{ID: uuid.New(), Code: "SUR", IsActive: true}
+
+	// Solo el año 2026 lo usan las unidades copiadas; el 2025 se queda en el origen
+	year := testAcademicYear(source.ID, 2026, repository.AcademicPeriodActive)
+	term := &repository.AcademicPeriod{
+		ID: uuid.New(), SchoolID: source.ID, ParentID: &year.ID, Type: repository.AcademicPeriodTerm, Name: "Primer periodo",
+		Year: 2026, StartDate: year.StartDate, EndDate: year.StartDate.AddDate(0, 3, 0), Status: repository.AcademicPeriodActive,
+	}
+	previous := testAcademicYear(source.ID, 2025, repository.AcademicPeriodClosed)
+
+	mockSchoolRepo.On("FindByID", mock.Anything, source.ID).Return(source, nil)
+	mockSchoolRepo.On("FindByID", mock.Anything, target.ID).Return(target, nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, target.ID, false).Return([]*entities.AcademicUnit{}, nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, source.ID, false).Return(units, nil)
+	mockPeriodRepo.On("ListBySchool", mock.Anything, source.ID).Return([]*repository.AcademicPeriod{previous, year, term}, nil)
+	mockPeriodRepo.On("ListBySchool", mock.Anything, target.ID).Return([]*repository.AcademicPeriod{}, nil)
+	mockPeriodRepo.On("FindYear", mock.Anything, target.ID, 2026).Return(nil, nil)
+	mockUnitRepo.On("LockHierarchy", mock.Anything, target.ID).Return(nil).Once()
+	mockUnitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, target.ID, mock.Anything).Return(false, nil)
+	var created []*entities.AcademicUnit
+	mockUnitRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.AcademicUnit")).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(1).(*entities.AcademicUnit))
+	}).Return(nil)
+	var periods []*repository.AcademicPeriod
+	mockPeriodRepo.On("Create", mock.Anything, mock.AnythingOfType("*repository.AcademicPeriod")).Run(func(args mock.Arguments) {
+		periods = append(periods, args.Get(1).(*repository.AcademicPeriod))
+	}).Return(nil)
+
+	req := dto.CloneSchoolRequest{TargetSchoolID: target.ID.String(), SkipMemberships: true, SkipSettings: true}
+	result, err := svc.CloneSchool(context.Background(), source.ID.String(), req, "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{2026}, result.AcademicYears)
+	require.Len(t, created, 2)
+	assert.Equal(t, 2026, created[0].AcademicYear)
+	require.Len(t, periods, 2)
+	assert.Equal(t, target.ID, periods[0].SchoolID)
+	assert.Equal(t, 2026, periods[0].Year)
+	assert.Equal(t, repository.AcademicPeriodPlanned, periods[0].Status)
+	require.NotNil(t, periods[1].ParentID)
+	assert.Equal(t, periods[0].ID, *periods[1].ParentID)
+	assert.Equal(t, "Primer periodo", periods[1].Name)
+	assert.Equal(t, repository.AcademicPeriodPlanned, periods[1].Status)
+}
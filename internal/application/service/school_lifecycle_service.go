@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// purgeStepTimeout limita cada paso de la purga, que corre fuera del request
+const purgeStepTimeout = 5 * time.Minute
+
+// SchoolLifecycleService administra el archivado, la restauración y la purga definitiva de escuelas.
+// Las materias no se archivan: son un catálogo global, no pertenecen a una escuela.
+type SchoolLifecycleService interface {
+	// ArchiveSchool desactiva la escuela y en cascada sus unidades y membresías activas
+	ArchiveSchool(ctx context.Context, schoolID string, req dto.ArchiveSchoolRequest, archivedBy string) (*dto.SchoolArchiveResponse, error)
+
+	// GetArchive obtiene el archivado vigente de una escuela archivada
+	GetArchive(ctx context.Context, schoolID string) (*dto.SchoolArchiveResponse, error)
+
+	// RestoreSchool reactiva la escuela y exactamente lo que su archivado desactivó
+	RestoreSchool(ctx context.Context, schoolID string, restoredBy string) (*dto.RestoreSchoolResponse, error)
+
+	// PurgeSchool inicia en segundo plano la eliminación definitiva de una escuela archivada
+	// cuyo período de retención ya venció
+	PurgeSchool(ctx context.Context, schoolID string, req dto.PurgeSchoolRequest, requestedBy string) (*dto.SchoolPurgeJobResponse, error)
+
+	// GetPurgeJob obtiene el progreso de una purga
+	GetPurgeJob(ctx context.Context, jobID string) (*dto.SchoolPurgeJobResponse, error)
+}
+
+type schoolLifecycleService struct {
+	schoolRepo     repository.SchoolRepository
+	unitRepo       repository.AcademicUnitRepository
+	membershipRepo repository.UnitMembershipRepository
+	lifecycleRepo  repository.SchoolLifecycleRepository
+	txManager      repository.TransactionManager
+	retention      time.Duration
+	logger         logger.Logger
+
+	// startJob lanza el job de purga; los tests lo reemplazan para ejecutarlo en línea
+	startJob func(func())
+}
+
+// NewSchoolLifecycleService crea un nuevo SchoolLifecycleService
+func NewSchoolLifecycleService(
+	schoolRepo repository.SchoolRepository,
+	unitRepo repository.AcademicUnitRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	lifecycleRepo repository.SchoolLifecycleRepository,
+	txManager repository.TransactionManager,
+	cfg config.LifecycleConfig,
+	logger logger.Logger,
+) SchoolLifecycleService {
+	return &schoolLifecycleService{
+		schoolRepo:     schoolRepo,
+		unitRepo:       unitRepo,
+		membershipRepo: membershipRepo,
+		lifecycleRepo:  lifecycleRepo,
+		txManager:      txManager,
+		retention:      cfg.PurgeRetention,
+		logger:         logger,
+		startJob:       func(run func()) { go run() },
+	}
+}
+
+func (s *schoolLifecycleService) ArchiveSchool(ctx context.Context, schoolID string, req dto.ArchiveSchoolRequest, archivedBy string) (*dto.SchoolArchiveResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+
+	units, err := s.unitRepo.FindBySchoolID(ctx, school.ID, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list units", err)
+	}
+	memberships, err := s.membershipRepo.FindActiveBySchool(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list memberships", err)
+	}
+
+	now := time.Now()
+	archive := &repository.SchoolArchive{
+		ID:         uuid.New(),
+		SchoolID:   school.ID,
+		SchoolCode: school.Code,
+		Reason:     strings.TrimSpace(req.Reason),
+		ArchivedBy: archivedBy,
+		ArchivedAt: now,
+		PurgeAfter: now.Add(s.retention),
+	}
+	var items []repository.SchoolArchiveItem
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Solo se desactiva lo que está activo, para que restaurar no reviva lo que ya estaba inactivo
+		for _, unit := range units {
+			if !unit.IsActive {
+				continue
+			}
+			unit.IsActive = false
+			unit.UpdatedAt = now
+			if err := s.unitRepo.Update(ctx, unit); err != nil {
+				return errors.NewDatabaseError("archive unit", err)
+			}
+			items = append(items, repository.SchoolArchiveItem{ArchiveID: archive.ID, EntityType: repository.SchoolArchiveItemUnit, EntityID: unit.ID})
+		}
+		for _, membership := range memberships {
+			membership.IsActive = false
+			membership.UpdatedAt = now
+			if err := s.membershipRepo.Update(ctx, membership); err != nil {
+				return errors.NewDatabaseError("archive membership", err)
+			}
+			items = append(items, repository.SchoolArchiveItem{ArchiveID: archive.ID, EntityType: repository.SchoolArchiveItemMembership, EntityID: membership.ID})
+		}
+
+		school.IsActive = false
+		school.UpdatedAt = now
+		if err := s.schoolRepo.Update(ctx, school); err != nil {
+			return errors.NewDatabaseError("archive school", err)
+		}
+		if err := s.schoolRepo.Delete(ctx, school.ID); err != nil {
+			return errors.NewDatabaseError("archive school", err)
+		}
+		if err := s.lifecycleRepo.CreateArchive(ctx, archive, items); err != nil {
+			return errors.NewDatabaseError("create school archive", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.ToSchoolArchiveResponse(archive, items)
+	s.logger.Info("school archived",
+		"school_id", school.ID.String(),
+		"archive_id", archive.ID.String(),
+		"units", resp.UnitsArchived,
+		"memberships", resp.MembershipsArchived,
+		"archived_by", archivedBy,
+	)
+	return resp, nil
+}
+
+func (s *schoolLifecycleService) GetArchive(ctx context.Context, schoolID string) (*dto.SchoolArchiveResponse, error) {
+	school, err := s.loadArchivedSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := s.lifecycleRepo.FindOpenArchive(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school archive", err)
+	}
+	if archive == nil {
+		return nil, errors.NewNotFoundError("school archive")
+	}
+	items, err := s.lifecycleRepo.ListArchiveItems(ctx, archive.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list school archive items", err)
+	}
+	return dto.ToSchoolArchiveResponse(archive, items), nil
+}
+
+func (s *schoolLifecycleService) RestoreSchool(ctx context.Context, schoolID string, restoredBy string) (*dto.RestoreSchoolResponse, error) {
+	school, err := s.loadArchivedSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.lifecycleRepo.FindUnfinishedPurgeJob(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find purge job", err)
+	}
+	if job != nil {
+		return nil, errors.NewConflictError("school purge is in progress")
+	}
+
+	exists, err := s.schoolRepo.ExistsByCode(ctx, school.Code)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check school", err)
+	}
+	if exists {
+		return nil, errors.NewConflictError("another active school already uses code " + school.Code)
+	}
+
+	// Escuelas eliminadas antes de existir el archivado no tienen registro: se restaura solo la escuela
+	archive, err := s.lifecycleRepo.FindOpenArchive(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school archive", err)
+	}
+	var items []repository.SchoolArchiveItem
+	if archive != nil {
+		if items, err = s.lifecycleRepo.ListArchiveItems(ctx, archive.ID); err != nil {
+			return nil, errors.NewDatabaseError("list school archive items", err)
+		}
+	}
+
+	resp := &dto.RestoreSchoolResponse{}
+	now := time.Now()
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.schoolRepo.Restore(ctx, school.ID); err != nil {
+			return errors.NewDatabaseError("restore school", err)
+		}
+
+		for _, item := range items {
+			restored, err := s.restoreItem(ctx, item, now)
+			if err != nil {
+				return err
+			}
+			switch {
+			case !restored:
+				resp.Skipped++
+			case item.EntityType == repository.SchoolArchiveItemUnit:
+				resp.UnitsRestored++
+			default:
+				resp.MembershipsRestored++
+			}
+		}
+
+		if archive != nil {
+			archive.RestoredBy = restoredBy
+			archive.RestoredAt = &now
+			if err := s.lifecycleRepo.UpdateArchive(ctx, archive); err != nil {
+				return errors.NewDatabaseError("update school archive", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	school.IsActive = true
+	school.DeletedAt = nil
+	school.UpdatedAt = now
+	resp.School = dto.ToSchoolResponse(school)
+
+	s.logger.Info("school restored",
+		"school_id", school.ID.String(),
+		"units", resp.UnitsRestored,
+		"memberships", resp.MembershipsRestored,
+		"skipped", resp.Skipped,
+		"restored_by", restoredBy,
+	)
+	return resp, nil
+}
+
+// restoreItem reactiva una entidad archivada; retorna false si ya no existe
+func (s *schoolLifecycleService) restoreItem(ctx context.Context, item repository.SchoolArchiveItem, now time.Time) (bool, error) {
+	switch item.EntityType {
+	case repository.SchoolArchiveItemUnit:
+		unit, err := s.unitRepo.FindByID(ctx, item.EntityID, false)
+		if isNotFoundError(err) || (err == nil && unit == nil) {
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.NewDatabaseError("find unit", err)
+		}
+		unit.IsActive = true
+		unit.UpdatedAt = now
+		if err := s.unitRepo.Update(ctx, unit); err != nil {
+			return false, errors.NewDatabaseError("restore unit", err)
+		}
+		return true, nil
+
+	case repository.SchoolArchiveItemMembership:
+		membership, err := s.membershipRepo.FindByID(ctx, item.EntityID)
+		if isNotFoundError(err) || (err == nil && membership == nil) {
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.NewDatabaseError("find membership", err)
+		}
+		membership.IsActive = true
+		membership.UpdatedAt = now
+		if err := s.membershipRepo.Update(ctx, membership); err != nil {
+			return false, errors.NewDatabaseError("restore membership", err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (s *schoolLifecycleService) PurgeSchool(ctx context.Context, schoolID string, req dto.PurgeSchoolRequest, requestedBy string) (*dto.SchoolPurgeJobResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	school, err := s.loadArchivedSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(req.ConfirmCode), school.Code) {
+		return nil, errors.NewValidationError("confirm_code does not match the school code")
+	}
+
+	archive, err := s.lifecycleRepo.FindOpenArchive(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school archive", err)
+	}
+	if archive == nil {
+		return nil, errors.NewBusinessRuleError("school has no archive record; restore and archive it before purging")
+	}
+	if time.Now().Before(archive.PurgeAfter) {
+		return nil, errors.NewBusinessRuleError(fmt.Sprintf("school can be purged after %s", archive.PurgeAfter.Format(time.RFC3339)))
+	}
+
+	running, err := s.lifecycleRepo.FindUnfinishedPurgeJob(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find purge job", err)
+	}
+	if running != nil {
+		return dto.ToSchoolPurgeJobResponse(running), nil
+	}
+
+	job := &repository.SchoolPurgeJob{
+		ID:          uuid.New(),
+		SchoolID:    school.ID,
+		ArchiveID:   archive.ID,
+		Status:      repository.PurgeJobStatusPending,
+		TotalSteps:  len(repository.PurgeSteps),
+		DeletedRows: map[string]int64{},
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.lifecycleRepo.CreatePurgeJob(ctx, job); err != nil {
+		return nil, errors.NewDatabaseError("create purge job", err)
+	}
+
+	s.logger.Info("school purge started",
+		"school_id", school.ID.String(),
+		"job_id", job.ID.String(),
+		"requested_by", requestedBy,
+	)
+	resp := dto.ToSchoolPurgeJobResponse(job)
+
+	// El job sigue aunque el request termine
+	s.startJob(func() { s.runPurge(job, archive) })
+	return resp, nil
+}
+
+// runPurge ejecuta los pasos de la purga en orden, guardando el progreso después de cada uno.
+// Cada paso corre en su propia transacción; si uno falla el job queda en failed y puede reintentarse.
+func (s *schoolLifecycleService) runPurge(job *repository.SchoolPurgeJob, archive *repository.SchoolArchive) {
+	started := time.Now()
+	job.Status = repository.PurgeJobStatusRunning
+	job.StartedAt = &started
+	s.savePurgeJob(job)
+
+	for _, step := range repository.PurgeSteps {
+		job.CurrentStep = step
+		s.savePurgeJob(job)
+
+		ctx, cancel := context.WithTimeout(context.Background(), purgeStepTimeout)
+		err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			deleted, err := s.lifecycleRepo.PurgeSchoolData(ctx, job.SchoolID, step)
+			if err != nil {
+				return err
+			}
+			job.DeletedRows[step] = deleted
+			return nil
+		})
+		cancel()
+
+		if err != nil {
+			finished := time.Now()
+			job.Status = repository.PurgeJobStatusFailed
+			job.Error = fmt.Sprintf("step %s: %v", step, err)
+			job.FinishedAt = &finished
+			s.savePurgeJob(job)
+			s.logger.Error("school purge failed",
+				"school_id", job.SchoolID.String(),
+				"job_id", job.ID.String(),
+				"step", step,
+				"error", err.Error(),
+			)
+			return
+		}
+		job.CompletedSteps++
+	}
+
+	finished := time.Now()
+	job.Status = repository.PurgeJobStatusCompleted
+	job.CurrentStep = ""
+	job.FinishedAt = &finished
+	s.savePurgeJob(job)
+
+	archive.PurgedAt = &finished
+	ctx, cancel := context.WithTimeout(context.Background(), purgeStepTimeout)
+	defer cancel()
+	if err := s.lifecycleRepo.UpdateArchive(ctx, archive); err != nil {
+		s.logger.Warn("error marking school archive as purged", "archive_id", archive.ID.String(), "error", err)
+	}
+
+	s.logger.Info("school purged",
+		"school_id", job.SchoolID.String(),
+		"job_id", job.ID.String(),
+		"duration", finished.Sub(started).String(),
+	)
+}
+
+// savePurgeJob guarda el progreso; un fallo aquí no detiene la purga
+func (s *schoolLifecycleService) savePurgeJob(job *repository.SchoolPurgeJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.lifecycleRepo.UpdatePurgeJob(ctx, job); err != nil {
+		s.logger.Warn("error saving purge job progress", "job_id", job.ID.String(), "error", err)
+	}
+}
+
+func (s *schoolLifecycleService) GetPurgeJob(ctx context.Context, jobID string) (*dto.SchoolPurgeJobResponse, error) {
+	id, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid job ID")
+	}
+
+	job, err := s.lifecycleRepo.FindPurgeJob(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find purge job", err)
+	}
+	if job == nil {
+		return nil, errors.NewNotFoundError("purge job")
+	}
+	return dto.ToSchoolPurgeJobResponse(job), nil
+}
+
+func (s *schoolLifecycleService) loadArchivedSchool(ctx context.Context, schoolID string) (*entities.School, error) {
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindArchivedByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("archived school")
+	}
+	return school, nil
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// MockSchoolLifecycleRepository mock implementation
+type MockSchoolLifecycleRepository struct {
+	mock.Mock
+}
+
+func (m *MockSchoolLifecycleRepository) CreateArchive(ctx context.Context, archive *repository.SchoolArchive, items []repository.SchoolArchiveItem) error {
+	args := m.Called(ctx, archive, items)
+	return args.Error(0)
+}
+
+func (m *MockSchoolLifecycleRepository) FindOpenArchive(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolArchive, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.SchoolArchive), args.Error(1)
+}
+
+func (m *MockSchoolLifecycleRepository) ListArchiveItems(ctx context.Context, archiveID uuid.UUID) ([]repository.SchoolArchiveItem, error) {
+	args := m.Called(ctx, archiveID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.SchoolArchiveItem), args.Error(1)
+}
+
+func (m *MockSchoolLifecycleRepository) UpdateArchive(ctx context.Context, archive *repository.SchoolArchive) error {
+	args := m.Called(ctx, archive)
+	return args.Error(0)
+}
+
+func (m *MockSchoolLifecycleRepository) CreatePurgeJob(ctx context.Context, job *repository.SchoolPurgeJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockSchoolLifecycleRepository) UpdatePurgeJob(ctx context.Context, job *repository.SchoolPurgeJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockSchoolLifecycleRepository) FindPurgeJob(ctx context.Context, id uuid.UUID) (*repository.SchoolPurgeJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.SchoolPurgeJob), args.Error(1)
+}
+
+func (m *MockSchoolLifecycleRepository) FindUnfinishedPurgeJob(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolPurgeJob, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.SchoolPurgeJob), args.Error(1)
+}
+
+func (m *MockSchoolLifecycleRepository) PurgeSchoolData(ctx context.Context, schoolID uuid.UUID, step string) (int64, error) {
+	args := m.Called(ctx, schoolID, step)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestSchoolLifecycle_ArchiveCascades(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockLifecycleRepo := new(MockSchoolLifecycleRepository)
+	svc := NewSchoolLifecycleService(mockSchoolRepo, mockUnitRepo, mockMembershipRepo, mockLifecycleRepo,
+		passthroughTxManager{}, config.LifecycleConfig{PurgeRetention: 30 * 24 * time.Hour}, newTestLogger())
+
+	school := &entities.School{ID: uuid.New(), Code: "ARC001", IsActive: true}
+	activeUnit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, IsActive: true}
+	inactiveUnit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, IsActive: false}
+	membership := &entities.Membership{ID: uuid.New(), SchoolID: school.ID, IsActive: true}
+
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, school.ID, false).Return([]*entities.AcademicUnit{activeUnit, inactiveUnit}, nil)
+	mockMembershipRepo.On("FindActiveBySchool", mock.Anything, school.ID).Return([]*entities.Membership{membership}, nil)
+	mockUnitRepo.On("Update", mock.Anything, activeUnit).Return(nil).Once()
+	mockMembershipRepo.On("Update", mock.Anything, membership).Return(nil).Once()
+	mockSchoolRepo.On("Update", mock.Anything, school).Return(nil)
+	mockSchoolRepo.On("Delete", mock.Anything, school.ID).Return(nil)
+	mockLifecycleRepo.On("CreateArchive", mock.Anything, mock.Anything, mock.MatchedBy(func(items []repository.SchoolArchiveItem) bool {
+		return len(items) == 2 && items[0].EntityID == activeUnit.ID && items[1].EntityID == membership.ID
+	})).Return(nil)
+
+	result, err := svc.ArchiveSchool(context.Background(), school.ID.String(), dto.ArchiveSchoolRequest{Reason: "cierre"}, "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.UnitsArchived)
+	assert.Equal(t, 1, result.MembershipsArchived)
+	assert.False(t, activeUnit.IsActive)
+	assert.False(t, membership.IsActive)
+	assert.False(t, school.IsActive)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), result.PurgeAfter, time.Minute)
+	mockUnitRepo.AssertNotCalled(t, "Update", mock.Anything, inactiveUnit)
+	mockLifecycleRepo.AssertExpectations(t)
+}
+
+func TestSchoolLifecycle_RestoreReactivatesArchivedItems(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockLifecycleRepo := new(MockSchoolLifecycleRepository)
+	svc := NewSchoolLifecycleService(mockSchoolRepo, mockUnitRepo, mockMembershipRepo, mockLifecycleRepo,
+		passthroughTxManager{}, config.LifecycleConfig{PurgeRetention: 30 * 24 * time.Hour}, newTestLogger())
+
+	deletedAt := time.Now().Add(-time.Hour)
+	school := &entities.School{ID: uuid.New(), Code: "ARC002", DeletedAt: &deletedAt}
+	archive := &repository.SchoolArchive{ID: uuid.New(), SchoolID: school.ID, SchoolCode: school.Code}
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID}
+	goneUnitID := uuid.New()
+	membership := &entities.Membership{ID: uuid.New(), SchoolID: school.ID}
+
+	mockSchoolRepo.On("FindArchivedByID", mock.Anything, school.ID).Return(school, nil)
+	mockLifecycleRepo.On("FindUnfinishedPurgeJob", mock.Anything, school.ID).Return(nil, nil)
+	mockSchoolRepo.On("ExistsByCode", mock.Anything, school.Code).Return(false, nil)
+	mockLifecycleRepo.On("FindOpenArchive", mock.Anything, school.ID).Return(archive, nil)
+	mockLifecycleRepo.On("ListArchiveItems", mock.Anything, archive.ID).Return([]repository.SchoolArchiveItem{
+		{ArchiveID: archive.ID, EntityType: repository.SchoolArchiveItemUnit, EntityID: unit.ID},
+		{ArchiveID: archive.ID, EntityType: repository.SchoolArchiveItemUnit, EntityID: goneUnitID},
+		{ArchiveID: archive.ID, EntityType: repository.SchoolArchiveItemMembership, EntityID: membership.ID},
+	}, nil)
+	mockSchoolRepo.On("Restore", mock.Anything, school.ID).Return(nil)
+	mockUnitRepo.On("FindByID", mock.Anything, unit.ID, false).Return(unit, nil)
+	mockUnitRepo.On("FindByID", mock.Anything, goneUnitID, false).Return(nil, errors.NewNotFoundError("academic_unit"))
+	mockUnitRepo.On("Update", mock.Anything, unit).Return(nil)
+	mockMembershipRepo.On("FindByID", mock.Anything, membership.ID).Return(membership, nil)
+	mockMembershipRepo.On("Update", mock.Anything, membership).Return(nil)
+	mockLifecycleRepo.On("UpdateArchive", mock.Anything, mock.MatchedBy(func(a *repository.SchoolArchive) bool {
+		return a.RestoredAt != nil && a.RestoredBy == "admin-1"
+	})).Return(nil)
+
+	result, err := svc.RestoreSchool(context.Background(), school.ID.String(), "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.UnitsRestored)
+	assert.Equal(t, 1, result.MembershipsRestored)
+	assert.Equal(t, 1, result.Skipped)
+	assert.True(t, unit.IsActive)
+	assert.True(t, membership.IsActive)
+	assert.True(t, result.School.IsActive)
+	mockLifecycleRepo.AssertExpectations(t)
+}
+
+func TestSchoolLifecycle_RestoreCodeTaken(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockLifecycleRepo := new(MockSchoolLifecycleRepository)
+	svc := NewSchoolLifecycleService(mockSchoolRepo, new(MockAcademicUnitRepository), new(MockUnitMembershipRepository), mockLifecycleRepo,
+		passthroughTxManager{}, config.LifecycleConfig{PurgeRetention: 30 * 24 * time.Hour}, newTestLogger())
+
+	school := &entities.School{ID: uuid.New(), Code: "ARC003"}
+	mockSchoolRepo.On("FindArchivedByID", mock.Anything, school.ID).Return(school, nil)
+	mockLifecycleRepo.On("FindUnfinishedPurgeJob", mock.Anything, school.ID).Return(nil, nil)
+	mockSchoolRepo.On("ExistsByCode", mock.Anything, school.Code).Return(true, nil)
+
+	_, err := svc.RestoreSchool(context.Background(), school.ID.String(), "admin-1")
+
+	require.Error(t, err)
+	mockSchoolRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
+
+func TestSchoolLifecycle_PurgeBeforeRetention(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockLifecycleRepo := new(MockSchoolLifecycleRepository)
+	svc := NewSchoolLifecycleService(mockSchoolRepo, new(MockAcademicUnitRepository), new(MockUnitMembershipRepository), mockLifecycleRepo,
+		passthroughTxManager{}, config.LifecycleConfig{PurgeRetention: 30 * 24 * time.Hour}, newTestLogger())
+
+	school := &entities.School{ID: uuid.New(), Code: "ARC004"}
+	mockSchoolRepo.On("FindArchivedByID", mock.Anything, school.ID).Return(school, nil)
+	mockLifecycleRepo.On("FindOpenArchive", mock.Anything, school.ID).Return(&repository.SchoolArchive{
+		ID: uuid.New(), SchoolID: school.ID, PurgeAfter: time.Now().Add(24 * time.Hour),
+	}, nil)
+
+	_, err := svc.PurgeSchool(context.Background(), school.ID.String(), dto.PurgeSchoolRequest{ConfirmCode: "ARC004"}, "admin-1")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can be purged after")
+	mockLifecycleRepo.AssertNotCalled(t, "CreatePurgeJob", mock.Anything, mock.Anything)
+}
+
+func TestSchoolLifecycle_PurgeRunsAllSteps(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockLifecycleRepo := new(MockSchoolLifecycleRepository)
+	svc := NewSchoolLifecycleService(mockSchoolRepo, new(MockAcademicUnitRepository), new(MockUnitMembershipRepository), mockLifecycleRepo,
+		passthroughTxManager{}, config.LifecycleConfig{PurgeRetention: 30 * 24 * time.Hour}, newTestLogger())
+	// Ejecutar la purga en línea para poder verificar el resultado
+	svc.(*schoolLifecycleService).startJob = func(run func()) { run() }
+
+	school := &entities.School{ID: uuid.New(), Code: "ARC005"}
+	archive := &repository.SchoolArchive{ID: uuid.New(), SchoolID: school.ID, PurgeAfter: time.Now().Add(-time.Hour)}
+	mockSchoolRepo.On("FindArchivedByID", mock.Anything, school.ID).Return(school, nil)
+	mockLifecycleRepo.On("FindOpenArchive", mock.Anything, school.ID).Return(archive, nil)
+	mockLifecycleRepo.On("FindUnfinishedPurgeJob", mock.Anything, school.ID).Return(nil, nil)
+	mockLifecycleRepo.On("CreatePurgeJob", mock.Anything, mock.Anything).Return(nil)
+	mockLifecycleRepo.On("UpdatePurgeJob", mock.Anything, mock.Anything).Return(nil)
+	for _, step := range repository.PurgeSteps {
+		mockLifecycleRepo.On("PurgeSchoolData", mock.Anything, school.ID, step).Return(int64(2), nil).Once()
+	}
+	mockLifecycleRepo.On("UpdateArchive", mock.Anything, archive).Return(nil)
+
+	job, err := svc.PurgeSchool(context.Background(), school.ID.String(), dto.PurgeSchoolRequest{ConfirmCode: "arc005"}, "admin-1")
+	require.NoError(t, err)
+	assert.Equal(t, repository.PurgeJobStatusPending, job.Status)
+
+	// La respuesta es la foto al crear el job; el progreso final queda en el último UpdatePurgeJob
+	last := mockLifecycleRepo.Calls[len(mockLifecycleRepo.Calls)-2]
+	require.Equal(t, "UpdatePurgeJob", last.Method)
+	finalJob := last.Arguments.Get(1).(*repository.SchoolPurgeJob)
+	assert.Equal(t, repository.PurgeJobStatusCompleted, finalJob.Status)
+	assert.Equal(t, len(repository.PurgeSteps), finalJob.CompletedSteps)
+	assert.Equal(t, int64(2), finalJob.DeletedRows[repository.PurgeStepAcademicUnits])
+	assert.NotNil(t, archive.PurgedAt)
+}
+
+func TestSchoolLifecycle_PurgeStepFailureMarksJobFailed(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockLifecycleRepo := new(MockSchoolLifecycleRepository)
+	svc := NewSchoolLifecycleService(mockSchoolRepo, new(MockAcademicUnitRepository), new(MockUnitMembershipRepository), mockLifecycleRepo,
+		passthroughTxManager{}, config.LifecycleConfig{PurgeRetention: 30 * 24 * time.Hour}, newTestLogger())
+	// Ejecutar la purga en línea para poder verificar el resultado
+	svc.(*schoolLifecycleService).startJob = func(run func()) { run() }
+
+	school := &entities.School{ID: uuid.New(), Code: "ARC006"}
+	archive := &repository.SchoolArchive{ID: uuid.New(), SchoolID: school.ID, PurgeAfter: time.Now().Add(-time.Hour)}
+	mockSchoolRepo.On("FindArchivedByID", mock.Anything, school.ID).Return(school, nil)
+	mockLifecycleRepo.On("FindOpenArchive", mock.Anything, school.ID).Return(archive, nil)
+	mockLifecycleRepo.On("FindUnfinishedPurgeJob", mock.Anything, school.ID).Return(nil, nil)
+	mockLifecycleRepo.On("CreatePurgeJob", mock.Anything, mock.Anything).Return(nil)
+	mockLifecycleRepo.On("UpdatePurgeJob", mock.Anything, mock.Anything).Return(nil)
+	mockLifecycleRepo.On("PurgeSchoolData", mock.Anything, school.ID, repository.PurgeStepMemberships).Return(int64(3), nil)
+	mockLifecycleRepo.On("PurgeSchoolData", mock.Anything, school.ID, repository.PurgeStepInvitations).Return(int64(0), stderrors.New("lock timeout"))
+
+	_, err := svc.PurgeSchool(context.Background(), school.ID.String(), dto.PurgeSchoolRequest{ConfirmCode: "ARC006"}, "admin-1")
+	require.NoError(t, err)
+
+	last := mockLifecycleRepo.Calls[len(mockLifecycleRepo.Calls)-1]
+	finalJob := last.Arguments.Get(1).(*repository.SchoolPurgeJob)
+	assert.Equal(t, repository.PurgeJobStatusFailed, finalJob.Status)
+	assert.Equal(t, 1, finalJob.CompletedSteps)
+	assert.Contains(t, finalJob.Error, "invitations")
+	assert.Nil(t, archive.PurgedAt)
+	mockLifecycleRepo.AssertNotCalled(t, "UpdateArchive", mock.Anything, mock.Anything)
+}
+
+func TestSchoolLifecycle_ArchivedSchoolIsReadOnly(t *testing.T) {
+	school := &entities.School{ID: uuid.New(), Code: "SCH", IsActive: false}
+	schoolRepo := new(MockSchoolRepository)
+	schoolRepo.On("FindByID", mock.Anything, school.ID).Return(nil, errors.NewNotFoundError("school"))
+	schoolRepo.On("FindArchivedByID", mock.Anything, school.ID).Return(school, nil)
+
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, Name: "Primero", AcademicYear: 0}
+	unitRepo := new(MockAcademicUnitRepository)
+	unitRepo.On("FindByID", mock.Anything, unit.ID, false).Return(unit, nil)
+	membership := &entities.Membership{ID: uuid.New(), UserID: uuid.New(), SchoolID: school.ID, AcademicUnitID: &unit.ID, Role: "teacher"}
+	membershipRepo := new(MockUnitMembershipRepository)
+	membershipRepo.On("FindByID", mock.Anything, membership.ID).Return(membership, nil)
+
+	unitService := NewAcademicUnitService(unitRepo, schoolRepo, new(MockAcademicPeriodRepository), membershipRepo, new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+	membershipService := NewUnitMembershipService(membershipRepo, unitRepo, schoolRepo, new(MockAcademicPeriodRepository), noCustomUnitTypes(), nil, noUnitCapacities(), passthroughTxManager{}, newTestLogger())
+
+	name := "Primero B"
+	_, err := unitService.UpdateUnit(context.Background(), unit.ID.String(), dto.UpdateAcademicUnitRequest{DisplayName: &name})
+	assertArchivedSchoolError(t, err)
+	_, err = unitService.CreateUnit(context.Background(), school.ID.String(), dto.CreateAcademicUnitRequest{Type: "grade", DisplayName: "Segundo"})
+	assertArchivedSchoolError(t, err)
+	_, err = membershipService.CreateMembership(context.Background(), dto.CreateMembershipRequest{UnitID: unit.ID.String(), UserID: uuid.New().String(), Role: "student"})
+	assertArchivedSchoolError(t, err)
+	assertArchivedSchoolError(t, membershipService.ExpireMembership(context.Background(), membership.ID.String()))
+
+	unitRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	unitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	membershipRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	membershipRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func assertArchivedSchoolError(t *testing.T, err error) {
+	t.Helper()
+	require.Error(t, err)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.StatusCode)
+	assert.Contains(t, appErr.Message, "archived")
+}
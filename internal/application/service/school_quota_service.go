@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// SchoolQuotaService controla los cupos MaxTeachers/MaxStudents de cada escuela y el MaxUnits de su plan
+type SchoolQuotaService interface {
+	// CheckMembershipQuota valida que la escuela tenga cupo para que el usuario ocupe el rol.
+	// userID puede ser uuid.Nil cuando el usuario aún no existe. Debe llamarse dentro de la
+	// transacción que crea la membresía: el cupo de la escuela queda bloqueado hasta el commit.
+	CheckMembershipQuota(ctx context.Context, schoolID, userID uuid.UUID, role string) error
+
+	// CheckUnitQuota valida que la escuela pueda crear o restaurar adding unidades sin superar
+	// el MaxUnits de su plan. Igual que CheckMembershipQuota, bloquea el cupo hasta el commit.
+	CheckUnitQuota(ctx context.Context, schoolID uuid.UUID, adding int) error
+	GetUsage(ctx context.Context, schoolID string) (*dto.SchoolUsageResponse, error)
+}
+
+type schoolQuotaService struct {
+	schoolRepo     repository.SchoolRepository
+	unitRepo       repository.AcademicUnitRepository
+	membershipRepo repository.UnitMembershipRepository
+	thresholds     []int
+	unitLimits     map[string]int
+	logger         logger.Logger
+}
+
+// NewSchoolQuotaService crea un nuevo SchoolQuotaService
+func NewSchoolQuotaService(
+	schoolRepo repository.SchoolRepository,
+	unitRepo repository.AcademicUnitRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	cfg config.QuotaDefaults,
+	subscriptions config.SubscriptionsConfig,
+	logger logger.Logger,
+) SchoolQuotaService {
+	thresholds := make([]int, 0, len(cfg.WarningThresholds))
+	for _, t := range cfg.WarningThresholds {
+		if t > 0 && t < 100 {
+			thresholds = append(thresholds, t)
+		}
+	}
+	sort.Ints(thresholds)
+
+	// La escuela no guarda MaxUnits: el límite sale del catálogo según su plan
+	unitLimits := make(map[string]int, len(subscriptions.Tiers))
+	for _, tier := range subscriptions.Tiers {
+		if name := strings.ToLower(strings.TrimSpace(tier.Name)); name != "" && tier.MaxUnits > 0 {
+			unitLimits[name] = tier.MaxUnits
+		}
+	}
+
+	return &schoolQuotaService{
+		schoolRepo:     schoolRepo,
+		unitRepo:       unitRepo,
+		membershipRepo: membershipRepo,
+		thresholds:     thresholds,
+		unitLimits:     unitLimits,
+		logger:         logger,
+	}
+}
+
+// quotaLimit retorna el límite del plan para el rol; false si el rol no consume cupo
+func quotaLimit(school *entities.School, role string) (int, bool) {
+	switch valueobject.MembershipRole(role) {
+	case valueobject.RoleTeacher:
+		return school.MaxTeachers, true
+	case valueobject.RoleStudent:
+		return school.MaxStudents, true
+	default:
+		return 0, false
+	}
+}
+
+func (s *schoolQuotaService) CheckMembershipQuota(ctx context.Context, schoolID, userID uuid.UUID, role string) error {
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return errors.NewNotFoundError("school")
+	}
+
+	limit, counted := quotaLimit(school, role)
+	if !counted || limit <= 0 {
+		return nil
+	}
+
+	// Un usuario que ya ocupa el rol en la escuela no consume un cupo adicional
+	if userID != uuid.Nil {
+		memberships, err := s.membershipRepo.FindByUser(ctx, userID)
+		if err != nil {
+			return errors.NewDatabaseError("find memberships", err)
+		}
+		now := time.Now()
+		for _, m := range memberships {
+			current := m.IsActive && (m.WithdrawnAt == nil || m.WithdrawnAt.After(now))
+			if current && m.SchoolID == schoolID && m.Role == role {
+				return nil
+			}
+		}
+	}
+
+	// Las altas concurrentes de la escuela esperan a que esta transacción cuente y cree la suya
+	if err := s.membershipRepo.LockSchoolQuota(ctx, schoolID); err != nil {
+		return errors.NewDatabaseError("lock school quota", err)
+	}
+
+	used, err := s.membershipRepo.CountActiveUsersBySchoolAndRole(ctx, schoolID, role)
+	if err != nil {
+		return errors.NewDatabaseError("count memberships", err)
+	}
+	if used >= limit {
+		s.logger.Warn("school quota exceeded",
+			"school_id", schoolID.String(),
+			"role", role,
+			"used", used,
+			"limit", limit,
+		)
+		return errors.NewBusinessRuleError(fmt.Sprintf(
+			"school has reached its %s quota (%d/%d) for subscription tier %q",
+			role, used, limit, school.SubscriptionTier,
+		))
+	}
+
+	// Avisar solo al cruzar un umbral, no en cada alta posterior
+	before := s.warningThreshold(used, limit)
+	if after := s.warningThreshold(used+1, limit); after > before {
+		s.logger.Warn("school quota warning threshold reached",
+			"school_id", schoolID.String(),
+			"role", role,
+			"used", used+1,
+			"limit", limit,
+			"threshold", after,
+		)
+	}
+	return nil
+}
+
+func (s *schoolQuotaService) CheckUnitQuota(ctx context.Context, schoolID uuid.UUID, adding int) error {
+	if adding <= 0 || len(s.unitLimits) == 0 {
+		return nil
+	}
+
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return errors.NewNotFoundError("school")
+	}
+
+	limit := s.unitLimits[strings.ToLower(strings.TrimSpace(school.SubscriptionTier))]
+	if limit <= 0 {
+		return nil
+	}
+
+	// Comparte el lock con las membresías y los cambios de plan de la escuela
+	if err := s.membershipRepo.LockSchoolQuota(ctx, schoolID); err != nil {
+		return errors.NewDatabaseError("lock school quota", err)
+	}
+
+	used, err := s.unitRepo.CountBySchoolID(ctx, schoolID)
+	if err != nil {
+		return errors.NewDatabaseError("count units", err)
+	}
+	if used+adding > limit {
+		s.logger.Warn("school unit quota exceeded",
+			"school_id", schoolID.String(),
+			"used", used,
+			"adding", adding,
+			"limit", limit,
+		)
+		return errors.NewBusinessRuleError(fmt.Sprintf(
+			"school cannot add %d unit(s), its units quota is %d/%d for subscription tier %q",
+			adding, used, limit, school.SubscriptionTier,
+		))
+	}
+	return nil
+}
+
+func (s *schoolQuotaService) GetUsage(ctx context.Context, schoolID string) (*dto.SchoolUsageResponse, error) {
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+
+	school, err := s.schoolRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+
+	teachers, err := s.usage(ctx, school, string(valueobject.RoleTeacher))
+	if err != nil {
+		return nil, err
+	}
+	students, err := s.usage(ctx, school, string(valueobject.RoleStudent))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SchoolUsageResponse{
+		SchoolID:          school.ID.String(),
+		SubscriptionTier:  school.SubscriptionTier,
+		Teachers:          teachers,
+		Students:          students,
+		WarningThresholds: s.thresholds,
+	}, nil
+}
+
+func (s *schoolQuotaService) usage(ctx context.Context, school *entities.School, role string) (dto.QuotaUsage, error) {
+	used, err := s.membershipRepo.CountActiveUsersBySchoolAndRole(ctx, school.ID, role)
+	if err != nil {
+		return dto.QuotaUsage{}, errors.NewDatabaseError("count memberships", err)
+	}
+
+	limit, _ := quotaLimit(school, role)
+	usage := dto.QuotaUsage{Used: used, Limit: limit}
+	if limit <= 0 {
+		usage.Limit = 0
+		return usage, nil
+	}
+
+	remaining := limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	usage.Remaining = &remaining
+	usage.UsagePercent = float64(used) * 100 / float64(limit)
+	usage.WarningThreshold = s.warningThreshold(used, limit)
+	usage.LimitReached = used >= limit
+	return usage, nil
+}
+
+// warningThreshold retorna el umbral más alto alcanzado (0 si ninguno)
+func (s *schoolQuotaService) warningThreshold(used, limit int) int {
+	reached := 0
+	for _, t := range s.thresholds {
+		if used*100 >= t*limit {
+			reached = t
+		}
+	}
+	return reached
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+func newTestQuotaService(schoolRepo *MockSchoolRepository, membershipRepo *MockUnitMembershipRepository) SchoolQuotaService {
+	return NewSchoolQuotaService(schoolRepo, new(MockAcademicUnitRepository), membershipRepo, config.QuotaDefaults{WarningThresholds: []int{90, 80}}, config.SubscriptionsConfig{}, newTestLogger())
+}
+
+// noUnitQuotas retorna un servicio de cupos cuyo catálogo no limita las unidades
+func noUnitQuotas() SchoolQuotaService {
+	return newTestQuotaService(new(MockSchoolRepository), new(MockUnitMembershipRepository))
+}
+
+func TestCheckMembershipQuota_LimitReached(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	service := newTestQuotaService(mockSchoolRepo, mockMembershipRepo)
+
+	school := &entities.School{ID: uuid.New(), SubscriptionTier: "free", MaxTeachers: 2, MaxStudents: 10}
+	userID := uuid.New()
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, userID).Return([]*entities.Membership{}, nil)
+	mockMembershipRepo.On("LockSchoolQuota", mock.Anything, school.ID).Return(nil)
+	mockMembershipRepo.On("CountActiveUsersBySchoolAndRole", mock.Anything, school.ID, "teacher").Return(2, nil)
+	mockMembershipRepo.On("CountActiveUsersBySchoolAndRole", mock.Anything, school.ID, "student").Return(3, nil)
+
+	err := service.CheckMembershipQuota(context.Background(), school.ID, userID, "teacher")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "teacher quota (2/2)")
+
+	assert.NoError(t, service.CheckMembershipQuota(context.Background(), school.ID, userID, "student"))
+}
+
+func TestCheckMembershipQuota_ExistingRoleOrUncountedRole(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	service := newTestQuotaService(mockSchoolRepo, mockMembershipRepo)
+
+	school := &entities.School{ID: uuid.New(), MaxTeachers: 1}
+	userID := uuid.New()
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, userID).Return([]*entities.Membership{
+		{ID: uuid.New(), UserID: userID, SchoolID: school.ID, Role: "teacher", IsActive: true},
+	}, nil)
+
+	// El docente ya ocupa su cupo: otra unidad en la misma escuela no suma
+	assert.NoError(t, service.CheckMembershipQuota(context.Background(), school.ID, userID, "teacher"))
+	// Roles fuera del plan no consumen cupo
+	assert.NoError(t, service.CheckMembershipQuota(context.Background(), school.ID, userID, "guardian"))
+	mockMembershipRepo.AssertNotCalled(t, "CountActiveUsersBySchoolAndRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetSchoolUsage(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	service := newTestQuotaService(mockSchoolRepo, mockMembershipRepo)
+
+	school := &entities.School{ID: uuid.New(), SubscriptionTier: "basic", MaxTeachers: 10, MaxStudents: 0}
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	mockMembershipRepo.On("CountActiveUsersBySchoolAndRole", mock.Anything, school.ID, "teacher").Return(9, nil)
+	mockMembershipRepo.On("CountActiveUsersBySchoolAndRole", mock.Anything, school.ID, "student").Return(120, nil)
+
+	usage, err := service.GetUsage(context.Background(), school.ID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{80, 90}, usage.WarningThresholds)
+	assert.Equal(t, 9, usage.Teachers.Used)
+	assert.Equal(t, 1, *usage.Teachers.Remaining)
+	assert.InDelta(t, 90.0, usage.Teachers.UsagePercent, 0.001)
+	assert.Equal(t, 90, usage.Teachers.WarningThreshold)
+	assert.False(t, usage.Teachers.LimitReached)
+	assert.Equal(t, dto.QuotaUsage{Used: 120}, usage.Students)
+}
+
+func TestCreateMembership_RejectedOverQuota(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	service := NewUnitMembershipService(mockMembershipRepo, mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), noCustomUnitTypes(), newTestQuotaService(mockSchoolRepo, mockMembershipRepo), noUnitCapacities(), passthroughTxManager{}, newTestLogger())
+
+	school := &entities.School{ID: uuid.New(), MaxStudents: 30}
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID}
+	userID := uuid.New()
+	mockUnitRepo.On("FindByID", mock.Anything, unit.ID, false).Return(unit, nil)
+	mockMembershipRepo.On("ExistsByUnitAndUser", mock.Anything, unit.ID, userID).Return(false, nil)
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, userID).Return([]*entities.Membership{}, nil)
+	mockMembershipRepo.On("LockSchoolQuota", mock.Anything, school.ID).Return(nil)
+	mockMembershipRepo.On("CountActiveUsersBySchoolAndRole", mock.Anything, school.ID, "student").Return(30, nil)
+
+	_, err := service.CreateMembership(context.Background(), dto.CreateMembershipRequest{
+		UnitID: unit.ID.String(),
+		UserID: userID.String(),
+		Role:   "student",
+	})
+
+	require.Error(t, err)
+	mockMembershipRepo.AssertCalled(t, "LockSchoolQuota", mock.Anything, school.ID)
+	mockMembershipRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCheckUnitQuota_CountsUnitsBeingAdded(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	cfg := config.SubscriptionsConfig{Tiers: []config.SubscriptionTierConfig{{Name: "free", MaxUnits: 10}}}
+	service := NewSchoolQuotaService(mockSchoolRepo, mockUnitRepo, mockMembershipRepo, config.QuotaDefaults{}, cfg, newTestLogger())
+
+	school := &entities.School{ID: uuid.New(), SubscriptionTier: "Free"}
+	legacy := &entities.School{ID: uuid.New(), SubscriptionTier: "legacy"}
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	mockSchoolRepo.On("FindByID", mock.Anything, legacy.ID).Return(legacy, nil)
+	mockMembershipRepo.On("LockSchoolQuota", mock.Anything, school.ID).Return(nil)
+	mockUnitRepo.On("CountBySchoolID", mock.Anything, school.ID).Return(8, nil)
+
+	assert.NoError(t, service.CheckUnitQuota(context.Background(), school.ID, 2))
+
+	err := service.CheckUnitQuota(context.Background(), school.ID, 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "units quota is 8/10")
+
+	// Un plan fuera del catálogo no limita las unidades
+	assert.NoError(t, service.CheckUnitQuota(context.Background(), legacy.ID, 50))
+	mockMembershipRepo.AssertNotCalled(t, "LockSchoolQuota", mock.Anything, legacy.ID)
+}
+
+func TestCreateUnit_RejectedOverUnitQuota(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockPeriodRepo := new(MockAcademicPeriodRepository)
+	cfg := config.SubscriptionsConfig{Tiers: []config.SubscriptionTierConfig{{Name: "free", MaxUnits: 10}}}
+	quotaService := NewSchoolQuotaService(mockSchoolRepo, mockUnitRepo, mockMembershipRepo, config.QuotaDefaults{}, cfg, newTestLogger())
+	service := NewAcademicUnitService(mockUnitRepo, mockSchoolRepo, mockPeriodRepo, mockMembershipRepo, new(MockUnitDeletionRepository), noCustomUnitTypes(), defaultNestingSettings(), noUnitCapacities(), quotaService, passthroughTxManager{}, newTestLogger())
+
+	school := &entities.School{ID: uuid.New(), SubscriptionTier: "free"}
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	mockUnitRepo.On("ExistsBySchoolIDAndCode", mock.Anything, school.ID, "G1").Return(false, nil)
+	mockUnitRepo.On("LockHierarchy", mock.Anything, school.ID).Return(nil)
+	mockPeriodRepo.On("FindActiveYear", mock.Anything, school.ID).Return(nil, nil)
+	mockMembershipRepo.On("LockSchoolQuota", mock.Anything, school.ID).Return(nil)
+	mockUnitRepo.On("CountBySchoolID", mock.Anything, school.ID).Return(10, nil)
+
+	_, err := service.CreateUnit(context.Background(), school.ID.String(), dto.CreateAcademicUnitRequest{
+		Type: "grade", DisplayName: "Primero", Code: "G1",
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "units quota is 10/10")
+	mockMembershipRepo.AssertCalled(t, "LockSchoolQuota", mock.Anything, school.ID)
+	mockUnitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
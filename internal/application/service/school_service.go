@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
@@ -19,8 +20,8 @@ type SchoolService interface {
 	GetSchool(ctx context.Context, id string) (*dto.SchoolResponse, error)
 	GetSchoolByCode(ctx context.Context, code string) (*dto.SchoolResponse, error)
 	UpdateSchool(ctx context.Context, id string, req dto.UpdateSchoolRequest) (*dto.SchoolResponse, error)
-	ListSchools(ctx context.Context) ([]dto.SchoolResponse, error)
-	DeleteSchool(ctx context.Context, id string) error
+	ListSchools(ctx context.Context, req dto.ListSchoolsRequest) ([]dto.SchoolResponse, error)
+	SearchSchools(ctx context.Context, req dto.ListSchoolsRequest) (*dto.SchoolListResponse, error)
 }
 
 type schoolService struct {
@@ -276,41 +277,61 @@ func (s *schoolService) UpdateSchool(ctx context.Context, id string, req dto.Upd
 	return &response, nil
 }
 
-func (s *schoolService) ListSchools(ctx context.Context) ([]dto.SchoolResponse, error) {
-	schools, err := s.schoolRepo.List(ctx, repository.ListFilters{})
+func (s *schoolService) ListSchools(ctx context.Context, req dto.ListSchoolsRequest) ([]dto.SchoolResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	schools, err := s.schoolRepo.List(ctx, schoolFilters(req, req.Limit))
 	if err != nil {
 		return nil, errors.NewDatabaseError("list schools", err)
 	}
-
 	return dto.ToSchoolResponseList(schools), nil
 }
 
-func (s *schoolService) DeleteSchool(ctx context.Context, id string) error {
-	schoolID, err := uuid.Parse(id)
-	if err != nil {
-		return errors.NewValidationError("invalid school ID")
+func (s *schoolService) SearchSchools(ctx context.Context, req dto.ListSchoolsRequest) (*dto.SchoolListResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
-	school, err := s.schoolRepo.FindByID(ctx, schoolID)
-	if err != nil {
-		s.logger.Error("database error",
-			"operation", "find_school",
-			"school_id", schoolID,
-			"error", err.Error(),
-		)
-		return errors.NewDatabaseError("find school", err)
+	limit := req.Limit
+	if limit == 0 {
+		limit = dto.DefaultSchoolListLimit
 	}
-	if school == nil {
-		return errors.NewNotFoundError("school")
+	filters := schoolFilters(req, limit)
+
+	total, err := s.schoolRepo.Count(ctx, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("count schools", err)
 	}
 
-	if err := s.schoolRepo.Delete(ctx, schoolID); err != nil {
-		return errors.NewDatabaseError("delete school", err)
+	schools := []*entities.School{}
+	if req.Offset < total {
+		if schools, err = s.schoolRepo.List(ctx, filters); err != nil {
+			return nil, errors.NewDatabaseError("list schools", err)
+		}
 	}
 
-	s.logger.Info("entity deleted",
-		"entity_type", "school",
-		"entity_id", id,
-	)
-	return nil
+	return &dto.SchoolListResponse{
+		Items:   dto.ToSchoolResponseList(schools),
+		Total:   total,
+		Limit:   limit,
+		Offset:  req.Offset,
+		HasMore: req.Offset+len(schools) < total,
+	}, nil
+}
+
+// schoolFilters traduce la búsqueda a filtros del repositorio; limit 0 no limita
+func schoolFilters(req dto.ListSchoolsRequest, limit int) repository.SchoolFilters {
+	return repository.SchoolFilters{
+		Search:           strings.TrimSpace(req.Search),
+		Country:          strings.TrimSpace(req.Country),
+		City:             strings.TrimSpace(req.City),
+		SubscriptionTier: strings.TrimSpace(req.SubscriptionTier),
+		IsActive:         req.IsActive,
+		SortBy:           req.SortBy,
+		SortDesc:         req.Order != "asc",
+		Limit:            limit,
+		Offset:           req.Offset,
+	}
 }
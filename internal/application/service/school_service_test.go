@@ -20,6 +20,13 @@ type MockSchoolRepository struct {
 	mock.Mock
 }
 
+// liveSchools simula escuelas activas, que admiten cambios en sus unidades y membresías
+func liveSchools() *MockSchoolRepository {
+	schoolRepo := new(MockSchoolRepository)
+	schoolRepo.On("FindByID", mock.Anything, mock.Anything).Return(&entities.School{IsActive: true}, nil).Maybe()
+	return schoolRepo
+}
+
 func (m *MockSchoolRepository) Create(ctx context.Context, school *entities.School) error {
 	args := m.Called(ctx, school)
 	return args.Error(0)
@@ -59,7 +66,12 @@ func (m *MockSchoolRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockSchoolRepository) List(ctx context.Context, filters repository.ListFilters) ([]*entities.School, error) {
+func (m *MockSchoolRepository) Count(ctx context.Context, filters repository.SchoolFilters) (int, error) {
+	args := m.Called(ctx, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSchoolRepository) List(ctx context.Context, filters repository.SchoolFilters) ([]*entities.School, error) {
 	args := m.Called(ctx, filters)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -78,6 +90,19 @@ func (m *MockSchoolRepository) ExistsByCode(ctx context.Context, code string) (b
 }
 
 // getTestDefaults retorna configuración de defaults para pruebas
+func (m *MockSchoolRepository) FindArchivedByID(ctx context.Context, id uuid.UUID) (*entities.School, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.School), args.Error(1)
+}
+
+func (m *MockSchoolRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func getTestDefaults() config.SchoolDefaults {
 	return config.SchoolDefaults{
 		Country:          "CO",
@@ -186,6 +211,68 @@ func TestGetSchool_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestListSchools_ReturnsAllMatchesWithoutPaging(t *testing.T) {
+	mockRepo := new(MockSchoolRepository)
+	service := NewSchoolService(mockRepo, newTestLogger(), getTestDefaults())
+
+	schools := []*entities.School{
+		{ID: uuid.New(), Name: "Colegio Norte", Code: "CN", IsActive: true},
+		{ID: uuid.New(), Name: "Colegio Sur", Code: "CS", IsActive: true},
+	}
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f repository.SchoolFilters) bool {
+		return f.Limit == 0 && f.Offset == 0 && f.SortDesc
+	})).Return(schools, nil)
+
+	result, err := service.ListSchools(context.Background(), dto.ListSchoolsRequest{})
+
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+	mockRepo.AssertNotCalled(t, "Count", mock.Anything, mock.Anything)
+}
+
+func TestSearchSchools_FiltersAndPagination(t *testing.T) {
+	mockRepo := new(MockSchoolRepository)
+	service := NewSchoolService(mockRepo, newTestLogger(), getTestDefaults())
+
+	active := true
+	matchFilters := mock.MatchedBy(func(f repository.SchoolFilters) bool {
+		return f.Search == "norte" && f.Country == "CO" && f.IsActive != nil && *f.IsActive &&
+			f.SortBy == repository.SchoolSortName && !f.SortDesc && f.Limit == 2 && f.Offset == 0
+	})
+	schools := []*entities.School{
+		{ID: uuid.New(), Name: "Colegio Norte A", Code: "CNA", IsActive: true},
+		{ID: uuid.New(), Name: "Colegio Norte B", Code: "CNB", IsActive: true},
+	}
+	mockRepo.On("Count", mock.Anything, matchFilters).Return(5, nil)
+	mockRepo.On("List", mock.Anything, matchFilters).Return(schools, nil)
+
+	result, err := service.SearchSchools(context.Background(), dto.ListSchoolsRequest{
+		Paginate: true,
+		Search:   " norte ",
+		Country:  "CO",
+		IsActive: &active,
+		SortBy:   "name",
+		Order:    "asc",
+		Limit:    2,
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, 5, result.Total)
+	assert.True(t, result.HasMore)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchSchools_InvalidSort(t *testing.T) {
+	mockRepo := new(MockSchoolRepository)
+	service := NewSchoolService(mockRepo, newTestLogger(), getTestDefaults())
+
+	_, err := service.SearchSchools(context.Background(), dto.ListSchoolsRequest{Paginate: true, SortBy: "password"})
+
+	require.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Count", mock.Anything, mock.Anything)
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// SchoolSettingsSchemaVersion es la versión vigente del schema de configuración de escuelas
+const SchoolSettingsSchemaVersion = 1
+
+//go:embed schemas/school_settings.v1.json
+var schoolSettingsSchemaV1 []byte
+
+// SchoolSettingsService administra la configuración tipada de las escuelas.
+// La configuración efectiva es la global con los valores propios de la escuela encima.
+type SchoolSettingsService interface {
+	// GetSchema retorna el JSON Schema con el que se valida la configuración efectiva
+	GetSchema() json.RawMessage
+
+	// GetSettings obtiene la configuración efectiva de la escuela
+	GetSettings(ctx context.Context, schoolID string) (*dto.SchoolSettingsResponse, error)
+
+	// PatchSettings aplica un merge patch sobre los valores propios, valida y registra el cambio
+	PatchSettings(ctx context.Context, schoolID string, req dto.PatchSchoolSettingsRequest, changedBy string) (*dto.SchoolSettingsResponse, error)
+
+	// ListHistory lista los cambios de configuración de la escuela
+	ListHistory(ctx context.Context, schoolID string) ([]dto.SchoolSettingsChangeResponse, error)
+
+	// GetUnitNestingRules obtiene las reglas efectivas de anidamiento de unidades de la escuela
+	GetUnitNestingRules(ctx context.Context, schoolID uuid.UUID) (valueobject.UnitNestingRules, error)
+}
+
+type schoolSettingsService struct {
+	schoolRepo   repository.SchoolRepository
+	settingsRepo repository.SchoolSettingsRepository
+	txManager    repository.TransactionManager
+	schema       *jsonSchema
+	global       map[string]interface{}
+	logger       logger.Logger
+}
+
+// NewSchoolSettingsService crea un nuevo SchoolSettingsService. Falla si la
+// configuración global no cumple el schema, ya que todas las escuelas la heredan.
+func NewSchoolSettingsService(
+	schoolRepo repository.SchoolRepository,
+	settingsRepo repository.SchoolSettingsRepository,
+	txManager repository.TransactionManager,
+	globalSettings map[string]interface{},
+	logger logger.Logger,
+) (SchoolSettingsService, error) {
+	schema, err := parseJSONSchema(schoolSettingsSchemaV1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Round-trip por JSON para trabajar con los mismos tipos que llegan en los requests
+	global, err := toJSONObject(globalSettings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid global school settings: %w", err)
+	}
+	if problems := schema.Validate(global); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid global school settings: %s", strings.Join(problems, "; "))
+	}
+	if _, err := decodeSchoolSettings(global); err != nil {
+		return nil, fmt.Errorf("invalid global school settings: %w", err)
+	}
+
+	return &schoolSettingsService{
+		schoolRepo:   schoolRepo,
+		settingsRepo: settingsRepo,
+		txManager:    txManager,
+		schema:       schema,
+		global:       global,
+		logger:       logger,
+	}, nil
+}
+
+func (s *schoolSettingsService) GetSchema() json.RawMessage {
+	return json.RawMessage(schoolSettingsSchemaV1)
+}
+
+func (s *schoolSettingsService) GetSettings(ctx context.Context, schoolID string) (*dto.SchoolSettingsResponse, error) {
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	record, overrides, err := s.loadOverrides(ctx, school.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.effective(overrides)
+	if err != nil {
+		// La global cambió y dejó inválido un valor propio: mejor fallar que servir algo inválido
+		s.logger.Error("stored school settings no longer valid", "school_id", school.ID.String(), "error", err.Error())
+		return nil, errors.NewBusinessRuleError("stored school settings are no longer valid: " + err.Error())
+	}
+	return toSchoolSettingsResponse(school.ID, record, settings, overrides), nil
+}
+
+func (s *schoolSettingsService) PatchSettings(ctx context.Context, schoolID string, req dto.PatchSchoolSettingsRequest, changedBy string) (*dto.SchoolSettingsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	record, overrides, err := s.loadOverrides(ctx, school.ID)
+	if err != nil {
+		return nil, err
+	}
+	currentVersion := 0
+	if record != nil {
+		currentVersion = record.Version
+	}
+	if req.Version != nil && *req.Version != currentVersion {
+		return nil, errors.NewConflictError(fmt.Sprintf("settings version is %d, not %d", currentVersion, *req.Version))
+	}
+
+	patch, err := toJSONObject(req.Settings)
+	if err != nil {
+		return nil, errors.NewValidationError("settings must be a JSON object")
+	}
+	newOverrides := mergePatch(deepCopyJSON(overrides), patch)
+
+	settings, err := s.effective(newOverrides)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(newOverrides, overrides) {
+		return toSchoolSettingsResponse(school.ID, record, settings, overrides), nil
+	}
+
+	overridesJSON, err := json.Marshal(newOverrides)
+	if err != nil {
+		return nil, errors.NewValidationError("settings must be a JSON object")
+	}
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return nil, errors.NewValidationError("settings must be a JSON object")
+	}
+
+	now := time.Now()
+	newRecord := &repository.SchoolSettings{
+		SchoolID:      school.ID,
+		SchemaVersion: SchoolSettingsSchemaVersion,
+		Version:       currentVersion + 1,
+		Overrides:     overridesJSON,
+		UpdatedBy:     changedBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if record != nil {
+		newRecord.CreatedAt = record.CreatedAt
+	}
+	change := &repository.SchoolSettingsChange{
+		ID:        uuid.New(),
+		SchoolID:  school.ID,
+		Version:   newRecord.Version,
+		Patch:     patchJSON,
+		Overrides: overridesJSON,
+		Reason:    strings.TrimSpace(req.Reason),
+		ChangedBy: changedBy,
+		CreatedAt: now,
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		saved, err := s.settingsRepo.Save(ctx, newRecord, currentVersion)
+		if err != nil {
+			return errors.NewDatabaseError("save school settings", err)
+		}
+		if !saved {
+			return errors.NewConflictError("settings were modified concurrently, reload and retry")
+		}
+		if err := s.settingsRepo.CreateChange(ctx, change); err != nil {
+			return errors.NewDatabaseError("create school settings change", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("school settings updated",
+		"school_id", school.ID.String(),
+		"version", newRecord.Version,
+		"changed_by", changedBy,
+	)
+	return toSchoolSettingsResponse(school.ID, newRecord, settings, newOverrides), nil
+}
+
+func (s *schoolSettingsService) ListHistory(ctx context.Context, schoolID string) ([]dto.SchoolSettingsChangeResponse, error) {
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.settingsRepo.ListChanges(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list school settings changes", err)
+	}
+
+	result := make([]dto.SchoolSettingsChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, dto.ToSchoolSettingsChangeResponse(change))
+	}
+	return result, nil
+}
+
+func (s *schoolSettingsService) GetUnitNestingRules(ctx context.Context, schoolID uuid.UUID) (valueobject.UnitNestingRules, error) {
+	_, overrides, err := s.loadOverrides(ctx, schoolID)
+	if err != nil {
+		return valueobject.UnitNestingRules{}, err
+	}
+	settings, err := s.effective(overrides)
+	if err != nil {
+		s.logger.Error("stored school settings no longer valid", "school_id", schoolID.String(), "error", err.Error())
+		return valueobject.UnitNestingRules{}, errors.NewBusinessRuleError("stored school settings are no longer valid: " + err.Error())
+	}
+	return toUnitNestingRules(settings.UnitNesting), nil
+}
+
+func (s *schoolSettingsService) loadSchool(ctx context.Context, schoolID string) (*entities.School, error) {
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	return school, nil
+}
+
+// loadOverrides obtiene el registro y sus valores propios (objeto vacío si la escuela no tiene)
+func (s *schoolSettingsService) loadOverrides(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolSettings, map[string]interface{}, error) {
+	record, err := s.settingsRepo.FindBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("find school settings", err)
+	}
+
+	overrides := map[string]interface{}{}
+	if record != nil && len(record.Overrides) > 0 {
+		if err := json.Unmarshal(record.Overrides, &overrides); err != nil {
+			return nil, nil, errors.NewDatabaseError("decode school settings", err)
+		}
+	}
+	return record, overrides, nil
+}
+
+// effective combina la global con los valores propios y valida el resultado contra el schema
+func (s *schoolSettingsService) effective(overrides map[string]interface{}) (*dto.SchoolSettings, error) {
+	merged := mergePatch(deepCopyJSON(s.global), overrides)
+	if problems := s.schema.Validate(merged); len(problems) > 0 {
+		return nil, errors.NewValidationError("invalid settings: " + strings.Join(problems, "; "))
+	}
+	settings, err := decodeSchoolSettings(merged)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid settings: " + err.Error())
+	}
+	return settings, nil
+}
+
+// decodeSchoolSettings convierte a la estructura tipada y aplica las reglas que
+// involucran varios campos (el schema solo valida cada campo por separado)
+func decodeSchoolSettings(doc map[string]interface{}) (*dto.SchoolSettings, error) {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var settings dto.SchoolSettings
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return nil, err
+	}
+
+	scale := settings.GradingScale
+	if scale.Min >= scale.Max {
+		return nil, fmt.Errorf("grading_scale.min must be lower than grading_scale.max")
+	}
+	if scale.Passing < scale.Min || scale.Passing > scale.Max {
+		return nil, fmt.Errorf("grading_scale.passing must be between min and max")
+	}
+
+	calendar := settings.AcademicCalendar
+	start := time.Date(2001, time.Month(calendar.StartMonth), calendar.StartDay, 0, 0, 0, 0, time.UTC)
+	if start.Day() != calendar.StartDay {
+		return nil, fmt.Errorf("academic_calendar.start_day is not a valid day for start_month")
+	}
+
+	// Sin tipos raíz no se podría crear ninguna unidad
+	if len(settings.UnitNesting.RootTypes) == 0 {
+		return nil, fmt.Errorf("unit_nesting.root_types must include at least one unit type")
+	}
+	return &settings, nil
+}
+
+// toUnitNestingRules convierte la sección unit_nesting (ya validada contra el schema) a reglas del dominio
+func toUnitNestingRules(nesting dto.UnitNestingSettings) valueobject.UnitNestingRules {
+	rules := valueobject.UnitNestingRules{
+		AllowedParents: make(map[valueobject.UnitType][]valueobject.UnitType, len(nesting.AllowedParents)),
+		RootTypes:      make([]valueobject.UnitType, 0, len(nesting.RootTypes)),
+		MaxDepth:       nesting.MaxDepth,
+	}
+	for child, parents := range nesting.AllowedParents {
+		allowed := make([]valueobject.UnitType, 0, len(parents))
+		for _, parent := range parents {
+			allowed = append(allowed, valueobject.UnitType(parent))
+		}
+		rules.AllowedParents[valueobject.UnitType(child)] = allowed
+	}
+	for _, root := range nesting.RootTypes {
+		rules.RootTypes = append(rules.RootTypes, valueobject.UnitType(root))
+	}
+	return rules
+}
+
+func toSchoolSettingsResponse(schoolID uuid.UUID, record *repository.SchoolSettings, settings *dto.SchoolSettings, overrides map[string]interface{}) *dto.SchoolSettingsResponse {
+	resp := &dto.SchoolSettingsResponse{
+		SchoolID:      schoolID.String(),
+		SchemaVersion: SchoolSettingsSchemaVersion,
+		Settings:      *settings,
+		Overrides:     overrides,
+	}
+	if record != nil {
+		resp.SchemaVersion = record.SchemaVersion
+		resp.Version = record.Version
+		resp.UpdatedBy = record.UpdatedBy
+		updatedAt := record.UpdatedAt
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+// mergePatch aplica un JSON merge patch (RFC 7386) sobre target. Los objetos que
+// quedan vacíos se eliminan para que vuelvan a heredar completos.
+func mergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	if target == nil {
+		target = map[string]interface{}{}
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		patchObj, ok := value.(map[string]interface{})
+		if !ok {
+			target[key] = value
+			continue
+		}
+		targetObj, _ := target[key].(map[string]interface{})
+		merged := mergePatch(targetObj, patchObj)
+		if len(merged) == 0 {
+			delete(target, key)
+			continue
+		}
+		target[key] = merged
+	}
+	return target
+}
+
+func deepCopyJSON(src map[string]interface{}) map[string]interface{} {
+	dst := make(map[string]interface{}, len(src))
+	for key, value := range src {
+		if obj, ok := value.(map[string]interface{}); ok {
+			dst[key] = deepCopyJSON(obj)
+			continue
+		}
+		dst[key] = value
+	}
+	return dst
+}
+
+func toJSONObject(value interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockSchoolSettingsRepository mock implementation
+type MockSchoolSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockSchoolSettingsRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolSettings, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.SchoolSettings), args.Error(1)
+}
+
+func (m *MockSchoolSettingsRepository) Save(ctx context.Context, settings *repository.SchoolSettings, expectedVersion int) (bool, error) {
+	args := m.Called(ctx, settings, expectedVersion)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSchoolSettingsRepository) CreateChange(ctx context.Context, change *repository.SchoolSettingsChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockSchoolSettingsRepository) ListChanges(ctx context.Context, schoolID uuid.UUID) ([]*repository.SchoolSettingsChange, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.SchoolSettingsChange), args.Error(1)
+}
+
+func testGlobalSchoolSettings() map[string]interface{} {
+	return map[string]interface{}{
+		"locale":   "es-CO",
+		"timezone": "America/Bogota",
+		"grading_scale": map[string]interface{}{
+			"type": "numeric", "min": 1.0, "max": 5.0, "passing": 3.0, "decimals": 1,
+		},
+		"academic_calendar": map[string]interface{}{
+			"start_month": 1, "start_day": 15, "terms": 4,
+		},
+		"attendance": map[string]interface{}{
+			"late_after_minutes": 10, "absence_alert_percent": 15, "excused_requires_document": true,
+		},
+		"unit_nesting": map[string]interface{}{
+			"max_depth":  6,
+			"root_types": []string{"school", "grade", "club", "department"},
+			"allowed_parents": map[string]interface{}{
+				"grade":      []string{"school"},
+				"section":    []string{"grade"},
+				"club":       []string{"school", "grade", "section", "department"},
+				"department": []string{"school", "department"},
+			},
+		},
+	}
+}
+
+func newTestSchoolSettingsService(t *testing.T) (SchoolSettingsService, *MockSchoolRepository, *MockSchoolSettingsRepository, *entities.School) {
+	schools := new(MockSchoolRepository)
+	settings := new(MockSchoolSettingsRepository)
+	svc, err := NewSchoolSettingsService(schools, settings, passthroughTxManager{}, testGlobalSchoolSettings(), newTestLogger())
+	require.NoError(t, err)
+
+	school := &entities.School{ID: uuid.New(), Name: "Colegio Settings", Code: "CST"}
+	schools.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	return svc, schools, settings, school
+}
+
+func TestSchoolSettings_GetInheritsGlobal(t *testing.T) {
+	svc, _, settingsRepo, school := newTestSchoolSettingsService(t)
+	settingsRepo.On("FindBySchoolID", mock.Anything, school.ID).Return(nil, nil)
+
+	result, err := svc.GetSettings(context.Background(), school.ID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Version)
+	assert.Equal(t, "America/Bogota", result.Settings.Timezone)
+	assert.Equal(t, 3.0, result.Settings.GradingScale.Passing)
+	assert.Empty(t, result.Overrides)
+}
+
+func TestSchoolSettings_PatchMergesAndRecordsHistory(t *testing.T) {
+	svc, _, settingsRepo, school := newTestSchoolSettingsService(t)
+	settingsRepo.On("FindBySchoolID", mock.Anything, school.ID).Return(&repository.SchoolSettings{
+		SchoolID: school.ID, SchemaVersion: 1, Version: 2,
+		Overrides: json.RawMessage(`{"locale":"en-US","attendance":{"late_after_minutes":5}}`),
+	}, nil)
+	settingsRepo.On("Save", mock.Anything, mock.MatchedBy(func(s *repository.SchoolSettings) bool {
+		return s.Version == 3 && s.UpdatedBy == "admin-1"
+	}), 2).Return(true, nil)
+	settingsRepo.On("CreateChange", mock.Anything, mock.MatchedBy(func(c *repository.SchoolSettingsChange) bool {
+		return c.Version == 3 && c.Reason == "nuevo calendario"
+	})).Return(nil)
+
+	version := 2
+	result, err := svc.PatchSettings(context.Background(), school.ID.String(), dto.PatchSchoolSettingsRequest{
+		Version: &version,
+		Settings: map[string]interface{}{
+			"locale":            nil,
+			"academic_calendar": map[string]interface{}{"start_month": 2, "start_day": 1},
+		},
+		Reason: "nuevo calendario",
+	}, "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Version)
+	assert.Equal(t, "es-CO", result.Settings.Locale, "null restores the inherited value")
+	assert.Equal(t, 2, result.Settings.AcademicCalendar.StartMonth)
+	assert.Equal(t, 4, result.Settings.AcademicCalendar.Terms)
+	assert.Equal(t, 5, result.Settings.Attendance.LateAfterMinutes)
+	assert.NotContains(t, result.Overrides, "locale")
+	settingsRepo.AssertExpectations(t)
+}
+
+func TestSchoolSettings_PatchRejectsInvalidSettings(t *testing.T) {
+	svc, _, settingsRepo, school := newTestSchoolSettingsService(t)
+	settingsRepo.On("FindBySchoolID", mock.Anything, school.ID).Return(nil, nil)
+
+	cases := map[string]map[string]interface{}{
+		"unknown key":       {"theme": "dark"},
+		"out of range":      {"attendance": map[string]interface{}{"absence_alert_percent": 150}},
+		"wrong type":        {"academic_calendar": map[string]interface{}{"terms": "four"}},
+		"invalid timezone":  {"timezone": "Mars/Olympus"},
+		"passing above max": {"grading_scale": map[string]interface{}{"passing": 6}},
+		"invalid day":       {"academic_calendar": map[string]interface{}{"start_month": 2, "start_day": 30}},
+		"unknown unit type": {"unit_nesting": map[string]interface{}{"allowed_parents": map[string]interface{}{"section": []string{"wing"}}}},
+		"no root types":     {"unit_nesting": map[string]interface{}{"root_types": []string{}}},
+	}
+	for name, patch := range cases {
+		_, err := svc.PatchSettings(context.Background(), school.ID.String(), dto.PatchSchoolSettingsRequest{Settings: patch}, "admin-1")
+		assert.Error(t, err, name)
+	}
+	settingsRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSchoolSettings_UnitNestingRulesMergeOverrides(t *testing.T) {
+	svc, _, settingsRepo, school := newTestSchoolSettingsService(t)
+	settingsRepo.On("FindBySchoolID", mock.Anything, school.ID).Return(&repository.SchoolSettings{
+		SchoolID: school.ID, SchemaVersion: 1, Version: 1,
+		Overrides: json.RawMessage(`{"unit_nesting":{"max_depth":3,"allowed_parents":{"section":["grade","department"]}}}`),
+	}, nil)
+
+	rules, err := svc.GetUnitNestingRules(context.Background(), school.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, rules.MaxDepth)
+	assert.True(t, rules.CanNest(valueobject.UnitTypeSection, valueobject.UnitTypeDepartment))
+	assert.True(t, rules.CanNest(valueobject.UnitTypeGrade, valueobject.UnitTypeSchool), "types without override keep the global rule")
+	assert.False(t, rules.CanBeRoot(valueobject.UnitTypeSection))
+}
+
+func TestSchoolSettings_PatchStaleVersionConflicts(t *testing.T) {
+	svc, _, settingsRepo, school := newTestSchoolSettingsService(t)
+	settingsRepo.On("FindBySchoolID", mock.Anything, school.ID).Return(&repository.SchoolSettings{
+		SchoolID: school.ID, SchemaVersion: 1, Version: 4, Overrides: json.RawMessage(`{}`),
+	}, nil)
+
+	stale := 3
+	_, err := svc.PatchSettings(context.Background(), school.ID.String(), dto.PatchSchoolSettingsRequest{
+		Version:  &stale,
+		Settings: map[string]interface{}{"locale": "en"},
+	}, "admin-1")
+
+	require.Error(t, err)
+	settingsRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNewSchoolSettingsService_RejectsInvalidGlobal(t *testing.T) {
+	global := testGlobalSchoolSettings()
+	delete(global, "timezone")
+
+	_, err := NewSchoolSettingsService(new(MockSchoolRepository), new(MockSchoolSettingsRepository), passthroughTxManager{}, global, newTestLogger())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timezone: is required")
+}
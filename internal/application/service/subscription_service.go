@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// SubscriptionService administra el catálogo de planes, sus features y los cambios de plan de las escuelas
+type SubscriptionService interface {
+	// ListTiers lista el catálogo de planes de menor a mayor
+	ListTiers(ctx context.Context) []dto.SubscriptionTierResponse
+
+	// GetSchoolSubscription obtiene el plan vigente de la escuela y su uso actual
+	GetSchoolSubscription(ctx context.Context, schoolID string) (*dto.SchoolSubscriptionResponse, error)
+
+	// IsFeatureEnabled indica si el plan de la escuela habilita el feature
+	IsFeatureEnabled(ctx context.Context, schoolID uuid.UUID, feature valueobject.SubscriptionFeature) (bool, error)
+
+	// CheckFeature es la variante de IsFeatureEnabled para la API
+	CheckFeature(ctx context.Context, schoolID, feature string) (*dto.FeatureCheckResponse, error)
+
+	// ChangeTier aplica un upgrade o downgrade validando el uso actual contra los límites del nuevo plan
+	ChangeTier(ctx context.Context, schoolID string, req dto.ChangeSubscriptionTierRequest, performedBy string) (*dto.SchoolSubscriptionResponse, error)
+
+	// ListTierHistory lista los cambios de plan de la escuela
+	ListTierHistory(ctx context.Context, schoolID string) ([]dto.SubscriptionChangeResponse, error)
+}
+
+// subscriptionTier es un plan del catálogo ya validado; rank es su posición en el catálogo
+type subscriptionTier struct {
+	config.SubscriptionTierConfig
+	rank     int
+	features map[valueobject.SubscriptionFeature]bool
+}
+
+type subscriptionService struct {
+	schoolRepo     repository.SchoolRepository
+	unitRepo       repository.AcademicUnitRepository
+	membershipRepo repository.UnitMembershipRepository
+	changeRepo     repository.SubscriptionChangeRepository
+	txManager      repository.TransactionManager
+	tiers          []*subscriptionTier
+	tiersByName    map[string]*subscriptionTier
+	logger         logger.Logger
+}
+
+// NewSubscriptionService crea un nuevo SubscriptionService con el catálogo de la configuración
+func NewSubscriptionService(
+	schoolRepo repository.SchoolRepository,
+	unitRepo repository.AcademicUnitRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	changeRepo repository.SubscriptionChangeRepository,
+	txManager repository.TransactionManager,
+	cfg config.SubscriptionsConfig,
+	logger logger.Logger,
+) SubscriptionService {
+	s := &subscriptionService{
+		schoolRepo:     schoolRepo,
+		unitRepo:       unitRepo,
+		membershipRepo: membershipRepo,
+		changeRepo:     changeRepo,
+		txManager:      txManager,
+		tiersByName:    make(map[string]*subscriptionTier, len(cfg.Tiers)),
+		logger:         logger,
+	}
+
+	for _, tierCfg := range cfg.Tiers {
+		name := strings.ToLower(strings.TrimSpace(tierCfg.Name))
+		if name == "" || s.tiersByName[name] != nil {
+			logger.Warn("ignoring invalid subscription tier", "tier", tierCfg.Name)
+			continue
+		}
+		tierCfg.Name = name
+		tier := &subscriptionTier{
+			SubscriptionTierConfig: tierCfg,
+			rank:                   len(s.tiers),
+			features:               make(map[valueobject.SubscriptionFeature]bool, len(tierCfg.Features)),
+		}
+		for _, f := range tierCfg.Features {
+			feature, err := valueobject.ParseSubscriptionFeature(f)
+			if err != nil {
+				logger.Warn("ignoring unknown subscription feature", "tier", name, "feature", f)
+				continue
+			}
+			tier.features[feature] = true
+		}
+		s.tiers = append(s.tiers, tier)
+		s.tiersByName[name] = tier
+	}
+	return s
+}
+
+func (s *subscriptionService) ListTiers(ctx context.Context) []dto.SubscriptionTierResponse {
+	responses := make([]dto.SubscriptionTierResponse, len(s.tiers))
+	for i, tier := range s.tiers {
+		responses[i] = toSubscriptionTierResponse(tier)
+	}
+	return responses
+}
+
+func (s *subscriptionService) GetSchoolSubscription(ctx context.Context, schoolID string) (*dto.SchoolSubscriptionResponse, error) {
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.usage(ctx, school.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSchoolSubscriptionResponse(school, usage), nil
+}
+
+func (s *subscriptionService) IsFeatureEnabled(ctx context.Context, schoolID uuid.UUID, feature valueobject.SubscriptionFeature) (bool, error) {
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return false, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return false, errors.NewNotFoundError("school")
+	}
+
+	// Un tier fuera del catálogo no habilita ningún feature
+	tier := s.tiersByName[strings.ToLower(school.SubscriptionTier)]
+	return tier != nil && tier.features[feature], nil
+}
+
+func (s *subscriptionService) CheckFeature(ctx context.Context, schoolID, feature string) (*dto.FeatureCheckResponse, error) {
+	parsed, err := valueobject.ParseSubscriptionFeature(feature)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error()).WithField("feature", feature)
+	}
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := s.IsFeatureEnabled(ctx, school.ID, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.FeatureCheckResponse{
+		SchoolID: school.ID.String(),
+		Tier:     school.SubscriptionTier,
+		Feature:  parsed.String(),
+		Enabled:  enabled,
+	}, nil
+}
+
+func (s *subscriptionService) ChangeTier(ctx context.Context, schoolID string, req dto.ChangeSubscriptionTierRequest, performedBy string) (*dto.SchoolSubscriptionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	target := s.tiersByName[strings.ToLower(strings.TrimSpace(req.Tier))]
+	if target == nil {
+		return nil, errors.NewValidationError("unknown subscription tier").WithField("tier", req.Tier)
+	}
+
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+
+	var school *entities.School
+	var usage dto.SubscriptionUsage
+	var change *repository.SubscriptionChange
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Con el cupo bloqueado no entran altas de miembros ni unidades entre la validación y el cambio
+		if err := s.membershipRepo.LockSchoolQuota(ctx, id); err != nil {
+			return errors.NewDatabaseError("lock school quota", err)
+		}
+		school, err = s.schoolRepo.FindByID(ctx, id)
+		if err != nil {
+			return errors.NewDatabaseError("find school", err)
+		}
+		if school == nil {
+			return errors.NewNotFoundError("school")
+		}
+		if strings.EqualFold(school.SubscriptionTier, target.Name) {
+			return errors.NewBusinessRuleError("school is already on tier " + target.Name)
+		}
+
+		// Tanto upgrades como downgrades se validan: el catálogo no garantiza límites crecientes
+		if usage, err = s.usage(ctx, school.ID); err != nil {
+			return err
+		}
+		if violations := limitViolations(target, usage); len(violations) > 0 {
+			return errors.NewBusinessRuleError(fmt.Sprintf(
+				"cannot change to tier %s, current usage exceeds its limits: %s",
+				target.Name, strings.Join(violations, "; "),
+			))
+		}
+
+		// Un tier actual fuera del catálogo se considera inferior a cualquiera del catálogo
+		direction := repository.SubscriptionChangeUpgrade
+		if current := s.tiersByName[strings.ToLower(school.SubscriptionTier)]; current != nil && current.rank > target.rank {
+			direction = repository.SubscriptionChangeDowngrade
+		}
+
+		now := time.Now()
+		change = &repository.SubscriptionChange{
+			ID:          uuid.New(),
+			SchoolID:    school.ID,
+			FromTier:    school.SubscriptionTier,
+			ToTier:      target.Name,
+			Direction:   direction,
+			Reason:      strings.TrimSpace(req.Reason),
+			PerformedBy: performedBy,
+			CreatedAt:   now,
+		}
+
+		school.SubscriptionTier = target.Name
+		school.MaxTeachers = target.MaxTeachers
+		school.MaxStudents = target.MaxStudents
+		school.UpdatedAt = now
+
+		if err := s.schoolRepo.Update(ctx, school); err != nil {
+			return errors.NewDatabaseError("update school", err)
+		}
+		if err := s.changeRepo.Create(ctx, change); err != nil {
+			return errors.NewDatabaseError("create subscription change", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("subscription tier changed",
+		"school_id", school.ID.String(),
+		"from_tier", change.FromTier,
+		"to_tier", change.ToTier,
+		"direction", change.Direction,
+		"performed_by", performedBy,
+	)
+	return s.toSchoolSubscriptionResponse(school, usage), nil
+}
+
+func (s *subscriptionService) ListTierHistory(ctx context.Context, schoolID string) ([]dto.SubscriptionChangeResponse, error) {
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.changeRepo.ListBySchool(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list subscription changes", err)
+	}
+
+	responses := make([]dto.SubscriptionChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = dto.ToSubscriptionChangeResponse(change)
+	}
+	return responses, nil
+}
+
+func (s *subscriptionService) loadSchool(ctx context.Context, schoolID string) (*entities.School, error) {
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	return school, nil
+}
+
+// usage cuenta los recursos limitados por plan. El almacenamiento no se registra por escuela,
+// por lo que MaxStorageMB es informativo y no se valida en los cambios de plan.
+func (s *subscriptionService) usage(ctx context.Context, schoolID uuid.UUID) (dto.SubscriptionUsage, error) {
+	var usage dto.SubscriptionUsage
+	var err error
+
+	if usage.Teachers, err = s.membershipRepo.CountActiveUsersBySchoolAndRole(ctx, schoolID, string(valueobject.RoleTeacher)); err != nil {
+		return usage, errors.NewDatabaseError("count memberships", err)
+	}
+	if usage.Students, err = s.membershipRepo.CountActiveUsersBySchoolAndRole(ctx, schoolID, string(valueobject.RoleStudent)); err != nil {
+		return usage, errors.NewDatabaseError("count memberships", err)
+	}
+	if usage.Units, err = s.unitRepo.CountBySchoolID(ctx, schoolID); err != nil {
+		return usage, errors.NewDatabaseError("count units", err)
+	}
+	return usage, nil
+}
+
+// limitViolations describe los límites del plan que el uso actual supera
+func limitViolations(tier *subscriptionTier, usage dto.SubscriptionUsage) []string {
+	checks := []struct {
+		name  string
+		used  int
+		limit int
+	}{
+		{"teachers", usage.Teachers, tier.MaxTeachers},
+		{"students", usage.Students, tier.MaxStudents},
+		{"units", usage.Units, tier.MaxUnits},
+	}
+
+	var violations []string
+	for _, c := range checks {
+		if c.limit > 0 && c.used > c.limit {
+			violations = append(violations, fmt.Sprintf("%s %d/%d", c.name, c.used, c.limit))
+		}
+	}
+	return violations
+}
+
+func (s *subscriptionService) toSchoolSubscriptionResponse(school *entities.School, usage dto.SubscriptionUsage) *dto.SchoolSubscriptionResponse {
+	response := &dto.SchoolSubscriptionResponse{
+		SchoolID: school.ID.String(),
+		Tier:     school.SubscriptionTier,
+		Usage:    usage,
+	}
+	if tier := s.tiersByName[strings.ToLower(school.SubscriptionTier)]; tier != nil {
+		plan := toSubscriptionTierResponse(tier)
+		response.Plan = &plan
+	}
+	return response
+}
+
+func toSubscriptionTierResponse(tier *subscriptionTier) dto.SubscriptionTierResponse {
+	features := make([]string, 0, len(tier.features))
+	for _, f := range tier.Features {
+		if tier.features[valueobject.SubscriptionFeature(f)] {
+			features = append(features, f)
+		}
+	}
+	displayName := tier.DisplayName
+	if displayName == "" {
+		displayName = tier.Name
+	}
+
+	return dto.SubscriptionTierResponse{
+		Name:         tier.Name,
+		DisplayName:  displayName,
+		MaxTeachers:  tier.MaxTeachers,
+		MaxStudents:  tier.MaxStudents,
+		MaxUnits:     tier.MaxUnits,
+		MaxStorageMB: tier.MaxStorageMB,
+		Features:     features,
+	}
+}
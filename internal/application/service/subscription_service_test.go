@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockSubscriptionChangeRepository mock implementation
+type MockSubscriptionChangeRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionChangeRepository) Create(ctx context.Context, change *repository.SubscriptionChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionChangeRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*repository.SubscriptionChange, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.SubscriptionChange), args.Error(1)
+}
+
+// testSubscriptionsConfig retorna el catálogo de planes usado por los tests
+func testSubscriptionsConfig() config.SubscriptionsConfig {
+	return config.SubscriptionsConfig{Tiers: []config.SubscriptionTierConfig{
+		{Name: "free", MaxTeachers: 5, MaxStudents: 50, MaxUnits: 10},
+		{Name: "basic", MaxTeachers: 20, MaxStudents: 200, MaxUnits: 50, Features: []string{"exports"}},
+		{Name: "premium", Features: []string{"exports", "sso", "api_tokens", "unknown"}},
+	}}
+}
+
+// allFeaturesEnabled retorna un SubscriptionService cuyo plan habilita todos los features en cualquier escuela
+func allFeaturesEnabled() SubscriptionService {
+	schools := new(MockSchoolRepository)
+	schools.On("FindByID", mock.Anything, mock.Anything).Return(&entities.School{IsActive: true, SubscriptionTier: "premium"}, nil).Maybe()
+	cfg := config.SubscriptionsConfig{Tiers: []config.SubscriptionTierConfig{
+		{Name: "premium", Features: []string{"sso", "api_tokens", "exports"}},
+	}}
+	return NewSubscriptionService(schools, nil, nil, nil, passthroughTxManager{}, cfg, newTestLogger())
+}
+
+// expectUsage configura el conteo de docentes, estudiantes y unidades activas de una escuela
+func expectUsage(mockMembershipRepo *MockUnitMembershipRepository, mockUnitRepo *MockAcademicUnitRepository,
+	schoolID uuid.UUID, teachers, students, units int) {
+	mockMembershipRepo.On("CountActiveUsersBySchoolAndRole", mock.Anything, schoolID, "teacher").Return(teachers, nil)
+	mockMembershipRepo.On("CountActiveUsersBySchoolAndRole", mock.Anything, schoolID, "student").Return(students, nil)
+	mockUnitRepo.On("CountBySchoolID", mock.Anything, schoolID).Return(units, nil)
+}
+
+func TestSubscription_IsFeatureEnabled(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	svc := NewSubscriptionService(mockSchoolRepo, new(MockAcademicUnitRepository), new(MockUnitMembershipRepository), new(MockSubscriptionChangeRepository),
+		passthroughTxManager{}, testSubscriptionsConfig(), newTestLogger())
+
+	tiers := svc.ListTiers(context.Background())
+	require.Len(t, tiers, 3)
+	assert.Equal(t, []string{"exports", "sso", "api_tokens"}, tiers[2].Features)
+
+	cases := map[string]bool{"free": false, "basic": false, "premium": true, "legacy": false}
+	for tier, expected := range cases {
+		school := &entities.School{ID: uuid.New(), SubscriptionTier: tier}
+		mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+
+		enabled, err := svc.IsFeatureEnabled(context.Background(), school.ID, valueobject.FeatureSSO)
+		require.NoError(t, err)
+		assert.Equal(t, expected, enabled, tier)
+	}
+}
+
+func TestChangeTier_DowngradeRejectedOverUsage(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockChangeRepo := new(MockSubscriptionChangeRepository)
+	svc := NewSubscriptionService(mockSchoolRepo, mockUnitRepo, mockMembershipRepo, mockChangeRepo,
+		passthroughTxManager{}, testSubscriptionsConfig(), newTestLogger())
+	school := &entities.School{ID: uuid.New(), SubscriptionTier: "basic", MaxTeachers: 20, MaxStudents: 200}
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	mockMembershipRepo.On("LockSchoolQuota", mock.Anything, school.ID).Return(nil).Once()
+	expectUsage(mockMembershipRepo, mockUnitRepo, school.ID, 8, 40, 12)
+
+	_, err := svc.ChangeTier(context.Background(), school.ID.String(),
+		dto.ChangeSubscriptionTierRequest{Tier: "free", Reason: "fin de contrato"}, "admin-1")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "teachers 8/5")
+	assert.Contains(t, err.Error(), "units 12/10")
+	mockSchoolRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockChangeRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockMembershipRepo.AssertExpectations(t)
+}
+
+func TestChangeTier_DowngradeRecordsHistory(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockChangeRepo := new(MockSubscriptionChangeRepository)
+	svc := NewSubscriptionService(mockSchoolRepo, mockUnitRepo, mockMembershipRepo, mockChangeRepo,
+		passthroughTxManager{}, testSubscriptionsConfig(), newTestLogger())
+	school := &entities.School{ID: uuid.New(), SubscriptionTier: "premium"}
+	mockSchoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	mockMembershipRepo.On("LockSchoolQuota", mock.Anything, school.ID).Return(nil).Once()
+	expectUsage(mockMembershipRepo, mockUnitRepo, school.ID, 10, 150, 30)
+	mockSchoolRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *entities.School) bool {
+		return s.SubscriptionTier == "basic" && s.MaxTeachers == 20 && s.MaxStudents == 200
+	})).Return(nil).Once()
+	mockChangeRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *repository.SubscriptionChange) bool {
+		return c.FromTier == "premium" && c.ToTier == "basic" &&
+			c.Direction == repository.SubscriptionChangeDowngrade && c.PerformedBy == "admin-1"
+	})).Return(nil).Once()
+
+	result, err := svc.ChangeTier(context.Background(), school.ID.String(),
+		dto.ChangeSubscriptionTierRequest{Tier: "Basic", Reason: "reducción de presupuesto"}, "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "basic", result.Tier)
+	require.NotNil(t, result.Plan)
+	assert.Equal(t, []string{"exports"}, result.Plan.Features)
+	mockSchoolRepo.AssertExpectations(t)
+	mockChangeRepo.AssertExpectations(t)
+}
+
+func TestChangeTier_UnknownTier(t *testing.T) {
+	mockSchoolRepo := new(MockSchoolRepository)
+	svc := NewSubscriptionService(mockSchoolRepo, new(MockAcademicUnitRepository), new(MockUnitMembershipRepository), new(MockSubscriptionChangeRepository),
+		passthroughTxManager{}, testSubscriptionsConfig(), newTestLogger())
+
+	_, err := svc.ChangeTier(context.Background(), uuid.New().String(),
+		dto.ChangeSubscriptionTierRequest{Tier: "gold", Reason: "prueba"}, "admin-1")
+
+	require.Error(t, err)
+	mockSchoolRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
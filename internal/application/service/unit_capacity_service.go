@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// UnitCapacityService controla el cupo de estudiantes de las unidades y su lista de espera
+type UnitCapacityService interface {
+	// ConfigureCapacity fija el cupo de la unidad (maxStudents 0 lo quita) y promueve
+	// estudiantes en espera si quedan lugares libres
+	ConfigureCapacity(ctx context.Context, schoolID, unitID uuid.UUID, maxStudents *int, waitlistEnabled *bool) error
+	// Occupancies retorna la ocupación de las unidades indicadas que tienen cupo
+	Occupancies(ctx context.Context, unitIDs []uuid.UUID) (map[uuid.UUID]*dto.UnitOccupancyResponse, error)
+	// ReserveSeat valida que la unidad tenga lugar para un nuevo estudiante. Si está llena y
+	// allowWaitlist lo permite, anota al estudiante en la lista de espera y retorna la entrada.
+	// Debe llamarse dentro de la transacción que crea la membresía.
+	ReserveSeat(ctx context.Context, schoolID, unitID, userID uuid.UUID, allowWaitlist bool) (*dto.WaitlistEntryResponse, error)
+	// FillFreedSeats promueve estudiantes en espera a los lugares libres de la unidad
+	FillFreedSeats(ctx context.Context, unitID uuid.UUID) (int, error)
+	ListWaitlist(ctx context.Context, unitID string) ([]dto.WaitlistEntryResponse, error)
+	CancelWaitlistEntry(ctx context.Context, unitID, entryID string) error
+}
+
+type unitCapacityService struct {
+	capacityRepo   repository.UnitCapacityRepository
+	unitRepo       repository.AcademicUnitRepository
+	membershipRepo repository.UnitMembershipRepository
+	quotaService   SchoolQuotaService
+	txManager      repository.TransactionManager
+	logger         logger.Logger
+}
+
+// NewUnitCapacityService crea un nuevo UnitCapacityService
+func NewUnitCapacityService(
+	capacityRepo repository.UnitCapacityRepository,
+	unitRepo repository.AcademicUnitRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	quotaService SchoolQuotaService,
+	txManager repository.TransactionManager,
+	logger logger.Logger,
+) UnitCapacityService {
+	return &unitCapacityService{
+		capacityRepo:   capacityRepo,
+		unitRepo:       unitRepo,
+		membershipRepo: membershipRepo,
+		quotaService:   quotaService,
+		txManager:      txManager,
+		logger:         logger,
+	}
+}
+
+func (s *unitCapacityService) ConfigureCapacity(ctx context.Context, schoolID, unitID uuid.UUID, maxStudents *int, waitlistEnabled *bool) error {
+	if maxStudents == nil && waitlistEnabled == nil {
+		return nil
+	}
+	if maxStudents != nil && *maxStudents < 0 {
+		return errors.NewValidationError("max_students cannot be negative")
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.capacityRepo.LockUnit(ctx, unitID); err != nil {
+			return errors.NewDatabaseError("lock unit capacity", err)
+		}
+		capacity, err := s.capacityRepo.FindCapacity(ctx, unitID)
+		if err != nil {
+			return errors.NewDatabaseError("find unit capacity", err)
+		}
+
+		switch {
+		case maxStudents != nil && *maxStudents == 0:
+			if capacity == nil {
+				return nil
+			}
+			if err := s.capacityRepo.DeleteCapacity(ctx, unitID); err != nil {
+				return errors.NewDatabaseError("delete unit capacity", err)
+			}
+		case capacity == nil && maxStudents == nil:
+			if *waitlistEnabled {
+				return errors.NewValidationError("waitlist_enabled requires max_students")
+			}
+			return nil
+		default:
+			if capacity == nil {
+				capacity = &repository.UnitCapacity{UnitID: unitID, SchoolID: schoolID}
+			}
+			if maxStudents != nil {
+				capacity.MaxStudents = *maxStudents
+			}
+			if waitlistEnabled != nil {
+				capacity.WaitlistEnabled = *waitlistEnabled
+			}
+			capacity.UpdatedAt = time.Now()
+			if err := s.capacityRepo.SaveCapacity(ctx, capacity); err != nil {
+				return errors.NewDatabaseError("save unit capacity", err)
+			}
+		}
+
+		// Un cupo mayor (o quitarlo) puede dejar lugar a los estudiantes en espera
+		_, err = s.FillFreedSeats(ctx, unitID)
+		return err
+	})
+}
+
+func (s *unitCapacityService) Occupancies(ctx context.Context, unitIDs []uuid.UUID) (map[uuid.UUID]*dto.UnitOccupancyResponse, error) {
+	capacities, err := s.capacityRepo.FindCapacities(ctx, unitIDs)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find unit capacities", err)
+	}
+
+	occupancies := make(map[uuid.UUID]*dto.UnitOccupancyResponse, len(capacities))
+	for unitID, capacity := range capacities {
+		students, err := countSeatedStudents(ctx, s.membershipRepo, unitID)
+		if err != nil {
+			return nil, err
+		}
+		waiting, err := s.capacityRepo.ListWaiting(ctx, unitID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("list unit waitlist", err)
+		}
+		occupancies[unitID] = toUnitOccupancy(capacity, students, len(waiting))
+	}
+	return occupancies, nil
+}
+
+func (s *unitCapacityService) ReserveSeat(ctx context.Context, schoolID, unitID, userID uuid.UUID, allowWaitlist bool) (*dto.WaitlistEntryResponse, error) {
+	if err := s.capacityRepo.LockUnit(ctx, unitID); err != nil {
+		return nil, errors.NewDatabaseError("lock unit capacity", err)
+	}
+	capacity, err := s.capacityRepo.FindCapacity(ctx, unitID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find unit capacity", err)
+	}
+	if capacity == nil {
+		return nil, nil
+	}
+
+	students, err := countSeatedStudents(ctx, s.membershipRepo, unitID)
+	if err != nil {
+		return nil, err
+	}
+	waiting, err := s.capacityRepo.ListWaiting(ctx, unitID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list unit waitlist", err)
+	}
+	// Los lugares libres son de quienes ya esperan
+	if students+len(waiting) < capacity.MaxStudents {
+		return nil, nil
+	}
+
+	if !allowWaitlist || !capacity.WaitlistEnabled {
+		return nil, errors.NewBusinessRuleError("unit is at capacity").
+			WithField("max_students", capacity.MaxStudents)
+	}
+	for _, entry := range waiting {
+		if entry.UserID == userID {
+			return nil, errors.NewAlreadyExistsError("waitlist entry for this user and unit")
+		}
+	}
+
+	entry := &repository.UnitWaitlistEntry{
+		ID:          uuid.New(),
+		UnitID:      unitID,
+		SchoolID:    schoolID,
+		UserID:      userID,
+		Status:      repository.UnitWaitlistWaiting,
+		RequestedAt: time.Now(),
+	}
+	if err := s.capacityRepo.CreateWaitlistEntry(ctx, entry); err != nil {
+		return nil, errors.NewDatabaseError("create waitlist entry", err)
+	}
+
+	s.logger.Info("student added to waitlist",
+		"entity_type", "unit_waitlist_entry",
+		"entity_id", entry.ID.String(),
+		"unit_id", unitID.String(),
+		"user_id", userID.String(),
+	)
+	response := dto.ToWaitlistEntryResponse(entry, len(waiting)+1)
+	return &response, nil
+}
+
+func (s *unitCapacityService) FillFreedSeats(ctx context.Context, unitID uuid.UUID) (int, error) {
+	promoted := 0
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.capacityRepo.LockUnit(ctx, unitID); err != nil {
+			return errors.NewDatabaseError("lock unit capacity", err)
+		}
+		waiting, err := s.capacityRepo.ListWaiting(ctx, unitID)
+		if err != nil {
+			return errors.NewDatabaseError("list unit waitlist", err)
+		}
+		if len(waiting) == 0 {
+			return nil
+		}
+
+		// Sin cupo configurado todos los que esperan tienen lugar
+		free := len(waiting)
+		capacity, err := s.capacityRepo.FindCapacity(ctx, unitID)
+		if err != nil {
+			return errors.NewDatabaseError("find unit capacity", err)
+		}
+		if capacity != nil {
+			students, err := countSeatedStudents(ctx, s.membershipRepo, unitID)
+			if err != nil {
+				return err
+			}
+			free = capacity.MaxStudents - students
+		}
+
+		for _, entry := range waiting {
+			if free <= 0 {
+				break
+			}
+			now := time.Now()
+			entry.ResolvedAt = &now
+
+			exists, err := s.membershipRepo.ExistsByUnitAndUser(ctx, unitID, entry.UserID)
+			if err != nil {
+				return errors.NewDatabaseError("check membership", err)
+			}
+			if exists {
+				// Se inscribió por otra vía mientras esperaba
+				entry.Status = repository.UnitWaitlistCancelled
+				if err := s.capacityRepo.UpdateWaitlistEntry(ctx, entry); err != nil {
+					return errors.NewDatabaseError("update waitlist entry", err)
+				}
+				continue
+			}
+
+			// Sin cupo en el plan de la escuela la lista queda detenida hasta el próximo lugar libre
+			if err := s.quotaService.CheckMembershipQuota(ctx, entry.SchoolID, entry.UserID, string(valueobject.RoleStudent)); err != nil {
+				s.logger.Warn("waitlist promotion stopped",
+					"unit_id", unitID.String(),
+					"user_id", entry.UserID.String(),
+					"error", err.Error(),
+				)
+				break
+			}
+
+			unitUUID := unitID
+			membership := &entities.Membership{
+				ID:             uuid.New(),
+				UserID:         entry.UserID,
+				SchoolID:       entry.SchoolID,
+				AcademicUnitID: &unitUUID,
+				Role:           string(valueobject.RoleStudent),
+				Metadata:       []byte("{}"),
+				IsActive:       true,
+				EnrolledAt:     now,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			}
+			if err := s.membershipRepo.Create(ctx, membership); err != nil {
+				return errors.NewDatabaseError("create membership", err)
+			}
+
+			entry.Status = repository.UnitWaitlistPromoted
+			entry.MembershipID = &membership.ID
+			if err := s.capacityRepo.UpdateWaitlistEntry(ctx, entry); err != nil {
+				return errors.NewDatabaseError("update waitlist entry", err)
+			}
+			promoted++
+			free--
+
+			s.logger.Info("student promoted from waitlist",
+				"entity_type", "membership",
+				"entity_id", membership.ID.String(),
+				"unit_id", unitID.String(),
+				"user_id", entry.UserID.String(),
+				"waitlist_entry_id", entry.ID.String(),
+			)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return promoted, nil
+}
+
+func (s *unitCapacityService) ListWaitlist(ctx context.Context, unitID string) ([]dto.WaitlistEntryResponse, error) {
+	unitUUID, err := uuid.Parse(unitID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid unit ID")
+	}
+	if _, err := s.findUnit(ctx, unitUUID); err != nil {
+		return nil, err
+	}
+
+	waiting, err := s.capacityRepo.ListWaiting(ctx, unitUUID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list unit waitlist", err)
+	}
+
+	responses := make([]dto.WaitlistEntryResponse, len(waiting))
+	for i, entry := range waiting {
+		responses[i] = dto.ToWaitlistEntryResponse(entry, i+1)
+	}
+	return responses, nil
+}
+
+func (s *unitCapacityService) CancelWaitlistEntry(ctx context.Context, unitID, entryID string) error {
+	unitUUID, err := uuid.Parse(unitID)
+	if err != nil {
+		return errors.NewValidationError("invalid unit ID")
+	}
+	entryUUID, err := uuid.Parse(entryID)
+	if err != nil {
+		return errors.NewValidationError("invalid waitlist entry ID")
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.capacityRepo.LockUnit(ctx, unitUUID); err != nil {
+			return errors.NewDatabaseError("lock unit capacity", err)
+		}
+		entry, err := s.capacityRepo.FindWaitlistEntry(ctx, entryUUID)
+		if err != nil {
+			return errors.NewDatabaseError("find waitlist entry", err)
+		}
+		if entry == nil || entry.UnitID != unitUUID {
+			return errors.NewNotFoundError("waitlist entry")
+		}
+		if entry.Status != repository.UnitWaitlistWaiting {
+			return errors.NewConflictError("waitlist entry is already " + entry.Status)
+		}
+
+		now := time.Now()
+		entry.Status = repository.UnitWaitlistCancelled
+		entry.ResolvedAt = &now
+		if err := s.capacityRepo.UpdateWaitlistEntry(ctx, entry); err != nil {
+			return errors.NewDatabaseError("update waitlist entry", err)
+		}
+
+		s.logger.Info("waitlist entry cancelled",
+			"entity_type", "unit_waitlist_entry",
+			"entity_id", entryID,
+			"unit_id", unitID,
+		)
+		return nil
+	})
+}
+
+func (s *unitCapacityService) findUnit(ctx context.Context, unitID uuid.UUID) (*entities.AcademicUnit, error) {
+	unit, err := s.unitRepo.FindByID(ctx, unitID, false)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewNotFoundError("academic unit")
+		}
+		return nil, errors.NewDatabaseError("find unit", err)
+	}
+	if unit == nil {
+		return nil, errors.NewNotFoundError("academic unit")
+	}
+	return unit, nil
+}
+
+// countSeatedStudents cuenta los estudiantes de la unidad que ocupan un lugar: membresías
+// activas que no vencieron (una fecha de baja futura todavía ocupa el lugar)
+func countSeatedStudents(ctx context.Context, membershipRepo repository.UnitMembershipRepository, unitID uuid.UUID) (int, error) {
+	memberships, err := membershipRepo.FindByUnitAndRole(ctx, unitID, string(valueobject.RoleStudent), false)
+	if err != nil {
+		return 0, errors.NewDatabaseError("find unit students", err)
+	}
+	now := time.Now()
+	count := 0
+	for _, m := range memberships {
+		if m.IsActive && (m.WithdrawnAt == nil || m.WithdrawnAt.After(now)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func toUnitOccupancy(capacity *repository.UnitCapacity, students, waiting int) *dto.UnitOccupancyResponse {
+	available := capacity.MaxStudents - students
+	if available < 0 {
+		available = 0
+	}
+	return &dto.UnitOccupancyResponse{
+		MaxStudents:     capacity.MaxStudents,
+		Students:        students,
+		Available:       available,
+		WaitlistEnabled: capacity.WaitlistEnabled,
+		Waiting:         waiting,
+	}
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockUnitCapacityRepository mock implementation
+type MockUnitCapacityRepository struct {
+	mock.Mock
+}
+
+func (m *MockUnitCapacityRepository) FindCapacity(ctx context.Context, unitID uuid.UUID) (*repository.UnitCapacity, error) {
+	args := m.Called(ctx, unitID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UnitCapacity), args.Error(1)
+}
+
+func (m *MockUnitCapacityRepository) FindCapacities(ctx context.Context, unitIDs []uuid.UUID) (map[uuid.UUID]*repository.UnitCapacity, error) {
+	args := m.Called(ctx, unitIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*repository.UnitCapacity), args.Error(1)
+}
+
+func (m *MockUnitCapacityRepository) SaveCapacity(ctx context.Context, capacity *repository.UnitCapacity) error {
+	args := m.Called(ctx, capacity)
+	return args.Error(0)
+}
+
+func (m *MockUnitCapacityRepository) DeleteCapacity(ctx context.Context, unitID uuid.UUID) error {
+	args := m.Called(ctx, unitID)
+	return args.Error(0)
+}
+
+func (m *MockUnitCapacityRepository) LockUnit(ctx context.Context, unitID uuid.UUID) error {
+	args := m.Called(ctx, unitID)
+	return args.Error(0)
+}
+
+func (m *MockUnitCapacityRepository) CreateWaitlistEntry(ctx context.Context, entry *repository.UnitWaitlistEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockUnitCapacityRepository) UpdateWaitlistEntry(ctx context.Context, entry *repository.UnitWaitlistEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockUnitCapacityRepository) FindWaitlistEntry(ctx context.Context, id uuid.UUID) (*repository.UnitWaitlistEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UnitWaitlistEntry), args.Error(1)
+}
+
+func (m *MockUnitCapacityRepository) ListWaiting(ctx context.Context, unitID uuid.UUID) ([]*repository.UnitWaitlistEntry, error) {
+	args := m.Called(ctx, unitID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.UnitWaitlistEntry), args.Error(1)
+}
+
+// MockUnitCapacityService mock implementation
+type MockUnitCapacityService struct {
+	mock.Mock
+}
+
+func (m *MockUnitCapacityService) ConfigureCapacity(ctx context.Context, schoolID, unitID uuid.UUID, maxStudents *int, waitlistEnabled *bool) error {
+	args := m.Called(ctx, schoolID, unitID, maxStudents, waitlistEnabled)
+	return args.Error(0)
+}
+
+func (m *MockUnitCapacityService) Occupancies(ctx context.Context, unitIDs []uuid.UUID) (map[uuid.UUID]*dto.UnitOccupancyResponse, error) {
+	args := m.Called(ctx, unitIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*dto.UnitOccupancyResponse), args.Error(1)
+}
+
+func (m *MockUnitCapacityService) ReserveSeat(ctx context.Context, schoolID, unitID, userID uuid.UUID, allowWaitlist bool) (*dto.WaitlistEntryResponse, error) {
+	args := m.Called(ctx, schoolID, unitID, userID, allowWaitlist)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.WaitlistEntryResponse), args.Error(1)
+}
+
+func (m *MockUnitCapacityService) FillFreedSeats(ctx context.Context, unitID uuid.UUID) (int, error) {
+	args := m.Called(ctx, unitID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUnitCapacityService) ListWaitlist(ctx context.Context, unitID string) ([]dto.WaitlistEntryResponse, error) {
+	args := m.Called(ctx, unitID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.WaitlistEntryResponse), args.Error(1)
+}
+
+func (m *MockUnitCapacityService) CancelWaitlistEntry(ctx context.Context, unitID, entryID string) error {
+	args := m.Called(ctx, unitID, entryID)
+	return args.Error(0)
+}
+
+// noUnitCapacities retorna un servicio de cupos para unidades sin límite de estudiantes
+func noUnitCapacities() *MockUnitCapacityService {
+	capacityService := new(MockUnitCapacityService)
+	capacityService.On("ConfigureCapacity", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	capacityService.On("Occupancies", mock.Anything, mock.Anything).Return(map[uuid.UUID]*dto.UnitOccupancyResponse{}, nil).Maybe()
+	capacityService.On("ReserveSeat", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	capacityService.On("FillFreedSeats", mock.Anything, mock.Anything).Return(0, nil).Maybe()
+	return capacityService
+}
+
+func seatedStudents(unitID uuid.UUID, n int) []*entities.Membership {
+	students := make([]*entities.Membership, n)
+	for i := range students {
+		students[i] = &entities.Membership{ID: uuid.New(), UserID: uuid.New(), AcademicUnitID: &unitID, Role: "student", IsActive: true}
+	}
+	return students
+}
+
+func TestCreateMembership_RespectsUnitCapacity(t *testing.T) {
+	school := &entities.School{ID: uuid.New()}
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, Name: "1A", Type: "section"}
+	waiting := &repository.UnitWaitlistEntry{ID: uuid.New(), UnitID: unit.ID, UserID: uuid.New(), Status: repository.UnitWaitlistWaiting}
+
+	tests := []struct {
+		name     string
+		capacity *repository.UnitCapacity
+		waiting  []*repository.UnitWaitlistEntry
+		created  bool
+		position int
+		message  string
+	}{
+		{name: "seat available", capacity: &repository.UnitCapacity{UnitID: unit.ID, MaxStudents: 3}, created: true},
+		{name: "full without waitlist", capacity: &repository.UnitCapacity{UnitID: unit.ID, MaxStudents: 2}, message: "unit is at capacity"},
+		{name: "full with waitlist", capacity: &repository.UnitCapacity{UnitID: unit.ID, MaxStudents: 2, WaitlistEnabled: true}, position: 1},
+		{name: "free seat held for the waitlist", capacity: &repository.UnitCapacity{UnitID: unit.ID, MaxStudents: 3, WaitlistEnabled: true}, waiting: []*repository.UnitWaitlistEntry{waiting}, position: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			membershipRepo := new(MockUnitMembershipRepository)
+			unitRepo := new(MockAcademicUnitRepository)
+			capacityRepo := new(MockUnitCapacityRepository)
+			schoolRepo := new(MockSchoolRepository)
+			quotaService := newTestQuotaService(schoolRepo, membershipRepo)
+			capacityService := NewUnitCapacityService(capacityRepo, unitRepo, membershipRepo, quotaService, passthroughTxManager{}, newTestLogger())
+			service := NewUnitMembershipService(membershipRepo, unitRepo, liveSchools(), new(MockAcademicPeriodRepository), noCustomUnitTypes(), quotaService, capacityService, passthroughTxManager{}, newTestLogger())
+
+			unitRepo.On("FindByID", mock.Anything, unit.ID, false).Return(unit, nil)
+			schoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+			membershipRepo.On("ExistsByUnitAndUser", mock.Anything, unit.ID, userID).Return(false, nil)
+			membershipRepo.On("FindByUnitAndRole", mock.Anything, unit.ID, "student", false).Return(seatedStudents(unit.ID, 2), nil)
+			capacityRepo.On("LockUnit", mock.Anything, unit.ID).Return(nil)
+			capacityRepo.On("FindCapacity", mock.Anything, unit.ID).Return(tt.capacity, nil)
+			capacityRepo.On("ListWaiting", mock.Anything, unit.ID).Return(tt.waiting, nil)
+			membershipRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			capacityRepo.On("CreateWaitlistEntry", mock.Anything, mock.MatchedBy(func(e *repository.UnitWaitlistEntry) bool {
+				return e.UserID == userID && e.Status == repository.UnitWaitlistWaiting
+			})).Return(nil).Maybe()
+
+			resp, err := service.CreateMembership(context.Background(), dto.CreateMembershipRequest{
+				UnitID: unit.ID.String(),
+				UserID: userID.String(),
+				Role:   "student",
+			})
+
+			if tt.message != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.message)
+				membershipRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			if tt.created {
+				assert.Nil(t, resp.Waitlist)
+				membershipRepo.AssertCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NotNil(t, resp.Waitlist)
+			assert.Empty(t, resp.ID)
+			assert.Equal(t, tt.position, resp.Waitlist.Position)
+			membershipRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			capacityRepo.AssertCalled(t, "CreateWaitlistEntry", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestExpireMembership_PromotesWaitlistedStudents(t *testing.T) {
+	school := &entities.School{ID: uuid.New()}
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, Name: "1A", Type: "section"}
+	leaving := &entities.Membership{ID: uuid.New(), UserID: uuid.New(), SchoolID: school.ID, AcademicUnitID: &unit.ID, Role: "student", IsActive: true}
+	alreadyEnrolled := &repository.UnitWaitlistEntry{ID: uuid.New(), UnitID: unit.ID, SchoolID: school.ID, UserID: uuid.New(), Status: repository.UnitWaitlistWaiting, RequestedAt: time.Now().Add(-2 * time.Hour)}
+	next := &repository.UnitWaitlistEntry{ID: uuid.New(), UnitID: unit.ID, SchoolID: school.ID, UserID: uuid.New(), Status: repository.UnitWaitlistWaiting, RequestedAt: time.Now().Add(-time.Hour)}
+	last := &repository.UnitWaitlistEntry{ID: uuid.New(), UnitID: unit.ID, SchoolID: school.ID, UserID: uuid.New(), Status: repository.UnitWaitlistWaiting, RequestedAt: time.Now()}
+
+	membershipRepo := new(MockUnitMembershipRepository)
+	unitRepo := new(MockAcademicUnitRepository)
+	periodRepo := new(MockAcademicPeriodRepository)
+	capacityRepo := new(MockUnitCapacityRepository)
+	schoolRepo := new(MockSchoolRepository)
+	quotaService := newTestQuotaService(schoolRepo, membershipRepo)
+	capacityService := NewUnitCapacityService(capacityRepo, unitRepo, membershipRepo, quotaService, passthroughTxManager{}, newTestLogger())
+	service := NewUnitMembershipService(membershipRepo, unitRepo, liveSchools(), periodRepo, noCustomUnitTypes(), quotaService, capacityService, passthroughTxManager{}, newTestLogger())
+
+	membershipRepo.On("FindByID", mock.Anything, leaving.ID).Return(leaving, nil)
+	unitRepo.On("FindByID", mock.Anything, unit.ID, true).Return(unit, nil)
+	membershipRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	schoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+
+	// Con la baja queda un solo lugar libre de 30
+	capacityRepo.On("LockUnit", mock.Anything, unit.ID).Return(nil)
+	capacityRepo.On("ListWaiting", mock.Anything, unit.ID).Return([]*repository.UnitWaitlistEntry{alreadyEnrolled, next, last}, nil)
+	capacityRepo.On("FindCapacity", mock.Anything, unit.ID).Return(&repository.UnitCapacity{UnitID: unit.ID, MaxStudents: 30, WaitlistEnabled: true}, nil)
+	membershipRepo.On("FindByUnitAndRole", mock.Anything, unit.ID, "student", false).Return(seatedStudents(unit.ID, 29), nil)
+	membershipRepo.On("ExistsByUnitAndUser", mock.Anything, unit.ID, alreadyEnrolled.UserID).Return(true, nil)
+	membershipRepo.On("ExistsByUnitAndUser", mock.Anything, unit.ID, next.UserID).Return(false, nil)
+	membershipRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *entities.Membership) bool {
+		return m.UserID == next.UserID && m.Role == "student" && *m.AcademicUnitID == unit.ID
+	})).Return(nil)
+	capacityRepo.On("UpdateWaitlistEntry", mock.Anything, mock.MatchedBy(func(e *repository.UnitWaitlistEntry) bool {
+		return e.ID == alreadyEnrolled.ID && e.Status == repository.UnitWaitlistCancelled
+	})).Return(nil)
+	capacityRepo.On("UpdateWaitlistEntry", mock.Anything, mock.MatchedBy(func(e *repository.UnitWaitlistEntry) bool {
+		return e.ID == next.ID && e.Status == repository.UnitWaitlistPromoted && e.MembershipID != nil
+	})).Return(nil)
+
+	require.NoError(t, service.ExpireMembership(context.Background(), leaving.ID.String()))
+
+	membershipRepo.AssertNumberOfCalls(t, "Create", 1)
+	capacityRepo.AssertNumberOfCalls(t, "UpdateWaitlistEntry", 2)
+	membershipRepo.AssertNotCalled(t, "ExistsByUnitAndUser", mock.Anything, unit.ID, last.UserID)
+}
+
+func TestConfigureCapacity_RequiresMaxStudentsForWaitlist(t *testing.T) {
+	unitID := uuid.New()
+	capacityRepo := new(MockUnitCapacityRepository)
+	service := NewUnitCapacityService(capacityRepo, new(MockAcademicUnitRepository), new(MockUnitMembershipRepository), nil, passthroughTxManager{}, newTestLogger())
+	capacityRepo.On("LockUnit", mock.Anything, unitID).Return(nil)
+	capacityRepo.On("FindCapacity", mock.Anything, unitID).Return(nil, nil)
+
+	enabled := true
+	err := service.ConfigureCapacity(context.Background(), uuid.New(), unitID, nil, &enabled)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "waitlist_enabled requires max_students")
+	capacityRepo.AssertNotCalled(t, "SaveCapacity", mock.Anything, mock.Anything)
+}
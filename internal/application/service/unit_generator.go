@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// maxGeneratedUnits limita las unidades que puede crear una sola generación
+const maxGeneratedUnits = 500
+
+// generatedUnit es una unidad del árbol generado, antes de persistirse
+type generatedUnit struct {
+	Type     string
+	Name     string
+	Code     string
+	Children []*generatedUnit
+}
+
+// unitGenerator expande los niveles de un BulkCreateUnitsRequest
+type unitGenerator struct {
+	levels []dto.UnitGeneratorLevel
+	values [][]string
+}
+
+// newUnitGenerator expande los valores de cada nivel y valida que la generación no supere maxGeneratedUnits por padre
+func newUnitGenerator(levels []dto.UnitGeneratorLevel) (*unitGenerator, error) {
+	g := &unitGenerator{levels: levels, values: make([][]string, len(levels))}
+	for i, level := range levels {
+		values, err := expandGeneratorValues(level)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("levels[%d]: %s", i, err.Error()))
+		}
+		g.values[i] = values
+	}
+	if g.UnitsPerParent() > maxGeneratedUnits {
+		return nil, errors.NewValidationError(fmt.Sprintf("generator would create more than %d units", maxGeneratedUnits))
+	}
+	return g, nil
+}
+
+// UnitsPerParent retorna las unidades que se generan debajo de cada padre
+func (g *unitGenerator) UnitsPerParent() int {
+	total, width := 0, 1
+	for _, values := range g.values {
+		width *= len(values)
+		total += width
+		if total > maxGeneratedUnits {
+			return total
+		}
+	}
+	return total
+}
+
+// Generate arma el árbol de unidades que cuelga de un padre con el nombre y código indicados
+func (g *unitGenerator) Generate(parentName, parentCode string) []*generatedUnit {
+	return g.generate(0, parentName, parentCode)
+}
+
+func (g *unitGenerator) generate(depth int, parentName, parentCode string) []*generatedUnit {
+	if depth == len(g.levels) {
+		return nil
+	}
+	level := g.levels[depth]
+	units := make([]*generatedUnit, len(g.values[depth]))
+	for i, value := range g.values[depth] {
+		replacer := strings.NewReplacer(
+			"{value}", value,
+			"{index}", strconv.Itoa(i+1),
+			"{parent_name}", parentName,
+			"{parent_code}", parentCode,
+		)
+		unit := &generatedUnit{
+			Type: level.Type,
+			Name: strings.TrimSpace(replacer.Replace(level.NameTemplate)),
+			Code: strings.TrimSpace(replacer.Replace(level.CodeTemplate)),
+		}
+		unit.Children = g.generate(depth+1, unit.Name, unit.Code)
+		units[i] = unit
+	}
+	return units
+}
+
+// expandGeneratorValues retorna los valores del nivel: la lista explícita o el rango numérico (1..11) o de letras (A..D)
+func expandGeneratorValues(level dto.UnitGeneratorLevel) ([]string, error) {
+	if level.Range == nil {
+		seen := make(map[string]bool, len(level.Values))
+		values := make([]string, len(level.Values))
+		for i, value := range level.Values {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				return nil, fmt.Errorf("values cannot be empty")
+			}
+			if seen[value] {
+				return nil, fmt.Errorf("duplicate value %s", value)
+			}
+			seen[value] = true
+			values[i] = value
+		}
+		return values, nil
+	}
+
+	from, to := strings.TrimSpace(level.Range.From), strings.TrimSpace(level.Range.To)
+	if start, err := strconv.Atoi(from); err == nil {
+		end, err := strconv.Atoi(to)
+		if err != nil {
+			return nil, fmt.Errorf("range from and to must both be numbers or letters")
+		}
+		return expandRange(start, end, strconv.Itoa)
+	}
+	if isRangeLetter(from) && isRangeLetter(to) && isUpperLetter(from[0]) == isUpperLetter(to[0]) {
+		return expandRange(int(from[0]), int(to[0]), func(c int) string { return string(rune(c)) })
+	}
+	return nil, fmt.Errorf("range from and to must both be numbers or letters of the same case")
+}
+
+func expandRange(start, end int, format func(int) string) ([]string, error) {
+	if start > end {
+		return nil, fmt.Errorf("range from cannot be greater than to")
+	}
+	if end-start >= maxGeneratedUnits {
+		return nil, fmt.Errorf("range cannot have more than %d values", maxGeneratedUnits)
+	}
+	values := make([]string, 0, end-start+1)
+	for i := start; i <= end; i++ {
+		values = append(values, format(i))
+	}
+	return values, nil
+}
+
+func isRangeLetter(s string) bool {
+	return len(s) == 1 && (isUpperLetter(s[0]) || (s[0] >= 'a' && s[0] <= 'z'))
+}
+
+func isUpperLetter(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
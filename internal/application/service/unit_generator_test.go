@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+)
+
+func TestExpandGeneratorValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   dto.UnitGeneratorLevel
+		want    []string
+		message string
+	}{
+		{name: "numeric range", level: dto.UnitGeneratorLevel{Range: &dto.UnitGeneratorRange{From: "9", To: "11"}}, want: []string{"9", "10", "11"}},
+		{name: "letter range", level: dto.UnitGeneratorLevel{Range: &dto.UnitGeneratorRange{From: "a", To: "c"}}, want: []string{"a", "b", "c"}},
+		{name: "explicit values", level: dto.UnitGeneratorLevel{Values: []string{" Mañana ", "Tarde"}}, want: []string{"Mañana", "Tarde"}},
+		{name: "mixed range", level: dto.UnitGeneratorLevel{Range: &dto.UnitGeneratorRange{From: "1", To: "D"}}, message: "numbers or letters"},
+		{name: "mixed case letters", level: dto.UnitGeneratorLevel{Range: &dto.UnitGeneratorRange{From: "a", To: "D"}}, message: "same case"},
+		{name: "reversed range", level: dto.UnitGeneratorLevel{Range: &dto.UnitGeneratorRange{From: "D", To: "A"}}, message: "greater than"},
+		{name: "oversized range", level: dto.UnitGeneratorLevel{Range: &dto.UnitGeneratorRange{From: "1", To: "100000"}}, message: "more than 500 values"},
+		{name: "duplicate values", level: dto.UnitGeneratorLevel{Values: []string{"A", "A"}}, message: "duplicate value A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := expandGeneratorValues(tt.level)
+			if tt.message != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.message)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, values)
+		})
+	}
+}
+
+func TestNewUnitGenerator_LimitsGeneratedUnits(t *testing.T) {
+	_, err := newUnitGenerator([]dto.UnitGeneratorLevel{
+		{Type: "grade", Range: &dto.UnitGeneratorRange{From: "1", To: "30"}, NameTemplate: "Grado {value}"},
+		{Type: "section", Range: &dto.UnitGeneratorRange{From: "1", To: "20"}, NameTemplate: "Sección {value}"},
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "more than 500 units")
+}
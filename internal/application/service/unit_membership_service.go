@@ -25,20 +25,38 @@ type UnitMembershipService interface {
 }
 
 type unitMembershipService struct {
-	membershipRepo repository.UnitMembershipRepository
-	unitRepo       repository.AcademicUnitRepository
-	logger         logger.Logger
+	membershipRepo  repository.UnitMembershipRepository
+	unitRepo        repository.AcademicUnitRepository
+	schoolRepo      repository.SchoolRepository
+	periodRepo      repository.AcademicPeriodRepository
+	unitTypeRepo    repository.SchoolUnitTypeRepository
+	quotaService    SchoolQuotaService
+	capacityService UnitCapacityService
+	txManager       repository.TransactionManager
+	logger          logger.Logger
 }
 
 func NewUnitMembershipService(
 	membershipRepo repository.UnitMembershipRepository,
 	unitRepo repository.AcademicUnitRepository,
+	schoolRepo repository.SchoolRepository,
+	periodRepo repository.AcademicPeriodRepository,
+	unitTypeRepo repository.SchoolUnitTypeRepository,
+	quotaService SchoolQuotaService,
+	capacityService UnitCapacityService,
+	txManager repository.TransactionManager,
 	logger logger.Logger,
 ) UnitMembershipService {
 	return &unitMembershipService{
-		membershipRepo: membershipRepo,
-		unitRepo:       unitRepo,
-		logger:         logger,
+		membershipRepo:  membershipRepo,
+		unitRepo:        unitRepo,
+		schoolRepo:      schoolRepo,
+		periodRepo:      periodRepo,
+		unitTypeRepo:    unitTypeRepo,
+		quotaService:    quotaService,
+		capacityService: capacityService,
+		txManager:       txManager,
+		logger:          logger,
 	}
 }
 
@@ -62,6 +80,12 @@ func (s *unitMembershipService) CreateMembership(ctx context.Context, req dto.Cr
 	if unit == nil {
 		return nil, errors.NewNotFoundError("academic unit")
 	}
+	if err := ensureSchoolWritable(ctx, s.schoolRepo, unit.SchoolID); err != nil {
+		return nil, err
+	}
+	if err := ensureAcademicYearWritable(ctx, s.periodRepo, unit.SchoolID, unit.AcademicYear); err != nil {
+		return nil, err
+	}
 
 	// Verificar que no existe membresía activa
 	exists, err := s.membershipRepo.ExistsByUnitAndUser(ctx, unitID, userID)
@@ -76,6 +100,10 @@ func (s *unitMembershipService) CreateMembership(ctx context.Context, req dto.Cr
 	if _, err := valueobject.ParseMembershipRole(req.Role); err != nil {
 		return nil, errors.NewValidationError(err.Error())
 	}
+	// Los tipos de unidad propios pueden restringir los roles
+	if err := checkUnitTypeRole(ctx, s.unitTypeRepo, unit, req.Role); err != nil {
+		return nil, err
+	}
 
 	// Crear entidad
 	now := time.Now()
@@ -98,9 +126,42 @@ func (s *unitMembershipService) CreateMembership(ctx context.Context, req dto.Cr
 		UpdatedAt:      now,
 	}
 
-	// Persistir
-	if err := s.membershipRepo.Create(ctx, membership); err != nil {
-		return nil, errors.NewDatabaseError("create membership", err)
+	// Los estudiantes ocupan un lugar del cupo de la unidad; sin lugar pueden quedar en espera
+	var waitlisted *dto.WaitlistEntryResponse
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if req.Role == string(valueobject.RoleStudent) {
+			entry, err := s.capacityService.ReserveSeat(ctx, unit.SchoolID, unitID, userID, true)
+			if err != nil {
+				return err
+			}
+			if entry != nil {
+				waitlisted = entry
+				return nil
+			}
+		}
+		// Validar cupo del plan de la escuela; el lugar de la unidad se bloquea antes,
+		// en el mismo orden que la promoción de la lista de espera
+		if err := s.quotaService.CheckMembershipQuota(ctx, unit.SchoolID, userID, req.Role); err != nil {
+			return err
+		}
+		if err := s.membershipRepo.Create(ctx, membership); err != nil {
+			return errors.NewDatabaseError("create membership", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if waitlisted != nil {
+		return &dto.MembershipResponse{
+			UnitID:     unitID.String(),
+			UserID:     userID.String(),
+			Role:       req.Role,
+			EnrolledAt: enrolledAt,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Waitlist:   waitlisted,
+		}, nil
 	}
 
 	s.logger.Info("entity created",
@@ -220,13 +281,22 @@ func (s *unitMembershipService) UpdateMembership(ctx context.Context, id string,
 	if membership == nil {
 		return nil, errors.NewNotFoundError("membership")
 	}
+	if err := s.ensureMembershipWritable(ctx, membership); err != nil {
+		return nil, err
+	}
 
 	// Actualizar campos
+	previousRole := membership.Role
 	if req.Role != nil {
 		// Validar rol usando value object
 		if _, err := valueobject.ParseMembershipRole(*req.Role); err != nil {
 			return nil, errors.NewValidationError(err.Error())
 		}
+		if *req.Role != membership.Role {
+			if err := s.checkMembershipUnitRole(ctx, membership, *req.Role); err != nil {
+				return nil, err
+			}
+		}
 		membership.Role = *req.Role
 	}
 	if req.ValidUntil != nil {
@@ -235,8 +305,32 @@ func (s *unitMembershipService) UpdateMembership(ctx context.Context, id string,
 
 	membership.UpdatedAt = time.Now()
 
-	if err := s.membershipRepo.Update(ctx, membership); err != nil {
-		return nil, errors.NewDatabaseError("update membership", err)
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Pasar a estudiante requiere un lugar libre; dejar de serlo libera uno
+		if membership.AcademicUnitID != nil && membership.Role != previousRole {
+			if membership.Role == string(valueobject.RoleStudent) {
+				if _, err := s.capacityService.ReserveSeat(ctx, membership.SchoolID, *membership.AcademicUnitID, membership.UserID, false); err != nil {
+					return err
+				}
+			}
+		}
+		if membership.Role != previousRole {
+			if err := s.quotaService.CheckMembershipQuota(ctx, membership.SchoolID, membership.UserID, membership.Role); err != nil {
+				return err
+			}
+		}
+		if err := s.membershipRepo.Update(ctx, membership); err != nil {
+			return errors.NewDatabaseError("update membership", err)
+		}
+		if membership.AcademicUnitID != nil && previousRole == string(valueobject.RoleStudent) && membership.Role != previousRole {
+			if _, err := s.capacityService.FillFreedSeats(ctx, *membership.AcademicUnitID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	updatedFields := []string{}
@@ -275,13 +369,22 @@ func (s *unitMembershipService) ExpireMembership(ctx context.Context, id string)
 	if membership == nil {
 		return errors.NewNotFoundError("membership")
 	}
+	if err := s.ensureMembershipWritable(ctx, membership); err != nil {
+		return err
+	}
 
 	now := time.Now()
 	membership.WithdrawnAt = &now
 	membership.UpdatedAt = now
 
-	if err := s.membershipRepo.Update(ctx, membership); err != nil {
-		return errors.NewDatabaseError("expire membership", err)
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.membershipRepo.Update(ctx, membership); err != nil {
+			return errors.NewDatabaseError("expire membership", err)
+		}
+		return s.fillFreedSeat(ctx, membership)
+	})
+	if err != nil {
+		return err
 	}
 
 	s.logger.Info("membership expired",
@@ -298,8 +401,28 @@ func (s *unitMembershipService) DeleteMembership(ctx context.Context, id string)
 		return errors.NewValidationError("invalid membership ID")
 	}
 
-	if err := s.membershipRepo.Delete(ctx, membershipID); err != nil {
-		return errors.NewDatabaseError("delete membership", err)
+	membership, err := s.membershipRepo.FindByID(ctx, membershipID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return errors.NewNotFoundError("membership")
+		}
+		return errors.NewDatabaseError("find membership", err)
+	}
+	if membership == nil {
+		return errors.NewNotFoundError("membership")
+	}
+	if err := s.ensureMembershipWritable(ctx, membership); err != nil {
+		return err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.membershipRepo.Delete(ctx, membershipID); err != nil {
+			return errors.NewDatabaseError("delete membership", err)
+		}
+		return s.fillFreedSeat(ctx, membership)
+	})
+	if err != nil {
+		return err
 	}
 
 	s.logger.Info("entity deleted",
@@ -310,6 +433,54 @@ func (s *unitMembershipService) DeleteMembership(ctx context.Context, id string)
 	return nil
 }
 
+// ensureMembershipWritable rechaza cambios sobre membresías de una escuela archivada o de unidades de un año lectivo cerrado
+func (s *unitMembershipService) ensureMembershipWritable(ctx context.Context, membership *entities.Membership) error {
+	if err := ensureSchoolWritable(ctx, s.schoolRepo, membership.SchoolID); err != nil {
+		return err
+	}
+	if membership.AcademicUnitID == nil {
+		return nil
+	}
+	unit, err := s.unitRepo.FindByID(ctx, *membership.AcademicUnitID, true)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return errors.NewDatabaseError("find unit", err)
+	}
+	if unit == nil {
+		return nil
+	}
+	return ensureAcademicYearWritable(ctx, s.periodRepo, unit.SchoolID, unit.AcademicYear)
+}
+
+// checkMembershipUnitRole valida el rol contra los roles permitidos por el tipo de la unidad de la membresía
+func (s *unitMembershipService) checkMembershipUnitRole(ctx context.Context, membership *entities.Membership, role string) error {
+	if membership.AcademicUnitID == nil {
+		return nil
+	}
+	unit, err := s.unitRepo.FindByID(ctx, *membership.AcademicUnitID, true)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return errors.NewDatabaseError("find unit", err)
+	}
+	if unit == nil {
+		return nil
+	}
+	return checkUnitTypeRole(ctx, s.unitTypeRepo, unit, role)
+}
+
+// fillFreedSeat promueve la lista de espera de la unidad cuando la membresía de un estudiante libera su lugar
+func (s *unitMembershipService) fillFreedSeat(ctx context.Context, membership *entities.Membership) error {
+	if membership.AcademicUnitID == nil || membership.Role != string(valueobject.RoleStudent) {
+		return nil
+	}
+	_, err := s.capacityService.FillFreedSeats(ctx, *membership.AcademicUnitID)
+	return err
+}
+
 // filterActiveMemberships filtra membresías para retornar solo las activas
 func filterActiveMemberships(memberships []*entities.Membership) []*entities.Membership {
 	result := make([]*entities.Membership, 0, len(memberships))
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockUnitMembershipRepository mock implementation
+type MockUnitMembershipRepository struct {
+	mock.Mock
+}
+
+func (m *MockUnitMembershipRepository) Create(ctx context.Context, membership *entities.Membership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockUnitMembershipRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Membership, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Membership), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) FindByUserAndUnit(ctx context.Context, userID, unitID uuid.UUID) (*entities.Membership, error) {
+	args := m.Called(ctx, userID, unitID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Membership), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]*entities.Membership, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Membership), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) FindByUnit(ctx context.Context, unitID uuid.UUID) ([]*entities.Membership, error) {
+	args := m.Called(ctx, unitID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Membership), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) FindByUnitAndRole(ctx context.Context, unitID uuid.UUID, role string, activeOnly bool) ([]*entities.Membership, error) {
+	args := m.Called(ctx, unitID, role, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Membership), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) Update(ctx context.Context, membership *entities.Membership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockUnitMembershipRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUnitMembershipRepository) ExistsByUnitAndUser(ctx context.Context, unitID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, unitID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) FindByUserAndSchool(ctx context.Context, userID, schoolID uuid.UUID) (*entities.Membership, error) {
+	args := m.Called(ctx, userID, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Membership), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) ReassignUser(ctx context.Context, membershipID, userID uuid.UUID) error {
+	args := m.Called(ctx, membershipID, userID)
+	return args.Error(0)
+}
+
+func (m *MockUnitMembershipRepository) LockSchoolQuota(ctx context.Context, schoolID uuid.UUID) error {
+	args := m.Called(ctx, schoolID)
+	return args.Error(0)
+}
+
+func (m *MockUnitMembershipRepository) CountActiveUsersBySchoolAndRole(ctx context.Context, schoolID uuid.UUID, role string) (int, error) {
+	args := m.Called(ctx, schoolID, role)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) FindActiveBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entities.Membership, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Membership), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) FindActiveByUnits(ctx context.Context, unitIDs []uuid.UUID) ([]*entities.Membership, error) {
+	args := m.Called(ctx, unitIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Membership), args.Error(1)
+}
+
+func (m *MockUnitMembershipRepository) ListByUnit(ctx context.Context, unitID uuid.UUID, filters repository.MembershipListFilters) ([]*entities.Membership, error) {
+	args := m.Called(ctx, unitID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Membership), args.Error(1)
+}
+
+// Tests
+
+func TestExpireMembership_Success(t *testing.T) {
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	service := NewUnitMembershipService(mockMembershipRepo, nil, liveSchools(), nil, nil, nil, noUnitCapacities(), passthroughTxManager{}, newTestLogger())
+
+	membership := &entities.Membership{ID: uuid.New(), UserID: uuid.New(), Role: "student", IsActive: true, EnrolledAt: time.Now()}
+
+	mockMembershipRepo.On("FindByID", mock.Anything, membership.ID).Return(membership, nil)
+	mockMembershipRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *entities.Membership) bool {
+		return m.WithdrawnAt != nil
+	})).Return(nil)
+
+	err := service.ExpireMembership(context.Background(), membership.ID.String())
+
+	require.NoError(t, err)
+	mockMembershipRepo.AssertExpectations(t)
+}
+
+func TestExpireMembership_NotFound(t *testing.T) {
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	service := NewUnitMembershipService(mockMembershipRepo, nil, liveSchools(), nil, nil, nil, noUnitCapacities(), passthroughTxManager{}, newTestLogger())
+
+	id := uuid.New()
+	mockMembershipRepo.On("FindByID", mock.Anything, id).Return(nil, nil)
+
+	err := service.ExpireMembership(context.Background(), id.String())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	mockMembershipRepo.AssertNotCalled(t, "Update")
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+)
+
+// UnitTreeTemplate es un árbol de unidades académicas reutilizable para el onboarding de escuelas
+type UnitTreeTemplate struct {
+	Name        string             `mapstructure:"name"`
+	DisplayName string             `mapstructure:"display_name"`
+	Description string             `mapstructure:"description"`
+	Units       []UnitTemplateNode `mapstructure:"units"`
+}
+
+// UnitTemplateNode es una unidad de la plantilla con sus hijas
+type UnitTemplateNode struct {
+	Type        string             `mapstructure:"type"`
+	Name        string             `mapstructure:"name"`
+	Code        string             `mapstructure:"code"`
+	Level       string             `mapstructure:"level"`
+	Description string             `mapstructure:"description"`
+	Children    []UnitTemplateNode `mapstructure:"children"`
+}
+
+// UnitCount retorna el total de unidades que crea la plantilla
+func (t *UnitTreeTemplate) UnitCount() int {
+	var count func(nodes []UnitTemplateNode) int
+	count = func(nodes []UnitTemplateNode) int {
+		total := len(nodes)
+		for _, n := range nodes {
+			total += count(n.Children)
+		}
+		return total
+	}
+	return count(t.Units)
+}
+
+// Validate verifica tipos, nombres y unicidad de códigos dentro de la plantilla
+func (t *UnitTreeTemplate) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("template name is required")
+	}
+	if len(t.Units) == 0 {
+		return fmt.Errorf("template %s has no units", t.Name)
+	}
+
+	codes := make(map[string]bool)
+	var walk func(nodes []UnitTemplateNode, path string) error
+	walk = func(nodes []UnitTemplateNode, path string) error {
+		for i, n := range nodes {
+			where := fmt.Sprintf("%s[%d]", path, i)
+			if _, err := valueobject.ParseUnitType(n.Type); err != nil {
+				return fmt.Errorf("template %s %s: %w", t.Name, where, err)
+			}
+			if strings.TrimSpace(n.Name) == "" {
+				return fmt.Errorf("template %s %s: name is required", t.Name, where)
+			}
+			if n.Code != "" {
+				if codes[n.Code] {
+					return fmt.Errorf("template %s %s: duplicate code %s", t.Name, where, n.Code)
+				}
+				codes[n.Code] = true
+			}
+			if err := walk(n.Children, where+".children"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(t.Units, "units")
+}
+
+// LoadUnitTreeTemplates carga las plantillas .yaml, .yml y .json del directorio.
+// Un directorio inexistente no es error: simplemente no hay plantillas.
+func LoadUnitTreeTemplates(dir string) (map[string]*UnitTreeTemplate, error) {
+	templates := make(map[string]*UnitTreeTemplate)
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return templates, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read templates dir: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		template, err := loadUnitTreeTemplate(file)
+		if err != nil {
+			return nil, err
+		}
+		if templates[template.Name] != nil {
+			return nil, fmt.Errorf("duplicate template name %s in %s", template.Name, file)
+		}
+		templates[template.Name] = template
+	}
+	return templates, nil
+}
+
+func loadUnitTreeTemplate(file string) (*UnitTreeTemplate, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read template %s: %w", file, err)
+	}
+
+	template := &UnitTreeTemplate{}
+	if err := v.Unmarshal(template); err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", file, err)
+	}
+	if template.Name == "" {
+		template.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
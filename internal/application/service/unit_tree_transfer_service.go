@@ -0,0 +1,742 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// UnitTreeTransferService exporta e importa el árbol de unidades de una escuela como JSON o YAML,
+// para versionar la estructura y llevarla de un ambiente a otro
+type UnitTreeTransferService interface {
+	// ExportUnitTree escribe el árbol de unidades del año lectivo, con sus membresías activas si se piden
+	ExportUnitTree(ctx context.Context, schoolID string, req dto.ExportUnitTreeRequest, w io.Writer) error
+	// ImportUnitTree aplica el documento como upsert por código de unidad; con dry_run solo informa las diferencias
+	ImportUnitTree(ctx context.Context, schoolID string, req dto.ImportUnitTreeRequest, r io.Reader, importedBy string) (*dto.UnitTreeImportResponse, error)
+}
+
+type unitTreeTransferService struct {
+	schoolRepo        repository.SchoolRepository
+	unitRepo          repository.AcademicUnitRepository
+	periodRepo        repository.AcademicPeriodRepository
+	membershipRepo    repository.UnitMembershipRepository
+	userRepo          repository.UserRepository
+	unitTypeRepo      repository.SchoolUnitTypeRepository
+	settingsService   SchoolSettingsService
+	unitService       AcademicUnitService
+	membershipService UnitMembershipService
+	quotaService      SchoolQuotaService
+	txManager         repository.TransactionManager
+	logger            logger.Logger
+}
+
+// NewUnitTreeTransferService crea un nuevo UnitTreeTransferService
+func NewUnitTreeTransferService(
+	schoolRepo repository.SchoolRepository,
+	unitRepo repository.AcademicUnitRepository,
+	periodRepo repository.AcademicPeriodRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	userRepo repository.UserRepository,
+	unitTypeRepo repository.SchoolUnitTypeRepository,
+	settingsService SchoolSettingsService,
+	unitService AcademicUnitService,
+	membershipService UnitMembershipService,
+	quotaService SchoolQuotaService,
+	txManager repository.TransactionManager,
+	logger logger.Logger,
+) UnitTreeTransferService {
+	return &unitTreeTransferService{
+		schoolRepo:        schoolRepo,
+		unitRepo:          unitRepo,
+		periodRepo:        periodRepo,
+		membershipRepo:    membershipRepo,
+		userRepo:          userRepo,
+		unitTypeRepo:      unitTypeRepo,
+		settingsService:   settingsService,
+		unitService:       unitService,
+		membershipService: membershipService,
+		quotaService:      quotaService,
+		txManager:         txManager,
+		logger:            logger,
+	}
+}
+
+// ==================== EXPORT ====================
+
+func (s *unitTreeTransferService) ExportUnitTree(ctx context.Context, schoolID string, req dto.ExportUnitTreeRequest, w io.Writer) error {
+	format, err := dto.ParseUnitTreeFormat(req.Format)
+	if err != nil {
+		return err
+	}
+	school, err := s.findSchool(ctx, schoolID)
+	if err != nil {
+		return err
+	}
+	year, err := resolveAcademicYear(ctx, s.periodRepo, school.ID, req.AcademicYear)
+	if err != nil {
+		return err
+	}
+
+	units, err := s.unitRepo.FindBySchoolID(ctx, school.ID, false)
+	if err != nil {
+		return errors.NewDatabaseError("find units", err)
+	}
+	units = filterUnitsByAcademicYear(units, year)
+
+	byID := make(map[string]*entities.AcademicUnit, len(units))
+	for _, unit := range units {
+		byID[unit.ID.String()] = unit
+	}
+
+	users := newUserLookup(s.userRepo)
+	var convert func(nodes []*dto.UnitTreeNode) ([]*dto.UnitTreeDocumentNode, error)
+	convert = func(nodes []*dto.UnitTreeNode) ([]*dto.UnitTreeDocumentNode, error) {
+		result := make([]*dto.UnitTreeDocumentNode, 0, len(nodes))
+		for _, node := range nodes {
+			unit := byID[node.ID]
+			docNode := &dto.UnitTreeDocumentNode{
+				Code:        node.Code,
+				Type:        node.Type,
+				DisplayName: node.DisplayName,
+				Metadata:    unitMetadata(unit),
+			}
+			if unit.Description != nil {
+				docNode.Description = *unit.Description
+			}
+			if req.IncludeMemberships {
+				memberships, err := s.exportMemberships(ctx, unit.ID, users)
+				if err != nil {
+					return nil, err
+				}
+				docNode.Memberships = memberships
+			}
+			children, err := convert(node.Children)
+			if err != nil {
+				return nil, err
+			}
+			if len(children) > 0 {
+				docNode.Children = children
+			}
+			result = append(result, docNode)
+		}
+		return result, nil
+	}
+
+	nodes, err := convert(dto.BuildUnitTree(units))
+	if err != nil {
+		return err
+	}
+
+	exportedAt := time.Now().UTC()
+	doc := &dto.UnitTreeDocument{
+		Version:      dto.UnitTreeDocumentVersion,
+		AcademicYear: year,
+		ExportedAt:   &exportedAt,
+		Units:        nodes,
+	}
+	if err := encodeUnitTreeDocument(w, format, doc); err != nil {
+		return err
+	}
+
+	s.logger.Info("entities exported",
+		"entity_type", "academic_unit_tree",
+		"school_id", schoolID,
+		"academic_year", year,
+		"format", format,
+		"rows", len(units),
+	)
+	return nil
+}
+
+// exportMemberships lista las membresías activas de la unidad identificando a los usuarios por email
+func (s *unitTreeTransferService) exportMemberships(ctx context.Context, unitID uuid.UUID, users *userLookup) ([]dto.UnitTreeDocumentMembership, error) {
+	memberships, err := s.membershipRepo.FindByUnit(ctx, unitID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find memberships", err)
+	}
+
+	var result []dto.UnitTreeDocumentMembership
+	for _, membership := range memberships {
+		if !membership.IsActive || membership.WithdrawnAt != nil {
+			continue
+		}
+		user, err := users.get(ctx, membership.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			continue
+		}
+		result = append(result, dto.UnitTreeDocumentMembership{Email: user.Email, Role: membership.Role})
+	}
+	return result, nil
+}
+
+// ==================== IMPORT ====================
+
+// unitTreeImportStep es una unidad del documento: existente (se actualiza si cambió) o nueva
+type unitTreeImportStep struct {
+	node   *dto.UnitTreeDocumentNode
+	parent *unitTreeImportStep // nil = raíz
+	unit   *entities.AcademicUnit
+	fields []string
+	change *dto.UnitTreeChange // nil si no cambia
+}
+
+// unitTreeMembershipStep es una membresía del documento que hay que crear o cambiar de rol
+type unitTreeMembershipStep struct {
+	step       *unitTreeImportStep
+	user       *entities.User
+	membership *entities.Membership // activa con otro rol; nil = se crea
+	change     *dto.UnitTreeMembershipChange
+}
+
+// unitTreeImportPlan son las diferencias entre el documento y las unidades del año lectivo
+type unitTreeImportPlan struct {
+	steps       []*unitTreeImportStep // en preorden: los padres antes que sus hijos
+	deletes     []*entities.AcademicUnit
+	memberships []*unitTreeMembershipStep
+	response    *dto.UnitTreeImportResponse
+}
+
+func (s *unitTreeTransferService) ImportUnitTree(ctx context.Context, schoolID string, req dto.ImportUnitTreeRequest, r io.Reader, importedBy string) (*dto.UnitTreeImportResponse, error) {
+	format, err := dto.ParseUnitTreeFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decodeUnitTreeDocument(r, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Una escuela archivada es de solo lectura
+	schoolUUID, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	if err := ensureSchoolWritable(ctx, s.schoolRepo, schoolUUID); err != nil {
+		return nil, err
+	}
+	school, err := s.findSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	// El año del documento es informativo: el destino es el solicitado o el activo de la escuela
+	year, err := resolveAcademicYear(ctx, s.periodRepo, school.ID, req.AcademicYear)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDocumentNesting(ctx, school.ID, doc); err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		plan, err := s.planImport(ctx, school.ID, year, doc, req.Prune)
+		if err != nil {
+			return nil, err
+		}
+		plan.response.DryRun = true
+		return plan.response, nil
+	}
+
+	var plan *unitTreeImportPlan
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.unitRepo.LockHierarchy(ctx, school.ID); err != nil {
+			return errors.NewDatabaseError("lock unit hierarchy", err)
+		}
+		plan, err = s.planImport(ctx, school.ID, year, doc, req.Prune)
+		if err != nil {
+			return err
+		}
+		return s.applyImport(ctx, school.ID, year, plan, importedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("academic unit tree imported",
+		"school_id", schoolID,
+		"academic_year", year,
+		"created", len(plan.response.Creates),
+		"updated", len(plan.response.Updates),
+		"deleted", len(plan.response.Deletes),
+		"memberships", len(plan.memberships),
+		"imported_by", importedBy,
+	)
+	return plan.response, nil
+}
+
+// checkDocumentNesting valida los tipos del documento y sus reglas de anidamiento sobre el árbol final
+func (s *unitTreeTransferService) checkDocumentNesting(ctx context.Context, schoolID uuid.UUID, doc *dto.UnitTreeDocument) error {
+	custom, err := loadSchoolUnitTypes(ctx, s.unitTypeRepo, schoolID)
+	if err != nil {
+		return err
+	}
+	rules, err := s.settingsService.GetUnitNestingRules(ctx, schoolID)
+	if err != nil {
+		return err
+	}
+	registry, rules := custom.registry(), custom.applyNesting(rules)
+
+	var walk func(nodes []*dto.UnitTreeDocumentNode, parent *valueobject.UnitType, depth int) error
+	walk = func(nodes []*dto.UnitTreeDocumentNode, parent *valueobject.UnitType, depth int) error {
+		for _, node := range nodes {
+			unitType, err := registry.Parse(node.Type)
+			if err != nil {
+				return errors.NewValidationError(fmt.Sprintf("unit %s: %s", node.Code, err.Error()))
+			}
+			if parent == nil && !rules.CanBeRoot(unitType) {
+				return errors.NewBusinessRuleError(fmt.Sprintf("unit %s: a %s must have a parent unit", node.Code, unitType))
+			}
+			if parent != nil && !rules.CanNest(unitType, *parent) {
+				return errors.NewBusinessRuleError(fmt.Sprintf("unit %s: a %s cannot be placed under a %s", node.Code, unitType, *parent))
+			}
+			if !rules.AllowsDepth(depth) {
+				return errors.NewBusinessRuleError(fmt.Sprintf("unit hierarchy cannot be deeper than %d levels", rules.MaxDepth))
+			}
+			if err := walk(node.Children, &unitType, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(doc.Units, nil, 1)
+}
+
+// planImport compara el documento con las unidades de la escuela: las unidades se emparejan por código
+func (s *unitTreeTransferService) planImport(ctx context.Context, schoolID uuid.UUID, year int, doc *dto.UnitTreeDocument, prune bool) (*unitTreeImportPlan, error) {
+	units, err := s.unitRepo.FindBySchoolID(ctx, schoolID, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find units", err)
+	}
+	byID := make(map[uuid.UUID]*entities.AcademicUnit, len(units))
+	byCode := make(map[string]*entities.AcademicUnit, len(units))
+	for _, unit := range units {
+		byID[unit.ID] = unit
+		if unit.Code != "" {
+			byCode[unit.Code] = unit
+		}
+	}
+
+	plan := &unitTreeImportPlan{
+		response: &dto.UnitTreeImportResponse{
+			AcademicYear: year,
+			Creates:      []dto.UnitTreeChange{},
+			Updates:      []dto.UnitTreeChange{},
+			Deletes:      []dto.UnitTreeChange{},
+		},
+	}
+	users := make(map[string]*entities.User)
+	imported := make(map[uuid.UUID]bool)
+
+	var walk func(nodes []*dto.UnitTreeDocumentNode, parent *unitTreeImportStep) error
+	walk = func(nodes []*dto.UnitTreeDocumentNode, parent *unitTreeImportStep) error {
+		for _, node := range nodes {
+			step := &unitTreeImportStep{node: node, parent: parent, unit: byCode[node.Code]}
+			if err := planUnitStep(step, year); err != nil {
+				return err
+			}
+			if step.unit != nil {
+				imported[step.unit.ID] = true
+			}
+			plan.steps = append(plan.steps, step)
+
+			switch {
+			case step.unit == nil:
+				plan.response.Creates = append(plan.response.Creates, *step.change)
+			case step.change != nil:
+				plan.response.Updates = append(plan.response.Updates, *step.change)
+			default:
+				plan.response.Unchanged++
+			}
+
+			for _, membership := range node.Memberships {
+				membershipStep, err := s.planMembership(ctx, step, membership, users)
+				if err != nil {
+					return err
+				}
+				if membershipStep != nil {
+					plan.memberships = append(plan.memberships, membershipStep)
+				}
+			}
+
+			if err := walk(node.Children, step); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(doc.Units, nil); err != nil {
+		return nil, err
+	}
+
+	if prune {
+		if err := planPrune(plan, units, byID, imported, year); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, membership := range plan.memberships {
+		plan.response.Memberships = append(plan.response.Memberships, *membership.change)
+	}
+	return plan, nil
+}
+
+// planPrune agrega al plan las unidades del año que el documento no menciona. Solo se eliminan
+// unidades de ese mismo año (las que no tienen año tampoco): si el subárbol de una unidad a eliminar
+// tiene unidades de otro año o sin año, la importación se rechaza porque el borrado las arrastraría.
+// Cada unidad que cae en cascada queda listada bajo su ancestro.
+func planPrune(plan *unitTreeImportPlan, units []*entities.AcademicUnit, byID map[uuid.UUID]*entities.AcademicUnit, imported map[uuid.UUID]bool, year int) error {
+	children := make(map[uuid.UUID][]*entities.AcademicUnit, len(units))
+	for _, unit := range units {
+		if unit.ParentUnitID != nil {
+			children[*unit.ParentUnitID] = append(children[*unit.ParentUnitID], unit)
+		}
+	}
+	pruned := func(unit *entities.AcademicUnit) bool {
+		return unit.AcademicYear == year && !imported[unit.ID]
+	}
+
+	// Las unidades del documento se mueven antes de eliminar, así que no cuentan como subárbol
+	var collect func(root, unit *entities.AcademicUnit) error
+	collect = func(root, unit *entities.AcademicUnit) error {
+		plan.deletes = append(plan.deletes, unit)
+		change := dto.UnitTreeChange{
+			UnitID:      unit.ID.String(),
+			Code:        unit.Code,
+			Type:        unit.Type,
+			DisplayName: unit.Name,
+		}
+		if unit.ParentUnitID != nil && byID[*unit.ParentUnitID] != nil {
+			change.ParentCode = byID[*unit.ParentUnitID].Code
+		}
+		plan.response.Deletes = append(plan.response.Deletes, change)
+
+		for _, child := range children[unit.ID] {
+			if imported[child.ID] {
+				continue
+			}
+			if !pruned(child) {
+				return errors.NewBusinessRuleError(fmt.Sprintf("unit %s cannot be pruned: unit %s below it belongs to another academic year", root.Code, child.Code))
+			}
+			if err := collect(root, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, unit := range units {
+		if !pruned(unit) {
+			continue
+		}
+		// Las que cuelgan de otra unidad eliminada se listan con su ancestro
+		if unit.ParentUnitID != nil && byID[*unit.ParentUnitID] != nil && pruned(byID[*unit.ParentUnitID]) {
+			continue
+		}
+		if err := collect(unit, unit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planUnitStep calcula qué cambia en la unidad del paso respecto del documento
+func planUnitStep(step *unitTreeImportStep, year int) error {
+	node, unit := step.node, step.unit
+	parentCode := ""
+	if step.parent != nil {
+		parentCode = step.parent.node.Code
+	}
+	change := &dto.UnitTreeChange{
+		Code:        node.Code,
+		Type:        node.Type,
+		DisplayName: node.DisplayName,
+		ParentCode:  parentCode,
+	}
+
+	if unit == nil {
+		step.change = change
+		return nil
+	}
+	if unit.AcademicYear != 0 && unit.AcademicYear != year {
+		return errors.NewBusinessRuleError(fmt.Sprintf("unit %s belongs to academic year %d", unit.Code, unit.AcademicYear))
+	}
+	if unit.Type != node.Type {
+		return errors.NewBusinessRuleError(fmt.Sprintf("unit %s cannot change its type from %s to %s", unit.Code, unit.Type, node.Type))
+	}
+
+	if unit.Name != node.DisplayName {
+		step.fields = append(step.fields, "display_name")
+	}
+	description := ""
+	if unit.Description != nil {
+		description = *unit.Description
+	}
+	if description != node.Description {
+		step.fields = append(step.fields, "description")
+	}
+	if !sameUnitMetadata(unitMetadata(unit), node.Metadata) {
+		step.fields = append(step.fields, "metadata")
+	}
+
+	// El padre cambia si el documento lo pone en otra unidad o bajo una unidad nueva
+	var parentID *uuid.UUID
+	parentIsNew := false
+	if step.parent != nil {
+		if step.parent.unit == nil {
+			parentIsNew = true
+		} else {
+			parentID = &step.parent.unit.ID
+		}
+	}
+	if parentIsNew || uuidPtrString(unit.ParentUnitID) != uuidPtrString(parentID) {
+		step.fields = append(step.fields, "parent")
+	}
+
+	if len(step.fields) > 0 {
+		change.UnitID = unit.ID.String()
+		change.Fields = step.fields
+		step.change = change
+	}
+	return nil
+}
+
+// planMembership decide si la membresía del documento se crea, cambia de rol o ya existe (nil)
+func (s *unitTreeTransferService) planMembership(ctx context.Context, step *unitTreeImportStep, membership dto.UnitTreeDocumentMembership, users map[string]*entities.User) (*unitTreeMembershipStep, error) {
+	change := &dto.UnitTreeMembershipChange{
+		UnitCode: step.node.Code,
+		Email:    membership.Email,
+		Role:     membership.Role,
+	}
+
+	user, cached := users[membership.Email]
+	if !cached {
+		var err error
+		user, err = s.userRepo.FindByEmail(ctx, membership.Email)
+		if err != nil && !isNotFoundError(err) {
+			return nil, errors.NewDatabaseError("find user", err)
+		}
+		users[membership.Email] = user
+	}
+	if user == nil {
+		change.Action = dto.UnitTreeMembershipSkip
+		change.Reason = "user not found"
+		return &unitTreeMembershipStep{step: step, change: change}, nil
+	}
+
+	membershipStep := &unitTreeMembershipStep{step: step, user: user, change: change}
+	if step.unit == nil {
+		change.Action = dto.UnitTreeMembershipCreate
+		return membershipStep, nil
+	}
+
+	existing, err := s.membershipRepo.FindByUserAndUnit(ctx, user.ID, step.unit.ID)
+	if err != nil && !isNotFoundError(err) {
+		return nil, errors.NewDatabaseError("find membership", err)
+	}
+	if existing == nil || !existing.IsActive || existing.WithdrawnAt != nil {
+		change.Action = dto.UnitTreeMembershipCreate
+		return membershipStep, nil
+	}
+	if existing.Role == membership.Role {
+		return nil, nil
+	}
+	change.Action = dto.UnitTreeMembershipUpdateRole
+	membershipStep.membership = existing
+	return membershipStep, nil
+}
+
+// applyImport aplica el plan: crea y actualiza en preorden, elimina lo que sobra y luego las membresías
+func (s *unitTreeTransferService) applyImport(ctx context.Context, schoolID uuid.UUID, year int, plan *unitTreeImportPlan, importedBy string) error {
+	// Las unidades que elimina el prune liberan cupo dentro de la misma transacción
+	if err := s.quotaService.CheckUnitQuota(ctx, schoolID, len(plan.response.Creates)-len(plan.response.Deletes)); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	creates, updates := 0, 0
+	for _, step := range plan.steps {
+		if step.change == nil {
+			continue
+		}
+		var parentID *uuid.UUID
+		if step.parent != nil {
+			parentID = &step.parent.unit.ID
+		}
+		metadata, err := json.Marshal(step.node.Metadata)
+		if err != nil {
+			return errors.NewValidationError(fmt.Sprintf("unit %s: invalid metadata", step.node.Code))
+		}
+		if step.node.Metadata == nil {
+			metadata = []byte("{}")
+		}
+		description := step.node.Description
+
+		if step.unit == nil {
+			step.unit = &entities.AcademicUnit{
+				ID:           uuid.New(),
+				ParentUnitID: parentID,
+				SchoolID:     schoolID,
+				Name:         step.node.DisplayName,
+				Code:         step.node.Code,
+				Type:         step.node.Type,
+				Description:  &description,
+				AcademicYear: year,
+				Metadata:     metadata,
+				IsActive:     true,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+			if err := s.unitRepo.Create(ctx, step.unit); err != nil {
+				return errors.NewDatabaseError("create unit", err)
+			}
+			plan.response.Creates[creates].UnitID = step.unit.ID.String()
+			creates++
+			continue
+		}
+
+		step.unit.Name = step.node.DisplayName
+		step.unit.Description = &description
+		step.unit.Metadata = metadata
+		step.unit.ParentUnitID = parentID
+		step.unit.UpdatedAt = now
+		if err := s.unitRepo.Update(ctx, step.unit); err != nil {
+			return errors.NewDatabaseError("update unit", err)
+		}
+		updates++
+	}
+
+	// Las unidades del documento ya no cuelgan de las que se eliminan: basta eliminar las de más arriba,
+	// el resto cae en el mismo batch de su ancestro
+	deleted := make(map[uuid.UUID]bool, len(plan.deletes))
+	for _, unit := range plan.deletes {
+		deleted[unit.ID] = true
+	}
+	for _, unit := range plan.deletes {
+		if unit.ParentUnitID != nil && deleted[*unit.ParentUnitID] {
+			continue
+		}
+		if _, err := s.unitService.DeleteUnit(ctx, unit.ID.String(), false, importedBy); err != nil {
+			return err
+		}
+	}
+
+	// Las membresías pasan por las validaciones de siempre: roles del tipo, cuota del plan y cupo de la unidad
+	for _, membership := range plan.memberships {
+		switch membership.change.Action {
+		case dto.UnitTreeMembershipCreate:
+			resp, err := s.membershipService.CreateMembership(ctx, dto.CreateMembershipRequest{
+				UnitID: membership.step.unit.ID.String(),
+				UserID: membership.user.ID.String(),
+				Role:   membership.change.Role,
+			})
+			if err != nil {
+				return err
+			}
+			if resp.Waitlist != nil {
+				membership.change.Action = dto.UnitTreeMembershipWaitlisted
+			}
+		case dto.UnitTreeMembershipUpdateRole:
+			role := membership.change.Role
+			if _, err := s.membershipService.UpdateMembership(ctx, membership.membership.ID.String(), dto.UpdateMembershipRequest{Role: &role}); err != nil {
+				return err
+			}
+		}
+	}
+	for i, membership := range plan.memberships {
+		plan.response.Memberships[i] = *membership.change
+	}
+	return nil
+}
+
+func (s *unitTreeTransferService) findSchool(ctx context.Context, schoolID string) (*entities.School, error) {
+	schoolUUID, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, schoolUUID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewNotFoundError("school")
+		}
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	return school, nil
+}
+
+// unitMetadata deserializa la metadata de la unidad (nil si está vacía)
+func unitMetadata(unit *entities.AcademicUnit) map[string]interface{} {
+	var metadata map[string]interface{}
+	if len(unit.Metadata) > 0 {
+		_ = json.Unmarshal(unit.Metadata, &metadata)
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// sameUnitMetadata compara la metadata normalizada como JSON, así los números de YAML y JSON coinciden
+func sameUnitMetadata(current, imported map[string]interface{}) bool {
+	if len(current) == 0 && len(imported) == 0 {
+		return true
+	}
+	raw, err := json.Marshal(imported)
+	if err != nil {
+		return false
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(current, normalized)
+}
+
+func encodeUnitTreeDocument(w io.Writer, format string, doc *dto.UnitTreeDocument) error {
+	if format == dto.UnitTreeFormatYAML {
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+func decodeUnitTreeDocument(r io.Reader, format string) (*dto.UnitTreeDocument, error) {
+	doc := &dto.UnitTreeDocument{}
+	var err error
+	if format == dto.UnitTreeFormatYAML {
+		err = yaml.NewDecoder(r).Decode(doc)
+	} else {
+		err = json.NewDecoder(r).Decode(doc)
+	}
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid %s document: %s", format, err.Error()))
+	}
+	return doc, nil
+}
@@ -0,0 +1,416 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockAcademicUnitService mock implementation
+type MockAcademicUnitService struct {
+	mock.Mock
+}
+
+func (m *MockAcademicUnitService) CreateUnit(ctx context.Context, schoolID string, req dto.CreateAcademicUnitRequest) (*dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, schoolID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AcademicUnitResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) GetUnit(ctx context.Context, id string) (*dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AcademicUnitResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) GetUnitTree(ctx context.Context, schoolID string, academicYear string) ([]*dto.UnitTreeNode, error) {
+	args := m.Called(ctx, schoolID, academicYear)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.UnitTreeNode), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) ListUnitsBySchool(ctx context.Context, schoolID string, includeDeleted bool, academicYear string) ([]dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, schoolID, includeDeleted, academicYear)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.AcademicUnitResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) ListUnitsByType(ctx context.Context, schoolID string, unitType string, academicYear string) ([]dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, schoolID, unitType, academicYear)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.AcademicUnitResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) UpdateUnit(ctx context.Context, id string, req dto.UpdateAcademicUnitRequest) (*dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AcademicUnitResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) BulkCreateUnits(ctx context.Context, schoolID string, req dto.BulkCreateUnitsRequest) (*dto.BulkCreateUnitsResponse, error) {
+	args := m.Called(ctx, schoolID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.BulkCreateUnitsResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) MoveUnit(ctx context.Context, id string, req dto.MoveAcademicUnitRequest) (*dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AcademicUnitResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) DeleteUnit(ctx context.Context, id string, dryRun bool, deletedBy string) (*dto.UnitDeletionResponse, error) {
+	args := m.Called(ctx, id, dryRun, deletedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UnitDeletionResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) RestoreUnit(ctx context.Context, id string, dryRun bool, restoredBy string) (*dto.UnitDeletionResponse, error) {
+	args := m.Called(ctx, id, dryRun, restoredBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UnitDeletionResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) GetHierarchyPath(ctx context.Context, id string) ([]dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.AcademicUnitResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) ListDescendants(ctx context.Context, id string, maxDepth int, includeDeleted bool) (*dto.UnitDescendantsResponse, error) {
+	args := m.Called(ctx, id, maxDepth, includeDeleted)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UnitDescendantsResponse), args.Error(1)
+}
+
+// MockUnitMembershipService mock implementation
+type MockUnitMembershipService struct {
+	mock.Mock
+}
+
+func (m *MockUnitMembershipService) CreateMembership(ctx context.Context, req dto.CreateMembershipRequest) (*dto.MembershipResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MembershipResponse), args.Error(1)
+}
+
+func (m *MockUnitMembershipService) GetMembership(ctx context.Context, id string) (*dto.MembershipResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MembershipResponse), args.Error(1)
+}
+
+func (m *MockUnitMembershipService) ListMembershipsByUnit(ctx context.Context, unitID string, activeOnly bool) ([]dto.MembershipResponse, error) {
+	args := m.Called(ctx, unitID, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.MembershipResponse), args.Error(1)
+}
+
+func (m *MockUnitMembershipService) ListMembershipsByUser(ctx context.Context, userID string, activeOnly bool) ([]dto.MembershipResponse, error) {
+	args := m.Called(ctx, userID, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.MembershipResponse), args.Error(1)
+}
+
+func (m *MockUnitMembershipService) ListMembershipsByRole(ctx context.Context, unitID string, role string, activeOnly bool) ([]dto.MembershipResponse, error) {
+	args := m.Called(ctx, unitID, role, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.MembershipResponse), args.Error(1)
+}
+
+func (m *MockUnitMembershipService) UpdateMembership(ctx context.Context, id string, req dto.UpdateMembershipRequest) (*dto.MembershipResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MembershipResponse), args.Error(1)
+}
+
+func (m *MockUnitMembershipService) ExpireMembership(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUnitMembershipService) DeleteMembership(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestExportUnitTree_WritesYAMLWithMemberships(t *testing.T) {
+	schoolID := uuid.New()
+	description := "Primaria mañana"
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 1", Code: "G1", Type: "grade", AcademicYear: 2026, Description: &description, Metadata: []byte(`{"shift":"morning"}`)}
+	section := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, ParentUnitID: &grade.ID, Name: "Sección A", Code: "G1A", Type: "section", AcademicYear: 2026, Metadata: []byte("{}")}
+	previousYear := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 1", Code: "G1-2025", Type: "grade", AcademicYear: 2025}
+
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockPeriodRepo := new(MockAcademicPeriodRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewUnitTreeTransferService(
+		mockSchoolRepo, mockUnitRepo, mockPeriodRepo, mockMembershipRepo, mockUserRepo, noCustomUnitTypes(), defaultNestingSettings(),
+		new(MockAcademicUnitService), new(MockUnitMembershipService),
+		newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger(),
+	)
+	mockSchoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	mockPeriodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, schoolID, false).Return([]*entities.AcademicUnit{grade, section, previousYear}, nil)
+
+	teacher := &entities.User{ID: uuid.New(), Email: "teacher@school.test"}
+	mockMembershipRepo.On("FindByUnit", mock.Anything, grade.ID).Return([]*entities.Membership{}, nil)
+	mockMembershipRepo.On("FindByUnit", mock.Anything, section.ID).Return([]*entities.Membership{
+		{ID: uuid.New(), UserID: teacher.ID, Role: "teacher", IsActive: true},
+		{ID: uuid.New(), UserID: uuid.New(), Role: "student", IsActive: false},
+	}, nil)
+	mockUserRepo.On("FindByID", mock.Anything, teacher.ID).Return(teacher, nil)
+
+	var buf bytes.Buffer
+	err := service.ExportUnitTree(context.Background(), schoolID.String(), dto.ExportUnitTreeRequest{Format: "yaml", IncludeMemberships: true}, &buf)
+
+	require.NoError(t, err)
+	var doc dto.UnitTreeDocument
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, dto.UnitTreeDocumentVersion, doc.Version)
+	assert.Equal(t, 2026, doc.AcademicYear)
+	require.Len(t, doc.Units, 1)
+	assert.Equal(t, "G1", doc.Units[0].Code)
+	assert.Equal(t, description, doc.Units[0].Description)
+	assert.Equal(t, map[string]interface{}{"shift": "morning"}, doc.Units[0].Metadata)
+	require.Len(t, doc.Units[0].Children, 1)
+	assert.Equal(t, "G1A", doc.Units[0].Children[0].Code)
+	assert.Nil(t, doc.Units[0].Children[0].Metadata)
+	assert.Equal(t, []dto.UnitTreeDocumentMembership{{Email: "teacher@school.test", Role: "teacher"}}, doc.Units[0].Children[0].Memberships)
+}
+
+const unitTreeImportDocument = `
+version: 1
+units:
+  - code: G1
+    type: grade
+    display_name: Grado 1
+    metadata:
+      shift: morning
+    children:
+      - code: G1A
+        type: section
+        display_name: Sección A
+      - code: G1B
+        type: section
+        display_name: Sección B
+`
+
+func TestImportUnitTree_DryRunReportsDiffWithoutChanges(t *testing.T) {
+	schoolID := uuid.New()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 1", Code: "G1", Type: "grade", AcademicYear: 2026, Metadata: []byte(`{"shift":"morning"}`)}
+	sectionA := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, ParentUnitID: &grade.ID, Name: "Seccion A", Code: "G1A", Type: "section", AcademicYear: 2026}
+	sectionC := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, ParentUnitID: &grade.ID, Name: "Sección C", Code: "G1C", Type: "section", AcademicYear: 2026}
+
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockPeriodRepo := new(MockAcademicPeriodRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockUnitService := new(MockAcademicUnitService)
+	service := NewUnitTreeTransferService(
+		mockSchoolRepo, mockUnitRepo, mockPeriodRepo, mockMembershipRepo, new(MockUserRepository), noCustomUnitTypes(), defaultNestingSettings(),
+		mockUnitService, new(MockUnitMembershipService),
+		newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger(),
+	)
+	mockSchoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	mockPeriodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, schoolID, false).Return([]*entities.AcademicUnit{grade, sectionA, sectionC}, nil)
+
+	resp, err := service.ImportUnitTree(context.Background(), schoolID.String(), dto.ImportUnitTreeRequest{Format: "yaml", DryRun: true, Prune: true}, strings.NewReader(unitTreeImportDocument), "admin")
+
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, 1, resp.Unchanged)
+	require.Len(t, resp.Creates, 1)
+	assert.Equal(t, dto.UnitTreeChange{Code: "G1B", Type: "section", DisplayName: "Sección B", ParentCode: "G1"}, resp.Creates[0])
+	require.Len(t, resp.Updates, 1)
+	assert.Equal(t, "G1A", resp.Updates[0].Code)
+	assert.Equal(t, []string{"display_name"}, resp.Updates[0].Fields)
+	require.Len(t, resp.Deletes, 1)
+	assert.Equal(t, sectionC.ID.String(), resp.Deletes[0].UnitID)
+	mockUnitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockUnitRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockUnitService.AssertNotCalled(t, "DeleteUnit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportUnitTree_AppliesUpsertAndPrunesTopMostUnits(t *testing.T) {
+	schoolID := uuid.New()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 1", Code: "G1", Type: "grade", AcademicYear: 2026, Metadata: []byte(`{"shift":"morning"}`)}
+	// G1A cuelga hoy de otro grado que el documento no menciona: se mueve antes de eliminar ese grado
+	oldGrade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 9", Code: "G9", Type: "grade", AcademicYear: 2026}
+	sectionA := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, ParentUnitID: &oldGrade.ID, Name: "Sección A", Code: "G1A", Type: "section", AcademicYear: 2026}
+	oldSection := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, ParentUnitID: &oldGrade.ID, Name: "Sección Z", Code: "G9Z", Type: "section", AcademicYear: 2026}
+
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockPeriodRepo := new(MockAcademicPeriodRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockUnitService := new(MockAcademicUnitService)
+	service := NewUnitTreeTransferService(
+		mockSchoolRepo, mockUnitRepo, mockPeriodRepo, mockMembershipRepo, new(MockUserRepository), noCustomUnitTypes(), defaultNestingSettings(),
+		mockUnitService, new(MockUnitMembershipService),
+		newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger(),
+	)
+	mockSchoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	mockPeriodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, schoolID, false).Return([]*entities.AcademicUnit{grade, oldGrade, sectionA, oldSection}, nil)
+
+	mockUnitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+	mockUnitRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.AcademicUnit) bool {
+		return u.ID == sectionA.ID && u.ParentUnitID != nil && *u.ParentUnitID == grade.ID
+	})).Return(nil).Once()
+	var created *entities.AcademicUnit
+	mockUnitRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*entities.AcademicUnit)
+	}).Return(nil).Once()
+	mockUnitService.On("DeleteUnit", mock.Anything, oldGrade.ID.String(), false, "admin").Return(&dto.UnitDeletionResponse{}, nil).Once()
+
+	resp, err := service.ImportUnitTree(context.Background(), schoolID.String(), dto.ImportUnitTreeRequest{Format: "yaml", Prune: true}, strings.NewReader(unitTreeImportDocument), "admin")
+
+	require.NoError(t, err)
+	assert.False(t, resp.DryRun)
+	require.NotNil(t, created)
+	assert.Equal(t, "G1B", created.Code)
+	assert.Equal(t, grade.ID, *created.ParentUnitID)
+	assert.Equal(t, 2026, created.AcademicYear)
+	require.Len(t, resp.Creates, 1)
+	assert.Equal(t, created.ID.String(), resp.Creates[0].UnitID)
+	require.Len(t, resp.Updates, 1)
+	assert.Equal(t, []string{"parent"}, resp.Updates[0].Fields)
+	assert.Len(t, resp.Deletes, 2)
+	mockUnitRepo.AssertExpectations(t)
+	mockUnitService.AssertExpectations(t)
+}
+
+func TestImportUnitTree_PruneListsCascadedUnitsAndKeepsUnitsWithoutYear(t *testing.T) {
+	schoolID := uuid.New()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 1", Code: "G1", Type: "grade", AcademicYear: 2026, Metadata: []byte(`{"shift":"morning"}`)}
+	sectionA := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, ParentUnitID: &grade.ID, Name: "Sección A", Code: "G1A", Type: "section", AcademicYear: 2026}
+	sectionB := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, ParentUnitID: &grade.ID, Name: "Sección B", Code: "G1B", Type: "section", AcademicYear: 2026}
+	oldGrade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 9", Code: "G9", Type: "grade", AcademicYear: 2026}
+	oldSection := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, ParentUnitID: &oldGrade.ID, Name: "Sección Z", Code: "G9Z", Type: "section", AcademicYear: 2026}
+	campus := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Sede", Code: "S1", Type: "grade"}
+
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockPeriodRepo := new(MockAcademicPeriodRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockUnitService := new(MockAcademicUnitService)
+	service := NewUnitTreeTransferService(
+		mockSchoolRepo, mockUnitRepo, mockPeriodRepo, mockMembershipRepo, new(MockUserRepository), noCustomUnitTypes(), defaultNestingSettings(),
+		mockUnitService, new(MockUnitMembershipService),
+		newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger(),
+	)
+	mockSchoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	mockPeriodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, schoolID, false).Return([]*entities.AcademicUnit{grade, sectionA, sectionB, oldSection, oldGrade, campus}, nil)
+
+	resp, err := service.ImportUnitTree(context.Background(), schoolID.String(), dto.ImportUnitTreeRequest{Format: "yaml", DryRun: true, Prune: true}, strings.NewReader(unitTreeImportDocument), "admin")
+
+	require.NoError(t, err)
+	require.Len(t, resp.Deletes, 2)
+	assert.Equal(t, oldGrade.ID.String(), resp.Deletes[0].UnitID)
+	assert.Equal(t, oldSection.ID.String(), resp.Deletes[1].UnitID)
+	assert.Equal(t, "G9", resp.Deletes[1].ParentCode)
+	mockUnitService.AssertNotCalled(t, "DeleteUnit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportUnitTree_PruneRejectsSubtreesWithOtherYears(t *testing.T) {
+	schoolID := uuid.New()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 1", Code: "G1", Type: "grade", AcademicYear: 2026}
+	oldGrade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 9", Code: "G9", Type: "grade", AcademicYear: 2026}
+	previousYear := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, ParentUnitID: &oldGrade.ID, Name: "Sección Z", Code: "G9Z-2025", Type: "section", AcademicYear: 2025}
+
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockPeriodRepo := new(MockAcademicPeriodRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockUnitService := new(MockAcademicUnitService)
+	service := NewUnitTreeTransferService(
+		mockSchoolRepo, mockUnitRepo, mockPeriodRepo, mockMembershipRepo, new(MockUserRepository), noCustomUnitTypes(), defaultNestingSettings(),
+		mockUnitService, new(MockUnitMembershipService),
+		newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger(),
+	)
+	mockSchoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	mockPeriodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, schoolID, false).Return([]*entities.AcademicUnit{grade, oldGrade, previousYear}, nil)
+	mockUnitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+
+	_, err := service.ImportUnitTree(context.Background(), schoolID.String(), dto.ImportUnitTreeRequest{Format: "yaml", Prune: true}, strings.NewReader(unitTreeImportDocument), "admin")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unit G9 cannot be pruned")
+	mockUnitService.AssertNotCalled(t, "DeleteUnit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportUnitTree_RejectsTypeChanges(t *testing.T) {
+	schoolID := uuid.New()
+	grade := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Grado 1", Code: "G1", Type: "level", AcademicYear: 2026}
+
+	mockSchoolRepo := new(MockSchoolRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	mockPeriodRepo := new(MockAcademicPeriodRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	service := NewUnitTreeTransferService(
+		mockSchoolRepo, mockUnitRepo, mockPeriodRepo, mockMembershipRepo, new(MockUserRepository), noCustomUnitTypes(), defaultNestingSettings(),
+		new(MockAcademicUnitService), new(MockUnitMembershipService),
+		newTestQuotaService(mockSchoolRepo, mockMembershipRepo), passthroughTxManager{}, newTestLogger(),
+	)
+	mockSchoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	mockPeriodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	mockUnitRepo.On("FindBySchoolID", mock.Anything, schoolID, false).Return([]*entities.AcademicUnit{grade}, nil)
+
+	_, err := service.ImportUnitTree(context.Background(), schoolID.String(), dto.ImportUnitTreeRequest{Format: "yaml", DryRun: true}, strings.NewReader(unitTreeImportDocument), "admin")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot change its type")
+}
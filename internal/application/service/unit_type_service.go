@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// UnitTypeService administra los tipos de unidad de cada escuela: los predefinidos
+// (school, grade, section, club, department) más los que la escuela registra
+type UnitTypeService interface {
+	// ListUnitTypes lista los tipos predefinidos y los propios de la escuela
+	ListUnitTypes(ctx context.Context, schoolID string) ([]dto.UnitTypeResponse, error)
+
+	// CreateUnitType registra un tipo propio
+	CreateUnitType(ctx context.Context, schoolID string, req dto.CreateUnitTypeRequest, createdBy string) (*dto.UnitTypeResponse, error)
+
+	// UpdateUnitType actualiza un tipo propio
+	UpdateUnitType(ctx context.Context, schoolID, code string, req dto.UpdateUnitTypeRequest) (*dto.UnitTypeResponse, error)
+
+	// DeleteUnitType elimina un tipo propio que ninguna unidad usa
+	DeleteUnitType(ctx context.Context, schoolID, code string) error
+}
+
+type unitTypeService struct {
+	schoolRepo      repository.SchoolRepository
+	unitTypeRepo    repository.SchoolUnitTypeRepository
+	unitRepo        repository.AcademicUnitRepository
+	settingsService SchoolSettingsService
+	txManager       repository.TransactionManager
+	logger          logger.Logger
+}
+
+// NewUnitTypeService crea un nuevo UnitTypeService
+func NewUnitTypeService(
+	schoolRepo repository.SchoolRepository,
+	unitTypeRepo repository.SchoolUnitTypeRepository,
+	unitRepo repository.AcademicUnitRepository,
+	settingsService SchoolSettingsService,
+	txManager repository.TransactionManager,
+	logger logger.Logger,
+) UnitTypeService {
+	return &unitTypeService{
+		schoolRepo:      schoolRepo,
+		unitTypeRepo:    unitTypeRepo,
+		unitRepo:        unitRepo,
+		settingsService: settingsService,
+		txManager:       txManager,
+		logger:          logger,
+	}
+}
+
+func (s *unitTypeService) ListUnitTypes(ctx context.Context, schoolID string) ([]dto.UnitTypeResponse, error) {
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := loadSchoolUnitTypes(ctx, s.unitTypeRepo, school.ID)
+	if err != nil {
+		return nil, err
+	}
+	baseRules, err := s.settingsService.GetUnitNestingRules(ctx, school.ID)
+	if err != nil {
+		return nil, err
+	}
+	rules := custom.applyNesting(baseRules)
+
+	result := make([]dto.UnitTypeResponse, 0, len(valueobject.AllUnitTypes())+len(custom))
+	for _, unitType := range valueobject.AllUnitTypes() {
+		parents := make([]string, 0, len(rules.AllowedParents[unitType]))
+		for _, parent := range rules.AllowedParents[unitType] {
+			parents = append(parents, parent.String())
+		}
+		result = append(result, dto.UnitTypeResponse{
+			Code:         unitType.String(),
+			Label:        unitType.String(),
+			Builtin:      true,
+			AllowedRoles: []string{},
+			ParentTypes:  parents,
+			CanBeRoot:    rules.CanBeRoot(unitType),
+		})
+	}
+	for _, unitType := range custom {
+		result = append(result, dto.ToUnitTypeResponse(unitType))
+	}
+	return result, nil
+}
+
+func (s *unitTypeService) CreateUnitType(ctx context.Context, schoolID string, req dto.CreateUnitTypeRequest, createdBy string) (*dto.UnitTypeResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	code := strings.TrimSpace(req.Code)
+	if err := valueobject.ValidateCustomUnitTypeCode(code); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	custom, err := loadSchoolUnitTypes(ctx, s.unitTypeRepo, school.ID)
+	if err != nil {
+		return nil, err
+	}
+	if custom.find(code) != nil {
+		return nil, errors.NewAlreadyExistsError("unit type").WithField("code", code)
+	}
+
+	now := time.Now()
+	unitType := &repository.SchoolUnitType{
+		ID:           uuid.New(),
+		SchoolID:     school.ID,
+		Code:         code,
+		Label:        strings.TrimSpace(req.Label),
+		Icon:         strings.TrimSpace(req.Icon),
+		AllowedRoles: req.AllowedRoles,
+		ParentTypes:  req.ParentTypes,
+		ChildTypes:   req.ChildTypes,
+		CanBeRoot:    req.CanBeRoot,
+		CreatedBy:    createdBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := validateSchoolUnitType(unitType, custom); err != nil {
+		return nil, err
+	}
+
+	if err := s.unitTypeRepo.Create(ctx, unitType); err != nil {
+		return nil, errors.NewDatabaseError("create unit type", err)
+	}
+
+	s.logger.Info("entity created",
+		"entity_type", "school_unit_type",
+		"entity_id", unitType.ID.String(),
+		"code", unitType.Code,
+		"school_id", school.ID.String(),
+	)
+	response := dto.ToUnitTypeResponse(unitType)
+	return &response, nil
+}
+
+func (s *unitTypeService) UpdateUnitType(ctx context.Context, schoolID, code string, req dto.UpdateUnitTypeRequest) (*dto.UnitTypeResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := loadSchoolUnitTypes(ctx, s.unitTypeRepo, school.ID)
+	if err != nil {
+		return nil, err
+	}
+	unitType := custom.find(code)
+	if unitType == nil {
+		if valueobject.UnitType(code).IsValid() {
+			return nil, errors.NewBusinessRuleError("built-in unit types cannot be modified")
+		}
+		return nil, errors.NewNotFoundError("unit type")
+	}
+
+	if req.Label != nil {
+		unitType.Label = strings.TrimSpace(*req.Label)
+	}
+	if req.Icon != nil {
+		unitType.Icon = strings.TrimSpace(*req.Icon)
+	}
+	if req.AllowedRoles != nil {
+		unitType.AllowedRoles = *req.AllowedRoles
+	}
+	if req.ParentTypes != nil {
+		unitType.ParentTypes = *req.ParentTypes
+	}
+	if req.ChildTypes != nil {
+		unitType.ChildTypes = *req.ChildTypes
+	}
+	if req.CanBeRoot != nil {
+		unitType.CanBeRoot = *req.CanBeRoot
+	}
+	if err := validateSchoolUnitType(unitType, custom); err != nil {
+		return nil, err
+	}
+
+	unitType.UpdatedAt = time.Now()
+	if err := s.unitTypeRepo.Update(ctx, unitType); err != nil {
+		return nil, errors.NewDatabaseError("update unit type", err)
+	}
+
+	s.logger.Info("entity updated",
+		"entity_type", "school_unit_type",
+		"entity_id", unitType.ID.String(),
+		"code", unitType.Code,
+	)
+	response := dto.ToUnitTypeResponse(unitType)
+	return &response, nil
+}
+
+func (s *unitTypeService) DeleteUnitType(ctx context.Context, schoolID, code string) error {
+	school, err := s.loadSchool(ctx, schoolID)
+	if err != nil {
+		return err
+	}
+	custom, err := loadSchoolUnitTypes(ctx, s.unitTypeRepo, school.ID)
+	if err != nil {
+		return err
+	}
+	unitType := custom.find(code)
+	if unitType == nil {
+		if valueobject.UnitType(code).IsValid() {
+			return errors.NewBusinessRuleError("built-in unit types cannot be deleted")
+		}
+		return errors.NewNotFoundError("unit type")
+	}
+
+	// Las eliminadas cuentan: restaurarlas dejaría unidades con un tipo inexistente
+	units, err := s.unitRepo.FindByType(ctx, school.ID, code, true)
+	if err != nil {
+		return errors.NewDatabaseError("find units by type", err)
+	}
+	if len(units) > 0 {
+		return errors.NewConflictError(fmt.Sprintf("unit type %s is used by %d units", code, len(units)))
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Los demás tipos propios dejan de referenciarlo en sus reglas de anidamiento
+		for _, other := range custom {
+			if other.ID == unitType.ID || (!containsString(other.ParentTypes, code) && !containsString(other.ChildTypes, code)) {
+				continue
+			}
+			other.ParentTypes = removeString(other.ParentTypes, code)
+			other.ChildTypes = removeString(other.ChildTypes, code)
+			other.UpdatedAt = time.Now()
+			if err := s.unitTypeRepo.Update(ctx, other); err != nil {
+				return errors.NewDatabaseError("update unit type", err)
+			}
+		}
+		if err := s.unitTypeRepo.Delete(ctx, unitType.ID); err != nil {
+			return errors.NewDatabaseError("delete unit type", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("entity deleted",
+		"entity_type", "school_unit_type",
+		"entity_id", unitType.ID.String(),
+		"code", code,
+	)
+	return nil
+}
+
+func (s *unitTypeService) loadSchool(ctx context.Context, schoolID string) (*entities.School, error) {
+	id, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	return school, nil
+}
+
+// validateSchoolUnitType valida roles y tipos referenciados (predefinidos, propios de la escuela o él mismo)
+func validateSchoolUnitType(unitType *repository.SchoolUnitType, custom schoolUnitTypes) error {
+	for _, role := range unitType.AllowedRoles {
+		if _, err := valueobject.ParseMembershipRole(role); err != nil {
+			return errors.NewValidationError(err.Error()).WithField("allowed_roles", role)
+		}
+	}
+
+	registry := custom.registry()
+	registry[valueobject.UnitType(unitType.Code)] = true
+	for field, types := range map[string][]string{"parent_types": unitType.ParentTypes, "child_types": unitType.ChildTypes} {
+		for _, t := range types {
+			if _, err := registry.Parse(t); err != nil {
+				return errors.NewValidationError(err.Error()).WithField(field, t)
+			}
+		}
+	}
+	if !unitType.CanBeRoot && len(unitType.ParentTypes) == 0 {
+		return errors.NewValidationError("unit type needs parent_types or can_be_root, otherwise no unit could use it")
+	}
+	return nil
+}
+
+// schoolUnitTypes son los tipos de unidad propios de una escuela
+type schoolUnitTypes []*repository.SchoolUnitType
+
+// loadSchoolUnitTypes obtiene los tipos propios de la escuela
+func loadSchoolUnitTypes(ctx context.Context, repo repository.SchoolUnitTypeRepository, schoolID uuid.UUID) (schoolUnitTypes, error) {
+	unitTypes, err := repo.ListBySchool(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list unit types", err)
+	}
+	return unitTypes, nil
+}
+
+// registry retorna los tipos válidos en la escuela (predefinidos más propios)
+func (t schoolUnitTypes) registry() valueobject.UnitTypeRegistry {
+	custom := make([]valueobject.UnitType, 0, len(t))
+	for _, unitType := range t {
+		custom = append(custom, valueobject.UnitType(unitType.Code))
+	}
+	return valueobject.NewUnitTypeRegistry(custom...)
+}
+
+func (t schoolUnitTypes) find(code string) *repository.SchoolUnitType {
+	for _, unitType := range t {
+		if unitType.Code == code {
+			return unitType
+		}
+	}
+	return nil
+}
+
+// applyNesting suma a las reglas de la escuela las de los tipos propios, sin modificar rules
+func (t schoolUnitTypes) applyNesting(rules valueobject.UnitNestingRules) valueobject.UnitNestingRules {
+	merged := valueobject.UnitNestingRules{
+		AllowedParents: make(map[valueobject.UnitType][]valueobject.UnitType, len(rules.AllowedParents)+len(t)),
+		RootTypes:      append([]valueobject.UnitType(nil), rules.RootTypes...),
+		MaxDepth:       rules.MaxDepth,
+	}
+	for child, parents := range rules.AllowedParents {
+		merged.AllowedParents[child] = append([]valueobject.UnitType(nil), parents...)
+	}
+	for _, unitType := range t {
+		code := valueobject.UnitType(unitType.Code)
+		for _, parent := range unitType.ParentTypes {
+			merged.AllowedParents[code] = append(merged.AllowedParents[code], valueobject.UnitType(parent))
+		}
+		for _, child := range unitType.ChildTypes {
+			merged.AllowedParents[valueobject.UnitType(child)] = append(merged.AllowedParents[valueobject.UnitType(child)], code)
+		}
+		if unitType.CanBeRoot {
+			merged.RootTypes = append(merged.RootTypes, code)
+		}
+	}
+	return merged
+}
+
+// checkUnitTypeRole valida que el rol esté permitido en las unidades del tipo de unit;
+// los tipos predefinidos y los propios sin allowed_roles aceptan cualquier rol
+func checkUnitTypeRole(ctx context.Context, repo repository.SchoolUnitTypeRepository, unit *entities.AcademicUnit, role string) error {
+	if valueobject.UnitType(unit.Type).IsValid() {
+		return nil
+	}
+	unitType, err := repo.FindByCode(ctx, unit.SchoolID, unit.Type)
+	if err != nil {
+		return errors.NewDatabaseError("find unit type", err)
+	}
+	if unitType == nil || len(unitType.AllowedRoles) == 0 || containsString(unitType.AllowedRoles, role) {
+		return nil
+	}
+	return errors.NewBusinessRuleError(fmt.Sprintf("role %s is not allowed in %s units", role, unit.Type))
+}
+
+func removeString(values []string, value string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
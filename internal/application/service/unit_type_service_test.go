@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockSchoolUnitTypeRepository mock implementation
+type MockSchoolUnitTypeRepository struct {
+	mock.Mock
+}
+
+func (m *MockSchoolUnitTypeRepository) Create(ctx context.Context, unitType *repository.SchoolUnitType) error {
+	args := m.Called(ctx, unitType)
+	return args.Error(0)
+}
+
+func (m *MockSchoolUnitTypeRepository) Update(ctx context.Context, unitType *repository.SchoolUnitType) error {
+	args := m.Called(ctx, unitType)
+	return args.Error(0)
+}
+
+func (m *MockSchoolUnitTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSchoolUnitTypeRepository) FindByCode(ctx context.Context, schoolID uuid.UUID, code string) (*repository.SchoolUnitType, error) {
+	args := m.Called(ctx, schoolID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.SchoolUnitType), args.Error(1)
+}
+
+func (m *MockSchoolUnitTypeRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*repository.SchoolUnitType, error) {
+	args := m.Called(ctx, schoolID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.SchoolUnitType), args.Error(1)
+}
+
+// customUnitTypes retorna un repositorio con los tipos propios indicados para cualquier escuela
+func customUnitTypes(unitTypes ...*repository.SchoolUnitType) *MockSchoolUnitTypeRepository {
+	repo := new(MockSchoolUnitTypeRepository)
+	repo.On("ListBySchool", mock.Anything, mock.Anything).Return(unitTypes, nil).Maybe()
+	for _, unitType := range unitTypes {
+		repo.On("FindByCode", mock.Anything, mock.Anything, unitType.Code).Return(unitType, nil).Maybe()
+	}
+	repo.On("FindByCode", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
+func noCustomUnitTypes() *MockSchoolUnitTypeRepository {
+	return customUnitTypes()
+}
+
+func TestCreateUnitType_ValidatesCodeRolesAndTypes(t *testing.T) {
+	school := &entities.School{ID: uuid.New()}
+	campus := &repository.SchoolUnitType{ID: uuid.New(), SchoolID: school.ID, Code: "campus", Label: "Sede", CanBeRoot: true}
+
+	tests := []struct {
+		name    string
+		req     dto.CreateUnitTypeRequest
+		message string
+	}{
+		{name: "built-in code", req: dto.CreateUnitTypeRequest{Code: "grade", Label: "Grado", CanBeRoot: true}, message: "built in"},
+		{name: "existing code", req: dto.CreateUnitTypeRequest{Code: "campus", Label: "Sede", CanBeRoot: true}, message: "already exists"},
+		{name: "unknown role", req: dto.CreateUnitTypeRequest{Code: "jornada", Label: "Jornada", CanBeRoot: true, AllowedRoles: []string{"janitor"}}, message: "invalid membership role"},
+		{name: "unknown parent type", req: dto.CreateUnitTypeRequest{Code: "jornada", Label: "Jornada", ParentTypes: []string{"wing"}}, message: "invalid unit type"},
+		{name: "unreachable type", req: dto.CreateUnitTypeRequest{Code: "jornada", Label: "Jornada"}, message: "parent_types or can_be_root"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schoolRepo := new(MockSchoolRepository)
+			unitTypeRepo := customUnitTypes(campus)
+			service := NewUnitTypeService(schoolRepo, unitTypeRepo, new(MockAcademicUnitRepository), defaultNestingSettings(), passthroughTxManager{}, newTestLogger())
+			schoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+
+			_, err := service.CreateUnitType(context.Background(), school.ID.String(), tt.req, "admin-1")
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+			unitTypeRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateUnit_AcceptsSchoolCustomTypes(t *testing.T) {
+	schoolID := uuid.New()
+	campusType := &repository.SchoolUnitType{ID: uuid.New(), SchoolID: schoolID, Code: "campus", Label: "Sede", CanBeRoot: true, ChildTypes: []string{"grade"}}
+	campus := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "Sede Norte", Type: "campus", AcademicYear: 2026}
+
+	unitRepo := new(MockAcademicUnitRepository)
+	schoolRepo := new(MockSchoolRepository)
+	periodRepo := new(MockAcademicPeriodRepository)
+	service := NewAcademicUnitService(unitRepo, schoolRepo, periodRepo, new(MockUnitMembershipRepository), new(MockUnitDeletionRepository), customUnitTypes(campusType), defaultNestingSettings(), noUnitCapacities(), noUnitQuotas(), passthroughTxManager{}, newTestLogger())
+
+	schoolRepo.On("FindByID", mock.Anything, schoolID).Return(&entities.School{ID: schoolID}, nil)
+	periodRepo.On("FindActiveYear", mock.Anything, schoolID).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	periodRepo.On("FindYear", mock.Anything, schoolID, 2026).Return(testAcademicYear(schoolID, 2026, repository.AcademicPeriodActive), nil)
+	unitRepo.On("LockHierarchy", mock.Anything, schoolID).Return(nil)
+	unitRepo.On("FindByID", mock.Anything, campus.ID, false).Return(campus, nil)
+	unitRepo.On("FindAncestors", mock.Anything, campus.ID).Return([]*entities.AcademicUnit{}, nil)
+	unitRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.CreateUnit(context.Background(), schoolID.String(), dto.CreateAcademicUnitRequest{Type: "campus", DisplayName: "Sede Sur"})
+	require.NoError(t, err)
+
+	parentID := campus.ID.String()
+	_, err = service.CreateUnit(context.Background(), schoolID.String(), dto.CreateAcademicUnitRequest{Type: "grade", DisplayName: "Primero", ParentUnitID: &parentID})
+	require.NoError(t, err, "child_types lets built-in types hang from the custom type")
+
+	_, err = service.CreateUnit(context.Background(), schoolID.String(), dto.CreateAcademicUnitRequest{Type: "track", DisplayName: "Track STEM"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid unit type")
+	unitRepo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestCreateMembership_RespectsCustomTypeRoles(t *testing.T) {
+	schoolID := uuid.New()
+	trackType := &repository.SchoolUnitType{ID: uuid.New(), SchoolID: schoolID, Code: "track", Label: "Track", CanBeRoot: true, AllowedRoles: []string{"student", "coordinator"}}
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "STEM", Type: "track"}
+	userID := uuid.New()
+
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockUnitRepo := new(MockAcademicUnitRepository)
+	service := NewUnitMembershipService(mockMembershipRepo, mockUnitRepo, liveSchools(), new(MockAcademicPeriodRepository), customUnitTypes(trackType), nil, noUnitCapacities(), passthroughTxManager{}, newTestLogger())
+	mockUnitRepo.On("FindByID", mock.Anything, unit.ID, false).Return(unit, nil)
+	mockMembershipRepo.On("ExistsByUnitAndUser", mock.Anything, unit.ID, userID).Return(false, nil)
+
+	_, err := service.CreateMembership(context.Background(), dto.CreateMembershipRequest{
+		UnitID: unit.ID.String(),
+		UserID: userID.String(),
+		Role:   "teacher",
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "role teacher is not allowed in track units")
+	mockMembershipRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestDeleteUnitType_RejectsTypesInUse(t *testing.T) {
+	school := &entities.School{ID: uuid.New()}
+	campus := &repository.SchoolUnitType{ID: uuid.New(), SchoolID: school.ID, Code: "campus", Label: "Sede", CanBeRoot: true}
+	jornada := &repository.SchoolUnitType{ID: uuid.New(), SchoolID: school.ID, Code: "jornada", Label: "Jornada", ParentTypes: []string{"campus", "school"}}
+
+	schoolRepo := new(MockSchoolRepository)
+	unitRepo := new(MockAcademicUnitRepository)
+	unitTypeRepo := customUnitTypes(campus, jornada)
+	service := NewUnitTypeService(schoolRepo, unitTypeRepo, unitRepo, defaultNestingSettings(), passthroughTxManager{}, newTestLogger())
+	schoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	unitRepo.On("FindByType", mock.Anything, school.ID, "campus", true).Return([]*entities.AcademicUnit{{ID: uuid.New()}}, nil).Once()
+
+	err := service.DeleteUnitType(context.Background(), school.ID.String(), "campus")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "used by 1 units")
+
+	unitRepo.On("FindByType", mock.Anything, school.ID, "campus", true).Return([]*entities.AcademicUnit{}, nil)
+	unitTypeRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *repository.SchoolUnitType) bool {
+		return u.Code == "jornada" && len(u.ParentTypes) == 1 && u.ParentTypes[0] == "school"
+	})).Return(nil)
+	unitTypeRepo.On("Delete", mock.Anything, campus.ID).Return(nil)
+
+	require.NoError(t, service.DeleteUnitType(context.Background(), school.ID.String(), "campus"))
+	unitTypeRepo.AssertExpectations(t)
+}
@@ -0,0 +1,768 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/similarity"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	// nameSimilarityThreshold es la similitud mínima (Jaro-Winkler) entre nombres completos
+	nameSimilarityThreshold = 0.92
+	// nameScoreWeight pondera el score por nombre: un nombre parecido pesa menos que un email igual
+	nameScoreWeight = 0.9
+	// phoneScore es el score de dos cuentas con el mismo teléfono (hogares comparten teléfono)
+	phoneScore = 0.85
+	// minPhoneDigits descarta teléfonos demasiado cortos para ser significativos
+	minPhoneDigits = 7
+	// defaultDuplicatesLimit es el tamaño de página por defecto de la cola de revisión
+	defaultDuplicatesLimit = 50
+
+	duplicateReasonEmail = "email"
+	duplicateReasonName  = "name"
+	duplicateReasonPhone = "phone"
+)
+
+// UserDuplicateService define la detección de cuentas duplicadas y su fusión
+type UserDuplicateService interface {
+	// ScanDuplicates busca pares de usuarios parecidos y los encola para revisión
+	ScanDuplicates(ctx context.Context, req dto.ScanDuplicatesRequest) (*dto.ScanDuplicatesResponse, error)
+
+	// ListCandidates lista la cola de revisión
+	ListCandidates(ctx context.Context, req dto.ListDuplicatesRequest) ([]*dto.DuplicateCandidateResponse, error)
+
+	// DismissCandidate descarta un candidato; los escaneos posteriores no lo vuelven a encolar
+	DismissCandidate(ctx context.Context, id string, performedBy string) (*dto.DuplicateCandidateResponse, error)
+
+	// MergeUsers fusiona la cuenta origen en la destino (o solo muestra el plan si DryRun)
+	MergeUsers(ctx context.Context, req dto.MergeUsersRequest, performedBy string) (*dto.MergeUsersResponse, error)
+}
+
+type userDuplicateService struct {
+	userRepo         repository.UserRepository
+	membershipRepo   repository.UnitMembershipRepository
+	guardianRepo     repository.GuardianRepository
+	statusChangeRepo repository.UserStatusChangeRepository
+	candidateRepo    repository.DuplicateCandidateRepository
+	profileRepo      repository.UserProfileRepository
+	loginEventRepo   repository.LoginEventRepository
+	invitationRepo   repository.InvitationRepository
+	txManager        repository.TransactionManager
+	tokenRevoker     UserTokenRevoker
+	logger           logger.Logger
+}
+
+// NewUserDuplicateService crea un nuevo UserDuplicateService
+func NewUserDuplicateService(
+	userRepo repository.UserRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	guardianRepo repository.GuardianRepository,
+	statusChangeRepo repository.UserStatusChangeRepository,
+	candidateRepo repository.DuplicateCandidateRepository,
+	profileRepo repository.UserProfileRepository,
+	loginEventRepo repository.LoginEventRepository,
+	invitationRepo repository.InvitationRepository,
+	txManager repository.TransactionManager,
+	tokenRevoker UserTokenRevoker,
+	logger logger.Logger,
+) UserDuplicateService {
+	return &userDuplicateService{
+		userRepo:         userRepo,
+		membershipRepo:   membershipRepo,
+		guardianRepo:     guardianRepo,
+		statusChangeRepo: statusChangeRepo,
+		candidateRepo:    candidateRepo,
+		profileRepo:      profileRepo,
+		loginEventRepo:   loginEventRepo,
+		invitationRepo:   invitationRepo,
+		txManager:        txManager,
+		tokenRevoker:     tokenRevoker,
+		logger:           logger,
+	}
+}
+
+// duplicateMatch acumula el score y las razones de un par de usuarios
+type duplicateMatch struct {
+	userA, userB uuid.UUID
+	score        float64
+	reasons      []string
+}
+
+// duplicateMatches acumula los pares encontrados en el orden en que aparecen
+type duplicateMatches struct {
+	byPair map[[2]uuid.UUID]*duplicateMatch
+	order  [][2]uuid.UUID
+}
+
+func (m *duplicateMatches) add(a, b uuid.UUID, score float64, reason string) {
+	key := duplicatePairKey(a, b)
+	match, exists := m.byPair[key]
+	if !exists {
+		match = &duplicateMatch{userA: key[0], userB: key[1]}
+		m.byPair[key] = match
+		m.order = append(m.order, key)
+	}
+	if score > match.score {
+		match.score = score
+	}
+	match.reasons = append(match.reasons, reason)
+}
+
+// addGroup agrega todos los pares de un grupo de usuarios que comparten un valor
+func (m *duplicateMatches) addGroup(ids []uuid.UUID, score float64, reason string) {
+	for i := 0; i < len(ids); i++ {
+		for j := i + 1; j < len(ids); j++ {
+			m.add(ids[i], ids[j], score, reason)
+		}
+	}
+}
+
+// addSimilarNames compara los nombres completos de un bloque de usuarios
+func (m *duplicateMatches) addSimilarNames(users []*entities.User) {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = similarity.NormalizeName(user.FirstName + " " + user.LastName)
+	}
+	for i := 0; i < len(users); i++ {
+		for j := i + 1; j < len(users); j++ {
+			sim := similarity.JaroWinkler(names[i], names[j])
+			if sim >= nameSimilarityThreshold {
+				m.add(users[i].ID, users[j].ID, sim*nameScoreWeight, duplicateReasonName)
+			}
+		}
+	}
+}
+
+func (m *duplicateMatches) list() []*duplicateMatch {
+	result := make([]*duplicateMatch, len(m.order))
+	for i, key := range m.order {
+		result[i] = m.byPair[key]
+	}
+	return result
+}
+
+func (s *userDuplicateService) ScanDuplicates(ctx context.Context, req dto.ScanDuplicatesRequest) (*dto.ScanDuplicatesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	filters := repository.ListFilters{}
+	if req.SchoolID != "" {
+		schoolID, _ := uuid.Parse(req.SchoolID)
+		filters.SchoolID = &schoolID
+	}
+
+	scanned, err := s.userRepo.Count(ctx, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("count users", err)
+	}
+	matches, err := s.findDuplicateMatches(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	response := &dto.ScanDuplicatesResponse{ScannedUsers: scanned}
+	now := time.Now()
+
+	for _, match := range matches {
+		existing, err := s.candidateRepo.FindByPair(ctx, match.userA, match.userB)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find duplicate candidate", err)
+		}
+
+		if existing == nil {
+			candidate := &repository.DuplicateCandidate{
+				ID:         uuid.New(),
+				UserIDA:    match.userA,
+				UserIDB:    match.userB,
+				Score:      match.score,
+				Reasons:    match.reasons,
+				Status:     repository.DuplicateStatusPending,
+				DetectedAt: now,
+			}
+			if err := s.candidateRepo.Create(ctx, candidate); err != nil {
+				return nil, errors.NewDatabaseError("create duplicate candidate", err)
+			}
+			response.NewCandidates++
+			continue
+		}
+
+		// Los candidatos ya revisados (descartados o fusionados) no se reabren
+		if existing.Status != repository.DuplicateStatusPending || existing.Score == match.score {
+			continue
+		}
+		existing.Score = match.score
+		existing.Reasons = match.reasons
+		if err := s.candidateRepo.Update(ctx, existing); err != nil {
+			return nil, errors.NewDatabaseError("update duplicate candidate", err)
+		}
+		response.UpdatedCandidates++
+	}
+
+	s.logger.Info("duplicate scan finished",
+		"scanned_users", response.ScannedUsers,
+		"new_candidates", response.NewCandidates,
+		"updated_candidates", response.UpdatedCandidates,
+	)
+
+	return response, nil
+}
+
+// findDuplicateMatches compara los usuarios por email normalizado, por teléfono (del perfil) y por similitud de nombre.
+// Email y teléfono se agrupan en la base; los nombres se comparan en memoria pero solo dentro del
+// mismo bloque (primeras letras del apellido normalizado), cargando un bloque a la vez.
+func (s *userDuplicateService) findDuplicateMatches(ctx context.Context, filters repository.ListFilters) ([]*duplicateMatch, error) {
+	matches := &duplicateMatches{byPair: make(map[[2]uuid.UUID]*duplicateMatch)}
+
+	emailGroups, err := s.userRepo.FindEmailDuplicates(ctx, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find email duplicates", err)
+	}
+	for _, group := range emailGroups {
+		matches.addGroup(group, 1, duplicateReasonEmail)
+	}
+
+	phoneGroups, err := s.userRepo.FindPhoneDuplicates(ctx, filters, minPhoneDigits)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find phone duplicates", err)
+	}
+	for _, group := range phoneGroups {
+		matches.addGroup(group, phoneScore, duplicateReasonPhone)
+	}
+
+	blocks, err := s.userRepo.ListNameBlocks(ctx, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list name blocks", err)
+	}
+	for _, block := range blocks {
+		users, err := s.userRepo.ListByNameBlock(ctx, filters, block)
+		if err != nil {
+			return nil, errors.NewDatabaseError("list users by name block", err)
+		}
+		matches.addSimilarNames(users)
+	}
+
+	return matches.list(), nil
+}
+
+// duplicatePairKey normaliza el par para que (a, b) y (b, a) sean el mismo candidato
+func duplicatePairKey(a, b uuid.UUID) [2]uuid.UUID {
+	if a.String() > b.String() {
+		a, b = b, a
+	}
+	return [2]uuid.UUID{a, b}
+}
+
+func (s *userDuplicateService) ListCandidates(ctx context.Context, req dto.ListDuplicatesRequest) ([]*dto.DuplicateCandidateResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	filters := repository.DuplicateCandidateFilters{Limit: req.Limit, Offset: req.Offset}
+	if filters.Limit <= 0 {
+		filters.Limit = defaultDuplicatesLimit
+	}
+	if req.Status != "" {
+		filters.Status = &req.Status
+	}
+
+	candidates, err := s.candidateRepo.List(ctx, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list duplicate candidates", err)
+	}
+
+	users := make(map[uuid.UUID]*entities.User)
+	lookup := func(id uuid.UUID) (*entities.User, error) {
+		if user, ok := users[id]; ok {
+			return user, nil
+		}
+		user, err := s.userRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find user", err)
+		}
+		users[id] = user
+		return user, nil
+	}
+
+	responses := make([]*dto.DuplicateCandidateResponse, len(candidates))
+	for i, candidate := range candidates {
+		userA, err := lookup(candidate.UserIDA)
+		if err != nil {
+			return nil, err
+		}
+		userB, err := lookup(candidate.UserIDB)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = dto.ToDuplicateCandidateResponse(candidate, userA, userB)
+	}
+	return responses, nil
+}
+
+func (s *userDuplicateService) DismissCandidate(ctx context.Context, id string, performedBy string) (*dto.DuplicateCandidateResponse, error) {
+	candidate, err := s.findCandidate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if candidate.Status != repository.DuplicateStatusPending {
+		return nil, errors.NewBusinessRuleError("duplicate candidate was already reviewed")
+	}
+
+	now := time.Now()
+	candidate.Status = repository.DuplicateStatusDismissed
+	candidate.ReviewedBy = &performedBy
+	candidate.ReviewedAt = &now
+	if err := s.candidateRepo.Update(ctx, candidate); err != nil {
+		return nil, errors.NewDatabaseError("update duplicate candidate", err)
+	}
+
+	s.logger.Info("duplicate candidate dismissed",
+		"entity_type", "duplicate_candidate",
+		"entity_id", candidate.ID.String(),
+		"performed_by", performedBy,
+	)
+
+	return dto.ToDuplicateCandidateResponse(candidate, nil, nil), nil
+}
+
+// relationReassignment es una relación de apoderado que pasa a la cuenta destino
+type relationReassignment struct {
+	relation   *entities.GuardianRelation
+	guardianID uuid.UUID
+	studentID  uuid.UUID
+}
+
+// mergePlan describe lo que hará una fusión antes de ejecutarla
+type mergePlan struct {
+	source, target        *entities.User
+	candidate             *repository.DuplicateCandidate
+	membershipsToMove     []*entities.Membership
+	membershipsToExpire   []*entities.Membership
+	relationsToMove       []relationReassignment
+	relationsToDeactivate []*entities.GuardianRelation
+	auditReferences       int
+	sourceProfile         *repository.UserProfile
+	targetProfile         *repository.UserProfile
+	loginEvents           int
+}
+
+// Qué pasa con el perfil del origen al fusionar
+const (
+	mergeProfileNone  = "none"  // el origen no tiene perfil
+	mergeProfileMove  = "move"  // el destino no tiene perfil: pasa el del origen
+	mergeProfileMerge = "merge" // el destino conserva el suyo y completa lo que le falta con el del origen
+)
+
+func (p *mergePlan) profileAction() string {
+	switch {
+	case p.sourceProfile == nil:
+		return mergeProfileNone
+	case p.targetProfile == nil:
+		return mergeProfileMove
+	default:
+		return mergeProfileMerge
+	}
+}
+
+func (s *userDuplicateService) MergeUsers(ctx context.Context, req dto.MergeUsersRequest, performedBy string) (*dto.MergeUsersResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.SourceUserID == req.TargetUserID {
+		return nil, errors.NewValidationError("source_user_id and target_user_id must be different")
+	}
+
+	// El plan se arma con ambas cuentas bloqueadas: una fusión o un cambio concurrente no puede invalidarlo
+	var plan *mergePlan
+	var response *dto.MergeUsersResponse
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if plan, err = s.buildMergePlan(ctx, req); err != nil {
+			return err
+		}
+		response = plan.toResponse()
+		if req.DryRun {
+			return nil
+		}
+		return s.applyMergePlan(ctx, plan, req.Reason, performedBy, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if req.DryRun {
+		return response, nil
+	}
+	response.Applied = true
+
+	// La cuenta retirada no debe conservar sesiones; un fallo aquí no revierte la fusión
+	revokeUserTokens(ctx, s.tokenRevoker, s.logger, plan.source.ID)
+
+	s.logger.Info("users merged",
+		"entity_type", "user",
+		"entity_id", plan.target.ID.String(),
+		"merged_user_id", plan.source.ID.String(),
+		"performed_by", performedBy,
+		"memberships_moved", len(plan.membershipsToMove),
+		"memberships_expired", len(plan.membershipsToExpire),
+		"guardian_relations_moved", len(plan.relationsToMove),
+		"guardian_relations_deactivated", len(plan.relationsToDeactivate),
+	)
+
+	return response, nil
+}
+
+func (s *userDuplicateService) buildMergePlan(ctx context.Context, req dto.MergeUsersRequest) (*mergePlan, error) {
+	sourceID, err := uuid.Parse(req.SourceUserID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id format")
+	}
+	targetID, err := uuid.Parse(req.TargetUserID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id format")
+	}
+
+	// Siempre en el mismo orden para que dos fusiones cruzadas no se bloqueen entre sí
+	locked := make(map[uuid.UUID]*entities.User, 2)
+	for _, id := range duplicatePairKey(sourceID, targetID) {
+		if locked[id], err = s.lockUser(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	source, target := locked[sourceID], locked[targetID]
+	if !target.IsActive {
+		return nil, errors.NewBusinessRuleError("target user must be active")
+	}
+
+	plan := &mergePlan{source: source, target: target}
+
+	if req.CandidateID != "" {
+		candidate, err := s.findCandidate(ctx, req.CandidateID)
+		if err != nil {
+			return nil, err
+		}
+		if duplicatePairKey(candidate.UserIDA, candidate.UserIDB) != duplicatePairKey(source.ID, target.ID) {
+			return nil, errors.NewValidationError("candidate does not match source and target users")
+		}
+		plan.candidate = candidate
+	} else {
+		candidate, err := s.candidateRepo.FindByPair(ctx, source.ID, target.ID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find duplicate candidate", err)
+		}
+		plan.candidate = candidate
+	}
+	if plan.candidate != nil && plan.candidate.Status == repository.DuplicateStatusMerged {
+		return nil, errors.NewBusinessRuleError("users were already merged")
+	}
+
+	if err := s.planMemberships(ctx, plan); err != nil {
+		return nil, err
+	}
+	if err := s.planGuardianRelations(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.statusChangeRepo.ListByUser(ctx, source.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list user status changes", err)
+	}
+	plan.auditReferences = len(changes)
+
+	if plan.sourceProfile, err = s.profileRepo.FindByUserID(ctx, source.ID); err != nil {
+		return nil, errors.NewDatabaseError("find user profile", err)
+	}
+	if plan.targetProfile, err = s.profileRepo.FindByUserID(ctx, target.ID); err != nil {
+		return nil, errors.NewDatabaseError("find user profile", err)
+	}
+	events, err := s.loginEventRepo.ListByUser(ctx, source.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find login history", err)
+	}
+	plan.loginEvents = len(events)
+
+	return plan, nil
+}
+
+// planMemberships decide qué membresías se mueven; si el destino ya pertenece a la
+// misma unidad (o escuela, sin unidad) la membresía del origen se expira en lugar de moverse
+func (s *userDuplicateService) planMemberships(ctx context.Context, plan *mergePlan) error {
+	now := time.Now()
+
+	targetMemberships, err := s.membershipRepo.FindByUser(ctx, plan.target.ID)
+	if err != nil {
+		return errors.NewDatabaseError("find memberships", err)
+	}
+	taken := make(map[string]bool, len(targetMemberships))
+	for _, membership := range targetMemberships {
+		if membership.IsActive && (membership.WithdrawnAt == nil || membership.WithdrawnAt.After(now)) {
+			taken[membershipPlacementKey(membership)] = true
+		}
+	}
+
+	sourceMemberships, err := s.membershipRepo.FindByUser(ctx, plan.source.ID)
+	if err != nil {
+		return errors.NewDatabaseError("find memberships", err)
+	}
+	for _, membership := range sourceMemberships {
+		if taken[membershipPlacementKey(membership)] {
+			plan.membershipsToExpire = append(plan.membershipsToExpire, membership)
+			continue
+		}
+		plan.membershipsToMove = append(plan.membershipsToMove, membership)
+	}
+	return nil
+}
+
+func membershipPlacementKey(membership *entities.Membership) string {
+	if membership.AcademicUnitID != nil {
+		return "unit:" + membership.AcademicUnitID.String()
+	}
+	return "school:" + membership.SchoolID.String()
+}
+
+// planGuardianRelations reasigna las relaciones del origen al destino. Se desactivan las
+// que quedarían como auto-relación o duplicarían una relación activa existente.
+func (s *userDuplicateService) planGuardianRelations(ctx context.Context, plan *mergePlan) error {
+	asGuardian, err := s.guardianRepo.FindByGuardian(ctx, plan.source.ID)
+	if err != nil {
+		return errors.NewDatabaseError("find guardian relations", err)
+	}
+	asStudent, err := s.guardianRepo.FindByStudent(ctx, plan.source.ID)
+	if err != nil {
+		return errors.NewDatabaseError("find guardian relations", err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	pairs := make(map[[2]uuid.UUID]bool)
+	for _, relation := range append(asGuardian, asStudent...) {
+		if seen[relation.ID] {
+			continue
+		}
+		seen[relation.ID] = true
+
+		guardianID, studentID := relation.GuardianID, relation.StudentID
+		if guardianID == plan.source.ID {
+			guardianID = plan.target.ID
+		}
+		if studentID == plan.source.ID {
+			studentID = plan.target.ID
+		}
+
+		if guardianID == studentID {
+			plan.relationsToDeactivate = append(plan.relationsToDeactivate, relation)
+			continue
+		}
+
+		if relation.IsActive {
+			pair := [2]uuid.UUID{guardianID, studentID}
+			exists, err := s.guardianRepo.ExistsActiveRelation(ctx, guardianID, studentID)
+			if err != nil {
+				return errors.NewDatabaseError("check guardian relation", err)
+			}
+			if exists || pairs[pair] {
+				plan.relationsToDeactivate = append(plan.relationsToDeactivate, relation)
+				continue
+			}
+			pairs[pair] = true
+		}
+
+		plan.relationsToMove = append(plan.relationsToMove, relationReassignment{
+			relation:   relation,
+			guardianID: guardianID,
+			studentID:  studentID,
+		})
+	}
+	return nil
+}
+
+// applyMergePlan ejecuta la fusión y completa la respuesta; se llama dentro de una transacción
+func (s *userDuplicateService) applyMergePlan(ctx context.Context, plan *mergePlan, reason, performedBy string, response *dto.MergeUsersResponse) error {
+	now := time.Now()
+
+	change := &repository.UserStatusChange{
+		ID:          uuid.New(),
+		UserID:      plan.target.ID,
+		Action:      repository.UserStatusActionMerged,
+		Reason:      mergeReason(plan.source, reason),
+		PerformedBy: performedBy,
+		CreatedAt:   now,
+	}
+
+	for _, membership := range plan.membershipsToMove {
+		if err := s.membershipRepo.ReassignUser(ctx, membership.ID, plan.target.ID); err != nil {
+			return errors.NewDatabaseError("reassign membership", err)
+		}
+		change.MembershipIDs = append(change.MembershipIDs, membership.ID)
+	}
+
+	for _, membership := range plan.membershipsToExpire {
+		if membership.WithdrawnAt != nil && !membership.WithdrawnAt.After(now) {
+			continue
+		}
+		membership.WithdrawnAt = &now
+		membership.UpdatedAt = now
+		if err := s.membershipRepo.Update(ctx, membership); err != nil {
+			return errors.NewDatabaseError("expire membership", err)
+		}
+	}
+
+	for _, move := range plan.relationsToMove {
+		if err := s.guardianRepo.ReassignRelation(ctx, move.relation.ID, move.guardianID, move.studentID); err != nil {
+			return errors.NewDatabaseError("reassign guardian relation", err)
+		}
+		change.GuardianRelationIDs = append(change.GuardianRelationIDs, move.relation.ID)
+	}
+
+	for _, relation := range plan.relationsToDeactivate {
+		if !relation.IsActive {
+			continue
+		}
+		relation.IsActive = false
+		relation.UpdatedAt = now
+		if err := s.guardianRepo.Update(ctx, relation); err != nil {
+			return errors.NewDatabaseError("deactivate guardian relation", err)
+		}
+	}
+
+	var err error
+	if response.AuditReferencesMoved, err = s.statusChangeRepo.ReassignUser(ctx, plan.source.ID, plan.target.ID); err != nil {
+		return errors.NewDatabaseError("reassign user status changes", err)
+	}
+	if response.LoginEventsMoved, err = s.loginEventRepo.ReassignUser(ctx, plan.source.ID, plan.target.ID); err != nil {
+		return errors.NewDatabaseError("reassign login history", err)
+	}
+	if err := s.mergeProfile(ctx, plan, now); err != nil {
+		return err
+	}
+
+	// Las invitaciones pendientes al email retirado pasan al destino; aceptarlas crearía otra cuenta duplicada
+	if response.InvitationsMoved, err = s.invitationRepo.ReassignPendingEmail(ctx, plan.source.Email, plan.target.Email); err != nil {
+		return errors.NewDatabaseError("reassign invitations", err)
+	}
+
+	// Retirar la cuenta origen
+	plan.source.IsActive = false
+	plan.source.UpdatedAt = now
+	if err := s.userRepo.Update(ctx, plan.source); err != nil {
+		return errors.NewDatabaseError("update user", err)
+	}
+	if err := s.userRepo.Delete(ctx, plan.source.ID); err != nil {
+		return errors.NewDatabaseError("delete user", err)
+	}
+
+	if err := s.statusChangeRepo.Create(ctx, change); err != nil {
+		return errors.NewDatabaseError("create user status change", err)
+	}
+
+	if plan.candidate != nil {
+		plan.candidate.Status = repository.DuplicateStatusMerged
+		plan.candidate.ReviewedBy = &performedBy
+		plan.candidate.ReviewedAt = &now
+		if err := s.candidateRepo.Update(ctx, plan.candidate); err != nil {
+			return errors.NewDatabaseError("update duplicate candidate", err)
+		}
+	}
+
+	return nil
+}
+
+// mergeProfile pasa el perfil del origen al destino, o completa con él los datos que le faltan al del destino
+func (s *userDuplicateService) mergeProfile(ctx context.Context, plan *mergePlan, now time.Time) error {
+	if plan.sourceProfile == nil {
+		return nil
+	}
+
+	profile := plan.targetProfile
+	if profile == nil {
+		moved := *plan.sourceProfile
+		moved.UserID = plan.target.ID
+		profile = &moved
+	} else {
+		if profile.Phone == nil {
+			profile.Phone = plan.sourceProfile.Phone
+		}
+		if profile.DateOfBirth == nil {
+			profile.DateOfBirth = plan.sourceProfile.DateOfBirth
+		}
+		if profile.AvatarURL == nil {
+			profile.AvatarURL = plan.sourceProfile.AvatarURL
+		}
+	}
+	profile.UpdatedAt = now
+
+	if err := s.profileRepo.Upsert(ctx, profile); err != nil {
+		return errors.NewDatabaseError("save user profile", err)
+	}
+	if err := s.profileRepo.Delete(ctx, plan.source.ID); err != nil {
+		return errors.NewDatabaseError("delete user profile", err)
+	}
+	return nil
+}
+
+func mergeReason(source *entities.User, reason string) string {
+	merged := "merged account " + source.ID.String() + " (" + source.Email + ")"
+	if reason == "" {
+		return merged
+	}
+	return merged + ": " + reason
+}
+
+func (p *mergePlan) toResponse() *dto.MergeUsersResponse {
+	response := &dto.MergeUsersResponse{
+		SourceUserID:                  p.source.ID.String(),
+		TargetUserID:                  p.target.ID.String(),
+		MembershipsToMove:             make([]string, 0, len(p.membershipsToMove)),
+		MembershipsToExpire:           make([]string, 0, len(p.membershipsToExpire)),
+		GuardianRelationsToMove:       make([]string, 0, len(p.relationsToMove)),
+		GuardianRelationsToDeactivate: make([]string, 0, len(p.relationsToDeactivate)),
+		AuditReferences:               p.auditReferences,
+		Profile:                       p.profileAction(),
+		LoginEvents:                   p.loginEvents,
+	}
+	for _, membership := range p.membershipsToMove {
+		response.MembershipsToMove = append(response.MembershipsToMove, membership.ID.String())
+	}
+	for _, membership := range p.membershipsToExpire {
+		response.MembershipsToExpire = append(response.MembershipsToExpire, membership.ID.String())
+	}
+	for _, move := range p.relationsToMove {
+		response.GuardianRelationsToMove = append(response.GuardianRelationsToMove, move.relation.ID.String())
+	}
+	for _, relation := range p.relationsToDeactivate {
+		response.GuardianRelationsToDeactivate = append(response.GuardianRelationsToDeactivate, relation.ID.String())
+	}
+	return response
+}
+
+func (s *userDuplicateService) lockUser(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, err := s.userRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user", err)
+	}
+	if user == nil {
+		return nil, errors.NewNotFoundError("user").WithField("id", id.String())
+	}
+	return user, nil
+}
+
+func (s *userDuplicateService) findCandidate(ctx context.Context, id string) (*repository.DuplicateCandidate, error) {
+	candidateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid candidate_id format")
+	}
+
+	candidate, err := s.candidateRepo.FindByID(ctx, candidateID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find duplicate candidate", err)
+	}
+	if candidate == nil {
+		return nil, errors.NewNotFoundError("duplicate candidate").WithField("id", id)
+	}
+	return candidate, nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockDuplicateCandidateRepository mock implementation
+type MockDuplicateCandidateRepository struct {
+	mock.Mock
+}
+
+func (m *MockDuplicateCandidateRepository) Create(ctx context.Context, candidate *repository.DuplicateCandidate) error {
+	args := m.Called(ctx, candidate)
+	return args.Error(0)
+}
+
+func (m *MockDuplicateCandidateRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.DuplicateCandidate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.DuplicateCandidate), args.Error(1)
+}
+
+func (m *MockDuplicateCandidateRepository) FindByPair(ctx context.Context, userA, userB uuid.UUID) (*repository.DuplicateCandidate, error) {
+	args := m.Called(ctx, userA, userB)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.DuplicateCandidate), args.Error(1)
+}
+
+func (m *MockDuplicateCandidateRepository) List(ctx context.Context, filters repository.DuplicateCandidateFilters) ([]*repository.DuplicateCandidate, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.DuplicateCandidate), args.Error(1)
+}
+
+func (m *MockDuplicateCandidateRepository) Update(ctx context.Context, candidate *repository.DuplicateCandidate) error {
+	args := m.Called(ctx, candidate)
+	return args.Error(0)
+}
+
+// passthroughTxManager ejecuta fn sin transacción real
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestScanDuplicates_EmailAndName(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCandidateRepo := new(MockDuplicateCandidateRepository)
+	service := NewUserDuplicateService(mockUserRepo, nil, nil, nil, mockCandidateRepo, nil, nil, nil, passthroughTxManager{}, nil, newTestLogger())
+
+	users := []*entities.User{
+		{ID: uuid.New(), Email: "ana.diaz@gmail.com", FirstName: "Ana", LastName: "Díaz"},
+		{ID: uuid.New(), Email: "AnaDiaz+school@gmail.com", FirstName: "Ana", LastName: "Diaz"},
+	}
+	mockUserRepo.On("Count", mock.Anything, mock.Anything).Return(3, nil)
+	mockUserRepo.On("FindEmailDuplicates", mock.Anything, mock.Anything).Return([][]uuid.UUID{{users[0].ID, users[1].ID}}, nil)
+	mockUserRepo.On("FindPhoneDuplicates", mock.Anything, mock.Anything, minPhoneDigits).Return(nil, nil)
+	mockUserRepo.On("ListNameBlocks", mock.Anything, mock.Anything).Return([]string{"di"}, nil)
+	mockUserRepo.On("ListByNameBlock", mock.Anything, mock.Anything, "di").Return(users, nil)
+	mockCandidateRepo.On("FindByPair", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	mockCandidateRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *repository.DuplicateCandidate) bool {
+		return c.Score == 1 && len(c.Reasons) == 2 && c.Status == repository.DuplicateStatusPending
+	})).Return(nil).Once()
+
+	result, err := service.ScanDuplicates(context.Background(), dto.ScanDuplicatesRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.ScannedUsers)
+	assert.Equal(t, 1, result.NewCandidates)
+	mockCandidateRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestScanDuplicates_SkipsDismissed(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCandidateRepo := new(MockDuplicateCandidateRepository)
+	service := NewUserDuplicateService(mockUserRepo, nil, nil, nil, mockCandidateRepo, nil, nil, nil, passthroughTxManager{}, nil, newTestLogger())
+
+	users := []*entities.User{
+		{ID: uuid.New(), Email: "a@example.com", FirstName: "Ana", LastName: "Diaz"},
+		{ID: uuid.New(), Email: "a@example.com", FirstName: "Ana", LastName: "Diaz"},
+	}
+	mockUserRepo.On("Count", mock.Anything, mock.Anything).Return(2, nil)
+	mockUserRepo.On("FindEmailDuplicates", mock.Anything, mock.Anything).Return([][]uuid.UUID{{users[0].ID, users[1].ID}}, nil)
+	mockUserRepo.On("FindPhoneDuplicates", mock.Anything, mock.Anything, minPhoneDigits).Return(nil, nil)
+	mockUserRepo.On("ListNameBlocks", mock.Anything, mock.Anything).Return([]string{"di"}, nil)
+	mockUserRepo.On("ListByNameBlock", mock.Anything, mock.Anything, "di").Return(users, nil)
+	mockCandidateRepo.On("FindByPair", mock.Anything, mock.Anything, mock.Anything).
+		Return(&repository.DuplicateCandidate{ID: uuid.New(), Status: repository.DuplicateStatusDismissed}, nil)
+
+	result, err := service.ScanDuplicates(context.Background(), dto.ScanDuplicatesRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.NewCandidates)
+	assert.Equal(t, 0, result.UpdatedCandidates)
+	mockCandidateRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockCandidateRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestMergeUsers_DryRunPreviewsConflicts(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	mockCandidateRepo := new(MockDuplicateCandidateRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	mockLoginRepo := new(MockLoginEventRepository)
+	service := NewUserDuplicateService(mockUserRepo, mockMembershipRepo, mockGuardianRepo, mockStatusRepo, mockCandidateRepo, mockProfileRepo, mockLoginRepo, nil, passthroughTxManager{}, nil, newTestLogger())
+
+	sourceID, targetID := uuid.New(), uuid.New()
+	sharedUnit, otherUnit := uuid.New(), uuid.New()
+	studentID := uuid.New()
+
+	moved := &entities.Membership{ID: uuid.New(), UserID: sourceID, AcademicUnitID: &otherUnit, IsActive: true}
+	conflicting := &entities.Membership{ID: uuid.New(), UserID: sourceID, AcademicUnitID: &sharedUnit, IsActive: true}
+	targetMembership := &entities.Membership{ID: uuid.New(), UserID: targetID, AcademicUnitID: &sharedUnit, IsActive: true}
+	selfRelation := &entities.GuardianRelation{ID: uuid.New(), GuardianID: sourceID, StudentID: targetID, IsActive: true}
+	movedRelation := &entities.GuardianRelation{ID: uuid.New(), GuardianID: sourceID, StudentID: studentID, IsActive: true}
+
+	mockUserRepo.On("FindByIDForUpdate", mock.Anything, sourceID).Return(&entities.User{ID: sourceID, Email: "ana@old.test", IsActive: true}, nil)
+	mockUserRepo.On("FindByIDForUpdate", mock.Anything, targetID).Return(&entities.User{ID: targetID, Email: "ana@school.test", IsActive: true}, nil)
+	mockCandidateRepo.On("FindByPair", mock.Anything, sourceID, targetID).Return(nil, nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, targetID).Return([]*entities.Membership{targetMembership}, nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, sourceID).Return([]*entities.Membership{moved, conflicting}, nil)
+	mockGuardianRepo.On("FindByGuardian", mock.Anything, sourceID).Return([]*entities.GuardianRelation{selfRelation, movedRelation}, nil)
+	mockGuardianRepo.On("FindByStudent", mock.Anything, sourceID).Return([]*entities.GuardianRelation{}, nil)
+	mockGuardianRepo.On("ExistsActiveRelation", mock.Anything, targetID, studentID).Return(false, nil)
+	mockStatusRepo.On("ListByUser", mock.Anything, sourceID).Return([]*repository.UserStatusChange{{ID: uuid.New()}}, nil)
+	mockProfileRepo.On("FindByUserID", mock.Anything, sourceID).Return(&repository.UserProfile{UserID: sourceID}, nil)
+	mockProfileRepo.On("FindByUserID", mock.Anything, targetID).Return(nil, nil)
+	mockLoginRepo.On("ListByUser", mock.Anything, sourceID).Return([]*repository.LoginEvent{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+
+	req := dto.MergeUsersRequest{SourceUserID: sourceID.String(), TargetUserID: targetID.String(), DryRun: true}
+	result, err := service.MergeUsers(context.Background(), req, "admin")
+
+	require.NoError(t, err)
+	assert.False(t, result.Applied)
+	assert.Equal(t, []string{moved.ID.String()}, result.MembershipsToMove)
+	assert.Equal(t, []string{conflicting.ID.String()}, result.MembershipsToExpire)
+	assert.Equal(t, []string{movedRelation.ID.String()}, result.GuardianRelationsToMove)
+	assert.Equal(t, []string{selfRelation.ID.String()}, result.GuardianRelationsToDeactivate)
+	assert.Equal(t, 1, result.AuditReferences)
+	assert.Equal(t, "move", result.Profile)
+	assert.Equal(t, 2, result.LoginEvents)
+	mockProfileRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	mockMembershipRepo.AssertNotCalled(t, "ReassignUser", mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestMergeUsers_AppliesAndRetiresSource(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	mockCandidateRepo := new(MockDuplicateCandidateRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	mockLoginRepo := new(MockLoginEventRepository)
+	mockInvitationRepo := new(MockInvitationRepository)
+	mockRevoker := new(MockTokenRevoker)
+	service := NewUserDuplicateService(mockUserRepo, mockMembershipRepo, mockGuardianRepo, mockStatusRepo, mockCandidateRepo, mockProfileRepo, mockLoginRepo, mockInvitationRepo, passthroughTxManager{}, mockRevoker, newTestLogger())
+
+	sourceID, targetID := uuid.New(), uuid.New()
+	unitID := uuid.New()
+	membership := &entities.Membership{ID: uuid.New(), UserID: sourceID, AcademicUnitID: &unitID, IsActive: true}
+	candidate := &repository.DuplicateCandidate{ID: uuid.New(), UserIDA: sourceID, UserIDB: targetID, Status: repository.DuplicateStatusPending}
+
+	mockUserRepo.On("FindByIDForUpdate", mock.Anything, sourceID).Return(&entities.User{ID: sourceID, Email: "ana@old.test", IsActive: true}, nil)
+	mockUserRepo.On("FindByIDForUpdate", mock.Anything, targetID).Return(&entities.User{ID: targetID, Email: "ana@school.test", IsActive: true}, nil)
+	mockCandidateRepo.On("FindByID", mock.Anything, candidate.ID).Return(candidate, nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, targetID).Return([]*entities.Membership{}, nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, sourceID).Return([]*entities.Membership{membership}, nil)
+	mockGuardianRepo.On("FindByGuardian", mock.Anything, sourceID).Return([]*entities.GuardianRelation{}, nil)
+	mockGuardianRepo.On("FindByStudent", mock.Anything, sourceID).Return([]*entities.GuardianRelation{}, nil)
+	mockStatusRepo.On("ListByUser", mock.Anything, sourceID).Return([]*repository.UserStatusChange{}, nil)
+	mockProfileRepo.On("FindByUserID", mock.Anything, sourceID).Return(&repository.UserProfile{UserID: sourceID, Phone: strPtr("+56 9 1234 5678")}, nil)
+	mockProfileRepo.On("FindByUserID", mock.Anything, targetID).Return(&repository.UserProfile{UserID: targetID, PreferredLanguage: "es"}, nil)
+	mockLoginRepo.On("ListByUser", mock.Anything, sourceID).Return([]*repository.LoginEvent{}, nil)
+
+	mockMembershipRepo.On("ReassignUser", mock.Anything, membership.ID, targetID).Return(nil).Once()
+	mockStatusRepo.On("ReassignUser", mock.Anything, sourceID, targetID).Return(int64(2), nil).Once()
+	mockLoginRepo.On("ReassignUser", mock.Anything, sourceID, targetID).Return(int64(3), nil).Once()
+	// El destino conserva su perfil y completa el teléfono con el del origen
+	mockProfileRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(p *repository.UserProfile) bool {
+		return p.UserID == targetID && p.PreferredLanguage == "es" && p.Phone != nil && *p.Phone == "+56 9 1234 5678"
+	})).Return(nil).Once()
+	mockProfileRepo.On("Delete", mock.Anything, sourceID).Return(nil).Once()
+	mockInvitationRepo.On("ReassignPendingEmail", mock.Anything, "ana@old.test", "ana@school.test").Return(int64(1), nil).Once()
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool { return u.ID == sourceID && !u.IsActive })).Return(nil).Once()
+	mockUserRepo.On("Delete", mock.Anything, sourceID).Return(nil).Once()
+	mockStatusRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *repository.UserStatusChange) bool {
+		return c.UserID == targetID && c.Action == repository.UserStatusActionMerged
+	})).Return(nil).Once()
+	mockCandidateRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *repository.DuplicateCandidate) bool {
+		return c.Status == repository.DuplicateStatusMerged
+	})).Return(nil).Once()
+	mockRevoker.On("RevokeUserTokens", mock.Anything, sourceID.String()).Return(nil).Once()
+
+	req := dto.MergeUsersRequest{SourceUserID: sourceID.String(), TargetUserID: targetID.String(), CandidateID: candidate.ID.String()}
+	result, err := service.MergeUsers(context.Background(), req, "admin")
+
+	require.NoError(t, err)
+	assert.True(t, result.Applied)
+	assert.Equal(t, int64(2), result.AuditReferencesMoved)
+	assert.Equal(t, int64(3), result.LoginEventsMoved)
+	assert.Equal(t, int64(1), result.InvitationsMoved)
+	assert.Equal(t, "merge", result.Profile)
+	mockProfileRepo.AssertExpectations(t)
+	mockLoginRepo.AssertExpectations(t)
+	mockInvitationRepo.AssertExpectations(t)
+	mockMembershipRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockStatusRepo.AssertExpectations(t)
+	mockCandidateRepo.AssertExpectations(t)
+	mockRevoker.AssertExpectations(t)
+}
+
+func TestMergeUsers_SameUser(t *testing.T) {
+	service := NewUserDuplicateService(nil, nil, nil, nil, nil, nil, nil, nil, passthroughTxManager{}, nil, newTestLogger())
+
+	id := uuid.New().String()
+	_, err := service.MergeUsers(context.Background(), dto.MergeUsersRequest{SourceUserID: id, TargetUserID: id}, "admin")
+
+	require.Error(t, err)
+}
+
+func TestScanDuplicates_SharedPhone(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCandidateRepo := new(MockDuplicateCandidateRepository)
+	service := NewUserDuplicateService(mockUserRepo, nil, nil, nil, mockCandidateRepo, nil, nil, nil, passthroughTxManager{}, nil, newTestLogger())
+
+	userA, userB := uuid.New(), uuid.New()
+	mockUserRepo.On("Count", mock.Anything, mock.Anything).Return(2, nil)
+	mockUserRepo.On("FindEmailDuplicates", mock.Anything, mock.Anything).Return(nil, nil)
+	mockUserRepo.On("FindPhoneDuplicates", mock.Anything, mock.Anything, minPhoneDigits).Return([][]uuid.UUID{{userA, userB}}, nil)
+	mockUserRepo.On("ListNameBlocks", mock.Anything, mock.Anything).Return(nil, nil)
+	mockCandidateRepo.On("FindByPair", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	mockCandidateRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *repository.DuplicateCandidate) bool {
+		return c.Score == phoneScore && len(c.Reasons) == 1 && c.Reasons[0] == duplicateReasonPhone
+	})).Return(nil).Once()
+
+	result, err := service.ScanDuplicates(context.Background(), dto.ScanDuplicatesRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.NewCandidates)
+	mockCandidateRepo.AssertExpectations(t)
+}
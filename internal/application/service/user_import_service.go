@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/crypto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/spreadsheet"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// Columnas reconocidas en el archivo de importación
+const (
+	importColEmail          = "email"
+	importColFirstName      = "first_name"
+	importColLastName       = "last_name"
+	importColRole           = "role"
+	importColPassword       = "password"
+	importColMembershipRole = "membership_role"
+)
+
+var requiredImportColumns = []string{
+	importColEmail,
+	importColFirstName,
+	importColLastName,
+	importColRole,
+	importColPassword,
+}
+
+// maxImportRows limita el tamaño de un archivo de importación
+const maxImportRows = 5000
+
+// dryRunPasswordHash reemplaza el hash en la vista previa: sus escrituras se revierten y bcrypt es costoso
+const dryRunPasswordHash = "dry-run"
+
+// errImportDryRun revierte la transacción de la vista previa
+var errImportDryRun = stderrors.New("import dry run")
+
+// UserImportService define las operaciones de importación masiva de usuarios
+type UserImportService interface {
+	// ImportUsers importa usuarios desde un archivo CSV/XLSX.
+	// Cada fila (usuario y membresía) se persiste en su propia transacción: si la importación se
+	// interrumpe puede reanudarse reenviando el archivo con skip_existing=true, que también completa
+	// las membresías que falten. El dry-run aplica las filas en una transacción que se revierte.
+	ImportUsers(ctx context.Context, req dto.ImportUsersRequest, format spreadsheet.Format, file io.Reader) (*dto.ImportUsersReport, error)
+}
+
+type userImportService struct {
+	userRepo          repository.UserRepository
+	unitRepo          repository.AcademicUnitRepository
+	membershipRepo    repository.UnitMembershipRepository
+	membershipService UnitMembershipService
+	txManager         repository.TransactionManager
+	passwordHasher    *crypto.PasswordHasher
+	logger            logger.Logger
+}
+
+// NewUserImportService crea un nuevo UserImportService
+func NewUserImportService(
+	userRepo repository.UserRepository,
+	unitRepo repository.AcademicUnitRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	membershipService UnitMembershipService,
+	txManager repository.TransactionManager,
+	logger logger.Logger,
+) UserImportService {
+	return &userImportService{
+		userRepo:          userRepo,
+		unitRepo:          unitRepo,
+		membershipRepo:    membershipRepo,
+		membershipService: membershipService,
+		txManager:         txManager,
+		passwordHasher:    crypto.NewPasswordHasher(12),
+		logger:            logger,
+	}
+}
+
+// importRow representa una fila ya mapeada a columnas
+type importRow struct {
+	number         int
+	user           dto.CreateUserRequest
+	membershipRole string
+}
+
+func (s *userImportService) ImportUsers(
+	ctx context.Context,
+	req dto.ImportUsersRequest,
+	format spreadsheet.Format,
+	file io.Reader,
+) (*dto.ImportUsersReport, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Resolver la unidad destino (opcional)
+	var unit *entities.AcademicUnit
+	if req.UnitCode != "" {
+		schoolID, _ := uuid.Parse(req.SchoolID)
+		found, err := s.unitRepo.FindBySchoolIDAndCode(ctx, schoolID, req.UnitCode)
+		if err != nil {
+			if _, ok := errors.GetAppError(err); ok {
+				return nil, err
+			}
+			return nil, errors.NewDatabaseError("find unit", err)
+		}
+		if found == nil {
+			return nil, errors.NewNotFoundError("academic unit").WithField("code", req.UnitCode)
+		}
+		unit = found
+
+		if req.MembershipRole == "" {
+			req.MembershipRole = string(valueobject.RoleStudent)
+		}
+		if _, err := valueobject.ParseMembershipRole(req.MembershipRole); err != nil {
+			return nil, errors.NewValidationError(err.Error()).WithField("membership_role", req.MembershipRole)
+		}
+	}
+
+	rows, err := s.parseRows(format, file)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.ImportUsersReport{DryRun: req.DryRun, Rows: make([]dto.ImportRowResult, 0, len(rows))}
+	seen := make(map[string]int, len(rows))
+
+	importRows := func(ctx context.Context) {
+		for _, row := range rows {
+			report.Add(s.processRow(ctx, req, unit, row, seen))
+		}
+	}
+	if req.DryRun {
+		// La vista previa aplica las filas y revierte todo al final: cada fila ve
+		// los lugares y el cupo del plan que consumen las anteriores
+		err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			importRows(ctx)
+			return errImportDryRun
+		})
+		if err != nil && !stderrors.Is(err, errImportDryRun) {
+			return nil, errors.NewDatabaseError("import dry run", err)
+		}
+	} else {
+		importRows(ctx)
+	}
+
+	s.logger.Info("users imported",
+		"dry_run", req.DryRun,
+		"total_rows", report.TotalRows,
+		"created", report.Created,
+		"skipped", report.Skipped,
+		"completed", report.Completed,
+		"failed", report.Failed,
+	)
+
+	return report, nil
+}
+
+// parseRows lee el archivo y mapea cada fila según el encabezado
+func (s *userImportService) parseRows(format spreadsheet.Format, file io.Reader) ([]importRow, error) {
+	// +1 por el encabezado
+	records, err := spreadsheet.ReadRows(format, file, maxImportRows+1)
+	if err != nil {
+		if stderrors.Is(err, spreadsheet.ErrTooManyRows) {
+			return nil, errors.NewValidationError("too many rows in import file").WithField("max_rows", strconv.Itoa(maxImportRows))
+		}
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	header := make(map[string]int, len(records[0]))
+	for i, col := range records[0] {
+		header[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+
+	for _, col := range requiredImportColumns {
+		if _, ok := header[col]; !ok {
+			return nil, errors.NewValidationError("missing required column").WithField("column", col)
+		}
+	}
+
+	cell := func(record []string, col string) string {
+		idx, ok := header[col]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	rows := make([]importRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		// Ignorar filas completamente vacías
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		rows = append(rows, importRow{
+			number: i + 2, // número de fila en el archivo (1 = encabezado)
+			user: dto.CreateUserRequest{
+				Email:     strings.ToLower(cell(record, importColEmail)),
+				Password:  cell(record, importColPassword),
+				FirstName: cell(record, importColFirstName),
+				LastName:  cell(record, importColLastName),
+				Role:      cell(record, importColRole),
+			},
+			membershipRole: cell(record, importColMembershipRole),
+		})
+	}
+
+	return rows, nil
+}
+
+// processRow valida y (si no es dry-run) persiste una fila
+func (s *userImportService) processRow(
+	ctx context.Context,
+	req dto.ImportUsersRequest,
+	unit *entities.AcademicUnit,
+	row importRow,
+	seen map[string]int,
+) dto.ImportRowResult {
+	result := dto.ImportRowResult{Row: row.number, Email: row.user.Email}
+	fail := func(msg string) dto.ImportRowResult {
+		result.Status = dto.ImportRowStatusFailed
+		result.Errors = append(result.Errors, msg)
+		return result
+	}
+
+	// 1. Validaciones de formato (mismas reglas que CreateUser)
+	if err := row.user.Validate(); err != nil {
+		return fail(err.Error())
+	}
+	if enum.SystemRole(row.user.Role) == enum.SystemRoleAdmin {
+		return fail("cannot create admin users through import")
+	}
+	if err := s.passwordHasher.Validate(row.user.Password); err != nil {
+		return fail(err.Error())
+	}
+
+	membershipRole := req.MembershipRole
+	if unit != nil && row.membershipRole != "" {
+		if _, err := valueobject.ParseMembershipRole(row.membershipRole); err != nil {
+			return fail(err.Error())
+		}
+		membershipRole = row.membershipRole
+	}
+
+	// 2. Duplicados dentro del mismo archivo
+	if first, ok := seen[row.user.Email]; ok {
+		return fail("duplicate email in file (first seen at row " + strconv.Itoa(first) + ")")
+	}
+	seen[row.user.Email] = row.number
+
+	// 3. Duplicados contra la base de datos
+	exists, err := s.userRepo.ExistsByEmail(ctx, row.user.Email)
+	if err != nil {
+		s.logger.Error("database error",
+			"operation", "check_user_email",
+			"row", row.number,
+			"error", err.Error(),
+		)
+		return fail("could not check email")
+	}
+	if exists {
+		if req.SkipExisting {
+			return s.completeExistingRow(ctx, req, unit, row, membershipRole, result)
+		}
+		return fail("user with this email already exists")
+	}
+
+	// 4. Persistir usuario y membresía juntos; la membresía pasa por las mismas validaciones
+	// que el alta manual (año cerrado, roles del tipo de unidad, lugares y cupo del plan)
+	passwordHash := dryRunPasswordHash
+	if !req.DryRun {
+		if passwordHash, err = s.passwordHasher.Hash(row.user.Password); err != nil {
+			return fail("could not hash password")
+		}
+	}
+
+	now := time.Now()
+	user := &entities.User{
+		ID:            uuid.New(),
+		Email:         row.user.Email,
+		PasswordHash:  passwordHash,
+		FirstName:     row.user.FirstName,
+		LastName:      row.user.LastName,
+		Role:          row.user.Role,
+		IsActive:      true,
+		EmailVerified: false,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if unit != nil {
+		user.SchoolID = &unit.SchoolID
+	}
+
+	var membership *dto.MembershipResponse
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			s.logger.Error("database error",
+				"operation", "create_user",
+				"row", row.number,
+				"error", err.Error(),
+			)
+			return stderrors.New("could not create user")
+		}
+		if unit == nil {
+			return nil
+		}
+		membership, err = s.enroll(ctx, unit, user.ID, membershipRole)
+		return err
+	})
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	return importedRow(req, result, user.ID, membership, dto.ImportRowStatusCreated)
+}
+
+// completeExistingRow resuelve una fila cuyo usuario ya existe con skip_existing: si la fila pide una
+// membresía que el usuario no tiene (por ejemplo, falló en una corrida anterior) la crea; si no, la omite
+func (s *userImportService) completeExistingRow(
+	ctx context.Context,
+	req dto.ImportUsersRequest,
+	unit *entities.AcademicUnit,
+	row importRow,
+	membershipRole string,
+	result dto.ImportRowResult,
+) dto.ImportRowResult {
+	fail := func(msg string) dto.ImportRowResult {
+		result.Status = dto.ImportRowStatusFailed
+		result.Errors = append(result.Errors, msg)
+		return result
+	}
+
+	if unit == nil {
+		result.Status = dto.ImportRowStatusSkipped
+		return result
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, row.user.Email)
+	if err != nil || user == nil {
+		return fail("could not find existing user")
+	}
+	result.UserID = user.ID.String()
+
+	hasMembership, err := s.membershipRepo.ExistsByUnitAndUser(ctx, unit.ID, user.ID)
+	if err != nil {
+		s.logger.Error("database error",
+			"operation", "check_membership",
+			"row", row.number,
+			"error", err.Error(),
+		)
+		return fail("could not check membership")
+	}
+	if hasMembership {
+		result.Status = dto.ImportRowStatusSkipped
+		return result
+	}
+
+	membership, err := s.enroll(ctx, unit, user.ID, membershipRole)
+	if err != nil {
+		return fail(err.Error())
+	}
+	return importedRow(req, result, user.ID, membership, dto.ImportRowStatusCompleted)
+}
+
+// enroll crea la membresía por el mismo camino que CreateMembership
+func (s *userImportService) enroll(ctx context.Context, unit *entities.AcademicUnit, userID uuid.UUID, role string) (*dto.MembershipResponse, error) {
+	return s.membershipService.CreateMembership(ctx, dto.CreateMembershipRequest{
+		UnitID: unit.ID.String(),
+		UserID: userID.String(),
+		Role:   role,
+	})
+}
+
+// importedRow completa el resultado de una fila aplicada; en dry-run no hay IDs que informar
+func importedRow(req dto.ImportUsersRequest, result dto.ImportRowResult, userID uuid.UUID, membership *dto.MembershipResponse, status string) dto.ImportRowResult {
+	if membership != nil {
+		result.Waitlisted = membership.Waitlist != nil
+	}
+	if req.DryRun {
+		result.Status = dto.ImportRowStatusValid
+		return result
+	}
+	result.Status = status
+	result.UserID = userID.String()
+	if membership != nil {
+		result.MembershipID = membership.ID
+	}
+	return result
+}
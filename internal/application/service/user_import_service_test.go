@@ -0,0 +1,184 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
+	memrepo "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/persistence/mock/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/spreadsheet"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+)
+
+const importTestCSV = `email,first_name,last_name,role,password
+new@example.com,John,Doe,teacher,SecurePass123!
+existing@example.com,Jane,Doe,student,SecurePass123!
+NEW@example.com,Johnny,Doe,teacher,SecurePass123!
+bad-email,Ana,Perez,student,SecurePass123!
+`
+
+// recordingTxManager ejecuta fn sin transacción y registra el error con que terminó cada una
+type recordingTxManager struct {
+	results []error
+}
+
+func (m *recordingTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	m.results = append(m.results, err)
+	return err
+}
+
+func TestImportUsers_DryRunReport(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	txManager := &recordingTxManager{}
+	service := NewUserImportService(mockRepo, nil, nil, nil, txManager, newTestLogger())
+
+	mockRepo.On("ExistsByEmail", mock.Anything, "new@example.com").Return(false, nil)
+	mockRepo.On("ExistsByEmail", mock.Anything, "existing@example.com").Return(true, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.Email == "new@example.com" && u.PasswordHash == dryRunPasswordHash
+	})).Return(nil).Once()
+
+	report, err := service.ImportUsers(context.Background(), dto.ImportUsersRequest{DryRun: true}, spreadsheet.FormatCSV, strings.NewReader(importTestCSV))
+
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 4, report.TotalRows)
+	assert.Equal(t, 1, report.Valid)
+	assert.Equal(t, 3, report.Failed)
+	assert.Equal(t, dto.ImportRowStatusValid, report.Rows[0].Status)
+	assert.Equal(t, 2, report.Rows[0].Row)
+	assert.Contains(t, report.Rows[1].Errors[0], "already exists")
+	assert.Contains(t, report.Rows[2].Errors[0], "duplicate email in file")
+	assert.Equal(t, dto.ImportRowStatusFailed, report.Rows[3].Status)
+	assert.Empty(t, report.Rows[0].UserID)
+	// Las escrituras de la vista previa se revierten
+	require.NotEmpty(t, txManager.results)
+	assert.ErrorIs(t, txManager.results[len(txManager.results)-1], errImportDryRun)
+	mockRepo.AssertExpectations(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	assert.Equal(t, 5, strings.Count(buf.String(), "\n"))
+}
+
+func TestImportUsers_SkipExistingCreatesRemaining(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewUserImportService(mockRepo, nil, nil, nil, passthroughTxManager{}, newTestLogger())
+
+	csv := "email,first_name,last_name,role,password\n" +
+		"new@example.com,John,Doe,teacher,SecurePass123!\n" +
+		"existing@example.com,Jane,Doe,student,SecurePass123!\n"
+
+	mockRepo.On("ExistsByEmail", mock.Anything, "new@example.com").Return(false, nil)
+	mockRepo.On("ExistsByEmail", mock.Anything, "existing@example.com").Return(true, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.User")).Return(nil).Once()
+
+	report, err := service.ImportUsers(context.Background(), dto.ImportUsersRequest{SkipExisting: true}, spreadsheet.FormatCSV, strings.NewReader(csv))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Skipped)
+	assert.NotEmpty(t, report.Rows[0].UserID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportUsers_SkipExistingCompletesMissingMembership(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	unitRepo := new(MockAcademicUnitRepository)
+	membershipRepo := new(MockUnitMembershipRepository)
+	schoolRepo := new(MockSchoolRepository)
+	membershipService := NewUnitMembershipService(membershipRepo, unitRepo, liveSchools(), new(MockAcademicPeriodRepository), noCustomUnitTypes(), newTestQuotaService(schoolRepo, membershipRepo), noUnitCapacities(), passthroughTxManager{}, newTestLogger())
+	service := NewUserImportService(userRepo, unitRepo, membershipRepo, membershipService, passthroughTxManager{}, newTestLogger())
+
+	school := &entities.School{ID: uuid.New()}
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, Code: "G1A", Type: "section"}
+	// Creado en una corrida anterior cuya membresía falló
+	orphan := &entities.User{ID: uuid.New(), Email: "orphan@example.com"}
+	enrolled := &entities.User{ID: uuid.New(), Email: "enrolled@example.com"}
+
+	csv := "email,first_name,last_name,role,password\n" +
+		"orphan@example.com,John,Doe,student,SecurePass123!\n" +
+		"enrolled@example.com,Jane,Doe,student,SecurePass123!\n"
+
+	unitRepo.On("FindBySchoolIDAndCode", mock.Anything, school.ID, "G1A").Return(unit, nil)
+	unitRepo.On("FindByID", mock.Anything, unit.ID, false).Return(unit, nil)
+	schoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	userRepo.On("ExistsByEmail", mock.Anything, mock.Anything).Return(true, nil)
+	userRepo.On("FindByEmail", mock.Anything, orphan.Email).Return(orphan, nil)
+	userRepo.On("FindByEmail", mock.Anything, enrolled.Email).Return(enrolled, nil)
+	membershipRepo.On("ExistsByUnitAndUser", mock.Anything, unit.ID, orphan.ID).Return(false, nil)
+	membershipRepo.On("ExistsByUnitAndUser", mock.Anything, unit.ID, enrolled.ID).Return(true, nil)
+	membershipRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *entities.Membership) bool {
+		return m.UserID == orphan.ID && *m.AcademicUnitID == unit.ID && m.Role == "student"
+	})).Return(nil).Once()
+
+	report, err := service.ImportUsers(context.Background(), dto.ImportUsersRequest{
+		SchoolID:     school.ID.String(),
+		UnitCode:     "G1A",
+		SkipExisting: true,
+	}, spreadsheet.FormatCSV, strings.NewReader(csv))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Completed)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, dto.ImportRowStatusCompleted, report.Rows[0].Status)
+	assert.NotEmpty(t, report.Rows[0].MembershipID)
+	assert.Equal(t, dto.ImportRowStatusSkipped, report.Rows[1].Status)
+	userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	membershipRepo.AssertExpectations(t)
+}
+
+func TestImportUsers_MissingColumn(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewUserImportService(mockRepo, nil, nil, nil, passthroughTxManager{}, newTestLogger())
+
+	csv := "email,first_name,last_name\nnew@example.com,John,Doe\n"
+
+	_, err := service.ImportUsers(context.Background(), dto.ImportUsersRequest{}, spreadsheet.FormatCSV, strings.NewReader(csv))
+
+	require.Error(t, err)
+	mockRepo.AssertNotCalled(t, "ExistsByEmail")
+}
+
+func TestImportUsers_DryRunCountsSeatsOfEarlierRows(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	unitRepo := new(MockAcademicUnitRepository)
+	schoolRepo := new(MockSchoolRepository)
+	membershipRepo := memrepo.NewMockUnitMembershipRepository()
+	quotaService := NewSchoolQuotaService(schoolRepo, unitRepo, membershipRepo, config.QuotaDefaults{}, config.SubscriptionsConfig{}, newTestLogger())
+	membershipService := NewUnitMembershipService(membershipRepo, unitRepo, liveSchools(), new(MockAcademicPeriodRepository), noCustomUnitTypes(), quotaService, noUnitCapacities(), passthroughTxManager{}, newTestLogger())
+	service := NewUserImportService(userRepo, unitRepo, membershipRepo, membershipService, &recordingTxManager{}, newTestLogger())
+
+	// El plan admite un solo estudiante más
+	school := &entities.School{ID: uuid.New(), MaxStudents: 1}
+	unit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: school.ID, Code: "G1A", Type: "section"}
+	unitRepo.On("FindBySchoolIDAndCode", mock.Anything, school.ID, "G1A").Return(unit, nil)
+	unitRepo.On("FindByID", mock.Anything, unit.ID, false).Return(unit, nil)
+	schoolRepo.On("FindByID", mock.Anything, school.ID).Return(school, nil)
+	userRepo.On("ExistsByEmail", mock.Anything, mock.Anything).Return(false, nil)
+	userRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	csv := "email,first_name,last_name,role,password\n" +
+		"first@example.com,John,Doe,student,SecurePass123!\n" +
+		"second@example.com,Jane,Doe,student,SecurePass123!\n"
+
+	report, err := service.ImportUsers(context.Background(), dto.ImportUsersRequest{
+		SchoolID: school.ID.String(),
+		UnitCode: "G1A",
+		DryRun:   true,
+	}, spreadsheet.FormatCSV, strings.NewReader(csv))
+
+	require.NoError(t, err)
+	assert.Equal(t, dto.ImportRowStatusValid, report.Rows[0].Status)
+	assert.Equal(t, dto.ImportRowStatusFailed, report.Rows[1].Status)
+	assert.Contains(t, report.Rows[1].Errors[0], "student quota (1/1)")
+}
@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// UserTokenRevoker revoca todos los tokens emitidos para un usuario
+type UserTokenRevoker interface {
+	RevokeUserTokens(ctx context.Context, userID string) error
+}
+
+// revokeUserTokens revoca los tokens del usuario y registra el fallo sin propagarlo,
+// porque se invoca después del commit. Retorna si la revocación se aplicó.
+func revokeUserTokens(ctx context.Context, revoker UserTokenRevoker, log logger.Logger, userID uuid.UUID) bool {
+	if revoker == nil {
+		return false
+	}
+	if err := revoker.RevokeUserTokens(ctx, userID.String()); err != nil {
+		log.Error("failed to revoke user tokens",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+		return false
+	}
+	return true
+}
+
+// UserLifecycleService define el flujo de desactivación y reactivación de usuarios
+type UserLifecycleService interface {
+	// DeactivateUser desactiva al usuario, expira sus membresías, desactiva sus
+	// relaciones de apoderado y revoca sus tokens, registrando el motivo
+	DeactivateUser(ctx context.Context, id string, req dto.DeactivateUserRequest, performedBy string) (*dto.UserStatusChangeResponse, error)
+
+	// ReactivateUser reactiva al usuario y opcionalmente restaura lo afectado por la última desactivación
+	ReactivateUser(ctx context.Context, id string, req dto.ReactivateUserRequest, performedBy string) (*dto.UserStatusChangeResponse, error)
+
+	// GetStatusHistory lista las desactivaciones y reactivaciones del usuario
+	GetStatusHistory(ctx context.Context, id string) ([]*dto.UserStatusChangeResponse, error)
+}
+
+type userLifecycleService struct {
+	userRepo         repository.UserRepository
+	membershipRepo   repository.UnitMembershipRepository
+	unitRepo         repository.AcademicUnitRepository
+	guardianRepo     repository.GuardianRepository
+	statusChangeRepo repository.UserStatusChangeRepository
+	periodRepo       repository.AcademicPeriodRepository
+	quotaService     SchoolQuotaService
+	capacityService  UnitCapacityService
+	txManager        repository.TransactionManager
+	tokenRevoker     UserTokenRevoker
+	logger           logger.Logger
+}
+
+// NewUserLifecycleService crea un nuevo UserLifecycleService
+func NewUserLifecycleService(
+	userRepo repository.UserRepository,
+	membershipRepo repository.UnitMembershipRepository,
+	unitRepo repository.AcademicUnitRepository,
+	guardianRepo repository.GuardianRepository,
+	statusChangeRepo repository.UserStatusChangeRepository,
+	periodRepo repository.AcademicPeriodRepository,
+	quotaService SchoolQuotaService,
+	capacityService UnitCapacityService,
+	txManager repository.TransactionManager,
+	tokenRevoker UserTokenRevoker,
+	logger logger.Logger,
+) UserLifecycleService {
+	return &userLifecycleService{
+		userRepo:         userRepo,
+		membershipRepo:   membershipRepo,
+		unitRepo:         unitRepo,
+		guardianRepo:     guardianRepo,
+		statusChangeRepo: statusChangeRepo,
+		periodRepo:       periodRepo,
+		quotaService:     quotaService,
+		capacityService:  capacityService,
+		txManager:        txManager,
+		tokenRevoker:     tokenRevoker,
+		logger:           logger,
+	}
+}
+
+func (s *userLifecycleService) DeactivateUser(
+	ctx context.Context,
+	id string,
+	req dto.DeactivateUserRequest,
+	performedBy string,
+) (*dto.UserStatusChangeResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, errors.NewBusinessRuleError("user is already inactive")
+	}
+
+	now := time.Now()
+	change := &repository.UserStatusChange{
+		ID:          uuid.New(),
+		UserID:      user.ID,
+		Action:      repository.UserStatusActionDeactivated,
+		Reason:      req.Reason,
+		PerformedBy: performedBy,
+		CreatedAt:   now,
+	}
+
+	// Los pasos 1 a 4 se aplican juntos: un fallo no deja al usuario inactivo con membresías vigentes
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 1. Desactivar usuario (bloquea login y refresh inmediatamente)
+		user.IsActive = false
+		user.UpdatedAt = now
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return errors.NewDatabaseError("update user", err)
+		}
+
+		// 2. Expirar membresías vigentes (igual que ExpireMembership)
+		memberships, err := s.membershipRepo.FindByUser(ctx, user.ID)
+		if err != nil {
+			return errors.NewDatabaseError("find memberships", err)
+		}
+		for _, membership := range memberships {
+			if !membership.IsActive || (membership.WithdrawnAt != nil && !membership.WithdrawnAt.After(now)) {
+				continue
+			}
+
+			membership.WithdrawnAt = &now
+			membership.UpdatedAt = now
+			if err := s.membershipRepo.Update(ctx, membership); err != nil {
+				return errors.NewDatabaseError("expire membership", err)
+			}
+			change.MembershipIDs = append(change.MembershipIDs, membership.ID)
+
+			// El lugar que deja un estudiante pasa al primero en la lista de espera
+			if membership.AcademicUnitID != nil && membership.Role == string(valueobject.RoleStudent) {
+				if _, err := s.capacityService.FillFreedSeats(ctx, *membership.AcademicUnitID); err != nil {
+					return err
+				}
+			}
+		}
+
+		// 3. Desactivar relaciones de apoderado (como apoderado y como estudiante)
+		if change.GuardianRelationIDs, err = s.deactivateGuardianRelations(ctx, user.ID, now); err != nil {
+			return err
+		}
+
+		// 4. Registrar el cambio con el motivo
+		if err := s.statusChangeRepo.Create(ctx, change); err != nil {
+			return errors.NewDatabaseError("create user status change", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Revocar sesiones y tokens vigentes (después del commit)
+	// Un fallo no revierte la desactivación: el usuario inactivo ya no puede hacer login ni refresh
+	tokensRevoked := revokeUserTokens(ctx, s.tokenRevoker, s.logger, user.ID)
+
+	s.logger.Info("user deactivated",
+		"entity_type", "user",
+		"entity_id", user.ID.String(),
+		"performed_by", performedBy,
+		"memberships_expired", len(change.MembershipIDs),
+		"guardian_relations_deactivated", len(change.GuardianRelationIDs),
+		"tokens_revoked", tokensRevoked,
+	)
+
+	response := dto.ToUserStatusChangeResponse(change)
+	response.TokensRevoked = tokensRevoked
+	return response, nil
+}
+
+func (s *userLifecycleService) ReactivateUser(
+	ctx context.Context,
+	id string,
+	req dto.ReactivateUserRequest,
+	performedBy string,
+) (*dto.UserStatusChangeResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsActive {
+		return nil, errors.NewBusinessRuleError("user is already active")
+	}
+
+	now := time.Now()
+	change := &repository.UserStatusChange{
+		ID:          uuid.New(),
+		UserID:      user.ID,
+		Action:      repository.UserStatusActionReactivated,
+		Reason:      req.Reason,
+		PerformedBy: performedBy,
+		CreatedAt:   now,
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		user.IsActive = true
+		user.UpdatedAt = now
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return errors.NewDatabaseError("update user", err)
+		}
+
+		if req.RestoreMemberships || req.RestoreGuardianRelations {
+			last, err := s.statusChangeRepo.FindLatestByUser(ctx, user.ID, repository.UserStatusActionDeactivated)
+			if err != nil {
+				return errors.NewDatabaseError("find user status change", err)
+			}
+
+			if last != nil && req.RestoreMemberships {
+				if change.MembershipIDs, err = s.restoreMemberships(ctx, last.MembershipIDs, now); err != nil {
+					return err
+				}
+			}
+			if last != nil && req.RestoreGuardianRelations {
+				if change.GuardianRelationIDs, err = s.restoreGuardianRelations(ctx, last.GuardianRelationIDs, now); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.statusChangeRepo.Create(ctx, change); err != nil {
+			return errors.NewDatabaseError("create user status change", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("user reactivated",
+		"entity_type", "user",
+		"entity_id", user.ID.String(),
+		"performed_by", performedBy,
+		"memberships_restored", len(change.MembershipIDs),
+		"guardian_relations_restored", len(change.GuardianRelationIDs),
+	)
+
+	return dto.ToUserStatusChangeResponse(change), nil
+}
+
+func (s *userLifecycleService) GetStatusHistory(ctx context.Context, id string) ([]*dto.UserStatusChangeResponse, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.statusChangeRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list user status changes", err)
+	}
+
+	responses := make([]*dto.UserStatusChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = dto.ToUserStatusChangeResponse(change)
+	}
+	return responses, nil
+}
+
+func (s *userLifecycleService) findUser(ctx context.Context, id string) (*entities.User, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id format")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("database error",
+			"operation", "find_user",
+			"user_id", userID,
+			"error", err.Error(),
+		)
+		return nil, errors.NewDatabaseError("find user", err)
+	}
+	if user == nil {
+		return nil, errors.NewNotFoundError("user").WithField("id", id)
+	}
+	return user, nil
+}
+
+func (s *userLifecycleService) deactivateGuardianRelations(ctx context.Context, userID uuid.UUID, now time.Time) ([]uuid.UUID, error) {
+	asGuardian, err := s.guardianRepo.FindByGuardian(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find guardian relations", err)
+	}
+	asStudent, err := s.guardianRepo.FindByStudent(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find guardian relations", err)
+	}
+
+	var ids []uuid.UUID
+	for _, relation := range append(asGuardian, asStudent...) {
+		if !relation.IsActive {
+			continue
+		}
+		relation.IsActive = false
+		relation.UpdatedAt = now
+		if err := s.guardianRepo.Update(ctx, relation); err != nil {
+			return nil, errors.NewDatabaseError("deactivate guardian relation", err)
+		}
+		ids = append(ids, relation.ID)
+	}
+	return ids, nil
+}
+
+func (s *userLifecycleService) restoreMemberships(ctx context.Context, ids []uuid.UUID, now time.Time) ([]uuid.UUID, error) {
+	var restored []uuid.UUID
+	for _, id := range ids {
+		membership, err := s.membershipRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find membership", err)
+		}
+		// Se omiten las membresías eliminadas o reactivadas por otra vía
+		if membership == nil || !membership.IsActive || membership.WithdrawnAt == nil {
+			continue
+		}
+		if err := s.ensureMembershipRestorable(ctx, membership); err != nil {
+			return nil, err
+		}
+
+		membership.WithdrawnAt = nil
+		membership.UpdatedAt = now
+		if err := s.membershipRepo.Update(ctx, membership); err != nil {
+			return nil, errors.NewDatabaseError("restore membership", err)
+		}
+		restored = append(restored, membership.ID)
+	}
+	return restored, nil
+}
+
+func (s *userLifecycleService) restoreGuardianRelations(ctx context.Context, ids []uuid.UUID, now time.Time) ([]uuid.UUID, error) {
+	var restored []uuid.UUID
+	for _, id := range ids {
+		relation, err := s.guardianRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find guardian relation", err)
+		}
+		if relation == nil || relation.IsActive {
+			continue
+		}
+
+		// No duplicar si ya se creó otra relación activa entre las mismas personas
+		exists, err := s.guardianRepo.ExistsActiveRelation(ctx, relation.GuardianID, relation.StudentID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("check guardian relation", err)
+		}
+		if exists {
+			continue
+		}
+
+		relation.IsActive = true
+		relation.UpdatedAt = now
+		if err := s.guardianRepo.Update(ctx, relation); err != nil {
+			return nil, errors.NewDatabaseError("restore guardian relation", err)
+		}
+		restored = append(restored, relation.ID)
+	}
+	return restored, nil
+}
+
+// ensureMembershipRestorable valida que la membresía pueda volver a estar vigente: su año académico
+// no puede estar cerrado y, mientras estuvo expirada, otros pudieron ocupar su lugar o el cupo del plan
+func (s *userLifecycleService) ensureMembershipRestorable(ctx context.Context, membership *entities.Membership) error {
+	if membership.AcademicUnitID != nil {
+		unit, err := s.unitRepo.FindByID(ctx, *membership.AcademicUnitID, true)
+		if err != nil && !isNotFoundError(err) {
+			return errors.NewDatabaseError("find unit", err)
+		}
+		if unit != nil {
+			if err := ensureAcademicYearWritable(ctx, s.periodRepo, unit.SchoolID, unit.AcademicYear); err != nil {
+				return err
+			}
+		}
+		if membership.Role == string(valueobject.RoleStudent) {
+			if _, err := s.capacityService.ReserveSeat(ctx, membership.SchoolID, *membership.AcademicUnitID, membership.UserID, false); err != nil {
+				return err
+			}
+		}
+	}
+	return s.quotaService.CheckMembershipQuota(ctx, membership.SchoolID, membership.UserID, membership.Role)
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// MockUserStatusChangeRepository mock implementation
+type MockUserStatusChangeRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserStatusChangeRepository) Create(ctx context.Context, change *repository.UserStatusChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockUserStatusChangeRepository) FindLatestByUser(ctx context.Context, userID uuid.UUID, action string) (*repository.UserStatusChange, error) {
+	args := m.Called(ctx, userID, action)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UserStatusChange), args.Error(1)
+}
+
+func (m *MockUserStatusChangeRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*repository.UserStatusChange, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.UserStatusChange), args.Error(1)
+}
+
+func (m *MockUserStatusChangeRepository) ReassignUser(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, fromUserID, toUserID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserStatusChangeRepository) RedactReason(ctx context.Context, text, replacement string) (int64, error) {
+	args := m.Called(ctx, text, replacement)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockTokenRevoker mock implementation
+type MockTokenRevoker struct {
+	mock.Mock
+}
+
+func (m *MockTokenRevoker) RevokeUserTokens(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// Tests
+
+func TestDeactivateUser_CascadesAndRecordsReason(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	mockRevoker := new(MockTokenRevoker)
+	service := NewUserLifecycleService(mockUserRepo, mockMembershipRepo, new(MockAcademicUnitRepository), mockGuardianRepo, mockStatusRepo, new(MockAcademicPeriodRepository), newTestQuotaService(liveSchools(), mockMembershipRepo), noUnitCapacities(), passthroughTxManager{}, mockRevoker, newTestLogger())
+
+	userID := uuid.New()
+	past := time.Now().Add(-24 * time.Hour)
+	active := &entities.Membership{ID: uuid.New(), UserID: userID, IsActive: true}
+	alreadyExpired := &entities.Membership{ID: uuid.New(), UserID: userID, IsActive: true, WithdrawnAt: &past}
+	relation := &entities.GuardianRelation{ID: uuid.New(), GuardianID: userID, StudentID: uuid.New(), IsActive: true}
+
+	mockUserRepo.On("FindByID", mock.Anything, userID).Return(&entities.User{ID: userID, IsActive: true}, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool { return !u.IsActive })).Return(nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, userID).Return([]*entities.Membership{active, alreadyExpired}, nil)
+	mockMembershipRepo.On("Update", mock.Anything, active).Return(nil).Once()
+	mockGuardianRepo.On("FindByGuardian", mock.Anything, userID).Return([]*entities.GuardianRelation{relation}, nil)
+	mockGuardianRepo.On("FindByStudent", mock.Anything, userID).Return([]*entities.GuardianRelation{}, nil)
+	mockGuardianRepo.On("Update", mock.Anything, relation).Return(nil)
+	mockStatusRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *repository.UserStatusChange) bool {
+		return c.Action == repository.UserStatusActionDeactivated && c.Reason == "left the school"
+	})).Return(nil)
+	mockRevoker.On("RevokeUserTokens", mock.Anything, userID.String()).Return(nil)
+
+	result, err := service.DeactivateUser(context.Background(), userID.String(), dto.DeactivateUserRequest{Reason: "left the school"}, "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{active.ID.String()}, result.MembershipIDs)
+	assert.Equal(t, []string{relation.ID.String()}, result.GuardianRelationIDs)
+	assert.True(t, result.TokensRevoked)
+	assert.Equal(t, "admin-1", result.PerformedBy)
+	assert.NotNil(t, active.WithdrawnAt)
+	assert.False(t, relation.IsActive)
+	mockUserRepo.AssertExpectations(t)
+	mockMembershipRepo.AssertExpectations(t)
+	mockGuardianRepo.AssertExpectations(t)
+	mockStatusRepo.AssertExpectations(t)
+	mockRevoker.AssertExpectations(t)
+}
+
+func TestDeactivateUser_FailureDoesNotRevokeTokens(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	mockRevoker := new(MockTokenRevoker)
+	service := NewUserLifecycleService(mockUserRepo, mockMembershipRepo, new(MockAcademicUnitRepository), mockGuardianRepo, mockStatusRepo, new(MockAcademicPeriodRepository), newTestQuotaService(liveSchools(), mockMembershipRepo), noUnitCapacities(), passthroughTxManager{}, mockRevoker, newTestLogger())
+
+	userID := uuid.New()
+	mockUserRepo.On("FindByID", mock.Anything, userID).Return(&entities.User{ID: userID, IsActive: true}, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, userID).Return([]*entities.Membership{}, nil)
+	mockGuardianRepo.On("FindByGuardian", mock.Anything, userID).Return([]*entities.GuardianRelation{}, nil)
+	mockGuardianRepo.On("FindByStudent", mock.Anything, userID).Return([]*entities.GuardianRelation{}, nil)
+	mockStatusRepo.On("Create", mock.Anything, mock.Anything).Return(stderrors.New("connection reset"))
+
+	_, err := service.DeactivateUser(context.Background(), userID.String(), dto.DeactivateUserRequest{Reason: "left the school"}, "admin-1")
+
+	// La transacción se revierte y los tokens siguen vigentes
+	require.Error(t, err)
+	mockRevoker.AssertNotCalled(t, "RevokeUserTokens", mock.Anything, mock.Anything)
+}
+
+func TestDeactivateUser_RequiresReason(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	service := NewUserLifecycleService(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, passthroughTxManager{}, nil, newTestLogger())
+
+	_, err := service.DeactivateUser(context.Background(), uuid.New().String(), dto.DeactivateUserRequest{}, "admin-1")
+
+	require.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "FindByID")
+}
+
+func TestReactivateUser_RestoresMemberships(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	service := NewUserLifecycleService(mockUserRepo, mockMembershipRepo, new(MockAcademicUnitRepository), nil, mockStatusRepo, new(MockAcademicPeriodRepository), newTestQuotaService(liveSchools(), mockMembershipRepo), noUnitCapacities(), passthroughTxManager{}, nil, newTestLogger())
+
+	userID := uuid.New()
+	withdrawnAt := time.Now().Add(-time.Hour)
+	membership := &entities.Membership{ID: uuid.New(), UserID: userID, IsActive: true, WithdrawnAt: &withdrawnAt}
+
+	mockUserRepo.On("FindByID", mock.Anything, userID).Return(&entities.User{ID: userID, IsActive: false}, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockStatusRepo.On("FindLatestByUser", mock.Anything, userID, repository.UserStatusActionDeactivated).
+		Return(&repository.UserStatusChange{MembershipIDs: []uuid.UUID{membership.ID}}, nil)
+	mockMembershipRepo.On("FindByID", mock.Anything, membership.ID).Return(membership, nil)
+	mockMembershipRepo.On("Update", mock.Anything, membership).Return(nil)
+	mockStatusRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := service.ReactivateUser(context.Background(), userID.String(), dto.ReactivateUserRequest{RestoreMemberships: true}, "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, repository.UserStatusActionReactivated, result.Action)
+	assert.Equal(t, []string{membership.ID.String()}, result.MembershipIDs)
+	assert.Nil(t, membership.WithdrawnAt)
+	mockMembershipRepo.AssertExpectations(t)
+}
+
+func TestDeactivateUser_PromotesWaitlistIntoFreedSeat(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockMembershipRepo := new(MockUnitMembershipRepository)
+	mockGuardianRepo := new(MockGuardianRepository)
+	mockStatusRepo := new(MockUserStatusChangeRepository)
+	capacityService := new(MockUnitCapacityService)
+	service := NewUserLifecycleService(mockUserRepo, mockMembershipRepo, new(MockAcademicUnitRepository), mockGuardianRepo, mockStatusRepo, new(MockAcademicPeriodRepository), newTestQuotaService(liveSchools(), mockMembershipRepo), capacityService, passthroughTxManager{}, nil, newTestLogger())
+
+	userID := uuid.New()
+	unitID := uuid.New()
+	student := &entities.Membership{ID: uuid.New(), UserID: userID, AcademicUnitID: &unitID, Role: "student", IsActive: true}
+
+	mockUserRepo.On("FindByID", mock.Anything, userID).Return(&entities.User{ID: userID, IsActive: true}, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockMembershipRepo.On("FindByUser", mock.Anything, userID).Return([]*entities.Membership{student}, nil)
+	mockMembershipRepo.On("Update", mock.Anything, student).Return(nil)
+	mockGuardianRepo.On("FindByGuardian", mock.Anything, userID).Return([]*entities.GuardianRelation{}, nil)
+	mockGuardianRepo.On("FindByStudent", mock.Anything, userID).Return([]*entities.GuardianRelation{}, nil)
+	mockStatusRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	capacityService.On("FillFreedSeats", mock.Anything, unitID).Return(1, nil).Once()
+
+	_, err := service.DeactivateUser(context.Background(), userID.String(), dto.DeactivateUserRequest{Reason: "left the school"}, "admin-1")
+
+	require.NoError(t, err)
+	capacityService.AssertExpectations(t)
+}
+
+func TestReactivateUser_RestoredMembershipsAreRechecked(t *testing.T) {
+	schoolID := uuid.New()
+	unitID := uuid.New()
+
+	tests := []struct {
+		name  string
+		setup func(periodRepo *MockAcademicPeriodRepository, capacityService *MockUnitCapacityService)
+	}{
+		{
+			name: "closed academic year",
+			setup: func(periodRepo *MockAcademicPeriodRepository, capacityService *MockUnitCapacityService) {
+				periodRepo.On("FindYear", mock.Anything, schoolID, 2025).Return(&repository.AcademicPeriod{Status: repository.AcademicPeriodClosed}, nil)
+			},
+		},
+		{
+			name: "unit at capacity",
+			setup: func(periodRepo *MockAcademicPeriodRepository, capacityService *MockUnitCapacityService) {
+				periodRepo.On("FindYear", mock.Anything, schoolID, 2025).Return(nil, nil)
+				capacityService.On("ReserveSeat", mock.Anything, schoolID, unitID, mock.Anything, false).
+					Return(nil, errors.NewBusinessRuleError("unit is at capacity"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockMembershipRepo := new(MockUnitMembershipRepository)
+			mockUnitRepo := new(MockAcademicUnitRepository)
+			mockStatusRepo := new(MockUserStatusChangeRepository)
+			periodRepo := new(MockAcademicPeriodRepository)
+			capacityService := new(MockUnitCapacityService)
+			tt.setup(periodRepo, capacityService)
+			service := NewUserLifecycleService(mockUserRepo, mockMembershipRepo, mockUnitRepo, nil, mockStatusRepo, periodRepo, newTestQuotaService(liveSchools(), mockMembershipRepo), capacityService, passthroughTxManager{}, nil, newTestLogger())
+
+			userID := uuid.New()
+			withdrawnAt := time.Now().Add(-time.Hour)
+			membership := &entities.Membership{ID: uuid.New(), UserID: userID, SchoolID: schoolID, AcademicUnitID: &unitID, Role: "student", IsActive: true, WithdrawnAt: &withdrawnAt}
+
+			mockUserRepo.On("FindByID", mock.Anything, userID).Return(&entities.User{ID: userID, IsActive: false}, nil)
+			mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			mockStatusRepo.On("FindLatestByUser", mock.Anything, userID, repository.UserStatusActionDeactivated).
+				Return(&repository.UserStatusChange{MembershipIDs: []uuid.UUID{membership.ID}}, nil)
+			mockMembershipRepo.On("FindByID", mock.Anything, membership.ID).Return(membership, nil)
+			mockUnitRepo.On("FindByID", mock.Anything, unitID, true).Return(&entities.AcademicUnit{ID: unitID, SchoolID: schoolID, AcademicYear: 2025}, nil)
+
+			_, err := service.ReactivateUser(context.Background(), userID.String(), dto.ReactivateUserRequest{RestoreMemberships: true}, "admin-1")
+
+			require.Error(t, err)
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, 422, appErr.StatusCode)
+			assert.NotNil(t, membership.WithdrawnAt)
+			mockMembershipRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// UserProfileService define las operaciones de autoservicio sobre el perfil propio
+type UserProfileService interface {
+	// GetMyProfile obtiene el usuario autenticado con su perfil
+	GetMyProfile(ctx context.Context, userID string) (*dto.MeResponse, error)
+
+	// UpdateMyProfile actualiza nombre, perfil y preferencias del usuario autenticado
+	UpdateMyProfile(ctx context.Context, userID string, req dto.UpdateMyProfileRequest) (*dto.MeResponse, error)
+}
+
+type userProfileService struct {
+	userRepo    repository.UserRepository
+	profileRepo repository.UserProfileRepository
+	txManager   repository.TransactionManager
+	logger      logger.Logger
+}
+
+// NewUserProfileService crea un nuevo UserProfileService
+func NewUserProfileService(
+	userRepo repository.UserRepository,
+	profileRepo repository.UserProfileRepository,
+	txManager repository.TransactionManager,
+	logger logger.Logger,
+) UserProfileService {
+	return &userProfileService{
+		userRepo:    userRepo,
+		profileRepo: profileRepo,
+		txManager:   txManager,
+		logger:      logger,
+	}
+}
+
+func (s *userProfileService) GetMyProfile(ctx context.Context, userID string) (*dto.MeResponse, error) {
+	user, profile, err := s.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MeResponse{
+		UserResponse: dto.ToUserResponse(user),
+		Profile:      dto.ToUserProfileResponse(profile),
+	}, nil
+}
+
+func (s *userProfileService) UpdateMyProfile(ctx context.Context, userID string, req dto.UpdateMyProfileRequest) (*dto.MeResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, profile, err := s.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	userChanged := false
+	if req.FirstName != nil && *req.FirstName != user.FirstName {
+		user.FirstName = *req.FirstName
+		userChanged = true
+	}
+	if req.LastName != nil && *req.LastName != user.LastName {
+		user.LastName = *req.LastName
+		userChanged = true
+	}
+
+	if req.Phone != nil {
+		profile.Phone = optionalString(*req.Phone)
+	}
+	if req.PreferredLanguage != nil {
+		profile.PreferredLanguage = *req.PreferredLanguage
+	}
+	if req.Timezone != nil {
+		profile.Timezone = *req.Timezone
+	}
+	if req.DateOfBirth != nil {
+		dob, err := req.ParsedDateOfBirth()
+		if err != nil {
+			return nil, err
+		}
+		profile.DateOfBirth = dob
+	}
+	if req.AvatarURL != nil {
+		profile.AvatarURL = optionalString(*req.AvatarURL)
+	}
+	if prefs := req.NotificationPreferences; prefs != nil {
+		if prefs.Email != nil {
+			profile.NotificationPreferences.Email = *prefs.Email
+		}
+		if prefs.SMS != nil {
+			profile.NotificationPreferences.SMS = *prefs.SMS
+		}
+		if prefs.Push != nil {
+			profile.NotificationPreferences.Push = *prefs.Push
+		}
+		if prefs.Digest != nil {
+			profile.NotificationPreferences.Digest = *prefs.Digest
+		}
+	}
+	profile.UpdatedAt = now
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if userChanged {
+			user.UpdatedAt = now
+			if err := s.userRepo.Update(ctx, user); err != nil {
+				return errors.NewDatabaseError("update user", err)
+			}
+		}
+		if err := s.profileRepo.Upsert(ctx, profile); err != nil {
+			return errors.NewDatabaseError("save user profile", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("user profile updated", "user_id", user.ID.String())
+
+	return &dto.MeResponse{
+		UserResponse: dto.ToUserResponse(user),
+		Profile:      dto.ToUserProfileResponse(profile),
+	}, nil
+}
+
+// load obtiene el usuario y su perfil; si aún no tiene perfil retorna uno con valores por defecto
+func (s *userProfileService) load(ctx context.Context, userID string) (*entities.User, *repository.UserProfile, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, nil, errors.NewValidationError("invalid user_id format")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("find user", err)
+	}
+	if user == nil {
+		return nil, nil, errors.NewNotFoundError("user")
+	}
+
+	profile, err := s.profileRepo.FindByUserID(ctx, id)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("find user profile", err)
+	}
+	if profile == nil {
+		now := time.Now()
+		profile = &repository.UserProfile{
+			UserID:            id,
+			PreferredLanguage: dto.DefaultPreferredLanguage,
+			Timezone:          dto.DefaultTimezone,
+			NotificationPreferences: repository.NotificationPreferences{
+				Email:  true,
+				Digest: "none",
+			},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	return user, profile, nil
+}
+
+// optionalString convierte un string vacío en nil (borra el campo)
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
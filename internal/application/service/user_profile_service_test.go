@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// MockUserProfileRepository mock implementation
+type MockUserProfileRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserProfileRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*repository.UserProfile, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UserProfile), args.Error(1)
+}
+
+func (m *MockUserProfileRepository) FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*repository.UserProfile, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.UserProfile), args.Error(1)
+}
+
+func (m *MockUserProfileRepository) Upsert(ctx context.Context, profile *repository.UserProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *MockUserProfileRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestGetMyProfile_DefaultsWhenMissing(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	service := NewUserProfileService(mockUserRepo, mockProfileRepo, passthroughTxManager{}, newTestLogger())
+
+	user := &entities.User{ID: uuid.New(), Email: "ana@example.com", FirstName: "Ana", LastName: "Diaz", IsActive: true}
+	mockUserRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	mockProfileRepo.On("FindByUserID", mock.Anything, user.ID).Return(nil, nil)
+
+	result, err := service.GetMyProfile(context.Background(), user.ID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, "ana@example.com", result.Email)
+	assert.Equal(t, dto.DefaultPreferredLanguage, result.Profile.PreferredLanguage)
+	assert.Equal(t, dto.DefaultTimezone, result.Profile.Timezone)
+	assert.True(t, result.Profile.NotificationPreferences.Email)
+	mockProfileRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
+
+func TestUpdateMyProfile_PatchesAndClears(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockProfileRepo := new(MockUserProfileRepository)
+	service := NewUserProfileService(mockUserRepo, mockProfileRepo, passthroughTxManager{}, newTestLogger())
+
+	user := &entities.User{ID: uuid.New(), FirstName: "Ana", LastName: "Diaz"}
+	existing := &repository.UserProfile{
+		UserID:            user.ID,
+		Phone:             strPtr("+56 9 1234 5678"),
+		PreferredLanguage: "es",
+		Timezone:          "UTC",
+		NotificationPreferences: repository.NotificationPreferences{
+			Email: true, Digest: "none",
+		},
+	}
+	mockUserRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	mockProfileRepo.On("FindByUserID", mock.Anything, user.ID).Return(existing, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.FirstName == "Anita"
+	})).Return(nil).Once()
+	mockProfileRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(p *repository.UserProfile) bool {
+		return p.Phone == nil && p.Timezone == "America/Santiago" && p.DateOfBirth != nil &&
+			p.NotificationPreferences.Email && p.NotificationPreferences.Digest == "weekly"
+	})).Return(nil).Once()
+
+	digest := "weekly"
+	result, err := service.UpdateMyProfile(context.Background(), user.ID.String(), dto.UpdateMyProfileRequest{
+		FirstName:               strPtr("Anita"),
+		Phone:                   strPtr(""),
+		Timezone:                strPtr("America/Santiago"),
+		DateOfBirth:             strPtr("1990-05-17"),
+		NotificationPreferences: &dto.NotificationPreferencesPatch{Digest: &digest},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Anita Diaz", result.FullName)
+	assert.Equal(t, "1990-05-17", *result.Profile.DateOfBirth)
+	mockUserRepo.AssertExpectations(t)
+	mockProfileRepo.AssertExpectations(t)
+}
+
+func TestUpdateMyProfile_InvalidInput(t *testing.T) {
+	service := NewUserProfileService(nil, nil, passthroughTxManager{}, newTestLogger())
+
+	cases := map[string]dto.UpdateMyProfileRequest{
+		"timezone":      {Timezone: strPtr("Mars/Olympus")},
+		"language":      {PreferredLanguage: strPtr("fr")},
+		"future dob":    {DateOfBirth: strPtr("2999-01-01")},
+		"bad dob":       {DateOfBirth: strPtr("17/05/1990")},
+		"phone":         {Phone: strPtr("call me maybe")},
+		"avatar scheme": {AvatarURL: strPtr("ftp://cdn.example.com/a.png")},
+	}
+
+	for name, req := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.UpdateMyProfile(context.Background(), uuid.New().String(), req)
+
+			assert.Error(t, err)
+		})
+	}
+}
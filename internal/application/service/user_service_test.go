@@ -65,6 +65,59 @@ func (m *MockUserRepository) ExistsByEmail(ctx context.Context, email string) (b
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockUserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Count(ctx context.Context, filters repository.ListFilters) (int, error) {
+	args := m.Called(ctx, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserRepository) FindEmailDuplicates(ctx context.Context, filters repository.ListFilters) ([][]uuid.UUID, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([][]uuid.UUID), args.Error(1)
+}
+
+func (m *MockUserRepository) FindPhoneDuplicates(ctx context.Context, filters repository.ListFilters, minDigits int) ([][]uuid.UUID, error) {
+	args := m.Called(ctx, filters, minDigits)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([][]uuid.UUID), args.Error(1)
+}
+
+func (m *MockUserRepository) ListNameBlocks(ctx context.Context, filters repository.ListFilters) ([]string, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepository) ListByNameBlock(ctx context.Context, filters repository.ListFilters, block string) ([]*entities.User, error) {
+	args := m.Called(ctx, filters, block)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
+
 // Tests
 
 func TestCreateUser_Success(t *testing.T) {
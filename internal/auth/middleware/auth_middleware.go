@@ -1,14 +1,69 @@
 // Package middleware contiene middlewares para autenticación
 package middleware
 
-// AuthMiddleware valida tokens JWT en requests entrantes
-// Extrae el token del header Authorization y valida con TokenService
-// Será implementado en FASE 2 del Sprint 1
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/auth/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// TokenRevocationChecker indica si un token fue revocado (blacklist o revocación del usuario)
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, token string) (bool, error)
+}
+
+// AuthMiddleware rechaza tokens revocados en las rutas protegidas.
+// Se aplica después de JWTAuthMiddleware, que ya validó firma y expiración.
 type AuthMiddleware struct {
-	// tokenService service.TokenService
+	checker TokenRevocationChecker
+	logger  logger.Logger
 }
 
 // NewAuthMiddleware crea una nueva instancia de AuthMiddleware
-// func NewAuthMiddleware(tokenService service.TokenService) *AuthMiddleware {
-// 	return &AuthMiddleware{tokenService: tokenService}
-// }
+func NewAuthMiddleware(checker TokenRevocationChecker, logger logger.Logger) *AuthMiddleware {
+	return &AuthMiddleware{checker: checker, logger: logger}
+}
+
+// Middleware retorna el middleware de Gin
+func (m *AuthMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+		if token == "" {
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Token requerido",
+				Code:    "TOKEN_REQUIRED",
+			})
+			c.Abort()
+			return
+		}
+
+		revoked, err := m.checker.IsTokenRevoked(c.Request.Context(), token)
+		if err != nil {
+			m.logger.Error("error verificando revocación del token", "error", err)
+			c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+				Error:   "service_unavailable",
+				Message: "No se pudo verificar el token",
+				Code:    "VERIFICATION_ERROR",
+			})
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Token revocado",
+				Code:    "TOKEN_REVOKED",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
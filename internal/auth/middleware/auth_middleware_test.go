@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+type stubRevocationChecker struct {
+	revoked bool
+	err     error
+}
+
+func (s stubRevocationChecker) IsTokenRevoked(_ context.Context, _ string) (bool, error) {
+	return s.revoked, s.err
+}
+
+func performAuthRequest(checker TokenRevocationChecker, authHeader string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Use(NewAuthMiddleware(checker, logger.NewZapLogger("error", "console")).Middleware())
+	router.GET("/v1/me", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/me", nil)
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthMiddleware_AllowsValidToken(t *testing.T) {
+	w := performAuthRequest(stubRevocationChecker{}, "Bearer valid-token")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthMiddleware_RejectsRevokedToken(t *testing.T) {
+	w := performAuthRequest(stubRevocationChecker{revoked: true}, "Bearer revoked-token")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "TOKEN_REVOKED")
+}
+
+func TestAuthMiddleware_MissingToken(t *testing.T) {
+	w := performAuthRequest(stubRevocationChecker{}, "")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "TOKEN_REQUIRED")
+}
+
+func TestAuthMiddleware_StoreUnavailable(t *testing.T) {
+	w := performAuthRequest(stubRevocationChecker{err: errors.New("connection refused")}, "Bearer token")
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
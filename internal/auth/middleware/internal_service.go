@@ -1,14 +1,42 @@
 // Package middleware contiene middlewares para autenticación
 package middleware
 
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/auth/dto"
+)
+
+// InternalServiceChecker identifica requests de servicios internos (API Key o rango IP)
+type InternalServiceChecker interface {
+	IsInternalService(c *gin.Context) bool
+}
+
 // InternalServiceMiddleware valida que las requests vengan de servicios internos autorizados
-// Verifica API Key y rango IP
-// Será implementado en FASE 2 del Sprint 1
 type InternalServiceMiddleware struct {
-	// config *config.InternalServicesConfig
+	checker InternalServiceChecker
 }
 
 // NewInternalServiceMiddleware crea una nueva instancia
-// func NewInternalServiceMiddleware(config *config.InternalServicesConfig) *InternalServiceMiddleware {
-// 	return &InternalServiceMiddleware{config: config}
-// }
+func NewInternalServiceMiddleware(checker InternalServiceChecker) *InternalServiceMiddleware {
+	return &InternalServiceMiddleware{checker: checker}
+}
+
+// Middleware retorna el middleware de Gin
+func (m *InternalServiceMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.checker.IsInternalService(c) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: "Endpoint reservado para servicios internos",
+				Code:    "INTERNAL_ONLY",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -45,6 +45,7 @@ type AuthService interface {
 type authService struct {
 	membershipRepo repository.UnitMembershipRepository
 	userRepo       repository.UserRepository
+	loginEventRepo repository.LoginEventRepository
 	tokenService   *TokenService
 	passwordHasher *crypto.PasswordHasher
 	logger         logger.Logger
@@ -54,6 +55,7 @@ type authService struct {
 func NewAuthService(
 	membershipRepo repository.UnitMembershipRepository,
 	userRepo repository.UserRepository,
+	loginEventRepo repository.LoginEventRepository,
 	tokenService *TokenService,
 	passwordHasher *crypto.PasswordHasher,
 	logger logger.Logger,
@@ -61,6 +63,7 @@ func NewAuthService(
 	return &authService{
 		membershipRepo: membershipRepo,
 		userRepo:       userRepo,
+		loginEventRepo: loginEventRepo,
 		tokenService:   tokenService,
 		passwordHasher: passwordHasher,
 		logger:         logger,
@@ -82,12 +85,14 @@ func (s *authService) Login(ctx context.Context, email, password string) (*dto.L
 	// 2. Verificar que el usuario está activo
 	if !user.IsActive {
 		s.logger.Warn("intento de login con usuario inactivo", "email", email, "user_id", user.ID.String())
+		s.recordLogin(ctx, user.ID, repository.LoginResultInactiveUser, nil)
 		return nil, ErrUserInactive
 	}
 
 	// 3. Verificar password
 	if err := s.passwordHasher.Compare(password, user.PasswordHash); err != nil {
 		s.logger.Warn("password incorrecto", "email", email)
+		s.recordLogin(ctx, user.ID, repository.LoginResultBadPassword, nil)
 		return nil, ErrInvalidCredentials
 	}
 
@@ -126,6 +131,7 @@ func (s *authService) Login(ctx context.Context, email, password string) (*dto.L
 		"role", user.Role,
 		"school_id", schoolID,
 	)
+	s.recordLogin(ctx, user.ID, repository.LoginResultSuccess, user.SchoolID)
 
 	// 7. Actualizar último login (fire and forget)
 	go func() {
@@ -141,6 +147,23 @@ func (s *authService) Login(ctx context.Context, email, password string) (*dto.L
 	return tokenResponse, nil
 }
 
+// recordLogin guarda el intento en el historial de login; un fallo no bloquea la autenticación
+func (s *authService) recordLogin(ctx context.Context, userID uuid.UUID, result string, schoolID *uuid.UUID) {
+	if s.loginEventRepo == nil {
+		return
+	}
+	event := &repository.LoginEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Result:     result,
+		SchoolID:   schoolID,
+		OccurredAt: time.Now(),
+	}
+	if err := s.loginEventRepo.Create(ctx, event); err != nil {
+		s.logger.Warn("error registrando historial de login", "user_id", userID.String(), "error", err)
+	}
+}
+
 // Logout invalida el access token agregándolo a la blacklist
 func (s *authService) Logout(ctx context.Context, accessToken string) error {
 	// Revocar el token (agregarlo a blacklist)
@@ -36,11 +36,19 @@ type TokenServiceConfig struct {
 	BlacklistCheck bool
 }
 
+// UserRevocationStore registra revocaciones de todos los tokens de un usuario.
+// Un token emitido antes (o en el mismo segundo) de la revocación se considera inválido.
+type UserRevocationStore interface {
+	RevokeUser(ctx context.Context, userID string, revokedAt time.Time, ttl time.Duration) error
+	RevokedAt(ctx context.Context, userID string) (time.Time, bool, error)
+}
+
 // TokenService gestiona operaciones de tokens
 type TokenService struct {
-	jwtManager *crypto.JWTManager
-	cache      TokenCache
-	config     TokenServiceConfig
+	jwtManager      *crypto.JWTManager
+	cache           TokenCache
+	config          TokenServiceConfig
+	userRevocations UserRevocationStore
 }
 
 // NewTokenService crea una nueva instancia
@@ -60,14 +68,71 @@ func NewTokenService(
 	}
 }
 
+// SetUserRevocationStore habilita la revocación de todos los tokens de un usuario
+func (s *TokenService) SetUserRevocationStore(store UserRevocationStore) {
+	s.userRevocations = store
+}
+
+// RevokeUserTokens invalida todos los tokens emitidos hasta ahora para el usuario
+func (s *TokenService) RevokeUserTokens(ctx context.Context, userID string) error {
+	if s.userRevocations == nil {
+		return ErrCacheUnavailable
+	}
+
+	// La revocación debe durar al menos lo que dura el token más largo (refresh)
+	ttl := s.jwtManager.GetConfig().RefreshTokenDuration
+	if err := s.userRevocations.RevokeUser(ctx, userID, time.Now(), ttl); err != nil {
+		return fmt.Errorf("error revocando tokens del usuario: %w", err)
+	}
+	return nil
+}
+
+// isRevokedForUser indica si el token fue emitido antes de una revocación del usuario
+func (s *TokenService) isRevokedForUser(ctx context.Context, claims *crypto.Claims) (bool, error) {
+	if s.userRevocations == nil || claims.IssuedAt == nil {
+		return false, nil
+	}
+
+	userID := claims.UserID
+	if userID == "" {
+		userID = claims.Subject
+	}
+
+	revokedAt, found, err := s.userRevocations.RevokedAt(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("error consultando revocaciones del usuario: %w", err)
+	}
+	if !found {
+		return false, nil
+	}
+	// iat tiene precisión de segundos
+	return !claims.IssuedAt.Time.After(revokedAt.Truncate(time.Second)), nil
+}
+
+// IsTokenRevoked indica si un token que ya pasó la validación de firma fue revocado,
+// ya sea individualmente (blacklist) o por una revocación de todos los tokens del usuario.
+// Un token que no valida se considera revocado.
+func (s *TokenService) IsTokenRevoked(ctx context.Context, token string) (bool, error) {
+	claims, err := s.jwtManager.ValidateToken(token)
+	if err != nil {
+		return true, nil
+	}
+
+	if s.config.BlacklistCheck && s.cache != nil && s.cache.IsBlacklisted(ctx, claims.ID) {
+		return true, nil
+	}
+
+	return s.isRevokedForUser(ctx, claims)
+}
+
 // VerifyToken verifica un token y retorna información del usuario
 func (s *TokenService) VerifyToken(ctx context.Context, token string) (*dto.VerifyTokenResponse, error) {
 	// 1. Generar hash del token para cache key
 	cacheKey := s.hashToken(token)
 
-	// 2. Verificar cache
+	// 2. Verificar cache (se ignora si el usuario fue revocado dentro de la ventana del cache)
 	if s.config.CacheEnabled && s.cache != nil {
-		if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if cached, found := s.cache.Get(ctx, cacheKey); found && !s.recentlyRevoked(ctx, cached.UserID) {
 			return cached, nil
 		}
 	}
@@ -93,6 +158,18 @@ func (s *TokenService) VerifyToken(ctx context.Context, token string) (*dto.Veri
 		}
 	}
 
+	// 4b. Verificar revocación masiva del usuario (ej: desactivación)
+	revoked, err := s.isRevokedForUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return &dto.VerifyTokenResponse{
+			Valid: false,
+			Error: "token revocado",
+		}, nil
+	}
+
 	// 5. Construir response
 	expiresAt := claims.ExpiresAt.Time
 	response := &dto.VerifyTokenResponse{
@@ -135,6 +212,16 @@ func (s *TokenService) VerifyTokenBulk(ctx context.Context, tokens []string) (*d
 	return &dto.VerifyTokenBulkResponse{Results: results}, nil
 }
 
+// recentlyRevoked indica si el usuario fue revocado dentro de la ventana del cache.
+// Si no se puede consultar se ignora el cache y la verificación completa decide.
+func (s *TokenService) recentlyRevoked(ctx context.Context, userID string) bool {
+	if s.userRevocations == nil || userID == "" {
+		return false
+	}
+	revokedAt, found, err := s.userRevocations.RevokedAt(ctx, userID)
+	return err != nil || (found && time.Since(revokedAt) < s.config.CacheTTL)
+}
+
 // RevokeToken agrega un token a la blacklist
 func (s *TokenService) RevokeToken(ctx context.Context, token string) error {
 	// Extraer token ID
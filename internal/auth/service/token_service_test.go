@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-administracion/internal/auth/dto"
+	mockRepo "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/persistence/mock/repository"
 	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/crypto"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -420,3 +422,60 @@ func TestTokenService_CalculateCacheTTL(t *testing.T) {
 	ttl2 := service.calculateCacheTTL(time.Now().Add(30 * time.Second))
 	assert.LessOrEqual(t, ttl2, 30*time.Second)
 }
+
+func TestTokenService_VerifyToken_RevokedForUser(t *testing.T) {
+	// Arrange
+	jwtManager := createTestJWTManager(t)
+	config := TokenServiceConfig{
+		CacheTTL:       60 * time.Second,
+		CacheEnabled:   false,
+		BlacklistCheck: false,
+	}
+
+	service := NewTokenService(jwtManager, nil, config)
+	service.SetUserRevocationStore(NewInMemoryUserRevocationStore())
+
+	token, _, err := jwtManager.GenerateAccessToken("user-123", "test@example.com", "teacher", "")
+	require.NoError(t, err)
+	otherToken, _, err := jwtManager.GenerateAccessToken("user-456", "other@example.com", "teacher", "")
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, service.RevokeUserTokens(context.Background(), "user-123"))
+	result, err := service.VerifyToken(context.Background(), token)
+	require.NoError(t, err)
+	otherResult, err := service.VerifyToken(context.Background(), otherToken)
+	require.NoError(t, err)
+
+	// Assert
+	assert.False(t, result.Valid)
+	assert.Equal(t, "token revocado", result.Error)
+	assert.True(t, otherResult.Valid)
+}
+
+func TestTokenService_IsTokenRevoked_SharedStore(t *testing.T) {
+	// Arrange: dos instancias de la API comparten el mismo repositorio de revocaciones
+	jwtManager := createTestJWTManager(t)
+	repo := mockRepo.NewMockUserTokenRevocationRepository()
+
+	instanceA := NewTokenService(jwtManager, nil, TokenServiceConfig{})
+	instanceA.SetUserRevocationStore(NewRepositoryUserRevocationStore(repo))
+	instanceB := NewTokenService(jwtManager, nil, TokenServiceConfig{})
+	instanceB.SetUserRevocationStore(NewRepositoryUserRevocationStore(repo))
+
+	userID := uuid.New().String()
+	token, _, err := jwtManager.GenerateAccessToken(userID, "test@example.com", "teacher", "")
+	require.NoError(t, err)
+
+	revoked, err := instanceB.IsTokenRevoked(context.Background(), token)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Act
+	require.NoError(t, instanceA.RevokeUserTokens(context.Background(), userID))
+
+	// Assert
+	revoked, err = instanceB.IsTokenRevoked(context.Background(), token)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// InMemoryUserRevocationStore implementa UserRevocationStore en memoria del proceso.
+// Con varias instancias cada una solo conoce sus revocaciones: sirve para tests y desarrollo local.
+type InMemoryUserRevocationStore struct {
+	mu      sync.RWMutex
+	entries map[string]userRevocation
+}
+
+type userRevocation struct {
+	revokedAt time.Time
+	expiresAt time.Time
+}
+
+// NewInMemoryUserRevocationStore crea un store vacío
+func NewInMemoryUserRevocationStore() *InMemoryUserRevocationStore {
+	return &InMemoryUserRevocationStore{
+		entries: make(map[string]userRevocation),
+	}
+}
+
+// RevokeUser registra la revocación del usuario por el tiempo indicado
+func (s *InMemoryUserRevocationStore) RevokeUser(_ context.Context, userID string, revokedAt time.Time, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[userID] = userRevocation{revokedAt: revokedAt, expiresAt: revokedAt.Add(ttl)}
+	return nil
+}
+
+// RevokedAt retorna el momento de la última revocación vigente del usuario
+func (s *InMemoryUserRevocationStore) RevokedAt(_ context.Context, userID string) (time.Time, bool, error) {
+	s.mu.RLock()
+	entry, ok := s.entries[userID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		s.mu.Lock()
+		delete(s.entries, userID)
+		s.mu.Unlock()
+		return time.Time{}, false, nil
+	}
+	return entry.revokedAt, true, nil
+}
+
+// RepositoryUserRevocationStore implementa UserRevocationStore sobre la base de datos,
+// compartida por todas las instancias de la API
+type RepositoryUserRevocationStore struct {
+	repo repository.UserTokenRevocationRepository
+}
+
+// NewRepositoryUserRevocationStore crea un store persistente
+func NewRepositoryUserRevocationStore(repo repository.UserTokenRevocationRepository) *RepositoryUserRevocationStore {
+	return &RepositoryUserRevocationStore{repo: repo}
+}
+
+// RevokeUser registra la revocación del usuario por el tiempo indicado
+func (s *RepositoryUserRevocationStore) RevokeUser(ctx context.Context, userID string, revokedAt time.Time, ttl time.Duration) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("user id inválido: %w", err)
+	}
+	return s.repo.Upsert(ctx, id, revokedAt, revokedAt.Add(ttl))
+}
+
+// RevokedAt retorna el momento de la última revocación vigente del usuario
+func (s *RepositoryUserRevocationStore) RevokedAt(ctx context.Context, userID string) (time.Time, bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		// Nunca se registran revocaciones para IDs que no son UUID
+		return time.Time{}, false, nil
+	}
+	revokedAt, err := s.repo.FindRevokedAt(ctx, id)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if revokedAt == nil {
+		return time.Time{}, false, nil
+	}
+	return *revokedAt, true, nil
+}
@@ -6,14 +6,17 @@ import (
 )
 
 type Config struct {
-	Environment string         `mapstructure:"environment"`
-	Server      ServerConfig   `mapstructure:"server"`
-	Database    DatabaseConfig `mapstructure:"database"`
-	Logging     LoggingConfig  `mapstructure:"logging"`
-	Auth        AuthConfig     `mapstructure:"auth"`
-	Redis       RedisConfig    `mapstructure:"redis"`
-	Defaults    DefaultsConfig `mapstructure:"defaults"`
-	CORS        CORSConfig     `mapstructure:"cors"`
+	Environment   string              `mapstructure:"environment"`
+	Server        ServerConfig        `mapstructure:"server"`
+	Database      DatabaseConfig      `mapstructure:"database"`
+	Logging       LoggingConfig       `mapstructure:"logging"`
+	Auth          AuthConfig          `mapstructure:"auth"`
+	Redis         RedisConfig         `mapstructure:"redis"`
+	Defaults      DefaultsConfig      `mapstructure:"defaults"`
+	Subscriptions SubscriptionsConfig `mapstructure:"subscriptions"`
+	Onboarding    OnboardingConfig    `mapstructure:"onboarding"`
+	Lifecycle     LifecycleConfig     `mapstructure:"lifecycle"`
+	CORS          CORSConfig          `mapstructure:"cors"`
 }
 
 type ServerConfig struct {
@@ -57,6 +60,9 @@ type AuthConfig struct {
 	RateLimit        RateLimitConfig        `mapstructure:"rate_limit"`
 	InternalServices InternalServicesConfig `mapstructure:"internal_services"`
 	Cache            AuthCacheConfig        `mapstructure:"cache"`
+	Invitations      InvitationsConfig      `mapstructure:"invitations"`
+	MFA              MFAConfig              `mapstructure:"mfa"`
+	AdminApproval    AdminApprovalConfig    `mapstructure:"admin_approval"`
 }
 
 // JWTConfig configuración de tokens JWT
@@ -104,6 +110,25 @@ type InternalServicesConfig struct {
 	IPRanges string `mapstructure:"ip_ranges"` // ENV: AUTH_INTERNAL_SERVICES_IP_RANGES formato CIDR
 }
 
+// InvitationsConfig configuración de invitaciones a escuelas
+type InvitationsConfig struct {
+	TTL           time.Duration `mapstructure:"ttl"`            // ENV: AUTH_INVITATIONS_TTL
+	AcceptURL     string        `mapstructure:"accept_url"`     // ENV: AUTH_INVITATIONS_ACCEPT_URL - el token se agrega como ?token=
+	SigningSecret string        `mapstructure:"signing_secret"` // ENV: AUTH_INVITATIONS_SIGNING_SECRET - distinto del secret JWT
+}
+
+// MFAConfig configuración del segundo factor (TOTP)
+type MFAConfig struct {
+	Issuer       string        `mapstructure:"issuer"`        // ENV: AUTH_MFA_ISSUER - nombre mostrado en la app autenticadora
+	MaxAttempts  int           `mapstructure:"max_attempts"`  // ENV: AUTH_MFA_MAX_ATTEMPTS - códigos inválidos seguidos antes del bloqueo
+	LockDuration time.Duration `mapstructure:"lock_duration"` // ENV: AUTH_MFA_LOCK_DURATION
+}
+
+// AdminApprovalConfig configuración de la aprobación de cambios de administradores por un segundo admin
+type AdminApprovalConfig struct {
+	TTL time.Duration `mapstructure:"ttl"` // ENV: AUTH_ADMIN_APPROVAL_TTL - vigencia de una solicitud pendiente
+}
+
 // AuthCacheConfig configuración de cache para autenticación
 type AuthCacheConfig struct {
 	TokenValidation CacheItemConfig `mapstructure:"token_validation"`
@@ -138,6 +163,10 @@ func (c *RedisConfig) GetRedisAddr() string {
 // DefaultsConfig contiene todas las configuraciones de valores por defecto
 type DefaultsConfig struct {
 	School SchoolDefaults `mapstructure:"school"`
+	Quotas QuotaDefaults  `mapstructure:"quotas"`
+	// SchoolSettings es el documento global de configuración que heredan todas las
+	// escuelas; debe cumplir el schema de settings completo (solo archivo, sin ENV)
+	SchoolSettings map[string]interface{} `mapstructure:"school_settings"`
 }
 
 // SchoolDefaults contiene los valores por defecto para escuelas
@@ -148,6 +177,37 @@ type SchoolDefaults struct {
 	MaxStudents      int    `mapstructure:"max_students"`      // ENV: EDUGO_ADMIN_DEFAULTS_SCHOOL_MAX_STUDENTS
 }
 
+// QuotaDefaults contiene los umbrales de aviso de cupos del plan (porcentaje de uso)
+type QuotaDefaults struct {
+	WarningThresholds []int `mapstructure:"warning_thresholds"` // ENV: EDUGO_ADMIN_DEFAULTS_QUOTAS_WARNING_THRESHOLDS - formato CSV
+}
+
+// SubscriptionsConfig contiene el catálogo de planes de suscripción, ordenado de menor a mayor
+type SubscriptionsConfig struct {
+	Tiers []SubscriptionTierConfig `mapstructure:"tiers"`
+}
+
+// SubscriptionTierConfig define los límites (0 = sin límite) y features habilitados de un plan
+type SubscriptionTierConfig struct {
+	Name         string   `mapstructure:"name"`
+	DisplayName  string   `mapstructure:"display_name"`
+	MaxTeachers  int      `mapstructure:"max_teachers"`
+	MaxStudents  int      `mapstructure:"max_students"`
+	MaxUnits     int      `mapstructure:"max_units"`
+	MaxStorageMB int      `mapstructure:"max_storage_mb"`
+	Features     []string `mapstructure:"features"` // sso, api_tokens, exports
+}
+
+// OnboardingConfig contiene la configuración del onboarding de escuelas
+type OnboardingConfig struct {
+	TemplatesDir string `mapstructure:"templates_dir"` // ENV: EDUGO_ADMIN_ONBOARDING_TEMPLATES_DIR - plantillas de árbol de unidades (.yaml/.json)
+}
+
+// LifecycleConfig contiene la configuración del archivado y la purga de escuelas
+type LifecycleConfig struct {
+	PurgeRetention time.Duration `mapstructure:"purge_retention"` // ENV: EDUGO_ADMIN_LIFECYCLE_PURGE_RETENTION - tiempo mínimo archivada antes de poder purgar
+}
+
 // CORSConfig contiene la configuración de CORS
 type CORSConfig struct {
 	AllowedOrigins string `mapstructure:"allowed_origins"` // ENV: ALLOWED_ORIGINS - formato CSV
@@ -56,6 +56,56 @@ func Load() (*Config, error) {
 	v.SetDefault("auth.cache.user_info.ttl", "300s")
 	v.SetDefault("auth.cache.user_info.max_size", 1000)
 
+	// Defaults - Invitations
+	v.SetDefault("auth.invitations.ttl", "72h")
+	v.SetDefault("auth.invitations.accept_url", "http://localhost:3000/invitations/accept")
+	v.SetDefault("auth.mfa.issuer", "EduGo")
+	v.SetDefault("auth.mfa.max_attempts", 5)
+	v.SetDefault("auth.mfa.lock_duration", "15m")
+	v.SetDefault("auth.admin_approval.ttl", "24h")
+
+	// Defaults - Cupos de escuelas
+	v.SetDefault("defaults.quotas.warning_thresholds", []int{80, 90})
+
+	// Defaults - Configuración global heredada por las escuelas
+	v.SetDefault("defaults.school_settings", map[string]interface{}{
+		"locale":   "es-CO",
+		"timezone": "America/Bogota",
+		"grading_scale": map[string]interface{}{
+			"type": "numeric", "min": 1.0, "max": 5.0, "passing": 3.0, "decimals": 1,
+		},
+		"academic_calendar": map[string]interface{}{
+			"start_month": 1, "start_day": 15, "terms": 4,
+		},
+		"attendance": map[string]interface{}{
+			"late_after_minutes": 10, "absence_alert_percent": 15, "excused_requires_document": true,
+		},
+		"unit_nesting": map[string]interface{}{
+			"max_depth":  6,
+			"root_types": []string{"school", "grade", "club", "department"},
+			"allowed_parents": map[string]interface{}{
+				"school":     []string{},
+				"grade":      []string{"school"},
+				"section":    []string{"grade"},
+				"club":       []string{"school", "grade", "section", "department"},
+				"department": []string{"school", "department"},
+			},
+		},
+	})
+
+	// Defaults - Catálogo de planes (config/config.yaml puede reemplazarlo completo)
+	v.SetDefault("subscriptions.tiers", []map[string]interface{}{
+		{"name": "free", "display_name": "Free", "max_teachers": 50, "max_students": 500, "max_units": 100, "max_storage_mb": 1024, "features": []string{}},
+		{"name": "basic", "display_name": "Basic", "max_teachers": 200, "max_students": 2000, "max_units": 500, "max_storage_mb": 10240, "features": []string{"exports"}},
+		{"name": "premium", "display_name": "Premium", "max_teachers": 0, "max_students": 0, "max_units": 0, "max_storage_mb": 102400, "features": []string{"exports", "api_tokens", "sso"}},
+	})
+
+	// Defaults - Onboarding
+	v.SetDefault("onboarding.templates_dir", "./config/unit-templates")
+
+	// Defaults - Ciclo de vida de escuelas
+	v.SetDefault("lifecycle.purge_retention", "720h")
+
 	// Defaults - Redis
 	v.SetDefault("redis.host", "localhost")
 	v.SetDefault("redis.port", 6379)
@@ -127,6 +177,15 @@ func Load() (*Config, error) {
 	_ = v.BindEnv("auth.cache.token_validation.ttl", "AUTH_CACHE_TOKEN_VALIDATION_TTL")
 	_ = v.BindEnv("auth.cache.user_info.ttl", "AUTH_CACHE_USER_INFO_TTL")
 
+	// Invitations
+	_ = v.BindEnv("auth.invitations.ttl", "AUTH_INVITATIONS_TTL")
+	_ = v.BindEnv("auth.invitations.accept_url", "AUTH_INVITATIONS_ACCEPT_URL")
+	_ = v.BindEnv("auth.invitations.signing_secret", "AUTH_INVITATIONS_SIGNING_SECRET")
+	_ = v.BindEnv("auth.mfa.issuer", "AUTH_MFA_ISSUER")
+	_ = v.BindEnv("auth.mfa.max_attempts", "AUTH_MFA_MAX_ATTEMPTS")
+	_ = v.BindEnv("auth.mfa.lock_duration", "AUTH_MFA_LOCK_DURATION")
+	_ = v.BindEnv("auth.admin_approval.ttl", "AUTH_ADMIN_APPROVAL_TTL")
+
 	// Redis
 	_ = v.BindEnv("redis.host", "REDIS_HOST")
 	_ = v.BindEnv("redis.port", "REDIS_PORT")
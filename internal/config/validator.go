@@ -59,6 +59,17 @@ func Validate(cfg *Config) error {
 		validationErrors = append(validationErrors, "auth.jwt.refresh_token_duration must be positive")
 	}
 
+	// ============================================
+	// Validar Auth Invitations
+	// ============================================
+	if cfg.Auth.Invitations.SigningSecret == "" {
+		validationErrors = append(validationErrors, "AUTH_INVITATIONS_SIGNING_SECRET is required")
+	} else if len(cfg.Auth.Invitations.SigningSecret) < 32 {
+		validationErrors = append(validationErrors, "AUTH_INVITATIONS_SIGNING_SECRET must be at least 32 characters")
+	} else if cfg.Auth.Invitations.SigningSecret == cfg.Auth.JWT.Secret {
+		validationErrors = append(validationErrors, "AUTH_INVITATIONS_SIGNING_SECRET must be different from AUTH_JWT_SECRET")
+	}
+
 	// ============================================
 	// Validar Auth Rate Limiting
 	// ============================================
@@ -7,6 +7,7 @@ import (
 
 	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
 	authHandler "github.com/EduGoGroup/edugo-api-administracion/internal/auth/handler"
+	authMiddleware "github.com/EduGoGroup/edugo-api-administracion/internal/auth/middleware"
 	authService "github.com/EduGoGroup/edugo-api-administracion/internal/auth/service"
 	"github.com/EduGoGroup/edugo-api-administracion/internal/config"
 	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
@@ -26,45 +27,102 @@ type Container struct {
 	JWTManager *auth.JWTManager
 
 	// Auth (centralizado)
-	PasswordHasher    *crypto.PasswordHasher
+	PasswordHasher     *crypto.PasswordHasher
 	InternalJWTManager *crypto.JWTManager
-	TokenService      *authService.TokenService
-	AuthService       authService.AuthService
-	AuthHandler       *authHandler.AuthHandler
-	VerifyHandler     *authHandler.VerifyHandler
+	TokenService       *authService.TokenService
+	AuthService        authService.AuthService
+	AuthHandler        *authHandler.AuthHandler
+	VerifyHandler      *authHandler.VerifyHandler
+	AuthMiddleware     *authMiddleware.AuthMiddleware
 
 	// Repositories
-	UserRepository           repository.UserRepository
-	SchoolRepository         repository.SchoolRepository
-	AcademicUnitRepository   repository.AcademicUnitRepository
-	UnitMembershipRepository repository.UnitMembershipRepository
-	UnitRepository           repository.UnitRepository
-	SubjectRepository        repository.SubjectRepository
-	MaterialRepository       repository.MaterialRepository
-	StatsRepository          repository.StatsRepository
-	GuardianRepository       repository.GuardianRepository
+	UserRepository                repository.UserRepository
+	SchoolRepository              repository.SchoolRepository
+	AcademicUnitRepository        repository.AcademicUnitRepository
+	UnitMembershipRepository      repository.UnitMembershipRepository
+	UnitRepository                repository.UnitRepository
+	SubjectRepository             repository.SubjectRepository
+	MaterialRepository            repository.MaterialRepository
+	StatsRepository               repository.StatsRepository
+	GuardianRepository            repository.GuardianRepository
+	UserStatusChangeRepository    repository.UserStatusChangeRepository
+	DuplicateCandidateRepository  repository.DuplicateCandidateRepository
+	TransactionManager            repository.TransactionManager
+	InvitationRepository          repository.InvitationRepository
+	UserProfileRepository         repository.UserProfileRepository
+	LoginEventRepository          repository.LoginEventRepository
+	UserMFARepository             repository.UserMFARepository
+	AdminChangeRequestRepository  repository.AdminChangeRequestRepository
+	SubscriptionChangeRepository  repository.SubscriptionChangeRepository
+	SchoolSettingsRepository      repository.SchoolSettingsRepository
+	SchoolLifecycleRepository     repository.SchoolLifecycleRepository
+	AcademicPeriodRepository      repository.AcademicPeriodRepository
+	RolloverRepository            repository.RolloverRepository
+	UnitDeletionRepository        repository.UnitDeletionRepository
+	SchoolUnitTypeRepository      repository.SchoolUnitTypeRepository
+	UnitCapacityRepository        repository.UnitCapacityRepository
+	UserTokenRevocationRepository repository.UserTokenRevocationRepository
 
 	// Services
-	UserService           service.UserService
-	SchoolService         service.SchoolService
-	AcademicUnitService   service.AcademicUnitService
-	UnitMembershipService service.UnitMembershipService
-	UnitService           service.UnitService
-	SubjectService        service.SubjectService
-	MaterialService       service.MaterialService
-	StatsService          service.StatsService
-	GuardianService       service.GuardianService
+	UserService             service.UserService
+	SchoolService           service.SchoolService
+	AcademicUnitService     service.AcademicUnitService
+	AcademicPeriodService   service.AcademicPeriodService
+	UnitMembershipService   service.UnitMembershipService
+	SchoolQuotaService      service.SchoolQuotaService
+	SubscriptionService     service.SubscriptionService
+	OnboardingService       service.OnboardingService
+	SchoolSettingsService   service.SchoolSettingsService
+	SchoolLifecycleService  service.SchoolLifecycleService
+	SchoolCloneService      service.SchoolCloneService
+	RolloverService         service.RolloverService
+	UnitService             service.UnitService
+	SubjectService          service.SubjectService
+	MaterialService         service.MaterialService
+	StatsService            service.StatsService
+	GuardianService         service.GuardianService
+	UserImportService       service.UserImportService
+	ExportService           service.ExportService
+	UserLifecycleService    service.UserLifecycleService
+	UserDuplicateService    service.UserDuplicateService
+	InvitationService       service.InvitationService
+	UserProfileService      service.UserProfileService
+	PersonalDataService     service.PersonalDataService
+	MFAService              service.MFAService
+	AdminManagementService  service.AdminManagementService
+	UnitTypeService         service.UnitTypeService
+	UnitCapacityService     service.UnitCapacityService
+	UnitTreeTransferService service.UnitTreeTransferService
 
 	// Handlers
-	UserHandler           *handler.UserHandler
-	SchoolHandler         *handler.SchoolHandler
-	AcademicUnitHandler   *handler.AcademicUnitHandler
-	UnitMembershipHandler *handler.UnitMembershipHandler
-	UnitHandler           *handler.UnitHandler
-	SubjectHandler        *handler.SubjectHandler
-	MaterialHandler       *handler.MaterialHandler
-	StatsHandler          *handler.StatsHandler
-	GuardianHandler       *handler.GuardianHandler
+	UserHandler             *handler.UserHandler
+	SchoolHandler           *handler.SchoolHandler
+	SchoolQuotaHandler      *handler.SchoolQuotaHandler
+	SubscriptionHandler     *handler.SubscriptionHandler
+	SchoolSettingsHandler   *handler.SchoolSettingsHandler
+	SchoolLifecycleHandler  *handler.SchoolLifecycleHandler
+	SchoolCloneHandler      *handler.SchoolCloneHandler
+	RolloverHandler         *handler.RolloverHandler
+	OnboardingHandler       *handler.OnboardingHandler
+	AcademicUnitHandler     *handler.AcademicUnitHandler
+	AcademicPeriodHandler   *handler.AcademicPeriodHandler
+	UnitMembershipHandler   *handler.UnitMembershipHandler
+	UnitHandler             *handler.UnitHandler
+	SubjectHandler          *handler.SubjectHandler
+	MaterialHandler         *handler.MaterialHandler
+	StatsHandler            *handler.StatsHandler
+	GuardianHandler         *handler.GuardianHandler
+	UserImportHandler       *handler.UserImportHandler
+	ExportHandler           *handler.ExportHandler
+	UserStatusHandler       *handler.UserStatusHandler
+	UserDuplicateHandler    *handler.UserDuplicateHandler
+	InvitationHandler       *handler.InvitationHandler
+	MeHandler               *handler.MeHandler
+	PersonalDataHandler     *handler.PersonalDataHandler
+	AdminManagementHandler  *handler.AdminManagementHandler
+	UnitTypeHandler         *handler.UnitTypeHandler
+	UnitCapacityHandler     *handler.UnitCapacityHandler
+	UnitTreeTransferHandler *handler.UnitTreeTransferHandler
 }
 
 // NewContainer crea un nuevo contenedor e inicializa todas las dependencias
@@ -122,11 +180,32 @@ func NewContainer(db *sql.DB, logger logger.Logger, jwtSecret string, cfg *confi
 	c.MaterialRepository = repositoryFactory.CreateMaterialRepository()
 	c.StatsRepository = repositoryFactory.CreateStatsRepository()
 	c.GuardianRepository = repositoryFactory.CreateGuardianRepository()
+	c.UserStatusChangeRepository = repositoryFactory.CreateUserStatusChangeRepository()
+	c.DuplicateCandidateRepository = repositoryFactory.CreateDuplicateCandidateRepository()
+	c.TransactionManager = repositoryFactory.CreateTransactionManager()
+	c.InvitationRepository = repositoryFactory.CreateInvitationRepository()
+	c.UserProfileRepository = repositoryFactory.CreateUserProfileRepository()
+	c.LoginEventRepository = repositoryFactory.CreateLoginEventRepository()
+	c.UserMFARepository = repositoryFactory.CreateUserMFARepository()
+	c.AdminChangeRequestRepository = repositoryFactory.CreateAdminChangeRequestRepository()
+	c.SubscriptionChangeRepository = repositoryFactory.CreateSubscriptionChangeRepository()
+	c.SchoolSettingsRepository = repositoryFactory.CreateSchoolSettingsRepository()
+	c.SchoolLifecycleRepository = repositoryFactory.CreateSchoolLifecycleRepository()
+	c.AcademicPeriodRepository = repositoryFactory.CreateAcademicPeriodRepository()
+	c.RolloverRepository = repositoryFactory.CreateRolloverRepository()
+	c.UnitDeletionRepository = repositoryFactory.CreateUnitDeletionRepository()
+	c.SchoolUnitTypeRepository = repositoryFactory.CreateSchoolUnitTypeRepository()
+	c.UnitCapacityRepository = repositoryFactory.CreateUnitCapacityRepository()
+	c.UserTokenRevocationRepository = repositoryFactory.CreateUserTokenRevocationRepository()
+
+	// Revocación de todos los tokens de un usuario (ej: al desactivarlo), compartida entre instancias
+	c.TokenService.SetUserRevocationStore(authService.NewRepositoryUserRevocationStore(c.UserTokenRevocationRepository))
 
 	// Auth Service (usa UserRepository y TokenService)
 	c.AuthService = authService.NewAuthService(
 		c.UnitMembershipRepository,
 		c.UserRepository,
+		c.LoginEventRepository,
 		c.TokenService,
 		c.PasswordHasher,
 		logger,
@@ -142,6 +221,9 @@ func NewContainer(db *sql.DB, logger logger.Logger, jwtSecret string, cfg *confi
 		map[string]string{"api-mobile": "internal-mobile-key", "api-worker": "internal-worker-key"},
 	)
 
+	// Rechazo de tokens revocados en las rutas protegidas
+	c.AuthMiddleware = authMiddleware.NewAuthMiddleware(c.TokenService, logger)
+
 	// Inicializar services (capa de aplicación)
 	c.UserService = service.NewUserService(
 		c.UserRepository,
@@ -152,14 +234,136 @@ func NewContainer(db *sql.DB, logger logger.Logger, jwtSecret string, cfg *confi
 		logger,
 		cfg.Defaults.School,
 	)
+	c.AcademicPeriodService = service.NewAcademicPeriodService(
+		c.AcademicPeriodRepository,
+		c.SchoolRepository,
+		c.TransactionManager,
+		logger,
+	)
+	c.SchoolQuotaService = service.NewSchoolQuotaService(
+		c.SchoolRepository,
+		c.AcademicUnitRepository,
+		c.UnitMembershipRepository,
+		cfg.Defaults.Quotas,
+		cfg.Subscriptions,
+		logger,
+	)
+	unitTreeTemplates, err := service.LoadUnitTreeTemplates(cfg.Onboarding.TemplatesDir)
+	if err != nil {
+		log.Fatalf("❌ Error cargando plantillas de onboarding: %v", err)
+	}
+	logger.Info("plantillas de onboarding cargadas", "dir", cfg.Onboarding.TemplatesDir, "count", len(unitTreeTemplates))
+	c.OnboardingService = service.NewOnboardingService(
+		c.SchoolService,
+		c.AcademicUnitRepository,
+		c.UserRepository,
+		c.UnitMembershipRepository,
+		c.SchoolQuotaService,
+		c.TransactionManager,
+		unitTreeTemplates,
+		logger,
+	)
+	c.SubscriptionService = service.NewSubscriptionService(
+		c.SchoolRepository,
+		c.AcademicUnitRepository,
+		c.UnitMembershipRepository,
+		c.SubscriptionChangeRepository,
+		c.TransactionManager,
+		cfg.Subscriptions,
+		logger,
+	)
+	c.SchoolSettingsService, err = service.NewSchoolSettingsService(
+		c.SchoolRepository,
+		c.SchoolSettingsRepository,
+		c.TransactionManager,
+		cfg.Defaults.SchoolSettings,
+		logger,
+	)
+	if err != nil {
+		log.Fatalf("❌ Error en la configuración global de escuelas: %v", err)
+	}
+	c.UnitCapacityService = service.NewUnitCapacityService(
+		c.UnitCapacityRepository,
+		c.AcademicUnitRepository,
+		c.UnitMembershipRepository,
+		c.SchoolQuotaService,
+		c.TransactionManager,
+		logger,
+	)
 	c.AcademicUnitService = service.NewAcademicUnitService(
 		c.AcademicUnitRepository,
 		c.SchoolRepository,
+		c.AcademicPeriodRepository,
+		c.UnitMembershipRepository,
+		c.UnitDeletionRepository,
+		c.SchoolUnitTypeRepository,
+		c.SchoolSettingsService,
+		c.UnitCapacityService,
+		c.SchoolQuotaService,
+		c.TransactionManager,
+		logger,
+	)
+	c.SchoolLifecycleService = service.NewSchoolLifecycleService(
+		c.SchoolRepository,
+		c.AcademicUnitRepository,
+		c.UnitMembershipRepository,
+		c.SchoolLifecycleRepository,
+		c.TransactionManager,
+		cfg.Lifecycle,
+		logger,
+	)
+	c.SchoolCloneService = service.NewSchoolCloneService(
+		c.SchoolService,
+		c.SchoolRepository,
+		c.AcademicUnitRepository,
+		c.UnitMembershipRepository,
+		c.SchoolSettingsRepository,
+		c.AcademicPeriodRepository,
+		c.SchoolQuotaService,
+		c.TransactionManager,
+		logger,
+	)
+	c.RolloverService = service.NewRolloverService(
+		c.SchoolRepository,
+		c.AcademicUnitRepository,
+		c.UnitMembershipRepository,
+		c.AcademicPeriodRepository,
+		c.RolloverRepository,
+		c.SchoolQuotaService,
+		c.TransactionManager,
+		logger,
+	)
+	c.UnitTypeService = service.NewUnitTypeService(
+		c.SchoolRepository,
+		c.SchoolUnitTypeRepository,
+		c.AcademicUnitRepository,
+		c.SchoolSettingsService,
+		c.TransactionManager,
 		logger,
 	)
 	c.UnitMembershipService = service.NewUnitMembershipService(
 		c.UnitMembershipRepository,
 		c.AcademicUnitRepository,
+		c.SchoolRepository,
+		c.AcademicPeriodRepository,
+		c.SchoolUnitTypeRepository,
+		c.SchoolQuotaService,
+		c.UnitCapacityService,
+		c.TransactionManager,
+		logger,
+	)
+	c.UnitTreeTransferService = service.NewUnitTreeTransferService(
+		c.SchoolRepository,
+		c.AcademicUnitRepository,
+		c.AcademicPeriodRepository,
+		c.UnitMembershipRepository,
+		c.UserRepository,
+		c.SchoolUnitTypeRepository,
+		c.SchoolSettingsService,
+		c.AcademicUnitService,
+		c.UnitMembershipService,
+		c.SchoolQuotaService,
+		c.TransactionManager,
 		logger,
 	)
 	c.UnitService = service.NewUnitService(
@@ -182,6 +386,97 @@ func NewContainer(db *sql.DB, logger logger.Logger, jwtSecret string, cfg *confi
 		c.GuardianRepository,
 		logger,
 	)
+	c.UserImportService = service.NewUserImportService(
+		c.UserRepository,
+		c.AcademicUnitRepository,
+		c.UnitMembershipRepository,
+		c.UnitMembershipService,
+		c.TransactionManager,
+		logger,
+	)
+	c.ExportService = service.NewExportService(
+		c.UserRepository,
+		c.AcademicUnitRepository,
+		c.UnitMembershipRepository,
+		c.GuardianRepository,
+		c.SubscriptionService,
+		logger,
+	)
+	c.UserLifecycleService = service.NewUserLifecycleService(
+		c.UserRepository,
+		c.UnitMembershipRepository,
+		c.AcademicUnitRepository,
+		c.GuardianRepository,
+		c.UserStatusChangeRepository,
+		c.AcademicPeriodRepository,
+		c.SchoolQuotaService,
+		c.UnitCapacityService,
+		c.TransactionManager,
+		c.TokenService,
+		logger,
+	)
+	c.UserDuplicateService = service.NewUserDuplicateService(
+		c.UserRepository,
+		c.UnitMembershipRepository,
+		c.GuardianRepository,
+		c.UserStatusChangeRepository,
+		c.DuplicateCandidateRepository,
+		c.UserProfileRepository,
+		c.LoginEventRepository,
+		c.InvitationRepository,
+		c.TransactionManager,
+		c.TokenService,
+		logger,
+	)
+	c.InvitationService = service.NewInvitationService(
+		c.InvitationRepository,
+		c.UserRepository,
+		c.SchoolRepository,
+		c.AcademicUnitRepository,
+		c.UnitMembershipRepository,
+		c.UnitMembershipService,
+		c.SchoolQuotaService,
+		c.TransactionManager,
+		crypto.NewSignedTokenSigner(cfg.Auth.Invitations.SigningSecret),
+		service.NewLogInvitationSender(logger),
+		cfg.Auth.Invitations,
+		logger,
+	)
+	c.UserProfileService = service.NewUserProfileService(
+		c.UserRepository,
+		c.UserProfileRepository,
+		c.TransactionManager,
+		logger,
+	)
+	c.MFAService = service.NewMFAService(
+		c.UserRepository,
+		c.UserMFARepository,
+		cfg.Auth.MFA,
+		logger,
+	)
+	c.AdminManagementService = service.NewAdminManagementService(
+		c.UserRepository,
+		c.SchoolRepository,
+		c.AdminChangeRequestRepository,
+		c.TransactionManager,
+		c.MFAService,
+		c.TokenService,
+		cfg.Auth.AdminApproval,
+		logger,
+	)
+	c.PersonalDataService = service.NewPersonalDataService(
+		c.UserRepository,
+		c.UserProfileRepository,
+		c.UnitMembershipRepository,
+		c.GuardianRepository,
+		c.LoginEventRepository,
+		c.UserStatusChangeRepository,
+		c.InvitationRepository,
+		c.AdminChangeRequestRepository,
+		c.TransactionManager,
+		c.TokenService,
+		logger,
+	)
 
 	// Inicializar handlers (capa de infraestructura HTTP)
 	c.UserHandler = handler.NewUserHandler(
@@ -192,10 +487,54 @@ func NewContainer(db *sql.DB, logger logger.Logger, jwtSecret string, cfg *confi
 		c.SchoolService,
 		logger,
 	)
+	c.SchoolQuotaHandler = handler.NewSchoolQuotaHandler(
+		c.SchoolQuotaService,
+		logger,
+	)
+	c.OnboardingHandler = handler.NewOnboardingHandler(
+		c.OnboardingService,
+		logger,
+	)
+	c.SubscriptionHandler = handler.NewSubscriptionHandler(
+		c.SubscriptionService,
+		logger,
+	)
+	c.SchoolSettingsHandler = handler.NewSchoolSettingsHandler(
+		c.SchoolSettingsService,
+		logger,
+	)
+	c.SchoolLifecycleHandler = handler.NewSchoolLifecycleHandler(
+		c.SchoolLifecycleService,
+		logger,
+	)
+	c.SchoolCloneHandler = handler.NewSchoolCloneHandler(
+		c.SchoolCloneService,
+		logger,
+	)
+	c.RolloverHandler = handler.NewRolloverHandler(
+		c.RolloverService,
+		logger,
+	)
+	c.UnitTypeHandler = handler.NewUnitTypeHandler(
+		c.UnitTypeService,
+		logger,
+	)
+	c.UnitCapacityHandler = handler.NewUnitCapacityHandler(
+		c.UnitCapacityService,
+		logger,
+	)
+	c.UnitTreeTransferHandler = handler.NewUnitTreeTransferHandler(
+		c.UnitTreeTransferService,
+		logger,
+	)
 	c.AcademicUnitHandler = handler.NewAcademicUnitHandler(
 		c.AcademicUnitService,
 		logger,
 	)
+	c.AcademicPeriodHandler = handler.NewAcademicPeriodHandler(
+		c.AcademicPeriodService,
+		logger,
+	)
 	c.UnitMembershipHandler = handler.NewUnitMembershipHandler(
 		c.UnitMembershipService,
 		logger,
@@ -220,6 +559,39 @@ func NewContainer(db *sql.DB, logger logger.Logger, jwtSecret string, cfg *confi
 		c.GuardianService,
 		logger,
 	)
+	c.UserImportHandler = handler.NewUserImportHandler(
+		c.UserImportService,
+		logger,
+	)
+	c.ExportHandler = handler.NewExportHandler(
+		c.ExportService,
+		logger,
+	)
+	c.UserStatusHandler = handler.NewUserStatusHandler(
+		c.UserLifecycleService,
+		logger,
+	)
+	c.UserDuplicateHandler = handler.NewUserDuplicateHandler(
+		c.UserDuplicateService,
+		logger,
+	)
+	c.InvitationHandler = handler.NewInvitationHandler(
+		c.InvitationService,
+		logger,
+	)
+	c.MeHandler = handler.NewMeHandler(
+		c.UserProfileService,
+		c.MFAService,
+		logger,
+	)
+	c.PersonalDataHandler = handler.NewPersonalDataHandler(
+		c.PersonalDataService,
+		logger,
+	)
+	c.AdminManagementHandler = handler.NewAdminManagementHandler(
+		c.AdminManagementService,
+		logger,
+	)
 
 	return c
 }
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de período académico
+const (
+	AcademicPeriodYear = "year"
+	AcademicPeriodTerm = "term"
+)
+
+// Estados de un período académico
+const (
+	AcademicPeriodPlanned = "planned"
+	AcademicPeriodActive  = "active"
+	AcademicPeriodClosed  = "closed"
+)
+
+// AcademicPeriod representa un año lectivo o uno de sus períodos (ParentID apunta al año).
+// Year identifica el año lectivo y es el valor que se guarda en academic_units.academic_year.
+type AcademicPeriod struct {
+	ID        uuid.UUID
+	SchoolID  uuid.UUID
+	ParentID  *uuid.UUID
+	Type      string
+	Name      string
+	Year      int
+	StartDate time.Time
+	EndDate   time.Time
+	Status    string
+	ClosedBy  string
+	ClosedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsClosed indica si el período es de solo lectura
+func (p *AcademicPeriod) IsClosed() bool {
+	return p.Status == AcademicPeriodClosed
+}
+
+// Overlaps indica si los rangos de fechas de ambos períodos se cruzan
+func (p *AcademicPeriod) Overlaps(other *AcademicPeriod) bool {
+	return !p.StartDate.After(other.EndDate) && !other.StartDate.After(p.EndDate)
+}
+
+// AcademicPeriodRepository define las operaciones de persistencia de años lectivos y períodos
+type AcademicPeriodRepository interface {
+	Create(ctx context.Context, period *AcademicPeriod) error
+	Update(ctx context.Context, period *AcademicPeriod) error
+
+	// FindByID obtiene un período (nil si no existe)
+	FindByID(ctx context.Context, id uuid.UUID) (*AcademicPeriod, error)
+
+	// FindYear obtiene el año lectivo de la escuela por su número (nil si no existe)
+	FindYear(ctx context.Context, schoolID uuid.UUID, year int) (*AcademicPeriod, error)
+
+	// FindActiveYear obtiene el año lectivo activo de la escuela (nil si no hay)
+	FindActiveYear(ctx context.Context, schoolID uuid.UUID) (*AcademicPeriod, error)
+
+	// ListBySchool lista años y períodos de la escuela ordenados por fecha de inicio
+	ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*AcademicPeriod, error)
+
+	// ListTerms lista los períodos de un año lectivo ordenados por fecha de inicio
+	ListTerms(ctx context.Context, yearID uuid.UUID) ([]*AcademicPeriod, error)
+}
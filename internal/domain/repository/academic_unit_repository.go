@@ -7,6 +7,19 @@ import (
 	"github.com/google/uuid"
 )
 
+// UnitDescendant es una unidad del subárbol con su profundidad relativa (1 = hijo directo)
+type UnitDescendant struct {
+	Unit  *entities.AcademicUnit
+	Depth int
+}
+
+// UnitSubtreeCount resume el subárbol de una unidad (sin contar la unidad ni las eliminadas)
+type UnitSubtreeCount struct {
+	Descendants int
+	ByType      map[string]int
+	MaxDepth    int
+}
+
 // AcademicUnitRepository define las operaciones de persistencia para AcademicUnit
 type AcademicUnitRepository interface {
 	// Create crea una nueva unidad académica
@@ -21,6 +34,9 @@ type AcademicUnitRepository interface {
 	// FindBySchoolID lista todas las unidades de una escuela
 	FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool) ([]*entities.AcademicUnit, error)
 
+	// CountBySchoolID cuenta las unidades no eliminadas de una escuela
+	CountBySchoolID(ctx context.Context, schoolID uuid.UUID) (int, error)
+
 	// FindByParentID lista unidades hijas de una unidad padre
 	FindByParentID(ctx context.Context, parentID uuid.UUID, includeDeleted bool) ([]*entities.AcademicUnit, error)
 
@@ -45,6 +61,22 @@ type AcademicUnitRepository interface {
 	// GetHierarchyPath obtiene el path jerárquico desde raíz hasta la unidad
 	GetHierarchyPath(ctx context.Context, id uuid.UUID) ([]*entities.AcademicUnit, error)
 
+	// FindAncestors obtiene los ancestros no eliminados de la unidad, de la raíz al padre
+	FindAncestors(ctx context.Context, id uuid.UUID) ([]*entities.AcademicUnit, error)
+
+	// FindDescendants obtiene el subárbol de la unidad ordenado por profundidad (maxDepth 0 = sin límite)
+	FindDescendants(ctx context.Context, id uuid.UUID, maxDepth int, includeDeleted bool) ([]UnitDescendant, error)
+
+	// CountSubtree cuenta los descendientes de la unidad por tipo; includeDeleted incluye los eliminados
+	CountSubtree(ctx context.Context, id uuid.UUID, includeDeleted bool) (*UnitSubtreeCount, error)
+
+	// IsAncestor indica si ancestorID es la propia unidad o alguno de sus ancestros (incluye eliminados)
+	IsAncestor(ctx context.Context, ancestorID, unitID uuid.UUID) (bool, error)
+
+	// LockHierarchy serializa los cambios de jerarquía de una escuela hasta el fin de la transacción;
+	// falla si no hay una transacción activa
+	LockHierarchy(ctx context.Context, schoolID uuid.UUID) error
+
 	// ExistsBySchoolIDAndCode verifica si existe una unidad con ese código en la escuela
 	ExistsBySchoolIDAndCode(ctx context.Context, schoolID uuid.UUID, code string) (bool, error)
 }
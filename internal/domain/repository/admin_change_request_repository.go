@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Acciones de una solicitud de cambio de administradores
+const (
+	AdminChangeActionCreate  = "create"
+	AdminChangeActionPromote = "promote"
+)
+
+// Estados de una solicitud de cambio de administradores
+const (
+	AdminChangeStatusPending  = "pending"
+	AdminChangeStatusApplied  = "applied"
+	AdminChangeStatusRejected = "rejected"
+)
+
+// Formas de autorizar un cambio de administradores
+const (
+	AdminApprovalMFA         = "mfa"
+	AdminApprovalSecondAdmin = "second_admin"
+)
+
+// AdminChangeRequest es la solicitud (y el registro de auditoría) de un alta o
+// promoción de administrador. Se aplica de inmediato si el solicitante se
+// re-autentica con MFA; si no, queda pendiente hasta que otro super admin la apruebe.
+type AdminChangeRequest struct {
+	ID             uuid.UUID
+	Action         string
+	TargetUserID   *uuid.UUID // usuario promovido, o creado al aplicar un alta
+	Email          string
+	FirstName      string
+	LastName       string
+	PasswordHash   string // solo para altas pendientes; se borra al decidir
+	SchoolID       *uuid.UUID
+	Reason         string
+	Status         string
+	ApprovalMethod string
+	RequestedBy    uuid.UUID
+	DecidedBy      *uuid.UUID
+	DecisionNote   string
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	DecidedAt      *time.Time
+}
+
+// IsExpired indica si una solicitud pendiente ya venció
+func (r *AdminChangeRequest) IsExpired(now time.Time) bool {
+	return r.Status == AdminChangeStatusPending && !now.Before(r.ExpiresAt)
+}
+
+// AdminChangeRequestFilters filtros para listar solicitudes
+type AdminChangeRequestFilters struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+// AdminChangeRequestRepository define las operaciones de persistencia de solicitudes de cambio de administradores
+type AdminChangeRequestRepository interface {
+	// Create registra una solicitud
+	Create(ctx context.Context, request *AdminChangeRequest) error
+
+	// FindByID busca una solicitud por ID (nil si no existe)
+	FindByID(ctx context.Context, id uuid.UUID) (*AdminChangeRequest, error)
+
+	// FindPendingByEmail busca una solicitud pendiente para el email (nil si no existe)
+	FindPendingByEmail(ctx context.Context, email string) (*AdminChangeRequest, error)
+
+	// List lista las solicitudes, de la más reciente a la más antigua
+	List(ctx context.Context, filters AdminChangeRequestFilters) ([]*AdminChangeRequest, error)
+
+	// Update actualiza una solicitud existente
+	Update(ctx context.Context, request *AdminChangeRequest) error
+
+	// AnonymizeEmail reemplaza el email de las solicitudes, borra sus nombres y rechaza las pendientes
+	AnonymizeEmail(ctx context.Context, email, replacement string) (int64, error)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Estados de un candidato a duplicado en la cola de revisión
+const (
+	DuplicateStatusPending   = "pending"
+	DuplicateStatusDismissed = "dismissed"
+	DuplicateStatusMerged    = "merged"
+)
+
+// DuplicateCandidate representa un par de usuarios que podrían ser la misma persona.
+// El par se guarda normalizado: UserIDA < UserIDB.
+type DuplicateCandidate struct {
+	ID         uuid.UUID
+	UserIDA    uuid.UUID
+	UserIDB    uuid.UUID
+	Score      float64
+	Reasons    []string
+	Status     string
+	DetectedAt time.Time
+	ReviewedBy *string
+	ReviewedAt *time.Time
+}
+
+// DuplicateCandidateFilters representa filtros para listar la cola de revisión
+type DuplicateCandidateFilters struct {
+	Status *string
+	Limit  int
+	Offset int
+}
+
+// DuplicateCandidateRepository define las operaciones de persistencia de la cola de duplicados
+type DuplicateCandidateRepository interface {
+	// Create registra un nuevo candidato
+	Create(ctx context.Context, candidate *DuplicateCandidate) error
+
+	// FindByID busca un candidato por ID (nil si no existe)
+	FindByID(ctx context.Context, id uuid.UUID) (*DuplicateCandidate, error)
+
+	// FindByPair busca el candidato de un par de usuarios en cualquier orden (nil si no existe)
+	FindByPair(ctx context.Context, userA, userB uuid.UUID) (*DuplicateCandidate, error)
+
+	// List lista candidatos ordenados por score descendente
+	List(ctx context.Context, filters DuplicateCandidateFilters) ([]*DuplicateCandidate, error)
+
+	// Update actualiza score, razones y estado de un candidato
+	Update(ctx context.Context, candidate *DuplicateCandidate) error
+}
@@ -16,6 +16,9 @@ type GuardianRepository interface {
 	Update(ctx context.Context, relation *entities.GuardianRelation) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	ExistsActiveRelation(ctx context.Context, guardianID, studentID uuid.UUID) (bool, error)
+	ReassignRelation(ctx context.Context, id, guardianID, studentID uuid.UUID) error
+	// FindByStudents lista las relaciones de varios estudiantes en una sola consulta
+	FindByStudents(ctx context.Context, studentIDs []uuid.UUID, activeOnly bool) ([]*entities.GuardianRelation, error)
 
 	// Original methods
 	CreateRelation(ctx context.Context, relation *entities.GuardianRelation) error
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Estados de una invitación. Una invitación pendiente con ExpiresAt vencido se considera expirada.
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+	InvitationStatusExpired  = "expired"
+)
+
+// Invitation representa la invitación de un email a una escuela (y opcionalmente a una unidad)
+// con un rol de membresía. Solo se guarda el hash del nonce del último link enviado.
+type Invitation struct {
+	ID             uuid.UUID
+	Email          string
+	SchoolID       uuid.UUID
+	AcademicUnitID *uuid.UUID
+	Role           string // rol de membresía
+	UserRole       string // rol de sistema si se crea la cuenta al aceptar
+	TokenHash      string
+	Status         string
+	InvitedBy      string
+	SentCount      int
+	ExpiresAt      time.Time
+	LastSentAt     time.Time
+	AcceptedUserID *uuid.UUID
+	AcceptedAt     *time.Time
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsExpired indica si la invitación pendiente ya venció
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return i.Status == InvitationStatusPending && !now.Before(i.ExpiresAt)
+}
+
+// InvitationFilters representa filtros para listar invitaciones de una escuela
+type InvitationFilters struct {
+	Status *string // pending incluye solo las no vencidas; expired las pendientes vencidas
+	Limit  int
+	Offset int
+}
+
+// InvitationRepository define las operaciones de persistencia para invitaciones
+type InvitationRepository interface {
+	// Create registra una nueva invitación
+	Create(ctx context.Context, invitation *Invitation) error
+
+	// FindByID busca una invitación por ID (nil si no existe)
+	FindByID(ctx context.Context, id uuid.UUID) (*Invitation, error)
+
+	// FindByIDForUpdate busca una invitación bloqueando la fila hasta el fin de la transacción (nil si no existe)
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*Invitation, error)
+
+	// FindPendingByEmail busca la invitación pendiente y vigente de un email en una escuela (nil si no existe)
+	FindPendingByEmail(ctx context.Context, schoolID uuid.UUID, email string) (*Invitation, error)
+
+	// ListBySchool lista las invitaciones de una escuela, de la más reciente a la más antigua
+	ListBySchool(ctx context.Context, schoolID uuid.UUID, filters InvitationFilters) ([]*Invitation, error)
+
+	// Update actualiza una invitación existente
+	Update(ctx context.Context, invitation *Invitation) error
+
+	// AnonymizeEmail reemplaza el email en todas sus invitaciones y revoca las pendientes
+	AnonymizeEmail(ctx context.Context, email, replacement string) (int64, error)
+
+	// ReassignPendingEmail dirige las invitaciones pendientes de un email a otro; las de escuelas
+	// donde el nuevo email ya tiene una pendiente se revocan. Retorna cuántas invitaciones cambió.
+	ReassignPendingEmail(ctx context.Context, fromEmail, toEmail string) (int64, error)
+}
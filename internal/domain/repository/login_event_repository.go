@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resultados de un intento de login registrado
+const (
+	LoginResultSuccess      = "success"
+	LoginResultBadPassword  = "invalid_password"
+	LoginResultInactiveUser = "inactive_user"
+)
+
+// LoginEvent registra un intento de login de un usuario existente
+type LoginEvent struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Result     string
+	SchoolID   *uuid.UUID
+	OccurredAt time.Time
+}
+
+// LoginEventRepository define las operaciones de persistencia del historial de login
+type LoginEventRepository interface {
+	// Create registra un intento de login
+	Create(ctx context.Context, event *LoginEvent) error
+
+	// ListByUser lista el historial de login del usuario, del más reciente al más antiguo
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*LoginEvent, error)
+
+	// DeleteByUser elimina el historial de login del usuario y retorna cuántos eventos borró
+	DeleteByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// ReassignUser mueve el historial de login de un usuario a otro y retorna cuántos eventos movió
+	ReassignUser(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error)
+}
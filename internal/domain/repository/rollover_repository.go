@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Acciones de un estudiante en el cambio de año lectivo
+const (
+	RolloverActionPromote  = "promote"
+	RolloverActionRetain   = "retain"
+	RolloverActionGraduate = "graduate"
+
+	// RolloverActionCarryOver aplica a estudiantes de unidades fuera de un grado (clubes, departamentos)
+	RolloverActionCarryOver = "carry_over"
+)
+
+// SchoolRollover registra un cambio de año lectivo aplicado a una escuela
+type SchoolRollover struct {
+	ID            uuid.UUID
+	SchoolID      uuid.UUID
+	FromYear      int
+	ToYear        int
+	UnitsCreated  int
+	Promoted      int
+	Retained      int
+	Graduated     int
+	PendingReview int // asignaciones de docentes y personal que quedan para revisión
+	PerformedBy   string
+	CreatedAt     time.Time
+}
+
+// SchoolRolloverEntry registra qué pasó con cada estudiante en el cambio de año
+type SchoolRolloverEntry struct {
+	RolloverID       uuid.UUID
+	UserID           uuid.UUID
+	Action           string
+	FromMembershipID uuid.UUID
+	FromUnitID       uuid.UUID
+	ToMembershipID   *uuid.UUID // nil si el estudiante egresó
+	ToUnitID         *uuid.UUID
+}
+
+// RolloverRepository define las operaciones de persistencia del historial de cambios de año
+type RolloverRepository interface {
+	// Create guarda el cambio de año con el detalle por estudiante
+	Create(ctx context.Context, rollover *SchoolRollover, entries []SchoolRolloverEntry) error
+
+	// FindByID obtiene un cambio de año (nil si no existe)
+	FindByID(ctx context.Context, id uuid.UUID) (*SchoolRollover, error)
+
+	// FindBySchoolAndYears obtiene el cambio de año ya aplicado entre dos años (nil si no existe)
+	FindBySchoolAndYears(ctx context.Context, schoolID uuid.UUID, fromYear, toYear int) (*SchoolRollover, error)
+
+	// ListEntries lista el detalle por estudiante de un cambio de año
+	ListEntries(ctx context.Context, rolloverID uuid.UUID) ([]SchoolRolloverEntry, error)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de entidad desactivados al archivar una escuela
+const (
+	SchoolArchiveItemUnit       = "academic_unit"
+	SchoolArchiveItemMembership = "membership"
+)
+
+// Estados de un job de purga
+const (
+	PurgeJobStatusPending   = "pending"
+	PurgeJobStatusRunning   = "running"
+	PurgeJobStatusCompleted = "completed"
+	PurgeJobStatusFailed    = "failed"
+)
+
+// Pasos de la purga definitiva, en orden de ejecución (dependientes antes que la escuela)
+const (
+	PurgeStepMemberships      = "memberships"
+	PurgeStepInvitations      = "invitations"
+	PurgeStepAcademicUnits    = "academic_units"
+	PurgeStepSettings         = "settings"
+	PurgeStepSubscriptionLogs = "subscription_changes"
+	PurgeStepUsers            = "detach_users"
+	PurgeStepArchiveItems     = "archive_items"
+	PurgeStepSchool           = "school"
+)
+
+// PurgeSteps es el orden en que se ejecutan los pasos de la purga
+var PurgeSteps = []string{
+	PurgeStepMemberships,
+	PurgeStepInvitations,
+	PurgeStepAcademicUnits,
+	PurgeStepSettings,
+	PurgeStepSubscriptionLogs,
+	PurgeStepUsers,
+	PurgeStepArchiveItems,
+	PurgeStepSchool,
+}
+
+// SchoolArchive registra el archivado de una escuela. Sigue vigente mientras
+// RestoredAt y PurgedAt sean nil; se conserva después de la purga como auditoría.
+type SchoolArchive struct {
+	ID         uuid.UUID
+	SchoolID   uuid.UUID
+	SchoolCode string
+	Reason     string
+	ArchivedBy string
+	ArchivedAt time.Time
+	PurgeAfter time.Time
+	RestoredBy string
+	RestoredAt *time.Time
+	PurgedAt   *time.Time
+}
+
+// IsOpen indica si el archivado sigue vigente (ni restaurado ni purgado)
+func (a *SchoolArchive) IsOpen() bool {
+	return a.RestoredAt == nil && a.PurgedAt == nil
+}
+
+// SchoolArchiveItem es una entidad que el archivado desactivó y que la restauración reactiva
+type SchoolArchiveItem struct {
+	ArchiveID  uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+}
+
+// SchoolPurgeJob es el progreso de una purga definitiva ejecutada en segundo plano
+type SchoolPurgeJob struct {
+	ID             uuid.UUID
+	SchoolID       uuid.UUID
+	ArchiveID      uuid.UUID
+	Status         string
+	TotalSteps     int
+	CompletedSteps int
+	CurrentStep    string
+	DeletedRows    map[string]int64
+	Error          string
+	RequestedBy    string
+	CreatedAt      time.Time
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+}
+
+// IsFinished indica si el job terminó (con éxito o con error)
+func (j *SchoolPurgeJob) IsFinished() bool {
+	return j.Status == PurgeJobStatusCompleted || j.Status == PurgeJobStatusFailed
+}
+
+// SchoolLifecycleRepository define la persistencia del archivado, la restauración y la purga de escuelas
+type SchoolLifecycleRepository interface {
+	// CreateArchive registra un archivado junto con las entidades que desactivó
+	CreateArchive(ctx context.Context, archive *SchoolArchive, items []SchoolArchiveItem) error
+
+	// FindOpenArchive obtiene el archivado vigente de la escuela (nil si no está archivada)
+	FindOpenArchive(ctx context.Context, schoolID uuid.UUID) (*SchoolArchive, error)
+
+	// ListArchiveItems lista las entidades desactivadas por un archivado
+	ListArchiveItems(ctx context.Context, archiveID uuid.UUID) ([]SchoolArchiveItem, error)
+
+	// UpdateArchive actualiza la restauración o la purga de un archivado
+	UpdateArchive(ctx context.Context, archive *SchoolArchive) error
+
+	// CreatePurgeJob registra un job de purga
+	CreatePurgeJob(ctx context.Context, job *SchoolPurgeJob) error
+
+	// UpdatePurgeJob guarda el progreso de un job de purga
+	UpdatePurgeJob(ctx context.Context, job *SchoolPurgeJob) error
+
+	// FindPurgeJob obtiene un job de purga por ID (nil si no existe)
+	FindPurgeJob(ctx context.Context, id uuid.UUID) (*SchoolPurgeJob, error)
+
+	// FindUnfinishedPurgeJob obtiene el job pendiente o en curso de la escuela (nil si no hay)
+	FindUnfinishedPurgeJob(ctx context.Context, schoolID uuid.UUID) (*SchoolPurgeJob, error)
+
+	// PurgeSchoolData elimina definitivamente los datos de un paso de la purga y
+	// retorna las filas afectadas. Cada paso es idempotente para poder reintentar.
+	PurgeSchoolData(ctx context.Context, schoolID uuid.UUID, step string) (int64, error)
+}
@@ -27,8 +27,17 @@ type SchoolRepository interface {
 	// Delete elimina una escuela (soft delete)
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	// List lista escuelas con filtros opcionales
-	List(ctx context.Context, filters ListFilters) ([]*entities.School, error)
+	// FindArchivedByID busca una escuela eliminada con soft delete (nil si no existe o no está eliminada)
+	FindArchivedByID(ctx context.Context, id uuid.UUID) (*entities.School, error)
+
+	// Restore revierte el soft delete de una escuela
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// List lista escuelas con filtros, orden y paginación
+	List(ctx context.Context, filters SchoolFilters) ([]*entities.School, error)
+
+	// Count cuenta las escuelas que cumplen los filtros (ignora orden y paginación)
+	Count(ctx context.Context, filters SchoolFilters) (int, error)
 
 	// ExistsByName verifica si existe una escuela con ese nombre
 	ExistsByName(ctx context.Context, name string) (bool, error)
@@ -36,3 +45,27 @@ type SchoolRepository interface {
 	// ExistsByCode verifica si existe una escuela con ese código
 	ExistsByCode(ctx context.Context, code string) (bool, error)
 }
+
+// Campos por los que se puede ordenar el listado de escuelas
+const (
+	SchoolSortName      = "name"
+	SchoolSortCode      = "code"
+	SchoolSortCountry   = "country"
+	SchoolSortCity      = "city"
+	SchoolSortTier      = "subscription_tier"
+	SchoolSortCreatedAt = "created_at"
+	SchoolSortUpdatedAt = "updated_at"
+)
+
+// SchoolFilters representa los filtros de búsqueda de escuelas
+type SchoolFilters struct {
+	Search           string // coincidencia parcial en nombre o código, sin distinguir mayúsculas
+	Country          string
+	City             string
+	SubscriptionTier string
+	IsActive         *bool
+	SortBy           string // uno de SchoolSort*; por defecto created_at
+	SortDesc         bool
+	Limit            int
+	Offset           int
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchoolSettings guarda solo los valores que la escuela sobrescribe; el resto
+// se hereda de la configuración global. Version aumenta en cada cambio.
+type SchoolSettings struct {
+	SchoolID      uuid.UUID
+	SchemaVersion int
+	Version       int
+	Overrides     json.RawMessage
+	UpdatedBy     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// SchoolSettingsChange registra un cambio de configuración (patch aplicado y resultado)
+type SchoolSettingsChange struct {
+	ID        uuid.UUID
+	SchoolID  uuid.UUID
+	Version   int
+	Patch     json.RawMessage
+	Overrides json.RawMessage
+	Reason    string
+	ChangedBy string
+	CreatedAt time.Time
+}
+
+// SchoolSettingsRepository define las operaciones de persistencia de la configuración por escuela
+type SchoolSettingsRepository interface {
+	// FindBySchoolID obtiene la configuración de la escuela (nil si nunca se modificó)
+	FindBySchoolID(ctx context.Context, schoolID uuid.UUID) (*SchoolSettings, error)
+
+	// Save guarda la configuración solo si la versión almacenada sigue siendo
+	// expectedVersion (0 = no existe aún). Retorna false si otro cambio ganó la carrera.
+	Save(ctx context.Context, settings *SchoolSettings, expectedVersion int) (bool, error)
+
+	// CreateChange registra un cambio en el historial
+	CreateChange(ctx context.Context, change *SchoolSettingsChange) error
+
+	// ListChanges lista el historial de la escuela, del más reciente al más antiguo
+	ListChanges(ctx context.Context, schoolID uuid.UUID) ([]*SchoolSettingsChange, error)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchoolUnitType es un tipo de unidad propio de una escuela (ciclo, jornada, campus...).
+// Los tipos predefinidos no se guardan; ParentTypes, ChildTypes y CanBeRoot se suman
+// a las reglas de anidamiento de la escuela.
+type SchoolUnitType struct {
+	ID           uuid.UUID
+	SchoolID     uuid.UUID
+	Code         string
+	Label        string
+	Icon         string
+	AllowedRoles []string // vacío = cualquier rol
+	ParentTypes  []string
+	ChildTypes   []string
+	CanBeRoot    bool
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// SchoolUnitTypeRepository define las operaciones de persistencia de los tipos de unidad propios
+type SchoolUnitTypeRepository interface {
+	// Create registra un tipo propio
+	Create(ctx context.Context, unitType *SchoolUnitType) error
+
+	// Update actualiza etiqueta, icono, roles y reglas de anidamiento
+	Update(ctx context.Context, unitType *SchoolUnitType) error
+
+	// Delete elimina el tipo
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// FindByCode busca un tipo de la escuela por código (nil si no existe)
+	FindByCode(ctx context.Context, schoolID uuid.UUID, code string) (*SchoolUnitType, error)
+
+	// ListBySchool lista los tipos propios de la escuela ordenados por código
+	ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*SchoolUnitType, error)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dirección de un cambio de plan según el orden del catálogo
+const (
+	SubscriptionChangeUpgrade   = "upgrade"
+	SubscriptionChangeDowngrade = "downgrade"
+)
+
+// SubscriptionChange registra un cambio de plan de suscripción de una escuela
+type SubscriptionChange struct {
+	ID          uuid.UUID
+	SchoolID    uuid.UUID
+	FromTier    string
+	ToTier      string
+	Direction   string
+	Reason      string
+	PerformedBy string
+	CreatedAt   time.Time
+}
+
+// SubscriptionChangeRepository define las operaciones de persistencia del historial de planes
+type SubscriptionChangeRepository interface {
+	// Create registra un cambio de plan
+	Create(ctx context.Context, change *SubscriptionChange) error
+
+	// ListBySchool lista el historial de una escuela, del más reciente al más antiguo
+	ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*SubscriptionChange, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+)
+
+// TransactionManager ejecuta varias operaciones de repositorio de forma atómica.
+// Los repositorios usan la transacción presente en el ctx recibido por fn.
+type TransactionManager interface {
+	// WithinTransaction ejecuta fn en una transacción; si fn retorna error se hace rollback
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Estados de una entrada de la lista de espera de una unidad
+const (
+	UnitWaitlistWaiting   = "waiting"
+	UnitWaitlistPromoted  = "promoted"
+	UnitWaitlistCancelled = "cancelled"
+)
+
+// UnitCapacity es el cupo de estudiantes de una unidad. Las unidades sin cupo no tienen límite.
+type UnitCapacity struct {
+	UnitID          uuid.UUID
+	SchoolID        uuid.UUID
+	MaxStudents     int
+	WaitlistEnabled bool
+	UpdatedAt       time.Time
+}
+
+// UnitWaitlistEntry es un estudiante en espera de un cupo de la unidad.
+// Al promoverse, MembershipID apunta a la membresía creada.
+type UnitWaitlistEntry struct {
+	ID           uuid.UUID
+	UnitID       uuid.UUID
+	SchoolID     uuid.UUID
+	UserID       uuid.UUID
+	Status       string
+	MembershipID *uuid.UUID
+	RequestedAt  time.Time
+	ResolvedAt   *time.Time
+}
+
+// UnitCapacityRepository define las operaciones de persistencia de cupos y listas de espera
+type UnitCapacityRepository interface {
+	// FindCapacity retorna el cupo de la unidad (nil si no tiene)
+	FindCapacity(ctx context.Context, unitID uuid.UUID) (*UnitCapacity, error)
+
+	// FindCapacities retorna los cupos de las unidades indicadas que tienen uno
+	FindCapacities(ctx context.Context, unitIDs []uuid.UUID) (map[uuid.UUID]*UnitCapacity, error)
+
+	// SaveCapacity crea o reemplaza el cupo de la unidad
+	SaveCapacity(ctx context.Context, capacity *UnitCapacity) error
+
+	// DeleteCapacity quita el cupo de la unidad
+	DeleteCapacity(ctx context.Context, unitID uuid.UUID) error
+
+	// LockUnit serializa las altas y bajas de estudiantes de la unidad hasta el fin de la transacción;
+	// falla si no hay una transacción activa
+	LockUnit(ctx context.Context, unitID uuid.UUID) error
+
+	// CreateWaitlistEntry agrega una entrada a la lista de espera
+	CreateWaitlistEntry(ctx context.Context, entry *UnitWaitlistEntry) error
+
+	// UpdateWaitlistEntry actualiza estado, membresía y fecha de resolución
+	UpdateWaitlistEntry(ctx context.Context, entry *UnitWaitlistEntry) error
+
+	// FindWaitlistEntry busca una entrada por ID (nil si no existe)
+	FindWaitlistEntry(ctx context.Context, id uuid.UUID) (*UnitWaitlistEntry, error)
+
+	// ListWaiting lista las entradas en espera de la unidad por orden de llegada
+	ListWaiting(ctx context.Context, unitID uuid.UUID) ([]*UnitWaitlistEntry, error)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de entidad afectados por una eliminación en cascada de unidades
+const (
+	UnitDeletionItemUnit       = "academic_unit"
+	UnitDeletionItemMembership = "membership"
+)
+
+// UnitDeletionBatch registra la eliminación en cascada de una unidad y su subárbol.
+// Sigue abierta mientras RestoredAt sea nil; la restauración revierte exactamente sus items.
+type UnitDeletionBatch struct {
+	ID                   uuid.UUID
+	SchoolID             uuid.UUID
+	RootUnitID           uuid.UUID
+	UnitsDeleted         int
+	MembershipsSuspended int
+	DeletedBy            string
+	DeletedAt            time.Time
+	RestoredBy           string
+	RestoredAt           *time.Time
+}
+
+// IsOpen indica si la eliminación todavía no fue restaurada
+func (b *UnitDeletionBatch) IsOpen() bool {
+	return b.RestoredAt == nil
+}
+
+// UnitDeletionItem es una unidad eliminada o una membresía suspendida por un batch
+type UnitDeletionItem struct {
+	BatchID    uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+}
+
+// UnitDeletionRepository define la persistencia de los batches de eliminación de unidades
+type UnitDeletionRepository interface {
+	// CreateBatch registra un batch junto con las entidades que afectó
+	CreateBatch(ctx context.Context, batch *UnitDeletionBatch, items []UnitDeletionItem) error
+
+	// FindBatch obtiene un batch por ID (nil si no existe)
+	FindBatch(ctx context.Context, id uuid.UUID) (*UnitDeletionBatch, error)
+
+	// FindOpenBatchByUnit obtiene el batch abierto que eliminó la unidad, como raíz o como descendiente (nil si no hay)
+	FindOpenBatchByUnit(ctx context.Context, unitID uuid.UUID) (*UnitDeletionBatch, error)
+
+	// ListItems lista las entidades afectadas por un batch
+	ListItems(ctx context.Context, batchID uuid.UUID) ([]UnitDeletionItem, error)
+
+	// UpdateBatch guarda la restauración de un batch
+	UpdateBatch(ctx context.Context, batch *UnitDeletionBatch) error
+}
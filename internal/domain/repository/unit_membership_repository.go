@@ -18,4 +18,24 @@ type UnitMembershipRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	ExistsByUnitAndUser(ctx context.Context, unitID, userID uuid.UUID) (bool, error)
 	FindByUserAndSchool(ctx context.Context, userID, schoolID uuid.UUID) (*entities.Membership, error)
+	ReassignUser(ctx context.Context, membershipID, userID uuid.UUID) error
+	// CountActiveUsersBySchoolAndRole cuenta usuarios distintos con membresía activa del rol en la escuela
+	CountActiveUsersBySchoolAndRole(ctx context.Context, schoolID uuid.UUID, role string) (int, error)
+	// LockSchoolQuota serializa las altas que consumen cupo de la escuela hasta el fin de la transacción;
+	// falla si no hay una transacción activa
+	LockSchoolQuota(ctx context.Context, schoolID uuid.UUID) error
+	// FindActiveBySchool lista las membresías activas de la escuela, incluidas las que no tienen unidad
+	FindActiveBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entities.Membership, error)
+	// FindActiveByUnits lista las membresías activas de las unidades indicadas
+	FindActiveByUnits(ctx context.Context, unitIDs []uuid.UUID) ([]*entities.Membership, error)
+	// ListByUnit lista una página de membresías de la unidad según los filtros
+	ListByUnit(ctx context.Context, unitID uuid.UUID, filters MembershipListFilters) ([]*entities.Membership, error)
+}
+
+// MembershipListFilters representa filtros para listar membresías de una unidad
+type MembershipListFilters struct {
+	Role       string // vacío = todos los roles
+	ActiveOnly bool   // solo membresías activas y no retiradas
+	Limit      int
+	Offset     int
 }
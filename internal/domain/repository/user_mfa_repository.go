@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserMFA guarda el secret TOTP de un usuario. EnabledAt es nil mientras
+// el usuario no confirme el enrolamiento con un primer código válido.
+type UserMFA struct {
+	UserID     uuid.UUID
+	Secret     string
+	EnabledAt  *time.Time
+	LastUsedAt *time.Time
+	// LastStep es el periodo TOTP del último código aceptado; los de ese periodo o anteriores ya no valen
+	LastStep int64
+	// FailedAttempts cuenta los códigos inválidos seguidos; al llegar al máximo se bloquea hasta LockedUntil
+	FailedAttempts int
+	LockedUntil    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsEnabled indica si el segundo factor está confirmado
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+// IsLocked indica si el segundo factor está bloqueado por intentos fallidos
+func (m *UserMFA) IsLocked(now time.Time) bool {
+	return m.LockedUntil != nil && now.Before(*m.LockedUntil)
+}
+
+// UserMFARepository define las operaciones de persistencia del segundo factor
+type UserMFARepository interface {
+	// FindByUserID obtiene la configuración MFA del usuario (nil si no tiene)
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*UserMFA, error)
+
+	// Upsert crea o reemplaza la configuración MFA del usuario
+	Upsert(ctx context.Context, mfa *UserMFA) error
+
+	// ConsumeStep registra el uso de un código del periodo step y reinicia los intentos fallidos.
+	// Retorna false si ya se aceptó un código de ese periodo o de uno posterior (replay).
+	ConsumeStep(ctx context.Context, userID uuid.UUID, step int64, usedAt time.Time) (bool, error)
+
+	// RecordFailedAttempt suma un código inválido; al llegar a maxAttempts bloquea el MFA hasta
+	// lockedUntil y reinicia la cuenta. Retorna true si este intento dejó el MFA bloqueado.
+	RecordFailedAttempt(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil, at time.Time) (bool, error)
+
+	// Delete elimina la configuración MFA del usuario (no falla si no existe)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationPreferences indica por qué canales quiere recibir avisos el usuario
+type NotificationPreferences struct {
+	Email  bool   `json:"email"`
+	SMS    bool   `json:"sms"`
+	Push   bool   `json:"push"`
+	Digest string `json:"digest"` // none, daily o weekly
+}
+
+// UserProfile contiene los datos de perfil y preferencias de un usuario (1:1 con User)
+type UserProfile struct {
+	UserID                  uuid.UUID
+	Phone                   *string
+	PreferredLanguage       string
+	Timezone                string
+	DateOfBirth             *time.Time
+	AvatarURL               *string
+	NotificationPreferences NotificationPreferences
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
+
+// UserProfileRepository define las operaciones de persistencia de perfiles de usuario
+type UserProfileRepository interface {
+	// FindByUserID obtiene el perfil de un usuario (nil si aún no tiene)
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*UserProfile, error)
+
+	// FindByUserIDs obtiene los perfiles existentes de varios usuarios
+	FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*UserProfile, error)
+
+	// Upsert crea o reemplaza el perfil del usuario
+	Upsert(ctx context.Context, profile *UserProfile) error
+
+	// Delete elimina el perfil del usuario (no falla si no existe)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
@@ -29,12 +29,37 @@ type UserRepository interface {
 
 	// ExistsByEmail verifica si existe un usuario con ese email
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+
+	// FindByIDs busca varios usuarios por ID; los que no existen se omiten
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error)
+
+	// FindByIDForUpdate busca un usuario bloqueando la fila hasta el fin de la transacción (nil si no existe)
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.User, error)
+
+	// Count cuenta los usuarios que cumplen los filtros (ignora Limit y Offset)
+	Count(ctx context.Context, filters ListFilters) (int, error)
+
+	// FindEmailDuplicates agrupa los usuarios que comparten email normalizado
+	// (como similarity.NormalizeEmail); solo retorna grupos de dos o más
+	FindEmailDuplicates(ctx context.Context, filters ListFilters) ([][]uuid.UUID, error)
+
+	// FindPhoneDuplicates agrupa los usuarios cuyo perfil tiene el mismo teléfono normalizado
+	// (como similarity.NormalizePhone) de al menos minDigits dígitos
+	FindPhoneDuplicates(ctx context.Context, filters ListFilters, minDigits int) ([][]uuid.UUID, error)
+
+	// ListNameBlocks lista los bloques de nombre (primeras dos letras del apellido normalizado)
+	// que tienen más de un usuario
+	ListNameBlocks(ctx context.Context, filters ListFilters) ([]string, error)
+
+	// ListByNameBlock lista los usuarios de un bloque de nombre
+	ListByNameBlock(ctx context.Context, filters ListFilters, block string) ([]*entities.User, error)
 }
 
 // ListFilters representa filtros para listar usuarios
 type ListFilters struct {
 	Role     *string
 	IsActive *bool
+	SchoolID *uuid.UUID // usuarios con membresía activa en la escuela
 	Limit    int
 	Offset   int
 }
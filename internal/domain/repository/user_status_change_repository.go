@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Acciones registradas en el historial de estado de usuarios
+const (
+	UserStatusActionDeactivated = "deactivated"
+	UserStatusActionReactivated = "reactivated"
+	UserStatusActionMerged      = "merged"
+	UserStatusActionErased      = "erased"
+)
+
+// UserStatusChange registra una desactivación o reactivación de usuario,
+// incluyendo las membresías y relaciones afectadas (expiradas al desactivar, restauradas al reactivar)
+type UserStatusChange struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Action              string
+	Reason              string
+	PerformedBy         string
+	MembershipIDs       []uuid.UUID
+	GuardianRelationIDs []uuid.UUID
+	CreatedAt           time.Time
+}
+
+// UserStatusChangeRepository define las operaciones de persistencia del historial de estado de usuarios
+type UserStatusChangeRepository interface {
+	// Create registra un cambio de estado
+	Create(ctx context.Context, change *UserStatusChange) error
+
+	// FindLatestByUser obtiene el último cambio de la acción indicada (nil si no existe)
+	FindLatestByUser(ctx context.Context, userID uuid.UUID, action string) (*UserStatusChange, error)
+
+	// ListByUser lista el historial de un usuario, del más reciente al más antiguo
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*UserStatusChange, error)
+
+	// ReassignUser mueve las referencias (user_id y performed_by) de un usuario a otro
+	ReassignUser(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error)
+
+	// RedactReason reemplaza un texto (sin distinguir mayúsculas) en los motivos que lo contienen
+	RedactReason(ctx context.Context, text, replacement string) (int64, error)
+}
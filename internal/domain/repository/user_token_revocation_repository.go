@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserTokenRevocationRepository persiste la revocación de todos los tokens de un usuario.
+// Es compartido por todas las instancias de la API: un token revocado deja de servir en cualquiera.
+type UserTokenRevocationRepository interface {
+	// Upsert registra la revocación del usuario; si ya existe conserva la más reciente
+	Upsert(ctx context.Context, userID uuid.UUID, revokedAt, expiresAt time.Time) error
+
+	// FindRevokedAt retorna la revocación vigente del usuario (nil si no tiene o ya expiró)
+	FindRevokedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error)
+}
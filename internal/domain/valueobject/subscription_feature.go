@@ -0,0 +1,37 @@
+package valueobject
+
+import "fmt"
+
+// SubscriptionFeature representa una funcionalidad habilitable por plan de suscripción
+type SubscriptionFeature string
+
+const (
+	FeatureSSO       SubscriptionFeature = "sso"
+	FeatureAPITokens SubscriptionFeature = "api_tokens"
+	FeatureExports   SubscriptionFeature = "exports"
+)
+
+var validSubscriptionFeatures = map[SubscriptionFeature]bool{
+	FeatureSSO:       true,
+	FeatureAPITokens: true,
+	FeatureExports:   true,
+}
+
+// IsValid verifica si el feature es válido
+func (f SubscriptionFeature) IsValid() bool {
+	return validSubscriptionFeatures[f]
+}
+
+// String retorna el feature como string
+func (f SubscriptionFeature) String() string {
+	return string(f)
+}
+
+// ParseSubscriptionFeature convierte un string a SubscriptionFeature
+func ParseSubscriptionFeature(s string) (SubscriptionFeature, error) {
+	feature := SubscriptionFeature(s)
+	if !feature.IsValid() {
+		return "", fmt.Errorf("invalid subscription feature: %s", s)
+	}
+	return feature, nil
+}
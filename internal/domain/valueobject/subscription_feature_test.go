@@ -0,0 +1,18 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSubscriptionFeature(t *testing.T) {
+	feature, err := valueobject.ParseSubscriptionFeature("api_tokens")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.FeatureAPITokens, feature)
+
+	_, err = valueobject.ParseSubscriptionFeature("white_label")
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package valueobject
+
+// DefaultUnitMaxDepth es la profundidad máxima por defecto del árbol de unidades (la raíz es el nivel 1)
+const DefaultUnitMaxDepth = 6
+
+// UnitNestingRules define qué tipos de unidad pueden colgar de cuáles, cuáles pueden
+// ser raíz y cuántos niveles puede tener el árbol de una escuela
+type UnitNestingRules struct {
+	AllowedParents map[UnitType][]UnitType
+	RootTypes      []UnitType
+	MaxDepth       int // 0 = sin límite
+}
+
+// DefaultUnitNestingRules retorna las reglas globales por defecto: las secciones siempre
+// pertenecen a un grado y los grados a la escuela
+func DefaultUnitNestingRules() UnitNestingRules {
+	return UnitNestingRules{
+		AllowedParents: map[UnitType][]UnitType{
+			UnitTypeSchool:     {},
+			UnitTypeGrade:      {UnitTypeSchool},
+			UnitTypeSection:    {UnitTypeGrade},
+			UnitTypeDepartment: {UnitTypeSchool, UnitTypeDepartment},
+			UnitTypeClub:       {UnitTypeSchool, UnitTypeGrade, UnitTypeSection, UnitTypeDepartment},
+		},
+		RootTypes: []UnitType{UnitTypeSchool, UnitTypeGrade, UnitTypeClub, UnitTypeDepartment},
+		MaxDepth:  DefaultUnitMaxDepth,
+	}
+}
+
+// CanNest indica si una unidad del tipo child puede colgar de una unidad del tipo parent
+func (r UnitNestingRules) CanNest(child, parent UnitType) bool {
+	for _, allowed := range r.AllowedParents[child] {
+		if allowed == parent {
+			return true
+		}
+	}
+	return false
+}
+
+// CanBeRoot indica si una unidad del tipo puede quedar sin padre
+func (r UnitNestingRules) CanBeRoot(t UnitType) bool {
+	for _, root := range r.RootTypes {
+		if root == t {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsDepth indica si el árbol puede llegar al nivel depth (la raíz es el nivel 1)
+func (r UnitNestingRules) AllowsDepth(depth int) bool {
+	return r.MaxDepth <= 0 || depth <= r.MaxDepth
+}
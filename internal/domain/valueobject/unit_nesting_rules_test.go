@@ -0,0 +1,22 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitNestingRules_Overrides(t *testing.T) {
+	rules := valueobject.DefaultUnitNestingRules()
+	rules.AllowedParents[valueobject.UnitTypeSection] = []valueobject.UnitType{valueobject.UnitTypeGrade, valueobject.UnitTypeDepartment}
+	rules.MaxDepth = 3
+
+	assert.True(t, rules.CanNest(valueobject.UnitTypeSection, valueobject.UnitTypeDepartment))
+	assert.False(t, valueobject.UnitTypeSection.CanBeChildOf(valueobject.UnitTypeDepartment), "defaults must not be shared")
+	assert.False(t, rules.CanNest(valueobject.UnitTypeGrade, valueobject.UnitTypeSection))
+	assert.False(t, rules.CanBeRoot(valueobject.UnitTypeSection))
+	assert.True(t, rules.AllowsDepth(3))
+	assert.False(t, rules.AllowsDepth(4))
+	assert.True(t, valueobject.UnitNestingRules{}.AllowsDepth(50))
+}
@@ -60,3 +60,13 @@ func AllUnitTypesStrings() []string {
 	}
 	return result
 }
+
+// CanBeChildOf indica si una unidad de este tipo puede colgar de una unidad del tipo parent según las reglas por defecto
+func (t UnitType) CanBeChildOf(parent UnitType) bool {
+	return DefaultUnitNestingRules().CanNest(t, parent)
+}
+
+// CanBeRoot indica si una unidad de este tipo puede quedar sin padre según las reglas por defecto
+func (t UnitType) CanBeRoot() bool {
+	return DefaultUnitNestingRules().CanBeRoot(t)
+}
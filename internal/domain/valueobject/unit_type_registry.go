@@ -0,0 +1,44 @@
+package valueobject
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var customUnitTypeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,29}$`)
+
+// UnitTypeRegistry son los tipos de unidad disponibles en una escuela: los predefinidos más los propios
+type UnitTypeRegistry map[UnitType]bool
+
+// NewUnitTypeRegistry crea el registro con los tipos predefinidos y los propios indicados
+func NewUnitTypeRegistry(custom ...UnitType) UnitTypeRegistry {
+	registry := make(UnitTypeRegistry, len(validUnitTypes)+len(custom))
+	for t := range validUnitTypes {
+		registry[t] = true
+	}
+	for _, t := range custom {
+		registry[t] = true
+	}
+	return registry
+}
+
+// Parse convierte un string a UnitType si el tipo existe en el registro
+func (r UnitTypeRegistry) Parse(s string) (UnitType, error) {
+	unitType := UnitType(s)
+	if !r[unitType] {
+		return "", fmt.Errorf("invalid unit type: %s", s)
+	}
+	return unitType, nil
+}
+
+// ValidateCustomUnitTypeCode valida el código de un tipo propio: minúsculas, dígitos y guion
+// bajo (2 a 30 caracteres) y distinto de los tipos predefinidos
+func ValidateCustomUnitTypeCode(code string) error {
+	if !customUnitTypeCodePattern.MatchString(code) {
+		return fmt.Errorf("unit type code must be 2-30 lowercase letters, digits or underscores, starting with a letter")
+	}
+	if UnitType(code).IsValid() {
+		return fmt.Errorf("unit type %s is built in", code)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitTypeRegistry_Parse(t *testing.T) {
+	registry := valueobject.NewUnitTypeRegistry("campus")
+
+	unitType, err := registry.Parse("campus")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.UnitType("campus"), unitType)
+
+	_, err = registry.Parse("grade")
+	assert.NoError(t, err, "built-in types stay available")
+
+	_, err = registry.Parse("jornada")
+	assert.Error(t, err)
+	_, err = valueobject.ParseUnitType("campus")
+	assert.Error(t, err, "custom types are only valid in their school's registry")
+}
+
+func TestValidateCustomUnitTypeCode(t *testing.T) {
+	assert.NoError(t, valueobject.ValidateCustomUnitTypeCode("ciclo"))
+	assert.NoError(t, valueobject.ValidateCustomUnitTypeCode("track_2"))
+	assert.Error(t, valueobject.ValidateCustomUnitTypeCode("section"))
+	assert.Error(t, valueobject.ValidateCustomUnitTypeCode("Campus"))
+	assert.Error(t, valueobject.ValidateCustomUnitTypeCode("c"))
+	assert.Error(t, valueobject.ValidateCustomUnitTypeCode("2nd_shift"))
+}
@@ -167,3 +167,15 @@ func TestAllUnitTypesStrings(t *testing.T) {
 	assert.Contains(t, types, "club")
 	assert.Contains(t, types, "department")
 }
+
+func TestUnitType_CanBeChildOf(t *testing.T) {
+	assert.True(t, valueobject.UnitTypeSection.CanBeChildOf(valueobject.UnitTypeGrade))
+	assert.True(t, valueobject.UnitTypeClub.CanBeChildOf(valueobject.UnitTypeSection))
+	assert.True(t, valueobject.UnitTypeDepartment.CanBeChildOf(valueobject.UnitTypeDepartment))
+	assert.False(t, valueobject.UnitTypeSection.CanBeChildOf(valueobject.UnitTypeSchool))
+	assert.False(t, valueobject.UnitTypeGrade.CanBeChildOf(valueobject.UnitTypeSection))
+	assert.False(t, valueobject.UnitTypeSchool.CanBeChildOf(valueobject.UnitTypeDepartment))
+
+	assert.True(t, valueobject.UnitTypeGrade.CanBeRoot())
+	assert.False(t, valueobject.UnitTypeSection.CanBeRoot())
+}
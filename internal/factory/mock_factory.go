@@ -50,3 +50,71 @@ func (f *mockRepositoryFactory) CreateStatsRepository() repository.StatsReposito
 func (f *mockRepositoryFactory) CreateGuardianRepository() repository.GuardianRepository {
 	return mockRepo.NewMockGuardianRepository()
 }
+
+func (f *mockRepositoryFactory) CreateUserStatusChangeRepository() repository.UserStatusChangeRepository {
+	return mockRepo.NewMockUserStatusChangeRepository()
+}
+
+func (f *mockRepositoryFactory) CreateDuplicateCandidateRepository() repository.DuplicateCandidateRepository {
+	return mockRepo.NewMockDuplicateCandidateRepository()
+}
+
+func (f *mockRepositoryFactory) CreateTransactionManager() repository.TransactionManager {
+	return mockRepo.NewMockTransactionManager()
+}
+
+func (f *mockRepositoryFactory) CreateInvitationRepository() repository.InvitationRepository {
+	return mockRepo.NewMockInvitationRepository()
+}
+
+func (f *mockRepositoryFactory) CreateUserProfileRepository() repository.UserProfileRepository {
+	return mockRepo.NewMockUserProfileRepository()
+}
+
+func (f *mockRepositoryFactory) CreateLoginEventRepository() repository.LoginEventRepository {
+	return mockRepo.NewMockLoginEventRepository()
+}
+
+func (f *mockRepositoryFactory) CreateUserMFARepository() repository.UserMFARepository {
+	return mockRepo.NewMockUserMFARepository()
+}
+
+func (f *mockRepositoryFactory) CreateAdminChangeRequestRepository() repository.AdminChangeRequestRepository {
+	return mockRepo.NewMockAdminChangeRequestRepository()
+}
+
+func (f *mockRepositoryFactory) CreateSubscriptionChangeRepository() repository.SubscriptionChangeRepository {
+	return mockRepo.NewMockSubscriptionChangeRepository()
+}
+
+func (f *mockRepositoryFactory) CreateSchoolSettingsRepository() repository.SchoolSettingsRepository {
+	return mockRepo.NewMockSchoolSettingsRepository()
+}
+
+func (f *mockRepositoryFactory) CreateSchoolLifecycleRepository() repository.SchoolLifecycleRepository {
+	return mockRepo.NewMockSchoolLifecycleRepository()
+}
+
+func (f *mockRepositoryFactory) CreateAcademicPeriodRepository() repository.AcademicPeriodRepository {
+	return mockRepo.NewMockAcademicPeriodRepository()
+}
+
+func (f *mockRepositoryFactory) CreateRolloverRepository() repository.RolloverRepository {
+	return mockRepo.NewMockRolloverRepository()
+}
+
+func (f *mockRepositoryFactory) CreateUnitDeletionRepository() repository.UnitDeletionRepository {
+	return mockRepo.NewMockUnitDeletionRepository()
+}
+
+func (f *mockRepositoryFactory) CreateSchoolUnitTypeRepository() repository.SchoolUnitTypeRepository {
+	return mockRepo.NewMockSchoolUnitTypeRepository()
+}
+
+func (f *mockRepositoryFactory) CreateUnitCapacityRepository() repository.UnitCapacityRepository {
+	return mockRepo.NewMockUnitCapacityRepository()
+}
+
+func (f *mockRepositoryFactory) CreateUserTokenRevocationRepository() repository.UserTokenRevocationRepository {
+	return mockRepo.NewMockUserTokenRevocationRepository()
+}
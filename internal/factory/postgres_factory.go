@@ -52,3 +52,71 @@ func (f *postgresRepositoryFactory) CreateStatsRepository() repository.StatsRepo
 func (f *postgresRepositoryFactory) CreateGuardianRepository() repository.GuardianRepository {
 	return postgresRepo.NewPostgresGuardianRepository(f.db)
 }
+
+func (f *postgresRepositoryFactory) CreateUserStatusChangeRepository() repository.UserStatusChangeRepository {
+	return postgresRepo.NewPostgresUserStatusChangeRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateDuplicateCandidateRepository() repository.DuplicateCandidateRepository {
+	return postgresRepo.NewPostgresDuplicateCandidateRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateTransactionManager() repository.TransactionManager {
+	return postgresRepo.NewPostgresTransactionManager(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateInvitationRepository() repository.InvitationRepository {
+	return postgresRepo.NewPostgresInvitationRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateUserProfileRepository() repository.UserProfileRepository {
+	return postgresRepo.NewPostgresUserProfileRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateLoginEventRepository() repository.LoginEventRepository {
+	return postgresRepo.NewPostgresLoginEventRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateUserMFARepository() repository.UserMFARepository {
+	return postgresRepo.NewPostgresUserMFARepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateAdminChangeRequestRepository() repository.AdminChangeRequestRepository {
+	return postgresRepo.NewPostgresAdminChangeRequestRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateSubscriptionChangeRepository() repository.SubscriptionChangeRepository {
+	return postgresRepo.NewPostgresSubscriptionChangeRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateSchoolSettingsRepository() repository.SchoolSettingsRepository {
+	return postgresRepo.NewPostgresSchoolSettingsRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateSchoolLifecycleRepository() repository.SchoolLifecycleRepository {
+	return postgresRepo.NewPostgresSchoolLifecycleRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateAcademicPeriodRepository() repository.AcademicPeriodRepository {
+	return postgresRepo.NewPostgresAcademicPeriodRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateRolloverRepository() repository.RolloverRepository {
+	return postgresRepo.NewPostgresRolloverRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateUnitDeletionRepository() repository.UnitDeletionRepository {
+	return postgresRepo.NewPostgresUnitDeletionRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateSchoolUnitTypeRepository() repository.SchoolUnitTypeRepository {
+	return postgresRepo.NewPostgresSchoolUnitTypeRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateUnitCapacityRepository() repository.UnitCapacityRepository {
+	return postgresRepo.NewPostgresUnitCapacityRepository(f.db)
+}
+
+func (f *postgresRepositoryFactory) CreateUserTokenRevocationRepository() repository.UserTokenRevocationRepository {
+	return postgresRepo.NewPostgresUserTokenRevocationRepository(f.db)
+}
@@ -17,4 +17,21 @@ type RepositoryFactory interface {
 	CreateMaterialRepository() repository.MaterialRepository
 	CreateStatsRepository() repository.StatsRepository
 	CreateGuardianRepository() repository.GuardianRepository
+	CreateUserStatusChangeRepository() repository.UserStatusChangeRepository
+	CreateDuplicateCandidateRepository() repository.DuplicateCandidateRepository
+	CreateTransactionManager() repository.TransactionManager
+	CreateInvitationRepository() repository.InvitationRepository
+	CreateUserProfileRepository() repository.UserProfileRepository
+	CreateLoginEventRepository() repository.LoginEventRepository
+	CreateUserMFARepository() repository.UserMFARepository
+	CreateAdminChangeRequestRepository() repository.AdminChangeRequestRepository
+	CreateSubscriptionChangeRepository() repository.SubscriptionChangeRepository
+	CreateSchoolSettingsRepository() repository.SchoolSettingsRepository
+	CreateSchoolLifecycleRepository() repository.SchoolLifecycleRepository
+	CreateAcademicPeriodRepository() repository.AcademicPeriodRepository
+	CreateRolloverRepository() repository.RolloverRepository
+	CreateUnitDeletionRepository() repository.UnitDeletionRepository
+	CreateSchoolUnitTypeRepository() repository.SchoolUnitTypeRepository
+	CreateUnitCapacityRepository() repository.UnitCapacityRepository
+	CreateUserTokenRevocationRepository() repository.UserTokenRevocationRepository
 }
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// AcademicPeriodHandler maneja los años lectivos y sus períodos
+type AcademicPeriodHandler struct {
+	periodService service.AcademicPeriodService
+	logger        logger.Logger
+}
+
+// NewAcademicPeriodHandler crea un nuevo AcademicPeriodHandler
+func NewAcademicPeriodHandler(periodService service.AcademicPeriodService, logger logger.Logger) *AcademicPeriodHandler {
+	return &AcademicPeriodHandler{
+		periodService: periodService,
+		logger:        logger,
+	}
+}
+
+// ListPeriods godoc
+// @Summary List a school's academic years
+// @Description Lists the academic years of a school ordered by start date, each with its terms
+// @Tags academic-periods
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {array} dto.AcademicPeriodResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/schools/{id}/academic-periods [get]
+// @Security BearerAuth
+func (h *AcademicPeriodHandler) ListPeriods(c *gin.Context) {
+	periods, err := h.periodService.ListPeriods(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, periods)
+}
+
+// GetCurrentPeriod godoc
+// @Summary Get a school's active academic year
+// @Description Returns the active academic year of the school with its terms
+// @Tags academic-periods
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {object} dto.AcademicPeriodResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/academic-periods/current [get]
+// @Security BearerAuth
+func (h *AcademicPeriodHandler) GetCurrentPeriod(c *gin.Context) {
+	period, err := h.periodService.GetCurrentPeriod(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// CreateYear godoc
+// @Summary Create an academic year
+// @Description Creates a planned academic year for the school. Its dates cannot overlap other years of the school
+// @Tags academic-periods
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param request body dto.CreateAcademicYearRequest true "Academic year"
+// @Success 201 {object} dto.AcademicPeriodResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /v1/schools/{id}/academic-years [post]
+// @Security BearerAuth
+func (h *AcademicPeriodHandler) CreateYear(c *gin.Context) {
+	var req dto.CreateAcademicYearRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	year, err := h.periodService.CreateYear(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, year)
+}
+
+// GetPeriod godoc
+// @Summary Get an academic year or term
+// @Tags academic-periods
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} dto.AcademicPeriodResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/academic-periods/{id} [get]
+// @Security BearerAuth
+func (h *AcademicPeriodHandler) GetPeriod(c *gin.Context) {
+	period, err := h.periodService.GetPeriod(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// UpdatePeriod godoc
+// @Summary Update an academic year or term
+// @Description Changes the name or dates of a period. Closed periods are read-only
+// @Tags academic-periods
+// @Accept json
+// @Produce json
+// @Param id path string true "Period ID"
+// @Param request body dto.UpdateAcademicPeriodRequest true "Fields to change"
+// @Success 200 {object} dto.AcademicPeriodResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/academic-periods/{id} [patch]
+// @Security BearerAuth
+func (h *AcademicPeriodHandler) UpdatePeriod(c *gin.Context) {
+	var req dto.UpdateAcademicPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	period, err := h.periodService.UpdatePeriod(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// CreateTerm godoc
+// @Summary Create a term inside an academic year
+// @Description Creates a planned term. Its dates must be within the year and cannot overlap other terms
+// @Tags academic-periods
+// @Accept json
+// @Produce json
+// @Param id path string true "Academic year ID"
+// @Param request body dto.CreateAcademicTermRequest true "Term"
+// @Success 201 {object} dto.AcademicPeriodResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/academic-periods/{id}/terms [post]
+// @Security BearerAuth
+func (h *AcademicPeriodHandler) CreateTerm(c *gin.Context) {
+	var req dto.CreateAcademicTermRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	term, err := h.periodService.CreateTerm(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, term)
+}
+
+// ActivatePeriod godoc
+// @Summary Activate an academic year or term
+// @Description Moves a planned period to active. A school has at most one active year, and a year at most one active term
+// @Tags academic-periods
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} dto.AcademicPeriodResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/academic-periods/{id}/activate [post]
+// @Security BearerAuth
+func (h *AcademicPeriodHandler) ActivatePeriod(c *gin.Context) {
+	period, err := h.periodService.ActivatePeriod(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// ClosePeriod godoc
+// @Summary Close an academic year or term
+// @Description Closes an active period. Closing a year also closes its terms and makes the year's units and memberships read-only
+// @Tags academic-periods
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} dto.AcademicPeriodResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/academic-periods/{id}/close [post]
+// @Security BearerAuth
+func (h *AcademicPeriodHandler) ClosePeriod(c *gin.Context) {
+	period, err := h.periodService.ClosePeriod(c.Request.Context(), c.Param("id"), actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -62,6 +63,38 @@ func (h *AcademicUnitHandler) CreateUnit(c *gin.Context) {
 	c.JSON(http.StatusCreated, unit)
 }
 
+// BulkCreateUnits godoc
+// @Summary Generate academic units from a pattern
+// @Description Creates in one transaction the unit tree described by the generator levels, e.g. "Grado {value}" for 1..11 with "Sección {value}" for A..D under each grade. The first level hangs from parent_unit_id, from every existing unit of parent_types in the academic year, or from the school root. Templates accept {value}, {index}, {parent_name} and {parent_code}
+// @Tags academic-units
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param request body dto.BulkCreateUnitsRequest true "Generator spec"
+// @Success 201 {object} dto.BulkCreateUnitsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/schools/{id}/units/bulk [post]
+// @Security BearerAuth
+func (h *AcademicUnitHandler) BulkCreateUnits(c *gin.Context) {
+	var req dto.BulkCreateUnitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err, "school_id", c.Param("id"))
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	result, err := h.unitService.BulkCreateUnits(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
 // GetUnit godoc
 // @Summary Get an academic unit by ID
 // @Description Retrieves an academic unit by its ID
@@ -92,10 +125,11 @@ func (h *AcademicUnitHandler) GetUnit(c *gin.Context) {
 
 // GetUnitTree godoc
 // @Summary Get the hierarchical tree of units for a school
-// @Description Retrieves the complete hierarchy tree of academic units for a school
+// @Description Retrieves the hierarchy tree of academic units for a school, scoped to an academic year. Units without a year are always included
 // @Tags academic-units
 // @Produce json
 // @Param schoolId path string true "School ID"
+// @Param academic_year query string false "Academic year to list (defaults to the active year, \"all\" disables the filter)"
 // @Success 200 {array} dto.UnitTreeNode
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -109,7 +143,7 @@ func (h *AcademicUnitHandler) GetUnitTree(c *gin.Context) {
 		return
 	}
 
-	tree, err := h.unitService.GetUnitTree(c.Request.Context(), schoolID)
+	tree, err := h.unitService.GetUnitTree(c.Request.Context(), schoolID, c.Query("academic_year"))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -120,11 +154,12 @@ func (h *AcademicUnitHandler) GetUnitTree(c *gin.Context) {
 
 // ListUnitsBySchool godoc
 // @Summary List all units for a school
-// @Description Retrieves all academic units for a specific school
+// @Description Retrieves the academic units of a school for an academic year. Units without a year are always included
 // @Tags academic-units
 // @Produce json
 // @Param schoolId path string true "School ID"
 // @Param includeDeleted query bool false "Include soft-deleted units"
+// @Param academic_year query string false "Academic year to list (defaults to the active year, \"all\" disables the filter)"
 // @Success 200 {array} dto.AcademicUnitResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -140,7 +175,7 @@ func (h *AcademicUnitHandler) ListUnitsBySchool(c *gin.Context) {
 
 	includeDeleted := c.DefaultQuery("includeDeleted", "false") == "true"
 
-	units, err := h.unitService.ListUnitsBySchool(c.Request.Context(), schoolID, includeDeleted)
+	units, err := h.unitService.ListUnitsBySchool(c.Request.Context(), schoolID, includeDeleted, c.Query("academic_year"))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -156,6 +191,7 @@ func (h *AcademicUnitHandler) ListUnitsBySchool(c *gin.Context) {
 // @Produce json
 // @Param schoolId path string true "School ID"
 // @Param type query string true "Unit type (grade, section, club, department)"
+// @Param academic_year query string false "Academic year to list (defaults to the active year, \"all\" disables the filter)"
 // @Success 200 {array} dto.AcademicUnitResponse
 // @Failure 400 {object} ErrorResponse
 // @Router /v1/schools/{schoolId}/units/by-type [get]
@@ -174,7 +210,7 @@ func (h *AcademicUnitHandler) ListUnitsByType(c *gin.Context) {
 		return
 	}
 
-	units, err := h.unitService.ListUnitsByType(c.Request.Context(), schoolID, unitType)
+	units, err := h.unitService.ListUnitsByType(c.Request.Context(), schoolID, unitType, c.Query("academic_year"))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -220,15 +256,86 @@ func (h *AcademicUnitHandler) UpdateUnit(c *gin.Context) {
 	c.JSON(http.StatusOK, unit)
 }
 
+// ListDescendants godoc
+// @Summary List the descendants of a unit
+// @Description Returns the subtree below a unit, ordered by depth, together with the unit counts of the whole subtree by type
+// @Tags academic-units
+// @Produce json
+// @Param id path string true "Unit ID"
+// @Param max_depth query int false "Maximum depth below the unit (0 or empty = unlimited)"
+// @Param includeDeleted query bool false "Include soft-deleted units"
+// @Success 200 {object} dto.UnitDescendantsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/units/{id}/descendants [get]
+// @Security BearerAuth
+func (h *AcademicUnitHandler) ListDescendants(c *gin.Context) {
+	id := c.Param("id")
+
+	maxDepth := 0
+	if raw := c.Query("max_depth"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "max_depth must be a number", Code: "INVALID_REQUEST"})
+			return
+		}
+		maxDepth = parsed
+	}
+	includeDeleted := c.DefaultQuery("includeDeleted", "false") == "true"
+
+	result, err := h.unitService.ListDescendants(c.Request.Context(), id, maxDepth, includeDeleted)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// MoveUnit godoc
+// @Summary Move an academic unit with its subtree
+// @Description Changes the parent of a unit; its descendants move with it. The new parent must belong to the same school and academic year, must not be deleted, must accept the unit's type and cannot be the unit or one of its descendants. Without parent_unit_id the unit becomes a root
+// @Tags academic-units
+// @Accept json
+// @Produce json
+// @Param id path string true "Unit ID"
+// @Param request body dto.MoveAcademicUnitRequest true "New parent"
+// @Success 200 {object} dto.AcademicUnitResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/units/{id}/move [post]
+// @Security BearerAuth
+func (h *AcademicUnitHandler) MoveUnit(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.MoveAcademicUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err, "unit_id", id)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	unit, err := h.unitService.MoveUnit(c.Request.Context(), id, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, unit)
+}
+
 // DeleteUnit godoc
-// @Summary Delete an academic unit
-// @Description Soft deletes an academic unit
+// @Summary Delete an academic unit with its subtree
+// @Description Soft deletes the unit and all its descendants and suspends their active memberships, recorded as one deletion batch. With dry_run=true only the affected counts are returned
 // @Tags academic-units
 // @Produce json
 // @Param id path string true "Unit ID"
-// @Success 204 "No Content"
+// @Param dry_run query bool false "Preview the affected counts without deleting"
+// @Success 200 {object} dto.UnitDeletionResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /v1/units/{id} [delete]
 // @Security BearerAuth
 func (h *AcademicUnitHandler) DeleteUnit(c *gin.Context) {
@@ -239,24 +346,26 @@ func (h *AcademicUnitHandler) DeleteUnit(c *gin.Context) {
 		return
 	}
 
-	err := h.unitService.DeleteUnit(c.Request.Context(), id)
+	result, err := h.unitService.DeleteUnit(c.Request.Context(), id, c.Query("dry_run") == "true", actorID(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, result)
 }
 
 // RestoreUnit godoc
-// @Summary Restore a soft-deleted academic unit
-// @Description Restores a previously soft-deleted academic unit
+// @Summary Restore a soft-deleted academic unit with its subtree
+// @Description Reverts the deletion batch that removed the unit: restores exactly the units it deleted and reactivates the memberships it suspended. A unit deleted as part of an ancestor's batch must be restored through that ancestor. With dry_run=true only the counts are returned
 // @Tags academic-units
 // @Produce json
 // @Param id path string true "Unit ID"
-// @Success 204 "No Content"
+// @Param dry_run query bool false "Preview the restored counts without restoring"
+// @Success 200 {object} dto.UnitDeletionResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /v1/units/{id}/restore [post]
 // @Security BearerAuth
 func (h *AcademicUnitHandler) RestoreUnit(c *gin.Context) {
@@ -267,13 +376,13 @@ func (h *AcademicUnitHandler) RestoreUnit(c *gin.Context) {
 		return
 	}
 
-	err := h.unitService.RestoreUnit(c.Request.Context(), id)
+	result, err := h.unitService.RestoreUnit(c.Request.Context(), id, c.Query("dry_run") == "true", actorID(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, result)
 }
 
 // GetHierarchyPath godoc
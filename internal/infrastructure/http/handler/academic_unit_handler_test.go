@@ -38,24 +38,24 @@ func (m *MockAcademicUnitService) GetUnit(ctx context.Context, id string) (*dto.
 	return args.Get(0).(*dto.AcademicUnitResponse), args.Error(1)
 }
 
-func (m *MockAcademicUnitService) GetUnitTree(ctx context.Context, schoolID string) ([]*dto.UnitTreeNode, error) {
-	args := m.Called(ctx, schoolID)
+func (m *MockAcademicUnitService) GetUnitTree(ctx context.Context, schoolID string, academicYear string) ([]*dto.UnitTreeNode, error) {
+	args := m.Called(ctx, schoolID, academicYear)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*dto.UnitTreeNode), args.Error(1)
 }
 
-func (m *MockAcademicUnitService) ListUnitsBySchool(ctx context.Context, schoolID string, includeDeleted bool) ([]dto.AcademicUnitResponse, error) {
-	args := m.Called(ctx, schoolID, includeDeleted)
+func (m *MockAcademicUnitService) ListUnitsBySchool(ctx context.Context, schoolID string, includeDeleted bool, academicYear string) ([]dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, schoolID, includeDeleted, academicYear)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]dto.AcademicUnitResponse), args.Error(1)
 }
 
-func (m *MockAcademicUnitService) ListUnitsByType(ctx context.Context, schoolID string, unitType string) ([]dto.AcademicUnitResponse, error) {
-	args := m.Called(ctx, schoolID, unitType)
+func (m *MockAcademicUnitService) ListUnitsByType(ctx context.Context, schoolID string, unitType string, academicYear string) ([]dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, schoolID, unitType, academicYear)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -70,14 +70,36 @@ func (m *MockAcademicUnitService) UpdateUnit(ctx context.Context, id string, req
 	return args.Get(0).(*dto.AcademicUnitResponse), args.Error(1)
 }
 
-func (m *MockAcademicUnitService) DeleteUnit(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
+func (m *MockAcademicUnitService) BulkCreateUnits(ctx context.Context, schoolID string, req dto.BulkCreateUnitsRequest) (*dto.BulkCreateUnitsResponse, error) {
+	args := m.Called(ctx, schoolID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.BulkCreateUnitsResponse), args.Error(1)
 }
 
-func (m *MockAcademicUnitService) RestoreUnit(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
+func (m *MockAcademicUnitService) MoveUnit(ctx context.Context, id string, req dto.MoveAcademicUnitRequest) (*dto.AcademicUnitResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AcademicUnitResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) DeleteUnit(ctx context.Context, id string, dryRun bool, deletedBy string) (*dto.UnitDeletionResponse, error) {
+	args := m.Called(ctx, id, dryRun, deletedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UnitDeletionResponse), args.Error(1)
+}
+
+func (m *MockAcademicUnitService) RestoreUnit(ctx context.Context, id string, dryRun bool, restoredBy string) (*dto.UnitDeletionResponse, error) {
+	args := m.Called(ctx, id, dryRun, restoredBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UnitDeletionResponse), args.Error(1)
 }
 
 func (m *MockAcademicUnitService) GetHierarchyPath(ctx context.Context, id string) ([]dto.AcademicUnitResponse, error) {
@@ -88,6 +110,14 @@ func (m *MockAcademicUnitService) GetHierarchyPath(ctx context.Context, id strin
 	return args.Get(0).([]dto.AcademicUnitResponse), args.Error(1)
 }
 
+func (m *MockAcademicUnitService) ListDescendants(ctx context.Context, id string, maxDepth int, includeDeleted bool) (*dto.UnitDescendantsResponse, error) {
+	args := m.Called(ctx, id, maxDepth, includeDeleted)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UnitDescendantsResponse), args.Error(1)
+}
+
 func setupAcademicUnitHandler() (*AcademicUnitHandler, *MockAcademicUnitService) {
 	gin.SetMode(gin.TestMode)
 	mockService := new(MockAcademicUnitService)
@@ -159,7 +189,7 @@ func TestAcademicUnitHandler_GetUnitTree_Success(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetUnitTree", mock.Anything, "school-123").Return(expectedResp, nil)
+	mockService.On("GetUnitTree", mock.Anything, "school-123", "").Return(expectedResp, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -180,7 +210,7 @@ func TestAcademicUnitHandler_ListUnitsBySchool_Success(t *testing.T) {
 		{ID: "unit-2", DisplayName: "Unit 2"},
 	}
 
-	mockService.On("ListUnitsBySchool", mock.Anything, "school-123", false).Return(expectedResp, nil)
+	mockService.On("ListUnitsBySchool", mock.Anything, "school-123", false, "").Return(expectedResp, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -200,7 +230,7 @@ func TestAcademicUnitHandler_ListUnitsByType_Success(t *testing.T) {
 		{ID: "unit-1", Type: "grade"},
 	}
 
-	mockService.On("ListUnitsByType", mock.Anything, "school-123", "grade").Return(expectedResp, nil)
+	mockService.On("ListUnitsByType", mock.Anything, "school-123", "grade", "").Return(expectedResp, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -243,7 +273,7 @@ func TestAcademicUnitHandler_UpdateUnit_Success(t *testing.T) {
 func TestAcademicUnitHandler_DeleteUnit_Success(t *testing.T) {
 	handler, mockService := setupAcademicUnitHandler()
 
-	mockService.On("DeleteUnit", mock.Anything, "unit-123").Return(nil)
+	mockService.On("DeleteUnit", mock.Anything, "unit-123", false, mock.Anything).Return(&dto.UnitDeletionResponse{UnitID: "unit-123", Units: 3}, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -259,7 +289,7 @@ func TestAcademicUnitHandler_DeleteUnit_Success(t *testing.T) {
 func TestAcademicUnitHandler_RestoreUnit_Success(t *testing.T) {
 	handler, mockService := setupAcademicUnitHandler()
 
-	mockService.On("RestoreUnit", mock.Anything, "unit-123").Return(nil)
+	mockService.On("RestoreUnit", mock.Anything, "unit-123", false, mock.Anything).Return(&dto.UnitDeletionResponse{UnitID: "unit-123", Units: 3}, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -293,6 +323,31 @@ func TestAcademicUnitHandler_GetHierarchyPath_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestAcademicUnitHandler_ListDescendants_ParsesQuery(t *testing.T) {
+	handler, mockService := setupAcademicUnitHandler()
+
+	mockService.On("ListDescendants", mock.Anything, "unit-123", 2, true).Return(&dto.UnitDescendantsResponse{UnitID: "unit-123", MaxDepth: 2}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/v1/units/unit-123/descendants?max_depth=2&includeDeleted=true", nil)
+	c.Params = gin.Params{{Key: "id", Value: "unit-123"}}
+
+	handler.ListDescendants(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/v1/units/unit-123/descendants?max_depth=deep", nil)
+	c.Params = gin.Params{{Key: "id", Value: "unit-123"}}
+
+	handler.ListDescendants(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestAcademicUnitHandler_GetUnit_NotFound(t *testing.T) {
 	handler, mockService := setupAcademicUnitHandler()
 
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// AdminManagementHandler maneja el alta y la promoción de administradores (solo super admins)
+type AdminManagementHandler struct {
+	adminService service.AdminManagementService
+	logger       logger.Logger
+}
+
+// NewAdminManagementHandler crea un nuevo AdminManagementHandler
+func NewAdminManagementHandler(
+	adminService service.AdminManagementService,
+	logger logger.Logger,
+) *AdminManagementHandler {
+	return &AdminManagementHandler{
+		adminService: adminService,
+		logger:       logger,
+	}
+}
+
+// CreateAdmin godoc
+// @Summary Create an admin
+// @Description Super admin only. With a valid mfa_code the admin is created immediately (201); otherwise a request is queued for approval by a second super admin (202). Without school_id the new admin is a super admin
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAdminRequest true "Admin data"
+// @Success 201 {object} dto.AdminChangeRequestResponse
+// @Success 202 {object} dto.AdminChangeRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admins [post]
+// @Security BearerAuth
+func (h *AdminManagementHandler) CreateAdmin(c *gin.Context) {
+	var req dto.CreateAdminRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.adminService.CreateAdmin(c.Request.Context(), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	h.respond(c, result, http.StatusCreated)
+}
+
+// PromoteToAdmin godoc
+// @Summary Promote a user to admin
+// @Description Super admin only. With a valid mfa_code the promotion is applied immediately (200); otherwise a request is queued for approval by a second super admin (202)
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param request body dto.PromoteAdminRequest true "Promotion data"
+// @Success 200 {object} dto.AdminChangeRequestResponse
+// @Success 202 {object} dto.AdminChangeRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/{userId}/promote-admin [post]
+// @Security BearerAuth
+func (h *AdminManagementHandler) PromoteToAdmin(c *gin.Context) {
+	var req dto.PromoteAdminRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.adminService.PromoteToAdmin(c.Request.Context(), c.Param("userId"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	h.respond(c, result, http.StatusOK)
+}
+
+// ListRequests godoc
+// @Summary List admin change requests
+// @Description Super admin only. Audit log of admin creations and promotions, newest first
+// @Tags admins
+// @Produce json
+// @Param status query string false "pending, applied or rejected"
+// @Param limit query int false "Page size (default 50)"
+// @Param offset query int false "Offset"
+// @Success 200 {array} dto.AdminChangeRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin-requests [get]
+// @Security BearerAuth
+func (h *AdminManagementHandler) ListRequests(c *gin.Context) {
+	var req dto.ListAdminChangeRequestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid query params", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid query params",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	result, err := h.adminService.ListRequests(c.Request.Context(), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ApproveRequest godoc
+// @Summary Approve an admin change request
+// @Description Super admin only; the approver must be different from the requester
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param id path string true "Request ID"
+// @Param request body dto.DecideAdminChangeRequest false "Decision note"
+// @Success 200 {object} dto.AdminChangeRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "Not pending, expired or same admin"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin-requests/{id}/approve [post]
+// @Security BearerAuth
+func (h *AdminManagementHandler) ApproveRequest(c *gin.Context) {
+	var req dto.DecideAdminChangeRequest
+	if c.Request.ContentLength > 0 && !h.bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.adminService.ApproveRequest(c.Request.Context(), c.Param("id"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RejectRequest godoc
+// @Summary Reject an admin change request
+// @Description Super admin only; the requester can also use it to cancel their own request
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param id path string true "Request ID"
+// @Param request body dto.DecideAdminChangeRequest false "Decision note"
+// @Success 200 {object} dto.AdminChangeRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "Not pending or expired"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin-requests/{id}/reject [post]
+// @Security BearerAuth
+func (h *AdminManagementHandler) RejectRequest(c *gin.Context) {
+	var req dto.DecideAdminChangeRequest
+	if c.Request.ContentLength > 0 && !h.bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.adminService.RejectRequest(c.Request.Context(), c.Param("id"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *AdminManagementHandler) bindJSON(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_REQUEST",
+		})
+		return false
+	}
+	return true
+}
+
+// respond usa 202 para solicitudes que quedaron esperando aprobación
+func (h *AdminManagementHandler) respond(c *gin.Context, result *dto.AdminChangeRequestResponse, appliedStatus int) {
+	if result.Status == repository.AdminChangeStatusPending {
+		c.JSON(http.StatusAccepted, result)
+		return
+	}
+	c.JSON(appliedStatus, result)
+}
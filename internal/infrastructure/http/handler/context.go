@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// actorID obtiene el ID del usuario autenticado que ejecuta la acción
+// (agregado por el middleware JWT). Usa "system" como fallback para desarrollo.
+func actorID(c *gin.Context) string {
+	for _, key := range []string{"user_id", "admin_id"} {
+		if value, exists := c.Get(key); exists {
+			if id := fmt.Sprint(value); id != "" {
+				return id
+			}
+		}
+	}
+	return "system"
+}
+
+// currentUserID obtiene el ID del usuario autenticado (agregado por el middleware JWT).
+// A diferencia de actorID no tiene fallback: las rutas de autoservicio requieren un usuario real.
+func currentUserID(c *gin.Context) (string, bool) {
+	value, exists := c.Get("user_id")
+	if !exists {
+		return "", false
+	}
+	id := fmt.Sprint(value)
+	return id, id != ""
+}
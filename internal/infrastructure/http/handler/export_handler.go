@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// ExportHandler maneja las peticiones HTTP de exportación de datos
+type ExportHandler struct {
+	exportService service.ExportService
+	logger        logger.Logger
+}
+
+// NewExportHandler crea un nuevo ExportHandler
+func NewExportHandler(
+	exportService service.ExportService,
+	logger logger.Logger,
+) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// ExportUsers godoc
+// @Summary Export users
+// @Description Streams users as CSV, XLSX or NDJSON
+// @Tags exports
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "csv (default), xlsx or ndjson"
+// @Param columns query string false "Comma separated columns (default: all)"
+// @Param role query string false "Filter by system role"
+// @Param is_active query bool false "Filter by active status"
+// @Param school_id query string false "Only users with an active membership in this school"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/exports/users [get]
+// @Security BearerAuth
+func (h *ExportHandler) ExportUsers(c *gin.Context) {
+	var req dto.ExportUsersRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	h.stream(c, req.ExportOptions, "users", func(w io.Writer) error {
+		return h.exportService.ExportUsers(c.Request.Context(), req, w)
+	})
+}
+
+// ExportUnitMemberships godoc
+// @Summary Export unit roster
+// @Description Streams the memberships of a unit as CSV, XLSX or NDJSON
+// @Tags exports
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param id path string true "Unit ID"
+// @Param format query string false "csv (default), xlsx or ndjson"
+// @Param columns query string false "Comma separated columns (default: all)"
+// @Param role query string false "Filter by membership role"
+// @Param active_only query bool false "Only active memberships"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/units/{id}/memberships/export [get]
+// @Security BearerAuth
+func (h *ExportHandler) ExportUnitMemberships(c *gin.Context) {
+	var req dto.ExportMembershipsRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+	req.UnitID = c.Param("id")
+
+	h.stream(c, req.ExportOptions, "memberships", func(w io.Writer) error {
+		return h.exportService.ExportUnitMemberships(c.Request.Context(), req, w)
+	})
+}
+
+// ExportGuardianRelations godoc
+// @Summary Export guardian relations of a unit
+// @Description Streams the guardian relations of the students of a unit as CSV, XLSX or NDJSON
+// @Tags exports
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param id path string true "Unit ID"
+// @Param format query string false "csv (default), xlsx or ndjson"
+// @Param columns query string false "Comma separated columns (default: all)"
+// @Param active_only query bool false "Only active relations"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/units/{id}/guardian-relations/export [get]
+// @Security BearerAuth
+func (h *ExportHandler) ExportGuardianRelations(c *gin.Context) {
+	var req dto.ExportGuardianRelationsRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+	req.UnitID = c.Param("id")
+
+	h.stream(c, req.ExportOptions, "guardian-relations", func(w io.Writer) error {
+		return h.exportService.ExportGuardianRelations(c.Request.Context(), req, w)
+	})
+}
+
+func (h *ExportHandler) bindQuery(c *gin.Context, req any) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		h.logger.Warn("invalid query params", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid query params",
+			Code:  "INVALID_REQUEST",
+		})
+		return false
+	}
+	return true
+}
+
+// stream prepara los headers de descarga y escribe la exportación directamente en la respuesta
+func (h *ExportHandler) stream(c *gin.Context, opts dto.ExportOptions, name string, export func(w io.Writer) error) {
+	format, err := opts.OutputFormat()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "unsupported export format",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", `attachment; filename="`+name+`.`+string(format)+`"`)
+
+	if err := export(c.Writer); err != nil {
+		if c.Writer.Written() {
+			// Ya se enviaron datos: no se puede cambiar el status, solo registrar
+			h.logger.Error("export interrupted", "export", name, "error", err.Error())
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		_ = c.Error(err)
+	}
+}
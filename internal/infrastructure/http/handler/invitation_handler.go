@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// InvitationHandler maneja las invitaciones a escuelas
+type InvitationHandler struct {
+	invitationService service.InvitationService
+	logger            logger.Logger
+}
+
+// NewInvitationHandler crea un nuevo InvitationHandler
+func NewInvitationHandler(
+	invitationService service.InvitationService,
+	logger logger.Logger,
+) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: invitationService,
+		logger:            logger,
+	}
+}
+
+// CreateInvitation godoc
+// @Summary Invite a user to a school
+// @Description Invites an email to a school (and optionally a unit) with a membership role. The invitee receives a signed, expiring link.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateInvitationRequest true "Invitation"
+// @Success 201 {object} dto.InvitationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Pending invitation or membership already exists"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invitations [post]
+// @Security BearerAuth
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	var req dto.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	invitation, err := h.invitationService.CreateInvitation(c.Request.Context(), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ListSchoolInvitations godoc
+// @Summary List invitations of a school
+// @Tags invitations
+// @Produce json
+// @Param id path string true "School ID"
+// @Param status query string false "pending, accepted, revoked or expired"
+// @Param limit query int false "Page size"
+// @Param offset query int false "Offset"
+// @Success 200 {array} dto.InvitationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/schools/{id}/invitations [get]
+// @Security BearerAuth
+func (h *InvitationHandler) ListSchoolInvitations(c *gin.Context) {
+	var req dto.ListInvitationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid query params", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid query params",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+	req.SchoolID = c.Param("id")
+
+	invitations, err := h.invitationService.ListInvitations(c.Request.Context(), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// ResendInvitation godoc
+// @Summary Resend an invitation
+// @Description Issues a new link (the previous one stops working) and renews the expiration
+// @Tags invitations
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} dto.InvitationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "Invitation is not pending"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invitations/{id}/resend [post]
+// @Security BearerAuth
+func (h *InvitationHandler) ResendInvitation(c *gin.Context) {
+	invitation, err := h.invitationService.ResendInvitation(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke an invitation
+// @Tags invitations
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} dto.InvitationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "Invitation is not pending"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invitations/{id}/revoke [post]
+// @Security BearerAuth
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	invitation, err := h.invitationService.RevokeInvitation(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation
+// @Description Public endpoint used from the invitation link. Creates or links the account, sets the password for new accounts and creates the membership atomically.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param request body dto.AcceptInvitationRequest true "Token and account data"
+// @Success 200 {object} dto.AcceptInvitationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Membership already exists"
+// @Failure 422 {object} ErrorResponse "Invitation expired, revoked or already accepted"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	var req dto.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	result, err := h.invitationService.AcceptInvitation(c.Request.Context(), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// MeHandler maneja el autoservicio del usuario autenticado sobre su propio perfil y su MFA
+type MeHandler struct {
+	profileService service.UserProfileService
+	mfaService     service.MFAService
+	logger         logger.Logger
+}
+
+// NewMeHandler crea un nuevo MeHandler
+func NewMeHandler(
+	profileService service.UserProfileService,
+	mfaService service.MFAService,
+	logger logger.Logger,
+) *MeHandler {
+	return &MeHandler{
+		profileService: profileService,
+		mfaService:     mfaService,
+		logger:         logger,
+	}
+}
+
+// GetMe godoc
+// @Summary Get my profile
+// @Description Returns the authenticated user with profile and notification preferences
+// @Tags me
+// @Produce json
+// @Success 200 {object} dto.MeResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/me [get]
+// @Security BearerAuth
+func (h *MeHandler) GetMe(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		h.unauthorized(c)
+		return
+	}
+
+	result, err := h.profileService.GetMyProfile(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateMe godoc
+// @Summary Update my profile
+// @Description Updates name, contact data, locale and notification preferences of the authenticated user. Empty strings clear phone, date_of_birth and avatar_url
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdateMyProfileRequest true "Profile changes"
+// @Success 200 {object} dto.MeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/me [patch]
+// @Security BearerAuth
+func (h *MeHandler) UpdateMe(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		h.unauthorized(c)
+		return
+	}
+
+	var req dto.UpdateMyProfileRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.profileService.UpdateMyProfile(c.Request.Context(), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetMFAStatus godoc
+// @Summary Get my MFA status
+// @Description Returns whether TOTP two-factor authentication is enabled or pending confirmation
+// @Tags me
+// @Produce json
+// @Success 200 {object} dto.MFAStatusResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/me/mfa [get]
+// @Security BearerAuth
+func (h *MeHandler) GetMFAStatus(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		h.unauthorized(c)
+		return
+	}
+
+	result, err := h.mfaService.GetStatus(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// EnrollMFA godoc
+// @Summary Start MFA enrollment
+// @Description Generates a new TOTP secret and otpauth URI. MFA becomes active after confirming a code. Replacing an active MFA requires a current code
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param request body dto.EnrollMFARequest false "Current code (only when MFA is already enabled)"
+// @Success 200 {object} dto.MFAEnrollmentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/me/mfa/enroll [post]
+// @Security BearerAuth
+func (h *MeHandler) EnrollMFA(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		h.unauthorized(c)
+		return
+	}
+
+	var req dto.EnrollMFARequest
+	// El body es opcional
+	if c.Request.ContentLength > 0 {
+		if !h.bindJSON(c, &req) {
+			return
+		}
+	}
+
+	result, err := h.mfaService.Enroll(c.Request.Context(), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ConfirmMFA godoc
+// @Summary Confirm MFA enrollment
+// @Description Activates TOTP two-factor authentication with the first valid code
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param request body dto.MFACodeRequest true "TOTP code"
+// @Success 200 {object} dto.MFAStatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "Enrollment not started or already enabled"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/me/mfa/confirm [post]
+// @Security BearerAuth
+func (h *MeHandler) ConfirmMFA(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		h.unauthorized(c)
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.mfaService.Confirm(c.Request.Context(), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DisableMFA godoc
+// @Summary Disable MFA
+// @Description Disables TOTP two-factor authentication; requires a current code
+// @Tags me
+// @Accept json
+// @Param request body dto.MFACodeRequest true "TOTP code"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "MFA not enabled"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/me/mfa/disable [post]
+// @Security BearerAuth
+func (h *MeHandler) DisableMFA(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		h.unauthorized(c)
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.mfaService.Disable(c.Request.Context(), userID, req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *MeHandler) bindJSON(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_REQUEST",
+		})
+		return false
+	}
+	return true
+}
+
+func (h *MeHandler) unauthorized(c *gin.Context) {
+	c.JSON(http.StatusUnauthorized, httpdto.ErrorResponse{
+		Error: "authenticated user required",
+		Code:  "UNAUTHORIZED",
+	})
+}
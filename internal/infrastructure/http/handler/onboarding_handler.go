@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// OnboardingHandler maneja el alta completa de escuelas
+type OnboardingHandler struct {
+	onboardingService service.OnboardingService
+	logger            logger.Logger
+}
+
+// NewOnboardingHandler crea un nuevo OnboardingHandler
+func NewOnboardingHandler(onboardingService service.OnboardingService, logger logger.Logger) *OnboardingHandler {
+	return &OnboardingHandler{
+		onboardingService: onboardingService,
+		logger:            logger,
+	}
+}
+
+// ListTemplates godoc
+// @Summary List unit tree templates
+// @Description Returns the unit tree templates available for school onboarding
+// @Tags onboarding
+// @Produce json
+// @Success 200 {array} dto.UnitTreeTemplateResponse
+// @Router /v1/onboarding/templates [get]
+// @Security BearerAuth
+func (h *OnboardingHandler) ListTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, h.onboardingService.ListTemplates(c.Request.Context()))
+}
+
+// OnboardSchool godoc
+// @Summary Onboard a school
+// @Description Creates the school, instantiates the unit tree template and creates the first director account with its school membership, all in one transaction
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Param request body dto.OnboardSchoolRequest true "Onboarding data"
+// @Success 201 {object} dto.OnboardSchoolResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/onboarding/schools [post]
+// @Security BearerAuth
+func (h *OnboardingHandler) OnboardSchool(c *gin.Context) {
+	var req dto.OnboardSchoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	result, err := h.onboardingService.OnboardSchool(c.Request.Context(), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
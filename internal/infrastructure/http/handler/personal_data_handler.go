@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// PersonalDataHandler maneja las solicitudes de acceso y borrado de datos personales
+type PersonalDataHandler struct {
+	personalDataService service.PersonalDataService
+	logger              logger.Logger
+}
+
+// NewPersonalDataHandler crea un nuevo PersonalDataHandler
+func NewPersonalDataHandler(
+	personalDataService service.PersonalDataService,
+	logger logger.Logger,
+) *PersonalDataHandler {
+	return &PersonalDataHandler{
+		personalDataService: personalDataService,
+		logger:              logger,
+	}
+}
+
+// ExportPersonalData godoc
+// @Summary Export personal data
+// @Description Downloads a ZIP archive with all personal data of the user: user row, profile, memberships, guardian relations, login history and status history
+// @Tags users
+// @Produce application/zip
+// @Param userId path string true "User ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/{userId}/personal-data [get]
+// @Security BearerAuth
+func (h *PersonalDataHandler) ExportPersonalData(c *gin.Context) {
+	userID := c.Param("userId")
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="personal-data-`+userID+`.zip"`)
+
+	if err := h.personalDataService.ExportPersonalData(c.Request.Context(), userID, actorID(c), c.Writer); err != nil {
+		if c.Writer.Written() {
+			// Ya se enviaron datos: no se puede cambiar el status, solo registrar
+			h.logger.Error("personal data export interrupted", "user_id", userID, "error", err.Error())
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		_ = c.Error(err)
+	}
+}
+
+// EraseUser godoc
+// @Summary Erase personal data
+// @Description Anonymizes the personal data of the user in place, keeping references and aggregate stats. Requires the user's current email as confirmation unless dry_run is set
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param request body dto.EraseUserRequest true "Erasure reason and confirmation"
+// @Success 200 {object} dto.EraseUserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "User already erased"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/{userId}/erase [post]
+// @Security BearerAuth
+func (h *PersonalDataHandler) EraseUser(c *gin.Context) {
+	var req dto.EraseUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	result, err := h.personalDataService.EraseUser(c.Request.Context(), c.Param("userId"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// RolloverHandler maneja el cambio de año lectivo de una escuela
+type RolloverHandler struct {
+	rolloverService service.RolloverService
+	logger          logger.Logger
+}
+
+// NewRolloverHandler crea un nuevo RolloverHandler
+func NewRolloverHandler(rolloverService service.RolloverService, logger logger.Logger) *RolloverHandler {
+	return &RolloverHandler{
+		rolloverService: rolloverService,
+		logger:          logger,
+	}
+}
+
+// Rollover godoc
+// @Summary Roll a school over to a new academic year
+// @Description Copies the unit tree of from_year into to_year, promotes students to the next grade (the last grade graduates) and closes their previous memberships. Teacher and staff assignments are reported for review instead of being moved. With dry_run the report is returned without applying anything
+// @Tags academic-periods
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param request body dto.RolloverRequest true "Rollover options"
+// @Success 200 {object} dto.RolloverResponse "Dry run report"
+// @Success 201 {object} dto.RolloverResponse "Applied rollover"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/schools/{id}/rollover [post]
+// @Security BearerAuth
+func (h *RolloverHandler) Rollover(c *gin.Context) {
+	var req dto.RolloverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	result, err := h.rolloverService.Rollover(c.Request.Context(), c.Param("id"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if result.DryRun {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetRollover godoc
+// @Summary Get an applied rollover
+// @Description Returns the totals of an applied rollover and what happened to each student
+// @Tags academic-periods
+// @Produce json
+// @Param id path string true "Rollover ID"
+// @Success 200 {object} dto.RolloverResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/school-rollovers/{id} [get]
+// @Security BearerAuth
+func (h *RolloverHandler) GetRollover(c *gin.Context) {
+	result, err := h.rolloverService.GetRollover(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
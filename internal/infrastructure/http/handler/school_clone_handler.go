@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// SchoolCloneHandler maneja la copia de la estructura de una escuela
+type SchoolCloneHandler struct {
+	cloneService service.SchoolCloneService
+	logger       logger.Logger
+}
+
+// NewSchoolCloneHandler crea un nuevo SchoolCloneHandler
+func NewSchoolCloneHandler(cloneService service.SchoolCloneService, logger logger.Logger) *SchoolCloneHandler {
+	return &SchoolCloneHandler{
+		cloneService: cloneService,
+		logger:       logger,
+	}
+}
+
+// CloneSchool godoc
+// @Summary Clone a school's structure
+// @Description Copies the academic unit tree, the academic years used by those units (with their terms), memberships and settings of a school into an existing school or a new one (e.g. a new campus). Unit codes are remapped by replacing the source school code with the target's and applying the optional prefix/suffix. With dry_run=true returns the preview, including code conflicts, without writing anything
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param id path string true "Source school ID"
+// @Param request body dto.CloneSchoolRequest true "Clone options"
+// @Success 200 {object} dto.CloneSchoolResponse "Preview (dry run)"
+// @Success 201 {object} dto.CloneSchoolResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /v1/schools/{id}/clone [post]
+// @Security BearerAuth
+func (h *SchoolCloneHandler) CloneSchool(c *gin.Context) {
+	var req dto.CloneSchoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	result, err := h.cloneService.CloneSchool(c.Request.Context(), c.Param("id"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	status := http.StatusCreated
+	if result.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, result)
+}
@@ -112,16 +112,45 @@ func (h *SchoolHandler) GetSchoolByCode(c *gin.Context) {
 }
 
 // ListSchools godoc
-// @Summary List all schools
-// @Description Retrieves a list of all schools in the system
+// @Summary List schools
+// @Description Lists the schools matching the filters as an array. With paginate=true the response is a page
+// @Description (dto.SchoolListResponse) with the total number of matches instead.
 // @Tags schools
 // @Produce json
+// @Param paginate query bool false "Respond with a page (items, total, limit, offset, has_more)"
+// @Param q query string false "Partial match on name or code"
+// @Param country query string false "Country"
+// @Param city query string false "City (case insensitive)"
+// @Param subscription_tier query string false "Subscription tier"
+// @Param is_active query bool false "Active flag"
+// @Param sort_by query string false "name, code, country, city, subscription_tier, created_at (default) or updated_at"
+// @Param order query string false "asc or desc (default)"
+// @Param limit query int false "Max results (max 200); page size 50 by default with paginate"
+// @Param offset query int false "Offset"
 // @Success 200 {array} dto.SchoolResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /v1/schools [get]
 // @Security BearerAuth
 func (h *SchoolHandler) ListSchools(c *gin.Context) {
-	schools, err := h.schoolService.ListSchools(c.Request.Context())
+	var req dto.ListSchoolsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid query params", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid query params", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	if req.Paginate {
+		page, err := h.schoolService.SearchSchools(c.Request.Context(), req)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, page)
+		return
+	}
+
+	schools, err := h.schoolService.ListSchools(c.Request.Context(), req)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -166,31 +195,3 @@ func (h *SchoolHandler) UpdateSchool(c *gin.Context) {
 
 	c.JSON(http.StatusOK, school)
 }
-
-// DeleteSchool godoc
-// @Summary Delete a school
-// @Description Soft deletes a school from the system
-// @Tags schools
-// @Produce json
-// @Param id path string true "School ID"
-// @Success 204 "No Content"
-// @Failure 400 {object} ErrorResponse
-// @Failure 404 {object} ErrorResponse
-// @Router /v1/schools/{id} [delete]
-// @Security BearerAuth
-func (h *SchoolHandler) DeleteSchool(c *gin.Context) {
-	id := c.Param("id")
-
-	if id == "" {
-		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "school ID is required", Code: "INVALID_REQUEST"})
-		return
-	}
-
-	err := h.schoolService.DeleteSchool(c.Request.Context(), id)
-	if err != nil {
-		_ = c.Error(err)
-		return
-	}
-
-	c.Status(http.StatusNoContent)
-}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -48,14 +47,22 @@ func (m *MockSchoolService) GetSchoolByCode(ctx context.Context, code string) (*
 	return args.Get(0).(*dto.SchoolResponse), args.Error(1)
 }
 
-func (m *MockSchoolService) ListSchools(ctx context.Context) ([]dto.SchoolResponse, error) {
-	args := m.Called(ctx)
+func (m *MockSchoolService) ListSchools(ctx context.Context, req dto.ListSchoolsRequest) ([]dto.SchoolResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]dto.SchoolResponse), args.Error(1)
 }
 
+func (m *MockSchoolService) SearchSchools(ctx context.Context, req dto.ListSchoolsRequest) (*dto.SchoolListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SchoolListResponse), args.Error(1)
+}
+
 func (m *MockSchoolService) UpdateSchool(ctx context.Context, id string, req dto.UpdateSchoolRequest) (*dto.SchoolResponse, error) {
 	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
@@ -64,11 +71,6 @@ func (m *MockSchoolService) UpdateSchool(ctx context.Context, id string, req dto
 	return args.Get(0).(*dto.SchoolResponse), args.Error(1)
 }
 
-func (m *MockSchoolService) DeleteSchool(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
 // MockLogger es un mock simple del logger
 type MockLogger struct{}
 
@@ -218,7 +220,7 @@ func TestSchoolHandler_ListSchools_Success(t *testing.T) {
 		{ID: "school-2", Name: "School 2"},
 	}
 
-	mockService.On("ListSchools", mock.Anything).Return(expectedResp, nil)
+	mockService.On("ListSchools", mock.Anything, mock.Anything).Return(expectedResp, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -236,6 +238,42 @@ func TestSchoolHandler_ListSchools_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestSchoolHandler_ListSchools_Paginated(t *testing.T) {
+	handler, mockService := setupSchoolHandler()
+
+	expectedResp := &dto.SchoolListResponse{
+		Items: []dto.SchoolResponse{
+			{ID: "school-1", Name: "School 1"},
+			{ID: "school-2", Name: "School 2"},
+		},
+		Total:   12,
+		Limit:   2,
+		HasMore: true,
+	}
+
+	mockService.On("SearchSchools", mock.Anything, mock.MatchedBy(func(req dto.ListSchoolsRequest) bool {
+		return req.Search == "school" && req.IsActive != nil && *req.IsActive && req.SortBy == "name" && req.Limit == 2
+	})).Return(expectedResp, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/v1/schools?paginate=true&q=school&is_active=true&sort_by=name&limit=2", nil)
+
+	handler.ListSchools(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.SchoolListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Items, 2)
+	assert.Equal(t, 12, resp.Total)
+	assert.True(t, resp.HasMore)
+
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "ListSchools", mock.Anything, mock.Anything)
+}
+
 func TestSchoolHandler_UpdateSchool_Success(t *testing.T) {
 	handler, mockService := setupSchoolHandler()
 
@@ -263,40 +301,6 @@ func TestSchoolHandler_UpdateSchool_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
-func TestSchoolHandler_DeleteSchool_Success(t *testing.T) {
-	handler, mockService := setupSchoolHandler()
-
-	mockService.On("DeleteSchool", mock.Anything, "school-123").Return(nil)
-
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = httptest.NewRequest("DELETE", "/v1/schools/school-123", nil)
-	c.Params = gin.Params{{Key: "id", Value: "school-123"}}
-
-	handler.DeleteSchool(c)
-
-	assert.True(t, w.Code == http.StatusNoContent || w.Code == http.StatusOK, "Expected 204 or 200, got %d", w.Code)
-	mockService.AssertExpectations(t)
-}
-
-func TestSchoolHandler_DeleteSchool_InternalError(t *testing.T) {
-	handler, mockService := setupSchoolHandler()
-
-	mockService.On("DeleteSchool", mock.Anything, "school-123").Return(errors.New("database error"))
-
-	gin.SetMode(gin.TestMode)
-	router := gin.New()
-	router.Use(middleware.ErrorHandler(&MockLogger{}))
-	router.DELETE("/v1/schools/:id", handler.DeleteSchool)
-
-	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("DELETE", "/v1/schools/school-123", nil)
-	router.ServeHTTP(w, req)
-
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	mockService.AssertExpectations(t)
-}
-
 // Helper function
 func ptrString(s string) *string {
 	return &s
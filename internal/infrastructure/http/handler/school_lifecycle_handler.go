@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// SchoolLifecycleHandler maneja el archivado, la restauración y la purga de escuelas
+type SchoolLifecycleHandler struct {
+	lifecycleService service.SchoolLifecycleService
+	logger           logger.Logger
+}
+
+// NewSchoolLifecycleHandler crea un nuevo SchoolLifecycleHandler
+func NewSchoolLifecycleHandler(lifecycleService service.SchoolLifecycleService, logger logger.Logger) *SchoolLifecycleHandler {
+	return &SchoolLifecycleHandler{
+		lifecycleService: lifecycleService,
+		logger:           logger,
+	}
+}
+
+// ArchiveSchool godoc
+// @Summary Archive a school
+// @Description Deactivates the school and, in cascade, its active academic units and memberships. Everything can be brought back with restore. Also served by DELETE /v1/schools/{id}
+// @Tags school-lifecycle
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param request body dto.ArchiveSchoolRequest false "Archive reason"
+// @Success 200 {object} dto.SchoolArchiveResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/archive [post]
+// @Security BearerAuth
+func (h *SchoolLifecycleHandler) ArchiveSchool(c *gin.Context) {
+	var req dto.ArchiveSchoolRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Warn("invalid request body", "error", err)
+			c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+			return
+		}
+	}
+
+	archive, err := h.lifecycleService.ArchiveSchool(c.Request.Context(), c.Param("id"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, archive)
+}
+
+// GetArchive godoc
+// @Summary Get a school's archive record
+// @Description Returns the current archive of an archived school, including when it becomes eligible for purge
+// @Tags school-lifecycle
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {object} dto.SchoolArchiveResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/archive [get]
+// @Security BearerAuth
+func (h *SchoolLifecycleHandler) GetArchive(c *gin.Context) {
+	archive, err := h.lifecycleService.GetArchive(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, archive)
+}
+
+// RestoreSchool godoc
+// @Summary Restore an archived school
+// @Description Reactivates the school and exactly the units and memberships its archive deactivated
+// @Tags school-lifecycle
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {object} dto.RestoreSchoolResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /v1/schools/{id}/restore [post]
+// @Security BearerAuth
+func (h *SchoolLifecycleHandler) RestoreSchool(c *gin.Context) {
+	result, err := h.lifecycleService.RestoreSchool(c.Request.Context(), c.Param("id"), actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// PurgeSchool godoc
+// @Summary Permanently purge an archived school
+// @Description Starts a background job that permanently deletes an archived school and its data once the retention period has passed. Returns the job; poll /v1/school-purge-jobs/{id} for progress
+// @Tags school-lifecycle
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param request body dto.PurgeSchoolRequest true "Confirmation with the school code"
+// @Success 202 {object} dto.SchoolPurgeJobResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/schools/{id}/purge [post]
+// @Security BearerAuth
+func (h *SchoolLifecycleHandler) PurgeSchool(c *gin.Context) {
+	var req dto.PurgeSchoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	job, err := h.lifecycleService.PurgeSchool(c.Request.Context(), c.Param("id"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetPurgeJob godoc
+// @Summary Get purge job progress
+// @Description Returns the status, completed steps and deleted rows per step of a school purge
+// @Tags school-lifecycle
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} dto.SchoolPurgeJobResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/school-purge-jobs/{id} [get]
+// @Security BearerAuth
+func (h *SchoolLifecycleHandler) GetPurgeJob(c *gin.Context) {
+	job, err := h.lifecycleService.GetPurgeJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
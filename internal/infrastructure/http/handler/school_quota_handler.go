@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// SchoolQuotaHandler expone el uso de los cupos del plan de una escuela
+type SchoolQuotaHandler struct {
+	quotaService service.SchoolQuotaService
+	logger       logger.Logger
+}
+
+// NewSchoolQuotaHandler crea un nuevo SchoolQuotaHandler
+func NewSchoolQuotaHandler(quotaService service.SchoolQuotaService, logger logger.Logger) *SchoolQuotaHandler {
+	return &SchoolQuotaHandler{
+		quotaService: quotaService,
+		logger:       logger,
+	}
+}
+
+// GetUsage godoc
+// @Summary Get school quota usage
+// @Description Returns active teachers and students against the school's MaxTeachers/MaxStudents quotas, with the highest warning threshold reached
+// @Tags schools
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {object} dto.SchoolUsageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/usage [get]
+// @Security BearerAuth
+func (h *SchoolQuotaHandler) GetUsage(c *gin.Context) {
+	usage, err := h.quotaService.GetUsage(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// SchoolSettingsHandler maneja la configuración tipada de las escuelas
+type SchoolSettingsHandler struct {
+	settingsService service.SchoolSettingsService
+	logger          logger.Logger
+}
+
+// NewSchoolSettingsHandler crea un nuevo SchoolSettingsHandler
+func NewSchoolSettingsHandler(settingsService service.SchoolSettingsService, logger logger.Logger) *SchoolSettingsHandler {
+	return &SchoolSettingsHandler{
+		settingsService: settingsService,
+		logger:          logger,
+	}
+}
+
+// GetSchema godoc
+// @Summary Get the school settings JSON Schema
+// @Description Returns the JSON Schema that the effective settings of every school must satisfy
+// @Tags school-settings
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /v1/school-settings/schema [get]
+// @Security BearerAuth
+func (h *SchoolSettingsHandler) GetSchema(c *gin.Context) {
+	c.Data(http.StatusOK, "application/schema+json", h.settingsService.GetSchema())
+}
+
+// GetSettings godoc
+// @Summary Get a school's settings
+// @Description Returns the effective settings (global defaults plus the school's own values), the school's overrides and the current version
+// @Tags school-settings
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {object} dto.SchoolSettingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/settings [get]
+// @Security BearerAuth
+func (h *SchoolSettingsHandler) GetSettings(c *gin.Context) {
+	settings, err := h.settingsService.GetSettings(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// PatchSettings godoc
+// @Summary Update a school's settings
+// @Description Applies a JSON merge patch to the school's own values (null restores the inherited value). The result is validated against the settings JSON Schema. Send the current version to detect concurrent changes
+// @Tags school-settings
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param request body dto.PatchSchoolSettingsRequest true "Settings patch"
+// @Success 200 {object} dto.SchoolSettingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /v1/schools/{id}/settings [patch]
+// @Security BearerAuth
+func (h *SchoolSettingsHandler) PatchSettings(c *gin.Context) {
+	var req dto.PatchSchoolSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	settings, err := h.settingsService.PatchSettings(c.Request.Context(), c.Param("id"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// ListHistory godoc
+// @Summary List a school's settings history
+// @Description Returns every settings change of the school with the applied patch and the resulting overrides, most recent first
+// @Tags school-settings
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {array} dto.SchoolSettingsChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/settings/history [get]
+// @Security BearerAuth
+func (h *SchoolSettingsHandler) ListHistory(c *gin.Context) {
+	history, err := h.settingsService.ListHistory(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetInternalSettings godoc
+// @Summary Get a school's settings (internal)
+// @Description Read-only settings endpoint for other EduGo services. Requires X-Service-API-Key or an internal network address instead of a user token
+// @Tags internal
+// @Produce json
+// @Param id path string true "School ID"
+// @Param X-Service-API-Key header string false "Service API key"
+// @Success 200 {object} dto.SchoolSettingsResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /internal/v1/schools/{id}/settings [get]
+func (h *SchoolSettingsHandler) GetInternalSettings(c *gin.Context) {
+	h.GetSettings(c)
+}
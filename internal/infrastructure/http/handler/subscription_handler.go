@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// SubscriptionHandler maneja el catálogo de planes y el plan de cada escuela
+type SubscriptionHandler struct {
+	subscriptionService service.SubscriptionService
+	logger              logger.Logger
+}
+
+// NewSubscriptionHandler crea un nuevo SubscriptionHandler
+func NewSubscriptionHandler(subscriptionService service.SubscriptionService, logger logger.Logger) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+		logger:              logger,
+	}
+}
+
+// ListTiers godoc
+// @Summary List subscription tiers
+// @Description Returns the subscription tier catalog ordered from lowest to highest, with limits (0 = unlimited) and enabled features
+// @Tags subscriptions
+// @Produce json
+// @Success 200 {array} dto.SubscriptionTierResponse
+// @Router /v1/subscription-tiers [get]
+// @Security BearerAuth
+func (h *SubscriptionHandler) ListTiers(c *gin.Context) {
+	c.JSON(http.StatusOK, h.subscriptionService.ListTiers(c.Request.Context()))
+}
+
+// GetSchoolSubscription godoc
+// @Summary Get a school's subscription
+// @Description Returns the school's current tier, its plan and the current usage of limited resources
+// @Tags subscriptions
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {object} dto.SchoolSubscriptionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/subscription [get]
+// @Security BearerAuth
+func (h *SubscriptionHandler) GetSchoolSubscription(c *gin.Context) {
+	subscription, err := h.subscriptionService.GetSchoolSubscription(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// ChangeTier godoc
+// @Summary Change a school's subscription tier
+// @Description Upgrades or downgrades the school. Fails if current teachers, students or units exceed the new tier's limits. The change is recorded in the tier history
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param request body dto.ChangeSubscriptionTierRequest true "Target tier"
+// @Success 200 {object} dto.SchoolSubscriptionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/schools/{id}/subscription [put]
+// @Security BearerAuth
+func (h *SubscriptionHandler) ChangeTier(c *gin.Context) {
+	var req dto.ChangeSubscriptionTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	subscription, err := h.subscriptionService.ChangeTier(c.Request.Context(), c.Param("id"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// ListTierHistory godoc
+// @Summary List a school's tier history
+// @Description Returns the school's upgrades and downgrades, most recent first
+// @Tags subscriptions
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {array} dto.SubscriptionChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/subscription/history [get]
+// @Security BearerAuth
+func (h *SubscriptionHandler) ListTierHistory(c *gin.Context) {
+	history, err := h.subscriptionService.ListTierHistory(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// CheckFeature godoc
+// @Summary Check a feature for a school
+// @Description Tells whether the school's tier enables the feature (sso, api_tokens, exports)
+// @Tags subscriptions
+// @Produce json
+// @Param id path string true "School ID"
+// @Param feature path string true "Feature" Enums(sso, api_tokens, exports)
+// @Success 200 {object} dto.FeatureCheckResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/features/{feature} [get]
+// @Security BearerAuth
+func (h *SubscriptionHandler) CheckFeature(c *gin.Context) {
+	result, err := h.subscriptionService.CheckFeature(c.Request.Context(), c.Param("id"), c.Param("feature"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// UnitCapacityHandler maneja la lista de espera de las unidades con cupo
+type UnitCapacityHandler struct {
+	capacityService service.UnitCapacityService
+	logger          logger.Logger
+}
+
+// NewUnitCapacityHandler crea un nuevo UnitCapacityHandler
+func NewUnitCapacityHandler(capacityService service.UnitCapacityService, logger logger.Logger) *UnitCapacityHandler {
+	return &UnitCapacityHandler{
+		capacityService: capacityService,
+		logger:          logger,
+	}
+}
+
+// ListWaitlist godoc
+// @Summary List a unit's waitlist
+// @Description Returns the students waiting for a seat in the unit, in promotion order
+// @Tags units
+// @Produce json
+// @Param id path string true "Unit ID"
+// @Success 200 {array} dto.WaitlistEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/units/{id}/waitlist [get]
+// @Security BearerAuth
+func (h *UnitCapacityHandler) ListWaitlist(c *gin.Context) {
+	entries, err := h.capacityService.ListWaitlist(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// CancelWaitlistEntry godoc
+// @Summary Remove a student from a unit's waitlist
+// @Description Cancels a waiting entry; the student keeps no seat and the rest of the waitlist moves up
+// @Tags units
+// @Param id path string true "Unit ID"
+// @Param entryId path string true "Waitlist entry ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /v1/units/{id}/waitlist/{entryId} [delete]
+// @Security BearerAuth
+func (h *UnitCapacityHandler) CancelWaitlistEntry(c *gin.Context) {
+	if err := h.capacityService.CancelWaitlistEntry(c.Request.Context(), c.Param("id"), c.Param("entryId")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
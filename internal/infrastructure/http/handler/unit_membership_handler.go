@@ -27,15 +27,17 @@ func NewUnitMembershipHandler(membershipService service.UnitMembershipService, l
 
 // CreateMembership godoc
 // @Summary Create a new membership
-// @Description Assigns a user to an academic unit with a specific role
+// @Description Assigns a user to an academic unit with a specific role. Students take a seat of the unit capacity; when the unit is full and has a waitlist the student is added to it and 202 is returned
 // @Tags memberships
 // @Accept json
 // @Produce json
 // @Param request body dto.CreateMembershipRequest true "Membership data"
 // @Success 201 {object} dto.MembershipResponse
+// @Success 202 {object} dto.MembershipResponse "Student added to the waitlist"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /v1/memberships [post]
 // @Security BearerAuth
 func (h *UnitMembershipHandler) CreateMembership(c *gin.Context) {
@@ -52,6 +54,10 @@ func (h *UnitMembershipHandler) CreateMembership(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
+	if membership.Waitlist != nil {
+		c.JSON(http.StatusAccepted, membership)
+		return
+	}
 
 	c.JSON(http.StatusCreated, membership)
 }
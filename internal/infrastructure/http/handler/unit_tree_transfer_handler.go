@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// UnitTreeTransferHandler maneja la exportación e importación del árbol de unidades de una escuela
+type UnitTreeTransferHandler struct {
+	transferService service.UnitTreeTransferService
+	logger          logger.Logger
+}
+
+// NewUnitTreeTransferHandler crea un nuevo UnitTreeTransferHandler
+func NewUnitTreeTransferHandler(transferService service.UnitTreeTransferService, logger logger.Logger) *UnitTreeTransferHandler {
+	return &UnitTreeTransferHandler{
+		transferService: transferService,
+		logger:          logger,
+	}
+}
+
+// ExportUnitTree godoc
+// @Summary Export a school's unit tree
+// @Description Downloads the unit tree of an academic year as JSON or YAML, with codes, types, metadata and optionally the active memberships (users by email)
+// @Tags academic-units
+// @Produce json
+// @Produce application/yaml
+// @Param id path string true "School ID"
+// @Param format query string false "json (default) or yaml"
+// @Param academic_year query int false "Academic year (default: active year)"
+// @Param include_memberships query bool false "Include the active memberships of each unit"
+// @Success 200 {object} dto.UnitTreeDocument
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/schools/{id}/units/export [get]
+// @Security BearerAuth
+func (h *UnitTreeTransferHandler) ExportUnitTree(c *gin.Context) {
+	var req dto.ExportUnitTreeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid query params", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid query params",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	// El documento se arma completo antes de responder: un error no deja una descarga a medias
+	var buf bytes.Buffer
+	if err := h.transferService.ExportUnitTree(c.Request.Context(), c.Param("id"), req, &buf); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	format, _ := dto.ParseUnitTreeFormat(req.Format)
+	contentType := "application/json"
+	if format == dto.UnitTreeFormatYAML {
+		contentType = "application/yaml"
+	}
+	c.Header("Content-Disposition", `attachment; filename="units.`+format+`"`)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// ImportUnitTree godoc
+// @Summary Import a school's unit tree
+// @Description Applies a JSON or YAML unit tree as an idempotent upsert keyed by unit code. Returns the creates, updates and deletes; with dry_run nothing is changed. Deletes only happen with prune
+// @Tags academic-units
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param id path string true "School ID"
+// @Param format query string false "json or yaml (default: from Content-Type)"
+// @Param academic_year query int false "Target academic year (default: active year)"
+// @Param dry_run query bool false "Only preview the changes"
+// @Param prune query bool false "Delete the units of the year that are not in the document"
+// @Param document body dto.UnitTreeDocument true "Unit tree"
+// @Success 200 {object} dto.UnitTreeImportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/schools/{id}/units/import [post]
+// @Security BearerAuth
+func (h *UnitTreeTransferHandler) ImportUnitTree(c *gin.Context) {
+	var req dto.ImportUnitTreeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid query params", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid query params",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+	if req.Format == "" && strings.Contains(c.ContentType(), "yaml") {
+		req.Format = dto.UnitTreeFormatYAML
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	resp, err := h.transferService.ImportUnitTree(c.Request.Context(), c.Param("id"), req, body, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// UnitTypeHandler maneja los tipos de unidad de cada escuela
+type UnitTypeHandler struct {
+	unitTypeService service.UnitTypeService
+	logger          logger.Logger
+}
+
+// NewUnitTypeHandler crea un nuevo UnitTypeHandler
+func NewUnitTypeHandler(unitTypeService service.UnitTypeService, logger logger.Logger) *UnitTypeHandler {
+	return &UnitTypeHandler{
+		unitTypeService: unitTypeService,
+		logger:          logger,
+	}
+}
+
+// ListUnitTypes godoc
+// @Summary List a school's unit types
+// @Description Returns the built-in unit types with the school's nesting rules followed by the school's custom types
+// @Tags unit-types
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {array} dto.UnitTypeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/schools/{id}/unit-types [get]
+// @Security BearerAuth
+func (h *UnitTypeHandler) ListUnitTypes(c *gin.Context) {
+	unitTypes, err := h.unitTypeService.ListUnitTypes(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, unitTypes)
+}
+
+// CreateUnitType godoc
+// @Summary Register a custom unit type
+// @Description Registers a unit type for the school (e.g. ciclo, jornada, campus) with its label, icon, allowed membership roles and the types it can be nested under or contain
+// @Tags unit-types
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param request body dto.CreateUnitTypeRequest true "Unit type"
+// @Success 201 {object} dto.UnitTypeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /v1/schools/{id}/unit-types [post]
+// @Security BearerAuth
+func (h *UnitTypeHandler) CreateUnitType(c *gin.Context) {
+	var req dto.CreateUnitTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	unitType, err := h.unitTypeService.CreateUnitType(c.Request.Context(), c.Param("id"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, unitType)
+}
+
+// UpdateUnitType godoc
+// @Summary Update a custom unit type
+// @Description Updates the label, icon, allowed roles or nesting rules of a custom unit type. Built-in types cannot be modified
+// @Tags unit-types
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param code path string true "Unit type code"
+// @Param request body dto.UpdateUnitTypeRequest true "Fields to update"
+// @Success 200 {object} dto.UnitTypeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/schools/{id}/unit-types/{code} [patch]
+// @Security BearerAuth
+func (h *UnitTypeHandler) UpdateUnitType(c *gin.Context) {
+	var req dto.UpdateUnitTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	unitType, err := h.unitTypeService.UpdateUnitType(c.Request.Context(), c.Param("id"), c.Param("code"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, unitType)
+}
+
+// DeleteUnitType godoc
+// @Summary Delete a custom unit type
+// @Description Deletes a custom unit type that no unit uses (deleted units included)
+// @Tags unit-types
+// @Param id path string true "School ID"
+// @Param code path string true "Unit type code"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /v1/schools/{id}/unit-types/{code} [delete]
+// @Security BearerAuth
+func (h *UnitTypeHandler) DeleteUnitType(c *gin.Context) {
+	if err := h.unitTypeService.DeleteUnitType(c.Request.Context(), c.Param("id"), c.Param("code")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// UserDuplicateHandler maneja la cola de usuarios duplicados y la fusión de cuentas
+type UserDuplicateHandler struct {
+	duplicateService service.UserDuplicateService
+	logger           logger.Logger
+}
+
+// NewUserDuplicateHandler crea un nuevo UserDuplicateHandler
+func NewUserDuplicateHandler(
+	duplicateService service.UserDuplicateService,
+	logger logger.Logger,
+) *UserDuplicateHandler {
+	return &UserDuplicateHandler{
+		duplicateService: duplicateService,
+		logger:           logger,
+	}
+}
+
+// ScanDuplicates godoc
+// @Summary Scan for duplicate users
+// @Description Compares users by normalized email, profile phone and name similarity and queues likely duplicates for review
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.ScanDuplicatesRequest false "Scan scope"
+// @Success 200 {object} dto.ScanDuplicatesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/duplicates/scan [post]
+// @Security BearerAuth
+func (h *UserDuplicateHandler) ScanDuplicates(c *gin.Context) {
+	var req dto.ScanDuplicatesRequest
+	// El body es opcional
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Warn("invalid request body", "error", err)
+			c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+				Error: "invalid request body",
+				Code:  "INVALID_REQUEST",
+			})
+			return
+		}
+	}
+
+	result, err := h.duplicateService.ScanDuplicates(c.Request.Context(), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListDuplicates godoc
+// @Summary List duplicate candidates
+// @Description Lists the duplicate review queue ordered by score
+// @Tags users
+// @Produce json
+// @Param status query string false "pending, dismissed or merged"
+// @Param limit query int false "Page size (default 50)"
+// @Param offset query int false "Offset"
+// @Success 200 {array} dto.DuplicateCandidateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/duplicates [get]
+// @Security BearerAuth
+func (h *UserDuplicateHandler) ListDuplicates(c *gin.Context) {
+	var req dto.ListDuplicatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid query params", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid query params",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	candidates, err := h.duplicateService.ListCandidates(c.Request.Context(), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, candidates)
+}
+
+// DismissDuplicate godoc
+// @Summary Dismiss a duplicate candidate
+// @Description Marks a candidate as not a duplicate so later scans do not queue it again
+// @Tags users
+// @Produce json
+// @Param candidateId path string true "Candidate ID"
+// @Success 200 {object} dto.DuplicateCandidateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "Candidate already reviewed"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/duplicates/{candidateId}/dismiss [post]
+// @Security BearerAuth
+func (h *UserDuplicateHandler) DismissDuplicate(c *gin.Context) {
+	candidate, err := h.duplicateService.DismissCandidate(c.Request.Context(), c.Param("candidateId"), actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, candidate)
+}
+
+// MergeUsers godoc
+// @Summary Merge two user accounts
+// @Description Moves memberships, guardian relations and audit references from the source user to the target user and retires the source. Use dry_run to preview.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.MergeUsersRequest true "Merge request"
+// @Success 200 {object} dto.MergeUsersResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/merge [post]
+// @Security BearerAuth
+func (h *UserDuplicateHandler) MergeUsers(c *gin.Context) {
+	var req dto.MergeUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	result, err := h.duplicateService.MergeUsers(c.Request.Context(), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
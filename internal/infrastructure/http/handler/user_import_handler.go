@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/spreadsheet"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// maxImportFileSize limita el tamaño del archivo de importación (10 MB)
+const maxImportFileSize = 10 << 20
+
+// UserImportHandler maneja las peticiones HTTP de importación masiva de usuarios
+type UserImportHandler struct {
+	importService service.UserImportService
+	logger        logger.Logger
+}
+
+// NewUserImportHandler crea un nuevo UserImportHandler
+func NewUserImportHandler(
+	importService service.UserImportService,
+	logger logger.Logger,
+) *UserImportHandler {
+	return &UserImportHandler{
+		importService: importService,
+		logger:        logger,
+	}
+}
+
+// ImportUsers godoc
+// @Summary Bulk import users
+// @Description Imports users from a CSV or XLSX file. Columns: email, first_name, last_name, role, password and optional membership_role.
+// @Description Each row (user and membership) is saved in its own transaction; memberships follow the same rules as POST /memberships (closed academic year, unit type roles, unit capacity and school quota).
+// @Description With dry_run=true the rows are applied and rolled back, so later rows account for the seats taken by earlier ones, and only the per-row report is returned. With result=csv the report is returned as a downloadable CSV file.
+// @Tags users
+// @Accept multipart/form-data
+// @Produce json
+// @Produce text/csv
+// @Param file formData file true "CSV or XLSX file"
+// @Param school_id formData string false "School ID (required when unit_code is set)"
+// @Param unit_code formData string false "Code of the unit where memberships are created"
+// @Param membership_role formData string false "Default membership role (default: student)"
+// @Param dry_run formData bool false "Validate only, do not persist"
+// @Param skip_existing formData bool false "Skip rows whose email already exists, creating the unit membership if it is missing (resume a previous import)"
+// @Param result query string false "Response format: json (default) or csv"
+// @Success 200 {object} dto.ImportUsersReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "Unit not found"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/import [post]
+// @Security BearerAuth
+func (h *UserImportHandler) ImportUsers(c *gin.Context) {
+	var req dto.ImportUsersRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "file is required",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	if fileHeader.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "file too large",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	format, err := spreadsheet.DetectFormat(fileHeader.Filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "unsupported file format, use .csv or .xlsx",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded file", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "could not read file",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	report, err := h.importService.ImportUsers(c.Request.Context(), req, format, file)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if c.Query("result") == "csv" {
+		var buf bytes.Buffer
+		if err := report.WriteCSV(&buf); err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="user-import-result.csv"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/application/service"
+	httpdto "github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/http/dto"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// UserStatusHandler maneja la desactivación y reactivación de usuarios
+type UserStatusHandler struct {
+	lifecycleService service.UserLifecycleService
+	logger           logger.Logger
+}
+
+// NewUserStatusHandler crea un nuevo UserStatusHandler
+func NewUserStatusHandler(
+	lifecycleService service.UserLifecycleService,
+	logger logger.Logger,
+) *UserStatusHandler {
+	return &UserStatusHandler{
+		lifecycleService: lifecycleService,
+		logger:           logger,
+	}
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate a user
+// @Description Deactivates a user, expires their memberships, deactivates their guardian relations and revokes their tokens
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param request body dto.DeactivateUserRequest true "Deactivation reason"
+// @Success 200 {object} dto.UserStatusChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "User already inactive"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/{userId}/deactivate [post]
+// @Security BearerAuth
+func (h *UserStatusHandler) DeactivateUser(c *gin.Context) {
+	var req dto.DeactivateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	change, err := h.lifecycleService.DeactivateUser(c.Request.Context(), c.Param("userId"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, change)
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate a user
+// @Description Reactivates a user and optionally restores the memberships and guardian relations affected by the last deactivation
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param request body dto.ReactivateUserRequest false "Reactivation options"
+// @Success 200 {object} dto.UserStatusChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "User already active"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/{userId}/reactivate [post]
+// @Security BearerAuth
+func (h *UserStatusHandler) ReactivateUser(c *gin.Context) {
+	var req dto.ReactivateUserRequest
+	// El body es opcional
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Warn("invalid request body", "error", err)
+			c.JSON(http.StatusBadRequest, httpdto.ErrorResponse{
+				Error: "invalid request body",
+				Code:  "INVALID_REQUEST",
+			})
+			return
+		}
+	}
+
+	change, err := h.lifecycleService.ReactivateUser(c.Request.Context(), c.Param("userId"), req, actorID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, change)
+}
+
+// GetStatusHistory godoc
+// @Summary Get user status history
+// @Description Lists deactivations and reactivations of a user, most recent first
+// @Tags users
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {array} dto.UserStatusChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/users/{userId}/status-history [get]
+// @Security BearerAuth
+func (h *UserStatusHandler) GetStatusHistory(c *gin.Context) {
+	history, err := h.lifecycleService.GetStatusHistory(c.Request.Context(), c.Param("userId"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
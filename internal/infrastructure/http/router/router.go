@@ -15,8 +15,21 @@ import (
 type Config struct {
 	SchoolRepo     repository.SchoolRepository
 	UnitRepo       repository.AcademicUnitRepository
-	Logger         logger.Logger
-	SchoolDefaults config.SchoolDefaults
+	PeriodRepo     repository.AcademicPeriodRepository
+	MembershipRepo repository.UnitMembershipRepository
+	DeletionRepo   repository.UnitDeletionRepository
+	UnitTypeRepo   repository.SchoolUnitTypeRepository
+	TxManager      repository.TransactionManager
+	// SettingsService resuelve las reglas de anidamiento de unidades de cada escuela
+	SettingsService service.SchoolSettingsService
+	// CapacityService calcula la ocupación y aplica el cupo de estudiantes de las unidades
+	CapacityService service.UnitCapacityService
+	// QuotaService aplica el cupo del plan de la escuela a las membresías restauradas
+	QuotaService service.SchoolQuotaService
+	// LifecycleService archiva las escuelas; DELETE /schools/:id archiva en cascada igual que en main.go
+	LifecycleService service.SchoolLifecycleService
+	Logger           logger.Logger
+	SchoolDefaults   config.SchoolDefaults
 	// NOTA: CORSConfig removido - CORS se configura en main.go para evitar duplicación
 	// Si en el futuro se usa SetupRouter desde main.go, pasar CORSConfig como parámetro
 }
@@ -41,11 +54,12 @@ func SetupRouter(cfg *Config) *gin.Engine {
 	{
 		// Inicializar servicios
 		schoolService := service.NewSchoolService(cfg.SchoolRepo, cfg.Logger, cfg.SchoolDefaults)
-		academicUnitService := service.NewAcademicUnitService(cfg.UnitRepo, cfg.SchoolRepo, cfg.Logger)
+		academicUnitService := service.NewAcademicUnitService(cfg.UnitRepo, cfg.SchoolRepo, cfg.PeriodRepo, cfg.MembershipRepo, cfg.DeletionRepo, cfg.UnitTypeRepo, cfg.SettingsService, cfg.CapacityService, cfg.QuotaService, cfg.TxManager, cfg.Logger)
 
 		// Handlers
 		schoolHandler := handler.NewSchoolHandler(schoolService, cfg.Logger)
 		unitHandler := handler.NewAcademicUnitHandler(academicUnitService, cfg.Logger)
+		lifecycleHandler := handler.NewSchoolLifecycleHandler(cfg.LifecycleService, cfg.Logger)
 
 		// School routes
 		schools := v1.Group("/schools")
@@ -55,7 +69,7 @@ func SetupRouter(cfg *Config) *gin.Engine {
 			schools.GET("/:id", schoolHandler.GetSchool)
 			schools.GET("/code/:code", schoolHandler.GetSchoolByCode)
 			schools.PUT("/:id", schoolHandler.UpdateSchool)
-			schools.DELETE("/:id", schoolHandler.DeleteSchool)
+			schools.DELETE("/:id", lifecycleHandler.ArchiveSchool) // archiva en cascada
 
 			// School-scoped unit routes
 			schools.POST("/:schoolId/units", unitHandler.CreateUnit)
@@ -71,7 +85,9 @@ func SetupRouter(cfg *Config) *gin.Engine {
 			units.PUT("/:id", unitHandler.UpdateUnit)
 			units.DELETE("/:id", unitHandler.DeleteUnit)
 			units.POST("/:id/restore", unitHandler.RestoreUnit)
-			units.GET("/:id/hierarchy-path", unitHandler.GetHierarchyPath) // ltree endpoint!
+			units.POST("/:id/move", unitHandler.MoveUnit)
+			units.GET("/:id/hierarchy-path", unitHandler.GetHierarchyPath) // CTE recursiva
+			units.GET("/:id/descendants", unitHandler.ListDescendants)
 		}
 	}
 
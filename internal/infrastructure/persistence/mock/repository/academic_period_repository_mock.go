@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockAcademicPeriodRepository es una implementación en memoria del AcademicPeriodRepository
+type MockAcademicPeriodRepository struct {
+	mu      sync.RWMutex
+	periods map[uuid.UUID]*repository.AcademicPeriod
+}
+
+// NewMockAcademicPeriodRepository crea una nueva instancia vacía
+func NewMockAcademicPeriodRepository() repository.AcademicPeriodRepository {
+	return &MockAcademicPeriodRepository{
+		periods: make(map[uuid.UUID]*repository.AcademicPeriod),
+	}
+}
+
+// Create guarda un nuevo período
+func (r *MockAcademicPeriodRepository) Create(ctx context.Context, period *repository.AcademicPeriod) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	periodCopy := *period
+	r.periods[period.ID] = &periodCopy
+	return nil
+}
+
+// Update actualiza un período existente
+func (r *MockAcademicPeriodRepository) Update(ctx context.Context, period *repository.AcademicPeriod) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.periods[period.ID]; !ok {
+		return nil
+	}
+	periodCopy := *period
+	r.periods[period.ID] = &periodCopy
+	return nil
+}
+
+// FindByID obtiene un período por ID
+func (r *MockAcademicPeriodRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.AcademicPeriod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	period, ok := r.periods[id]
+	if !ok {
+		return nil, nil
+	}
+	periodCopy := *period
+	return &periodCopy, nil
+}
+
+// FindYear obtiene el año lectivo de la escuela por su número
+func (r *MockAcademicPeriodRepository) FindYear(ctx context.Context, schoolID uuid.UUID, year int) (*repository.AcademicPeriod, error) {
+	return r.findFirst(func(p *repository.AcademicPeriod) bool {
+		return p.SchoolID == schoolID && p.Type == repository.AcademicPeriodYear && p.Year == year
+	}), nil
+}
+
+// FindActiveYear obtiene el año lectivo activo de la escuela
+func (r *MockAcademicPeriodRepository) FindActiveYear(ctx context.Context, schoolID uuid.UUID) (*repository.AcademicPeriod, error) {
+	return r.findFirst(func(p *repository.AcademicPeriod) bool {
+		return p.SchoolID == schoolID && p.Type == repository.AcademicPeriodYear && p.Status == repository.AcademicPeriodActive
+	}), nil
+}
+
+// ListBySchool lista años y períodos de la escuela ordenados por fecha de inicio
+func (r *MockAcademicPeriodRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*repository.AcademicPeriod, error) {
+	return r.filter(func(p *repository.AcademicPeriod) bool {
+		return p.SchoolID == schoolID
+	}), nil
+}
+
+// ListTerms lista los períodos de un año lectivo
+func (r *MockAcademicPeriodRepository) ListTerms(ctx context.Context, yearID uuid.UUID) ([]*repository.AcademicPeriod, error) {
+	return r.filter(func(p *repository.AcademicPeriod) bool {
+		return p.ParentID != nil && *p.ParentID == yearID
+	}), nil
+}
+
+func (r *MockAcademicPeriodRepository) findFirst(match func(*repository.AcademicPeriod) bool) *repository.AcademicPeriod {
+	periods := r.filter(match)
+	if len(periods) == 0 {
+		return nil
+	}
+	return periods[0]
+}
+
+func (r *MockAcademicPeriodRepository) filter(match func(*repository.AcademicPeriod) bool) []*repository.AcademicPeriod {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.AcademicPeriod
+	for _, period := range r.periods {
+		if match(period) {
+			periodCopy := *period
+			result = append(result, &periodCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StartDate.Equal(result[j].StartDate) {
+			return result[i].Type > result[j].Type // el año antes que su primer período
+		}
+		return result[i].StartDate.Before(result[j].StartDate)
+	})
+	return result
+}
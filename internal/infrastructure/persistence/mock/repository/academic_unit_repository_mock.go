@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -145,6 +146,19 @@ func (r *MockAcademicUnitRepository) FindBySchoolID(ctx context.Context, schoolI
 }
 
 // FindByParentID lista unidades hijas de una unidad padre
+func (r *MockAcademicUnitRepository) CountBySchoolID(ctx context.Context, schoolID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, unit := range r.academicUnits {
+		if unit.SchoolID == schoolID && unit.DeletedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (r *MockAcademicUnitRepository) FindByParentID(ctx context.Context, parentID uuid.UUID, includeDeleted bool) ([]*entities.AcademicUnit, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -372,6 +386,115 @@ func (r *MockAcademicUnitRepository) GetHierarchyPath(ctx context.Context, id uu
 	return path, nil
 }
 
+// FindAncestors obtiene los ancestros no eliminados de la unidad, de la raíz al padre
+func (r *MockAcademicUnitRepository) FindAncestors(ctx context.Context, id uuid.UUID) ([]*entities.AcademicUnit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	unit, exists := r.academicUnits[id]
+	if !exists || unit.DeletedAt != nil {
+		return nil, nil
+	}
+
+	var ancestors []*entities.AcademicUnit
+	visited := map[uuid.UUID]bool{unit.ID: true}
+	for unit.ParentUnitID != nil {
+		parent, exists := r.academicUnits[*unit.ParentUnitID]
+		if !exists || parent.DeletedAt != nil || visited[parent.ID] {
+			break
+		}
+		visited[parent.ID] = true
+		parentCopy := *parent
+		ancestors = append([]*entities.AcademicUnit{&parentCopy}, ancestors...)
+		unit = parent
+	}
+	return ancestors, nil
+}
+
+// FindDescendants obtiene el subárbol de la unidad ordenado por profundidad y nombre
+func (r *MockAcademicUnitRepository) FindDescendants(ctx context.Context, id uuid.UUID, maxDepth int, includeDeleted bool) ([]repository.UnitDescendant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.descendants(id, maxDepth, includeDeleted), nil
+}
+
+// CountSubtree cuenta los descendientes de la unidad por tipo; includeDeleted incluye los eliminados
+func (r *MockAcademicUnitRepository) CountSubtree(ctx context.Context, id uuid.UUID, includeDeleted bool) (*repository.UnitSubtreeCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := &repository.UnitSubtreeCount{ByType: map[string]int{}}
+	for _, descendant := range r.descendants(id, 0, includeDeleted) {
+		count.Descendants++
+		count.ByType[descendant.Unit.Type]++
+		if descendant.Depth > count.MaxDepth {
+			count.MaxDepth = descendant.Depth
+		}
+	}
+	return count, nil
+}
+
+// descendants recorre el subárbol por niveles, igual que la CTE recursiva de Postgres
+// Nota: Esta función debe ser llamada con el mutex ya adquirido
+func (r *MockAcademicUnitRepository) descendants(id uuid.UUID, maxDepth int, includeDeleted bool) []repository.UnitDescendant {
+	children := make(map[uuid.UUID][]*entities.AcademicUnit)
+	for _, unit := range r.academicUnits {
+		if unit.ParentUnitID != nil && (includeDeleted || unit.DeletedAt == nil) {
+			children[*unit.ParentUnitID] = append(children[*unit.ParentUnitID], unit)
+		}
+	}
+
+	var result []repository.UnitDescendant
+	visited := map[uuid.UUID]bool{id: true}
+	level := []uuid.UUID{id}
+	for depth := 1; len(level) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		var next []uuid.UUID
+		var units []*entities.AcademicUnit
+		for _, parentID := range level {
+			for _, child := range children[parentID] {
+				if visited[child.ID] {
+					continue
+				}
+				visited[child.ID] = true
+				unitCopy := *child
+				units = append(units, &unitCopy)
+				next = append(next, child.ID)
+			}
+		}
+		sort.Slice(units, func(i, j int) bool { return units[i].Name < units[j].Name })
+		for _, unit := range units {
+			result = append(result, repository.UnitDescendant{Unit: unit, Depth: depth})
+		}
+		level = next
+	}
+	return result
+}
+
+// IsAncestor indica si ancestorID es la propia unidad o alguno de sus ancestros
+func (r *MockAcademicUnitRepository) IsAncestor(ctx context.Context, ancestorID, unitID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	visited := make(map[uuid.UUID]bool)
+	for current, exists := r.academicUnits[unitID]; exists && !visited[current.ID]; {
+		if current.ID == ancestorID {
+			return true, nil
+		}
+		visited[current.ID] = true
+		if current.ParentUnitID == nil {
+			break
+		}
+		current, exists = r.academicUnits[*current.ParentUnitID]
+	}
+	return false, nil
+}
+
+// LockHierarchy no hace nada: el mock ya serializa con su mutex
+func (r *MockAcademicUnitRepository) LockHierarchy(ctx context.Context, schoolID uuid.UUID) error {
+	return nil
+}
+
 // ExistsBySchoolIDAndCode verifica si existe una unidad con ese código en la escuela
 func (r *MockAcademicUnitRepository) ExistsBySchoolIDAndCode(ctx context.Context, schoolID uuid.UUID, code string) (bool, error) {
 	r.mu.RLock()
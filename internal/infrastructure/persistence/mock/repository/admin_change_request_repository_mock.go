@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// MockAdminChangeRequestRepository es una implementación en memoria del AdminChangeRequestRepository
+type MockAdminChangeRequestRepository struct {
+	mu       sync.RWMutex
+	requests map[uuid.UUID]*repository.AdminChangeRequest
+}
+
+// NewMockAdminChangeRequestRepository crea una nueva instancia vacía
+func NewMockAdminChangeRequestRepository() repository.AdminChangeRequestRepository {
+	return &MockAdminChangeRequestRepository{
+		requests: make(map[uuid.UUID]*repository.AdminChangeRequest),
+	}
+}
+
+// Create registra una solicitud
+func (r *MockAdminChangeRequestRepository) Create(ctx context.Context, request *repository.AdminChangeRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if request.ID == uuid.Nil {
+		request.ID = uuid.New()
+	}
+	requestCopy := *request
+	requestCopy.Email = strings.ToLower(request.Email)
+	r.requests[request.ID] = &requestCopy
+	return nil
+}
+
+// FindByID busca una solicitud por ID
+func (r *MockAdminChangeRequestRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.AdminChangeRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	request, exists := r.requests[id]
+	if !exists {
+		return nil, nil
+	}
+	requestCopy := *request
+	return &requestCopy, nil
+}
+
+// FindPendingByEmail busca una solicitud pendiente y vigente para el email
+func (r *MockAdminChangeRequestRepository) FindPendingByEmail(ctx context.Context, email string) (*repository.AdminChangeRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	var latest *repository.AdminChangeRequest
+	for _, request := range r.requests {
+		if !strings.EqualFold(request.Email, email) || request.Status != repository.AdminChangeStatusPending || request.IsExpired(now) {
+			continue
+		}
+		if latest == nil || request.CreatedAt.After(latest.CreatedAt) {
+			latest = request
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	requestCopy := *latest
+	return &requestCopy, nil
+}
+
+// List lista las solicitudes, de la más reciente a la más antigua
+func (r *MockAdminChangeRequestRepository) List(ctx context.Context, filters repository.AdminChangeRequestFilters) ([]*repository.AdminChangeRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.AdminChangeRequest
+	for _, request := range r.requests {
+		if filters.Status != "" && request.Status != filters.Status {
+			continue
+		}
+		requestCopy := *request
+		result = append(result, &requestCopy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	if filters.Offset > 0 {
+		if filters.Offset >= len(result) {
+			return []*repository.AdminChangeRequest{}, nil
+		}
+		result = result[filters.Offset:]
+	}
+	if filters.Limit > 0 && filters.Limit < len(result) {
+		result = result[:filters.Limit]
+	}
+	return result, nil
+}
+
+// Update actualiza una solicitud existente
+func (r *MockAdminChangeRequestRepository) Update(ctx context.Context, request *repository.AdminChangeRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.requests[request.ID]; !exists {
+		return errors.NewNotFoundError("admin change request")
+	}
+	requestCopy := *request
+	r.requests[request.ID] = &requestCopy
+	return nil
+}
+
+// AnonymizeEmail reemplaza el email de las solicitudes, borra sus nombres y rechaza las pendientes
+func (r *MockAdminChangeRequestRepository) AnonymizeEmail(ctx context.Context, email, replacement string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	now := time.Now()
+	for _, request := range r.requests {
+		if !strings.EqualFold(request.Email, email) {
+			continue
+		}
+		request.Email = replacement
+		request.FirstName = ""
+		request.LastName = ""
+		request.PasswordHash = ""
+		if request.Status == repository.AdminChangeStatusPending {
+			request.Status = repository.AdminChangeStatusRejected
+			request.DecidedAt = &now
+		}
+		count++
+	}
+	return count, nil
+}
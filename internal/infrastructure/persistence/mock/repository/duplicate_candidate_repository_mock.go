@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// MockDuplicateCandidateRepository es una implementación en memoria del DuplicateCandidateRepository
+type MockDuplicateCandidateRepository struct {
+	mu         sync.RWMutex
+	candidates map[uuid.UUID]*repository.DuplicateCandidate
+}
+
+// NewMockDuplicateCandidateRepository crea una nueva instancia vacía
+func NewMockDuplicateCandidateRepository() repository.DuplicateCandidateRepository {
+	return &MockDuplicateCandidateRepository{
+		candidates: make(map[uuid.UUID]*repository.DuplicateCandidate),
+	}
+}
+
+// Create registra un nuevo candidato
+func (r *MockDuplicateCandidateRepository) Create(ctx context.Context, candidate *repository.DuplicateCandidate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.candidates {
+		if samePair(existing, candidate.UserIDA, candidate.UserIDB) {
+			return errors.NewConflictError("duplicate candidate already exists for this pair")
+		}
+	}
+
+	if candidate.ID == uuid.Nil {
+		candidate.ID = uuid.New()
+	}
+	r.candidates[candidate.ID] = copyCandidate(candidate)
+	return nil
+}
+
+// FindByID busca un candidato por ID
+func (r *MockDuplicateCandidateRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.DuplicateCandidate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	candidate, exists := r.candidates[id]
+	if !exists {
+		return nil, nil
+	}
+	return copyCandidate(candidate), nil
+}
+
+// FindByPair busca el candidato de un par de usuarios en cualquier orden
+func (r *MockDuplicateCandidateRepository) FindByPair(ctx context.Context, userA, userB uuid.UUID) (*repository.DuplicateCandidate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, candidate := range r.candidates {
+		if samePair(candidate, userA, userB) {
+			return copyCandidate(candidate), nil
+		}
+	}
+	return nil, nil
+}
+
+// List lista candidatos ordenados por score descendente
+func (r *MockDuplicateCandidateRepository) List(ctx context.Context, filters repository.DuplicateCandidateFilters) ([]*repository.DuplicateCandidate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.DuplicateCandidate
+	for _, candidate := range r.candidates {
+		if filters.Status != nil && candidate.Status != *filters.Status {
+			continue
+		}
+		result = append(result, copyCandidate(candidate))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].DetectedAt.After(result[j].DetectedAt)
+	})
+
+	if filters.Offset > 0 {
+		if filters.Offset >= len(result) {
+			return []*repository.DuplicateCandidate{}, nil
+		}
+		result = result[filters.Offset:]
+	}
+	if filters.Limit > 0 && filters.Limit < len(result) {
+		result = result[:filters.Limit]
+	}
+	return result, nil
+}
+
+// Update actualiza un candidato existente
+func (r *MockDuplicateCandidateRepository) Update(ctx context.Context, candidate *repository.DuplicateCandidate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.candidates[candidate.ID]; !exists {
+		return errors.NewNotFoundError("duplicate candidate not found")
+	}
+	r.candidates[candidate.ID] = copyCandidate(candidate)
+	return nil
+}
+
+func samePair(candidate *repository.DuplicateCandidate, userA, userB uuid.UUID) bool {
+	return (candidate.UserIDA == userA && candidate.UserIDB == userB) ||
+		(candidate.UserIDA == userB && candidate.UserIDB == userA)
+}
+
+func copyCandidate(candidate *repository.DuplicateCandidate) *repository.DuplicateCandidate {
+	candidateCopy := *candidate
+	candidateCopy.Reasons = append([]string(nil), candidate.Reasons...)
+	return &candidateCopy
+}
@@ -214,3 +214,46 @@ func (r *MockGuardianRepository) copyRelation(relation *entities.GuardianRelatio
 	relationCopy := *relation
 	return &relationCopy
 }
+
+// ReassignRelation cambia el guardian y/o estudiante de una relación
+func (r *MockGuardianRepository) ReassignRelation(ctx context.Context, id, guardianID, studentID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	relation, exists := r.relations[id]
+	if !exists {
+		return errors.NewNotFoundError("guardian relation not found")
+	}
+	if guardianID == studentID {
+		return errors.NewValidationError("guardian cannot be the same as student")
+	}
+
+	relation.GuardianID = guardianID
+	relation.StudentID = studentID
+	relation.UpdatedAt = time.Now()
+	return nil
+}
+
+// FindByStudents lista las relaciones de varios estudiantes
+func (r *MockGuardianRepository) FindByStudents(ctx context.Context, studentIDs []uuid.UUID, activeOnly bool) ([]*entities.GuardianRelation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	students := make(map[uuid.UUID]bool, len(studentIDs))
+	for _, id := range studentIDs {
+		students[id] = true
+	}
+
+	var result []*entities.GuardianRelation
+	for _, relation := range r.relations {
+		if !students[relation.StudentID] {
+			continue
+		}
+		if activeOnly && !relation.IsActive {
+			continue
+		}
+		result = append(result, r.copyRelation(relation))
+	}
+
+	return result, nil
+}
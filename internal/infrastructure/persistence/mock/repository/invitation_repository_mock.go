@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// MockInvitationRepository es una implementación en memoria del InvitationRepository
+type MockInvitationRepository struct {
+	mu          sync.RWMutex
+	invitations map[uuid.UUID]*repository.Invitation
+}
+
+// NewMockInvitationRepository crea una nueva instancia vacía
+func NewMockInvitationRepository() repository.InvitationRepository {
+	return &MockInvitationRepository{
+		invitations: make(map[uuid.UUID]*repository.Invitation),
+	}
+}
+
+// Create registra una nueva invitación
+func (r *MockInvitationRepository) Create(ctx context.Context, invitation *repository.Invitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if invitation.ID == uuid.Nil {
+		invitation.ID = uuid.New()
+	}
+	invitationCopy := *invitation
+	invitationCopy.Email = strings.ToLower(invitation.Email)
+	r.invitations[invitation.ID] = &invitationCopy
+	return nil
+}
+
+// FindByID busca una invitación por ID
+func (r *MockInvitationRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invitation, exists := r.invitations[id]
+	if !exists {
+		return nil, nil
+	}
+	invitationCopy := *invitation
+	return &invitationCopy, nil
+}
+
+// FindByIDForUpdate busca una invitación por ID (el mock no tiene transacciones que bloquear)
+func (r *MockInvitationRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*repository.Invitation, error) {
+	return r.FindByID(ctx, id)
+}
+
+// FindPendingByEmail busca la invitación pendiente y vigente de un email en una escuela
+func (r *MockInvitationRepository) FindPendingByEmail(ctx context.Context, schoolID uuid.UUID, email string) (*repository.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	var found *repository.Invitation
+	for _, invitation := range r.invitations {
+		if invitation.SchoolID != schoolID || invitation.Email != strings.ToLower(email) {
+			continue
+		}
+		if invitation.Status != repository.InvitationStatusPending || invitation.IsExpired(now) {
+			continue
+		}
+		if found == nil || invitation.CreatedAt.After(found.CreatedAt) {
+			found = invitation
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	invitationCopy := *found
+	return &invitationCopy, nil
+}
+
+// ListBySchool lista las invitaciones de una escuela, de la más reciente a la más antigua
+func (r *MockInvitationRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID, filters repository.InvitationFilters) ([]*repository.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	var result []*repository.Invitation
+	for _, invitation := range r.invitations {
+		if invitation.SchoolID != schoolID {
+			continue
+		}
+		if filters.Status != nil {
+			switch *filters.Status {
+			case repository.InvitationStatusPending:
+				if invitation.Status != repository.InvitationStatusPending || invitation.IsExpired(now) {
+					continue
+				}
+			case repository.InvitationStatusExpired:
+				if !invitation.IsExpired(now) {
+					continue
+				}
+			default:
+				if invitation.Status != *filters.Status {
+					continue
+				}
+			}
+		}
+		invitationCopy := *invitation
+		result = append(result, &invitationCopy)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	if filters.Offset > 0 {
+		if filters.Offset >= len(result) {
+			return []*repository.Invitation{}, nil
+		}
+		result = result[filters.Offset:]
+	}
+	if filters.Limit > 0 && filters.Limit < len(result) {
+		result = result[:filters.Limit]
+	}
+	return result, nil
+}
+
+// Update actualiza una invitación existente
+func (r *MockInvitationRepository) Update(ctx context.Context, invitation *repository.Invitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.invitations[invitation.ID]; !exists {
+		return errors.NewNotFoundError("invitation")
+	}
+	invitationCopy := *invitation
+	r.invitations[invitation.ID] = &invitationCopy
+	return nil
+}
+
+// AnonymizeEmail reemplaza el email en todas sus invitaciones y revoca las pendientes
+func (r *MockInvitationRepository) AnonymizeEmail(ctx context.Context, email, replacement string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var updated int64
+	now := time.Now()
+	for _, invitation := range r.invitations {
+		if !strings.EqualFold(invitation.Email, email) {
+			continue
+		}
+		invitation.Email = replacement
+		if invitation.Status == repository.InvitationStatusPending {
+			invitation.Status = repository.InvitationStatusRevoked
+			invitation.RevokedAt = &now
+		}
+		invitation.UpdatedAt = now
+		updated++
+	}
+	return updated, nil
+}
+
+// ReassignPendingEmail dirige las invitaciones pendientes de un email a otro
+func (r *MockInvitationRepository) ReassignPendingEmail(ctx context.Context, fromEmail, toEmail string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	taken := make(map[uuid.UUID]bool)
+	for _, invitation := range r.invitations {
+		if strings.EqualFold(invitation.Email, toEmail) && invitation.Status == repository.InvitationStatusPending && !invitation.IsExpired(now) {
+			taken[invitation.SchoolID] = true
+		}
+	}
+
+	var updated int64
+	for _, invitation := range r.invitations {
+		if !strings.EqualFold(invitation.Email, fromEmail) || invitation.Status != repository.InvitationStatusPending {
+			continue
+		}
+		if taken[invitation.SchoolID] {
+			invitation.Status = repository.InvitationStatusRevoked
+			invitation.RevokedAt = &now
+		} else {
+			invitation.Email = strings.ToLower(toEmail)
+		}
+		invitation.UpdatedAt = now
+		updated++
+	}
+	return updated, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockLoginEventRepository es una implementación en memoria del LoginEventRepository
+type MockLoginEventRepository struct {
+	mu     sync.RWMutex
+	events []*repository.LoginEvent
+}
+
+// NewMockLoginEventRepository crea una nueva instancia vacía
+func NewMockLoginEventRepository() repository.LoginEventRepository {
+	return &MockLoginEventRepository{}
+}
+
+// Create registra un intento de login
+func (r *MockLoginEventRepository) Create(ctx context.Context, event *repository.LoginEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	eventCopy := *event
+	r.events = append(r.events, &eventCopy)
+	return nil
+}
+
+// ListByUser lista el historial de login del usuario
+func (r *MockLoginEventRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*repository.LoginEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.LoginEvent
+	for _, event := range r.events {
+		if event.UserID == userID {
+			eventCopy := *event
+			result = append(result, &eventCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].OccurredAt.After(result[j].OccurredAt)
+	})
+	return result, nil
+}
+
+// DeleteByUser elimina el historial de login del usuario
+func (r *MockLoginEventRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.events[:0]
+	var deleted int64
+	for _, event := range r.events {
+		if event.UserID == userID {
+			deleted++
+			continue
+		}
+		kept = append(kept, event)
+	}
+	r.events = kept
+	return deleted, nil
+}
+
+// ReassignUser mueve el historial de login de un usuario a otro
+func (r *MockLoginEventRepository) ReassignUser(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var moved int64
+	for _, event := range r.events {
+		if event.UserID == fromUserID {
+			event.UserID = toUserID
+			moved++
+		}
+	}
+	return moved, nil
+}
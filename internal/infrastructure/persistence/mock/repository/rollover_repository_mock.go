@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockRolloverRepository es una implementación en memoria del RolloverRepository
+type MockRolloverRepository struct {
+	mu        sync.RWMutex
+	rollovers map[uuid.UUID]*repository.SchoolRollover
+	entries   map[uuid.UUID][]repository.SchoolRolloverEntry
+}
+
+// NewMockRolloverRepository crea una nueva instancia vacía
+func NewMockRolloverRepository() repository.RolloverRepository {
+	return &MockRolloverRepository{
+		rollovers: make(map[uuid.UUID]*repository.SchoolRollover),
+		entries:   make(map[uuid.UUID][]repository.SchoolRolloverEntry),
+	}
+}
+
+// Create guarda el cambio de año con su detalle
+func (r *MockRolloverRepository) Create(ctx context.Context, rollover *repository.SchoolRollover, entries []repository.SchoolRolloverEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rolloverCopy := *rollover
+	r.rollovers[rollover.ID] = &rolloverCopy
+	r.entries[rollover.ID] = append([]repository.SchoolRolloverEntry(nil), entries...)
+	return nil
+}
+
+// FindByID obtiene un cambio de año por ID
+func (r *MockRolloverRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.SchoolRollover, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rollover, ok := r.rollovers[id]
+	if !ok {
+		return nil, nil
+	}
+	rolloverCopy := *rollover
+	return &rolloverCopy, nil
+}
+
+// FindBySchoolAndYears obtiene el cambio de año aplicado entre dos años
+func (r *MockRolloverRepository) FindBySchoolAndYears(ctx context.Context, schoolID uuid.UUID, fromYear, toYear int) (*repository.SchoolRollover, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, rollover := range r.rollovers {
+		if rollover.SchoolID == schoolID && rollover.FromYear == fromYear && rollover.ToYear == toYear {
+			rolloverCopy := *rollover
+			return &rolloverCopy, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListEntries lista el detalle por estudiante
+func (r *MockRolloverRepository) ListEntries(ctx context.Context, rolloverID uuid.UUID) ([]repository.SchoolRolloverEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]repository.SchoolRolloverEntry(nil), r.entries[rolloverID]...), nil
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockSchoolLifecycleRepository es una implementación en memoria del SchoolLifecycleRepository.
+// Cada mock tiene su propio almacenamiento, así que la purga solo limpia los items de
+// archivado y el resto de pasos reporta 0 filas.
+type MockSchoolLifecycleRepository struct {
+	mu       sync.RWMutex
+	archives map[uuid.UUID]*repository.SchoolArchive
+	items    map[uuid.UUID][]repository.SchoolArchiveItem
+	jobs     map[uuid.UUID]*repository.SchoolPurgeJob
+}
+
+// NewMockSchoolLifecycleRepository crea una nueva instancia vacía
+func NewMockSchoolLifecycleRepository() repository.SchoolLifecycleRepository {
+	return &MockSchoolLifecycleRepository{
+		archives: make(map[uuid.UUID]*repository.SchoolArchive),
+		items:    make(map[uuid.UUID][]repository.SchoolArchiveItem),
+		jobs:     make(map[uuid.UUID]*repository.SchoolPurgeJob),
+	}
+}
+
+// CreateArchive registra un archivado y sus items
+func (r *MockSchoolLifecycleRepository) CreateArchive(ctx context.Context, archive *repository.SchoolArchive, items []repository.SchoolArchiveItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	archiveCopy := *archive
+	r.archives[archive.ID] = &archiveCopy
+	r.items[archive.ID] = append([]repository.SchoolArchiveItem(nil), items...)
+	return nil
+}
+
+// FindOpenArchive obtiene el archivado vigente de la escuela
+func (r *MockSchoolLifecycleRepository) FindOpenArchive(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolArchive, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *repository.SchoolArchive
+	for _, archive := range r.archives {
+		if archive.SchoolID != schoolID || !archive.IsOpen() {
+			continue
+		}
+		if latest == nil || archive.ArchivedAt.After(latest.ArchivedAt) {
+			latest = archive
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	archiveCopy := *latest
+	return &archiveCopy, nil
+}
+
+// ListArchiveItems lista las entidades desactivadas por un archivado
+func (r *MockSchoolLifecycleRepository) ListArchiveItems(ctx context.Context, archiveID uuid.UUID) ([]repository.SchoolArchiveItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]repository.SchoolArchiveItem(nil), r.items[archiveID]...), nil
+}
+
+// UpdateArchive actualiza la restauración o la purga de un archivado
+func (r *MockSchoolLifecycleRepository) UpdateArchive(ctx context.Context, archive *repository.SchoolArchive) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.archives[archive.ID]; !ok {
+		return nil
+	}
+	archiveCopy := *archive
+	r.archives[archive.ID] = &archiveCopy
+	return nil
+}
+
+// CreatePurgeJob registra un job de purga
+func (r *MockSchoolLifecycleRepository) CreatePurgeJob(ctx context.Context, job *repository.SchoolPurgeJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.ID] = copyPurgeJob(job)
+	return nil
+}
+
+// UpdatePurgeJob guarda el progreso de un job de purga
+func (r *MockSchoolLifecycleRepository) UpdatePurgeJob(ctx context.Context, job *repository.SchoolPurgeJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.jobs[job.ID]; !ok {
+		return nil
+	}
+	r.jobs[job.ID] = copyPurgeJob(job)
+	return nil
+}
+
+// FindPurgeJob obtiene un job de purga por ID
+func (r *MockSchoolLifecycleRepository) FindPurgeJob(ctx context.Context, id uuid.UUID) (*repository.SchoolPurgeJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyPurgeJob(job), nil
+}
+
+// FindUnfinishedPurgeJob obtiene el job pendiente o en curso de la escuela
+func (r *MockSchoolLifecycleRepository) FindUnfinishedPurgeJob(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolPurgeJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, job := range r.jobs {
+		if job.SchoolID == schoolID && !job.IsFinished() {
+			return copyPurgeJob(job), nil
+		}
+	}
+	return nil, nil
+}
+
+// PurgeSchoolData elimina los items de archivado de la escuela; el resto de pasos no aplica en memoria
+func (r *MockSchoolLifecycleRepository) PurgeSchoolData(ctx context.Context, schoolID uuid.UUID, step string) (int64, error) {
+	known := false
+	for _, s := range repository.PurgeSteps {
+		if s == step {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return 0, fmt.Errorf("unknown purge step: %s", step)
+	}
+	if step != repository.PurgeStepArchiveItems {
+		return 0, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for id, archive := range r.archives {
+		if archive.SchoolID == schoolID {
+			deleted += int64(len(r.items[id]))
+			delete(r.items, id)
+		}
+	}
+	return deleted, nil
+}
+
+func copyPurgeJob(job *repository.SchoolPurgeJob) *repository.SchoolPurgeJob {
+	jobCopy := *job
+	jobCopy.DeletedRows = make(map[string]int64, len(job.DeletedRows))
+	for step, rows := range job.DeletedRows {
+		jobCopy.DeletedRows[step] = rows
+	}
+	return &jobCopy
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -173,25 +175,44 @@ func (r *MockSchoolRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// List lista todas las escuelas activas con filtros opcionales
-func (r *MockSchoolRepository) List(ctx context.Context, filters repository.ListFilters) ([]*entities.School, error) {
+// FindArchivedByID busca una escuela eliminada con soft delete
+func (r *MockSchoolRepository) FindArchivedByID(ctx context.Context, id uuid.UUID) (*entities.School, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var result []*entities.School
+	school, exists := r.schools[id]
+	if !exists || school.DeletedAt == nil {
+		return nil, nil
+	}
 
-	// Filtrar escuelas
-	for _, school := range r.schools {
-		// Excluir escuelas eliminadas
-		if school.DeletedAt != nil {
-			continue
-		}
+	schoolCopy := *school
+	return &schoolCopy, nil
+}
 
-		// Agregar copia de la escuela
-		schoolCopy := *school
-		result = append(result, &schoolCopy)
+// Restore revierte el soft delete de una escuela
+func (r *MockSchoolRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	school, exists := r.schools[id]
+	if !exists || school.DeletedAt == nil {
+		return errors.NewNotFoundError("school not found")
 	}
 
+	school.DeletedAt = nil
+	school.IsActive = true
+	school.UpdatedAt = time.Now()
+	return nil
+}
+
+// List lista escuelas con filtros, orden y paginación
+func (r *MockSchoolRepository) List(ctx context.Context, filters repository.SchoolFilters) ([]*entities.School, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := r.filter(filters)
+	sortSchools(result, filters.SortBy, filters.SortDesc)
+
 	// Aplicar offset
 	if filters.Offset > 0 {
 		if filters.Offset >= len(result) {
@@ -208,6 +229,89 @@ func (r *MockSchoolRepository) List(ctx context.Context, filters repository.List
 	return result, nil
 }
 
+// Count cuenta las escuelas que cumplen los filtros
+func (r *MockSchoolRepository) Count(ctx context.Context, filters repository.SchoolFilters) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.filter(filters)), nil
+}
+
+// filter retorna copias de las escuelas no eliminadas que cumplen los filtros
+func (r *MockSchoolRepository) filter(filters repository.SchoolFilters) []*entities.School {
+	search := strings.ToLower(filters.Search)
+
+	var result []*entities.School
+	for _, school := range r.schools {
+		// Excluir escuelas eliminadas
+		if school.DeletedAt != nil {
+			continue
+		}
+		if search != "" &&
+			!strings.Contains(strings.ToLower(school.Name), search) &&
+			!strings.Contains(strings.ToLower(school.Code), search) {
+			continue
+		}
+		if filters.Country != "" && school.Country != filters.Country {
+			continue
+		}
+		if filters.City != "" && (school.City == nil || !strings.EqualFold(*school.City, filters.City)) {
+			continue
+		}
+		if filters.SubscriptionTier != "" && school.SubscriptionTier != filters.SubscriptionTier {
+			continue
+		}
+		if filters.IsActive != nil && school.IsActive != *filters.IsActive {
+			continue
+		}
+
+		// Agregar copia de la escuela
+		schoolCopy := *school
+		result = append(result, &schoolCopy)
+	}
+	return result
+}
+
+// sortSchools ordena igual que el repositorio PostgreSQL (id como desempate)
+func sortSchools(schools []*entities.School, sortBy string, desc bool) {
+	compare := func(a, b *entities.School) int {
+		switch sortBy {
+		case repository.SchoolSortName:
+			return strings.Compare(a.Name, b.Name)
+		case repository.SchoolSortCode:
+			return strings.Compare(a.Code, b.Code)
+		case repository.SchoolSortCountry:
+			return strings.Compare(a.Country, b.Country)
+		case repository.SchoolSortCity:
+			var ca, cb string
+			if a.City != nil {
+				ca = *a.City
+			}
+			if b.City != nil {
+				cb = *b.City
+			}
+			return strings.Compare(ca, cb)
+		case repository.SchoolSortTier:
+			return strings.Compare(a.SubscriptionTier, b.SubscriptionTier)
+		case repository.SchoolSortUpdatedAt:
+			return a.UpdatedAt.Compare(b.UpdatedAt)
+		default:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+	}
+
+	sort.SliceStable(schools, func(i, j int) bool {
+		c := compare(schools[i], schools[j])
+		if c == 0 {
+			return schools[i].ID.String() < schools[j].ID.String()
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
 // ExistsByName verifica si existe una escuela con el nombre dado
 func (r *MockSchoolRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
 	r.mu.RLock()
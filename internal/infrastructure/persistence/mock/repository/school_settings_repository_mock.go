@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockSchoolSettingsRepository es una implementación en memoria del SchoolSettingsRepository
+type MockSchoolSettingsRepository struct {
+	mu       sync.RWMutex
+	settings map[uuid.UUID]*repository.SchoolSettings
+	changes  []*repository.SchoolSettingsChange
+}
+
+// NewMockSchoolSettingsRepository crea una nueva instancia vacía
+func NewMockSchoolSettingsRepository() repository.SchoolSettingsRepository {
+	return &MockSchoolSettingsRepository{
+		settings: make(map[uuid.UUID]*repository.SchoolSettings),
+	}
+}
+
+// FindBySchoolID obtiene la configuración de la escuela
+func (r *MockSchoolSettingsRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, ok := r.settings[schoolID]
+	if !ok {
+		return nil, nil
+	}
+	settingsCopy := *settings
+	return &settingsCopy, nil
+}
+
+// Save guarda la configuración si la versión almacenada coincide con expectedVersion
+func (r *MockSchoolSettingsRepository) Save(ctx context.Context, settings *repository.SchoolSettings, expectedVersion int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.settings[settings.SchoolID]
+	if (!ok && expectedVersion != 0) || (ok && current.Version != expectedVersion) {
+		return false, nil
+	}
+
+	settingsCopy := *settings
+	if ok {
+		settingsCopy.CreatedAt = current.CreatedAt
+	}
+	r.settings[settings.SchoolID] = &settingsCopy
+	return true, nil
+}
+
+// CreateChange registra un cambio en el historial
+func (r *MockSchoolSettingsRepository) CreateChange(ctx context.Context, change *repository.SchoolSettingsChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changeCopy := *change
+	r.changes = append(r.changes, &changeCopy)
+	return nil
+}
+
+// ListChanges lista el historial de la escuela
+func (r *MockSchoolSettingsRepository) ListChanges(ctx context.Context, schoolID uuid.UUID) ([]*repository.SchoolSettingsChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.SchoolSettingsChange
+	for _, change := range r.changes {
+		if change.SchoolID == schoolID {
+			changeCopy := *change
+			result = append(result, &changeCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version > result[j].Version
+	})
+	return result, nil
+}
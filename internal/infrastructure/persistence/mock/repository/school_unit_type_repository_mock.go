@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockSchoolUnitTypeRepository es una implementación en memoria del SchoolUnitTypeRepository
+type MockSchoolUnitTypeRepository struct {
+	mu        sync.RWMutex
+	unitTypes map[uuid.UUID]*repository.SchoolUnitType
+}
+
+// NewMockSchoolUnitTypeRepository crea una nueva instancia vacía
+func NewMockSchoolUnitTypeRepository() repository.SchoolUnitTypeRepository {
+	return &MockSchoolUnitTypeRepository{
+		unitTypes: make(map[uuid.UUID]*repository.SchoolUnitType),
+	}
+}
+
+// Create registra un tipo propio
+func (r *MockSchoolUnitTypeRepository) Create(ctx context.Context, unitType *repository.SchoolUnitType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.unitTypes[unitType.ID] = copySchoolUnitType(unitType)
+	return nil
+}
+
+// Update actualiza un tipo propio
+func (r *MockSchoolUnitTypeRepository) Update(ctx context.Context, unitType *repository.SchoolUnitType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.unitTypes[unitType.ID]; ok {
+		r.unitTypes[unitType.ID] = copySchoolUnitType(unitType)
+	}
+	return nil
+}
+
+// Delete elimina un tipo propio
+func (r *MockSchoolUnitTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.unitTypes, id)
+	return nil
+}
+
+// FindByCode busca un tipo de la escuela por código
+func (r *MockSchoolUnitTypeRepository) FindByCode(ctx context.Context, schoolID uuid.UUID, code string) (*repository.SchoolUnitType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, unitType := range r.unitTypes {
+		if unitType.SchoolID == schoolID && unitType.Code == code {
+			return copySchoolUnitType(unitType), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListBySchool lista los tipos propios de la escuela ordenados por código
+func (r *MockSchoolUnitTypeRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*repository.SchoolUnitType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.SchoolUnitType
+	for _, unitType := range r.unitTypes {
+		if unitType.SchoolID == schoolID {
+			result = append(result, copySchoolUnitType(unitType))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result, nil
+}
+
+func copySchoolUnitType(unitType *repository.SchoolUnitType) *repository.SchoolUnitType {
+	unitTypeCopy := *unitType
+	unitTypeCopy.AllowedRoles = append([]string(nil), unitType.AllowedRoles...)
+	unitTypeCopy.ParentTypes = append([]string(nil), unitType.ParentTypes...)
+	unitTypeCopy.ChildTypes = append([]string(nil), unitType.ChildTypes...)
+	return &unitTypeCopy
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockSubscriptionChangeRepository es una implementación en memoria del SubscriptionChangeRepository
+type MockSubscriptionChangeRepository struct {
+	mu      sync.RWMutex
+	changes []*repository.SubscriptionChange
+}
+
+// NewMockSubscriptionChangeRepository crea una nueva instancia vacía
+func NewMockSubscriptionChangeRepository() repository.SubscriptionChangeRepository {
+	return &MockSubscriptionChangeRepository{}
+}
+
+// Create registra un cambio de plan
+func (r *MockSubscriptionChangeRepository) Create(ctx context.Context, change *repository.SubscriptionChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changeCopy := *change
+	r.changes = append(r.changes, &changeCopy)
+	return nil
+}
+
+// ListBySchool lista el historial de planes de la escuela
+func (r *MockSubscriptionChangeRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*repository.SubscriptionChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.SubscriptionChange
+	for _, change := range r.changes {
+		if change.SchoolID == schoolID {
+			changeCopy := *change
+			result = append(result, &changeCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
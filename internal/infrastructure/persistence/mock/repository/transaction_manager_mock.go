@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+)
+
+// mockTxKey marca el contexto cuando ya hay una transacción mock en curso
+type mockTxKey struct{}
+
+// MockTransactionManager ejecuta fn directamente: los repositorios mock no soportan rollback,
+// pero se serializan las transacciones para que no se intercalen
+type MockTransactionManager struct {
+	mu sync.Mutex
+}
+
+// NewMockTransactionManager crea una nueva instancia de MockTransactionManager
+func NewMockTransactionManager() repository.TransactionManager {
+	return &MockTransactionManager{}
+}
+
+// WithinTransaction ejecuta fn sin transacción real
+func (m *MockTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Transacción anidada: reutilizar la existente
+	if ctx.Value(mockTxKey{}) != nil {
+		return fn(ctx)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fn(context.WithValue(ctx, mockTxKey{}, true))
+}
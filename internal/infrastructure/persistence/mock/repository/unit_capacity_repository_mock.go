@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockUnitCapacityRepository es una implementación en memoria del UnitCapacityRepository
+type MockUnitCapacityRepository struct {
+	mu         sync.RWMutex
+	capacities map[uuid.UUID]*repository.UnitCapacity
+	entries    map[uuid.UUID]*repository.UnitWaitlistEntry
+}
+
+// NewMockUnitCapacityRepository crea una nueva instancia vacía
+func NewMockUnitCapacityRepository() repository.UnitCapacityRepository {
+	return &MockUnitCapacityRepository{
+		capacities: make(map[uuid.UUID]*repository.UnitCapacity),
+		entries:    make(map[uuid.UUID]*repository.UnitWaitlistEntry),
+	}
+}
+
+// FindCapacity retorna el cupo de la unidad
+func (r *MockUnitCapacityRepository) FindCapacity(ctx context.Context, unitID uuid.UUID) (*repository.UnitCapacity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	capacity, ok := r.capacities[unitID]
+	if !ok {
+		return nil, nil
+	}
+	capacityCopy := *capacity
+	return &capacityCopy, nil
+}
+
+// FindCapacities retorna los cupos de las unidades indicadas
+func (r *MockUnitCapacityRepository) FindCapacities(ctx context.Context, unitIDs []uuid.UUID) (map[uuid.UUID]*repository.UnitCapacity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[uuid.UUID]*repository.UnitCapacity)
+	for _, unitID := range unitIDs {
+		if capacity, ok := r.capacities[unitID]; ok {
+			capacityCopy := *capacity
+			result[unitID] = &capacityCopy
+		}
+	}
+	return result, nil
+}
+
+// SaveCapacity crea o reemplaza el cupo de la unidad
+func (r *MockUnitCapacityRepository) SaveCapacity(ctx context.Context, capacity *repository.UnitCapacity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	capacityCopy := *capacity
+	r.capacities[capacity.UnitID] = &capacityCopy
+	return nil
+}
+
+// DeleteCapacity quita el cupo de la unidad
+func (r *MockUnitCapacityRepository) DeleteCapacity(ctx context.Context, unitID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.capacities, unitID)
+	return nil
+}
+
+// LockUnit no hace nada: el mock ya serializa con su mutex
+func (r *MockUnitCapacityRepository) LockUnit(ctx context.Context, unitID uuid.UUID) error {
+	return nil
+}
+
+// CreateWaitlistEntry agrega una entrada a la lista de espera
+func (r *MockUnitCapacityRepository) CreateWaitlistEntry(ctx context.Context, entry *repository.UnitWaitlistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[entry.ID] = copyUnitWaitlistEntry(entry)
+	return nil
+}
+
+// UpdateWaitlistEntry actualiza una entrada de la lista de espera
+func (r *MockUnitCapacityRepository) UpdateWaitlistEntry(ctx context.Context, entry *repository.UnitWaitlistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[entry.ID]; ok {
+		r.entries[entry.ID] = copyUnitWaitlistEntry(entry)
+	}
+	return nil
+}
+
+// FindWaitlistEntry busca una entrada por ID
+func (r *MockUnitCapacityRepository) FindWaitlistEntry(ctx context.Context, id uuid.UUID) (*repository.UnitWaitlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.entries[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyUnitWaitlistEntry(entry), nil
+}
+
+// ListWaiting lista las entradas en espera de la unidad por orden de llegada
+func (r *MockUnitCapacityRepository) ListWaiting(ctx context.Context, unitID uuid.UUID) ([]*repository.UnitWaitlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.UnitWaitlistEntry
+	for _, entry := range r.entries {
+		if entry.UnitID == unitID && entry.Status == repository.UnitWaitlistWaiting {
+			result = append(result, copyUnitWaitlistEntry(entry))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RequestedAt.Before(result[j].RequestedAt)
+	})
+	return result, nil
+}
+
+func copyUnitWaitlistEntry(entry *repository.UnitWaitlistEntry) *repository.UnitWaitlistEntry {
+	entryCopy := *entry
+	if entry.MembershipID != nil {
+		id := *entry.MembershipID
+		entryCopy.MembershipID = &id
+	}
+	if entry.ResolvedAt != nil {
+		resolvedAt := *entry.ResolvedAt
+		entryCopy.ResolvedAt = &resolvedAt
+	}
+	return &entryCopy
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockUnitDeletionRepository es una implementación en memoria del UnitDeletionRepository
+type MockUnitDeletionRepository struct {
+	mu      sync.RWMutex
+	batches map[uuid.UUID]*repository.UnitDeletionBatch
+	items   map[uuid.UUID][]repository.UnitDeletionItem
+}
+
+// NewMockUnitDeletionRepository crea una nueva instancia vacía
+func NewMockUnitDeletionRepository() repository.UnitDeletionRepository {
+	return &MockUnitDeletionRepository{
+		batches: make(map[uuid.UUID]*repository.UnitDeletionBatch),
+		items:   make(map[uuid.UUID][]repository.UnitDeletionItem),
+	}
+}
+
+// CreateBatch registra un batch con sus items
+func (r *MockUnitDeletionRepository) CreateBatch(ctx context.Context, batch *repository.UnitDeletionBatch, items []repository.UnitDeletionItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batchCopy := *batch
+	r.batches[batch.ID] = &batchCopy
+	r.items[batch.ID] = append([]repository.UnitDeletionItem(nil), items...)
+	return nil
+}
+
+// FindBatch obtiene un batch por ID
+func (r *MockUnitDeletionRepository) FindBatch(ctx context.Context, id uuid.UUID) (*repository.UnitDeletionBatch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	batch, ok := r.batches[id]
+	if !ok {
+		return nil, nil
+	}
+	batchCopy := *batch
+	return &batchCopy, nil
+}
+
+// FindOpenBatchByUnit obtiene el batch abierto más reciente que eliminó la unidad
+func (r *MockUnitDeletionRepository) FindOpenBatchByUnit(ctx context.Context, unitID uuid.UUID) (*repository.UnitDeletionBatch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *repository.UnitDeletionBatch
+	for id, batch := range r.batches {
+		if !batch.IsOpen() || (found != nil && !batch.DeletedAt.After(found.DeletedAt)) {
+			continue
+		}
+		for _, item := range r.items[id] {
+			if item.EntityType == repository.UnitDeletionItemUnit && item.EntityID == unitID {
+				found = batch
+				break
+			}
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	batchCopy := *found
+	return &batchCopy, nil
+}
+
+// ListItems lista las entidades afectadas por un batch
+func (r *MockUnitDeletionRepository) ListItems(ctx context.Context, batchID uuid.UUID) ([]repository.UnitDeletionItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]repository.UnitDeletionItem(nil), r.items[batchID]...), nil
+}
+
+// UpdateBatch guarda la restauración de un batch
+func (r *MockUnitDeletionRepository) UpdateBatch(ctx context.Context, batch *repository.UnitDeletionBatch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batchCopy := *batch
+	r.batches[batch.ID] = &batchCopy
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -353,3 +354,115 @@ func (r *MockUnitMembershipRepository) FindByUserAndSchool(ctx context.Context,
 
 	return nil, nil
 }
+
+// ReassignUser mueve una membership a otro usuario
+func (r *MockUnitMembershipRepository) ReassignUser(ctx context.Context, membershipID, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	membership, exists := r.memberships[membershipID]
+	if !exists {
+		return errors.NewNotFoundError("membership not found")
+	}
+
+	membership.UserID = userID
+	membership.UpdatedAt = time.Now()
+	return nil
+}
+
+// LockSchoolQuota no hace nada: el mock no tiene transacciones concurrentes
+func (r *MockUnitMembershipRepository) LockSchoolQuota(ctx context.Context, schoolID uuid.UUID) error {
+	return nil
+}
+
+// CountActiveUsersBySchoolAndRole cuenta usuarios distintos con membresía activa del rol en la escuela
+func (r *MockUnitMembershipRepository) CountActiveUsersBySchoolAndRole(ctx context.Context, schoolID uuid.UUID, role string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	users := make(map[uuid.UUID]struct{})
+	for _, membership := range r.memberships {
+		if membership.SchoolID != schoolID || membership.Role != role || !membership.IsActive {
+			continue
+		}
+		if membership.WithdrawnAt != nil && !membership.WithdrawnAt.After(now) {
+			continue
+		}
+		users[membership.UserID] = struct{}{}
+	}
+
+	return len(users), nil
+}
+
+// FindActiveBySchool lista las membresías activas de la escuela
+func (r *MockUnitMembershipRepository) FindActiveBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entities.Membership, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entities.Membership
+	for _, membership := range r.memberships {
+		if membership.SchoolID == schoolID && membership.IsActive {
+			result = append(result, r.copyMembership(membership))
+		}
+	}
+	return result, nil
+}
+
+// FindActiveByUnits lista las membresías activas de las unidades indicadas
+func (r *MockUnitMembershipRepository) FindActiveByUnits(ctx context.Context, unitIDs []uuid.UUID) ([]*entities.Membership, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	units := make(map[uuid.UUID]bool, len(unitIDs))
+	for _, id := range unitIDs {
+		units[id] = true
+	}
+	var result []*entities.Membership
+	for _, membership := range r.memberships {
+		if membership.AcademicUnitID != nil && units[*membership.AcademicUnitID] && membership.IsActive {
+			result = append(result, r.copyMembership(membership))
+		}
+	}
+	return result, nil
+}
+
+// ListByUnit lista una página de membresías de la unidad según los filtros
+func (r *MockUnitMembershipRepository) ListByUnit(ctx context.Context, unitID uuid.UUID, filters repository.MembershipListFilters) ([]*entities.Membership, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entities.Membership
+	for _, membership := range r.memberships {
+		if membership.AcademicUnitID == nil || *membership.AcademicUnitID != unitID {
+			continue
+		}
+		if filters.Role != "" && membership.Role != filters.Role {
+			continue
+		}
+		if filters.ActiveOnly && (!membership.IsActive || membership.WithdrawnAt != nil) {
+			continue
+		}
+		result = append(result, r.copyMembership(membership))
+	}
+
+	// Ordenar como PostgreSQL (enrolled_at DESC, id) para que la paginación sea estable
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].EnrolledAt.Equal(result[j].EnrolledAt) {
+			return result[i].EnrolledAt.After(result[j].EnrolledAt)
+		}
+		return result[i].ID.String() < result[j].ID.String()
+	})
+
+	if filters.Offset > 0 {
+		if filters.Offset >= len(result) {
+			return []*entities.Membership{}, nil
+		}
+		result = result[filters.Offset:]
+	}
+	if filters.Limit > 0 && filters.Limit < len(result) {
+		result = result[:filters.Limit]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockUserMFARepository es una implementación en memoria del UserMFARepository
+type MockUserMFARepository struct {
+	mu      sync.RWMutex
+	secrets map[uuid.UUID]*repository.UserMFA
+}
+
+// NewMockUserMFARepository crea una nueva instancia vacía
+func NewMockUserMFARepository() repository.UserMFARepository {
+	return &MockUserMFARepository{
+		secrets: make(map[uuid.UUID]*repository.UserMFA),
+	}
+}
+
+// FindByUserID obtiene la configuración MFA del usuario
+func (r *MockUserMFARepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*repository.UserMFA, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mfa, exists := r.secrets[userID]
+	if !exists {
+		return nil, nil
+	}
+	mfaCopy := *mfa
+	return &mfaCopy, nil
+}
+
+// Upsert crea o reemplaza la configuración MFA del usuario
+func (r *MockUserMFARepository) Upsert(ctx context.Context, mfa *repository.UserMFA) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfaCopy := *mfa
+	r.secrets[mfa.UserID] = &mfaCopy
+	return nil
+}
+
+// ConsumeStep registra el uso de un código si su periodo es posterior al último aceptado
+func (r *MockUserMFARepository) ConsumeStep(ctx context.Context, userID uuid.UUID, step int64, usedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfa, exists := r.secrets[userID]
+	if !exists || mfa.LastStep >= step {
+		return false, nil
+	}
+	mfa.LastStep = step
+	mfa.LastUsedAt = &usedAt
+	mfa.FailedAttempts = 0
+	mfa.LockedUntil = nil
+	mfa.UpdatedAt = usedAt
+	return true, nil
+}
+
+// RecordFailedAttempt suma un intento fallido y bloquea al llegar al máximo
+func (r *MockUserMFARepository) RecordFailedAttempt(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfa, exists := r.secrets[userID]
+	if !exists {
+		return false, nil
+	}
+	mfa.FailedAttempts++
+	mfa.UpdatedAt = at
+	if mfa.FailedAttempts < maxAttempts {
+		return false, nil
+	}
+	mfa.FailedAttempts = 0
+	mfa.LockedUntil = &lockedUntil
+	return true, nil
+}
+
+// Delete elimina la configuración MFA del usuario
+func (r *MockUserMFARepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.secrets, userID)
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockUserProfileRepository es una implementación en memoria del UserProfileRepository
+type MockUserProfileRepository struct {
+	mu       sync.RWMutex
+	profiles map[uuid.UUID]*repository.UserProfile
+}
+
+// NewMockUserProfileRepository crea una nueva instancia vacía
+func NewMockUserProfileRepository() repository.UserProfileRepository {
+	return &MockUserProfileRepository{
+		profiles: make(map[uuid.UUID]*repository.UserProfile),
+	}
+}
+
+// FindByUserID obtiene el perfil de un usuario
+func (r *MockUserProfileRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*repository.UserProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, exists := r.profiles[userID]
+	if !exists {
+		return nil, nil
+	}
+	profileCopy := *profile
+	return &profileCopy, nil
+}
+
+// FindByUserIDs obtiene los perfiles existentes de varios usuarios
+func (r *MockUserProfileRepository) FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*repository.UserProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.UserProfile
+	for _, id := range userIDs {
+		if profile, exists := r.profiles[id]; exists {
+			profileCopy := *profile
+			result = append(result, &profileCopy)
+		}
+	}
+	return result, nil
+}
+
+// Upsert crea o reemplaza el perfil del usuario
+func (r *MockUserProfileRepository) Upsert(ctx context.Context, profile *repository.UserProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	profileCopy := *profile
+	if existing, exists := r.profiles[profile.UserID]; exists {
+		profileCopy.CreatedAt = existing.CreatedAt
+	}
+	r.profiles[profile.UserID] = &profileCopy
+	return nil
+}
+
+// Delete elimina el perfil del usuario
+func (r *MockUserProfileRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.profiles, userID)
+	return nil
+}
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-administracion/internal/infrastructure/persistence/mock/dataset"
+	"github.com/EduGoGroup/edugo-api-administracion/internal/shared/similarity"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/google/uuid"
@@ -154,18 +156,7 @@ func (r *MockUserRepository) List(ctx context.Context, filters repository.ListFi
 
 	// Filtrar usuarios
 	for _, user := range r.users {
-		// Excluir usuarios eliminados
-		if user.DeletedAt != nil {
-			continue
-		}
-
-		// Aplicar filtro de rol
-		if filters.Role != nil && user.Role != *filters.Role {
-			continue
-		}
-
-		// Aplicar filtro de estado activo
-		if filters.IsActive != nil && user.IsActive != *filters.IsActive {
+		if !matchesUserFilters(user, filters) {
 			continue
 		}
 
@@ -174,6 +165,14 @@ func (r *MockUserRepository) List(ctx context.Context, filters repository.ListFi
 		result = append(result, &userCopy)
 	}
 
+	// Ordenar como PostgreSQL (created_at DESC, id) para que la paginación sea estable
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].ID.String() < result[j].ID.String()
+	})
+
 	// Aplicar offset
 	if filters.Offset > 0 {
 		if filters.Offset >= len(result) {
@@ -204,6 +203,144 @@ func (r *MockUserRepository) ExistsByEmail(ctx context.Context, email string) (b
 	return false, nil
 }
 
+// FindByIDs busca varios usuarios por ID; los que no existen se omiten
+func (r *MockUserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entities.User
+	for _, id := range ids {
+		user, exists := r.users[id]
+		if !exists || user.DeletedAt != nil {
+			continue
+		}
+		userCopy := *user
+		result = append(result, &userCopy)
+	}
+
+	return result, nil
+}
+
+// FindByIDForUpdate busca un usuario por ID (el mock no tiene transacciones que bloquear)
+func (r *MockUserRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	return r.FindByID(ctx, id)
+}
+
+// Count cuenta los usuarios que cumplen los filtros
+func (r *MockUserRepository) Count(ctx context.Context, filters repository.ListFilters) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, user := range r.users {
+		if matchesUserFilters(user, filters) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FindEmailDuplicates agrupa los usuarios que comparten email normalizado
+func (r *MockUserRepository) FindEmailDuplicates(ctx context.Context, filters repository.ListFilters) ([][]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byEmail := make(map[string][]uuid.UUID)
+	for _, user := range r.users {
+		if !matchesUserFilters(user, filters) {
+			continue
+		}
+		if email := similarity.NormalizeEmail(user.Email); email != "" {
+			byEmail[email] = append(byEmail[email], user.ID)
+		}
+	}
+	return duplicateGroups(byEmail), nil
+}
+
+// FindPhoneDuplicates retorna vacío: el mock no conoce los perfiles de los usuarios
+func (r *MockUserRepository) FindPhoneDuplicates(ctx context.Context, filters repository.ListFilters, minDigits int) ([][]uuid.UUID, error) {
+	return nil, nil
+}
+
+// ListNameBlocks lista los bloques de nombre con más de un usuario
+func (r *MockUserRepository) ListNameBlocks(ctx context.Context, filters repository.ListFilters) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, user := range r.users {
+		if block := nameBlock(user); block != "" && matchesUserFilters(user, filters) {
+			counts[block]++
+		}
+	}
+
+	var blocks []string
+	for block, count := range counts {
+		if count > 1 {
+			blocks = append(blocks, block)
+		}
+	}
+	sort.Strings(blocks)
+	return blocks, nil
+}
+
+// ListByNameBlock lista los usuarios de un bloque de nombre
+func (r *MockUserRepository) ListByNameBlock(ctx context.Context, filters repository.ListFilters, block string) ([]*entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entities.User
+	for _, user := range r.users {
+		if nameBlock(user) == block && matchesUserFilters(user, filters) {
+			userCopy := *user
+			result = append(result, &userCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID.String() < result[j].ID.String() })
+	return result, nil
+}
+
+// matchesUserFilters aplica los filtros de listado (sin Limit ni Offset) y excluye los eliminados
+func matchesUserFilters(user *entities.User, filters repository.ListFilters) bool {
+	if user.DeletedAt != nil {
+		return false
+	}
+	if filters.Role != nil && user.Role != *filters.Role {
+		return false
+	}
+	if filters.IsActive != nil && user.IsActive != *filters.IsActive {
+		return false
+	}
+	// El mock solo conoce la escuela principal del usuario
+	if filters.SchoolID != nil && (user.SchoolID == nil || *user.SchoolID != *filters.SchoolID) {
+		return false
+	}
+	return true
+}
+
+// nameBlock son las dos primeras letras del apellido normalizado (vacío si es más corto)
+func nameBlock(user *entities.User) string {
+	lastName := []rune(similarity.NormalizeName(user.LastName))
+	if len(lastName) < 2 {
+		return ""
+	}
+	return string(lastName[:2])
+}
+
+// duplicateGroups retorna los grupos de dos o más usuarios, ordenados para que el resultado sea estable
+func duplicateGroups(byKey map[string][]uuid.UUID) [][]uuid.UUID {
+	var groups [][]uuid.UUID
+	for _, ids := range byKey {
+		if len(ids) < 2 {
+			continue
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+		groups = append(groups, ids)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0].String() < groups[j][0].String() })
+	return groups
+}
+
 // Reset reinicia el repositorio a su estado inicial (útil para testing)
 func (r *MockUserRepository) Reset() {
 	r.mu.Lock()
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockUserStatusChangeRepository es una implementación en memoria del UserStatusChangeRepository
+type MockUserStatusChangeRepository struct {
+	mu      sync.RWMutex
+	changes []*repository.UserStatusChange
+}
+
+// NewMockUserStatusChangeRepository crea una nueva instancia vacía
+func NewMockUserStatusChangeRepository() repository.UserStatusChangeRepository {
+	return &MockUserStatusChangeRepository{}
+}
+
+// Create registra un cambio de estado
+func (r *MockUserStatusChangeRepository) Create(ctx context.Context, change *repository.UserStatusChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if change.ID == uuid.Nil {
+		change.ID = uuid.New()
+	}
+
+	changeCopy := *change
+	r.changes = append(r.changes, &changeCopy)
+	return nil
+}
+
+// FindLatestByUser obtiene el último cambio de la acción indicada
+func (r *MockUserStatusChangeRepository) FindLatestByUser(ctx context.Context, userID uuid.UUID, action string) (*repository.UserStatusChange, error) {
+	changes, _ := r.ListByUser(ctx, userID)
+	for _, change := range changes {
+		if change.Action == action {
+			return change, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListByUser lista el historial de un usuario, del más reciente al más antiguo
+func (r *MockUserStatusChangeRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*repository.UserStatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.UserStatusChange
+	for _, change := range r.changes {
+		if change.UserID == userID {
+			changeCopy := *change
+			result = append(result, &changeCopy)
+		}
+	}
+
+	// Orden estable: los registros se agregan en orden cronológico
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// ReassignUser mueve las referencias de un usuario a otro
+func (r *MockUserStatusChangeRepository) ReassignUser(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for _, change := range r.changes {
+		if change.UserID == fromUserID {
+			change.UserID = toUserID
+			count++
+		}
+		if change.PerformedBy == fromUserID.String() {
+			change.PerformedBy = toUserID.String()
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RedactReason reemplaza un texto (sin distinguir mayúsculas) en los motivos que lo contienen
+func (r *MockUserStatusChangeRepository) RedactReason(ctx context.Context, text, replacement string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(text))
+	var count int64
+	for _, change := range r.changes {
+		if pattern.MatchString(change.Reason) {
+			change.Reason = pattern.ReplaceAllLiteralString(change.Reason, replacement)
+			count++
+		}
+	}
+	return count, nil
+}
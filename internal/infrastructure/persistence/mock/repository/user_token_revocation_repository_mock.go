@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MockUserTokenRevocationRepository es una implementación en memoria del UserTokenRevocationRepository
+type MockUserTokenRevocationRepository struct {
+	mu          sync.RWMutex
+	revocations map[uuid.UUID]mockTokenRevocation
+}
+
+type mockTokenRevocation struct {
+	revokedAt time.Time
+	expiresAt time.Time
+}
+
+// NewMockUserTokenRevocationRepository crea una nueva instancia vacía
+func NewMockUserTokenRevocationRepository() repository.UserTokenRevocationRepository {
+	return &MockUserTokenRevocationRepository{
+		revocations: make(map[uuid.UUID]mockTokenRevocation),
+	}
+}
+
+// Upsert registra la revocación del usuario conservando la más reciente
+func (r *MockUserTokenRevocationRepository) Upsert(ctx context.Context, userID uuid.UUID, revokedAt, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, exists := r.revocations[userID]; exists {
+		if current.revokedAt.After(revokedAt) {
+			revokedAt = current.revokedAt
+		}
+		if current.expiresAt.After(expiresAt) {
+			expiresAt = current.expiresAt
+		}
+	}
+	r.revocations[userID] = mockTokenRevocation{revokedAt: revokedAt, expiresAt: expiresAt}
+	return nil
+}
+
+// FindRevokedAt retorna la revocación vigente del usuario
+func (r *MockUserTokenRevocationRepository) FindRevokedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revocation, exists := r.revocations[userID]
+	if !exists || !time.Now().Before(revocation.expiresAt) {
+		return nil, nil
+	}
+	revokedAt := revocation.revokedAt
+	return &revokedAt, nil
+}
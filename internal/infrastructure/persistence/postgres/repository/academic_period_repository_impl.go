@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+const academicPeriodColumns = `id, school_id, parent_id, type, name, year, start_date, end_date, status,
+	closed_by, closed_at, created_at, updated_at`
+
+type postgresAcademicPeriodRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAcademicPeriodRepository crea un nuevo repository de PostgreSQL
+func NewPostgresAcademicPeriodRepository(db *sql.DB) repository.AcademicPeriodRepository {
+	return &postgresAcademicPeriodRepository{db: db}
+}
+
+func (r *postgresAcademicPeriodRepository) Create(ctx context.Context, period *repository.AcademicPeriod) error {
+	query := `INSERT INTO academic_periods (` + academicPeriodColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		period.ID, period.SchoolID, period.ParentID, period.Type, period.Name, period.Year,
+		period.StartDate, period.EndDate, period.Status, period.ClosedBy, period.ClosedAt,
+		period.CreatedAt, period.UpdatedAt,
+	)
+	return err
+}
+
+func (r *postgresAcademicPeriodRepository) Update(ctx context.Context, period *repository.AcademicPeriod) error {
+	query := `UPDATE academic_periods
+		SET name = $2, start_date = $3, end_date = $4, status = $5, closed_by = $6, closed_at = $7, updated_at = $8
+		WHERE id = $1`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		period.ID, period.Name, period.StartDate, period.EndDate, period.Status,
+		period.ClosedBy, period.ClosedAt, period.UpdatedAt,
+	)
+	return err
+}
+
+func (r *postgresAcademicPeriodRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.AcademicPeriod, error) {
+	query := `SELECT ` + academicPeriodColumns + ` FROM academic_periods WHERE id = $1`
+	return r.findOne(ctx, query, id)
+}
+
+func (r *postgresAcademicPeriodRepository) FindYear(ctx context.Context, schoolID uuid.UUID, year int) (*repository.AcademicPeriod, error) {
+	query := `SELECT ` + academicPeriodColumns + ` FROM academic_periods
+		WHERE school_id = $1 AND type = 'year' AND year = $2`
+	return r.findOne(ctx, query, schoolID, year)
+}
+
+func (r *postgresAcademicPeriodRepository) FindActiveYear(ctx context.Context, schoolID uuid.UUID) (*repository.AcademicPeriod, error) {
+	query := `SELECT ` + academicPeriodColumns + ` FROM academic_periods
+		WHERE school_id = $1 AND type = 'year' AND status = 'active'`
+	return r.findOne(ctx, query, schoolID)
+}
+
+func (r *postgresAcademicPeriodRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*repository.AcademicPeriod, error) {
+	query := `SELECT ` + academicPeriodColumns + ` FROM academic_periods
+		WHERE school_id = $1 ORDER BY start_date, type DESC`
+	return r.findMany(ctx, query, schoolID)
+}
+
+func (r *postgresAcademicPeriodRepository) ListTerms(ctx context.Context, yearID uuid.UUID) ([]*repository.AcademicPeriod, error) {
+	query := `SELECT ` + academicPeriodColumns + ` FROM academic_periods
+		WHERE parent_id = $1 ORDER BY start_date`
+	return r.findMany(ctx, query, yearID)
+}
+
+func (r *postgresAcademicPeriodRepository) findOne(ctx context.Context, query string, args ...interface{}) (*repository.AcademicPeriod, error) {
+	period, err := scanAcademicPeriod(conn(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+func (r *postgresAcademicPeriodRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*repository.AcademicPeriod, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var periods []*repository.AcademicPeriod
+	for rows.Next() {
+		period, err := scanAcademicPeriod(rows)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+	return periods, rows.Err()
+}
+
+func scanAcademicPeriod(row rowScanner) (*repository.AcademicPeriod, error) {
+	period := &repository.AcademicPeriod{}
+	err := row.Scan(
+		&period.ID, &period.SchoolID, &period.ParentID, &period.Type, &period.Name, &period.Year,
+		&period.StartDate, &period.EndDate, &period.Status, &period.ClosedBy, &period.ClosedAt,
+		&period.CreatedAt, &period.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return period, nil
+}
@@ -22,7 +22,7 @@ func NewPostgresAcademicUnitRepository(db *sql.DB) repository.AcademicUnitReposi
 func (r *postgresAcademicUnitRepository) Create(ctx context.Context, unit *entities.AcademicUnit) error {
 	query := `INSERT INTO academic_units (id, parent_unit_id, school_id, type, name, code, description, level, academic_year, metadata, is_active, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		unit.ID, unit.ParentUnitID, unit.SchoolID, unit.Type, unit.Name, unit.Code,
 		unit.Description, unit.Level, unit.AcademicYear, unit.Metadata, unit.IsActive,
 		unit.CreatedAt, unit.UpdatedAt,
@@ -55,6 +55,13 @@ func (r *postgresAcademicUnitRepository) FindBySchoolID(ctx context.Context, sch
 	return r.scanMany(ctx, query, schoolID)
 }
 
+func (r *postgresAcademicUnitRepository) CountBySchoolID(ctx context.Context, schoolID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM academic_units WHERE school_id = $1 AND deleted_at IS NULL`
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, schoolID).Scan(&count)
+	return count, err
+}
+
 func (r *postgresAcademicUnitRepository) FindByParentID(ctx context.Context, parentID uuid.UUID, includeDeleted bool) ([]*entities.AcademicUnit, error) {
 	query := `SELECT id, parent_unit_id, school_id, type, name, code, description, level, academic_year, metadata, is_active, created_at, updated_at, deleted_at
 		FROM academic_units WHERE parent_unit_id = $1`
@@ -84,7 +91,7 @@ func (r *postgresAcademicUnitRepository) FindByType(ctx context.Context, schoolI
 func (r *postgresAcademicUnitRepository) Update(ctx context.Context, unit *entities.AcademicUnit) error {
 	query := `UPDATE academic_units SET parent_unit_id = $1, name = $2, code = $3, description = $4, level = $5,
 		academic_year = $6, metadata = $7, is_active = $8, updated_at = $9 WHERE id = $10 AND deleted_at IS NULL`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		unit.ParentUnitID, unit.Name, unit.Code, unit.Description, unit.Level,
 		unit.AcademicYear, unit.Metadata, unit.IsActive, unit.UpdatedAt, unit.ID,
 	)
@@ -94,46 +101,146 @@ func (r *postgresAcademicUnitRepository) Update(ctx context.Context, unit *entit
 func (r *postgresAcademicUnitRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	now := time.Now()
 	query := `UPDATE academic_units SET deleted_at = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL`
-	_, err := r.db.ExecContext(ctx, query, now, now, id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, now, now, id)
 	return err
 }
 
 func (r *postgresAcademicUnitRepository) Restore(ctx context.Context, id uuid.UUID) error {
 	query := `UPDATE academic_units SET deleted_at = NULL, updated_at = $1 WHERE id = $2`
-	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, time.Now(), id)
 	return err
 }
 
 func (r *postgresAcademicUnitRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM academic_units WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	return err
 }
 
+// Columnas de academic_units calificadas con el alias u, para las consultas recursivas
+const academicUnitColumnsU = `u.id, u.parent_unit_id, u.school_id, u.type, u.name, u.code, u.description, u.level,
+	u.academic_year, u.metadata, u.is_active, u.created_at, u.updated_at, u.deleted_at`
+
+// ancestorsCTE recorre hacia arriba desde $1 sin pasar por unidades eliminadas; el arreglo
+// path corta la recursión si los datos ya tienen un ciclo
+const ancestorsCTE = `WITH RECURSIVE ancestors AS (
+		SELECT id, parent_unit_id, 0 AS depth, ARRAY[id] AS path
+		FROM academic_units WHERE id = $1 AND deleted_at IS NULL
+		UNION ALL
+		SELECT p.id, p.parent_unit_id, a.depth + 1, a.path || p.id
+		FROM academic_units p JOIN ancestors a ON p.id = a.parent_unit_id
+		WHERE p.deleted_at IS NULL AND NOT p.id = ANY(a.path)
+	)`
+
 func (r *postgresAcademicUnitRepository) GetHierarchyPath(ctx context.Context, id uuid.UUID) ([]*entities.AcademicUnit, error) {
-	// Implementación recursiva simple - puede optimizarse con CTE
-	var path []*entities.AcademicUnit
-	currentID := id
-
-	for {
-		unit, err := r.FindByID(ctx, currentID, false)
-		if err != nil || unit == nil {
-			break
+	query := ancestorsCTE + `
+		SELECT ` + academicUnitColumnsU + `
+		FROM ancestors a JOIN academic_units u ON u.id = a.id
+		ORDER BY a.depth DESC`
+	return r.scanMany(ctx, query, id)
+}
+
+func (r *postgresAcademicUnitRepository) FindAncestors(ctx context.Context, id uuid.UUID) ([]*entities.AcademicUnit, error) {
+	query := ancestorsCTE + `
+		SELECT ` + academicUnitColumnsU + `
+		FROM ancestors a JOIN academic_units u ON u.id = a.id
+		WHERE a.depth > 0
+		ORDER BY a.depth DESC`
+	return r.scanMany(ctx, query, id)
+}
+
+// descendantsCTE recorre hacia abajo desde $1 hasta la profundidad $2 (0 = sin límite);
+// con $3 = false no entra en unidades eliminadas
+const descendantsCTE = `WITH RECURSIVE subtree AS (
+		SELECT id, 0 AS depth, ARRAY[id] AS path FROM academic_units WHERE id = $1
+		UNION ALL
+		SELECT c.id, s.depth + 1, s.path || c.id
+		FROM academic_units c JOIN subtree s ON c.parent_unit_id = s.id
+		WHERE NOT c.id = ANY(s.path)
+			AND ($2::int = 0 OR s.depth < $2::int)
+			AND ($3::bool OR c.deleted_at IS NULL)
+	)`
+
+func (r *postgresAcademicUnitRepository) FindDescendants(ctx context.Context, id uuid.UUID, maxDepth int, includeDeleted bool) ([]repository.UnitDescendant, error) {
+	query := descendantsCTE + `
+		SELECT ` + academicUnitColumnsU + `, s.depth
+		FROM subtree s JOIN academic_units u ON u.id = s.id
+		WHERE s.depth > 0
+		ORDER BY s.depth, u.name`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, id, maxDepth, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var descendants []repository.UnitDescendant
+	for rows.Next() {
+		unit := &entities.AcademicUnit{}
+		var depth int
+		err := rows.Scan(
+			&unit.ID, &unit.ParentUnitID, &unit.SchoolID, &unit.Type, &unit.Name, &unit.Code,
+			&unit.Description, &unit.Level, &unit.AcademicYear, &unit.Metadata, &unit.IsActive,
+			&unit.CreatedAt, &unit.UpdatedAt, &unit.DeletedAt, &depth,
+		)
+		if err != nil {
+			return nil, err
 		}
-		path = append([]*entities.AcademicUnit{unit}, path...)
-		if unit.ParentUnitID == nil {
-			break
+		descendants = append(descendants, repository.UnitDescendant{Unit: unit, Depth: depth})
+	}
+	return descendants, rows.Err()
+}
+
+func (r *postgresAcademicUnitRepository) CountSubtree(ctx context.Context, id uuid.UUID, includeDeleted bool) (*repository.UnitSubtreeCount, error) {
+	query := descendantsCTE + `
+		SELECT u.type, COUNT(*), MAX(s.depth)
+		FROM subtree s JOIN academic_units u ON u.id = s.id
+		WHERE s.depth > 0
+		GROUP BY u.type`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, id, 0, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	count := &repository.UnitSubtreeCount{ByType: map[string]int{}}
+	for rows.Next() {
+		var unitType string
+		var total, depth int
+		if err := rows.Scan(&unitType, &total, &depth); err != nil {
+			return nil, err
+		}
+		count.ByType[unitType] = total
+		count.Descendants += total
+		if depth > count.MaxDepth {
+			count.MaxDepth = depth
 		}
-		currentID = *unit.ParentUnitID
 	}
+	return count, rows.Err()
+}
+
+func (r *postgresAcademicUnitRepository) IsAncestor(ctx context.Context, ancestorID, unitID uuid.UUID) (bool, error) {
+	// El arreglo path corta la recursión si los datos ya tienen un ciclo
+	query := `WITH RECURSIVE ancestors AS (
+			SELECT id, parent_unit_id, ARRAY[id] AS path FROM academic_units WHERE id = $2
+			UNION ALL
+			SELECT u.id, u.parent_unit_id, a.path || u.id
+			FROM academic_units u JOIN ancestors a ON u.id = a.parent_unit_id
+			WHERE NOT u.id = ANY(a.path)
+		)
+		SELECT EXISTS(SELECT 1 FROM ancestors WHERE id = $1)`
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, ancestorID, unitID).Scan(&exists)
+	return exists, err
+}
 
-	return path, nil
+func (r *postgresAcademicUnitRepository) LockHierarchy(ctx context.Context, schoolID uuid.UUID) error {
+	return advisoryXactLock(ctx, "academic_units:"+schoolID.String())
 }
 
 func (r *postgresAcademicUnitRepository) ExistsBySchoolIDAndCode(ctx context.Context, schoolID uuid.UUID, code string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM academic_units WHERE school_id = $1 AND code = $2 AND deleted_at IS NULL)`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, schoolID, code).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, schoolID, code).Scan(&exists)
 	return exists, err
 }
 
@@ -141,7 +248,7 @@ func (r *postgresAcademicUnitRepository) ExistsBySchoolIDAndCode(ctx context.Con
 
 func (r *postgresAcademicUnitRepository) scanOne(ctx context.Context, query string, args ...interface{}) (*entities.AcademicUnit, error) {
 	unit := &entities.AcademicUnit{}
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(
 		&unit.ID, &unit.ParentUnitID, &unit.SchoolID, &unit.Type, &unit.Name, &unit.Code,
 		&unit.Description, &unit.Level, &unit.AcademicYear, &unit.Metadata, &unit.IsActive,
 		&unit.CreatedAt, &unit.UpdatedAt, &unit.DeletedAt,
@@ -156,7 +263,7 @@ func (r *postgresAcademicUnitRepository) scanOne(ctx context.Context, query stri
 }
 
 func (r *postgresAcademicUnitRepository) scanMany(ctx context.Context, query string, args ...interface{}) ([]*entities.AcademicUnit, error) {
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresAdminChangeRequestRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAdminChangeRequestRepository crea un nuevo repository de PostgreSQL
+func NewPostgresAdminChangeRequestRepository(db *sql.DB) repository.AdminChangeRequestRepository {
+	return &postgresAdminChangeRequestRepository{db: db}
+}
+
+const adminChangeRequestColumns = `id, action, target_user_id, email, first_name, last_name, password_hash,
+	school_id, reason, status, approval_method, requested_by, decided_by, decision_note, expires_at,
+	created_at, decided_at`
+
+func (r *postgresAdminChangeRequestRepository) Create(ctx context.Context, request *repository.AdminChangeRequest) error {
+	query := `INSERT INTO admin_change_requests (` + adminChangeRequestColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		request.ID, request.Action, request.TargetUserID, strings.ToLower(request.Email), request.FirstName,
+		request.LastName, request.PasswordHash, request.SchoolID, request.Reason, request.Status,
+		request.ApprovalMethod, request.RequestedBy, request.DecidedBy, request.DecisionNote,
+		request.ExpiresAt, request.CreatedAt, request.DecidedAt,
+	)
+	return err
+}
+
+func (r *postgresAdminChangeRequestRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.AdminChangeRequest, error) {
+	query := `SELECT ` + adminChangeRequestColumns + ` FROM admin_change_requests WHERE id = $1`
+	request, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return request, err
+}
+
+func (r *postgresAdminChangeRequestRepository) FindPendingByEmail(ctx context.Context, email string) (*repository.AdminChangeRequest, error) {
+	query := `SELECT ` + adminChangeRequestColumns + ` FROM admin_change_requests
+		WHERE email = $1 AND status = $2 AND expires_at > $3
+		ORDER BY created_at DESC LIMIT 1`
+	request, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query,
+		strings.ToLower(email), repository.AdminChangeStatusPending, time.Now(),
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return request, err
+}
+
+func (r *postgresAdminChangeRequestRepository) List(ctx context.Context, filters repository.AdminChangeRequestFilters) ([]*repository.AdminChangeRequest, error) {
+	query := `SELECT ` + adminChangeRequestColumns + ` FROM admin_change_requests WHERE 1=1`
+	var args []interface{}
+
+	if filters.Status != "" {
+		args = append(args, filters.Status)
+		query += ` AND status = $` + strconv.Itoa(len(args))
+	}
+
+	query += ` ORDER BY created_at DESC, id`
+
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += ` LIMIT $` + strconv.Itoa(len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += ` OFFSET $` + strconv.Itoa(len(args))
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var requests []*repository.AdminChangeRequest
+	for rows.Next() {
+		request, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	return requests, rows.Err()
+}
+
+func (r *postgresAdminChangeRequestRepository) Update(ctx context.Context, request *repository.AdminChangeRequest) error {
+	query := `UPDATE admin_change_requests
+		SET target_user_id = $1, password_hash = $2, status = $3, approval_method = $4,
+		    decided_by = $5, decision_note = $6, decided_at = $7
+		WHERE id = $8`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		request.TargetUserID, request.PasswordHash, request.Status, request.ApprovalMethod,
+		request.DecidedBy, request.DecisionNote, request.DecidedAt, request.ID,
+	)
+	return err
+}
+
+func (r *postgresAdminChangeRequestRepository) AnonymizeEmail(ctx context.Context, email, replacement string) (int64, error) {
+	query := `UPDATE admin_change_requests
+		SET email = $2, first_name = '', last_name = '', password_hash = '',
+		    status = CASE WHEN status = $3 THEN $4 ELSE status END,
+		    decided_at = CASE WHEN status = $3 THEN NOW() ELSE decided_at END
+		WHERE LOWER(email) = LOWER($1)`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		email, replacement, repository.AdminChangeStatusPending, repository.AdminChangeStatusRejected,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *postgresAdminChangeRequestRepository) scan(row rowScanner) (*repository.AdminChangeRequest, error) {
+	request := &repository.AdminChangeRequest{}
+	err := row.Scan(
+		&request.ID, &request.Action, &request.TargetUserID, &request.Email, &request.FirstName,
+		&request.LastName, &request.PasswordHash, &request.SchoolID, &request.Reason, &request.Status,
+		&request.ApprovalMethod, &request.RequestedBy, &request.DecidedBy, &request.DecisionNote,
+		&request.ExpiresAt, &request.CreatedAt, &request.DecidedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
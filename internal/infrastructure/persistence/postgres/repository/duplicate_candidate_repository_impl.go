@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresDuplicateCandidateRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDuplicateCandidateRepository crea un nuevo repository de PostgreSQL
+func NewPostgresDuplicateCandidateRepository(db *sql.DB) repository.DuplicateCandidateRepository {
+	return &postgresDuplicateCandidateRepository{db: db}
+}
+
+const duplicateCandidateColumns = `id, user_id_a, user_id_b, score, reasons, status, detected_at, reviewed_by, reviewed_at`
+
+func (r *postgresDuplicateCandidateRepository) Create(ctx context.Context, candidate *repository.DuplicateCandidate) error {
+	reasons, err := json.Marshal(candidate.Reasons)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO user_duplicate_candidates (` + duplicateCandidateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		candidate.ID, candidate.UserIDA, candidate.UserIDB, candidate.Score, reasons,
+		candidate.Status, candidate.DetectedAt, candidate.ReviewedBy, candidate.ReviewedAt,
+	)
+	return err
+}
+
+func (r *postgresDuplicateCandidateRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.DuplicateCandidate, error) {
+	query := `SELECT ` + duplicateCandidateColumns + ` FROM user_duplicate_candidates WHERE id = $1`
+	candidate, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return candidate, err
+}
+
+func (r *postgresDuplicateCandidateRepository) FindByPair(ctx context.Context, userA, userB uuid.UUID) (*repository.DuplicateCandidate, error) {
+	query := `SELECT ` + duplicateCandidateColumns + ` FROM user_duplicate_candidates
+		WHERE (user_id_a = $1 AND user_id_b = $2) OR (user_id_a = $2 AND user_id_b = $1)`
+	candidate, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, userA, userB))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return candidate, err
+}
+
+func (r *postgresDuplicateCandidateRepository) List(ctx context.Context, filters repository.DuplicateCandidateFilters) ([]*repository.DuplicateCandidate, error) {
+	query := `SELECT ` + duplicateCandidateColumns + ` FROM user_duplicate_candidates WHERE 1=1`
+	args := []interface{}{}
+
+	if filters.Status != nil {
+		args = append(args, *filters.Status)
+		query += ` AND status = $` + strconv.Itoa(len(args))
+	}
+
+	query += ` ORDER BY score DESC, detected_at DESC`
+
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += ` LIMIT $` + strconv.Itoa(len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += ` OFFSET $` + strconv.Itoa(len(args))
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var candidates []*repository.DuplicateCandidate
+	for rows.Next() {
+		candidate, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, rows.Err()
+}
+
+func (r *postgresDuplicateCandidateRepository) Update(ctx context.Context, candidate *repository.DuplicateCandidate) error {
+	reasons, err := json.Marshal(candidate.Reasons)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE user_duplicate_candidates
+		SET score = $1, reasons = $2, status = $3, reviewed_by = $4, reviewed_at = $5
+		WHERE id = $6`
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		candidate.Score, reasons, candidate.Status, candidate.ReviewedBy, candidate.ReviewedAt, candidate.ID,
+	)
+	return err
+}
+
+func (r *postgresDuplicateCandidateRepository) scan(row rowScanner) (*repository.DuplicateCandidate, error) {
+	candidate := &repository.DuplicateCandidate{}
+	var reasons []byte
+	if err := row.Scan(
+		&candidate.ID, &candidate.UserIDA, &candidate.UserIDB, &candidate.Score, &reasons,
+		&candidate.Status, &candidate.DetectedAt, &candidate.ReviewedBy, &candidate.ReviewedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(reasons) > 0 {
+		if err := json.Unmarshal(reasons, &candidate.Reasons); err != nil {
+			return nil, err
+		}
+	}
+	return candidate, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type postgresGuardianRepository struct {
@@ -21,7 +22,7 @@ func NewPostgresGuardianRepository(db *sql.DB) repository.GuardianRepository {
 func (r *postgresGuardianRepository) CreateRelation(ctx context.Context, relation *entities.GuardianRelation) error {
 	query := `INSERT INTO guardian_relations (id, guardian_id, student_id, relationship_type, is_active, created_at, updated_at, created_by)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		relation.ID, relation.GuardianID, relation.StudentID, relation.RelationshipType,
 		relation.IsActive, relation.CreatedAt, relation.UpdatedAt, relation.CreatedBy,
 	)
@@ -32,7 +33,7 @@ func (r *postgresGuardianRepository) FindRelationByID(ctx context.Context, id uu
 	query := `SELECT id, guardian_id, student_id, relationship_type, is_active, created_at, updated_at, created_by
 		FROM guardian_relations WHERE id = $1`
 	relation := &entities.GuardianRelation{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&relation.ID, &relation.GuardianID, &relation.StudentID, &relation.RelationshipType,
 		&relation.IsActive, &relation.CreatedAt, &relation.UpdatedAt, &relation.CreatedBy,
 	)
@@ -56,18 +57,18 @@ func (r *postgresGuardianRepository) FindRelationsByStudent(ctx context.Context,
 
 func (r *postgresGuardianRepository) UpdateRelation(ctx context.Context, relation *entities.GuardianRelation) error {
 	query := `UPDATE guardian_relations SET relationship_type = $1, is_active = $2, updated_at = $3 WHERE id = $4`
-	_, err := r.db.ExecContext(ctx, query, relation.RelationshipType, relation.IsActive, relation.UpdatedAt, relation.ID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, relation.RelationshipType, relation.IsActive, relation.UpdatedAt, relation.ID)
 	return err
 }
 
 func (r *postgresGuardianRepository) DeleteRelation(ctx context.Context, id uuid.UUID) error {
 	query := `UPDATE guardian_relations SET is_active = false, updated_at = $1 WHERE id = $2`
-	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, time.Now(), id)
 	return err
 }
 
-func (r *postgresGuardianRepository) scanRelations(ctx context.Context, query string, id uuid.UUID) ([]*entities.GuardianRelation, error) {
-	rows, err := r.db.QueryContext(ctx, query, id)
+func (r *postgresGuardianRepository) scanRelations(ctx context.Context, query string, args ...interface{}) ([]*entities.GuardianRelation, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +115,25 @@ func (r *postgresGuardianRepository) Delete(ctx context.Context, id uuid.UUID) e
 func (r *postgresGuardianRepository) ExistsActiveRelation(ctx context.Context, guardianID, studentID uuid.UUID) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM guardian_relations WHERE guardian_id = $1 AND student_id = $2 AND is_active = true)`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, guardianID, studentID).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, guardianID, studentID).Scan(&exists)
 	return exists, err
 }
+
+func (r *postgresGuardianRepository) ReassignRelation(ctx context.Context, id, guardianID, studentID uuid.UUID) error {
+	query := `UPDATE guardian_relations SET guardian_id = $1, student_id = $2, updated_at = $3 WHERE id = $4`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, guardianID, studentID, time.Now(), id)
+	return err
+}
+
+func (r *postgresGuardianRepository) FindByStudents(ctx context.Context, studentIDs []uuid.UUID, activeOnly bool) ([]*entities.GuardianRelation, error) {
+	if len(studentIDs) == 0 {
+		return nil, nil
+	}
+	query := `SELECT id, guardian_id, student_id, relationship_type, is_active, created_at, updated_at, created_by
+		FROM guardian_relations WHERE student_id = ANY($1::uuid[])`
+	if activeOnly {
+		query += ` AND is_active = true`
+	}
+	query += ` ORDER BY student_id, created_at`
+	return r.scanRelations(ctx, query, pq.Array(studentIDs))
+}
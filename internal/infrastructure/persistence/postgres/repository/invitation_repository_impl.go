@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresInvitationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresInvitationRepository crea un nuevo repository de PostgreSQL
+func NewPostgresInvitationRepository(db *sql.DB) repository.InvitationRepository {
+	return &postgresInvitationRepository{db: db}
+}
+
+const invitationColumns = `id, email, school_id, academic_unit_id, role, user_role, token_hash, status, invited_by,
+	sent_count, expires_at, last_sent_at, accepted_user_id, accepted_at, revoked_at, created_at, updated_at`
+
+func (r *postgresInvitationRepository) Create(ctx context.Context, invitation *repository.Invitation) error {
+	query := `INSERT INTO school_invitations (` + invitationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		invitation.ID, strings.ToLower(invitation.Email), invitation.SchoolID, invitation.AcademicUnitID,
+		invitation.Role, invitation.UserRole, invitation.TokenHash, invitation.Status, invitation.InvitedBy,
+		invitation.SentCount, invitation.ExpiresAt, invitation.LastSentAt, invitation.AcceptedUserID,
+		invitation.AcceptedAt, invitation.RevokedAt, invitation.CreatedAt, invitation.UpdatedAt,
+	)
+	return err
+}
+
+func (r *postgresInvitationRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM school_invitations WHERE id = $1`
+	invitation, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return invitation, err
+}
+
+func (r *postgresInvitationRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*repository.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM school_invitations WHERE id = $1 FOR UPDATE`
+	invitation, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return invitation, err
+}
+
+func (r *postgresInvitationRepository) FindPendingByEmail(ctx context.Context, schoolID uuid.UUID, email string) (*repository.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM school_invitations
+		WHERE school_id = $1 AND email = $2 AND status = $3 AND expires_at > $4
+		ORDER BY created_at DESC LIMIT 1`
+	invitation, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query,
+		schoolID, strings.ToLower(email), repository.InvitationStatusPending, time.Now(),
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return invitation, err
+}
+
+func (r *postgresInvitationRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID, filters repository.InvitationFilters) ([]*repository.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM school_invitations WHERE school_id = $1`
+	args := []interface{}{schoolID}
+
+	if filters.Status != nil {
+		switch *filters.Status {
+		case repository.InvitationStatusPending:
+			args = append(args, repository.InvitationStatusPending, time.Now())
+			query += ` AND status = $` + strconv.Itoa(len(args)-1) + ` AND expires_at > $` + strconv.Itoa(len(args))
+		case repository.InvitationStatusExpired:
+			args = append(args, repository.InvitationStatusPending, time.Now())
+			query += ` AND status = $` + strconv.Itoa(len(args)-1) + ` AND expires_at <= $` + strconv.Itoa(len(args))
+		default:
+			args = append(args, *filters.Status)
+			query += ` AND status = $` + strconv.Itoa(len(args))
+		}
+	}
+
+	query += ` ORDER BY created_at DESC, id`
+
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += ` LIMIT $` + strconv.Itoa(len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += ` OFFSET $` + strconv.Itoa(len(args))
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var invitations []*repository.Invitation
+	for rows.Next() {
+		invitation, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations, rows.Err()
+}
+
+func (r *postgresInvitationRepository) Update(ctx context.Context, invitation *repository.Invitation) error {
+	query := `UPDATE school_invitations
+		SET token_hash = $1, status = $2, sent_count = $3, expires_at = $4, last_sent_at = $5,
+		    accepted_user_id = $6, accepted_at = $7, revoked_at = $8, updated_at = $9
+		WHERE id = $10`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		invitation.TokenHash, invitation.Status, invitation.SentCount, invitation.ExpiresAt,
+		invitation.LastSentAt, invitation.AcceptedUserID, invitation.AcceptedAt, invitation.RevokedAt,
+		invitation.UpdatedAt, invitation.ID,
+	)
+	return err
+}
+
+func (r *postgresInvitationRepository) AnonymizeEmail(ctx context.Context, email, replacement string) (int64, error) {
+	query := `UPDATE school_invitations
+		SET email = $2,
+			status = CASE WHEN status = $3 THEN $4 ELSE status END,
+			revoked_at = CASE WHEN status = $3 THEN NOW() ELSE revoked_at END,
+			updated_at = NOW()
+		WHERE LOWER(email) = LOWER($1)`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		email, replacement, repository.InvitationStatusPending, repository.InvitationStatusRevoked,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *postgresInvitationRepository) ReassignPendingEmail(ctx context.Context, fromEmail, toEmail string) (int64, error) {
+	query := `WITH taken AS (
+			SELECT school_id FROM school_invitations
+			WHERE LOWER(email) = LOWER($2) AND status = $3 AND expires_at > NOW()
+		)
+		UPDATE school_invitations
+		SET email = CASE WHEN school_id IN (SELECT school_id FROM taken) THEN email ELSE LOWER($2) END,
+			status = CASE WHEN school_id IN (SELECT school_id FROM taken) THEN $4 ELSE status END,
+			revoked_at = CASE WHEN school_id IN (SELECT school_id FROM taken) THEN NOW() ELSE revoked_at END,
+			updated_at = NOW()
+		WHERE LOWER(email) = LOWER($1) AND status = $3`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		fromEmail, toEmail, repository.InvitationStatusPending, repository.InvitationStatusRevoked,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *postgresInvitationRepository) scan(row rowScanner) (*repository.Invitation, error) {
+	invitation := &repository.Invitation{}
+	err := row.Scan(
+		&invitation.ID, &invitation.Email, &invitation.SchoolID, &invitation.AcademicUnitID,
+		&invitation.Role, &invitation.UserRole, &invitation.TokenHash, &invitation.Status, &invitation.InvitedBy,
+		&invitation.SentCount, &invitation.ExpiresAt, &invitation.LastSentAt, &invitation.AcceptedUserID,
+		&invitation.AcceptedAt, &invitation.RevokedAt, &invitation.CreatedAt, &invitation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresLoginEventRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLoginEventRepository crea un nuevo repository de PostgreSQL
+func NewPostgresLoginEventRepository(db *sql.DB) repository.LoginEventRepository {
+	return &postgresLoginEventRepository{db: db}
+}
+
+func (r *postgresLoginEventRepository) Create(ctx context.Context, event *repository.LoginEvent) error {
+	query := `INSERT INTO user_login_events (id, user_id, result, school_id, occurred_at)
+		VALUES ($1, $2, $3, $4, $5)`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		event.ID, event.UserID, event.Result, event.SchoolID, event.OccurredAt,
+	)
+	return err
+}
+
+func (r *postgresLoginEventRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*repository.LoginEvent, error) {
+	query := `SELECT id, user_id, result, school_id, occurred_at
+		FROM user_login_events WHERE user_id = $1 ORDER BY occurred_at DESC`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*repository.LoginEvent
+	for rows.Next() {
+		event := &repository.LoginEvent{}
+		if err := rows.Scan(&event.ID, &event.UserID, &event.Result, &event.SchoolID, &event.OccurredAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *postgresLoginEventRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM user_login_events WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *postgresLoginEventRepository) ReassignUser(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE user_login_events SET user_id = $1 WHERE user_id = $2`, toUserID, fromUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -24,7 +24,7 @@ func (r *postgresMaterialRepository) Delete(ctx context.Context, id uuid.UUID) e
 		WHERE id = $2
 	`
 
-	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, time.Now(), id)
 	return err
 }
 
@@ -32,6 +32,6 @@ func (r *postgresMaterialRepository) Exists(ctx context.Context, id uuid.UUID) (
 	query := `SELECT EXISTS(SELECT 1 FROM materials WHERE id = $1 AND is_deleted = false)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&exists)
 	return exists, err
 }
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+const rolloverColumns = `id, school_id, from_year, to_year, units_created, promoted, retained, graduated,
+	pending_review, performed_by, created_at`
+
+type postgresRolloverRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRolloverRepository crea un nuevo repository de PostgreSQL
+func NewPostgresRolloverRepository(db *sql.DB) repository.RolloverRepository {
+	return &postgresRolloverRepository{db: db}
+}
+
+func (r *postgresRolloverRepository) Create(ctx context.Context, rollover *repository.SchoolRollover, entries []repository.SchoolRolloverEntry) error {
+	query := `INSERT INTO school_rollovers (` + rolloverColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query,
+		rollover.ID, rollover.SchoolID, rollover.FromYear, rollover.ToYear, rollover.UnitsCreated,
+		rollover.Promoted, rollover.Retained, rollover.Graduated, rollover.PendingReview,
+		rollover.PerformedBy, rollover.CreatedAt,
+	); err != nil {
+		return err
+	}
+
+	entryQuery := `INSERT INTO school_rollover_entries
+		(rollover_id, user_id, action, from_membership_id, from_unit_id, to_membership_id, to_unit_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	for _, entry := range entries {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, entryQuery,
+			rollover.ID, entry.UserID, entry.Action, entry.FromMembershipID, entry.FromUnitID,
+			entry.ToMembershipID, entry.ToUnitID,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresRolloverRepository) FindByID(ctx context.Context, id uuid.UUID) (*repository.SchoolRollover, error) {
+	query := `SELECT ` + rolloverColumns + ` FROM school_rollovers WHERE id = $1`
+	return r.findOne(ctx, query, id)
+}
+
+func (r *postgresRolloverRepository) FindBySchoolAndYears(ctx context.Context, schoolID uuid.UUID, fromYear, toYear int) (*repository.SchoolRollover, error) {
+	query := `SELECT ` + rolloverColumns + ` FROM school_rollovers
+		WHERE school_id = $1 AND from_year = $2 AND to_year = $3`
+	return r.findOne(ctx, query, schoolID, fromYear, toYear)
+}
+
+func (r *postgresRolloverRepository) ListEntries(ctx context.Context, rolloverID uuid.UUID) ([]repository.SchoolRolloverEntry, error) {
+	query := `SELECT rollover_id, user_id, action, from_membership_id, from_unit_id, to_membership_id, to_unit_id
+		FROM school_rollover_entries WHERE rollover_id = $1 ORDER BY action, user_id`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, rolloverID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []repository.SchoolRolloverEntry
+	for rows.Next() {
+		var entry repository.SchoolRolloverEntry
+		if err := rows.Scan(
+			&entry.RolloverID, &entry.UserID, &entry.Action, &entry.FromMembershipID, &entry.FromUnitID,
+			&entry.ToMembershipID, &entry.ToUnitID,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (r *postgresRolloverRepository) findOne(ctx context.Context, query string, args ...interface{}) (*repository.SchoolRollover, error) {
+	rollover := &repository.SchoolRollover{}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(
+		&rollover.ID, &rollover.SchoolID, &rollover.FromYear, &rollover.ToYear, &rollover.UnitsCreated,
+		&rollover.Promoted, &rollover.Retained, &rollover.Graduated, &rollover.PendingReview,
+		&rollover.PerformedBy, &rollover.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rollover, nil
+}
@@ -0,0 +1,6 @@
+package repository
+
+// rowScanner abstrae *sql.Row y *sql.Rows para reutilizar funciones de scan
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// purgeQueries son las sentencias de cada paso de la purga; todas reciben el school_id como $1.
+// Los usuarios no se eliminan (pueden pertenecer a otras escuelas), solo se les quita la referencia.
+var purgeQueries = map[string][]string{
+	repository.PurgeStepMemberships: {
+		`DELETE FROM school_rollover_entries WHERE rollover_id IN (SELECT id FROM school_rollovers WHERE school_id = $1)`,
+		`DELETE FROM school_rollovers WHERE school_id = $1`,
+		`DELETE FROM unit_waitlist_entries WHERE school_id = $1`,
+		`DELETE FROM memberships WHERE school_id = $1`,
+	},
+	repository.PurgeStepInvitations: {`DELETE FROM school_invitations WHERE school_id = $1`},
+	repository.PurgeStepAcademicUnits: {
+		`UPDATE academic_units SET parent_unit_id = NULL WHERE school_id = $1 AND parent_unit_id IS NOT NULL`,
+		`DELETE FROM unit_deletion_items WHERE batch_id IN (SELECT id FROM unit_deletion_batches WHERE school_id = $1)`,
+		`DELETE FROM unit_deletion_batches WHERE school_id = $1`,
+		`DELETE FROM unit_capacities WHERE school_id = $1`,
+		`DELETE FROM academic_units WHERE school_id = $1`,
+		`DELETE FROM school_unit_types WHERE school_id = $1`,
+		`DELETE FROM academic_periods WHERE school_id = $1 AND parent_id IS NOT NULL`,
+		`DELETE FROM academic_periods WHERE school_id = $1`,
+	},
+	repository.PurgeStepSettings: {
+		`DELETE FROM school_settings_history WHERE school_id = $1`,
+		`DELETE FROM school_settings WHERE school_id = $1`,
+	},
+	repository.PurgeStepSubscriptionLogs: {`DELETE FROM school_subscription_changes WHERE school_id = $1`},
+	repository.PurgeStepUsers:            {`UPDATE users SET school_id = NULL WHERE school_id = $1`},
+	repository.PurgeStepArchiveItems: {
+		`DELETE FROM school_archive_items WHERE archive_id IN (SELECT id FROM school_archives WHERE school_id = $1)`,
+	},
+	repository.PurgeStepSchool: {`DELETE FROM schools WHERE id = $1 AND deleted_at IS NOT NULL`},
+}
+
+const purgeJobColumns = `id, school_id, archive_id, status, total_steps, completed_steps, current_step,
+	deleted_rows, error, requested_by, created_at, started_at, finished_at`
+
+type postgresSchoolLifecycleRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSchoolLifecycleRepository crea un nuevo repository de PostgreSQL
+func NewPostgresSchoolLifecycleRepository(db *sql.DB) repository.SchoolLifecycleRepository {
+	return &postgresSchoolLifecycleRepository{db: db}
+}
+
+func (r *postgresSchoolLifecycleRepository) CreateArchive(ctx context.Context, archive *repository.SchoolArchive, items []repository.SchoolArchiveItem) error {
+	query := `INSERT INTO school_archives (id, school_id, school_code, reason, archived_by, archived_at, purge_after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query,
+		archive.ID, archive.SchoolID, archive.SchoolCode, archive.Reason,
+		archive.ArchivedBy, archive.ArchivedAt, archive.PurgeAfter,
+	); err != nil {
+		return err
+	}
+
+	itemQuery := `INSERT INTO school_archive_items (archive_id, entity_type, entity_id) VALUES ($1, $2, $3)`
+	for _, item := range items {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, itemQuery, archive.ID, item.EntityType, item.EntityID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresSchoolLifecycleRepository) FindOpenArchive(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolArchive, error) {
+	query := `SELECT id, school_id, school_code, reason, archived_by, archived_at, purge_after, restored_by, restored_at, purged_at
+		FROM school_archives
+		WHERE school_id = $1 AND restored_at IS NULL AND purged_at IS NULL
+		ORDER BY archived_at DESC LIMIT 1`
+	archive := &repository.SchoolArchive{}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, schoolID).Scan(
+		&archive.ID, &archive.SchoolID, &archive.SchoolCode, &archive.Reason, &archive.ArchivedBy,
+		&archive.ArchivedAt, &archive.PurgeAfter, &archive.RestoredBy, &archive.RestoredAt, &archive.PurgedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+func (r *postgresSchoolLifecycleRepository) ListArchiveItems(ctx context.Context, archiveID uuid.UUID) ([]repository.SchoolArchiveItem, error) {
+	query := `SELECT archive_id, entity_type, entity_id FROM school_archive_items WHERE archive_id = $1`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, archiveID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var items []repository.SchoolArchiveItem
+	for rows.Next() {
+		var item repository.SchoolArchiveItem
+		if err := rows.Scan(&item.ArchiveID, &item.EntityType, &item.EntityID); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (r *postgresSchoolLifecycleRepository) UpdateArchive(ctx context.Context, archive *repository.SchoolArchive) error {
+	query := `UPDATE school_archives SET restored_by = $1, restored_at = $2, purged_at = $3 WHERE id = $4`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, archive.RestoredBy, archive.RestoredAt, archive.PurgedAt, archive.ID)
+	return err
+}
+
+func (r *postgresSchoolLifecycleRepository) CreatePurgeJob(ctx context.Context, job *repository.SchoolPurgeJob) error {
+	deletedRows, err := json.Marshal(job.DeletedRows)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO school_purge_jobs (` + purgeJobColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		job.ID, job.SchoolID, job.ArchiveID, job.Status, job.TotalSteps, job.CompletedSteps, job.CurrentStep,
+		deletedRows, job.Error, job.RequestedBy, job.CreatedAt, job.StartedAt, job.FinishedAt,
+	)
+	return err
+}
+
+func (r *postgresSchoolLifecycleRepository) UpdatePurgeJob(ctx context.Context, job *repository.SchoolPurgeJob) error {
+	deletedRows, err := json.Marshal(job.DeletedRows)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE school_purge_jobs
+		SET status = $1, completed_steps = $2, current_step = $3, deleted_rows = $4, error = $5, started_at = $6, finished_at = $7
+		WHERE id = $8`
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		job.Status, job.CompletedSteps, job.CurrentStep, deletedRows, job.Error, job.StartedAt, job.FinishedAt, job.ID,
+	)
+	return err
+}
+
+func (r *postgresSchoolLifecycleRepository) FindPurgeJob(ctx context.Context, id uuid.UUID) (*repository.SchoolPurgeJob, error) {
+	query := `SELECT ` + purgeJobColumns + ` FROM school_purge_jobs WHERE id = $1`
+	return r.scanPurgeJob(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+}
+
+func (r *postgresSchoolLifecycleRepository) FindUnfinishedPurgeJob(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolPurgeJob, error) {
+	query := `SELECT ` + purgeJobColumns + ` FROM school_purge_jobs
+		WHERE school_id = $1 AND status IN ($2, $3) ORDER BY created_at DESC LIMIT 1`
+	return r.scanPurgeJob(conn(ctx, r.db).QueryRowContext(ctx, query,
+		schoolID, repository.PurgeJobStatusPending, repository.PurgeJobStatusRunning,
+	))
+}
+
+func (r *postgresSchoolLifecycleRepository) PurgeSchoolData(ctx context.Context, schoolID uuid.UUID, step string) (int64, error) {
+	queries, ok := purgeQueries[step]
+	if !ok {
+		return 0, fmt.Errorf("unknown purge step: %s", step)
+	}
+
+	var affected int64
+	for _, query := range queries {
+		result, err := conn(ctx, r.db).ExecContext(ctx, query, schoolID)
+		if err != nil {
+			return affected, err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return affected, err
+		}
+		affected = rows
+	}
+	// Se reporta lo afectado por la última sentencia (la que elimina o desvincula)
+	return affected, nil
+}
+
+func (r *postgresSchoolLifecycleRepository) scanPurgeJob(row *sql.Row) (*repository.SchoolPurgeJob, error) {
+	job := &repository.SchoolPurgeJob{}
+	var deletedRows []byte
+	err := row.Scan(
+		&job.ID, &job.SchoolID, &job.ArchiveID, &job.Status, &job.TotalSteps, &job.CompletedSteps, &job.CurrentStep,
+		&deletedRows, &job.Error, &job.RequestedBy, &job.CreatedAt, &job.StartedAt, &job.FinishedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(deletedRows) > 0 {
+		if err := json.Unmarshal(deletedRows, &job.DeletedRows); err != nil {
+			return nil, err
+		}
+	}
+	return job, nil
+}
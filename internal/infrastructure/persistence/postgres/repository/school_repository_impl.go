@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
@@ -28,7 +30,7 @@ func (r *postgresSchoolRepository) Create(ctx context.Context, school *entities.
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		school.ID,
 		school.Name,
 		school.Code,
@@ -59,7 +61,7 @@ func (r *postgresSchoolRepository) FindByID(ctx context.Context, id uuid.UUID) (
 	`
 
 	school := &entities.School{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&school.ID,
 		&school.Name,
 		&school.Code,
@@ -98,7 +100,7 @@ func (r *postgresSchoolRepository) FindByCode(ctx context.Context, code string)
 	`
 
 	school := &entities.School{}
-	err := r.db.QueryRowContext(ctx, query, code).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, code).Scan(
 		&school.ID,
 		&school.Name,
 		&school.Code,
@@ -137,7 +139,7 @@ func (r *postgresSchoolRepository) FindByName(ctx context.Context, name string)
 	`
 
 	school := &entities.School{}
-	err := r.db.QueryRowContext(ctx, query, name).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, name).Scan(
 		&school.ID,
 		&school.Name,
 		&school.Code,
@@ -175,7 +177,7 @@ func (r *postgresSchoolRepository) Update(ctx context.Context, school *entities.
 		WHERE id = $13 AND deleted_at IS NULL
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		school.Name,
 		school.Address,
 		school.City,
@@ -202,42 +204,132 @@ func (r *postgresSchoolRepository) Delete(ctx context.Context, id uuid.UUID) err
 	`
 
 	now := time.Now()
-	_, err := r.db.ExecContext(ctx, query, now, now, id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, now, now, id)
 	return err
 }
 
-func (r *postgresSchoolRepository) List(ctx context.Context, filters repository.ListFilters) ([]*entities.School, error) {
+func (r *postgresSchoolRepository) FindArchivedByID(ctx context.Context, id uuid.UUID) (*entities.School, error) {
 	query := `
 		SELECT id, name, code, address, city, country, phone, email, metadata,
 		       is_active, subscription_tier, max_teachers, max_students,
 		       created_at, updated_at, deleted_at
 		FROM schools
-		WHERE deleted_at IS NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
 	`
 
+	school := &entities.School{}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&school.ID,
+		&school.Name,
+		&school.Code,
+		&school.Address,
+		&school.City,
+		&school.Country,
+		&school.Phone,
+		&school.Email,
+		&school.Metadata,
+		&school.IsActive,
+		&school.SubscriptionTier,
+		&school.MaxTeachers,
+		&school.MaxStudents,
+		&school.CreatedAt,
+		&school.UpdatedAt,
+		&school.DeletedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return school, nil
+}
+
+func (r *postgresSchoolRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE schools
+		SET deleted_at = NULL, is_active = true, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NOT NULL
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, time.Now(), id)
+	return err
+}
+
+// schoolSortColumns mapea los campos de orden permitidos a columnas (evita inyección en ORDER BY)
+var schoolSortColumns = map[string]string{
+	repository.SchoolSortName:      "name",
+	repository.SchoolSortCode:      "code",
+	repository.SchoolSortCountry:   "country",
+	repository.SchoolSortCity:      "city",
+	repository.SchoolSortTier:      "subscription_tier",
+	repository.SchoolSortCreatedAt: "created_at",
+	repository.SchoolSortUpdatedAt: "updated_at",
+}
+
+// likeEscaper escapa los comodines de LIKE para buscar el texto literal
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// schoolWhere construye el WHERE de la búsqueda de escuelas y sus argumentos
+func schoolWhere(filters repository.SchoolFilters) (string, []interface{}) {
+	where := ` WHERE deleted_at IS NULL`
 	args := []interface{}{}
-	argCount := 1
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return `$` + strconv.Itoa(len(args))
+	}
 
+	if filters.Search != "" {
+		p := arg("%" + likeEscaper.Replace(filters.Search) + "%")
+		where += ` AND (name ILIKE ` + p + ` OR code ILIKE ` + p + `)`
+	}
+	if filters.Country != "" {
+		where += ` AND country = ` + arg(filters.Country)
+	}
+	if filters.City != "" {
+		where += ` AND LOWER(city) = LOWER(` + arg(filters.City) + `)`
+	}
+	if filters.SubscriptionTier != "" {
+		where += ` AND subscription_tier = ` + arg(filters.SubscriptionTier)
+	}
 	if filters.IsActive != nil {
-		query += ` AND is_active = $` + string(rune(argCount+'0'))
-		args = append(args, *filters.IsActive)
-		argCount++
+		where += ` AND is_active = ` + arg(*filters.IsActive)
 	}
+	return where, args
+}
 
-	query += ` ORDER BY created_at DESC`
+func (r *postgresSchoolRepository) List(ctx context.Context, filters repository.SchoolFilters) ([]*entities.School, error) {
+	where, args := schoolWhere(filters)
+	query := `
+		SELECT id, name, code, address, city, country, phone, email, metadata,
+		       is_active, subscription_tier, max_teachers, max_students,
+		       created_at, updated_at, deleted_at
+		FROM schools` + where
+
+	column, ok := schoolSortColumns[filters.SortBy]
+	if !ok {
+		column = "created_at"
+	}
+	direction := " ASC"
+	if filters.SortDesc {
+		direction = " DESC"
+	}
+	// id como desempate para que la paginación sea estable
+	query += ` ORDER BY ` + column + direction + `, id`
 
 	if filters.Limit > 0 {
-		query += ` LIMIT $` + string(rune(argCount+'0'))
 		args = append(args, filters.Limit)
-		argCount++
+		query += ` LIMIT $` + strconv.Itoa(len(args))
 	}
 
 	if filters.Offset > 0 {
-		query += ` OFFSET $` + string(rune(argCount+'0'))
 		args = append(args, filters.Offset)
+		query += ` OFFSET $` + strconv.Itoa(len(args))
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -273,16 +365,23 @@ func (r *postgresSchoolRepository) List(ctx context.Context, filters repository.
 	return schools, rows.Err()
 }
 
+func (r *postgresSchoolRepository) Count(ctx context.Context, filters repository.SchoolFilters) (int, error) {
+	where, args := schoolWhere(filters)
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM schools`+where, args...).Scan(&count)
+	return count, err
+}
+
 func (r *postgresSchoolRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM schools WHERE name = $1 AND deleted_at IS NULL)`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, name).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, name).Scan(&exists)
 	return exists, err
 }
 
 func (r *postgresSchoolRepository) ExistsByCode(ctx context.Context, code string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM schools WHERE code = $1 AND deleted_at IS NULL)`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, code).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, code).Scan(&exists)
 	return exists, err
 }
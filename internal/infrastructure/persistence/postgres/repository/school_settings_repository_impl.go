@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresSchoolSettingsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSchoolSettingsRepository crea un nuevo repository de PostgreSQL
+func NewPostgresSchoolSettingsRepository(db *sql.DB) repository.SchoolSettingsRepository {
+	return &postgresSchoolSettingsRepository{db: db}
+}
+
+func (r *postgresSchoolSettingsRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolSettings, error) {
+	query := `SELECT school_id, schema_version, version, overrides, updated_by, created_at, updated_at
+		FROM school_settings WHERE school_id = $1`
+	settings := &repository.SchoolSettings{}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, schoolID).Scan(
+		&settings.SchoolID, &settings.SchemaVersion, &settings.Version, &settings.Overrides,
+		&settings.UpdatedBy, &settings.CreatedAt, &settings.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (r *postgresSchoolSettingsRepository) Save(ctx context.Context, settings *repository.SchoolSettings, expectedVersion int) (bool, error) {
+	var (
+		result sql.Result
+		err    error
+	)
+	if expectedVersion == 0 {
+		query := `INSERT INTO school_settings (school_id, schema_version, version, overrides, updated_by, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (school_id) DO NOTHING`
+		result, err = conn(ctx, r.db).ExecContext(ctx, query,
+			settings.SchoolID, settings.SchemaVersion, settings.Version, []byte(settings.Overrides),
+			settings.UpdatedBy, settings.CreatedAt, settings.UpdatedAt,
+		)
+	} else {
+		query := `UPDATE school_settings
+			SET schema_version = $2, version = $3, overrides = $4, updated_by = $5, updated_at = $6
+			WHERE school_id = $1 AND version = $7`
+		result, err = conn(ctx, r.db).ExecContext(ctx, query,
+			settings.SchoolID, settings.SchemaVersion, settings.Version, []byte(settings.Overrides),
+			settings.UpdatedBy, settings.UpdatedAt, expectedVersion,
+		)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+func (r *postgresSchoolSettingsRepository) CreateChange(ctx context.Context, change *repository.SchoolSettingsChange) error {
+	query := `INSERT INTO school_settings_history (id, school_id, version, patch, overrides, reason, changed_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		change.ID, change.SchoolID, change.Version, []byte(change.Patch), []byte(change.Overrides),
+		change.Reason, change.ChangedBy, change.CreatedAt,
+	)
+	return err
+}
+
+func (r *postgresSchoolSettingsRepository) ListChanges(ctx context.Context, schoolID uuid.UUID) ([]*repository.SchoolSettingsChange, error) {
+	query := `SELECT id, school_id, version, patch, overrides, reason, changed_by, created_at
+		FROM school_settings_history WHERE school_id = $1 ORDER BY version DESC`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var changes []*repository.SchoolSettingsChange
+	for rows.Next() {
+		change := &repository.SchoolSettingsChange{}
+		if err := rows.Scan(
+			&change.ID, &change.SchoolID, &change.Version, &change.Patch, &change.Overrides,
+			&change.Reason, &change.ChangedBy, &change.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
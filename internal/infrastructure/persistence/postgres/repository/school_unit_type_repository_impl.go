@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresSchoolUnitTypeRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSchoolUnitTypeRepository crea un nuevo repository de PostgreSQL
+func NewPostgresSchoolUnitTypeRepository(db *sql.DB) repository.SchoolUnitTypeRepository {
+	return &postgresSchoolUnitTypeRepository{db: db}
+}
+
+const schoolUnitTypeColumns = `id, school_id, code, label, icon, allowed_roles, parent_types, child_types, can_be_root, created_by, created_at, updated_at`
+
+func (r *postgresSchoolUnitTypeRepository) Create(ctx context.Context, unitType *repository.SchoolUnitType) error {
+	roles, parents, children, err := marshalSchoolUnitTypeLists(unitType)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO school_unit_types (` + schoolUnitTypeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		unitType.ID, unitType.SchoolID, unitType.Code, unitType.Label, unitType.Icon,
+		roles, parents, children, unitType.CanBeRoot,
+		unitType.CreatedBy, unitType.CreatedAt, unitType.UpdatedAt,
+	)
+	return err
+}
+
+func (r *postgresSchoolUnitTypeRepository) Update(ctx context.Context, unitType *repository.SchoolUnitType) error {
+	roles, parents, children, err := marshalSchoolUnitTypeLists(unitType)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE school_unit_types
+		SET label = $1, icon = $2, allowed_roles = $3, parent_types = $4, child_types = $5, can_be_root = $6, updated_at = $7
+		WHERE id = $8`
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		unitType.Label, unitType.Icon, roles, parents, children, unitType.CanBeRoot, unitType.UpdatedAt, unitType.ID,
+	)
+	return err
+}
+
+func (r *postgresSchoolUnitTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM school_unit_types WHERE id = $1`, id)
+	return err
+}
+
+func (r *postgresSchoolUnitTypeRepository) FindByCode(ctx context.Context, schoolID uuid.UUID, code string) (*repository.SchoolUnitType, error) {
+	query := `SELECT ` + schoolUnitTypeColumns + ` FROM school_unit_types WHERE school_id = $1 AND code = $2`
+	unitType, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, schoolID, code))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return unitType, err
+}
+
+func (r *postgresSchoolUnitTypeRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*repository.SchoolUnitType, error) {
+	query := `SELECT ` + schoolUnitTypeColumns + ` FROM school_unit_types WHERE school_id = $1 ORDER BY code`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var unitTypes []*repository.SchoolUnitType
+	for rows.Next() {
+		unitType, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		unitTypes = append(unitTypes, unitType)
+	}
+	return unitTypes, rows.Err()
+}
+
+func (r *postgresSchoolUnitTypeRepository) scan(row rowScanner) (*repository.SchoolUnitType, error) {
+	unitType := &repository.SchoolUnitType{}
+	var roles, parents, children []byte
+	if err := row.Scan(
+		&unitType.ID, &unitType.SchoolID, &unitType.Code, &unitType.Label, &unitType.Icon,
+		&roles, &parents, &children, &unitType.CanBeRoot,
+		&unitType.CreatedBy, &unitType.CreatedAt, &unitType.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, list := range []struct {
+		raw    []byte
+		target *[]string
+	}{{roles, &unitType.AllowedRoles}, {parents, &unitType.ParentTypes}, {children, &unitType.ChildTypes}} {
+		if len(list.raw) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(list.raw, list.target); err != nil {
+			return nil, err
+		}
+	}
+	return unitType, nil
+}
+
+// marshalSchoolUnitTypeLists serializa las listas como arreglos JSON (nunca null)
+func marshalSchoolUnitTypeLists(unitType *repository.SchoolUnitType) (roles, parents, children []byte, err error) {
+	lists := make([][]byte, 0, 3)
+	for _, list := range [][]string{unitType.AllowedRoles, unitType.ParentTypes, unitType.ChildTypes} {
+		if list == nil {
+			list = []string{}
+		}
+		raw, err := json.Marshal(list)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		lists = append(lists, raw)
+	}
+	return lists[0], lists[1], lists[2], nil
+}
@@ -28,7 +28,7 @@ func (r *postgresStatsRepository) GetGlobalStats(ctx context.Context) (repositor
 			(SELECT COUNT(*) FROM guardian_relations WHERE is_active = true) AS total_guardian_relations
 	`
 
-	err := r.db.QueryRowContext(ctx, query).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query).Scan(
 		&stats.TotalUsers,
 		&stats.TotalActiveUsers,
 		&stats.TotalSchools,
@@ -23,7 +23,7 @@ func (r *postgresSubjectRepository) Create(ctx context.Context, subject *entitie
 		INSERT INTO subjects (id, name, description, metadata, is_active, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		subject.ID, subject.Name, subject.Description, subject.Metadata,
 		subject.IsActive, subject.CreatedAt, subject.UpdatedAt,
 	)
@@ -36,7 +36,7 @@ func (r *postgresSubjectRepository) FindByID(ctx context.Context, id uuid.UUID)
 		FROM subjects WHERE id = $1
 	`
 	subject := &entities.Subject{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&subject.ID, &subject.Name, &subject.Description, &subject.Metadata,
 		&subject.IsActive, &subject.CreatedAt, &subject.UpdatedAt,
 	)
@@ -51,7 +51,7 @@ func (r *postgresSubjectRepository) Update(ctx context.Context, subject *entitie
 		UPDATE subjects SET name = $1, description = $2, metadata = $3,
 		       is_active = $4, updated_at = $5 WHERE id = $6
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		subject.Name, subject.Description, subject.Metadata,
 		subject.IsActive, subject.UpdatedAt, subject.ID,
 	)
@@ -60,7 +60,7 @@ func (r *postgresSubjectRepository) Update(ctx context.Context, subject *entitie
 
 func (r *postgresSubjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `UPDATE subjects SET is_active = false, updated_at = $1 WHERE id = $2`
-	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, time.Now(), id)
 	return err
 }
 
@@ -69,7 +69,7 @@ func (r *postgresSubjectRepository) List(ctx context.Context) ([]*entities.Subje
 		SELECT id, name, description, metadata, is_active, created_at, updated_at
 		FROM subjects WHERE is_active = true ORDER BY name
 	`
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +99,7 @@ func (r *postgresSubjectRepository) FindBySchoolID(ctx context.Context, schoolID
 		SELECT id, name, description, metadata, is_active, created_at, updated_at
 		FROM subjects WHERE is_active = true ORDER BY name
 	`
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +123,6 @@ func (r *postgresSubjectRepository) FindBySchoolID(ctx context.Context, schoolID
 func (r *postgresSubjectRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM subjects WHERE name = $1 AND is_active = true)`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, name).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, name).Scan(&exists)
 	return exists, err
 }
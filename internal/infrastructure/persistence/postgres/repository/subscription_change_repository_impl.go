@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresSubscriptionChangeRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSubscriptionChangeRepository crea un nuevo repository de PostgreSQL
+func NewPostgresSubscriptionChangeRepository(db *sql.DB) repository.SubscriptionChangeRepository {
+	return &postgresSubscriptionChangeRepository{db: db}
+}
+
+func (r *postgresSubscriptionChangeRepository) Create(ctx context.Context, change *repository.SubscriptionChange) error {
+	query := `INSERT INTO school_subscription_changes (id, school_id, from_tier, to_tier, direction, reason, performed_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		change.ID, change.SchoolID, change.FromTier, change.ToTier, change.Direction,
+		change.Reason, change.PerformedBy, change.CreatedAt,
+	)
+	return err
+}
+
+func (r *postgresSubscriptionChangeRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID) ([]*repository.SubscriptionChange, error) {
+	query := `SELECT id, school_id, from_tier, to_tier, direction, reason, performed_by, created_at
+		FROM school_subscription_changes WHERE school_id = $1 ORDER BY created_at DESC`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var changes []*repository.SubscriptionChange
+	for rows.Next() {
+		change := &repository.SubscriptionChange{}
+		if err := rows.Scan(
+			&change.ID, &change.SchoolID, &change.FromTier, &change.ToTier, &change.Direction,
+			&change.Reason, &change.PerformedBy, &change.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+)
+
+// txKey es la clave del contexto donde se guarda la transacción activa
+type txKey struct{}
+
+// dbConn abstrae *sql.DB y *sql.Tx
+type dbConn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn retorna la transacción activa del contexto o, si no hay, la conexión base
+func conn(ctx context.Context, db *sql.DB) dbConn {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// advisoryXactLock toma el advisory lock de key hasta el fin de la transacción del contexto.
+// Sin transacción el lock se liberaría al terminar la sentencia, por lo que se rechaza.
+func advisoryXactLock(ctx context.Context, key string) error {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		return fmt.Errorf("advisory lock %q requires an active transaction", key)
+	}
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, key)
+	return err
+}
+
+type postgresTransactionManager struct {
+	db *sql.DB
+}
+
+// NewPostgresTransactionManager crea un TransactionManager sobre PostgreSQL
+func NewPostgresTransactionManager(db *sql.DB) repository.TransactionManager {
+	return &postgresTransactionManager{db: db}
+}
+
+func (m *postgresTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Transacción anidada: reutilizar la existente
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	// Un panic dentro de fn revierte la transacción antes de propagarse
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type postgresUnitCapacityRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUnitCapacityRepository crea un nuevo repository de PostgreSQL
+func NewPostgresUnitCapacityRepository(db *sql.DB) repository.UnitCapacityRepository {
+	return &postgresUnitCapacityRepository{db: db}
+}
+
+const unitCapacityColumns = `unit_id, school_id, max_students, waitlist_enabled, updated_at`
+
+const unitWaitlistColumns = `id, unit_id, school_id, user_id, status, membership_id, requested_at, resolved_at`
+
+func (r *postgresUnitCapacityRepository) FindCapacity(ctx context.Context, unitID uuid.UUID) (*repository.UnitCapacity, error) {
+	query := `SELECT ` + unitCapacityColumns + ` FROM unit_capacities WHERE unit_id = $1`
+	capacity, err := r.scanCapacity(conn(ctx, r.db).QueryRowContext(ctx, query, unitID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return capacity, err
+}
+
+func (r *postgresUnitCapacityRepository) FindCapacities(ctx context.Context, unitIDs []uuid.UUID) (map[uuid.UUID]*repository.UnitCapacity, error) {
+	capacities := make(map[uuid.UUID]*repository.UnitCapacity)
+	if len(unitIDs) == 0 {
+		return capacities, nil
+	}
+
+	ids := make([]string, len(unitIDs))
+	for i, id := range unitIDs {
+		ids[i] = id.String()
+	}
+
+	query := `SELECT ` + unitCapacityColumns + ` FROM unit_capacities WHERE unit_id = ANY($1::uuid[])`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		capacity, err := r.scanCapacity(rows)
+		if err != nil {
+			return nil, err
+		}
+		capacities[capacity.UnitID] = capacity
+	}
+	return capacities, rows.Err()
+}
+
+func (r *postgresUnitCapacityRepository) SaveCapacity(ctx context.Context, capacity *repository.UnitCapacity) error {
+	query := `INSERT INTO unit_capacities (` + unitCapacityColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (unit_id) DO UPDATE SET
+			max_students = EXCLUDED.max_students,
+			waitlist_enabled = EXCLUDED.waitlist_enabled,
+			updated_at = EXCLUDED.updated_at`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		capacity.UnitID, capacity.SchoolID, capacity.MaxStudents, capacity.WaitlistEnabled, capacity.UpdatedAt,
+	)
+	return err
+}
+
+func (r *postgresUnitCapacityRepository) DeleteCapacity(ctx context.Context, unitID uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM unit_capacities WHERE unit_id = $1`, unitID)
+	return err
+}
+
+func (r *postgresUnitCapacityRepository) LockUnit(ctx context.Context, unitID uuid.UUID) error {
+	return advisoryXactLock(ctx, "unit_capacities:"+unitID.String())
+}
+
+func (r *postgresUnitCapacityRepository) CreateWaitlistEntry(ctx context.Context, entry *repository.UnitWaitlistEntry) error {
+	query := `INSERT INTO unit_waitlist_entries (` + unitWaitlistColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		entry.ID, entry.UnitID, entry.SchoolID, entry.UserID, entry.Status,
+		entry.MembershipID, entry.RequestedAt, entry.ResolvedAt,
+	)
+	return err
+}
+
+func (r *postgresUnitCapacityRepository) UpdateWaitlistEntry(ctx context.Context, entry *repository.UnitWaitlistEntry) error {
+	query := `UPDATE unit_waitlist_entries SET status = $1, membership_id = $2, resolved_at = $3 WHERE id = $4`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, entry.Status, entry.MembershipID, entry.ResolvedAt, entry.ID)
+	return err
+}
+
+func (r *postgresUnitCapacityRepository) FindWaitlistEntry(ctx context.Context, id uuid.UUID) (*repository.UnitWaitlistEntry, error) {
+	query := `SELECT ` + unitWaitlistColumns + ` FROM unit_waitlist_entries WHERE id = $1`
+	entry, err := r.scanWaitlistEntry(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return entry, err
+}
+
+func (r *postgresUnitCapacityRepository) ListWaiting(ctx context.Context, unitID uuid.UUID) ([]*repository.UnitWaitlistEntry, error) {
+	query := `SELECT ` + unitWaitlistColumns + ` FROM unit_waitlist_entries
+		WHERE unit_id = $1 AND status = $2 ORDER BY requested_at, id`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, unitID, repository.UnitWaitlistWaiting)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []*repository.UnitWaitlistEntry
+	for rows.Next() {
+		entry, err := r.scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (r *postgresUnitCapacityRepository) scanCapacity(row rowScanner) (*repository.UnitCapacity, error) {
+	capacity := &repository.UnitCapacity{}
+	err := row.Scan(&capacity.UnitID, &capacity.SchoolID, &capacity.MaxStudents, &capacity.WaitlistEnabled, &capacity.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return capacity, nil
+}
+
+func (r *postgresUnitCapacityRepository) scanWaitlistEntry(row rowScanner) (*repository.UnitWaitlistEntry, error) {
+	entry := &repository.UnitWaitlistEntry{}
+	err := row.Scan(
+		&entry.ID, &entry.UnitID, &entry.SchoolID, &entry.UserID, &entry.Status,
+		&entry.MembershipID, &entry.RequestedAt, &entry.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+const unitDeletionBatchColumns = `id, school_id, root_unit_id, units_deleted, memberships_suspended,
+	deleted_by, deleted_at, restored_by, restored_at`
+
+type postgresUnitDeletionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUnitDeletionRepository crea un nuevo repository de PostgreSQL
+func NewPostgresUnitDeletionRepository(db *sql.DB) repository.UnitDeletionRepository {
+	return &postgresUnitDeletionRepository{db: db}
+}
+
+func (r *postgresUnitDeletionRepository) CreateBatch(ctx context.Context, batch *repository.UnitDeletionBatch, items []repository.UnitDeletionItem) error {
+	query := `INSERT INTO unit_deletion_batches (` + unitDeletionBatchColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query,
+		batch.ID, batch.SchoolID, batch.RootUnitID, batch.UnitsDeleted, batch.MembershipsSuspended,
+		batch.DeletedBy, batch.DeletedAt, batch.RestoredBy, batch.RestoredAt,
+	); err != nil {
+		return err
+	}
+
+	itemQuery := `INSERT INTO unit_deletion_items (batch_id, entity_type, entity_id) VALUES ($1, $2, $3)`
+	for _, item := range items {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, itemQuery, batch.ID, item.EntityType, item.EntityID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresUnitDeletionRepository) FindBatch(ctx context.Context, id uuid.UUID) (*repository.UnitDeletionBatch, error) {
+	query := `SELECT ` + unitDeletionBatchColumns + ` FROM unit_deletion_batches WHERE id = $1`
+	return r.findOne(ctx, query, id)
+}
+
+func (r *postgresUnitDeletionRepository) FindOpenBatchByUnit(ctx context.Context, unitID uuid.UUID) (*repository.UnitDeletionBatch, error) {
+	query := `SELECT ` + unitDeletionBatchColumns + ` FROM unit_deletion_batches
+		WHERE restored_at IS NULL AND id IN (
+			SELECT batch_id FROM unit_deletion_items WHERE entity_type = $1 AND entity_id = $2
+		)
+		ORDER BY deleted_at DESC LIMIT 1`
+	return r.findOne(ctx, query, repository.UnitDeletionItemUnit, unitID)
+}
+
+func (r *postgresUnitDeletionRepository) ListItems(ctx context.Context, batchID uuid.UUID) ([]repository.UnitDeletionItem, error) {
+	query := `SELECT batch_id, entity_type, entity_id FROM unit_deletion_items WHERE batch_id = $1 ORDER BY entity_type, entity_id`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var items []repository.UnitDeletionItem
+	for rows.Next() {
+		var item repository.UnitDeletionItem
+		if err := rows.Scan(&item.BatchID, &item.EntityType, &item.EntityID); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (r *postgresUnitDeletionRepository) UpdateBatch(ctx context.Context, batch *repository.UnitDeletionBatch) error {
+	query := `UPDATE unit_deletion_batches SET restored_by = $1, restored_at = $2 WHERE id = $3`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, batch.RestoredBy, batch.RestoredAt, batch.ID)
+	return err
+}
+
+func (r *postgresUnitDeletionRepository) findOne(ctx context.Context, query string, args ...interface{}) (*repository.UnitDeletionBatch, error) {
+	batch := &repository.UnitDeletionBatch{}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(
+		&batch.ID, &batch.SchoolID, &batch.RootUnitID, &batch.UnitsDeleted, &batch.MembershipsSuspended,
+		&batch.DeletedBy, &batch.DeletedAt, &batch.RestoredBy, &batch.RestoredAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
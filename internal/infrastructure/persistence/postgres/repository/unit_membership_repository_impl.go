@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strconv"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type postgresUnitMembershipRepository struct {
@@ -21,7 +23,7 @@ func NewPostgresUnitMembershipRepository(db *sql.DB) repository.UnitMembershipRe
 func (r *postgresUnitMembershipRepository) Create(ctx context.Context, membership *entities.Membership) error {
 	query := `INSERT INTO memberships (id, user_id, school_id, academic_unit_id, role, metadata, is_active, enrolled_at, withdrawn_at, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		membership.ID, membership.UserID, membership.SchoolID, membership.AcademicUnitID,
 		membership.Role, membership.Metadata, membership.IsActive, membership.EnrolledAt,
 		membership.WithdrawnAt, membership.CreatedAt, membership.UpdatedAt,
@@ -33,7 +35,7 @@ func (r *postgresUnitMembershipRepository) FindByID(ctx context.Context, id uuid
 	query := `SELECT id, user_id, school_id, academic_unit_id, role, metadata, is_active, enrolled_at, withdrawn_at, created_at, updated_at
 		FROM memberships WHERE id = $1`
 	membership := &entities.Membership{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&membership.ID, &membership.UserID, &membership.SchoolID, &membership.AcademicUnitID,
 		&membership.Role, &membership.Metadata, &membership.IsActive, &membership.EnrolledAt,
 		&membership.WithdrawnAt, &membership.CreatedAt, &membership.UpdatedAt,
@@ -48,7 +50,7 @@ func (r *postgresUnitMembershipRepository) FindByUserAndUnit(ctx context.Context
 	query := `SELECT id, user_id, school_id, academic_unit_id, role, metadata, is_active, enrolled_at, withdrawn_at, created_at, updated_at
 		FROM memberships WHERE user_id = $1 AND academic_unit_id = $2 AND is_active = true`
 	membership := &entities.Membership{}
-	err := r.db.QueryRowContext(ctx, query, userID, unitID).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID, unitID).Scan(
 		&membership.ID, &membership.UserID, &membership.SchoolID, &membership.AcademicUnitID,
 		&membership.Role, &membership.Metadata, &membership.IsActive, &membership.EnrolledAt,
 		&membership.WithdrawnAt, &membership.CreatedAt, &membership.UpdatedAt,
@@ -84,7 +86,7 @@ func (r *postgresUnitMembershipRepository) FindByUnitAndRole(ctx context.Context
 }
 
 func (r *postgresUnitMembershipRepository) scanMembershipsWithRole(ctx context.Context, query string, unitID uuid.UUID, role string) ([]*entities.Membership, error) {
-	rows, err := r.db.QueryContext(ctx, query, unitID, role)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, unitID, role)
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +110,7 @@ func (r *postgresUnitMembershipRepository) scanMembershipsWithRole(ctx context.C
 
 func (r *postgresUnitMembershipRepository) Update(ctx context.Context, membership *entities.Membership) error {
 	query := `UPDATE memberships SET role = $1, metadata = $2, is_active = $3, withdrawn_at = $4, updated_at = $5 WHERE id = $6`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		membership.Role, membership.Metadata, membership.IsActive,
 		membership.WithdrawnAt, membership.UpdatedAt, membership.ID,
 	)
@@ -118,12 +120,12 @@ func (r *postgresUnitMembershipRepository) Update(ctx context.Context, membershi
 func (r *postgresUnitMembershipRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	now := time.Now()
 	query := `UPDATE memberships SET is_active = false, withdrawn_at = $1, updated_at = $2 WHERE id = $3`
-	_, err := r.db.ExecContext(ctx, query, now, now, id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, now, now, id)
 	return err
 }
 
-func (r *postgresUnitMembershipRepository) scanMemberships(ctx context.Context, query string, id uuid.UUID) ([]*entities.Membership, error) {
-	rows, err := r.db.QueryContext(ctx, query, id)
+func (r *postgresUnitMembershipRepository) scanMemberships(ctx context.Context, query string, args ...interface{}) ([]*entities.Membership, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +150,7 @@ func (r *postgresUnitMembershipRepository) scanMemberships(ctx context.Context,
 func (r *postgresUnitMembershipRepository) ExistsByUnitAndUser(ctx context.Context, unitID, userID uuid.UUID) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM memberships WHERE academic_unit_id = $1 AND user_id = $2 AND is_active = true)`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, unitID, userID).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, unitID, userID).Scan(&exists)
 	return exists, err
 }
 
@@ -156,7 +158,7 @@ func (r *postgresUnitMembershipRepository) FindByUserAndSchool(ctx context.Conte
 	query := `SELECT id, user_id, school_id, academic_unit_id, role, metadata, is_active, enrolled_at, withdrawn_at, created_at, updated_at
 		FROM memberships WHERE user_id = $1 AND school_id = $2 AND is_active = true LIMIT 1`
 	membership := &entities.Membership{}
-	err := r.db.QueryRowContext(ctx, query, userID, schoolID).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID, schoolID).Scan(
 		&membership.ID, &membership.UserID, &membership.SchoolID, &membership.AcademicUnitID,
 		&membership.Role, &membership.Metadata, &membership.IsActive, &membership.EnrolledAt,
 		&membership.WithdrawnAt, &membership.CreatedAt, &membership.UpdatedAt,
@@ -166,3 +168,63 @@ func (r *postgresUnitMembershipRepository) FindByUserAndSchool(ctx context.Conte
 	}
 	return membership, err
 }
+
+func (r *postgresUnitMembershipRepository) ReassignUser(ctx context.Context, membershipID, userID uuid.UUID) error {
+	query := `UPDATE memberships SET user_id = $1, updated_at = $2 WHERE id = $3`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, userID, time.Now(), membershipID)
+	return err
+}
+
+func (r *postgresUnitMembershipRepository) FindActiveBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entities.Membership, error) {
+	query := `SELECT id, user_id, school_id, academic_unit_id, role, metadata, is_active, enrolled_at, withdrawn_at, created_at, updated_at
+		FROM memberships WHERE school_id = $1 AND is_active = true ORDER BY enrolled_at DESC`
+	return r.scanMemberships(ctx, query, schoolID)
+}
+
+func (r *postgresUnitMembershipRepository) FindActiveByUnits(ctx context.Context, unitIDs []uuid.UUID) ([]*entities.Membership, error) {
+	if len(unitIDs) == 0 {
+		return nil, nil
+	}
+	query := `SELECT id, user_id, school_id, academic_unit_id, role, metadata, is_active, enrolled_at, withdrawn_at, created_at, updated_at
+		FROM memberships WHERE academic_unit_id = ANY($1) AND is_active = true ORDER BY enrolled_at DESC`
+	return r.scanMemberships(ctx, query, pq.Array(unitIDs))
+}
+
+func (r *postgresUnitMembershipRepository) LockSchoolQuota(ctx context.Context, schoolID uuid.UUID) error {
+	return advisoryXactLock(ctx, "school_quota:"+schoolID.String())
+}
+
+func (r *postgresUnitMembershipRepository) CountActiveUsersBySchoolAndRole(ctx context.Context, schoolID uuid.UUID, role string) (int, error) {
+	query := `SELECT COUNT(DISTINCT user_id) FROM memberships
+		WHERE school_id = $1 AND role = $2 AND is_active = true AND (withdrawn_at IS NULL OR withdrawn_at > NOW())`
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, schoolID, role).Scan(&count)
+	return count, err
+}
+
+func (r *postgresUnitMembershipRepository) ListByUnit(ctx context.Context, unitID uuid.UUID, filters repository.MembershipListFilters) ([]*entities.Membership, error) {
+	query := `SELECT id, user_id, school_id, academic_unit_id, role, metadata, is_active, enrolled_at, withdrawn_at, created_at, updated_at
+		FROM memberships WHERE academic_unit_id = $1`
+	args := []interface{}{unitID}
+
+	if filters.Role != "" {
+		args = append(args, filters.Role)
+		query += ` AND role = $` + strconv.Itoa(len(args))
+	}
+	if filters.ActiveOnly {
+		query += ` AND is_active = true AND withdrawn_at IS NULL`
+	}
+
+	// id como desempate para que la paginación sea estable
+	query += ` ORDER BY enrolled_at DESC, id`
+
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += ` LIMIT $` + strconv.Itoa(len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += ` OFFSET $` + strconv.Itoa(len(args))
+	}
+	return r.scanMemberships(ctx, query, args...)
+}
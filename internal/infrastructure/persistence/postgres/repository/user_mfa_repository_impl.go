@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresUserMFARepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserMFARepository crea un nuevo repository de PostgreSQL
+func NewPostgresUserMFARepository(db *sql.DB) repository.UserMFARepository {
+	return &postgresUserMFARepository{db: db}
+}
+
+func (r *postgresUserMFARepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*repository.UserMFA, error) {
+	query := `SELECT user_id, secret, enabled_at, last_used_at, last_step, failed_attempts, locked_until,
+		       created_at, updated_at
+		FROM user_mfa WHERE user_id = $1`
+	mfa := &repository.UserMFA{}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(
+		&mfa.UserID, &mfa.Secret, &mfa.EnabledAt, &mfa.LastUsedAt, &mfa.LastStep, &mfa.FailedAttempts, &mfa.LockedUntil,
+		&mfa.CreatedAt, &mfa.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mfa, nil
+}
+
+func (r *postgresUserMFARepository) Upsert(ctx context.Context, mfa *repository.UserMFA) error {
+	query := `INSERT INTO user_mfa (user_id, secret, enabled_at, last_used_at, last_step, failed_attempts, locked_until,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = EXCLUDED.secret,
+			enabled_at = EXCLUDED.enabled_at,
+			last_used_at = EXCLUDED.last_used_at,
+			last_step = EXCLUDED.last_step,
+			failed_attempts = EXCLUDED.failed_attempts,
+			locked_until = EXCLUDED.locked_until,
+			updated_at = EXCLUDED.updated_at`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		mfa.UserID, mfa.Secret, mfa.EnabledAt, mfa.LastUsedAt, mfa.LastStep, mfa.FailedAttempts, mfa.LockedUntil,
+		mfa.CreatedAt, mfa.UpdatedAt,
+	)
+	return err
+}
+
+// ConsumeStep avanza last_step solo si el periodo es posterior: dos requests concurrentes con el
+// mismo código no pueden ser aceptadas las dos
+func (r *postgresUserMFARepository) ConsumeStep(ctx context.Context, userID uuid.UUID, step int64, usedAt time.Time) (bool, error) {
+	query := `UPDATE user_mfa
+		SET last_step = $2, last_used_at = $3, failed_attempts = 0, locked_until = NULL, updated_at = $3
+		WHERE user_id = $1 AND last_step < $2`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, userID, step, usedAt)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+func (r *postgresUserMFARepository) RecordFailedAttempt(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil, at time.Time) (bool, error) {
+	query := `UPDATE user_mfa SET
+			failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END,
+			updated_at = $4
+		WHERE user_id = $1
+		RETURNING COALESCE(locked_until = $3, false)`
+	var locked bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID, maxAttempts, lockedUntil, at).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return locked, err
+}
+
+func (r *postgresUserMFARepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID)
+	return err
+}
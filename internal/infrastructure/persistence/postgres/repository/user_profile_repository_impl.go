@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type postgresUserProfileRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserProfileRepository crea un nuevo repository de PostgreSQL
+func NewPostgresUserProfileRepository(db *sql.DB) repository.UserProfileRepository {
+	return &postgresUserProfileRepository{db: db}
+}
+
+const userProfileColumns = `user_id, phone, preferred_language, timezone, date_of_birth, avatar_url,
+	notification_preferences, created_at, updated_at`
+
+func (r *postgresUserProfileRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*repository.UserProfile, error) {
+	query := `SELECT ` + userProfileColumns + ` FROM user_profiles WHERE user_id = $1`
+	profile, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return profile, err
+}
+
+func (r *postgresUserProfileRepository) FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*repository.UserProfile, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	query := `SELECT ` + userProfileColumns + ` FROM user_profiles WHERE user_id = ANY($1::uuid[])`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var profiles []*repository.UserProfile
+	for rows.Next() {
+		profile, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+func (r *postgresUserProfileRepository) Upsert(ctx context.Context, profile *repository.UserProfile) error {
+	preferences, err := json.Marshal(profile.NotificationPreferences)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO user_profiles (` + userProfileColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id) DO UPDATE SET
+			phone = EXCLUDED.phone,
+			preferred_language = EXCLUDED.preferred_language,
+			timezone = EXCLUDED.timezone,
+			date_of_birth = EXCLUDED.date_of_birth,
+			avatar_url = EXCLUDED.avatar_url,
+			notification_preferences = EXCLUDED.notification_preferences,
+			updated_at = EXCLUDED.updated_at`
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		profile.UserID, profile.Phone, profile.PreferredLanguage, profile.Timezone,
+		profile.DateOfBirth, profile.AvatarURL, preferences, profile.CreatedAt, profile.UpdatedAt,
+	)
+	return err
+}
+
+func (r *postgresUserProfileRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM user_profiles WHERE user_id = $1`, userID)
+	return err
+}
+
+func (r *postgresUserProfileRepository) scan(row rowScanner) (*repository.UserProfile, error) {
+	profile := &repository.UserProfile{}
+	var preferences []byte
+	if err := row.Scan(
+		&profile.UserID, &profile.Phone, &profile.PreferredLanguage, &profile.Timezone,
+		&profile.DateOfBirth, &profile.AvatarURL, &preferences, &profile.CreatedAt, &profile.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(preferences) > 0 {
+		if err := json.Unmarshal(preferences, &profile.NotificationPreferences); err != nil {
+			return nil, err
+		}
+	}
+	return profile, nil
+}
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strconv"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // postgresUserRepository implementa repository.UserRepository para PostgreSQL
@@ -29,7 +31,7 @@ func (r *postgresUserRepository) Create(ctx context.Context, user *entities.User
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		user.ID,
 		user.Email,
 		user.PasswordHash,
@@ -58,7 +60,7 @@ func (r *postgresUserRepository) FindByID(
 	`
 
 	user := &entities.User{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
@@ -95,7 +97,7 @@ func (r *postgresUserRepository) FindByEmail(
 	`
 
 	user := &entities.User{}
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, email).Scan(
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
@@ -128,7 +130,7 @@ func (r *postgresUserRepository) Update(ctx context.Context, user *entities.User
 		WHERE id = $7 AND deleted_at IS NULL
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		user.FirstName,
 		user.LastName,
 		user.Role,
@@ -150,7 +152,7 @@ func (r *postgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error
 	`
 
 	now := time.Now()
-	_, err := r.db.ExecContext(ctx, query, now, now, id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, now, now, id)
 	return err
 }
 
@@ -159,42 +161,29 @@ func (r *postgresUserRepository) List(
 	ctx context.Context,
 	filters repository.ListFilters,
 ) ([]*entities.User, error) {
+	conditions, args := userFilterConditions(filters)
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, is_active,
 		       email_verified, created_at, updated_at, deleted_at
 		FROM users
-		WHERE deleted_at IS NULL
-	`
+		WHERE deleted_at IS NULL` + conditions
+	argCount := len(args) + 1
 
-	args := []interface{}{}
-	argCount := 1
-
-	if filters.Role != nil {
-		query += ` AND role = $` + string(rune(argCount+'0'))
-		args = append(args, *filters.Role)
-		argCount++
-	}
-
-	if filters.IsActive != nil {
-		query += ` AND is_active = $` + string(rune(argCount+'0'))
-		args = append(args, *filters.IsActive)
-		argCount++
-	}
-
-	query += ` ORDER BY created_at DESC`
+	// id como desempate para que la paginación sea estable
+	query += ` ORDER BY created_at DESC, id`
 
 	if filters.Limit > 0 {
-		query += ` LIMIT $` + string(rune(argCount+'0'))
+		query += ` LIMIT $` + strconv.Itoa(argCount)
 		args = append(args, filters.Limit)
 		argCount++
 	}
 
 	if filters.Offset > 0 {
-		query += ` OFFSET $` + string(rune(argCount+'0'))
+		query += ` OFFSET $` + strconv.Itoa(argCount)
 		args = append(args, filters.Offset)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -211,12 +200,251 @@ func (r *postgresUserRepository) ExistsByEmail(
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND deleted_at IS NULL)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, email).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, email).Scan(&exists)
 	return exists, err
 }
 
+// FindByIDs busca varios usuarios por ID en una sola consulta
+func (r *postgresUserRepository) FindByIDs(
+	ctx context.Context,
+	ids []uuid.UUID,
+) ([]*entities.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, email, password_hash, first_name, last_name, role, is_active,
+		       email_verified, created_at, updated_at, deleted_at
+		FROM users
+		WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	return r.scanRows(rows)
+}
+
+// FindByIDForUpdate busca un usuario bloqueando la fila hasta el fin de la transacción
+func (r *postgresUserRepository) FindByIDForUpdate(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.User, error) {
+	query := `
+		SELECT id, email, password_hash, first_name, last_name, role, is_active,
+		       email_verified, created_at, updated_at, deleted_at
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	users, err := r.scanRows(rows)
+	if err != nil || len(users) == 0 {
+		return nil, err
+	}
+	return users[0], nil
+}
+
+// Count cuenta los usuarios que cumplen los filtros
+func (r *postgresUserRepository) Count(
+	ctx context.Context,
+	filters repository.ListFilters,
+) (int, error) {
+	conditions, args := userFilterConditions(filters)
+	query := `SELECT COUNT(*) FROM users WHERE deleted_at IS NULL` + conditions
+
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// normalizedEmailSQL replica similarity.NormalizeEmail: minúsculas, sin sufijo +tag,
+// googlemail.com como gmail.com y sin puntos en la parte local de gmail
+const normalizedEmailSQL = `
+	CASE WHEN lower(btrim(email)) !~ '^.+@[^@]*$' THEN lower(btrim(email))
+	ELSE (
+		CASE WHEN substring(lower(btrim(email)) FROM '@([^@]*)$') IN ('gmail.com', 'googlemail.com')
+		THEN replace(regexp_replace(regexp_replace(lower(btrim(email)), '@[^@]*$', ''), '^([^+]+)\+.*$', '\1'), '.', '')
+		ELSE regexp_replace(regexp_replace(lower(btrim(email)), '@[^@]*$', ''), '^([^+]+)\+.*$', '\1')
+		END
+	) || '@' || (
+		CASE WHEN substring(lower(btrim(email)) FROM '@([^@]*)$') = 'googlemail.com' THEN 'gmail.com'
+		ELSE substring(lower(btrim(email)) FROM '@([^@]*)$')
+		END
+	)
+	END`
+
+// FindEmailDuplicates agrupa por email normalizado en la base, sin cargar los usuarios
+func (r *postgresUserRepository) FindEmailDuplicates(
+	ctx context.Context,
+	filters repository.ListFilters,
+) ([][]uuid.UUID, error) {
+	conditions, args := userFilterConditions(filters)
+	query := `
+		SELECT array_agg(id::text ORDER BY id)
+		FROM (
+			SELECT id, ` + normalizedEmailSQL + ` AS email_key
+			FROM users
+			WHERE deleted_at IS NULL` + conditions + `
+		) normalized
+		WHERE email_key <> ''
+		GROUP BY email_key
+		HAVING COUNT(*) > 1
+	`
+	return r.queryGroups(ctx, query, args...)
+}
+
+// FindPhoneDuplicates agrupa por teléfono del perfil (solo dígitos, sin ceros iniciales)
+func (r *postgresUserRepository) FindPhoneDuplicates(
+	ctx context.Context,
+	filters repository.ListFilters,
+	minDigits int,
+) ([][]uuid.UUID, error) {
+	conditions, args := userFilterConditions(filters)
+	args = append(args, minDigits)
+	query := `
+		SELECT array_agg(id::text ORDER BY id)
+		FROM (
+			SELECT users.id, ltrim(regexp_replace(user_profiles.phone, '[^0-9]', '', 'g'), '0') AS phone_key
+			FROM users
+			JOIN user_profiles ON user_profiles.user_id = users.id
+			WHERE users.deleted_at IS NULL AND user_profiles.phone IS NOT NULL` + conditions + `
+		) normalized
+		WHERE length(phone_key) >= $` + strconv.Itoa(len(args)) + `
+		GROUP BY phone_key
+		HAVING COUNT(*) > 1
+	`
+	return r.queryGroups(ctx, query, args...)
+}
+
+// nameBlockSQL replica el bloque de similarity.NormalizeName: las dos primeras letras del
+// apellido en minúsculas, sin acentos ni signos
+const nameBlockSQL = `left(btrim(regexp_replace(regexp_replace(
+		translate(lower(last_name), 'áàäâãéèëêíìïîóòöôõúùüûñç', 'aaaaaeeeeiiiiooooouuuunc'),
+		'[^[:alpha:][:space:].''-]', '', 'g'), '[[:space:].''-]+', ' ', 'g')), 2)`
+
+// ListNameBlocks lista los bloques de nombre con más de un usuario
+func (r *postgresUserRepository) ListNameBlocks(
+	ctx context.Context,
+	filters repository.ListFilters,
+) ([]string, error) {
+	conditions, args := userFilterConditions(filters)
+	query := `
+		SELECT name_block
+		FROM (
+			SELECT ` + nameBlockSQL + ` AS name_block
+			FROM users
+			WHERE deleted_at IS NULL` + conditions + `
+		) blocks
+		WHERE char_length(name_block) = 2
+		GROUP BY name_block
+		HAVING COUNT(*) > 1
+		ORDER BY name_block
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var blocks []string
+	for rows.Next() {
+		var block string
+		if err := rows.Scan(&block); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, rows.Err()
+}
+
+// ListByNameBlock lista los usuarios de un bloque de nombre
+func (r *postgresUserRepository) ListByNameBlock(
+	ctx context.Context,
+	filters repository.ListFilters,
+	block string,
+) ([]*entities.User, error) {
+	conditions, args := userFilterConditions(filters)
+	args = append(args, block)
+	query := `
+		SELECT id, email, password_hash, first_name, last_name, role, is_active,
+		       email_verified, created_at, updated_at, deleted_at
+		FROM users
+		WHERE deleted_at IS NULL` + conditions + `
+		  AND ` + nameBlockSQL + ` = $` + strconv.Itoa(len(args)) + `
+		ORDER BY id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	return r.scanRows(rows)
+}
+
 // Helper methods
 
+// userFilterConditions arma las condiciones de los filtros (sin Limit ni Offset) con argumentos desde $1
+func userFilterConditions(filters repository.ListFilters) (string, []interface{}) {
+	conditions := ""
+	args := []interface{}{}
+
+	if filters.Role != nil {
+		args = append(args, *filters.Role)
+		conditions += ` AND users.role = $` + strconv.Itoa(len(args))
+	}
+
+	if filters.IsActive != nil {
+		args = append(args, *filters.IsActive)
+		conditions += ` AND users.is_active = $` + strconv.Itoa(len(args))
+	}
+
+	if filters.SchoolID != nil {
+		args = append(args, *filters.SchoolID)
+		conditions += ` AND users.id IN (SELECT user_id FROM memberships WHERE school_id = $` + strconv.Itoa(len(args)) + ` AND is_active = true)`
+	}
+
+	return conditions, args
+}
+
+// queryGroups ejecuta una consulta que retorna un arreglo de IDs por fila
+func (r *postgresUserRepository) queryGroups(ctx context.Context, query string, args ...interface{}) ([][]uuid.UUID, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var groups [][]uuid.UUID
+	for rows.Next() {
+		var ids pq.StringArray
+		if err := rows.Scan(&ids); err != nil {
+			return nil, err
+		}
+		group := make([]uuid.UUID, len(ids))
+		for i, id := range ids {
+			if group[i], err = uuid.Parse(id); err != nil {
+				return nil, err
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
 func (r *postgresUserRepository) scanRows(rows *sql.Rows) ([]*entities.User, error) {
 	var users []*entities.User
 
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"regexp"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresUserStatusChangeRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserStatusChangeRepository crea un nuevo repository de PostgreSQL
+func NewPostgresUserStatusChangeRepository(db *sql.DB) repository.UserStatusChangeRepository {
+	return &postgresUserStatusChangeRepository{db: db}
+}
+
+func (r *postgresUserStatusChangeRepository) Create(ctx context.Context, change *repository.UserStatusChange) error {
+	membershipIDs, err := json.Marshal(change.MembershipIDs)
+	if err != nil {
+		return err
+	}
+	relationIDs, err := json.Marshal(change.GuardianRelationIDs)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO user_status_changes (id, user_id, action, reason, performed_by, membership_ids, guardian_relation_ids, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		change.ID, change.UserID, change.Action, change.Reason, change.PerformedBy,
+		membershipIDs, relationIDs, change.CreatedAt,
+	)
+	return err
+}
+
+func (r *postgresUserStatusChangeRepository) FindLatestByUser(ctx context.Context, userID uuid.UUID, action string) (*repository.UserStatusChange, error) {
+	query := `SELECT id, user_id, action, reason, performed_by, membership_ids, guardian_relation_ids, created_at
+		FROM user_status_changes WHERE user_id = $1 AND action = $2 ORDER BY created_at DESC LIMIT 1`
+	change, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, userID, action))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return change, err
+}
+
+func (r *postgresUserStatusChangeRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*repository.UserStatusChange, error) {
+	query := `SELECT id, user_id, action, reason, performed_by, membership_ids, guardian_relation_ids, created_at
+		FROM user_status_changes WHERE user_id = $1 ORDER BY created_at DESC`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var changes []*repository.UserStatusChange
+	for rows.Next() {
+		change, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+func (r *postgresUserStatusChangeRepository) scan(row rowScanner) (*repository.UserStatusChange, error) {
+	change := &repository.UserStatusChange{}
+	var membershipIDs, relationIDs []byte
+	if err := row.Scan(
+		&change.ID, &change.UserID, &change.Action, &change.Reason, &change.PerformedBy,
+		&membershipIDs, &relationIDs, &change.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(membershipIDs) > 0 {
+		if err := json.Unmarshal(membershipIDs, &change.MembershipIDs); err != nil {
+			return nil, err
+		}
+	}
+	if len(relationIDs) > 0 {
+		if err := json.Unmarshal(relationIDs, &change.GuardianRelationIDs); err != nil {
+			return nil, err
+		}
+	}
+	return change, nil
+}
+
+func (r *postgresUserStatusChangeRepository) ReassignUser(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE user_status_changes SET user_id = $1 WHERE user_id = $2`, toUserID, fromUserID)
+	if err != nil {
+		return 0, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err = conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE user_status_changes SET performed_by = $1 WHERE performed_by = $2`, toUserID.String(), fromUserID.String())
+	if err != nil {
+		return 0, err
+	}
+	performed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return moved + performed, nil
+}
+
+func (r *postgresUserStatusChangeRepository) RedactReason(ctx context.Context, text, replacement string) (int64, error) {
+	pattern := regexp.QuoteMeta(text)
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE user_status_changes SET reason = regexp_replace(reason, $1, $2, 'gi') WHERE reason ~* $1`,
+		pattern, replacement)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-administracion/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type postgresUserTokenRevocationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserTokenRevocationRepository crea un nuevo repository de PostgreSQL
+func NewPostgresUserTokenRevocationRepository(db *sql.DB) repository.UserTokenRevocationRepository {
+	return &postgresUserTokenRevocationRepository{db: db}
+}
+
+func (r *postgresUserTokenRevocationRepository) Upsert(ctx context.Context, userID uuid.UUID, revokedAt, expiresAt time.Time) error {
+	query := `INSERT INTO user_token_revocations (user_id, revoked_at, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			revoked_at = GREATEST(user_token_revocations.revoked_at, EXCLUDED.revoked_at),
+			expires_at = GREATEST(user_token_revocations.expires_at, EXCLUDED.expires_at)`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, userID, revokedAt, expiresAt)
+	return err
+}
+
+func (r *postgresUserTokenRevocationRepository) FindRevokedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	query := `SELECT revoked_at FROM user_token_revocations WHERE user_id = $1 AND expires_at > NOW()`
+	var revokedAt time.Time
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&revokedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &revokedAt, nil
+}
@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignedTokenClaims son los datos contenidos en un token firmado
+type SignedTokenClaims struct {
+	Subject   string
+	Nonce     string
+	ExpiresAt time.Time
+}
+
+// SignedTokenSigner genera y verifica tokens opacos firmados con HMAC-SHA256.
+// Se usan para links de un solo uso (ej: invitaciones) donde un JWT sería excesivo.
+type SignedTokenSigner struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// NewSignedTokenSigner crea un nuevo signer con el secret indicado
+func NewSignedTokenSigner(secret string) *SignedTokenSigner {
+	return &SignedTokenSigner{secret: []byte(secret), now: time.Now}
+}
+
+// Sign genera un token para el subject con un nonce aleatorio.
+// El nonce permite invalidar tokens anteriores guardando solo el último.
+func (s *SignedTokenSigner) Sign(subject string, expiresAt time.Time) (string, SignedTokenClaims, error) {
+	nonceBytes := make([]byte, 16)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return "", SignedTokenClaims{}, err
+	}
+
+	claims := SignedTokenClaims{
+		Subject:   subject,
+		Nonce:     hex.EncodeToString(nonceBytes),
+		ExpiresAt: expiresAt,
+	}
+
+	payload := claims.Subject + "|" + strconv.FormatInt(expiresAt.Unix(), 10) + "|" + claims.Nonce
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + s.signature(encoded), claims, nil
+}
+
+// Verify valida la firma y la expiración del token y retorna sus claims
+func (s *SignedTokenSigner) Verify(token string) (*SignedTokenClaims, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found || encoded == "" || signature == "" {
+		return nil, ErrMalformedToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(encoded))) {
+		return nil, ErrInvalidSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	parts := strings.Split(string(payload), "|")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	expUnix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	claims := &SignedTokenClaims{
+		Subject:   parts[0],
+		Nonce:     parts[2],
+		ExpiresAt: time.Unix(expUnix, 0),
+	}
+	if !s.now().Before(claims.ExpiresAt) {
+		return claims, ErrTokenExpired
+	}
+
+	return claims, nil
+}
+
+// HashNonce retorna el hash con el que se persiste un nonce (nunca se guarda en claro)
+func HashNonce(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *SignedTokenSigner) signature(encoded string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
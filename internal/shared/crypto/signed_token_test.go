@@ -0,0 +1,53 @@
+package crypto
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignedTokenSigner_RoundTrip(t *testing.T) {
+	signer := NewSignedTokenSigner("test-secret-key-minimum-32-characters-long")
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	token, claims, err := signer.Sign("invitation-1", expiresAt)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	got, err := signer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got.Subject != "invitation-1" || got.Nonce != claims.Nonce || !got.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("Verify() claims = %+v, want %+v", got, claims)
+	}
+}
+
+func TestSignedTokenSigner_Rejects(t *testing.T) {
+	signer := NewSignedTokenSigner("test-secret-key-minimum-32-characters-long")
+	other := NewSignedTokenSigner("another-secret-key-minimum-32-characters")
+
+	valid, _, _ := signer.Sign("invitation-1", time.Now().Add(time.Hour))
+	expired, _, _ := signer.Sign("invitation-1", time.Now().Add(-time.Minute))
+	forged, _, _ := other.Sign("invitation-1", time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"expirado", expired, ErrTokenExpired},
+		{"otra firma", forged, ErrInvalidSignature},
+		{"sin firma", "abc", ErrMalformedToken},
+		{"payload alterado", "x" + valid, ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.Verify(tt.token); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- RFC 6238 usa HMAC-SHA1, compatible con las apps autenticadoras
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+	// totpSkew es la cantidad de periodos aceptados antes y después del actual (desfase de reloj)
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret genera un secret aleatorio de 160 bits codificado en base32
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TOTPCode calcula el código TOTP (RFC 6238) del secret para el instante indicado
+func TOTPCode(secret string, at time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+	return hotp(key, uint64(totpStep(at))), nil
+}
+
+// totpStep calcula el periodo TOTP del instante
+func totpStep(at time.Time) int64 {
+	return at.Unix() / int64(totpPeriod.Seconds())
+}
+
+// VerifyTOTP verifica el código aceptando un periodo de desfase en cada dirección
+func VerifyTOTP(secret, code string, at time.Time) bool {
+	_, ok := MatchTOTP(secret, code, at)
+	return ok
+}
+
+// MatchTOTP verifica el código como VerifyTOTP y retorna el periodo (contador RFC 6238) al que
+// corresponde, para que quien lo acepte pueda rechazar un segundo uso del mismo periodo
+func MatchTOTP(secret, code string, at time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	for offset := -totpSkew; offset <= totpSkew; offset++ {
+		when := at.Add(time.Duration(offset) * totpPeriod)
+		expected, err := TOTPCode(secret, when)
+		if err != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return totpStep(when), true
+		}
+	}
+	return 0, false
+}
+
+// TOTPProvisioningURI genera la URI otpauth:// para registrar el secret en una app autenticadora
+func TOTPProvisioningURI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// hotp calcula el código HOTP (RFC 4226) para el contador
+func hotp(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Secret del anexo B de RFC 6238 ("12345678901234567890" en base32)
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFCVectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := TOTPCode(rfcTOTPSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("TOTPCode(%d) error = %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	now := time.Now()
+
+	current, _ := TOTPCode(secret, now)
+	previous, _ := TOTPCode(secret, now.Add(-30*time.Second))
+	stale, _ := TOTPCode(secret, now.Add(-2*time.Minute))
+
+	if !VerifyTOTP(secret, current, now) {
+		t.Error("VerifyTOTP() rejected the current code")
+	}
+	if !VerifyTOTP(secret, previous, now) {
+		t.Error("VerifyTOTP() rejected the previous period within skew")
+	}
+	if stale != current && VerifyTOTP(secret, stale, now) {
+		t.Error("VerifyTOTP() accepted a code outside the skew window")
+	}
+	if VerifyTOTP(secret, "12345", now) || VerifyTOTP("not base32!", current, now) {
+		t.Error("VerifyTOTP() accepted malformed input")
+	}
+}
+
+func TestMatchTOTP_ReturnsPeriod(t *testing.T) {
+	at := time.Unix(59, 0)
+	previous, _ := TOTPCode(rfcTOTPSecret, at.Add(-30*time.Second))
+
+	step, ok := MatchTOTP(rfcTOTPSecret, previous, at)
+	if !ok {
+		t.Fatal("MatchTOTP() rejected the previous period within skew")
+	}
+	if step != 0 {
+		t.Errorf("MatchTOTP() step = %d, want 0", step)
+	}
+	if _, ok := MatchTOTP(rfcTOTPSecret, "12345", at); ok {
+		t.Error("MatchTOTP() accepted malformed input")
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("EduGo", "admin@edugo.com", rfcTOTPSecret)
+
+	if !strings.HasPrefix(uri, "otpauth://totp/EduGo:admin@edugo.com?") {
+		t.Errorf("unexpected URI prefix: %s", uri)
+	}
+	if !strings.Contains(uri, "secret="+rfcTOTPSecret) || !strings.Contains(uri, "issuer=EduGo") {
+		t.Errorf("URI missing parameters: %s", uri)
+	}
+}
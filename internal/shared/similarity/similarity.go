@@ -0,0 +1,139 @@
+// Package similarity proporciona normalización y comparación aproximada de datos personales
+package similarity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// domainsIgnoringDots son proveedores donde los puntos del local-part no son significativos
+var domainsIgnoringDots = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+}
+
+// NormalizeEmail normaliza un email para detectar duplicados:
+// minúsculas, sin etiqueta "+tag" y sin puntos en proveedores que los ignoran
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	if domain == "googlemail.com" {
+		domain = "gmail.com"
+	}
+	if domainsIgnoringDots[domain] {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+
+	return local + "@" + domain
+}
+
+// accentReplacer elimina los diacríticos más comunes en nombres en español y portugués
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "ä", "a", "â", "a", "ã", "a",
+	"é", "e", "è", "e", "ë", "e", "ê", "e",
+	"í", "i", "ì", "i", "ï", "i", "î", "i",
+	"ó", "o", "ò", "o", "ö", "o", "ô", "o", "õ", "o",
+	"ú", "u", "ù", "u", "ü", "u", "û", "u",
+	"ñ", "n", "ç", "c",
+)
+
+// NormalizeName normaliza un nombre: minúsculas, sin acentos, sin signos y con espacios simples
+func NormalizeName(name string) string {
+	name = accentReplacer.Replace(strings.ToLower(name))
+
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r):
+			sb.WriteRune(r)
+		case unicode.IsSpace(r), r == '-', r == '\'', r == '.':
+			sb.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// NormalizePhone deja solo los dígitos del teléfono y descarta los ceros iniciales
+// de marcación, de forma que "+56 9 1234 5678" y "0056912345678" coincidan
+func NormalizePhone(phone string) string {
+	var sb strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+		}
+	}
+	return strings.TrimLeft(sb.String(), "0")
+}
+
+// JaroWinkler retorna la similitud Jaro-Winkler entre dos strings (0 = distintos, 1 = iguales)
+func JaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	matchDistance := max(len(ra), len(rb))/2 - 1
+	if matchDistance < 0 {
+		matchDistance = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+
+	for i := range ra {
+		start := max(0, i-matchDistance)
+		end := min(len(rb), i+matchDistance+1)
+		for j := start; j < end; j++ {
+			if matchedB[j] || ra[i] != rb[j] {
+				continue
+			}
+			matchedA[i], matchedB[j] = true, true
+			matches++
+			break
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	k := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[k] {
+			k++
+		}
+		if ra[i] != rb[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	// Bonificación por prefijo común (hasta 4 caracteres)
+	prefix := 0
+	for i := 0; i < min(4, len(ra), len(rb)) && ra[i] == rb[i]; i++ {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,44 @@
+package similarity
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := map[string]string{
+		"  Juan.Perez+colegio@GMail.com ": "juanperez@gmail.com",
+		"juan.perez@googlemail.com":       "juanperez@gmail.com",
+		"ana.diaz+x@edugo.cl":             "ana.diaz@edugo.cl",
+		"invalid":                         "invalid",
+	}
+	for input, expected := range tests {
+		if got := NormalizeEmail(input); got != expected {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	if got := NormalizeName("  José-María  NÚÑEZ "); got != "jose maria nunez" {
+		t.Errorf("unexpected %q", got)
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	if NormalizePhone("+56 9 1234-5678") != NormalizePhone("0056912345678") {
+		t.Error("phones should match")
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	if got := JaroWinkler("martha", "marhta"); got < 0.96 || got > 0.962 {
+		t.Errorf("JaroWinkler(martha, marhta) = %f", got)
+	}
+	if JaroWinkler("juan perez", "juan perez") != 1 {
+		t.Error("identical strings should score 1")
+	}
+	if JaroWinkler("abc", "") != 0 {
+		t.Error("empty string should score 0")
+	}
+	if JaroWinkler("juan perez", "maria gonzalez") > 0.7 {
+		t.Error("different names should score low")
+	}
+}
@@ -0,0 +1,300 @@
+// Package spreadsheet proporciona lectura y escritura básica de archivos tabulares (CSV y XLSX)
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Format representa el formato de un archivo tabular
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// Límites de lectura
+const (
+	// MaxFileSize es el tamaño máximo del archivo recibido (10 MB)
+	MaxFileSize = 10 << 20
+	// MaxColumns es la cantidad de columnas de una hoja de Excel (A..XFD)
+	MaxColumns = 16384
+	// maxPartSize limita cada parte XML descomprimida del xlsx (evita zip bombs)
+	maxPartSize = 64 << 20
+)
+
+// Errores de lectura
+var (
+	ErrUnsupportedFormat = errors.New("formato de archivo no soportado")
+	ErrEmptyFile         = errors.New("el archivo no contiene filas")
+	ErrNoWorksheet       = errors.New("el archivo xlsx no contiene hojas")
+	ErrFileTooLarge      = errors.New("el archivo supera el tamaño máximo")
+	ErrTooManyRows       = errors.New("el archivo supera la cantidad máxima de filas")
+	ErrInvalidCellRef    = errors.New("referencia de celda inválida")
+)
+
+// DetectFormat determina el formato a partir del nombre de archivo
+func DetectFormat(filename string) (Format, error) {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ReadRows lee las filas del archivo (encabezado incluido). Para XLSX solo se lee la primera hoja.
+// Con maxRows > 0 deja de leer y retorna ErrTooManyRows al superar esa cantidad de filas.
+func ReadRows(format Format, r io.Reader, maxRows int) ([][]string, error) {
+	var (
+		rows [][]string
+		err  error
+	)
+
+	switch format {
+	case FormatCSV:
+		rows, err = readCSV(r, maxRows)
+	case FormatXLSX:
+		rows, err = readXLSX(r, maxRows)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+	return rows, nil
+}
+
+// limitedReader corta la lectura al superar MaxFileSize
+type limitedReader struct {
+	r io.Reader // io.LimitReader con un byte extra para detectar el exceso
+	n int64
+}
+
+func newLimitedReader(r io.Reader) *limitedReader {
+	return &limitedReader{r: io.LimitReader(r, MaxFileSize+1)}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > MaxFileSize {
+		return n, ErrFileTooLarge
+	}
+	return n, err
+}
+
+func readCSV(r io.Reader, maxRows int) ([][]string, error) {
+	reader := csv.NewReader(newLimitedReader(r))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if errors.Is(err, ErrFileTooLarge) {
+				return nil, ErrFileTooLarge
+			}
+			return nil, fmt.Errorf("leer csv: %w", err)
+		}
+		if maxRows > 0 && len(rows) >= maxRows {
+			return nil, ErrTooManyRows
+		}
+		rows = append(rows, record)
+	}
+
+	// Quitar BOM de UTF-8 que agregan algunas planillas al exportar
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	return rows, nil
+}
+
+// Estructuras mínimas de SpreadsheetML necesarias para leer celdas
+type xlsxSharedStrings struct {
+	Items []struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"si"`
+}
+
+type xlsxRow struct {
+	Cells []struct {
+		Ref       string `xml:"r,attr"`
+		Type      string `xml:"t,attr"`
+		Value     string `xml:"v"`
+		InlineStr struct {
+			Text string `xml:"t"`
+		} `xml:"is"`
+	} `xml:"c"`
+}
+
+func readXLSX(r io.Reader, maxRows int) ([][]string, error) {
+	// zip necesita acceso aleatorio: se carga el archivo, acotado a MaxFileSize
+	data, err := io.ReadAll(newLimitedReader(r))
+	if err != nil {
+		if errors.Is(err, ErrFileTooLarge) {
+			return nil, ErrFileTooLarge
+		}
+		return nil, fmt.Errorf("leer xlsx: %w", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("abrir xlsx: %w", err)
+	}
+
+	var shared xlsxSharedStrings
+	if err := decodeZipXML(archive, "xl/sharedStrings.xml", &shared); err != nil && !errors.Is(err, errZipEntryNotFound) {
+		return nil, err
+	}
+
+	strs := make([]string, len(shared.Items))
+	for i, item := range shared.Items {
+		if item.Text != "" || len(item.Runs) == 0 {
+			strs[i] = item.Text
+			continue
+		}
+		var sb strings.Builder
+		for _, run := range item.Runs {
+			sb.WriteString(run.Text)
+		}
+		strs[i] = sb.String()
+	}
+
+	sheet, err := openZipEntry(archive, "xl/worksheets/sheet1.xml")
+	if err != nil {
+		if errors.Is(err, errZipEntryNotFound) {
+			return nil, ErrNoWorksheet
+		}
+		return nil, err
+	}
+	defer func() { _ = sheet.Close() }()
+
+	// La hoja se recorre fila por fila para cortar apenas se supera maxRows
+	var rows [][]string
+	decoder := xml.NewDecoder(io.LimitReader(sheet, maxPartSize))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decodificar hoja: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		if maxRows > 0 && len(rows) >= maxRows {
+			return nil, ErrTooManyRows
+		}
+
+		var row xlsxRow
+		if err := decoder.DecodeElement(&row, &start); err != nil {
+			return nil, fmt.Errorf("decodificar hoja: %w", err)
+		}
+		values := []string{}
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				if col, err = columnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			if col >= MaxColumns {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidCellRef, cell.Ref)
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err == nil && idx >= 0 && idx < len(strs) {
+					values[col] = strs[idx]
+				}
+			case "inlineStr":
+				values[col] = cell.InlineStr.Text
+			default:
+				values[col] = cell.Value
+			}
+		}
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+var errZipEntryNotFound = errors.New("zip entry not found")
+
+func openZipEntry(archive *zip.Reader, name string) (io.ReadCloser, error) {
+	for _, f := range archive.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("abrir %s: %w", name, err)
+		}
+		return rc, nil
+	}
+	return nil, errZipEntryNotFound
+}
+
+func decodeZipXML(archive *zip.Reader, name string, v any) error {
+	rc, err := openZipEntry(archive, name)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, maxPartSize)).Decode(v); err != nil {
+		return fmt.Errorf("decodificar %s: %w", name, err)
+	}
+	return nil
+}
+
+// columnIndex convierte una referencia de celda (ej: "C7") en índice de columna base 0.
+// La referencia debe tener letras de columna (hasta XFD) seguidas del número de fila.
+func columnIndex(ref string) (int, error) {
+	idx, letters := 0, 0
+	for letters < len(ref) && ref[letters] >= 'A' && ref[letters] <= 'Z' {
+		idx = idx*26 + int(ref[letters]-'A'+1)
+		letters++
+		if idx > MaxColumns {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidCellRef, ref)
+		}
+	}
+	if letters == 0 || letters == len(ref) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCellRef, ref)
+	}
+	for _, ch := range ref[letters:] {
+		if ch < '0' || ch > '9' {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidCellRef, ref)
+		}
+	}
+	return idx - 1, nil
+}
@@ -0,0 +1,128 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriterReaderRoundTrip(t *testing.T) {
+	header := []string{"email", "name"}
+	row := []string{"a<b>@example.com", `Doe, "Jr"`}
+
+	for _, format := range []Format{FormatCSV, FormatXLSX} {
+		var buf bytes.Buffer
+		w, err := NewWriter(format, &buf)
+		if err != nil {
+			t.Fatalf("%s: NewWriter: %v", format, err)
+		}
+		if err := w.WriteHeader(header); err != nil {
+			t.Fatalf("%s: WriteHeader: %v", format, err)
+		}
+		if err := w.WriteRow(row); err != nil {
+			t.Fatalf("%s: WriteRow: %v", format, err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: Close: %v", format, err)
+		}
+
+		rows, err := ReadRows(format, &buf, 0)
+		if err != nil {
+			t.Fatalf("%s: ReadRows: %v", format, err)
+		}
+		if len(rows) != 2 || strings.Join(rows[0], "|") != strings.Join(header, "|") || strings.Join(rows[1], "|") != strings.Join(row, "|") {
+			t.Errorf("%s: unexpected rows %q", format, rows)
+		}
+	}
+}
+
+func TestNDJSONWriterKeepsColumnOrder(t *testing.T) {
+	var buf bytes.Buffer
+	w, _ := NewWriter(FormatNDJSON, &buf)
+
+	if err := w.WriteRow([]string{"x"}); err != ErrHeaderNotWritten {
+		t.Fatalf("expected ErrHeaderNotWritten, got %v", err)
+	}
+
+	_ = w.WriteHeader([]string{"b", "a"})
+	_ = w.WriteRow([]string{"1", "2"})
+	_ = w.Close()
+
+	if got := buf.String(); got != "{\"b\":\"1\",\"a\":\"2\"}\n" {
+		t.Errorf("unexpected output %q", got)
+	}
+}
+
+func TestReadRows_CSVWithBOM(t *testing.T) {
+	rows, err := ReadRows(FormatCSV, strings.NewReader("\ufeffemail\nx@example.com\n"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows[0][0] != "email" {
+		t.Errorf("BOM not removed: %q", rows[0][0])
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	if f, _ := DetectFormat("Users.XLSX"); f != FormatXLSX {
+		t.Errorf("expected xlsx, got %s", f)
+	}
+	if _, err := DetectFormat("users.txt"); err != ErrUnsupportedFormat {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
+
+// buildXLSX arma un xlsx mínimo con la hoja indicada
+func buildXLSX(t *testing.T, sheetData string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	f, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.Write([]byte(`<worksheet><sheetData>` + sheetData + `</sheetData></worksheet>`))
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestReadRows_XLSXRejectsMalformedCellRefs(t *testing.T) {
+	for _, ref := range []string{"7", "a1", "ZZZZZZ1", "XFE1", "B"} {
+		sheet := buildXLSX(t, `<row><c r="`+ref+`" t="inlineStr"><is><t>x</t></is></c></row>`)
+		if _, err := ReadRows(FormatXLSX, sheet, 0); !errors.Is(err, ErrInvalidCellRef) {
+			t.Errorf("ref %q: expected ErrInvalidCellRef, got %v", ref, err)
+		}
+	}
+
+	rows, err := ReadRows(FormatXLSX, buildXLSX(t, `<row><c r="C1" t="inlineStr"><is><t>x</t></is></c></row>`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows[0]) != 3 || rows[0][2] != "x" {
+		t.Errorf("unexpected rows %q", rows)
+	}
+}
+
+func TestReadRows_StopsAtMaxRows(t *testing.T) {
+	sheet := buildXLSX(t, strings.Repeat(`<row><c r="A1"><v>1</v></c></row>`, 4))
+	if _, err := ReadRows(FormatXLSX, sheet, 3); !errors.Is(err, ErrTooManyRows) {
+		t.Errorf("xlsx: expected ErrTooManyRows, got %v", err)
+	}
+	if _, err := ReadRows(FormatCSV, strings.NewReader("a\nb\nc\nd\n"), 3); !errors.Is(err, ErrTooManyRows) {
+		t.Errorf("csv: expected ErrTooManyRows, got %v", err)
+	}
+	if _, err := ReadRows(FormatCSV, strings.NewReader("a\nb\nc\n"), 3); err != nil {
+		t.Errorf("csv: unexpected error %v", err)
+	}
+}
+
+func TestReadRows_RejectsOversizedFile(t *testing.T) {
+	oversized := strings.NewReader(strings.Repeat("a,b\n", MaxFileSize/4+1))
+	if _, err := ReadRows(FormatCSV, oversized, 0); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("expected ErrFileTooLarge, got %v", err)
+	}
+}
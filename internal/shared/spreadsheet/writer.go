@@ -0,0 +1,238 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// FormatNDJSON representa JSON delimitado por saltos de línea (un objeto por fila)
+const FormatNDJSON Format = "ndjson"
+
+// ErrHeaderNotWritten se retorna al escribir filas antes del encabezado
+var ErrHeaderNotWritten = errors.New("el encabezado debe escribirse antes de las filas")
+
+// ParseFormat convierte un string a Format de salida
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	case FormatNDJSON:
+		return FormatNDJSON, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType retorna el MIME type del formato
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Writer escribe filas de forma incremental (streaming) sin mantenerlas en memoria
+type Writer interface {
+	// WriteHeader escribe los nombres de columna; debe llamarse una sola vez y primero
+	WriteHeader(columns []string) error
+	// WriteRow escribe una fila con el mismo orden que el encabezado
+	WriteRow(values []string) error
+	// Close vacía buffers y finaliza el archivo
+	Close() error
+}
+
+// NewWriter crea un Writer para el formato indicado
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{w: bufio.NewWriter(w)}, nil
+	case FormatXLSX:
+		return &xlsxWriter{zw: zip.NewWriter(w)}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// ==================== CSV ====================
+
+type csvWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func (c *csvWriter) WriteHeader(columns []string) error {
+	return c.w.Write(columns)
+}
+
+func (c *csvWriter) WriteRow(values []string) error {
+	if err := c.w.Write(values); err != nil {
+		return err
+	}
+	// Vaciar el buffer periódicamente para que la respuesta fluya
+	c.rows++
+	if c.rows%100 == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ==================== NDJSON ====================
+
+type ndjsonWriter struct {
+	w    *bufio.Writer
+	keys [][]byte
+}
+
+func (n *ndjsonWriter) WriteHeader(columns []string) error {
+	n.keys = make([][]byte, len(columns))
+	for i, col := range columns {
+		key, err := json.Marshal(col)
+		if err != nil {
+			return err
+		}
+		n.keys[i] = key
+	}
+	return nil
+}
+
+func (n *ndjsonWriter) WriteRow(values []string) error {
+	if n.keys == nil {
+		return ErrHeaderNotWritten
+	}
+
+	// Se arma el objeto manualmente para respetar el orden de columnas
+	_ = n.w.WriteByte('{')
+	for i, key := range n.keys {
+		if i > 0 {
+			_ = n.w.WriteByte(',')
+		}
+		_, _ = n.w.Write(key)
+		_ = n.w.WriteByte(':')
+
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, _ = n.w.Write(encoded)
+	}
+	_, err := n.w.WriteString("}\n")
+	return err
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.w.Flush()
+}
+
+// ==================== XLSX ====================
+
+// xlsxWriter genera un libro con una sola hoja usando inline strings,
+// escribiendo la hoja directamente en el zip a medida que llegan las filas
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	row   int
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+func (x *xlsxWriter) WriteHeader(columns []string) error {
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		f, err := x.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := x.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return err
+	}
+	x.sheet = sheet
+
+	return x.WriteRow(columns)
+}
+
+func (x *xlsxWriter) WriteRow(values []string) error {
+	if x.sheet == nil {
+		return ErrHeaderNotWritten
+	}
+
+	x.row++
+	var sb strings.Builder
+	sb.WriteString("<row>")
+	for _, value := range values {
+		sb.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(&sb, []byte(value)); err != nil {
+			return err
+		}
+		sb.WriteString("</t></is></c>")
+	}
+	sb.WriteString("</row>")
+
+	_, err := io.WriteString(x.sheet, sb.String())
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if x.sheet != nil {
+		if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+			return err
+		}
+	}
+	return x.zw.Close()
+}
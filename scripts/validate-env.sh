@@ -85,6 +85,11 @@ validate_min_length "AUTH_JWT_SECRET" 32
 validate_required "AUTH_JWT_ISSUER"
 validate_value "AUTH_JWT_ISSUER" "edugo-central"
 
+echo ""
+echo "--- Variables de Invitaciones ---"
+validate_required "AUTH_INVITATIONS_SIGNING_SECRET"
+validate_min_length "AUTH_INVITATIONS_SIGNING_SECRET" 32
+
 echo ""
 echo "--- Variables de Rate Limiting ---"
 validate_required "AUTH_RATE_LIMIT_LOGIN_ATTEMPTS"
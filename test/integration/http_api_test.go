@@ -353,6 +353,38 @@ func TestSchoolAPI_ListAll(t *testing.T) {
 	}
 }
 
+// TestSchoolAPI_SearchPaginated verifica la búsqueda paginada de escuelas
+func TestSchoolAPI_SearchPaginated(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for i := 1; i <= 3; i++ {
+		doRequest(t, server, "POST", "/v1/schools", dto.CreateSchoolRequest{
+			Name: "Test School " + string(rune('A'+i-1)),
+			Code: "SRCH00" + string(rune('0'+i)),
+		})
+	}
+
+	resp, body := doRequest(t, server, "GET", "/v1/schools?paginate=true&q=SRCH00&sort_by=code&order=asc", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list dto.SchoolListResponse
+	err := json.Unmarshal(body, &list)
+	require.NoError(t, err, "should unmarshal schools list")
+	assert.GreaterOrEqual(t, list.Total, 3)
+
+	expectedCodes := map[string]bool{"SRCH001": false, "SRCH002": false, "SRCH003": false}
+	for _, school := range list.Items {
+		if _, exists := expectedCodes[school.Code]; exists {
+			expectedCodes[school.Code] = true
+		}
+	}
+
+	for code, found := range expectedCodes {
+		assert.True(t, found, "should find school with code %s", code)
+	}
+}
+
 // TestSchoolAPI_UpdateAndDelete verifica actualizacion y eliminacion
 func TestSchoolAPI_UpdateAndDelete(t *testing.T) {
 	server, cleanup := setupTestServer(t)